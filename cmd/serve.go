@@ -26,6 +26,7 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
 	serveadmin "github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/serve"
 	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
 )
@@ -90,6 +91,39 @@ func (s *ServerService) GetSchedulerJobRegistrars(
 			DistAccountResolver: serveOpts.SubmitterEngine.DistributionAccountResolver,
 			CircleService:       serveOpts.CircleService,
 		}),
+		scheduler.WithClaimableBalanceStatusJobOption(jobs.ClaimableBalanceStatusJobOptions{
+			Models:              models,
+			HorizonClient:       serveOpts.SubmitterEngine.HorizonClient,
+			DistAccountResolver: serveOpts.SubmitterEngine.DistributionAccountResolver,
+		}),
+		scheduler.WithScheduledDisbursementsJobOption(jobs.ScheduledDisbursementsJobOptions{
+			Models:                     models,
+			DistAccountResolver:        serveOpts.SubmitterEngine.DistributionAccountResolver,
+			DistributionAccountService: serveOpts.DistributionAccountService,
+			EventProducer:              serveOpts.EventProducer,
+			CrashTrackerClient:         serveOpts.CrashTrackerClient.Clone(),
+			AuthManager:                auth.NewAuthManager(auth.WithDefaultAuthenticatorOption(serveOpts.MtnDBConnectionPool, auth.NewDefaultPasswordEncrypter(), 0)),
+		}),
+		scheduler.WithDisbursementTemplatesJobOption(jobs.DisbursementTemplatesJobOptions{
+			Models: models,
+		}),
+		scheduler.WithWebhookDeliveriesJobOption(jobs.WebhookDeliveriesJobOptions{
+			Models:               models,
+			EncryptionPassphrase: serveOpts.DistAccEncryptionPassphrase,
+		}),
+		scheduler.WithDisbursementInstructionUploadsJobOption(jobs.DisbursementInstructionUploadsJobOptions{
+			Models: models,
+		}),
+		scheduler.WithUndeliveredInvitationsFallbackJobOption(jobs.UndeliveredInvitationsFallbackJobOptions{
+			Models:                      models,
+			MessageDispatcher:           serveOpts.MessageDispatcher,
+			MaxInvitationResendAttempts: int64(serveOpts.MaxInvitationResendAttempts),
+			Sep10SigningPrivateKey:      serveOpts.Sep10SigningPrivateKey,
+			CrashTrackerClient:          serveOpts.CrashTrackerClient.Clone(),
+		}),
+		scheduler.WithIdempotencyKeysCleanupJobOption(jobs.IdempotencyKeysCleanupJobOptions{
+			Models: models,
+		}),
 	}
 
 	if serveOpts.EnableScheduler {
@@ -448,6 +482,19 @@ func (c *ServeCommand) Command(serverService ServerServiceInterface, monitorServ
 			Required:       true,
 		})
 
+	// whatsapp
+	whatsAppOpts := di.WhatsAppClientOptions{MessengerOptions: &messengerOptions}
+	configOpts = append(configOpts,
+		&config.ConfigOption{
+			// message sender type
+			Name:           "whatsapp-sender-type",
+			Usage:          fmt.Sprintf("WhatsApp Sender Type. Leave it empty to disable the WhatsApp channel. Options: %+v", message.MessengerType("").ValidWhatsAppTypes()),
+			OptType:        types.String,
+			CustomSetValue: cmdUtils.SetConfigOptionOptionalMessengerType,
+			ConfigKey:      &whatsAppOpts.WhatsAppType,
+			Required:       false,
+		})
+
 	// email
 	emailOpts := di.EmailClientOptions{MessengerOptions: &messengerOptions}
 	configOpts = append(configOpts,
@@ -569,6 +616,7 @@ func (c *ServeCommand) Command(serverService ServerServiceInterface, monitorServ
 			defer func() {
 				di.CleanupInstanceByValue(ctx, tssDBConnectionPool)
 			}()
+			serveOpts.TSSDBConnectionPool = tssDBConnectionPool
 
 			// Setup the Crash Tracker client
 			crashTrackerClient, err := di.NewCrashTracker(ctx, crashTrackerOptions)
@@ -588,13 +636,20 @@ func (c *ServeCommand) Command(serverService ServerServiceInterface, monitorServ
 
 			// Setup the Message Dispatcher
 			messageDispatcherOpts := di.MessageDispatcherOpts{
-				EmailOpts: &emailOpts,
-				SMSOpts:   &smsOpts,
+				EmailOpts:            &emailOpts,
+				SMSOpts:              &smsOpts,
+				TenantClientConfigs:  message.NewClientConfigModel(mtnDBConnectionPool),
+				EncryptionPassphrase: serveOpts.DistAccEncryptionPassphrase,
+			}
+			if whatsAppOpts.WhatsAppType != "" {
+				messageDispatcherOpts.WhatsAppOpts = &whatsAppOpts
 			}
 			serveOpts.MessageDispatcher, err = di.NewMessageDispatcher(ctx, messageDispatcherOpts)
 			if err != nil {
 				log.Ctx(ctx).Fatalf("error creating message dispatcher: %s", err.Error())
 			}
+			serveOpts.TwilioAuthToken = messengerOptions.TwilioAuthToken
+			serveOpts.AWSSESNotificationTopicARN = messengerOptions.AWSSESNotificationTopicARN
 
 			// Setup the AP Auth enforcer
 			apAPIService, err := di.NewAnchorPlatformAPIService(serveOpts.AnchorPlatformBasePlatformURL, serveOpts.AnchorPlatformOutgoingJWTSecret)
@@ -655,6 +710,13 @@ func (c *ServeCommand) Command(serverService ServerServiceInterface, monitorServ
 			serveOpts.DistributionAccountService = distributionAccountService
 			adminServeOpts.DistributionAccountService = distributionAccountService
 
+			// Setup the Message Delivery Status Service
+			mtnModels, err := data.NewModels(mtnDBConnectionPool)
+			if err != nil {
+				log.Ctx(ctx).Fatalf("error creating models for the message delivery status service: %v", err)
+			}
+			serveOpts.MessageDeliveryStatusService = &services.MessageDeliveryStatusService{Models: mtnModels}
+
 			// Validate the Event Broker Type and Scheduler Jobs
 			if eventBrokerOptions.EventBrokerType == events.NoneEventBrokerType && !serveOpts.EnableScheduler {
 				log.Ctx(ctx).Fatalf("Both Event Brokers and Scheduler are disabled. Please enable one.")
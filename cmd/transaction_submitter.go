@@ -83,6 +83,14 @@ func (c *TxSubmitterCommand) Command(submitterService TxSubmitterServiceInterfac
 			FlagDefault: 6,
 			Required:    true,
 		},
+		{
+			Name:        "max-payments-per-transaction",
+			Usage:       "Maximum number of payments bundled in a single Stellar transaction. Values lower or equal to 1 disable batching",
+			OptType:     types.Int,
+			ConfigKey:   &tssOpts.MaxPaymentsPerTransaction,
+			FlagDefault: 1,
+			Required:    false,
+		},
 	}
 
 	// metrics server options
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
@@ -18,6 +19,7 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/events"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/monitor"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
 )
 
@@ -45,6 +47,35 @@ func SetConfigOptionMessengerType(co *config.ConfigOption) error {
 	return nil
 }
 
+// SetConfigOptionOptionalMessengerType parses the messenger type like SetConfigOptionMessengerType, but leaves it empty
+// when the option isn't set.
+func SetConfigOptionOptionalMessengerType(co *config.ConfigOption) error {
+	if strings.TrimSpace(viper.GetString(co.Name)) == "" {
+		*(co.ConfigKey.(*message.MessengerType)) = ""
+		return nil
+	}
+
+	return SetConfigOptionMessengerType(co)
+}
+
+// SetConfigOptionTwilioWhatsAppContentSID returns a setter that adds the content SID of the given template type to the
+// map of Twilio WhatsApp content SIDs, when the option is set.
+func SetConfigOptionTwilioWhatsAppContentSID(templateType message.MessageTemplateType) func(co *config.ConfigOption) error {
+	return func(co *config.ConfigOption) error {
+		contentSID := strings.TrimSpace(viper.GetString(co.Name))
+		if contentSID == "" {
+			return nil
+		}
+
+		contentSIDs := co.ConfigKey.(*map[message.MessageTemplateType]string)
+		if *contentSIDs == nil {
+			*contentSIDs = make(map[message.MessageTemplateType]string)
+		}
+		(*contentSIDs)[templateType] = contentSID
+		return nil
+	}
+}
+
 func SetConfigOptionMetricType(co *config.ConfigOption) error {
 	metricType := viper.GetString(co.Name)
 
@@ -293,3 +324,34 @@ func SetRegistrationContactType(co *config.ConfigOption) error {
 	*(co.ConfigKey.(*data.RegistrationContactType)) = regAccountType
 	return nil
 }
+
+// SetConfigOptionRemoteSigners parses a JSON array of remote signers, in the format
+// `[{"url": "https://signer.example.com/sign", "public_key": "G...", "auth_token": "..."}]`.
+func SetConfigOptionRemoteSigners(co *config.ConfigOption) error {
+	signersStr := strings.TrimSpace(viper.GetString(co.Name))
+
+	key, ok := co.ConfigKey.(*[]signing.RemoteSigner)
+	if !ok {
+		return fmt.Errorf("the expected type for the config key in %s is a remote signer slice, but a %T was provided instead", co.Name, co.ConfigKey)
+	}
+
+	if signersStr == "" {
+		*key = nil
+		return nil
+	}
+
+	var signers []signing.RemoteSigner
+	if err := json.Unmarshal([]byte(signersStr), &signers); err != nil {
+		return fmt.Errorf("parsing remote signers in %s: %w", co.Name, err)
+	}
+
+	for i, signer := range signers {
+		if err := signer.Validate(); err != nil {
+			return fmt.Errorf("validating remote signer %d in %s: %w", i, co.Name, err)
+		}
+	}
+
+	*key = signers
+
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/events"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/monitor"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
 )
 
@@ -136,6 +137,85 @@ func Test_SetConfigOptionMessengerType(t *testing.T) {
 	}
 }
 
+func Test_SetConfigOptionOptionalMessengerType(t *testing.T) {
+	opts := struct{ messengerType message.MessengerType }{}
+
+	co := config.ConfigOption{
+		Name:           "whatsapp-sender-type",
+		OptType:        types.String,
+		CustomSetValue: SetConfigOptionOptionalMessengerType,
+		ConfigKey:      &opts.messengerType,
+	}
+
+	testCases := []customSetterTestCase[message.MessengerType]{
+		{
+			name:       "🎉 leaves the messenger type empty when it's not set",
+			args:       []string{},
+			wantResult: "",
+		},
+		{
+			name:            "returns an error if the messenger type is invalid",
+			args:            []string{"--whatsapp-sender-type", "test"},
+			wantErrContains: `couldn't parse messenger type in whatsapp-sender-type: invalid message sender type "TEST"`,
+		},
+		{
+			name:       "🎉 handles messenger type TWILIO_WHATSAPP (through CLI args)",
+			args:       []string{"--whatsapp-sender-type", "twilio_whatsapp"},
+			wantResult: message.MessengerTypeTwilioWhatsApp,
+		},
+		{
+			name:       "🎉 handles messenger type TWILIO_WHATSAPP (through ENV vars)",
+			envValue:   "TWILIO_WHATSAPP",
+			wantResult: message.MessengerTypeTwilioWhatsApp,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts.messengerType = ""
+			customSetterTester[message.MessengerType](t, tc, co)
+		})
+	}
+}
+
+func Test_SetConfigOptionTwilioWhatsAppContentSID(t *testing.T) {
+	opts := struct {
+		contentSIDs map[message.MessageTemplateType]string
+	}{}
+
+	co := config.ConfigOption{
+		Name:           "twilio-whatsapp-otp-content-sid",
+		OptType:        types.String,
+		CustomSetValue: SetConfigOptionTwilioWhatsAppContentSID(message.MessageTemplateTypeReceiverOTP),
+		ConfigKey:      &opts.contentSIDs,
+	}
+
+	testCases := []customSetterTestCase[map[message.MessageTemplateType]string]{
+		{
+			name:       "🎉 leaves the content SIDs empty when it's not set",
+			args:       []string{},
+			wantResult: nil,
+		},
+		{
+			name:       "🎉 sets the content SID of the template type (through CLI args)",
+			args:       []string{"--twilio-whatsapp-otp-content-sid", "HX123"},
+			wantResult: map[message.MessageTemplateType]string{message.MessageTemplateTypeReceiverOTP: "HX123"},
+		},
+		{
+			name:       "🎉 sets the content SID of the template type (through ENV vars)",
+			envValue:   "HX123",
+			wantResult: map[message.MessageTemplateType]string{message.MessageTemplateTypeReceiverOTP: "HX123"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts.contentSIDs = nil
+			customSetterTester[map[message.MessageTemplateType]string](t, tc, co)
+		})
+	}
+}
+
 func Test_SetConfigOptionLogLevel(t *testing.T) {
 	opts := struct{ logrusLevel logrus.Level }{}
 
@@ -763,3 +843,54 @@ func Test_SetConfigOptionCircleAPIType(t *testing.T) {
 		})
 	}
 }
+
+func Test_SetConfigOptionRemoteSigners(t *testing.T) {
+	opts := struct{ remoteSigners []signing.RemoteSigner }{}
+
+	co := config.ConfigOption{
+		Name:           "distribution-remote-signers",
+		OptType:        types.String,
+		CustomSetValue: SetConfigOptionRemoteSigners,
+		ConfigKey:      &opts.remoteSigners,
+		Required:       false,
+	}
+
+	const publicKey = "GAX46JJZ3NPUM2EUBTTGFM6ITDF7IGAFNBSVWDONPYZJREHFPP2I5U7S"
+	testCases := []customSetterTestCase[[]signing.RemoteSigner]{
+		{
+			name:            "returns an error if the value is not a valid JSON",
+			args:            []string{"--distribution-remote-signers", "invalid"},
+			wantErrContains: "parsing remote signers in distribution-remote-signers",
+		},
+		{
+			name:            "returns an error if a signer is invalid",
+			args:            []string{"--distribution-remote-signers", `[{"url": "https://signer.example.com/sign", "public_key": "invalid"}]`},
+			wantErrContains: `validating remote signer 0 in distribution-remote-signers: public key "invalid" is not a valid Ed25519 public key`,
+		},
+		{
+			name: "🎉 handles empty values",
+			args: []string{"--distribution-remote-signers", ""},
+		},
+		{
+			name: "🎉 handles remote signers (through CLI args)",
+			args: []string{"--distribution-remote-signers", `[{"url": "https://signer.example.com/sign", "public_key": "` + publicKey + `", "auth_token": "token"}]`},
+			wantResult: []signing.RemoteSigner{
+				{URL: "https://signer.example.com/sign", PublicKey: publicKey, AuthToken: "token"},
+			},
+		},
+		{
+			name:     "🎉 handles remote signers (through ENV vars)",
+			envValue: `[{"url": "https://signer.example.com/sign", "public_key": "` + publicKey + `"}]`,
+			wantResult: []signing.RemoteSigner{
+				{URL: "https://signer.example.com/sign", PublicKey: publicKey},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts.remoteSigners = nil
+			customSetterTester[[]signing.RemoteSigner](t, tc, co)
+		})
+	}
+}
@@ -58,6 +58,30 @@ func TwilioConfigOptions(opts *message.MessengerOptions) []*config.ConfigOption
 			ConfigKey: &opts.TwilioSendGridSenderAddress,
 			Required:  false,
 		},
+		// Twilio WhatsApp
+		{
+			Name:      "twilio-whatsapp-sender-number",
+			Usage:     "The WhatsApp-enabled phone number, in E.164 format, that Twilio will use to send WhatsApp messages",
+			OptType:   types.String,
+			ConfigKey: &opts.TwilioWhatsAppSenderNumber,
+			Required:  false,
+		},
+		{
+			Name:           "twilio-whatsapp-invitation-content-sid",
+			Usage:          "The SID of the Twilio Content template approved by WhatsApp for the receiver invitations. Its variables are {{1}} the organization name and {{2}} the registration link",
+			OptType:        types.String,
+			CustomSetValue: SetConfigOptionTwilioWhatsAppContentSID(message.MessageTemplateTypeReceiverInvitation),
+			ConfigKey:      &opts.TwilioWhatsAppContentSIDs,
+			Required:       false,
+		},
+		{
+			Name:           "twilio-whatsapp-otp-content-sid",
+			Usage:          "The SID of the Twilio Content template approved by WhatsApp for the receiver OTPs. Its variables are {{1}} the OTP and {{2}} the organization name",
+			OptType:        types.String,
+			CustomSetValue: SetConfigOptionTwilioWhatsAppContentSID(message.MessageTemplateTypeReceiverOTP),
+			ConfigKey:      &opts.TwilioWhatsAppContentSIDs,
+			Required:       false,
+		},
 	}
 }
 
@@ -102,6 +126,20 @@ func AWSConfigOptions(opts *message.MessengerOptions) []*config.ConfigOption {
 			ConfigKey: &opts.AWSSESSenderID,
 			Required:  false,
 		},
+		{
+			Name:      "aws-ses-configuration-set-name",
+			Usage:     "The AWS SES configuration set the emails are sent with. Its event destination must publish the delivery events to the SNS topic subscribed to the message status callback.",
+			OptType:   types.String,
+			ConfigKey: &opts.AWSSESConfigurationSetName,
+			Required:  false,
+		},
+		{
+			Name:      "aws-ses-notification-topic-arn",
+			Usage:     "The ARN of the SNS topic the AWS SES delivery events are published to. The SNS messages of other topics sent to the message status callback are rejected.",
+			OptType:   types.String,
+			ConfigKey: &opts.AWSSESNotificationTopicARN,
+			Required:  false,
+		},
 	}
 }
 
@@ -266,6 +304,29 @@ func BaseDistributionAccountSignatureClientConfigOptions(opts *signing.Signature
 			ConfigKey:      &opts.DistributionPrivateKey,
 			Required:       true,
 		},
+		{
+			Name:      "distribution-remote-signer-account",
+			Usage:     "The public key of the Stellar distribution account whose keys are held by remote signers. Used by tenants with the distribution account type DISTRIBUTION_ACCOUNT.STELLAR.REMOTE.",
+			OptType:   types.String,
+			ConfigKey: &opts.RemoteSignerOptions.DistributionAccount,
+			Required:  false,
+		},
+		{
+			Name:           "distribution-remote-signers",
+			Usage:          `A JSON array with the remote signers of the distribution account, in the order they should be called. Example: [{"url": "https://signer.example.com/sign", "public_key": "G...", "auth_token": "..."}]`,
+			OptType:        types.String,
+			CustomSetValue: SetConfigOptionRemoteSigners,
+			ConfigKey:      &opts.RemoteSignerOptions.Signers,
+			Required:       false,
+		},
+		{
+			Name:        "distribution-remote-signer-threshold",
+			Usage:       "The number of remote signatures needed to authorize a distribution account transaction.",
+			OptType:     types.Int,
+			ConfigKey:   &opts.RemoteSignerOptions.Threshold,
+			FlagDefault: 1,
+			Required:    false,
+		},
 	}
 }
 
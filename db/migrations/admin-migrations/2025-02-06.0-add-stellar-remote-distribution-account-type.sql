@@ -0,0 +1,27 @@
+-- This migration adds the distribution account type of the Stellar accounts whose keys are held by remote signers.
+-- +migrate Up
+ALTER TYPE distribution_account_type ADD VALUE 'DISTRIBUTION_ACCOUNT.STELLAR.REMOTE';
+
+-- +migrate Down
+-- Remove default value, and change type to text
+ALTER TABLE tenants
+    ALTER COLUMN distribution_account_type DROP DEFAULT,
+    ALTER COLUMN distribution_account_type TYPE text;
+
+-- Drop enum
+DROP TYPE distribution_account_type;
+
+-- The remote signers use the distribution account configured for the whole instance, like the ENV type.
+UPDATE tenants SET distribution_account_type = 'DISTRIBUTION_ACCOUNT.STELLAR.ENV' WHERE distribution_account_type = 'DISTRIBUTION_ACCOUNT.STELLAR.REMOTE';
+
+-- Create the enum without the remote type
+CREATE TYPE distribution_account_type AS ENUM (
+    'DISTRIBUTION_ACCOUNT.STELLAR.ENV',
+    'DISTRIBUTION_ACCOUNT.STELLAR.DB_VAULT',
+    'DISTRIBUTION_ACCOUNT.CIRCLE.DB_VAULT'
+);
+
+-- Update column to the enum type, and set default value
+ALTER TABLE tenants
+    ALTER COLUMN distribution_account_type TYPE distribution_account_type USING distribution_account_type::text::distribution_account_type,
+    ALTER COLUMN distribution_account_type SET DEFAULT 'DISTRIBUTION_ACCOUNT.STELLAR.DB_VAULT';
@@ -0,0 +1,30 @@
+-- Add the claimable balance payout mode to disbursements, and the claimable balance tracking to payments.
+
+-- +migrate Up
+CREATE TYPE disbursement_payout_mode AS ENUM ('PAYMENT', 'CLAIMABLE_BALANCE');
+
+ALTER TABLE disbursements
+    ADD COLUMN payout_mode disbursement_payout_mode NOT NULL DEFAULT 'PAYMENT';
+
+CREATE TYPE claimable_balance_status AS ENUM ('UNCLAIMED', 'CLAIMED', 'RECLAIMED');
+
+ALTER TABLE payments
+    ADD COLUMN claimable_balance_id VARCHAR(72) NULL,
+    ADD COLUMN claimable_balance_status claimable_balance_status NULL;
+
+CREATE INDEX idx_payments_unclaimed_claimable_balances ON payments (updated_at) WHERE claimable_balance_status = 'UNCLAIMED';
+
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_payments_unclaimed_claimable_balances;
+
+ALTER TABLE payments
+    DROP COLUMN claimable_balance_status,
+    DROP COLUMN claimable_balance_id;
+
+DROP TYPE claimable_balance_status;
+
+ALTER TABLE disbursements
+    DROP COLUMN payout_mode;
+
+DROP TYPE disbursement_payout_mode;
@@ -0,0 +1,23 @@
+-- +migrate Up
+
+ALTER TABLE disbursements
+    ADD COLUMN receive_asset_code VARCHAR(12) NULL,
+    ADD COLUMN receive_asset_issuer VARCHAR(56) NULL,
+    ADD COLUMN max_slippage_bps INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT max_slippage_bps_check CHECK (max_slippage_bps >= 0 AND max_slippage_bps <= 10000);
+
+ALTER TABLE payments
+    ADD COLUMN path_payment_path TEXT[] NULL,
+    ADD COLUMN source_amount NUMERIC(19,7) NULL;
+
+-- +migrate Down
+
+ALTER TABLE payments
+    DROP COLUMN path_payment_path,
+    DROP COLUMN source_amount;
+
+ALTER TABLE disbursements
+    DROP CONSTRAINT max_slippage_bps_check,
+    DROP COLUMN receive_asset_code,
+    DROP COLUMN receive_asset_issuer,
+    DROP COLUMN max_slippage_bps;
@@ -0,0 +1,19 @@
+-- Add the SCHEDULED status and the scheduled start time to disbursements.
+
+-- +migrate Up
+ALTER TYPE disbursement_status ADD VALUE IF NOT EXISTS 'SCHEDULED' AFTER 'READY';
+
+ALTER TABLE disbursements
+    ADD COLUMN scheduled_start_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX idx_disbursements_scheduled_start_at ON disbursements (scheduled_start_at) WHERE scheduled_start_at IS NOT NULL;
+
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_disbursements_scheduled_start_at;
+
+-- Postgres can't remove values from an enum, so the SCHEDULED value is kept and the disbursements go back to READY.
+UPDATE disbursements SET status = 'READY' WHERE status::text = 'SCHEDULED';
+
+ALTER TABLE disbursements
+    DROP COLUMN scheduled_start_at;
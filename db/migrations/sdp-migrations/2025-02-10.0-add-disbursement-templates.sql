@@ -0,0 +1,43 @@
+-- Add recurring disbursement templates, which are cloned into new disbursements following a cron schedule.
+
+-- +migrate Up
+CREATE TYPE disbursement_template_status AS ENUM (
+    'ACTIVE',
+    'PAUSED'
+);
+
+CREATE TABLE disbursement_templates (
+    id VARCHAR(36) PRIMARY KEY DEFAULT public.uuid_generate_v4(),
+    name VARCHAR(128) NOT NULL,
+    status disbursement_template_status NOT NULL DEFAULT 'ACTIVE',
+    wallet_id VARCHAR(36) NOT NULL REFERENCES wallets (id),
+    asset_id VARCHAR(36) NOT NULL REFERENCES assets (id),
+    verification_field verification_type NULL,
+    verification_question TEXT NULL,
+    registration_contact_type registration_contact_types NOT NULL,
+    receiver_registration_message_template TEXT NULL,
+    schedule VARCHAR(64) NOT NULL,
+    disbursement_status disbursement_status NOT NULL DEFAULT 'DRAFT',
+    instructions JSONB NULL,
+    file_name TEXT NULL,
+    file_content BYTEA NULL,
+    next_run_at TIMESTAMP WITH TIME ZONE NULL,
+    last_run_at TIMESTAMP WITH TIME ZONE NULL,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT disbursement_templates_name_unique UNIQUE (name),
+    CONSTRAINT disbursement_templates_disbursement_status_check CHECK (disbursement_status IN ('DRAFT', 'READY'))
+);
+
+CREATE INDEX idx_disbursement_templates_next_run_at ON disbursement_templates (next_run_at) WHERE status = 'ACTIVE';
+
+CREATE TRIGGER refresh_disbursement_template_updated_at BEFORE UPDATE ON disbursement_templates FOR EACH ROW EXECUTE PROCEDURE update_at_refresh();
+
+
+-- +migrate Down
+DROP TRIGGER refresh_disbursement_template_updated_at ON disbursement_templates;
+
+DROP TABLE disbursement_templates;
+
+DROP TYPE disbursement_template_status;
@@ -0,0 +1,31 @@
+-- Add the disbursement approvals and the organization quorum rules used to decide how many approvals are required.
+
+-- +migrate Up
+CREATE TYPE disbursement_approval_decision AS ENUM (
+    'APPROVED',
+    'REJECTED'
+);
+
+CREATE TABLE disbursement_approvals (
+    id VARCHAR(36) PRIMARY KEY DEFAULT public.uuid_generate_v4(),
+    disbursement_id VARCHAR(36) NOT NULL REFERENCES disbursements (id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL,
+    decision disbursement_approval_decision NOT NULL,
+    comment TEXT NULL,
+    invalidated_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_disbursement_approvals_disbursement_id ON disbursement_approvals (disbursement_id);
+CREATE UNIQUE INDEX disbursement_approvals_active_user_unique ON disbursement_approvals (disbursement_id, user_id) WHERE invalidated_at IS NULL;
+
+ALTER TABLE organizations
+    ADD COLUMN approval_quorum_rules JSONB NOT NULL DEFAULT '[]';
+
+-- +migrate Down
+ALTER TABLE organizations
+    DROP COLUMN approval_quorum_rules;
+
+DROP TABLE disbursement_approvals;
+
+DROP TYPE disbursement_approval_decision;
@@ -0,0 +1,137 @@
+-- Add outbound webhooks. Status changes of payments, disbursements and receiver wallets are enqueued as deliveries for
+-- the subscriptions listening to them, and the deliveries are sent by the `webhook_deliveries_job`. The subscription
+-- secrets are stored encrypted, the same way as the Circle API key.
+
+-- +migrate Up
+CREATE TABLE webhook_subscriptions (
+    id VARCHAR(36) PRIMARY KEY DEFAULT public.uuid_generate_v4(),
+    url VARCHAR(2048) NOT NULL,
+    encrypted_secret TEXT NOT NULL,
+    encrypter_public_key VARCHAR(256) NOT NULL,
+    event_types TEXT[] NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER refresh_webhook_subscriptions_updated_at BEFORE UPDATE ON webhook_subscriptions FOR EACH ROW EXECUTE PROCEDURE update_at_refresh();
+
+CREATE TYPE webhook_delivery_status AS ENUM (
+    'PENDING',
+    'SUCCESS',
+    'FAILED'
+);
+
+CREATE TABLE webhook_deliveries (
+    id VARCHAR(36) PRIMARY KEY DEFAULT public.uuid_generate_v4(),
+    subscription_id VARCHAR(36) NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    status webhook_delivery_status NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_attempt_at TIMESTAMPTZ NULL,
+    last_response_status INTEGER NULL,
+    last_error TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries (next_attempt_at) WHERE status = 'PENDING';
+
+CREATE TRIGGER refresh_webhook_deliveries_updated_at BEFORE UPDATE ON webhook_deliveries FOR EACH ROW EXECUTE PROCEDURE update_at_refresh();
+
+-- `enqueue_webhook_deliveries` creates a pending delivery of the event for each enabled subscription listening to it.
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION enqueue_webhook_deliveries(p_event_type TEXT, p_data JSONB)
+RETURNS void AS $$
+	BEGIN
+	    INSERT INTO
+	        webhook_deliveries (subscription_id, event_type, payload)
+	    SELECT
+	        ws.id,
+	        p_event_type,
+	        jsonb_build_object('event_type', p_event_type, 'created_at', NOW(), 'data', p_data)
+	    FROM
+	        webhook_subscriptions ws
+	    WHERE
+	        ws.enabled AND p_event_type = ANY(ws.event_types);
+	END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION payments_webhook_fn()
+RETURNS TRIGGER AS $$
+	BEGIN
+	    PERFORM enqueue_webhook_deliveries('payment.status_changed', jsonb_build_object(
+	        'id', NEW.id,
+	        'disbursement_id', NEW.disbursement_id,
+	        'receiver_wallet_id', NEW.receiver_wallet_id,
+	        'asset_id', NEW.asset_id,
+	        'amount', NEW.amount::text,
+	        'external_payment_id', NEW.external_payment_id,
+	        'stellar_transaction_id', NEW.stellar_transaction_id,
+	        'previous_status', OLD.status,
+	        'status', NEW.status
+	    ));
+	    RETURN NULL;
+	END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER payments_webhook_trigger AFTER UPDATE OF status ON payments FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status) EXECUTE PROCEDURE payments_webhook_fn();
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION disbursements_webhook_fn()
+RETURNS TRIGGER AS $$
+	BEGIN
+	    PERFORM enqueue_webhook_deliveries('disbursement.status_changed', jsonb_build_object(
+	        'id', NEW.id,
+	        'name', NEW.name,
+	        'previous_status', OLD.status,
+	        'status', NEW.status
+	    ));
+	    RETURN NULL;
+	END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER disbursements_webhook_trigger AFTER UPDATE OF status ON disbursements FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status) EXECUTE PROCEDURE disbursements_webhook_fn();
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION receiver_wallets_webhook_fn()
+RETURNS TRIGGER AS $$
+	BEGIN
+	    PERFORM enqueue_webhook_deliveries('receiver_wallet.status_changed', jsonb_build_object(
+	        'id', NEW.id,
+	        'receiver_id', NEW.receiver_id,
+	        'wallet_id', NEW.wallet_id,
+	        'stellar_address', NEW.stellar_address,
+	        'previous_status', OLD.status,
+	        'status', NEW.status
+	    ));
+	    RETURN NULL;
+	END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER receiver_wallets_webhook_trigger AFTER UPDATE OF status ON receiver_wallets FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status) EXECUTE PROCEDURE receiver_wallets_webhook_fn();
+
+-- +migrate Down
+DROP TRIGGER receiver_wallets_webhook_trigger ON receiver_wallets;
+DROP FUNCTION receiver_wallets_webhook_fn;
+DROP TRIGGER disbursements_webhook_trigger ON disbursements;
+DROP FUNCTION disbursements_webhook_fn;
+DROP TRIGGER payments_webhook_trigger ON payments;
+DROP FUNCTION payments_webhook_fn;
+DROP FUNCTION enqueue_webhook_deliveries;
+
+DROP TABLE webhook_deliveries;
+DROP TYPE webhook_delivery_status;
+DROP TABLE webhook_subscriptions;
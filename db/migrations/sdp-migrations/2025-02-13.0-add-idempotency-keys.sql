@@ -0,0 +1,22 @@
+-- Add the idempotency keys, used to replay the response of mutating requests retried with the same `Idempotency-Key`.
+
+-- +migrate Up
+CREATE TABLE idempotency_keys (
+    id VARCHAR(36) PRIMARY KEY DEFAULT public.uuid_generate_v4(),
+    idempotency_key VARCHAR(255) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    request_method VARCHAR(16) NOT NULL,
+    request_path VARCHAR(2048) NOT NULL,
+    request_fingerprint VARCHAR(64) NOT NULL,
+    response_status INTEGER NULL,
+    response_content_type VARCHAR(255) NULL,
+    response_body BYTEA NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ NULL
+);
+
+CREATE UNIQUE INDEX idempotency_keys_user_key_unique ON idempotency_keys (user_id, idempotency_key);
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys (created_at);
+
+-- +migrate Down
+DROP TABLE idempotency_keys;
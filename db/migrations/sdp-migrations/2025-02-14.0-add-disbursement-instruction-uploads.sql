@@ -0,0 +1,41 @@
+-- Add asynchronous instruction uploads. The uploaded file is stored and its instructions are processed in chunks by the
+-- `disbursement_instruction_uploads_job`, which records the progress, the errors of each row and a final summary.
+
+-- +migrate Up
+CREATE TYPE disbursement_instruction_upload_status AS ENUM (
+    'PENDING',
+    'PROCESSING',
+    'COMPLETED',
+    'FAILED'
+);
+
+CREATE TABLE disbursement_instruction_uploads (
+    id VARCHAR(36) PRIMARY KEY DEFAULT public.uuid_generate_v4(),
+    disbursement_id VARCHAR(36) NOT NULL REFERENCES disbursements (id) ON DELETE CASCADE,
+    status disbursement_instruction_upload_status NOT NULL DEFAULT 'PENDING',
+    file_name VARCHAR(255) NOT NULL,
+    file_content BYTEA NOT NULL,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    errors JSONB NULL,
+    summary JSONB NULL,
+    created_by VARCHAR(36) NOT NULL,
+    started_at TIMESTAMPTZ NULL,
+    completed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_disbursement_instruction_uploads_disbursement_id ON disbursement_instruction_uploads (disbursement_id, created_at DESC);
+-- A disbursement can only have one upload being processed at a time.
+CREATE UNIQUE INDEX idx_disbursement_instruction_uploads_active ON disbursement_instruction_uploads (disbursement_id) WHERE status IN ('PENDING', 'PROCESSING');
+
+CREATE TRIGGER refresh_disbursement_instruction_uploads_updated_at BEFORE UPDATE ON disbursement_instruction_uploads FOR EACH ROW EXECUTE PROCEDURE update_at_refresh();
+
+-- +migrate Down
+DROP TRIGGER refresh_disbursement_instruction_uploads_updated_at ON disbursement_instruction_uploads;
+
+DROP TABLE disbursement_instruction_uploads;
+
+DROP TYPE disbursement_instruction_upload_status;
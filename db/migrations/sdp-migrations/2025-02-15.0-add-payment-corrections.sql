@@ -0,0 +1,31 @@
+-- Add the payment corrections, used to change the amount or the receiver wallet of failed payments before retrying them.
+
+-- +migrate Up
+CREATE TYPE payment_correction_status AS ENUM (
+    'PENDING_APPROVAL',
+    'APPLIED',
+    'REJECTED'
+);
+
+CREATE TABLE payment_corrections (
+    id VARCHAR(36) PRIMARY KEY DEFAULT public.uuid_generate_v4(),
+    payment_id VARCHAR(36) NOT NULL REFERENCES payments (id) ON DELETE CASCADE,
+    status payment_correction_status NOT NULL,
+    previous_amount NUMERIC(19, 7) NOT NULL,
+    amount NUMERIC(19, 7) NOT NULL,
+    previous_receiver_wallet_id VARCHAR(36) NOT NULL REFERENCES receiver_wallets (id),
+    receiver_wallet_id VARCHAR(36) NOT NULL REFERENCES receiver_wallets (id),
+    comment TEXT NULL,
+    requested_by VARCHAR(36) NOT NULL,
+    reviewed_by VARCHAR(36) NULL,
+    reviewed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_payment_corrections_payment_id ON payment_corrections (payment_id);
+CREATE UNIQUE INDEX payment_corrections_pending_unique ON payment_corrections (payment_id) WHERE status = 'PENDING_APPROVAL';
+
+-- +migrate Down
+DROP TABLE payment_corrections;
+
+DROP TYPE payment_correction_status;
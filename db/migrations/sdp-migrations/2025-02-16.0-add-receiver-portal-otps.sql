@@ -0,0 +1,13 @@
+-- Add the one-time passwords used by receivers to access the receiver portal, where they can see their payments. The
+-- OTPs are stored hashed, like the receiver verification values.
+
+-- +migrate Up
+CREATE TABLE receiver_portal_otps (
+    receiver_id VARCHAR(36) PRIMARY KEY REFERENCES receivers (id) ON DELETE CASCADE,
+    hashed_otp TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +migrate Down
+DROP TABLE receiver_portal_otps;
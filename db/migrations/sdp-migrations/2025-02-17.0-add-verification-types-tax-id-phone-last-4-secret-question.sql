@@ -0,0 +1,40 @@
+-- This migration adds the 'TAX_ID', 'PHONE_LAST_4' and 'CUSTOM_SECRET_QUESTION' verification types, along with the
+-- question asked to the receivers of the disbursements verified with a custom secret question, and the question each
+-- receiver verification answers, so the registration page can show it.
+-- +migrate Up
+ALTER TYPE verification_type ADD VALUE 'TAX_ID';
+ALTER TYPE verification_type ADD VALUE 'PHONE_LAST_4';
+ALTER TYPE verification_type ADD VALUE 'CUSTOM_SECRET_QUESTION';
+
+ALTER TABLE disbursements
+    ADD COLUMN verification_question TEXT NULL;
+
+ALTER TABLE receiver_verifications
+    ADD COLUMN question TEXT NULL;
+
+-- +migrate Down
+ALTER TABLE receiver_verifications
+    DROP COLUMN question;
+
+ALTER TABLE disbursements
+    DROP COLUMN verification_question;
+
+-- Remove the verifications with the types being dropped, since they can't be mapped to the remaining types
+DELETE FROM receiver_verifications WHERE verification_field IN ('TAX_ID', 'PHONE_LAST_4', 'CUSTOM_SECRET_QUESTION');
+UPDATE disbursements SET verification_field = NULL WHERE verification_field IN ('TAX_ID', 'PHONE_LAST_4', 'CUSTOM_SECRET_QUESTION');
+UPDATE disbursement_templates SET verification_field = NULL WHERE verification_field IN ('TAX_ID', 'PHONE_LAST_4', 'CUSTOM_SECRET_QUESTION');
+
+-- Create new type
+CREATE TYPE verification_type_new AS ENUM ('DATE_OF_BIRTH', 'YEAR_MONTH', 'PIN', 'NATIONAL_ID_NUMBER');
+
+-- Change the columns to the new type (receiver_verifications, disbursements & disbursement_templates)
+ALTER TABLE receiver_verifications ALTER COLUMN verification_field TYPE verification_type_new USING verification_field::text::verification_type_new;
+ALTER TABLE disbursements ALTER COLUMN verification_field DROP DEFAULT;
+ALTER TABLE disbursements ALTER COLUMN verification_field TYPE verification_type_new USING verification_field::text::verification_type_new;
+ALTER TABLE disbursement_templates ALTER COLUMN verification_field TYPE verification_type_new USING verification_field::text::verification_type_new;
+
+-- Drop old type
+DROP TYPE verification_type;
+
+-- Rename new type
+ALTER TYPE verification_type_new RENAME TO verification_type;
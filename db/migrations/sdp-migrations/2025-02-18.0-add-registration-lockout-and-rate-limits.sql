@@ -0,0 +1,31 @@
+-- Add the organization settings that lock out receivers failing their verification too many times and rate limit the
+-- wallet registration endpoints, and the table holding the rate limit counters. The limit per IP is opt-in, since the IP
+-- address is the remote address of the connection, so all the receivers are counted as one behind a load balancer.
+
+-- +migrate Up
+ALTER TABLE organizations
+    ADD COLUMN verification_max_attempts INTEGER NOT NULL DEFAULT 15 CHECK (verification_max_attempts > 0),
+    ADD COLUMN verification_lockout_minutes INTEGER NOT NULL DEFAULT 60 CHECK (verification_lockout_minutes >= 0),
+    ADD COLUMN registration_rate_limit_window_minutes INTEGER NOT NULL DEFAULT 60 CHECK (registration_rate_limit_window_minutes BETWEEN 1 AND 1440),
+    ADD COLUMN registration_rate_limit_per_contact INTEGER NOT NULL DEFAULT 10 CHECK (registration_rate_limit_per_contact >= 0),
+    ADD COLUMN registration_rate_limit_per_ip INTEGER NOT NULL DEFAULT 0 CHECK (registration_rate_limit_per_ip >= 0);
+
+CREATE TABLE registration_rate_limits (
+    endpoint VARCHAR(32) NOT NULL,
+    key VARCHAR(128) NOT NULL,
+    hits INTEGER NOT NULL DEFAULT 1,
+    window_started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (endpoint, key)
+);
+
+CREATE INDEX idx_registration_rate_limits_window_started_at ON registration_rate_limits (window_started_at);
+
+-- +migrate Down
+DROP TABLE registration_rate_limits;
+
+ALTER TABLE organizations
+    DROP COLUMN verification_max_attempts,
+    DROP COLUMN verification_lockout_minutes,
+    DROP COLUMN registration_rate_limit_window_minutes,
+    DROP COLUMN registration_rate_limit_per_contact,
+    DROP COLUMN registration_rate_limit_per_ip;
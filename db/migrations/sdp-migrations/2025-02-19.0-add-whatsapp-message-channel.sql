@@ -0,0 +1,48 @@
+-- This migration adds the `WHATSAPP` message channel and the `TWILIO_WHATSAPP` message type.
+-- +migrate Up
+ALTER TYPE message_channel ADD VALUE 'WHATSAPP';
+ALTER TYPE message_type ADD VALUE 'TWILIO_WHATSAPP';
+
+-- +migrate Down
+-- Remove the `TWILIO_WHATSAPP` message type
+DELETE FROM messages WHERE type = 'TWILIO_WHATSAPP';
+
+CREATE TYPE temp_message_type AS ENUM (
+    'TWILIO_SMS',
+    'TWILIO_EMAIL',
+    'AWS_SMS',
+    'AWS_EMAIL',
+    'DRY_RUN'
+    );
+
+ALTER TABLE messages
+    ALTER COLUMN type TYPE temp_message_type USING type::text::temp_message_type;
+
+DROP TYPE message_type;
+
+ALTER TYPE temp_message_type RENAME TO message_type;
+
+-- Remove the `WHATSAPP` message channel
+UPDATE receiver_verifications SET verification_channel = 'SMS' WHERE verification_channel = 'WHATSAPP';
+
+CREATE TYPE temp_message_channel AS ENUM ('SMS', 'EMAIL');
+
+-- The type of a column used in a trigger definition can't be changed, so the trigger is recreated afterwards
+DROP TRIGGER validate_organizations_message_channel_priority ON organizations;
+ALTER TABLE organizations ALTER COLUMN message_channel_priority DROP DEFAULT;
+ALTER TABLE organizations
+    ALTER COLUMN message_channel_priority TYPE temp_message_channel[]
+        USING array_remove(message_channel_priority, 'WHATSAPP')::text[]::temp_message_channel[];
+ALTER TABLE receiver_verifications
+    ALTER COLUMN verification_channel TYPE temp_message_channel USING verification_channel::text::temp_message_channel;
+
+DROP TYPE message_channel;
+
+ALTER TYPE temp_message_channel RENAME TO message_channel;
+
+ALTER TABLE organizations
+    ALTER COLUMN message_channel_priority SET DEFAULT ARRAY['SMS'::message_channel, 'EMAIL'::message_channel];
+
+CREATE TRIGGER validate_organizations_message_channel_priority
+    BEFORE INSERT OR UPDATE OF message_channel_priority ON organizations
+    FOR EACH ROW EXECUTE FUNCTION check_message_channel_priority();
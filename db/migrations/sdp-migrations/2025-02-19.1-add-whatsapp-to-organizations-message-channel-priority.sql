@@ -0,0 +1,18 @@
+-- This migration adds the `WHATSAPP` message channel to the message channel priority of the organizations, with the
+-- lowest priority. It can't be done in the same migration that adds the enum value, since Postgres doesn't allow using
+-- a new enum value in the same transaction that added it.
+-- +migrate Up
+ALTER TABLE organizations
+    ALTER COLUMN message_channel_priority
+        SET DEFAULT ARRAY['SMS'::message_channel, 'EMAIL'::message_channel, 'WHATSAPP'::message_channel];
+
+UPDATE organizations
+    SET message_channel_priority = array_append(message_channel_priority, 'WHATSAPP'::message_channel)
+    WHERE NOT ('WHATSAPP'::message_channel = ANY(message_channel_priority));
+
+-- +migrate Down
+-- The `WHATSAPP` channel is removed from the organizations priority when the channel is dropped, in the previous
+-- migration, since the priority must include all the message channels until then.
+ALTER TABLE organizations
+    ALTER COLUMN message_channel_priority
+        SET DEFAULT ARRAY['SMS'::message_channel, 'EMAIL'::message_channel];
@@ -0,0 +1,19 @@
+-- This migration adds the messenger_client_configs table, with the messaging provider credentials configured by the
+-- tenant for each channel. The channels without a config use the messenger clients configured for the whole instance.
+-- +migrate Up
+CREATE TABLE messenger_client_configs (
+    channel message_channel PRIMARY KEY,
+    messenger_type message_type NOT NULL,
+    encrypted_credentials TEXT NOT NULL,
+    encrypter_public_key VARCHAR(256) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- TRIGGER: updated_at
+CREATE TRIGGER refresh_messenger_client_configs_updated_at BEFORE UPDATE ON messenger_client_configs FOR EACH ROW EXECUTE PROCEDURE update_at_refresh();
+
+-- +migrate Down
+DROP TRIGGER refresh_messenger_client_configs_updated_at ON messenger_client_configs;
+
+DROP TABLE messenger_client_configs;
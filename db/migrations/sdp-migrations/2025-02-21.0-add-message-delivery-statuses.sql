@@ -0,0 +1,51 @@
+-- This migration adds the delivery statuses reported by the messaging providers, and their error codes, to the messages.
+-- +migrate Up
+ALTER TYPE message_status ADD VALUE 'DELIVERED';
+ALTER TYPE message_status ADD VALUE 'BOUNCED';
+
+ALTER TABLE messages
+    ADD COLUMN error_code VARCHAR(64) NULL;
+
+-- +migrate Down
+UPDATE messages SET status = 'SUCCESS' WHERE status = 'DELIVERED';
+UPDATE messages SET status = 'FAILURE' WHERE status = 'BOUNCED';
+
+ALTER TABLE messages
+    DROP COLUMN error_code;
+
+-- The defaults and the status history function depend on the type, so they're recreated afterwards
+ALTER TABLE messages
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status_history DROP DEFAULT;
+
+DROP FUNCTION create_message_status_history;
+
+CREATE TYPE temp_message_status AS ENUM (
+    'PENDING',
+    'SUCCESS',
+    'FAILURE'
+    );
+
+ALTER TABLE messages
+    ALTER COLUMN status TYPE temp_message_status USING status::text::temp_message_status;
+
+DROP TYPE message_status;
+
+ALTER TYPE temp_message_status RENAME TO message_status;
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION create_message_status_history(time_stamp TIMESTAMP WITH TIME ZONE, m_status message_status, status_message VARCHAR)
+RETURNS jsonb AS $$
+	BEGIN
+        RETURN jsonb_build_object(
+            'timestamp', time_stamp,
+            'status', m_status,
+            'status_message', status_message
+        );
+	END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+ALTER TABLE messages
+    ALTER COLUMN status SET DEFAULT message_status('PENDING'),
+    ALTER COLUMN status_history SET DEFAULT ARRAY[create_message_status_history(NOW(), message_status('PENDING'), NULL)];
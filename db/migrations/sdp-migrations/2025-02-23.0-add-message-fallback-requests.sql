@@ -0,0 +1,14 @@
+-- Queue the fallback of the undelivered invitations along with their delivery status, so they're resent by a job even
+-- if the request that reported the status fails afterwards.
+
+-- +migrate Up
+ALTER TABLE messages
+    ADD COLUMN fallback_requested_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX idx_messages_fallback_requested_at ON messages (fallback_requested_at) WHERE fallback_requested_at IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX idx_messages_fallback_requested_at;
+
+ALTER TABLE messages
+    DROP COLUMN fallback_requested_at;
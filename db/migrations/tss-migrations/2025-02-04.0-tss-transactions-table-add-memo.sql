@@ -0,0 +1,13 @@
+-- +migrate Up
+
+ALTER TABLE submitter_transactions
+    ADD COLUMN memo VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN memo_type VARCHAR(4) NOT NULL DEFAULT '',
+    ADD CONSTRAINT memo_type_check CHECK ((memo = '' AND memo_type = '') OR (memo != '' AND memo_type IN ('text', 'id', 'hash')));
+
+-- +migrate Down
+
+ALTER TABLE submitter_transactions
+    DROP CONSTRAINT memo_type_check,
+    DROP COLUMN memo,
+    DROP COLUMN memo_type;
@@ -0,0 +1,15 @@
+-- +migrate Up
+
+-- No conversion of the existing rows is needed: the float64 amounts only existed in the Go model. The amount column has
+-- been an exact NUMERIC(19,7) since 2024-02-05.0, and Postgres rounded the float values to its 7 decimal places when
+-- they were inserted, so the stored amounts already are the exact decimals the new model reads. The precision lost by
+-- formatting the amounts with 6 decimals only affected the submitted Stellar transactions, which can't be rewritten
+-- here.
+-- We only enforce that amounts are positive at the database level, which the TSS already validated before inserting.
+ALTER TABLE submitter_transactions
+    ADD CONSTRAINT amount_positive_check CHECK (amount > 0);
+
+-- +migrate Down
+
+ALTER TABLE submitter_transactions
+    DROP CONSTRAINT amount_positive_check;
@@ -0,0 +1,21 @@
+-- +migrate Up
+
+-- When payments are batched, several submitter_transactions share the same Stellar transaction, so the hash and XDR
+-- columns can no longer be unique. The operation_index column maps each row to its operation in the Stellar transaction.
+ALTER TABLE submitter_transactions
+    DROP CONSTRAINT submitter_transactions_stellar_transaction_hash_key,
+    DROP CONSTRAINT submitter_transactions_xdr_sent_key,
+    DROP CONSTRAINT submitter_transactions_xdr_received_key,
+    ADD COLUMN operation_index INTEGER NULL CHECK (operation_index >= 0 AND operation_index < 100);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_stellar_transaction_hash_operation_index ON submitter_transactions (stellar_transaction_hash, COALESCE(operation_index, 0));
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_unique_stellar_transaction_hash_operation_index;
+
+ALTER TABLE submitter_transactions
+    DROP COLUMN operation_index,
+    ADD CONSTRAINT submitter_transactions_stellar_transaction_hash_key UNIQUE (stellar_transaction_hash),
+    ADD CONSTRAINT submitter_transactions_xdr_sent_key UNIQUE (xdr_sent),
+    ADD CONSTRAINT submitter_transactions_xdr_received_key UNIQUE (xdr_received);
@@ -0,0 +1,11 @@
+-- +migrate Up
+
+ALTER TABLE submitter_transactions
+    ADD COLUMN claimable_balance BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN claimable_balance_id VARCHAR(72) NULL;
+
+-- +migrate Down
+
+ALTER TABLE submitter_transactions
+    DROP COLUMN claimable_balance,
+    DROP COLUMN claimable_balance_id;
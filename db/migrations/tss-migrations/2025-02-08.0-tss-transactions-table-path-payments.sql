@@ -0,0 +1,17 @@
+-- +migrate Up
+
+ALTER TABLE submitter_transactions
+    ADD COLUMN destination_asset_code VARCHAR(12) NOT NULL DEFAULT '',
+    ADD COLUMN destination_asset_issuer VARCHAR(56) NOT NULL DEFAULT '',
+    ADD COLUMN max_slippage_bps INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN path_payment_path TEXT[] NULL,
+    ADD COLUMN source_amount NUMERIC(19,7) NULL;
+
+-- +migrate Down
+
+ALTER TABLE submitter_transactions
+    DROP COLUMN destination_asset_code,
+    DROP COLUMN destination_asset_issuer,
+    DROP COLUMN max_slippage_bps,
+    DROP COLUMN path_payment_path,
+    DROP COLUMN source_amount;
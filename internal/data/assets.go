@@ -271,7 +271,7 @@ func (a *AssetModel) GetAssetsPerReceiverWallet(ctx context.Context, receiverWal
 				rw.id = ANY($2)
 				AND rw.invitation_sent_at IS NOT NULL
 				AND m.created_at > rw.invitation_sent_at
-				AND m.status = 'SUCCESS'::message_status
+				AND m.status IN ('SUCCESS'::message_status, 'DELIVERED'::message_status)
 			GROUP BY
 				m.receiver_wallet_id,
 				m.wallet_id,
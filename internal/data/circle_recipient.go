@@ -161,6 +161,21 @@ func (m CircleRecipientModel) ResetRecipientsForRetryIfNeeded(ctx context.Contex
 	return updatedRecipients, nil
 }
 
+// Delete removes the circle recipient of a receiver wallet, so a new one is created with the receiver wallet's current
+// Stellar account the next time a payment is sent to it.
+func (m CircleRecipientModel) Delete(ctx context.Context, sqlExec db.SQLExecuter, receiverWalletID string) error {
+	if receiverWalletID == "" {
+		return fmt.Errorf("receiverWalletID is required")
+	}
+
+	_, err := sqlExec.ExecContext(ctx, "DELETE FROM circle_recipients WHERE receiver_wallet_id = $1", receiverWalletID)
+	if err != nil {
+		return fmt.Errorf("deleting circle recipient of receiver wallet %s: %w", receiverWalletID, err)
+	}
+
+	return nil
+}
+
 func (m CircleRecipientModel) Update(ctx context.Context, receiverWalletID string, update CircleRecipientUpdate) (*CircleRecipient, error) {
 	if receiverWalletID == "" {
 		return nil, fmt.Errorf("receiverWalletID is required")
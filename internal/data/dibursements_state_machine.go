@@ -10,12 +10,13 @@ type DisbursementStatus string
 const (
 	DraftDisbursementStatus     DisbursementStatus = "DRAFT"
 	ReadyDisbursementStatus     DisbursementStatus = "READY"
+	ScheduledDisbursementStatus DisbursementStatus = "SCHEDULED"
 	StartedDisbursementStatus   DisbursementStatus = "STARTED"
 	PausedDisbursementStatus    DisbursementStatus = "PAUSED"
 	CompletedDisbursementStatus DisbursementStatus = "COMPLETED"
 )
 
-var NotStartedDisbursementStatuses = []DisbursementStatus{DraftDisbursementStatus, ReadyDisbursementStatus}
+var NotStartedDisbursementStatuses = []DisbursementStatus{DraftDisbursementStatus, ReadyDisbursementStatus, ScheduledDisbursementStatus}
 
 // TransitionTo transitions the disbursement status to the target state
 func (status DisbursementStatus) TransitionTo(targetState DisbursementStatus) error {
@@ -24,7 +25,7 @@ func (status DisbursementStatus) TransitionTo(targetState DisbursementStatus) er
 
 // DisbursementStatuses returns a list of all possible disbursement statuses
 func DisbursementStatuses() []DisbursementStatus {
-	return []DisbursementStatus{DraftDisbursementStatus, ReadyDisbursementStatus, StartedDisbursementStatus, PausedDisbursementStatus, CompletedDisbursementStatus}
+	return []DisbursementStatus{DraftDisbursementStatus, ReadyDisbursementStatus, ScheduledDisbursementStatus, StartedDisbursementStatus, PausedDisbursementStatus, CompletedDisbursementStatus}
 }
 
 // SourceStatuses returns a list of states that the payment status can transition from given the target state
@@ -44,7 +45,12 @@ func DisbursementStateMachineWithInitialState(initialState DisbursementStatus) *
 	transitions := []StateTransition{
 		{From: DraftDisbursementStatus.State(), To: ReadyDisbursementStatus.State()},       // instructions uploaded successfully
 		{From: ReadyDisbursementStatus.State(), To: ReadyDisbursementStatus.State()},       // user re-uploads instructions
+		{From: ReadyDisbursementStatus.State(), To: DraftDisbursementStatus.State()},       // approver rejects disbursement, or instructions are uploaded asynchronously
 		{From: ReadyDisbursementStatus.State(), To: StartedDisbursementStatus.State()},     // user starts disbursement
+		{From: ReadyDisbursementStatus.State(), To: ScheduledDisbursementStatus.State()},   // user schedules disbursement
+		{From: ScheduledDisbursementStatus.State(), To: ReadyDisbursementStatus.State()},   // user cancels the schedule
+		{From: ScheduledDisbursementStatus.State(), To: DraftDisbursementStatus.State()},   // approver rejects scheduled disbursement
+		{From: ScheduledDisbursementStatus.State(), To: StartedDisbursementStatus.State()}, // scheduled start time is reached, or user starts it earlier
 		{From: StartedDisbursementStatus.State(), To: PausedDisbursementStatus.State()},    // user pauses disbursement
 		{From: PausedDisbursementStatus.State(), To: StartedDisbursementStatus.State()},    // user resumes disbursement
 		{From: StartedDisbursementStatus.State(), To: CompletedDisbursementStatus.State()}, // all payments went through
@@ -56,7 +62,7 @@ func DisbursementStateMachineWithInitialState(initialState DisbursementStatus) *
 // Validate validates the disbursement status
 func (status DisbursementStatus) Validate() error {
 	switch DisbursementStatus(strings.ToUpper(string(status))) {
-	case DraftDisbursementStatus, ReadyDisbursementStatus, StartedDisbursementStatus, PausedDisbursementStatus, CompletedDisbursementStatus:
+	case DraftDisbursementStatus, ReadyDisbursementStatus, ScheduledDisbursementStatus, StartedDisbursementStatus, PausedDisbursementStatus, CompletedDisbursementStatus:
 		return nil
 	default:
 		return fmt.Errorf("invalid disbursement status: %s", status)
@@ -0,0 +1,138 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+// DisbursementApproval is the decision of a user about starting a disbursement. Approvals are invalidated when the
+// disbursement is rejected or its instructions are replaced, so they only count towards the quorum of the instructions
+// they were given for.
+type DisbursementApproval struct {
+	ID             string                       `json:"id" db:"id"`
+	DisbursementID string                       `json:"disbursement_id" db:"disbursement_id"`
+	UserID         string                       `json:"user_id" db:"user_id"`
+	Decision       DisbursementApprovalDecision `json:"decision" db:"decision"`
+	Comment        string                       `json:"comment,omitempty" db:"comment"`
+	InvalidatedAt  *time.Time                   `json:"invalidated_at,omitempty" db:"invalidated_at"`
+	CreatedAt      time.Time                    `json:"created_at" db:"created_at"`
+}
+
+type DisbursementApprovalDecision string
+
+const (
+	ApprovedDisbursementApprovalDecision DisbursementApprovalDecision = "APPROVED"
+	RejectedDisbursementApprovalDecision DisbursementApprovalDecision = "REJECTED"
+)
+
+var ErrDisbursementAlreadyReviewedByUser = errors.New("disbursement was already reviewed by this user")
+
+type DisbursementApprovalInsert struct {
+	DisbursementID string
+	UserID         string
+	Decision       DisbursementApprovalDecision
+	Comment        string
+}
+
+type DisbursementApprovalModel struct {
+	dbConnectionPool db.DBConnectionPool
+}
+
+const selectDisbursementApprovalQuery = `
+	SELECT
+		da.id,
+		da.disbursement_id,
+		da.user_id,
+		da.decision,
+		COALESCE(da.comment, '') AS comment,
+		da.invalidated_at,
+		da.created_at
+	FROM
+		disbursement_approvals da
+`
+
+func (m *DisbursementApprovalModel) Insert(ctx context.Context, sqlExec db.SQLExecuter, insert DisbursementApprovalInsert) (*DisbursementApproval, error) {
+	const query = `
+		INSERT INTO
+			disbursement_approvals (disbursement_id, user_id, decision, comment)
+		VALUES
+			($1, $2, $3, $4)
+		RETURNING
+			id, disbursement_id, user_id, decision, COALESCE(comment, '') AS comment, invalidated_at, created_at
+	`
+
+	var approval DisbursementApproval
+	err := sqlExec.GetContext(ctx, &approval, query, insert.DisbursementID, insert.UserID, insert.Decision, utils.SQLNullString(insert.Comment))
+	if err != nil {
+		if strings.Contains(err.Error(), "disbursement_approvals_active_user_unique") {
+			return nil, ErrDisbursementAlreadyReviewedByUser
+		}
+		return nil, fmt.Errorf("inserting %s approval for disbursement %s: %w", insert.Decision, insert.DisbursementID, err)
+	}
+
+	return &approval, nil
+}
+
+// GetAllByDisbursementID returns all the approvals of a disbursement, including the invalidated ones, oldest first.
+func (m *DisbursementApprovalModel) GetAllByDisbursementID(ctx context.Context, sqlExec db.SQLExecuter, disbursementID string) ([]*DisbursementApproval, error) {
+	query := fmt.Sprintf("%s %s", selectDisbursementApprovalQuery, "WHERE da.disbursement_id = $1 ORDER BY da.created_at ASC")
+
+	approvals := []*DisbursementApproval{}
+	err := sqlExec.SelectContext(ctx, &approvals, query, disbursementID)
+	if err != nil {
+		return nil, fmt.Errorf("querying approvals of disbursement %s: %w", disbursementID, err)
+	}
+
+	return approvals, nil
+}
+
+// CountActiveApprovals returns the number of approvals of a disbursement that weren't invalidated. When userIDs is not
+// nil, only the approvals of those users are counted.
+func (m *DisbursementApprovalModel) CountActiveApprovals(ctx context.Context, sqlExec db.SQLExecuter, disbursementID string, userIDs []string) (int, error) {
+	const query = `
+		SELECT
+			COUNT(*)
+		FROM
+			disbursement_approvals da
+		WHERE
+			da.disbursement_id = $1
+			AND da.decision = $2
+			AND da.invalidated_at IS NULL
+			AND ($3::text[] IS NULL OR da.user_id = ANY($3))
+	`
+
+	var count int
+	err := sqlExec.GetContext(ctx, &count, query, disbursementID, ApprovedDisbursementApprovalDecision, pq.Array(userIDs))
+	if err != nil {
+		return 0, fmt.Errorf("counting approvals of disbursement %s: %w", disbursementID, err)
+	}
+
+	return count, nil
+}
+
+// InvalidateAll invalidates all the decisions of a disbursement, so they no longer count towards its quorum.
+func (m *DisbursementApprovalModel) InvalidateAll(ctx context.Context, sqlExec db.SQLExecuter, disbursementID string) error {
+	const query = `
+		UPDATE
+			disbursement_approvals
+		SET
+			invalidated_at = NOW()
+		WHERE
+			disbursement_id = $1
+			AND invalidated_at IS NULL
+	`
+
+	_, err := sqlExec.ExecContext(ctx, query, disbursementID)
+	if err != nil {
+		return fmt.Errorf("invalidating approvals of disbursement %s: %w", disbursementID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+)
+
+func Test_DisbursementApprovalModel(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{Status: ReadyDisbursementStatus})
+
+	approvals, err := models.DisbursementApprovals.GetAllByDisbursementID(ctx, dbConnectionPool, disbursement.ID)
+	require.NoError(t, err)
+	assert.Empty(t, approvals)
+
+	t.Run("🎉 inserts approvals", func(t *testing.T) {
+		approval, err := models.DisbursementApprovals.Insert(ctx, dbConnectionPool, DisbursementApprovalInsert{
+			DisbursementID: disbursement.ID,
+			UserID:         "user-1",
+			Decision:       ApprovedDisbursementApprovalDecision,
+			Comment:        "looks good",
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, approval.ID)
+		assert.Equal(t, disbursement.ID, approval.DisbursementID)
+		assert.Equal(t, "user-1", approval.UserID)
+		assert.Equal(t, ApprovedDisbursementApprovalDecision, approval.Decision)
+		assert.Equal(t, "looks good", approval.Comment)
+		assert.Nil(t, approval.InvalidatedAt)
+
+		_, err = models.DisbursementApprovals.Insert(ctx, dbConnectionPool, DisbursementApprovalInsert{
+			DisbursementID: disbursement.ID,
+			UserID:         "user-2",
+			Decision:       ApprovedDisbursementApprovalDecision,
+		})
+		require.NoError(t, err)
+
+		count, err := models.DisbursementApprovals.CountActiveApprovals(ctx, dbConnectionPool, disbursement.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("returns an error when the user already reviewed the disbursement", func(t *testing.T) {
+		_, err := models.DisbursementApprovals.Insert(ctx, dbConnectionPool, DisbursementApprovalInsert{
+			DisbursementID: disbursement.ID,
+			UserID:         "user-1",
+			Decision:       RejectedDisbursementApprovalDecision,
+		})
+		assert.ErrorIs(t, err, ErrDisbursementAlreadyReviewedByUser)
+	})
+
+	t.Run("🎉 invalidates the approvals, allowing users to review again", func(t *testing.T) {
+		err := models.DisbursementApprovals.InvalidateAll(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+
+		count, err := models.DisbursementApprovals.CountActiveApprovals(ctx, dbConnectionPool, disbursement.ID, nil)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		_, err = models.DisbursementApprovals.Insert(ctx, dbConnectionPool, DisbursementApprovalInsert{
+			DisbursementID: disbursement.ID,
+			UserID:         "user-1",
+			Decision:       ApprovedDisbursementApprovalDecision,
+		})
+		require.NoError(t, err)
+
+		approvals, err := models.DisbursementApprovals.GetAllByDisbursementID(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		require.Len(t, approvals, 3)
+		assert.NotNil(t, approvals[0].InvalidatedAt)
+		assert.NotNil(t, approvals[1].InvalidatedAt)
+		assert.Nil(t, approvals[2].InvalidatedAt)
+
+		count, err = models.DisbursementApprovals.CountActiveApprovals(ctx, dbConnectionPool, disbursement.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("🎉 counts only the approvals of the given users", func(t *testing.T) {
+		usersDisbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{Status: ReadyDisbursementStatus})
+		for _, userID := range []string{"controller-1", "controller-2", "developer-1"} {
+			_, err := models.DisbursementApprovals.Insert(ctx, dbConnectionPool, DisbursementApprovalInsert{
+				DisbursementID: usersDisbursement.ID,
+				UserID:         userID,
+				Decision:       ApprovedDisbursementApprovalDecision,
+			})
+			require.NoError(t, err)
+		}
+
+		count, err := models.DisbursementApprovals.CountActiveApprovals(ctx, dbConnectionPool, usersDisbursement.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		count, err = models.DisbursementApprovals.CountActiveApprovals(ctx, dbConnectionPool, usersDisbursement.ID, []string{"controller-1", "controller-3"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		count, err = models.DisbursementApprovals.CountActiveApprovals(ctx, dbConnectionPool, usersDisbursement.ID, []string{})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func Test_DisbursementModel_RecordApprovalDecision(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{Status: ReadyDisbursementStatus})
+
+	t.Run("returns an error when the disbursement is not in the source status", func(t *testing.T) {
+		err := models.Disbursements.RecordApprovalDecision(ctx, dbConnectionPool, "user-1", disbursement.ID, ApprovedDisbursementApprovalDecision, ScheduledDisbursementStatus, ScheduledDisbursementStatus)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("🎉 records an approval", func(t *testing.T) {
+		err := models.Disbursements.RecordApprovalDecision(ctx, dbConnectionPool, "user-1", disbursement.ID, ApprovedDisbursementApprovalDecision, ReadyDisbursementStatus, ReadyDisbursementStatus)
+		require.NoError(t, err)
+
+		got, err := models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ReadyDisbursementStatus, got.Status)
+		lastEntry := got.StatusHistory[len(got.StatusHistory)-1]
+		assert.Equal(t, "user-1", lastEntry.UserID)
+		assert.Equal(t, ReadyDisbursementStatus, lastEntry.Status)
+		assert.Equal(t, ApprovedDisbursementApprovalDecision, lastEntry.ApprovalDecision)
+		assert.True(t, lastEntry.IsApprovalDecision())
+	})
+
+	t.Run("🎉 records a rejection", func(t *testing.T) {
+		err := models.Disbursements.RecordApprovalDecision(ctx, dbConnectionPool, "user-2", disbursement.ID, RejectedDisbursementApprovalDecision, ReadyDisbursementStatus, DraftDisbursementStatus)
+		require.NoError(t, err)
+
+		got, err := models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, DraftDisbursementStatus, got.Status)
+		lastEntry := got.StatusHistory[len(got.StatusHistory)-1]
+		assert.Equal(t, "user-2", lastEntry.UserID)
+		assert.Equal(t, DraftDisbursementStatus, lastEntry.Status)
+		assert.Equal(t, RejectedDisbursementApprovalDecision, lastEntry.ApprovalDecision)
+	})
+}
@@ -0,0 +1,283 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+)
+
+// MaxInstructionsPerUpload is the maximum number of instructions of an asynchronous upload, which is processed in
+// chunks by a background job instead of within the request.
+const MaxInstructionsPerUpload = 500000
+
+// DisbursementInstructionUpload is an instructions file uploaded to a disbursement to be processed asynchronously. The
+// rows are validated first and then processed in chunks, recording the progress as they're persisted.
+type DisbursementInstructionUpload struct {
+	ID             string                                `json:"id" db:"id"`
+	DisbursementID string                                `json:"disbursement_id" db:"disbursement_id"`
+	Status         DisbursementInstructionUploadStatus   `json:"status" db:"status"`
+	FileName       string                                `json:"file_name" db:"file_name"`
+	FileContent    []byte                                `json:"-" db:"file_content"`
+	TotalRows      int                                   `json:"total_rows" db:"total_rows"`
+	ProcessedRows  int                                   `json:"processed_rows" db:"processed_rows"`
+	FailedRows     int                                   `json:"failed_rows" db:"failed_rows"`
+	Errors         DisbursementInstructionUploadErrors   `json:"errors,omitempty" db:"errors"`
+	Summary        *DisbursementInstructionUploadSummary `json:"summary,omitempty" db:"summary"`
+	CreatedBy      string                                `json:"created_by" db:"created_by"`
+	StartedAt      *time.Time                            `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt    *time.Time                            `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt      time.Time                             `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time                             `json:"updated_at" db:"updated_at"`
+}
+
+type DisbursementInstructionUploadStatus string
+
+const (
+	PendingDisbursementInstructionUploadStatus    DisbursementInstructionUploadStatus = "PENDING"
+	ProcessingDisbursementInstructionUploadStatus DisbursementInstructionUploadStatus = "PROCESSING"
+	CompletedDisbursementInstructionUploadStatus  DisbursementInstructionUploadStatus = "COMPLETED"
+	FailedDisbursementInstructionUploadStatus     DisbursementInstructionUploadStatus = "FAILED"
+)
+
+// DisbursementInstructionUploadErrors are the errors found in an upload, keyed by the row and field they refer to, the
+// same way the errors of synchronous uploads are returned.
+type DisbursementInstructionUploadErrors map[string]string
+
+func (e *DisbursementInstructionUploadErrors) Scan(src interface{}) error {
+	if src == nil {
+		*e = nil
+		return nil
+	}
+
+	byteValue, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("unexpected type for DisbursementInstructionUploadErrors %T", src)
+	}
+
+	return json.Unmarshal(byteValue, e)
+}
+
+var _ sql.Scanner = (*DisbursementInstructionUploadErrors)(nil)
+
+func (e DisbursementInstructionUploadErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	errorsJSON, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling disbursement instruction upload errors: %w", err)
+	}
+
+	return string(errorsJSON), nil
+}
+
+var _ driver.Valuer = DisbursementInstructionUploadErrors{}
+
+// DisbursementInstructionUploadSummary sums up what was persisted by an upload. It's updated as the chunks are
+// processed, and is final once the upload is COMPLETED.
+type DisbursementInstructionUploadSummary struct {
+	ReceiversCreated int    `json:"receivers_created"`
+	PaymentsCreated  int    `json:"payments_created"`
+	TotalAmount      string `json:"total_amount"`
+}
+
+func (s *DisbursementInstructionUploadSummary) Scan(src interface{}) error {
+	byteValue, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("unexpected type for DisbursementInstructionUploadSummary %T", src)
+	}
+
+	return json.Unmarshal(byteValue, s)
+}
+
+var _ sql.Scanner = (*DisbursementInstructionUploadSummary)(nil)
+
+func (s DisbursementInstructionUploadSummary) Value() (driver.Value, error) {
+	summaryJSON, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling disbursement instruction upload summary: %w", err)
+	}
+
+	return string(summaryJSON), nil
+}
+
+var _ driver.Valuer = DisbursementInstructionUploadSummary{}
+
+type DisbursementInstructionUploadInsert struct {
+	DisbursementID string
+	FileName       string
+	FileContent    []byte
+	CreatedBy      string
+}
+
+// DisbursementInstructionUploadUpdate holds the fields to update in an upload. Zero-valued fields are left untouched.
+type DisbursementInstructionUploadUpdate struct {
+	Status        DisbursementInstructionUploadStatus   `db:"status"`
+	TotalRows     int                                   `db:"total_rows"`
+	ProcessedRows int                                   `db:"processed_rows"`
+	FailedRows    int                                   `db:"failed_rows"`
+	Errors        DisbursementInstructionUploadErrors   `db:"errors"`
+	Summary       *DisbursementInstructionUploadSummary `db:"summary"`
+	StartedAt     *time.Time                            `db:"started_at"`
+	CompletedAt   *time.Time                            `db:"completed_at"`
+}
+
+type DisbursementInstructionUploadModel struct {
+	dbConnectionPool db.DBConnectionPool
+}
+
+// disbursementInstructionUploadColumns are the columns of an upload, except for its file content, which is only loaded
+// for the uploads about to be processed.
+const disbursementInstructionUploadColumns = `
+	diu.id,
+	diu.disbursement_id,
+	diu.status,
+	diu.file_name,
+	diu.total_rows,
+	diu.processed_rows,
+	diu.failed_rows,
+	diu.errors,
+	diu.summary,
+	diu.created_by,
+	diu.started_at,
+	diu.completed_at,
+	diu.created_at,
+	diu.updated_at
+`
+
+// Insert creates an upload, returning ErrRecordAlreadyExists if the disbursement already has an upload that is PENDING
+// or PROCESSING.
+func (m *DisbursementInstructionUploadModel) Insert(ctx context.Context, sqlExec db.SQLExecuter, insert DisbursementInstructionUploadInsert) (*DisbursementInstructionUpload, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO
+			disbursement_instruction_uploads AS diu (disbursement_id, file_name, file_content, created_by)
+		VALUES
+			($1, $2, $3, $4)
+		RETURNING
+			%s
+	`, disbursementInstructionUploadColumns)
+
+	var upload DisbursementInstructionUpload
+	err := sqlExec.GetContext(ctx, &upload, query, insert.DisbursementID, insert.FileName, insert.FileContent, insert.CreatedBy)
+	if err != nil {
+		if isDuplicateError(err) {
+			return nil, ErrRecordAlreadyExists
+		}
+		return nil, fmt.Errorf("inserting instruction upload for disbursement %s: %w", insert.DisbursementID, err)
+	}
+
+	return &upload, nil
+}
+
+// Get returns an upload of the given disbursement.
+func (m *DisbursementInstructionUploadModel) Get(ctx context.Context, sqlExec db.SQLExecuter, disbursementID, id string) (*DisbursementInstructionUpload, error) {
+	query := fmt.Sprintf("SELECT %s FROM disbursement_instruction_uploads diu WHERE diu.disbursement_id = $1 AND diu.id = $2", disbursementInstructionUploadColumns)
+
+	var upload DisbursementInstructionUpload
+	err := sqlExec.GetContext(ctx, &upload, query, disbursementID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("querying instruction upload ID %s: %w", id, err)
+	}
+
+	return &upload, nil
+}
+
+// GetAllByDisbursementID returns the uploads of a disbursement, most recent first.
+func (m *DisbursementInstructionUploadModel) GetAllByDisbursementID(ctx context.Context, sqlExec db.SQLExecuter, disbursementID string) ([]*DisbursementInstructionUpload, error) {
+	query := fmt.Sprintf("SELECT %s FROM disbursement_instruction_uploads diu WHERE diu.disbursement_id = $1 ORDER BY diu.created_at DESC", disbursementInstructionUploadColumns)
+
+	uploads := []*DisbursementInstructionUpload{}
+	err := sqlExec.SelectContext(ctx, &uploads, query, disbursementID)
+	if err != nil {
+		return nil, fmt.Errorf("querying instruction uploads of disbursement %s: %w", disbursementID, err)
+	}
+
+	return uploads, nil
+}
+
+// HasActive returns whether the disbursement has an upload that is PENDING or PROCESSING.
+func (m *DisbursementInstructionUploadModel) HasActive(ctx context.Context, sqlExec db.SQLExecuter, disbursementID string) (bool, error) {
+	const query = `
+		SELECT EXISTS(
+			SELECT 1 FROM disbursement_instruction_uploads WHERE disbursement_id = $1 AND status IN ('PENDING', 'PROCESSING')
+		)
+	`
+
+	var exists bool
+	err := sqlExec.GetContext(ctx, &exists, query, disbursementID)
+	if err != nil {
+		return false, fmt.Errorf("checking active instruction uploads of disbursement %s: %w", disbursementID, err)
+	}
+
+	return exists, nil
+}
+
+// GetNextActiveForUpdate returns and locks the oldest upload that is PENDING or PROCESSING, along with its file
+// content. Uploads locked by another transaction are skipped, and ErrRecordNotFound is returned when there are none.
+func (m *DisbursementInstructionUploadModel) GetNextActiveForUpdate(ctx context.Context, dbTx db.DBTransaction) (*DisbursementInstructionUpload, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			%s,
+			diu.file_content
+		FROM
+			disbursement_instruction_uploads diu
+		WHERE
+			diu.status IN ('PENDING', 'PROCESSING')
+		ORDER BY diu.created_at ASC
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`, disbursementInstructionUploadColumns)
+
+	var upload DisbursementInstructionUpload
+	err := dbTx.GetContext(ctx, &upload, query)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("querying next active instruction upload: %w", err)
+	}
+
+	return &upload, nil
+}
+
+func (m *DisbursementInstructionUploadModel) Update(ctx context.Context, sqlExec db.SQLExecuter, id string, update DisbursementInstructionUploadUpdate) error {
+	setClause, params := BuildSetClause(update)
+	if setClause == "" {
+		return fmt.Errorf("no fields to update: %w", ErrMissingInput)
+	}
+
+	query := sqlExec.Rebind(fmt.Sprintf(`
+		UPDATE
+			disbursement_instruction_uploads
+		SET
+			%s
+		WHERE
+			id = ?
+	`, setClause))
+	params = append(params, id)
+
+	result, err := sqlExec.ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("updating instruction upload ID %s: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting rows affected when updating instruction upload ID %s: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
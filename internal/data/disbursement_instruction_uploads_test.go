@@ -0,0 +1,151 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+)
+
+func Test_DisbursementInstructionUploadModel(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{Status: DraftDisbursementStatus})
+	otherDisbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{Status: DraftDisbursementStatus})
+	uploadModel := models.DisbursementInstructionUploads
+
+	upload, err := uploadModel.Insert(ctx, dbConnectionPool, DisbursementInstructionUploadInsert{
+		DisbursementID: disbursement.ID,
+		FileName:       "instructions.csv",
+		FileContent:    []byte("phone,id,amount,verification\n"),
+		CreatedBy:      "user-id",
+	})
+	require.NoError(t, err)
+
+	t.Run("🎉 inserts a pending upload", func(t *testing.T) {
+		assert.NotEmpty(t, upload.ID)
+		assert.Equal(t, disbursement.ID, upload.DisbursementID)
+		assert.Equal(t, PendingDisbursementInstructionUploadStatus, upload.Status)
+		assert.Equal(t, "instructions.csv", upload.FileName)
+		assert.Equal(t, "user-id", upload.CreatedBy)
+		assert.Zero(t, upload.TotalRows)
+		assert.Nil(t, upload.Errors)
+		assert.Nil(t, upload.Summary)
+	})
+
+	t.Run("🔴 a disbursement can only have one active upload", func(t *testing.T) {
+		_, err = uploadModel.Insert(ctx, dbConnectionPool, DisbursementInstructionUploadInsert{
+			DisbursementID: disbursement.ID,
+			FileName:       "instructions.csv",
+			FileContent:    []byte("phone,id,amount,verification\n"),
+			CreatedBy:      "user-id",
+		})
+		assert.ErrorIs(t, err, ErrRecordAlreadyExists)
+
+		hasActive, err := uploadModel.HasActive(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.True(t, hasActive)
+
+		hasActive, err = uploadModel.HasActive(ctx, dbConnectionPool, otherDisbursement.ID)
+		require.NoError(t, err)
+		assert.False(t, hasActive)
+	})
+
+	t.Run("🔴 gets only the uploads of the disbursement", func(t *testing.T) {
+		_, err = uploadModel.Get(ctx, dbConnectionPool, otherDisbursement.ID, upload.ID)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+
+		uploads, err := uploadModel.GetAllByDisbursementID(ctx, dbConnectionPool, otherDisbursement.ID)
+		require.NoError(t, err)
+		assert.Empty(t, uploads)
+	})
+
+	t.Run("🎉 locks the next active upload and updates its progress", func(t *testing.T) {
+		dbTx, err := dbConnectionPool.BeginTxx(ctx, nil)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, dbTx.Rollback())
+		}()
+
+		nextUpload, err := uploadModel.GetNextActiveForUpdate(ctx, dbTx)
+		require.NoError(t, err)
+		assert.Equal(t, upload.ID, nextUpload.ID)
+		assert.Equal(t, []byte("phone,id,amount,verification\n"), nextUpload.FileContent)
+
+		now := time.Now()
+		err = uploadModel.Update(ctx, dbTx, upload.ID, DisbursementInstructionUploadUpdate{
+			Status:        ProcessingDisbursementInstructionUploadStatus,
+			TotalRows:     3000,
+			ProcessedRows: 1000,
+			Summary:       &DisbursementInstructionUploadSummary{ReceiversCreated: 10, PaymentsCreated: 1000, TotalAmount: "1000.0000000"},
+			StartedAt:     &now,
+		})
+		require.NoError(t, err)
+
+		updatedUpload, err := uploadModel.Get(ctx, dbTx, disbursement.ID, upload.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ProcessingDisbursementInstructionUploadStatus, updatedUpload.Status)
+		assert.Equal(t, 3000, updatedUpload.TotalRows)
+		assert.Equal(t, 1000, updatedUpload.ProcessedRows)
+		assert.Equal(t, &DisbursementInstructionUploadSummary{ReceiversCreated: 10, PaymentsCreated: 1000, TotalAmount: "1000.0000000"}, updatedUpload.Summary)
+		assert.NotNil(t, updatedUpload.StartedAt)
+		assert.Nil(t, updatedUpload.CompletedAt)
+	})
+
+	t.Run("🎉 finished uploads are no longer active", func(t *testing.T) {
+		now := time.Now()
+		err = uploadModel.Update(ctx, dbConnectionPool, upload.ID, DisbursementInstructionUploadUpdate{
+			Status:      FailedDisbursementInstructionUploadStatus,
+			FailedRows:  1,
+			Errors:      DisbursementInstructionUploadErrors{"line 2 - phone": "invalid phone format. Correct format: +380445555555"},
+			CompletedAt: &now,
+		})
+		require.NoError(t, err)
+
+		failedUpload, err := uploadModel.Get(ctx, dbConnectionPool, disbursement.ID, upload.ID)
+		require.NoError(t, err)
+		assert.Equal(t, FailedDisbursementInstructionUploadStatus, failedUpload.Status)
+		assert.Equal(t, DisbursementInstructionUploadErrors{"line 2 - phone": "invalid phone format. Correct format: +380445555555"}, failedUpload.Errors)
+
+		dbTx, err := dbConnectionPool.BeginTxx(ctx, nil)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, dbTx.Rollback())
+		}()
+		_, err = uploadModel.GetNextActiveForUpdate(ctx, dbTx)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+
+		newUpload, err := uploadModel.Insert(ctx, dbConnectionPool, DisbursementInstructionUploadInsert{
+			DisbursementID: disbursement.ID,
+			FileName:       "fixed-instructions.csv",
+			FileContent:    []byte("phone,id,amount,verification\n"),
+			CreatedBy:      "user-id",
+		})
+		require.NoError(t, err)
+
+		uploads, err := uploadModel.GetAllByDisbursementID(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		require.Len(t, uploads, 2)
+		assert.Equal(t, newUpload.ID, uploads[0].ID)
+		assert.Equal(t, upload.ID, uploads[1].ID)
+	})
+
+	t.Run("🔴 updating requires at least one field", func(t *testing.T) {
+		err = uploadModel.Update(ctx, dbConnectionPool, upload.ID, DisbursementInstructionUploadUpdate{})
+		assert.ErrorIs(t, err, ErrMissingInput)
+	})
+}
@@ -13,13 +13,13 @@ import (
 )
 
 type DisbursementInstruction struct {
-	Phone             string `csv:"phone"`
-	Email             string `csv:"email"`
-	ID                string `csv:"id"`
-	Amount            string `csv:"amount"`
-	VerificationValue string `csv:"verification"`
-	ExternalPaymentId string `csv:"paymentID"`
-	WalletAddress     string `csv:"walletAddress"`
+	Phone             string `csv:"phone" json:"phone,omitempty"`
+	Email             string `csv:"email" json:"email,omitempty"`
+	ID                string `csv:"id" json:"id"`
+	Amount            string `csv:"amount" json:"amount"`
+	VerificationValue string `csv:"verification" json:"verification,omitempty"`
+	ExternalPaymentId string `csv:"paymentID" json:"payment_id,omitempty"`
+	WalletAddress     string `csv:"walletAddress" json:"wallet_address,omitempty"`
 }
 
 func (di *DisbursementInstruction) Contact() (string, error) {
@@ -42,6 +42,7 @@ type DisbursementInstructionModel struct {
 	receiverModel             *ReceiverModel
 	paymentModel              *PaymentModel
 	disbursementModel         *DisbursementModel
+	disbursementApprovalModel *DisbursementApprovalModel
 }
 
 const MaxInstructionsPerDisbursement = 10000
@@ -55,6 +56,7 @@ func NewDisbursementInstructionModel(dbConnectionPool db.DBConnectionPool) *Disb
 		receiverModel:             &ReceiverModel{},
 		paymentModel:              &PaymentModel{dbConnectionPool: dbConnectionPool},
 		disbursementModel:         &DisbursementModel{dbConnectionPool: dbConnectionPool},
+		disbursementApprovalModel: &DisbursementApprovalModel{dbConnectionPool: dbConnectionPool},
 	}
 }
 
@@ -70,6 +72,8 @@ type DisbursementInstructionsOpts struct {
 	Disbursement            *Disbursement
 	DisbursementUpdate      *DisbursementUpdate
 	MaxNumberOfInstructions int
+	// KeepDraft leaves the disbursement in DRAFT after the instructions are processed, instead of moving it to READY.
+	KeepDraft bool
 }
 
 // ProcessAll Processes all disbursement instructions and persists the data to the database.
@@ -98,29 +102,10 @@ func (di DisbursementInstructionModel) ProcessAll(ctx context.Context, opts Disb
 
 	// We need all the following logic to be executed in one transaction.
 	return db.RunInTransaction(ctx, di.dbConnectionPool, nil, func(dbTx db.DBTransaction) error {
-		// Step 1: Fetch all receivers by contact information (phone, email, etc.) and create missing ones
-		registrationContactType := opts.Disbursement.RegistrationContactType
-		receiversByIDMap, err := di.reconcileExistingReceiversWithInstructions(ctx, dbTx, opts.Instructions, registrationContactType.ReceiverContactType)
+		// Steps 1-3: Reconcile the receivers, their wallets and verifications with the instructions
+		receiversByIDMap, receiverIDToReceiverWalletIDMap, _, err := di.processReceivers(ctx, dbTx, opts.Instructions, opts.Disbursement)
 		if err != nil {
-			return fmt.Errorf("processing receivers: %w", err)
-		}
-
-		// Step 2: Fetch all receiver wallets and create missing ones
-		receiverIDToReceiverWalletIDMap, err := di.processReceiverWallets(ctx, dbTx, receiversByIDMap, opts.Disbursement)
-		if err != nil {
-			return fmt.Errorf("processing receiver wallets: %w", err)
-		}
-
-		// Step 3: Register supplied wallets or process receiver verifications based on the registration contact type
-		if registrationContactType.IncludesWalletAddress {
-			if err = di.registerSuppliedWallets(ctx, dbTx, opts.Instructions, receiversByIDMap, receiverIDToReceiverWalletIDMap); err != nil {
-				return fmt.Errorf("registering supplied wallets: %w", err)
-			}
-		} else {
-			err = di.processReceiverVerifications(ctx, dbTx, receiversByIDMap, opts.Instructions, opts.Disbursement, registrationContactType.ReceiverContactType)
-			if err != nil {
-				return fmt.Errorf("processing receiver verifications: %w", err)
-			}
+			return err
 		}
 
 		// Step 4: Delete all pre-existing draft payments tied to this disbursement for each receiver in one call
@@ -138,7 +123,15 @@ func (di DisbursementInstructionModel) ProcessAll(ctx context.Context, opts Disb
 			return fmt.Errorf("persisting payment file: %w", err)
 		}
 
-		// Step 7: Update Disbursement Status
+		// Step 7: Invalidate the approvals given for the previous instructions
+		if err = di.disbursementApprovalModel.InvalidateAll(ctx, dbTx, opts.Disbursement.ID); err != nil {
+			return fmt.Errorf("invalidating approvals: %w", err)
+		}
+
+		// Step 8: Update Disbursement Status
+		if opts.KeepDraft {
+			return nil
+		}
 		if err = di.disbursementModel.UpdateStatus(ctx, dbTx, opts.UserID, opts.Disbursement.ID, ReadyDisbursementStatus); err != nil {
 			return fmt.Errorf("updating status: %w", err)
 		}
@@ -147,6 +140,175 @@ func (di DisbursementInstructionModel) ProcessAll(ctx context.Context, opts Disb
 	})
 }
 
+type DisbursementInstructionsChunkOpts struct {
+	UserID       string
+	Instructions []*DisbursementInstruction
+	Disbursement *Disbursement
+	// DisbursementUpdate is the file persisted to the disbursement once the last chunk is processed.
+	DisbursementUpdate *DisbursementUpdate
+	IsFirstChunk       bool
+	IsLastChunk        bool
+}
+
+// DisbursementInstructionsChunkResult is what was persisted when processing a chunk of instructions.
+type DisbursementInstructionsChunkResult struct {
+	ReceiversCreated int
+	PaymentsCreated  int
+}
+
+// ProcessChunk processes a chunk of the instructions of an asynchronous upload within the given transaction, following
+// the same steps as ProcessAll. The draft payments and approvals of the disbursement are only dropped with the first
+// chunk, and the file is only persisted and the disbursement moved to READY with the last one. Receivers must not be
+// repeated across the chunks of an upload, since each chunk creates one payment per instruction.
+func (di DisbursementInstructionModel) ProcessChunk(ctx context.Context, dbTx db.DBTransaction, opts DisbursementInstructionsChunkOpts) (*DisbursementInstructionsChunkResult, error) {
+	if opts.IsFirstChunk {
+		if err := di.paymentModel.DeleteAllDraftForDisbursement(ctx, dbTx, opts.Disbursement.ID); err != nil {
+			return nil, fmt.Errorf("deleting draft payments: %w", err)
+		}
+		if err := di.disbursementApprovalModel.InvalidateAll(ctx, dbTx, opts.Disbursement.ID); err != nil {
+			return nil, fmt.Errorf("invalidating approvals: %w", err)
+		}
+	}
+
+	receiversByIDMap, receiverIDToReceiverWalletIDMap, receiversCreated, err := di.processReceivers(ctx, dbTx, opts.Instructions, opts.Disbursement)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = di.createPayments(ctx, dbTx, receiversByIDMap, receiverIDToReceiverWalletIDMap, opts.Instructions, opts.Disbursement); err != nil {
+		return nil, fmt.Errorf("creating payments: %w", err)
+	}
+
+	if opts.IsLastChunk {
+		if err = di.disbursementModel.Update(ctx, opts.DisbursementUpdate); err != nil {
+			return nil, fmt.Errorf("persisting payment file: %w", err)
+		}
+		if err = di.disbursementModel.UpdateStatus(ctx, dbTx, opts.UserID, opts.Disbursement.ID, ReadyDisbursementStatus); err != nil {
+			return nil, fmt.Errorf("updating status: %w", err)
+		}
+	}
+
+	return &DisbursementInstructionsChunkResult{
+		ReceiversCreated: receiversCreated,
+		PaymentsCreated:  len(opts.Instructions),
+	}, nil
+}
+
+// ExistingInstructionReceiver is an existing receiver of an instruction, along with the data the instruction would be
+// reconciled with when processed.
+type ExistingInstructionReceiver struct {
+	Receiver *Receiver
+	// Verification is the verification of the receiver for the verification field of the disbursement, if any.
+	Verification *ReceiverVerification
+	// ReceiverWallet is the receiver wallet of the receiver for the wallet of the disbursement, if any.
+	ReceiverWallet *ReceiverWallet
+}
+
+// GetExistingReceivers returns the existing receivers of the instructions keyed by contact, along with their
+// verifications and receiver wallets for the disbursement. Nothing is written, so it can be used to preview how the
+// instructions would be reconciled with the existing receivers before processing them.
+func (di DisbursementInstructionModel) GetExistingReceivers(ctx context.Context, sqlExec db.SQLExecuter, instructions []*DisbursementInstruction, disbursement *Disbursement) (map[string]*ExistingInstructionReceiver, error) {
+	contacts := make([]string, 0, len(instructions))
+	for _, instruction := range instructions {
+		contact, err := instruction.Contact()
+		if err != nil {
+			return nil, fmt.Errorf("resolving contact information for instruction with ID %s: %w", instruction.ID, err)
+		}
+		contacts = append(contacts, contact)
+	}
+
+	receivers, err := di.receiverModel.GetByContacts(ctx, sqlExec, contacts...)
+	if err != nil {
+		return nil, fmt.Errorf("fetching receivers by contacts: %w", err)
+	}
+
+	contactType := disbursement.RegistrationContactType.ReceiverContactType
+	existingReceiversByContact := make(map[string]*ExistingInstructionReceiver, len(receivers))
+	existingReceiversByID := make(map[string]*ExistingInstructionReceiver, len(receivers))
+	for _, receiver := range receivers {
+		contact := receiver.ContactByType(contactType)
+		if contact == "" {
+			continue
+		}
+		existingReceiver := &ExistingInstructionReceiver{Receiver: receiver}
+		existingReceiversByContact[contact] = existingReceiver
+		existingReceiversByID[receiver.ID] = existingReceiver
+	}
+	if len(existingReceiversByID) == 0 {
+		return existingReceiversByContact, nil
+	}
+	receiverIDs := maps.Keys(existingReceiversByID)
+
+	if disbursement.RegistrationContactType.IncludesWalletAddress {
+		receiverWallets, walletsErr := di.receiverWalletModel.GetByReceiverIDsAndWalletID(ctx, sqlExec, receiverIDs, disbursement.Wallet.ID)
+		if walletsErr != nil {
+			return nil, fmt.Errorf("fetching receiver wallets: %w", walletsErr)
+		}
+		if len(receiverWallets) == 0 {
+			return existingReceiversByContact, nil
+		}
+
+		receiverWalletIDs := make([]string, 0, len(receiverWallets))
+		for _, receiverWallet := range receiverWallets {
+			receiverWalletIDs = append(receiverWalletIDs, receiverWallet.ID)
+		}
+		// The stellar address of the receiver wallets is only loaded by GetByIDs.
+		fullReceiverWallets, walletsErr := di.receiverWalletModel.GetByIDs(ctx, sqlExec, receiverWalletIDs...)
+		if walletsErr != nil {
+			return nil, fmt.Errorf("fetching receiver wallets by IDs: %w", walletsErr)
+		}
+		for i := range fullReceiverWallets {
+			if existingReceiver, ok := existingReceiversByID[fullReceiverWallets[i].Receiver.ID]; ok {
+				existingReceiver.ReceiverWallet = &fullReceiverWallets[i]
+			}
+		}
+	} else {
+		verifications, verificationsErr := di.receiverVerificationModel.GetByReceiverIDsAndVerificationField(ctx, sqlExec, receiverIDs, disbursement.VerificationField)
+		if verificationsErr != nil {
+			return nil, fmt.Errorf("fetching receiver verifications: %w", verificationsErr)
+		}
+		for _, verification := range verifications {
+			if existingReceiver, ok := existingReceiversByID[verification.ReceiverID]; ok {
+				existingReceiver.Verification = verification
+			}
+		}
+	}
+
+	return existingReceiversByContact, nil
+}
+
+// processReceivers creates the missing receivers and receiver wallets of the instructions, and then registers the
+// supplied wallets or processes the receiver verifications, based on the registration contact type. It returns the
+// receivers by ID, their receiver wallet IDs and the number of receivers created.
+func (di DisbursementInstructionModel) processReceivers(ctx context.Context, dbTx db.DBTransaction, instructions []*DisbursementInstruction, disbursement *Disbursement) (map[string]*Receiver, map[string]string, int, error) {
+	// Step 1: Fetch all receivers by contact information (phone, email, etc.) and create missing ones
+	registrationContactType := disbursement.RegistrationContactType
+	receiversByIDMap, receiversCreated, err := di.reconcileExistingReceiversWithInstructions(ctx, dbTx, instructions, registrationContactType.ReceiverContactType)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("processing receivers: %w", err)
+	}
+
+	// Step 2: Fetch all receiver wallets and create missing ones
+	receiverIDToReceiverWalletIDMap, err := di.processReceiverWallets(ctx, dbTx, receiversByIDMap, disbursement)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("processing receiver wallets: %w", err)
+	}
+
+	// Step 3: Register supplied wallets or process receiver verifications based on the registration contact type
+	if registrationContactType.IncludesWalletAddress {
+		if err = di.registerSuppliedWallets(ctx, dbTx, instructions, receiversByIDMap, receiverIDToReceiverWalletIDMap); err != nil {
+			return nil, nil, 0, fmt.Errorf("registering supplied wallets: %w", err)
+		}
+	} else {
+		err = di.processReceiverVerifications(ctx, dbTx, receiversByIDMap, instructions, disbursement, registrationContactType.ReceiverContactType)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("processing receiver verifications: %w", err)
+		}
+	}
+
+	return receiversByIDMap, receiverIDToReceiverWalletIDMap, receiversCreated, nil
+}
+
 func (di DisbursementInstructionModel) registerSuppliedWallets(ctx context.Context, dbTx db.DBTransaction, instructions []*DisbursementInstruction, receiversByIDMap map[string]*Receiver, receiverIDToReceiverWalletIDMap map[string]string) error {
 	// Construct a map of receiverWalletID to receiverWallet
 	receiverWalletsByIDMap, err := di.getReceiverWalletsByIDMap(ctx, dbTx, maps.Values(receiverIDToReceiverWalletIDMap))
@@ -198,21 +360,22 @@ func (di DisbursementInstructionModel) getReceiverWalletsByIDMap(ctx context.Con
 	return receiverWalletsByIDMap, nil
 }
 
-// reconcileExistingReceiversWithInstructions fetches all existing receivers by their contact information and creates missing ones.
-func (di DisbursementInstructionModel) reconcileExistingReceiversWithInstructions(ctx context.Context, dbTx db.DBTransaction, instructions []*DisbursementInstruction, contactType ReceiverContactType) (map[string]*Receiver, error) {
+// reconcileExistingReceiversWithInstructions fetches all existing receivers by their contact information and creates
+// missing ones, returning the receivers by ID and the number of receivers created.
+func (di DisbursementInstructionModel) reconcileExistingReceiversWithInstructions(ctx context.Context, dbTx db.DBTransaction, instructions []*DisbursementInstruction, contactType ReceiverContactType) (map[string]*Receiver, int, error) {
 	// Step 1: Fetch existing receivers
 	contacts := make([]string, 0, len(instructions))
 	for _, instruction := range instructions {
 		contact, err := instruction.Contact()
 		if err != nil {
-			return nil, fmt.Errorf("resolving contact information for instruction with ID %s: %w", instruction.ID, err)
+			return nil, 0, fmt.Errorf("resolving contact information for instruction with ID %s: %w", instruction.ID, err)
 		}
 		contacts = append(contacts, contact)
 	}
 
 	existingReceivers, err := di.receiverModel.GetByContacts(ctx, dbTx, contacts...)
 	if err != nil {
-		return nil, fmt.Errorf("fetching receivers by contacts: %w", err)
+		return nil, 0, fmt.Errorf("fetching receivers by contacts: %w", err)
 	}
 
 	// Step 2: Create maps for quick lookup
@@ -220,26 +383,31 @@ func (di DisbursementInstructionModel) reconcileExistingReceiversWithInstruction
 	for _, receiver := range existingReceivers {
 		contact := receiver.ContactByType(contactType)
 		if contact == "" {
-			return nil, fmt.Errorf("receiver with ID %s has no contact information for contact type %s", receiver.ID, contactType)
+			return nil, 0, fmt.Errorf("receiver with ID %s has no contact information for contact type %s", receiver.ID, contactType)
 		}
 		existingReceiversByContactMap[contact] = receiver
 	}
 
 	// Step 3: Create missing receivers from instructions
+	receiversCreated := 0
 	for _, instruction := range instructions {
-		if createErr := di.createReceiverFromInstructionIfNeeded(ctx, dbTx, instruction, existingReceiversByContactMap); createErr != nil {
-			return nil, fmt.Errorf("creating receiver from instruction: %w", createErr)
+		created, createErr := di.createReceiverFromInstructionIfNeeded(ctx, dbTx, instruction, existingReceiversByContactMap)
+		if createErr != nil {
+			return nil, 0, fmt.Errorf("creating receiver from instruction: %w", createErr)
+		}
+		if created {
+			receiversCreated++
 		}
 	}
 
 	// Step 4: Fetch all receivers again
 	receivers, err := di.receiverModel.GetByContacts(ctx, dbTx, contacts...)
 	if err != nil {
-		return nil, fmt.Errorf("fetching receivers by contact information: %w", err)
+		return nil, 0, fmt.Errorf("fetching receivers by contact information: %w", err)
 	}
 
 	if len(receivers) != len(instructions) {
-		return nil, fmt.Errorf("receiver count mismatch after processing instructions")
+		return nil, 0, fmt.Errorf("receiver count mismatch after processing instructions")
 	}
 
 	receiversByIDMap := make(map[string]*Receiver)
@@ -247,14 +415,15 @@ func (di DisbursementInstructionModel) reconcileExistingReceiversWithInstruction
 		receiversByIDMap[receiver.ID] = receiver
 	}
 
-	return receiversByIDMap, nil
+	return receiversByIDMap, receiversCreated, nil
 }
 
-// createReceiverFromInstructionIfNeeded create a new receiver if it doesn't exist for the given instruction.
-func (di DisbursementInstructionModel) createReceiverFromInstructionIfNeeded(ctx context.Context, dbTx db.DBTransaction, instruction *DisbursementInstruction, existingReceiversByContactMap map[string]*Receiver) error {
+// createReceiverFromInstructionIfNeeded create a new receiver if it doesn't exist for the given instruction, returning
+// whether it was created.
+func (di DisbursementInstructionModel) createReceiverFromInstructionIfNeeded(ctx context.Context, dbTx db.DBTransaction, instruction *DisbursementInstruction, existingReceiversByContactMap map[string]*Receiver) (bool, error) {
 	contact, err := instruction.Contact()
 	if err != nil {
-		return fmt.Errorf("resolving contact information for instruction with ID %s: %w", instruction.ID, err)
+		return false, fmt.Errorf("resolving contact information for instruction with ID %s: %w", instruction.ID, err)
 	}
 
 	_, exists := existingReceiversByContactMap[contact]
@@ -271,11 +440,12 @@ func (di DisbursementInstructionModel) createReceiverFromInstructionIfNeeded(ctx
 		}
 		_, insertErr := di.receiverModel.Insert(ctx, dbTx, receiverInsert)
 		if insertErr != nil {
-			return fmt.Errorf("inserting receiver: %w", insertErr)
+			return false, fmt.Errorf("inserting receiver: %w", insertErr)
 		}
+		return true, nil
 	}
 
-	return nil
+	return false, nil
 }
 
 func (di DisbursementInstructionModel) processReceiverVerifications(ctx context.Context, dbTx db.DBTransaction, receiversByIDMap map[string]*Receiver, instructions []*DisbursementInstruction, disbursement *Disbursement, contactType ReceiverContactType) error {
@@ -316,12 +486,16 @@ func (di DisbursementInstructionModel) processReceiverVerifications(ctx context.
 				ReceiverID:        receiver.ID,
 				VerificationValue: instruction.VerificationValue,
 				VerificationField: disbursement.VerificationField,
+				Question:          disbursement.VerificationQuestion,
 			}
 			_, insertErr := di.receiverVerificationModel.Insert(ctx, dbTx, verificationInsert)
 			if insertErr != nil {
 				return fmt.Errorf("error inserting receiver verification: %w", insertErr)
 			}
-		} else if !CompareVerificationValue(verification.HashedValue, instruction.VerificationValue) {
+			continue
+		}
+
+		if !verification.VerificationField.CompareValue(verification.HashedValue, instruction.VerificationValue) {
 			if verification.ConfirmedAt != nil {
 				return fmt.Errorf("%w: receiver verification for %s doesn't match. Check instruction with ID %s", ErrReceiverVerificationMismatch, contact, instruction.ID)
 			}
@@ -330,6 +504,15 @@ func (di DisbursementInstructionModel) processReceiverVerifications(ctx context.
 				return fmt.Errorf("error updating receiver verification for disbursement id %s: %w", disbursement.ID, updateErr)
 			}
 		}
+
+		// The receivers that haven't registered yet are asked the question of their latest disbursement.
+		if verification.ConfirmedAt == nil && disbursement.VerificationQuestion != "" &&
+			(verification.Question == nil || *verification.Question != disbursement.VerificationQuestion) {
+			updateErr := di.receiverVerificationModel.UpdateQuestion(ctx, dbTx, verification.ReceiverID, verification.VerificationField, disbursement.VerificationQuestion)
+			if updateErr != nil {
+				return fmt.Errorf("updating receiver verification question for disbursement id %s: %w", disbursement.ID, updateErr)
+			}
+		}
 	}
 
 	return nil
@@ -5,12 +5,15 @@ import (
 	"database/sql"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
 	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
 )
 
 func Test_DisbursementInstructionModel_ProcessAll(t *testing.T) {
@@ -398,6 +401,76 @@ func Test_DisbursementInstructionModel_ProcessAll(t *testing.T) {
 		require.Equal(t, disbursementUpdate.FileName, actualDisbursement.FileName)
 	})
 
+	t.Run("success - the secret question is stored and updated for the receivers that didn't register", func(t *testing.T) {
+		defer cleanup()
+
+		instructions := []*DisbursementInstruction{
+			{Phone: "+380-12-345-674", Amount: "100.01", ID: "1", VerificationValue: "Blue"},
+			{Phone: "+380-12-345-675", Amount: "100.02", ID: "2", VerificationValue: "Green"},
+		}
+		processWithQuestion := func(name, question string) {
+			questionDisbursement := CreateDraftDisbursementFixture(t, ctx, dbConnectionPool, &DisbursementModel{dbConnectionPool: dbConnectionPool}, Disbursement{
+				Name:                 name,
+				Asset:                asset,
+				Wallet:               wallet,
+				VerificationField:    VerificationTypeCustomSecretQuestion,
+				VerificationQuestion: question,
+			})
+			require.Equal(t, question, questionDisbursement.VerificationQuestion)
+
+			err := di.ProcessAll(ctx, DisbursementInstructionsOpts{
+				UserID:       "user-id",
+				Instructions: instructions,
+				Disbursement: questionDisbursement,
+				DisbursementUpdate: &DisbursementUpdate{
+					ID:          questionDisbursement.ID,
+					FileName:    "instructions.csv",
+					FileContent: CreateInstructionsFixture(t, instructions),
+				},
+				MaxNumberOfInstructions: MaxInstructionsPerDisbursement,
+			})
+			require.NoError(t, err)
+		}
+		getQuestions := func(receivers []*Receiver) map[string]string {
+			verifications, err := di.receiverVerificationModel.GetByReceiverIDsAndVerificationField(ctx, dbConnectionPool, []string{receivers[0].ID, receivers[1].ID}, VerificationTypeCustomSecretQuestion)
+			require.NoError(t, err)
+			require.Len(t, verifications, 2)
+
+			questions := map[string]string{}
+			for _, verification := range verifications {
+				require.NotNil(t, verification.Question)
+				questions[verification.ReceiverID] = *verification.Question
+			}
+			return questions
+		}
+
+		processWithQuestion("secret question disbursement 1", "What is your favorite color?")
+		receivers, err := di.receiverModel.GetByContacts(ctx, dbConnectionPool, instructions[0].Phone, instructions[1].Phone)
+		require.NoError(t, err)
+		require.Len(t, receivers, 2)
+		assert.Equal(t, map[string]string{
+			receivers[0].ID: "What is your favorite color?",
+			receivers[1].ID: "What is your favorite color?",
+		}, getQuestions(receivers))
+
+		// The first receiver registers, so the question it answered is kept.
+		err = di.receiverVerificationModel.UpdateReceiverVerification(ctx, ReceiverVerificationUpdate{
+			ReceiverID:          receivers[0].ID,
+			VerificationField:   VerificationTypeCustomSecretQuestion,
+			VerificationChannel: message.MessageChannelSMS,
+			ConfirmedAt:         utils.TimePtr(time.Now()),
+			ConfirmedByType:     ConfirmedByTypeReceiver,
+			ConfirmedByID:       receivers[0].ID,
+		}, dbConnectionPool)
+		require.NoError(t, err)
+
+		processWithQuestion("secret question disbursement 2", "What is the color of your car?")
+		assert.Equal(t, map[string]string{
+			receivers[0].ID: "What is your favorite color?",
+			receivers[1].ID: "What is the color of your car?",
+		}, getQuestions(receivers))
+	})
+
 	t.Run("success - existing receiver wallet", func(t *testing.T) {
 		defer cleanup()
 
@@ -556,6 +629,130 @@ func Test_DisbursementInstructionModel_ProcessAll(t *testing.T) {
 	})
 }
 
+func Test_DisbursementInstructionModel_ProcessChunk(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, outerErr := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, outerErr)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+
+	asset := CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVV")
+	wallet := CreateWalletFixture(t, ctx, dbConnectionPool, "wallet1", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+	disbursementModel := &DisbursementModel{dbConnectionPool: dbConnectionPool}
+	di := NewDisbursementInstructionModel(dbConnectionPool)
+
+	instructions := []*DisbursementInstruction{
+		{Phone: "+380-12-345-671", Amount: "100.01", ID: "123456781", VerificationValue: "1990-01-01"},
+		{Phone: "+380-12-345-672", Amount: "100.02", ID: "123456782", VerificationValue: "1990-01-02"},
+		{Phone: "+380-12-345-673", Amount: "100.03", ID: "123456783", VerificationValue: "1990-01-03"},
+	}
+
+	cleanup := func() {
+		DeleteAllPaymentsFixtures(t, ctx, dbConnectionPool)
+		DeleteAllReceiverVerificationFixtures(t, ctx, dbConnectionPool)
+		DeleteAllReceiverWalletsFixtures(t, ctx, dbConnectionPool)
+		DeleteAllReceiversFixtures(t, ctx, dbConnectionPool)
+	}
+
+	processChunk := func(t *testing.T, disbursement *Disbursement, chunk []*DisbursementInstruction, isFirstChunk, isLastChunk bool) (*DisbursementInstructionsChunkResult, error) {
+		dbTx, err := dbConnectionPool.BeginTxx(ctx, nil)
+		require.NoError(t, err)
+
+		result, err := di.ProcessChunk(ctx, dbTx, DisbursementInstructionsChunkOpts{
+			UserID:       "user-id",
+			Instructions: chunk,
+			Disbursement: disbursement,
+			DisbursementUpdate: &DisbursementUpdate{
+				ID:          disbursement.ID,
+				FileName:    "instructions.csv",
+				FileContent: CreateInstructionsFixture(t, instructions),
+			},
+			IsFirstChunk: isFirstChunk,
+			IsLastChunk:  isLastChunk,
+		})
+		if err != nil {
+			require.NoError(t, dbTx.Rollback())
+			return nil, err
+		}
+		require.NoError(t, dbTx.Commit())
+		return result, nil
+	}
+
+	t.Run("success - the chunks are persisted and the disbursement is ready after the last one", func(t *testing.T) {
+		defer cleanup()
+
+		disbursement := CreateDraftDisbursementFixture(t, ctx, dbConnectionPool, disbursementModel, Disbursement{
+			Name:   "chunked disbursement",
+			Asset:  asset,
+			Wallet: wallet,
+		})
+
+		result, err := processChunk(t, disbursement, instructions[:2], true, false)
+		require.NoError(t, err)
+		assert.Equal(t, &DisbursementInstructionsChunkResult{ReceiversCreated: 2, PaymentsCreated: 2}, result)
+
+		disbursement, err = disbursementModel.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, DraftDisbursementStatus, disbursement.Status)
+		assert.Empty(t, disbursement.FileName)
+		assert.ElementsMatch(t, []string{"100.01", "100.02"}, GetPaymentsByDisbursementID(t, ctx, dbConnectionPool, disbursement.ID))
+
+		result, err = processChunk(t, disbursement, instructions[2:], false, true)
+		require.NoError(t, err)
+		assert.Equal(t, &DisbursementInstructionsChunkResult{ReceiversCreated: 1, PaymentsCreated: 1}, result)
+
+		disbursement, err = disbursementModel.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ReadyDisbursementStatus, disbursement.Status)
+		assert.Equal(t, "instructions.csv", disbursement.FileName)
+		assert.ElementsMatch(t, []string{"100.01", "100.02", "100.03"}, GetPaymentsByDisbursementID(t, ctx, dbConnectionPool, disbursement.ID))
+	})
+
+	t.Run("success - the first chunk replaces the previous draft payments", func(t *testing.T) {
+		defer cleanup()
+
+		disbursement := CreateDraftDisbursementFixture(t, ctx, dbConnectionPool, disbursementModel, Disbursement{
+			Name:   "re-uploaded disbursement",
+			Asset:  asset,
+			Wallet: wallet,
+		})
+
+		_, err := processChunk(t, disbursement, instructions[:1], true, false)
+		require.NoError(t, err)
+
+		result, err := processChunk(t, disbursement, instructions[1:], true, false)
+		require.NoError(t, err)
+		assert.Equal(t, &DisbursementInstructionsChunkResult{ReceiversCreated: 2, PaymentsCreated: 2}, result)
+		assert.ElementsMatch(t, []string{"100.02", "100.03"}, GetPaymentsByDisbursementID(t, ctx, dbConnectionPool, disbursement.ID))
+	})
+
+	t.Run("failure - receiver verification mismatch", func(t *testing.T) {
+		defer cleanup()
+
+		disbursement := CreateDraftDisbursementFixture(t, ctx, dbConnectionPool, disbursementModel, Disbursement{
+			Name:   "mismatched disbursement",
+			Asset:  asset,
+			Wallet: wallet,
+		})
+
+		_, err := processChunk(t, disbursement, instructions[:1], true, false)
+		require.NoError(t, err)
+
+		receivers, err := di.receiverModel.GetByContacts(ctx, dbConnectionPool, instructions[0].Phone)
+		require.NoError(t, err)
+		require.Len(t, receivers, 1)
+		ConfirmVerificationForRecipient(t, ctx, dbConnectionPool, receivers[0].ID)
+
+		mismatchedInstruction := *instructions[0]
+		mismatchedInstruction.VerificationValue = "1990-01-09"
+		_, err = processChunk(t, disbursement, []*DisbursementInstruction{&mismatchedInstruction}, false, true)
+		assert.ErrorIs(t, err, ErrReceiverVerificationMismatch)
+	})
+}
+
 func assertEqualReceivers(t *testing.T, expectedPhones, expectedExternalIDs []string, actualReceivers []*Receiver) {
 	assert.Len(t, actualReceivers, len(expectedPhones))
 
@@ -635,3 +832,74 @@ func GetExternalPaymentIDsByDisbursementID(t *testing.T, ctx context.Context, db
 
 	return externalPaymentIDs
 }
+
+func Test_DisbursementInstructionModel_GetExistingReceivers(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, outerErr := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, outerErr)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+
+	wallet := CreateWalletFixture(t, ctx, dbConnectionPool, "wallet1", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+	disbursementModel := &DisbursementModel{dbConnectionPool: dbConnectionPool}
+	di := NewDisbursementInstructionModel(dbConnectionPool)
+
+	receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{PhoneNumber: "+380445555555"})
+	verification := CreateReceiverVerificationFixture(t, ctx, dbConnectionPool, ReceiverVerificationInsert{
+		ReceiverID:        receiver.ID,
+		VerificationField: VerificationTypeDateOfBirth,
+		VerificationValue: "1990-01-01",
+	})
+	receiverWallet := CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, RegisteredReceiversWalletStatus)
+
+	instructions := []*DisbursementInstruction{
+		{Phone: "+380445555555", Amount: "100", ID: "1", VerificationValue: "1990-01-01"},
+		{Phone: "+380445555556", Amount: "100", ID: "2", VerificationValue: "1990-01-01"},
+	}
+
+	t.Run("verifications are returned for disbursements without wallet addresses", func(t *testing.T) {
+		disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, disbursementModel, &Disbursement{
+			Wallet:            wallet,
+			VerificationField: VerificationTypeDateOfBirth,
+		})
+
+		existingReceivers, err := di.GetExistingReceivers(ctx, dbConnectionPool, instructions, disbursement)
+		require.NoError(t, err)
+		require.Len(t, existingReceivers, 1)
+
+		existingReceiver := existingReceivers["+380445555555"]
+		require.NotNil(t, existingReceiver)
+		assert.Equal(t, receiver.ID, existingReceiver.Receiver.ID)
+		require.NotNil(t, existingReceiver.Verification)
+		assert.Equal(t, verification.HashedValue, existingReceiver.Verification.HashedValue)
+		assert.Nil(t, existingReceiver.ReceiverWallet)
+	})
+
+	t.Run("receiver wallets are returned for disbursements with wallet addresses", func(t *testing.T) {
+		disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, disbursementModel, &Disbursement{
+			Wallet:                  wallet,
+			RegistrationContactType: RegistrationContactTypePhoneAndWalletAddress,
+		})
+
+		existingReceivers, err := di.GetExistingReceivers(ctx, dbConnectionPool, instructions, disbursement)
+		require.NoError(t, err)
+		require.Len(t, existingReceivers, 1)
+
+		existingReceiver := existingReceivers["+380445555555"]
+		require.NotNil(t, existingReceiver)
+		require.NotNil(t, existingReceiver.ReceiverWallet)
+		assert.Equal(t, receiverWallet.StellarAddress, existingReceiver.ReceiverWallet.StellarAddress)
+		assert.Nil(t, existingReceiver.Verification)
+	})
+
+	t.Run("nothing is returned when the receivers don't exist", func(t *testing.T) {
+		disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, disbursementModel, &Disbursement{Wallet: wallet})
+
+		existingReceivers, err := di.GetExistingReceivers(ctx, dbConnectionPool, instructions[1:], disbursement)
+		require.NoError(t, err)
+		assert.Empty(t, existingReceivers)
+	})
+}
@@ -0,0 +1,278 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+// DisbursementTemplate is a recurring disbursement, whose instructions are cloned into a new disbursement on each
+// occurrence of its cron Schedule.
+type DisbursementTemplate struct {
+	ID                                  string                           `json:"id" db:"id"`
+	Name                                string                           `json:"name" db:"name"`
+	Status                              DisbursementTemplateStatus       `json:"status" db:"status"`
+	Wallet                              *Wallet                          `json:"wallet,omitempty" db:"wallet"`
+	Asset                               *Asset                           `json:"asset,omitempty" db:"asset"`
+	VerificationField                   VerificationType                 `json:"verification_field,omitempty" db:"verification_field"`
+	VerificationQuestion                string                           `json:"verification_question,omitempty" db:"verification_question"`
+	RegistrationContactType             RegistrationContactType          `json:"registration_contact_type" db:"registration_contact_type"`
+	ReceiverRegistrationMessageTemplate string                           `json:"receiver_registration_message_template" db:"receiver_registration_message_template"`
+	Schedule                            string                           `json:"schedule" db:"schedule"`
+	DisbursementStatus                  DisbursementStatus               `json:"disbursement_status" db:"disbursement_status"`
+	Instructions                        DisbursementTemplateInstructions `json:"-" db:"instructions"`
+	TotalInstructions                   int                              `json:"total_instructions" db:"total_instructions"`
+	FileName                            string                           `json:"file_name,omitempty" db:"file_name"`
+	FileContent                         []byte                           `json:"-" db:"file_content"`
+	NextRunAt                           *time.Time                       `json:"next_run_at,omitempty" db:"next_run_at"`
+	LastRunAt                           *time.Time                       `json:"last_run_at,omitempty" db:"last_run_at"`
+	CreatedBy                           string                           `json:"created_by" db:"created_by"`
+	CreatedAt                           time.Time                        `json:"created_at" db:"created_at"`
+	UpdatedAt                           time.Time                        `json:"updated_at" db:"updated_at"`
+}
+
+type DisbursementTemplateStatus string
+
+const (
+	ActiveDisbursementTemplateStatus DisbursementTemplateStatus = "ACTIVE"
+	PausedDisbursementTemplateStatus DisbursementTemplateStatus = "PAUSED"
+)
+
+// ToDisbursementTemplateStatus converts a string to a DisbursementTemplateStatus.
+func ToDisbursementTemplateStatus(s string) (DisbursementTemplateStatus, error) {
+	status := DisbursementTemplateStatus(strings.ToUpper(strings.TrimSpace(s)))
+	switch status {
+	case ActiveDisbursementTemplateStatus, PausedDisbursementTemplateStatus:
+		return status, nil
+	default:
+		return "", fmt.Errorf("invalid disbursement template status %q", s)
+	}
+}
+
+// DisbursementTemplateInstructions is the instruction set stored in a template, persisted as JSON.
+type DisbursementTemplateInstructions []*DisbursementInstruction
+
+// Value implements the driver.Valuer interface.
+func (i DisbursementTemplateInstructions) Value() (driver.Value, error) {
+	if i == nil {
+		return nil, nil
+	}
+	instructionsJSON, err := json.Marshal([]*DisbursementInstruction(i))
+	if err != nil {
+		return nil, fmt.Errorf("marshaling disbursement template instructions: %w", err)
+	}
+	return string(instructionsJSON), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (i *DisbursementTemplateInstructions) Scan(src interface{}) error {
+	if src == nil {
+		*i = nil
+		return nil
+	}
+
+	srcBytes, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("unexpected type %T for disbursement template instructions", src)
+	}
+	if err := json.Unmarshal(srcBytes, (*[]*DisbursementInstruction)(i)); err != nil {
+		return fmt.Errorf("unmarshaling disbursement template instructions: %w", err)
+	}
+	return nil
+}
+
+type DisbursementTemplateInsert struct {
+	Name                                string
+	WalletID                            string
+	AssetID                             string
+	VerificationField                   VerificationType
+	VerificationQuestion                string
+	RegistrationContactType             RegistrationContactType
+	ReceiverRegistrationMessageTemplate string
+	Schedule                            string
+	DisbursementStatus                  DisbursementStatus
+	NextRunAt                           time.Time
+	CreatedBy                           string
+}
+
+// DisbursementTemplateUpdate holds the fields to update in a template. Zero-valued fields are left untouched.
+type DisbursementTemplateUpdate struct {
+	Name                                string                           `db:"name"`
+	Status                              DisbursementTemplateStatus       `db:"status"`
+	ReceiverRegistrationMessageTemplate string                           `db:"receiver_registration_message_template"`
+	Schedule                            string                           `db:"schedule"`
+	DisbursementStatus                  DisbursementStatus               `db:"disbursement_status"`
+	Instructions                        DisbursementTemplateInstructions `db:"instructions"`
+	FileName                            string                           `db:"file_name"`
+	FileContent                         []byte                           `db:"file_content"`
+	NextRunAt                           time.Time                        `db:"next_run_at"`
+	LastRunAt                           time.Time                        `db:"last_run_at"`
+}
+
+type DisbursementTemplateModel struct {
+	dbConnectionPool db.DBConnectionPool
+}
+
+var ErrDisbursementTemplateNameAlreadyExists = errors.New("a disbursement template with this name already exists")
+
+const selectDisbursementTemplateQuery = `
+		SELECT
+			dt.id,
+			dt.name,
+			dt.status,
+			COALESCE(dt.verification_field::text, '') as verification_field,
+			COALESCE(dt.verification_question, '') as verification_question,
+			dt.registration_contact_type,
+			COALESCE(dt.receiver_registration_message_template, '') as receiver_registration_message_template,
+			dt.schedule,
+			dt.disbursement_status,
+			dt.instructions,
+			COALESCE(jsonb_array_length(dt.instructions), 0) as total_instructions,
+			COALESCE(dt.file_name, '') as file_name,
+			dt.file_content,
+			dt.next_run_at,
+			dt.last_run_at,
+			dt.created_by,
+			dt.created_at,
+			dt.updated_at,
+			w.id as "wallet.id",
+			w.name as "wallet.name",
+			w.homepage as "wallet.homepage",
+			w.sep_10_client_domain as "wallet.sep_10_client_domain",
+			w.deep_link_schema as "wallet.deep_link_schema",
+			w.enabled as "wallet.enabled",
+			w.created_at as "wallet.created_at",
+			w.updated_at as "wallet.updated_at",
+			a.id as "asset.id",
+			a.code as "asset.code",
+			a.issuer as "asset.issuer",
+			a.created_at as "asset.created_at",
+			a.updated_at as "asset.updated_at"
+		FROM
+			disbursement_templates dt
+		JOIN wallets w on dt.wallet_id = w.id
+		JOIN assets a on dt.asset_id = a.id
+	`
+
+func (m *DisbursementTemplateModel) Insert(ctx context.Context, insert DisbursementTemplateInsert) (*DisbursementTemplate, error) {
+	const q = `
+		INSERT INTO
+			disbursement_templates (name, wallet_id, asset_id, verification_field, verification_question, registration_contact_type, receiver_registration_message_template, schedule, disbursement_status, next_run_at, created_by)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+	disbursementStatus := insert.DisbursementStatus
+	if disbursementStatus == "" {
+		disbursementStatus = DraftDisbursementStatus
+	}
+
+	var newID string
+	err := m.dbConnectionPool.GetContext(ctx, &newID, q,
+		insert.Name,
+		insert.WalletID,
+		insert.AssetID,
+		utils.SQLNullString(string(insert.VerificationField)),
+		utils.SQLNullString(insert.VerificationQuestion),
+		insert.RegistrationContactType,
+		utils.SQLNullString(insert.ReceiverRegistrationMessageTemplate),
+		insert.Schedule,
+		disbursementStatus,
+		insert.NextRunAt,
+		insert.CreatedBy,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "disbursement_templates_name_unique") {
+			return nil, ErrDisbursementTemplateNameAlreadyExists
+		}
+		return nil, fmt.Errorf("inserting disbursement template %s: %w", insert.Name, err)
+	}
+
+	return m.Get(ctx, m.dbConnectionPool, newID)
+}
+
+func (m *DisbursementTemplateModel) Get(ctx context.Context, sqlExec db.SQLExecuter, id string) (*DisbursementTemplate, error) {
+	var template DisbursementTemplate
+
+	query := fmt.Sprintf("%s %s", selectDisbursementTemplateQuery, "WHERE dt.id = $1")
+	err := sqlExec.GetContext(ctx, &template, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("querying disbursement template ID %s: %w", id, err)
+	}
+
+	return &template, nil
+}
+
+// GetAll returns all the disbursement templates, most recent first.
+func (m *DisbursementTemplateModel) GetAll(ctx context.Context, sqlExec db.SQLExecuter) ([]*DisbursementTemplate, error) {
+	templates := []*DisbursementTemplate{}
+
+	query := fmt.Sprintf("%s %s", selectDisbursementTemplateQuery, "ORDER BY dt.created_at DESC")
+	err := sqlExec.SelectContext(ctx, &templates, query)
+	if err != nil {
+		return nil, fmt.Errorf("querying disbursement templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// GetDue returns the active templates with instructions whose next run time is at or before now.
+func (m *DisbursementTemplateModel) GetDue(ctx context.Context, sqlExec db.SQLExecuter, now time.Time) ([]*DisbursementTemplate, error) {
+	templates := []*DisbursementTemplate{}
+
+	query := fmt.Sprintf("%s %s", selectDisbursementTemplateQuery, `
+		WHERE dt.status = $1
+			AND dt.next_run_at <= $2
+			AND dt.instructions IS NOT NULL
+		ORDER BY dt.next_run_at ASC
+	`)
+	err := sqlExec.SelectContext(ctx, &templates, query, ActiveDisbursementTemplateStatus, now)
+	if err != nil {
+		return nil, fmt.Errorf("querying due disbursement templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (m *DisbursementTemplateModel) Update(ctx context.Context, sqlExec db.SQLExecuter, id string, update DisbursementTemplateUpdate) (*DisbursementTemplate, error) {
+	setClause, params := BuildSetClause(update)
+	if setClause == "" {
+		return nil, fmt.Errorf("no fields to update: %w", ErrMissingInput)
+	}
+
+	query := sqlExec.Rebind(fmt.Sprintf(`
+		UPDATE
+			disbursement_templates
+		SET
+			%s
+		WHERE
+			id = ?
+		RETURNING id
+	`, setClause))
+	params = append(params, id)
+
+	var updatedID string
+	err := sqlExec.GetContext(ctx, &updatedID, query, params...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		if strings.Contains(err.Error(), "disbursement_templates_name_unique") {
+			return nil, ErrDisbursementTemplateNameAlreadyExists
+		}
+		return nil, fmt.Errorf("updating disbursement template ID %s: %w", id, err)
+	}
+
+	return m.Get(ctx, sqlExec, updatedID)
+}
@@ -0,0 +1,233 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+)
+
+func Test_ToDisbursementTemplateStatus(t *testing.T) {
+	status, err := ToDisbursementTemplateStatus("active")
+	require.NoError(t, err)
+	assert.Equal(t, ActiveDisbursementTemplateStatus, status)
+
+	status, err = ToDisbursementTemplateStatus(" PAUSED ")
+	require.NoError(t, err)
+	assert.Equal(t, PausedDisbursementTemplateStatus, status)
+
+	_, err = ToDisbursementTemplateStatus("DELETED")
+	assert.EqualError(t, err, `invalid disbursement template status "DELETED"`)
+}
+
+func Test_DisbursementTemplateModel_Insert(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	wallet := CreateDefaultWalletFixture(t, ctx, dbConnectionPool)
+	asset := GetAssetFixture(t, ctx, dbConnectionPool, FixtureAssetUSDC)
+	nextRunAt := time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	insert := DisbursementTemplateInsert{
+		Name:                                "monthly stipend",
+		WalletID:                            wallet.ID,
+		AssetID:                             asset.ID,
+		VerificationField:                   VerificationTypeDateOfBirth,
+		RegistrationContactType:             RegistrationContactTypeEmail,
+		ReceiverRegistrationMessageTemplate: "Hello!",
+		Schedule:                            "0 9 1 * *",
+		NextRunAt:                           nextRunAt,
+		CreatedBy:                           "user-id",
+	}
+
+	t.Run("🎉 inserts a template", func(t *testing.T) {
+		template, err := models.DisbursementTemplates.Insert(ctx, insert)
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, template.ID)
+		assert.Equal(t, "monthly stipend", template.Name)
+		assert.Equal(t, ActiveDisbursementTemplateStatus, template.Status)
+		assert.Equal(t, wallet.ID, template.Wallet.ID)
+		assert.Equal(t, asset.ID, template.Asset.ID)
+		assert.Equal(t, VerificationTypeDateOfBirth, template.VerificationField)
+		assert.Equal(t, RegistrationContactTypeEmail, template.RegistrationContactType)
+		assert.Equal(t, "Hello!", template.ReceiverRegistrationMessageTemplate)
+		assert.Equal(t, "0 9 1 * *", template.Schedule)
+		assert.Equal(t, DraftDisbursementStatus, template.DisbursementStatus)
+		assert.Nil(t, template.Instructions)
+		assert.Equal(t, 0, template.TotalInstructions)
+		require.NotNil(t, template.NextRunAt)
+		assert.True(t, nextRunAt.Equal(*template.NextRunAt))
+		assert.Nil(t, template.LastRunAt)
+		assert.Equal(t, "user-id", template.CreatedBy)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		template, err := models.DisbursementTemplates.Insert(ctx, insert)
+		assert.ErrorIs(t, err, ErrDisbursementTemplateNameAlreadyExists)
+		assert.Nil(t, template)
+	})
+}
+
+func Test_DisbursementTemplateModel_Get(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := models.DisbursementTemplates.Get(ctx, dbConnectionPool, "not-found")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("🎉 returns the template with its instructions", func(t *testing.T) {
+		instructions := DisbursementTemplateInstructions{
+			{Phone: "+380445555555", ID: "123456789", Amount: "100.5", VerificationValue: "1990-01-01"},
+			{Phone: "+380445555556", ID: "123456780", Amount: "50", VerificationValue: "1990-01-02"},
+		}
+		template := CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, DisbursementTemplateInsert{}, instructions)
+
+		got, err := models.DisbursementTemplates.Get(ctx, dbConnectionPool, template.ID)
+		require.NoError(t, err)
+		assert.Equal(t, instructions, got.Instructions)
+		assert.Equal(t, 2, got.TotalInstructions)
+		assert.Equal(t, "instructions.csv", got.FileName)
+		assert.NotEmpty(t, got.FileContent)
+	})
+}
+
+func Test_DisbursementTemplateModel_GetAll(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	templates, err := models.DisbursementTemplates.GetAll(ctx, dbConnectionPool)
+	require.NoError(t, err)
+	assert.Empty(t, templates)
+
+	template1 := CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, DisbursementTemplateInsert{Name: "template 1"}, nil)
+	template2 := CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, DisbursementTemplateInsert{Name: "template 2"}, nil)
+
+	templates, err = models.DisbursementTemplates.GetAll(ctx, dbConnectionPool)
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, template2.ID, templates[0].ID)
+	assert.Equal(t, template1.ID, templates[1].ID)
+}
+
+func Test_DisbursementTemplateModel_GetDue(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	now := time.Now()
+	instructions := DisbursementTemplateInstructions{{Phone: "+380445555555", ID: "1", Amount: "10", VerificationValue: "1990-01-01"}}
+
+	due := CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, DisbursementTemplateInsert{
+		NextRunAt: now.Add(-time.Minute),
+	}, instructions)
+	// not due yet
+	CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, DisbursementTemplateInsert{
+		NextRunAt: now.Add(time.Hour),
+	}, instructions)
+	// without instructions
+	CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, DisbursementTemplateInsert{
+		NextRunAt: now.Add(-time.Minute),
+	}, nil)
+	// paused
+	paused := CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, DisbursementTemplateInsert{
+		NextRunAt: now.Add(-time.Minute),
+	}, instructions)
+	_, err = models.DisbursementTemplates.Update(ctx, dbConnectionPool, paused.ID, DisbursementTemplateUpdate{Status: PausedDisbursementTemplateStatus})
+	require.NoError(t, err)
+
+	templates, err := models.DisbursementTemplates.GetDue(ctx, dbConnectionPool, now)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, due.ID, templates[0].ID)
+}
+
+func Test_DisbursementTemplateModel_Update(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	template := CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, DisbursementTemplateInsert{Name: "template"}, nil)
+
+	t.Run("no fields to update", func(t *testing.T) {
+		_, err := models.DisbursementTemplates.Update(ctx, dbConnectionPool, template.ID, DisbursementTemplateUpdate{})
+		assert.ErrorIs(t, err, ErrMissingInput)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := models.DisbursementTemplates.Update(ctx, dbConnectionPool, "not-found", DisbursementTemplateUpdate{Name: "new name"})
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, DisbursementTemplateInsert{Name: "other template"}, nil)
+		_, err := models.DisbursementTemplates.Update(ctx, dbConnectionPool, template.ID, DisbursementTemplateUpdate{Name: "other template"})
+		assert.ErrorIs(t, err, ErrDisbursementTemplateNameAlreadyExists)
+	})
+
+	t.Run("🎉 updates only the provided fields", func(t *testing.T) {
+		lastRunAt := time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC)
+		nextRunAt := time.Date(2030, time.February, 1, 9, 0, 0, 0, time.UTC)
+
+		updated, err := models.DisbursementTemplates.Update(ctx, dbConnectionPool, template.ID, DisbursementTemplateUpdate{
+			Schedule:           "0 9 1 * *",
+			DisbursementStatus: ReadyDisbursementStatus,
+			Status:             PausedDisbursementTemplateStatus,
+			LastRunAt:          lastRunAt,
+			NextRunAt:          nextRunAt,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "template", updated.Name)
+		assert.Equal(t, "0 9 1 * *", updated.Schedule)
+		assert.Equal(t, ReadyDisbursementStatus, updated.DisbursementStatus)
+		assert.Equal(t, PausedDisbursementTemplateStatus, updated.Status)
+		assert.True(t, lastRunAt.Equal(*updated.LastRunAt))
+		assert.True(t, nextRunAt.Equal(*updated.NextRunAt))
+	})
+}
@@ -24,6 +24,7 @@ type Disbursement struct {
 	Asset                               *Asset                    `json:"asset,omitempty" db:"asset"`
 	Status                              DisbursementStatus        `json:"status" db:"status"`
 	VerificationField                   VerificationType          `json:"verification_field,omitempty" db:"verification_field"`
+	VerificationQuestion                string                    `json:"verification_question,omitempty" db:"verification_question"`
 	StatusHistory                       DisbursementStatusHistory `json:"status_history,omitempty" csv:"-" db:"status_history"`
 	ReceiverRegistrationMessageTemplate string                    `json:"receiver_registration_message_template" csv:"-" db:"receiver_registration_message_template"`
 	FileName                            string                    `json:"file_name,omitempty" csv:"-" db:"file_name"`
@@ -31,6 +32,14 @@ type Disbursement struct {
 	CreatedAt                           time.Time                 `json:"created_at" db:"created_at"`
 	UpdatedAt                           time.Time                 `json:"updated_at" db:"updated_at"`
 	RegistrationContactType             RegistrationContactType   `json:"registration_contact_type,omitempty" db:"registration_contact_type"`
+	PayoutMode                          PayoutMode                `json:"payout_mode,omitempty" db:"payout_mode"`
+	// ReceiveAssetCode and ReceiveAssetIssuer are set when receivers should get a different asset than the disbursement
+	// asset, which is converted on-chain through path payments that spend up to MaxSlippageBps over the quoted price.
+	ReceiveAssetCode   string `json:"receive_asset_code,omitempty" db:"receive_asset_code"`
+	ReceiveAssetIssuer string `json:"receive_asset_issuer,omitempty" db:"receive_asset_issuer"`
+	MaxSlippageBps     int    `json:"max_slippage_bps,omitempty" db:"max_slippage_bps"`
+	// ScheduledStartAt is when a SCHEDULED disbursement will be started automatically.
+	ScheduledStartAt *time.Time `json:"scheduled_start_at,omitempty" db:"scheduled_start_at"`
 	*DisbursementStats
 }
 
@@ -57,7 +66,16 @@ type DisbursementStatusHistoryEntry struct {
 	UserID    string             `json:"user_id"`
 	Status    DisbursementStatus `json:"status"`
 	Timestamp time.Time          `json:"timestamp"`
+	// ApprovalDecision is set on the entries recording an approval or rejection, whose user is the reviewer rather than
+	// the user who created or started the disbursement.
+	ApprovalDecision DisbursementApprovalDecision `json:"approval_decision,omitempty"`
 }
+
+// IsApprovalDecision returns whether the entry records an approval or rejection of the disbursement.
+func (e DisbursementStatusHistoryEntry) IsApprovalDecision() bool {
+	return e.ApprovalDecision != ""
+}
+
 type DisbursementModel struct {
 	dbConnectionPool db.DBConnectionPool
 }
@@ -72,11 +90,16 @@ var (
 func (d *DisbursementModel) Insert(ctx context.Context, disbursement *Disbursement) (string, error) {
 	const q = `
 		INSERT INTO 
-		    disbursements (name, status, status_history, wallet_id, asset_id, verification_field, receiver_registration_message_template, registration_contact_type)
+		    disbursements (name, status, status_history, wallet_id, asset_id, verification_field, verification_question, receiver_registration_message_template, registration_contact_type, payout_mode, receive_asset_code, receive_asset_issuer, max_slippage_bps)
 		VALUES 
-		    ($1, $2, $3, $4, $5, $6, $7, $8)
+		    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 		RETURNING id
 	`
+	payoutMode := disbursement.PayoutMode
+	if payoutMode == "" {
+		payoutMode = PayoutModePayment
+	}
+
 	var newID string
 	err := d.dbConnectionPool.GetContext(ctx, &newID, q,
 		disbursement.Name,
@@ -85,8 +108,13 @@ func (d *DisbursementModel) Insert(ctx context.Context, disbursement *Disburseme
 		disbursement.Wallet.ID,
 		disbursement.Asset.ID,
 		utils.SQLNullString(string(disbursement.VerificationField)),
+		utils.SQLNullString(disbursement.VerificationQuestion),
 		disbursement.ReceiverRegistrationMessageTemplate,
 		disbursement.RegistrationContactType,
+		payoutMode,
+		utils.SQLNullString(disbursement.ReceiveAssetCode),
+		utils.SQLNullString(disbursement.ReceiveAssetIssuer),
+		disbursement.MaxSlippageBps,
 	)
 	if err != nil {
 		// check if the error is a duplicate key error
@@ -120,11 +148,17 @@ const selectDisbursementQuery = `
 			d.status,
 			d.status_history,
 			COALESCE(d.verification_field::text, '') as verification_field,
+			COALESCE(d.verification_question, '') as verification_question,
 			COALESCE(d.file_name, '') as file_name,
 			d.file_content,
 			d.created_at,
 			d.updated_at,
 			d.registration_contact_type,
+			d.payout_mode,
+			COALESCE(d.receive_asset_code, '') as receive_asset_code,
+			COALESCE(d.receive_asset_issuer, '') as receive_asset_issuer,
+			d.max_slippage_bps,
+			d.scheduled_start_at,
 			COALESCE(d.receiver_registration_message_template, '') as receiver_registration_message_template,
 			w.id as "wallet.id",
 			w.name as "wallet.name",
@@ -281,6 +315,55 @@ func (d *DisbursementModel) GetAll(ctx context.Context, sqlExec db.SQLExecuter,
 	return disbursements, nil
 }
 
+// disbursementStreamBatchSize is the number of disbursements read from the cursor before their statistics are populated
+// in StreamAll.
+const disbursementStreamBatchSize = 500
+
+// StreamAll runs the same query as GetAll with QueryTypeSelectAll, but calls fn with each disbursement as it's read from
+// the database cursor instead of loading all of them into memory. The statistics are populated in batches, so it
+// doesn't take one query per disbursement. It stops at the first error returned by fn.
+func (d *DisbursementModel) StreamAll(ctx context.Context, sqlExec db.SQLExecuter, queryParams *QueryParams, fn func(*Disbursement) error) error {
+	query, params := d.newDisbursementQuery(selectDisbursementQuery, queryParams, QueryTypeSelectAll)
+
+	rows, err := sqlExec.QueryxContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("error querying disbursements: %w", err)
+	}
+	defer db.CloseRows(ctx, rows)
+
+	batch := make([]*Disbursement, 0, disbursementStreamBatchSize)
+	handleBatch := func() error {
+		if err := d.populateStatistics(ctx, batch); err != nil {
+			return fmt.Errorf("error populating disbursement statistics: %w", err)
+		}
+		for _, disbursement := range batch {
+			if err := fn(disbursement); err != nil {
+				return fmt.Errorf("handling disbursement %s: %w", disbursement.ID, err)
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		disbursement := &Disbursement{}
+		if err = rows.StructScan(disbursement); err != nil {
+			return fmt.Errorf("error scanning disbursement: %w", err)
+		}
+		batch = append(batch, disbursement)
+		if len(batch) == disbursementStreamBatchSize {
+			if err = handleBatch(); err != nil {
+				return err
+			}
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating disbursements: %w", err)
+	}
+
+	return handleBatch()
+}
+
 // UpdateStatus updates the status of the given disbursement.
 func (d *DisbursementModel) UpdateStatus(ctx context.Context, sqlExec db.SQLExecuter, userID string, disbursementID string, targetStatus DisbursementStatus) error {
 	sourceStatuses := targetStatus.SourceStatuses()
@@ -318,6 +401,75 @@ func (d *DisbursementModel) UpdateStatus(ctx context.Context, sqlExec db.SQLExec
 	return nil
 }
 
+// RecordApprovalDecision appends the decision of a reviewer to the disbursement status history, moving the disbursement
+// from fromStatus to toStatus. Approvals keep the disbursement in its current status.
+func (d *DisbursementModel) RecordApprovalDecision(ctx context.Context, sqlExec db.SQLExecuter, userID, disbursementID string, decision DisbursementApprovalDecision, fromStatus, toStatus DisbursementStatus) error {
+	query := `
+		UPDATE
+			disbursements
+		SET
+			status = $1,
+			status_history = array_append(status_history, create_disbursement_status_history(NOW(), $1, $2) || jsonb_build_object('approval_decision', $3::text))
+		WHERE
+			id = $4 AND status = $5
+		`
+	result, err := sqlExec.ExecContext(ctx, query, toStatus, userID, decision, disbursementID, fromStatus)
+	if err != nil {
+		return fmt.Errorf("recording %s decision on disbursement %s: %w", decision, disbursementID, err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting number of rows affected: %w", err)
+	}
+	if numRowsAffected == 0 {
+		return fmt.Errorf("disbursement %s status was not updated from %s to %s: %w", disbursementID, fromStatus, toStatus, ErrRecordNotFound)
+	}
+
+	return nil
+}
+
+// UpdateScheduledStartAt sets the time when the disbursement will be started automatically. A nil scheduledStartAt
+// clears the schedule.
+func (d *DisbursementModel) UpdateScheduledStartAt(ctx context.Context, sqlExec db.SQLExecuter, disbursementID string, scheduledStartAt *time.Time) error {
+	query := `
+		UPDATE
+			disbursements
+		SET
+			scheduled_start_at = $1
+		WHERE
+			id = $2
+		`
+	result, err := sqlExec.ExecContext(ctx, query, scheduledStartAt, disbursementID)
+	if err != nil {
+		return fmt.Errorf("updating scheduled start time of disbursement %s: %w", disbursementID, err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting number of rows affected: %w", err)
+	}
+	if numRowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetDueScheduled returns the SCHEDULED disbursements whose scheduled start time is before or at the given time,
+// starting from the ones that are due for longer.
+func (d *DisbursementModel) GetDueScheduled(ctx context.Context, sqlExec db.SQLExecuter, now time.Time) ([]*Disbursement, error) {
+	disbursements := []*Disbursement{}
+
+	query := fmt.Sprintf("%s %s", selectDisbursementQuery, "WHERE d.status = $1 AND d.scheduled_start_at <= $2 ORDER BY d.scheduled_start_at ASC")
+	err := sqlExec.SelectContext(ctx, &disbursements, query, ScheduledDisbursementStatus, now)
+	if err != nil {
+		return nil, fmt.Errorf("querying due scheduled disbursements: %w", err)
+	}
+
+	return disbursements, nil
+}
+
 // newDisbursementQuery generates the full query and parameters for a disbursement search query
 func (d *DisbursementModel) newDisbursementQuery(baseQuery string, queryParams *QueryParams, queryType QueryType) (string, []interface{}) {
 	qb := NewQueryBuilder(baseQuery)
@@ -85,6 +85,36 @@ func Test_DisbursementStatus_TransitionTo(t *testing.T) {
 			target: StartedDisbursementStatus,
 			err:    nil,
 		},
+		{
+			name:   "approver rejects disbursement transition",
+			actual: ReadyDisbursementStatus,
+			target: DraftDisbursementStatus,
+			err:    nil,
+		},
+		{
+			name:   "approver rejects scheduled disbursement transition",
+			actual: ScheduledDisbursementStatus,
+			target: DraftDisbursementStatus,
+			err:    nil,
+		},
+		{
+			name:   "user schedules disbursement transition",
+			actual: ReadyDisbursementStatus,
+			target: ScheduledDisbursementStatus,
+			err:    nil,
+		},
+		{
+			name:   "user cancels the schedule transition",
+			actual: ScheduledDisbursementStatus,
+			target: ReadyDisbursementStatus,
+			err:    nil,
+		},
+		{
+			name:   "scheduled disbursement starts transition",
+			actual: ScheduledDisbursementStatus,
+			target: StartedDisbursementStatus,
+			err:    nil,
+		},
 		{
 			name:   "user pauses disbursement transition",
 			actual: StartedDisbursementStatus,
@@ -118,6 +148,12 @@ func Test_DisbursementStatus_TransitionTo(t *testing.T) {
 		{
 			name:   "invalid transition 3",
 			actual: DraftDisbursementStatus,
+			target: ScheduledDisbursementStatus,
+			err:    fmt.Errorf("cannot transition from DRAFT to SCHEDULED"),
+		},
+		{
+			name:   "invalid transition 4",
+			actual: DraftDisbursementStatus,
 			target: PausedDisbursementStatus,
 			err:    fmt.Errorf("cannot transition from DRAFT to PAUSED"),
 		},
@@ -144,17 +180,22 @@ func Test_DisbursementStatus_SourceStatuses(t *testing.T) {
 		{
 			name:                   "Draft",
 			targetStatus:           DraftDisbursementStatus,
-			expectedSourceStatuses: []DisbursementStatus{},
+			expectedSourceStatuses: []DisbursementStatus{ReadyDisbursementStatus, ScheduledDisbursementStatus},
 		},
 		{
 			name:                   "Ready",
 			targetStatus:           ReadyDisbursementStatus,
-			expectedSourceStatuses: []DisbursementStatus{DraftDisbursementStatus, ReadyDisbursementStatus},
+			expectedSourceStatuses: []DisbursementStatus{DraftDisbursementStatus, ReadyDisbursementStatus, ScheduledDisbursementStatus},
+		},
+		{
+			name:                   "Scheduled",
+			targetStatus:           ScheduledDisbursementStatus,
+			expectedSourceStatuses: []DisbursementStatus{ReadyDisbursementStatus},
 		},
 		{
 			name:                   "Started",
 			targetStatus:           StartedDisbursementStatus,
-			expectedSourceStatuses: []DisbursementStatus{ReadyDisbursementStatus, PausedDisbursementStatus},
+			expectedSourceStatuses: []DisbursementStatus{ReadyDisbursementStatus, ScheduledDisbursementStatus, PausedDisbursementStatus},
 		},
 		{
 			name:                   "Paused",
@@ -175,6 +216,6 @@ func Test_DisbursementStatus_SourceStatuses(t *testing.T) {
 }
 
 func Test_DisbursementStatus_DisbursementStatuses(t *testing.T) {
-	expectedStatuses := []DisbursementStatus{DraftDisbursementStatus, ReadyDisbursementStatus, StartedDisbursementStatus, PausedDisbursementStatus, CompletedDisbursementStatus}
+	expectedStatuses := []DisbursementStatus{DraftDisbursementStatus, ReadyDisbursementStatus, ScheduledDisbursementStatus, StartedDisbursementStatus, PausedDisbursementStatus, CompletedDisbursementStatus}
 	require.Equal(t, expectedStatuses, DisbursementStatuses())
 }
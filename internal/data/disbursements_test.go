@@ -772,3 +772,72 @@ func Test_DisbursementModel_Delete(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func Test_DisbursementModel_UpdateScheduledStartAt(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, outerErr := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, outerErr)
+	defer dbConnectionPool.Close()
+
+	models, outerErr := NewModels(dbConnectionPool)
+	require.NoError(t, outerErr)
+	ctx := context.Background()
+
+	t.Run("returns error when disbursement not found", func(t *testing.T) {
+		err := models.Disbursements.UpdateScheduledStartAt(ctx, dbConnectionPool, "non-existent-id", nil)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("🎉 sets and clears the scheduled start time", func(t *testing.T) {
+		disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{
+			Status: ReadyDisbursementStatus,
+		})
+		scheduledStartAt := time.Date(2030, 1, 2, 9, 0, 0, 0, time.UTC)
+
+		err := models.Disbursements.UpdateScheduledStartAt(ctx, dbConnectionPool, disbursement.ID, &scheduledStartAt)
+		require.NoError(t, err)
+		disbursement, err = models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		require.NotNil(t, disbursement.ScheduledStartAt)
+		assert.True(t, scheduledStartAt.Equal(*disbursement.ScheduledStartAt))
+
+		err = models.Disbursements.UpdateScheduledStartAt(ctx, dbConnectionPool, disbursement.ID, nil)
+		require.NoError(t, err)
+		disbursement, err = models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Nil(t, disbursement.ScheduledStartAt)
+	})
+}
+
+func Test_DisbursementModel_GetDueScheduled(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, outerErr := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, outerErr)
+	defer dbConnectionPool.Close()
+
+	models, outerErr := NewModels(dbConnectionPool)
+	require.NoError(t, outerErr)
+	ctx := context.Background()
+
+	now := time.Now()
+	createScheduledDisbursement := func(status DisbursementStatus, scheduledStartAt time.Time) *Disbursement {
+		return CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{
+			Status:           status,
+			ScheduledStartAt: &scheduledStartAt,
+		})
+	}
+	dueLater := createScheduledDisbursement(ScheduledDisbursementStatus, now.Add(-time.Minute))
+	dueEarlier := createScheduledDisbursement(ScheduledDisbursementStatus, now.Add(-time.Hour))
+	_ = createScheduledDisbursement(ScheduledDisbursementStatus, now.Add(time.Hour))
+	_ = createScheduledDisbursement(StartedDisbursementStatus, now.Add(-time.Hour))
+
+	disbursements, err := models.Disbursements.GetDueScheduled(ctx, dbConnectionPool, now)
+	require.NoError(t, err)
+	require.Len(t, disbursements, 2)
+	assert.Equal(t, dueEarlier.ID, disbursements[0].ID)
+	assert.Equal(t, dueLater.ID, disbursements[1].ID)
+}
@@ -327,18 +327,18 @@ func DeleteAllReceiversFixtures(t *testing.T, ctx context.Context, sqlExec db.SQ
 func CreateReceiverVerificationFixture(t *testing.T, ctx context.Context, sqlExec db.SQLExecuter, insert ReceiverVerificationInsert) *ReceiverVerification {
 	const query = `
 		INSERT INTO receiver_verifications
-			(receiver_id, verification_field, hashed_value)
+			(receiver_id, verification_field, hashed_value, question)
 		VALUES
-			($1, $2, $3)
+			($1, $2, $3, $4)
 		RETURNING
 			*
 	`
 
 	var verification ReceiverVerification
-	verificationValue, err := HashVerificationValue(insert.VerificationValue)
+	verificationValue, err := insert.VerificationField.HashValue(insert.VerificationValue)
 	require.NoError(t, err)
 
-	err = sqlExec.GetContext(ctx, &verification, query, insert.ReceiverID, insert.VerificationField, verificationValue)
+	err = sqlExec.GetContext(ctx, &verification, query, insert.ReceiverID, insert.VerificationField, verificationValue, utils.SQLNullString(insert.Question))
 	require.NoError(t, err)
 
 	return &verification
@@ -507,9 +507,10 @@ func CreatePaymentFixture(t *testing.T, ctx context.Context, sqlExec db.SQLExecu
 	const query = `
 		INSERT INTO payments
 			(receiver_id, disbursement_id, receiver_wallet_id, asset_id, amount, status, status_history,
-			stellar_transaction_id, stellar_operation_id, created_at, updated_at, external_payment_id)
+			stellar_transaction_id, stellar_operation_id, created_at, updated_at, external_payment_id,
+			claimable_balance_id, claimable_balance_status)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		RETURNING
 			id
 	`
@@ -527,6 +528,8 @@ func CreatePaymentFixture(t *testing.T, ctx context.Context, sqlExec db.SQLExecu
 		p.CreatedAt,
 		p.UpdatedAt,
 		p.ExternalPaymentID,
+		utils.SQLNullString(p.ClaimableBalanceID),
+		utils.SQLNullString(string(p.ClaimableBalanceStatus)),
 	)
 	require.NoError(t, err)
 
@@ -566,6 +569,9 @@ func CreateDisbursementFixture(t *testing.T, ctx context.Context, sqlExec db.SQL
 	if utils.IsEmpty(d.RegistrationContactType) {
 		d.RegistrationContactType = RegistrationContactTypePhone
 	}
+	if d.PayoutMode == "" {
+		d.PayoutMode = PayoutModePayment
+	}
 
 	// insert disbursement
 	if d.StatusHistory == nil {
@@ -577,9 +583,9 @@ func CreateDisbursementFixture(t *testing.T, ctx context.Context, sqlExec db.SQL
 
 	const q = `
 		INSERT INTO 
-		    disbursements (name, status, status_history, wallet_id, asset_id, verification_field, receiver_registration_message_template, registration_contact_type, created_at)
+		    disbursements (name, status, status_history, wallet_id, asset_id, verification_field, verification_question, receiver_registration_message_template, registration_contact_type, payout_mode, receive_asset_code, receive_asset_issuer, max_slippage_bps, scheduled_start_at, created_at)
 		VALUES 
-		    ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id
 	`
 	var newID string
@@ -590,8 +596,14 @@ func CreateDisbursementFixture(t *testing.T, ctx context.Context, sqlExec db.SQL
 		d.Wallet.ID,
 		d.Asset.ID,
 		utils.SQLNullString(string(d.VerificationField)),
+		utils.SQLNullString(d.VerificationQuestion),
 		d.ReceiverRegistrationMessageTemplate,
 		d.RegistrationContactType,
+		d.PayoutMode,
+		utils.SQLNullString(d.ReceiveAssetCode),
+		utils.SQLNullString(d.ReceiveAssetIssuer),
+		d.MaxSlippageBps,
+		d.ScheduledStartAt,
 		d.CreatedAt,
 	)
 	require.NoError(t, err)
@@ -673,6 +685,86 @@ func DeleteAllDisbursementFixtures(t *testing.T, ctx context.Context, sqlExec db
 	require.NoError(t, err)
 }
 
+func CreateDisbursementTemplateFixture(t *testing.T, ctx context.Context, sqlExec db.SQLExecuter, model *DisbursementTemplateModel, insert DisbursementTemplateInsert, instructions DisbursementTemplateInstructions) *DisbursementTemplate {
+	if insert.Name == "" {
+		randomName, err := utils.RandomString(10)
+		require.NoError(t, err)
+		insert.Name = randomName
+	}
+	if insert.WalletID == "" {
+		insert.WalletID = CreateDefaultWalletFixture(t, ctx, sqlExec).ID
+	}
+	if insert.AssetID == "" {
+		insert.AssetID = GetAssetFixture(t, ctx, sqlExec, FixtureAssetUSDC).ID
+	}
+	if insert.VerificationField == "" {
+		insert.VerificationField = VerificationTypeDateOfBirth
+	}
+	if utils.IsEmpty(insert.RegistrationContactType) {
+		insert.RegistrationContactType = RegistrationContactTypePhone
+	}
+	if insert.Schedule == "" {
+		insert.Schedule = "@monthly"
+	}
+	if insert.NextRunAt.IsZero() {
+		insert.NextRunAt = time.Now().Add(time.Hour)
+	}
+	if insert.CreatedBy == "" {
+		insert.CreatedBy = "user-id"
+	}
+
+	template, err := model.Insert(ctx, insert)
+	require.NoError(t, err)
+
+	if instructions != nil {
+		template, err = model.Update(ctx, sqlExec, template.ID, DisbursementTemplateUpdate{
+			Instructions: instructions,
+			FileName:     "instructions.csv",
+			FileContent:  CreateInstructionsFixture(t, instructions),
+		})
+		require.NoError(t, err)
+	}
+
+	return template
+}
+
+func DeleteAllDisbursementTemplateFixtures(t *testing.T, ctx context.Context, sqlExec db.SQLExecuter) {
+	const query = "DELETE FROM disbursement_templates"
+	_, err := sqlExec.ExecContext(ctx, query)
+	require.NoError(t, err)
+}
+
+func CreateWebhookSubscriptionFixture(t *testing.T, ctx context.Context, sqlExec db.SQLExecuter, model *WebhookSubscriptionModel, insert WebhookSubscriptionInsert) *WebhookSubscription {
+	if insert.URL == "" {
+		insert.URL = "https://example.com/webhooks"
+	}
+	if insert.EncryptedSecret == "" {
+		secret, err := utils.RandomString(32)
+		require.NoError(t, err)
+		insert.EncryptedSecret = secret
+	}
+	if insert.EncrypterPublicKey == "" {
+		insert.EncrypterPublicKey = "encrypter-public-key"
+	}
+	if len(insert.EventTypes) == 0 {
+		insert.EventTypes = AllWebhookEventTypes()
+	}
+	if insert.CreatedBy == "" {
+		insert.CreatedBy = "user-id"
+	}
+
+	subscription, err := model.Insert(ctx, sqlExec, insert)
+	require.NoError(t, err)
+	return subscription
+}
+
+// DeleteAllWebhookFixtures deletes all webhook subscriptions, along with their deliveries.
+func DeleteAllWebhookFixtures(t *testing.T, ctx context.Context, sqlExec db.SQLExecuter) {
+	const query = "DELETE FROM webhook_subscriptions"
+	_, err := sqlExec.ExecContext(ctx, query)
+	require.NoError(t, err)
+}
+
 func CreateMessageFixture(t *testing.T, ctx context.Context, sqlExec db.SQLExecuter, m *Message) *Message {
 	if m.TextEncrypted == "" {
 		m.TextEncrypted = "text encrypted"
@@ -0,0 +1,177 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+const (
+	// IdempotencyKeyTTL is how long an idempotency key is kept. After that, the key can be reused for a new request.
+	IdempotencyKeyTTL = 24 * time.Hour
+	// IdempotencyKeyLeaseDuration is how long a request can hold its idempotency key without storing its response. The
+	// keys of requests that never finished, e.g. because the server crashed, can be reused after that.
+	IdempotencyKeyLeaseDuration = 5 * time.Minute
+)
+
+// IdempotencyKey is a request sent with an `Idempotency-Key` header by a user, along with the response it got, so the
+// response can be replayed when the same request is retried.
+type IdempotencyKey struct {
+	ID                  string     `db:"id"`
+	Key                 string     `db:"idempotency_key"`
+	UserID              string     `db:"user_id"`
+	RequestMethod       string     `db:"request_method"`
+	RequestPath         string     `db:"request_path"`
+	RequestFingerprint  string     `db:"request_fingerprint"`
+	ResponseStatus      *int       `db:"response_status"`
+	ResponseContentType string     `db:"response_content_type"`
+	ResponseBody        []byte     `db:"response_body"`
+	CreatedAt           time.Time  `db:"created_at"`
+	CompletedAt         *time.Time `db:"completed_at"`
+}
+
+// IsCompleted returns whether the response of the request was stored. Keys that are not completed belong to requests
+// still being processed.
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.CompletedAt != nil
+}
+
+// IsExpired returns whether the key is older than IdempotencyKeyTTL.
+func (k *IdempotencyKey) IsExpired(now time.Time) bool {
+	return k.CreatedAt.Add(IdempotencyKeyTTL).Before(now)
+}
+
+// IsAbandoned returns whether the key wasn't completed within IdempotencyKeyLeaseDuration.
+func (k *IdempotencyKey) IsAbandoned(now time.Time) bool {
+	return !k.IsCompleted() && k.CreatedAt.Add(IdempotencyKeyLeaseDuration).Before(now)
+}
+
+// Matches returns whether the key was created for the same request.
+func (k *IdempotencyKey) Matches(method, path, fingerprint string) bool {
+	return k.RequestMethod == method && k.RequestPath == path && k.RequestFingerprint == fingerprint
+}
+
+type IdempotencyKeyInsert struct {
+	Key                string
+	UserID             string
+	RequestMethod      string
+	RequestPath        string
+	RequestFingerprint string
+}
+
+type IdempotencyKeyModel struct {
+	dbConnectionPool db.DBConnectionPool
+}
+
+const idempotencyKeyColumns = `
+	id,
+	idempotency_key,
+	user_id,
+	request_method,
+	request_path,
+	request_fingerprint,
+	response_status,
+	COALESCE(response_content_type, '') AS response_content_type,
+	response_body,
+	created_at,
+	completed_at
+`
+
+// Insert creates an idempotency key, returning ErrRecordAlreadyExists if the user already used the key.
+func (m *IdempotencyKeyModel) Insert(ctx context.Context, sqlExec db.SQLExecuter, insert IdempotencyKeyInsert) (*IdempotencyKey, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO
+			idempotency_keys (idempotency_key, user_id, request_method, request_path, request_fingerprint)
+		VALUES
+			($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, idempotency_key) DO NOTHING
+		RETURNING
+			%s
+	`, idempotencyKeyColumns)
+
+	var idempotencyKey IdempotencyKey
+	err := sqlExec.GetContext(ctx, &idempotencyKey, query, insert.Key, insert.UserID, insert.RequestMethod, insert.RequestPath, insert.RequestFingerprint)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordAlreadyExists
+		}
+		return nil, fmt.Errorf("inserting idempotency key for user %s: %w", insert.UserID, err)
+	}
+
+	return &idempotencyKey, nil
+}
+
+func (m *IdempotencyKeyModel) Get(ctx context.Context, sqlExec db.SQLExecuter, userID, key string) (*IdempotencyKey, error) {
+	query := fmt.Sprintf("SELECT %s FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2", idempotencyKeyColumns)
+
+	var idempotencyKey IdempotencyKey
+	err := sqlExec.GetContext(ctx, &idempotencyKey, query, userID, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("querying idempotency key for user %s: %w", userID, err)
+	}
+
+	return &idempotencyKey, nil
+}
+
+// Complete stores the response of the request made with the idempotency key.
+func (m *IdempotencyKeyModel) Complete(ctx context.Context, sqlExec db.SQLExecuter, id string, responseStatus int, responseContentType string, responseBody []byte) error {
+	const query = `
+		UPDATE
+			idempotency_keys
+		SET
+			response_status = $1,
+			response_content_type = $2,
+			response_body = $3,
+			completed_at = NOW()
+		WHERE
+			id = $4
+	`
+
+	result, err := sqlExec.ExecContext(ctx, query, responseStatus, utils.SQLNullString(responseContentType), responseBody, id)
+	if err != nil {
+		return fmt.Errorf("completing idempotency key %s: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting rows affected when completing idempotency key %s: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Delete removes an idempotency key, allowing it to be used again.
+func (m *IdempotencyKeyModel) Delete(ctx context.Context, sqlExec db.SQLExecuter, id string) error {
+	_, err := sqlExec.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("deleting idempotency key %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes the idempotency keys older than IdempotencyKeyTTL, returning how many were removed.
+func (m *IdempotencyKeyModel) DeleteExpired(ctx context.Context, sqlExec db.SQLExecuter, now time.Time) (int64, error) {
+	result, err := sqlExec.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE created_at < $1", now.Add(-IdempotencyKeyTTL))
+	if err != nil {
+		return 0, fmt.Errorf("deleting expired idempotency keys: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("getting rows affected when deleting expired idempotency keys: %w", err)
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,130 @@
+package data
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+)
+
+func Test_IdempotencyKey_IsExpired(t *testing.T) {
+	now := time.Now()
+
+	key := IdempotencyKey{CreatedAt: now.Add(-IdempotencyKeyTTL + time.Minute)}
+	assert.False(t, key.IsExpired(now))
+
+	key = IdempotencyKey{CreatedAt: now.Add(-IdempotencyKeyTTL - time.Minute)}
+	assert.True(t, key.IsExpired(now))
+}
+
+func Test_IdempotencyKey_IsAbandoned(t *testing.T) {
+	now := time.Now()
+
+	key := IdempotencyKey{CreatedAt: now.Add(-IdempotencyKeyLeaseDuration + time.Minute)}
+	assert.False(t, key.IsAbandoned(now))
+
+	key = IdempotencyKey{CreatedAt: now.Add(-IdempotencyKeyLeaseDuration - time.Minute)}
+	assert.True(t, key.IsAbandoned(now))
+
+	completedAt := now.Add(-IdempotencyKeyLeaseDuration)
+	key.CompletedAt = &completedAt
+	assert.False(t, key.IsAbandoned(now))
+}
+
+func Test_IdempotencyKey_Matches(t *testing.T) {
+	key := IdempotencyKey{RequestMethod: http.MethodPost, RequestPath: "/disbursements", RequestFingerprint: "fingerprint"}
+
+	assert.True(t, key.Matches(http.MethodPost, "/disbursements", "fingerprint"))
+	assert.False(t, key.Matches(http.MethodPatch, "/disbursements", "fingerprint"))
+	assert.False(t, key.Matches(http.MethodPost, "/payments/retry", "fingerprint"))
+	assert.False(t, key.Matches(http.MethodPost, "/disbursements", "another-fingerprint"))
+}
+
+func Test_IdempotencyKeyModel(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	insert := IdempotencyKeyInsert{
+		Key:                "key-1",
+		UserID:             "user-1",
+		RequestMethod:      http.MethodPost,
+		RequestPath:        "/disbursements",
+		RequestFingerprint: "fingerprint",
+	}
+
+	var idempotencyKey *IdempotencyKey
+	t.Run("🎉 inserts an idempotency key", func(t *testing.T) {
+		idempotencyKey, err = models.IdempotencyKeys.Insert(ctx, dbConnectionPool, insert)
+		require.NoError(t, err)
+		assert.NotEmpty(t, idempotencyKey.ID)
+		assert.Equal(t, "key-1", idempotencyKey.Key)
+		assert.Equal(t, "user-1", idempotencyKey.UserID)
+		assert.True(t, idempotencyKey.Matches(http.MethodPost, "/disbursements", "fingerprint"))
+		assert.False(t, idempotencyKey.IsCompleted())
+	})
+
+	t.Run("returns an error when the user already used the key", func(t *testing.T) {
+		_, err = models.IdempotencyKeys.Insert(ctx, dbConnectionPool, insert)
+		assert.ErrorIs(t, err, ErrRecordAlreadyExists)
+
+		// other users can use the same key
+		otherUserInsert := insert
+		otherUserInsert.UserID = "user-2"
+		_, err = models.IdempotencyKeys.Insert(ctx, dbConnectionPool, otherUserInsert)
+		require.NoError(t, err)
+	})
+
+	t.Run("🎉 completes an idempotency key", func(t *testing.T) {
+		err = models.IdempotencyKeys.Complete(ctx, dbConnectionPool, idempotencyKey.ID, http.StatusCreated, "application/json", []byte(`{"id":"1"}`))
+		require.NoError(t, err)
+
+		got, err := models.IdempotencyKeys.Get(ctx, dbConnectionPool, "user-1", "key-1")
+		require.NoError(t, err)
+		assert.True(t, got.IsCompleted())
+		require.NotNil(t, got.ResponseStatus)
+		assert.Equal(t, http.StatusCreated, *got.ResponseStatus)
+		assert.Equal(t, "application/json", got.ResponseContentType)
+		assert.Equal(t, []byte(`{"id":"1"}`), got.ResponseBody)
+
+		err = models.IdempotencyKeys.Complete(ctx, dbConnectionPool, "unknown-id", http.StatusOK, "", nil)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("🎉 deletes an idempotency key", func(t *testing.T) {
+		err = models.IdempotencyKeys.Delete(ctx, dbConnectionPool, idempotencyKey.ID)
+		require.NoError(t, err)
+
+		_, err = models.IdempotencyKeys.Get(ctx, dbConnectionPool, "user-1", "key-1")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("🎉 deletes the expired idempotency keys", func(t *testing.T) {
+		_, err = models.IdempotencyKeys.Get(ctx, dbConnectionPool, "user-2", "key-1")
+		require.NoError(t, err)
+
+		deleted, err := models.IdempotencyKeys.DeleteExpired(ctx, dbConnectionPool, time.Now())
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+
+		deleted, err = models.IdempotencyKeys.DeleteExpired(ctx, dbConnectionPool, time.Now().Add(IdempotencyKeyTTL+time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		_, err = models.IdempotencyKeys.Get(ctx, dbConnectionPool, "user-2", "key-1")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
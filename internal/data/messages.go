@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/lib/pq"
 
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
@@ -18,10 +20,29 @@ type MessageStatus string
 
 var (
 	PendingMessageStatus MessageStatus = "PENDING"
+	// SuccessMessageStatus means the provider accepted to send the message.
 	SuccessMessageStatus MessageStatus = "SUCCESS"
 	FailureMessageStatus MessageStatus = "FAILURE"
+	// DeliveredMessageStatus and BouncedMessageStatus are reported by the provider after it tried to deliver the
+	// message. The messages the provider couldn't deliver for other reasons are set to FailureMessageStatus.
+	DeliveredMessageStatus MessageStatus = "DELIVERED"
+	BouncedMessageStatus   MessageStatus = "BOUNCED"
 )
 
+// MessageStatusFromDeliveryStatus returns the message status of the delivery status reported by the provider.
+func MessageStatusFromDeliveryStatus(deliveryStatus message.DeliveryStatus) (MessageStatus, error) {
+	switch deliveryStatus {
+	case message.DeliveryStatusDelivered:
+		return DeliveredMessageStatus, nil
+	case message.DeliveryStatusBounced:
+		return BouncedMessageStatus, nil
+	case message.DeliveryStatusFailed:
+		return FailureMessageStatus, nil
+	default:
+		return "", fmt.Errorf("unknown delivery status %q", deliveryStatus)
+	}
+}
+
 type MessageModel struct {
 	dbConnectionPool db.DBConnectionPool
 }
@@ -37,11 +58,16 @@ type Message struct {
 	TitleEncrypted   string                `db:"title_encrypted"`
 	Status           MessageStatus         `db:"status"`
 	StatusHistory    MessageStatusHistory  `db:"status_history"`
-	CreatedAt        time.Time             `db:"created_at"`
-	UpdatedAt        time.Time             `db:"updated_at"`
+	ErrorCode        *string               `db:"error_code"`
+	// FallbackRequestedAt is set while the undelivered invitation waits to be resent through a fallback channel.
+	FallbackRequestedAt *time.Time `db:"fallback_requested_at"`
+	CreatedAt           time.Time  `db:"created_at"`
+	UpdatedAt           time.Time  `db:"updated_at"`
 }
 
 type MessageInsert struct {
+	// ID is optional, and is generated when empty. It's set beforehand when the message ID is passed to the provider.
+	ID               string
 	Type             message.MessengerType
 	AssetID          *string
 	ReceiverID       string
@@ -98,16 +124,16 @@ func (m *MessageModel) Insert(ctx context.Context, newMsg *MessageInsert) (*Mess
 	const query = `
 		INSERT INTO messages
 			(
-				type, asset_id, receiver_id, wallet_id, receiver_wallet_id,
+				id, type, asset_id, receiver_id, wallet_id, receiver_wallet_id,
 				text_encrypted, title_encrypted, status, status_history
 			)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			(COALESCE(NULLIF($1, ''), public.uuid_generate_v4()::text), $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING
 			*
 	`
 	var msg Message
-	err := m.dbConnectionPool.GetContext(ctx, &msg, query, newMsg.Type, newMsg.AssetID, newMsg.ReceiverID, newMsg.WalletID, newMsg.ReceiverWalletID, newMsg.TextEncrypted, newMsg.TitleEncrypted, newMsg.Status, newMsg.StatusHistory)
+	err := m.dbConnectionPool.GetContext(ctx, &msg, query, newMsg.ID, newMsg.Type, newMsg.AssetID, newMsg.ReceiverID, newMsg.WalletID, newMsg.ReceiverWalletID, newMsg.TextEncrypted, newMsg.TitleEncrypted, newMsg.Status, newMsg.StatusHistory)
 	if err != nil {
 		return nil, fmt.Errorf("error inserting message: %w", err)
 	}
@@ -117,12 +143,17 @@ func (m *MessageModel) Insert(ctx context.Context, newMsg *MessageInsert) (*Mess
 
 func (m *MessageModel) BulkInsert(ctx context.Context, sqlExec db.SQLExecuter, newMsgs []*MessageInsert) error {
 	var (
-		types, receiverIDs, walletIDs             pq.StringArray
+		ids, types, receiverIDs, walletIDs        pq.StringArray
 		encryptedTexts, encryptedTitles, statuses pq.StringArray
 		assetIDs, receiverWalletIDs               []sql.NullString
 	)
 
 	for _, newMsg := range newMsgs {
+		id := newMsg.ID
+		if id == "" {
+			id = uuid.NewString()
+		}
+		ids = append(ids, id)
 		types = append(types, string(newMsg.Type))
 
 		assetID := ""
@@ -155,18 +186,18 @@ func (m *MessageModel) BulkInsert(ctx context.Context, sqlExec db.SQLExecuter, n
 		INSERT INTO messages
 			(
 				type, asset_id, receiver_id, wallet_id, receiver_wallet_id,
-				text_encrypted, title_encrypted, status
+				text_encrypted, title_encrypted, status, id
 			)
 		SELECT
 			UNNEST($1::message_type[]) AS type, UNNEST($2::text[]) AS asset_id, UNNEST($3::text[]) AS receiver_id, UNNEST($4::text[]) AS wallet_id,
 			UNNEST($5::text[]) AS receiver_wallet_id, UNNEST($6::text[]) AS text_encrypted, UNNEST($7::text[]) AS title_encrypted,
-			UNNEST($8::message_status[]) AS status
+			UNNEST($8::message_status[]) AS status, UNNEST($9::text[]) AS id
 		RETURNING
 			id
 	`
 
 	var newMsgIDs []string
-	err := sqlExec.SelectContext(ctx, &newMsgIDs, insertQuery, types, pq.Array(assetIDs), receiverIDs, walletIDs, pq.Array(receiverWalletIDs), encryptedTexts, encryptedTitles, statuses)
+	err := sqlExec.SelectContext(ctx, &newMsgIDs, insertQuery, types, pq.Array(assetIDs), receiverIDs, walletIDs, pq.Array(receiverWalletIDs), encryptedTexts, encryptedTitles, statuses, ids)
 	if err != nil {
 		return fmt.Errorf("error inserting messages in BulkInsert: %w", err)
 	}
@@ -188,3 +219,116 @@ func (m *MessageModel) BulkInsert(ctx context.Context, sqlExec db.SQLExecuter, n
 
 	return nil
 }
+
+// UpdateSendResult sets the messenger type the message was sent through and the status of the attempt, for a message
+// stored as PENDING before it was sent. The status is kept if the provider already reported the delivery status, which
+// can happen before the send returns.
+func (m *MessageModel) UpdateSendResult(ctx context.Context, sqlExec db.SQLExecuter, messageID string, messengerType message.MessengerType, status MessageStatus) error {
+	const query = `
+		UPDATE
+			messages
+		SET
+			type = $2::message_type,
+			status = CASE WHEN status = 'PENDING'::message_status THEN $3::message_status ELSE status END,
+			status_history = CASE
+				WHEN status = 'PENDING'::message_status THEN array_append(status_history, create_message_status_history(NOW(), $3::message_status, NULL))
+				ELSE status_history
+			END
+		WHERE
+			id = $1
+	`
+
+	result, err := sqlExec.ExecContext(ctx, query, messageID, messengerType, status)
+	if err != nil {
+		return fmt.Errorf("updating the send result of message %s: %w", messageID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting the rows affected by the update of message %s: %w", messageID, err)
+	}
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// UpdateDeliveryStatus sets the delivery status the provider reported for a message it had accepted to send, along with
+// the provider's error code and message when it couldn't be delivered. The undelivered invitations are queued to be
+// resent through a fallback channel in the same statement, so the fallback isn't lost if it can't be sent right away. Only the messages in the SUCCESS status, or still
+// PENDING because the provider reported the status before the send returned, are updated, so a status reported more
+// than once is only applied once. It returns ErrRecordNotFound otherwise.
+func (m *MessageModel) UpdateDeliveryStatus(ctx context.Context, sqlExec db.SQLExecuter, messageID string, status MessageStatus, errorCode, statusMessage string) (*Message, error) {
+	const query = `
+		UPDATE
+			messages
+		SET
+			status = $2::message_status,
+			error_code = NULLIF($3::text, ''),
+			status_history = array_append(status_history, create_message_status_history(NOW(), $2::message_status, NULLIF($4::text, ''))),
+			fallback_requested_at = CASE
+				WHEN $2::message_status IN ('FAILURE'::message_status, 'BOUNCED'::message_status)
+					AND receiver_wallet_id IS NOT NULL AND asset_id IS NOT NULL THEN NOW()
+			END
+		WHERE
+			id = $1
+			AND status IN ('PENDING'::message_status, 'SUCCESS'::message_status)
+		RETURNING
+			*
+	`
+
+	var msg Message
+	err := sqlExec.GetContext(ctx, &msg, query, messageID, status, errorCode, statusMessage)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("updating the delivery status of message %s: %w", messageID, err)
+	}
+
+	return &msg, nil
+}
+
+// ClaimFallbackRequests claims the undelivered invitations queued to be resent through a fallback channel at or before
+// now, oldest first. Claimed messages are postponed until claimedUntil, so they're not picked up again while they're
+// being resent, and are retried after that if their fallback is never completed. Messages locked by another
+// transaction are skipped.
+func (m *MessageModel) ClaimFallbackRequests(ctx context.Context, sqlExec db.SQLExecuter, now, claimedUntil time.Time, limit int) ([]*Message, error) {
+	const query = `
+		UPDATE
+			messages
+		SET
+			fallback_requested_at = $2
+		WHERE
+			id IN (
+				SELECT id
+				FROM messages
+				WHERE fallback_requested_at <= $1
+				ORDER BY fallback_requested_at ASC
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING
+			*
+	`
+
+	msgs := []*Message{}
+	err := sqlExec.SelectContext(ctx, &msgs, query, now, claimedUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("claiming message fallback requests: %w", err)
+	}
+
+	return msgs, nil
+}
+
+// CompleteFallbackRequest removes the message from the fallback queue once it was resent.
+func (m *MessageModel) CompleteFallbackRequest(ctx context.Context, sqlExec db.SQLExecuter, messageID string) error {
+	const query = "UPDATE messages SET fallback_requested_at = NULL WHERE id = $1"
+
+	if _, err := sqlExec.ExecContext(ctx, query, messageID); err != nil {
+		return fmt.Errorf("completing the fallback request of message %s: %w", messageID, err)
+	}
+
+	return nil
+}
@@ -171,3 +171,227 @@ func Test_MessageModel_BulkInsert(t *testing.T) {
 		assert.Equal(t, SuccessMessageStatus, messages[2].StatusHistory[1].Status)
 	})
 }
+
+func Test_MessageStatusFromDeliveryStatus(t *testing.T) {
+	testCases := []struct {
+		deliveryStatus message.DeliveryStatus
+		wantStatus     MessageStatus
+		wantErr        string
+	}{
+		{deliveryStatus: message.DeliveryStatusDelivered, wantStatus: DeliveredMessageStatus},
+		{deliveryStatus: message.DeliveryStatusBounced, wantStatus: BouncedMessageStatus},
+		{deliveryStatus: message.DeliveryStatusFailed, wantStatus: FailureMessageStatus},
+		{deliveryStatus: "UNKNOWN", wantErr: `unknown delivery status "UNKNOWN"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.deliveryStatus), func(t *testing.T) {
+			status, err := MessageStatusFromDeliveryStatus(tc.deliveryStatus)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantStatus, status)
+		})
+	}
+}
+
+func Test_MessageModel_UpdateDeliveryStatus(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	mm := &MessageModel{dbConnectionPool: dbConnectionPool}
+
+	receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	wallet := CreateWalletFixture(t, ctx, dbConnectionPool, "wallet", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+
+	t.Run("returns an error if the message doesn't exist", func(t *testing.T) {
+		msg, err := mm.UpdateDeliveryStatus(ctx, dbConnectionPool, "unknown-id", DeliveredMessageStatus, "", "")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+		assert.Nil(t, msg)
+	})
+
+	t.Run("returns an error if the message wasn't sent successfully", func(t *testing.T) {
+		defer DeleteAllMessagesFixtures(t, ctx, dbConnectionPool)
+
+		failedMsg := CreateMessageFixture(t, ctx, dbConnectionPool, &Message{
+			Type:       message.MessengerTypeTwilioSMS,
+			ReceiverID: receiver.ID,
+			WalletID:   wallet.ID,
+			Status:     FailureMessageStatus,
+		})
+
+		msg, err := mm.UpdateDeliveryStatus(ctx, dbConnectionPool, failedMsg.ID, DeliveredMessageStatus, "", "")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+		assert.Nil(t, msg)
+	})
+
+	t.Run("🎉 updates the delivery status reported before the send returned", func(t *testing.T) {
+		defer DeleteAllMessagesFixtures(t, ctx, dbConnectionPool)
+
+		pendingMsg := CreateMessageFixture(t, ctx, dbConnectionPool, &Message{
+			Type:       message.MessengerTypeTwilioSMS,
+			ReceiverID: receiver.ID,
+			WalletID:   wallet.ID,
+			Status:     PendingMessageStatus,
+		})
+
+		msg, err := mm.UpdateDeliveryStatus(ctx, dbConnectionPool, pendingMsg.ID, DeliveredMessageStatus, "", "")
+		require.NoError(t, err)
+		assert.Equal(t, DeliveredMessageStatus, msg.Status)
+
+		err = mm.UpdateSendResult(ctx, dbConnectionPool, pendingMsg.ID, message.MessengerTypeTwilioWhatsApp, SuccessMessageStatus)
+		require.NoError(t, err)
+
+		var msgDB Message
+		err = dbConnectionPool.GetContext(ctx, &msgDB, "SELECT * FROM messages WHERE id = $1", pendingMsg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, DeliveredMessageStatus, msgDB.Status)
+		assert.Equal(t, message.MessengerTypeTwilioWhatsApp, msgDB.Type)
+	})
+
+	t.Run("🎉 updates the delivery status only once", func(t *testing.T) {
+		defer DeleteAllMessagesFixtures(t, ctx, dbConnectionPool)
+
+		sentMsg := CreateMessageFixture(t, ctx, dbConnectionPool, &Message{
+			Type:       message.MessengerTypeAWSEmail,
+			ReceiverID: receiver.ID,
+			WalletID:   wallet.ID,
+			Status:     SuccessMessageStatus,
+		})
+
+		msg, err := mm.UpdateDeliveryStatus(ctx, dbConnectionPool, sentMsg.ID, BouncedMessageStatus, "Permanent/General", "smtp; 550 5.1.1 user unknown")
+		require.NoError(t, err)
+
+		assert.Equal(t, BouncedMessageStatus, msg.Status)
+		assert.Nil(t, msg.FallbackRequestedAt, "only the invitations are resent through a fallback channel")
+		require.NotNil(t, msg.ErrorCode)
+		assert.Equal(t, "Permanent/General", *msg.ErrorCode)
+		require.Len(t, msg.StatusHistory, len(sentMsg.StatusHistory)+1)
+		lastEntry := msg.StatusHistory[len(msg.StatusHistory)-1]
+		assert.Equal(t, BouncedMessageStatus, lastEntry.Status)
+		require.NotNil(t, lastEntry.StatusMessage)
+		assert.Equal(t, "smtp; 550 5.1.1 user unknown", *lastEntry.StatusMessage)
+
+		msg, err = mm.UpdateDeliveryStatus(ctx, dbConnectionPool, sentMsg.ID, DeliveredMessageStatus, "", "")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+		assert.Nil(t, msg)
+	})
+}
+
+func Test_MessageModel_UpdateSendResult(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	mm := &MessageModel{dbConnectionPool: dbConnectionPool}
+
+	receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	wallet := CreateWalletFixture(t, ctx, dbConnectionPool, "wallet", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+
+	t.Run("returns an error if the message doesn't exist", func(t *testing.T) {
+		err := mm.UpdateSendResult(ctx, dbConnectionPool, "unknown-id", message.MessengerTypeTwilioSMS, SuccessMessageStatus)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("🎉 updates the pending message", func(t *testing.T) {
+		defer DeleteAllMessagesFixtures(t, ctx, dbConnectionPool)
+
+		pendingMsg := CreateMessageFixture(t, ctx, dbConnectionPool, &Message{
+			Type:       message.MessengerTypeTwilioSMS,
+			ReceiverID: receiver.ID,
+			WalletID:   wallet.ID,
+			Status:     PendingMessageStatus,
+		})
+
+		err := mm.UpdateSendResult(ctx, dbConnectionPool, pendingMsg.ID, message.MessengerTypeAWSEmail, FailureMessageStatus)
+		require.NoError(t, err)
+
+		var msg Message
+		err = dbConnectionPool.GetContext(ctx, &msg, "SELECT * FROM messages WHERE id = $1", pendingMsg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, message.MessengerTypeAWSEmail, msg.Type)
+		assert.Equal(t, FailureMessageStatus, msg.Status)
+		require.Len(t, msg.StatusHistory, len(pendingMsg.StatusHistory)+1)
+		assert.Equal(t, FailureMessageStatus, msg.StatusHistory[len(msg.StatusHistory)-1].Status)
+	})
+}
+
+func Test_MessageModel_FallbackRequests(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	mm := &MessageModel{dbConnectionPool: dbConnectionPool}
+
+	receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	wallet := CreateWalletFixture(t, ctx, dbConnectionPool, "wallet", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+	asset := CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GABC65XJDMXTGPNZRCI6V3KOKKWVK55UEKGQLONRIVYPMEJNNQ45YOEE")
+	receiverWallet := CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, ReadyReceiversWalletStatus)
+
+	createSentInvitation := func(t *testing.T) *Message {
+		return CreateMessageFixture(t, ctx, dbConnectionPool, &Message{
+			Type:             message.MessengerTypeTwilioSMS,
+			AssetID:          &asset.ID,
+			ReceiverID:       receiver.ID,
+			WalletID:         wallet.ID,
+			ReceiverWalletID: &receiverWallet.ID,
+			Status:           SuccessMessageStatus,
+		})
+	}
+
+	t.Run("🎉 delivered invitations are not queued", func(t *testing.T) {
+		defer DeleteAllMessagesFixtures(t, ctx, dbConnectionPool)
+		sentMsg := createSentInvitation(t)
+
+		msg, err := mm.UpdateDeliveryStatus(ctx, dbConnectionPool, sentMsg.ID, DeliveredMessageStatus, "", "")
+		require.NoError(t, err)
+		assert.Nil(t, msg.FallbackRequestedAt)
+
+		msgs, err := mm.ClaimFallbackRequests(ctx, dbConnectionPool, time.Now(), time.Now().Add(time.Minute), 10)
+		require.NoError(t, err)
+		assert.Empty(t, msgs)
+	})
+
+	t.Run("🎉 undelivered invitations are claimed until their fallback is completed", func(t *testing.T) {
+		defer DeleteAllMessagesFixtures(t, ctx, dbConnectionPool)
+		sentMsg := createSentInvitation(t)
+
+		msg, err := mm.UpdateDeliveryStatus(ctx, dbConnectionPool, sentMsg.ID, FailureMessageStatus, "30003", "Unreachable destination handset")
+		require.NoError(t, err)
+		require.NotNil(t, msg.FallbackRequestedAt)
+
+		now := time.Now()
+		msgs, err := mm.ClaimFallbackRequests(ctx, dbConnectionPool, now, now.Add(time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		assert.Equal(t, sentMsg.ID, msgs[0].ID)
+
+		// Claimed messages are not claimed again until the claim expires.
+		msgs, err = mm.ClaimFallbackRequests(ctx, dbConnectionPool, now, now.Add(time.Minute), 10)
+		require.NoError(t, err)
+		assert.Empty(t, msgs)
+
+		msgs, err = mm.ClaimFallbackRequests(ctx, dbConnectionPool, now.Add(2*time.Minute), now.Add(3*time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+
+		err = mm.CompleteFallbackRequest(ctx, dbConnectionPool, sentMsg.ID)
+		require.NoError(t, err)
+
+		msgs, err = mm.ClaimFallbackRequests(ctx, dbConnectionPool, now.Add(time.Hour), now.Add(2*time.Hour), 10)
+		require.NoError(t, err)
+		assert.Empty(t, msgs)
+	})
+}
@@ -14,21 +14,30 @@ var (
 )
 
 type Models struct {
-	Disbursements            *DisbursementModel
-	Wallets                  *WalletModel
-	Assets                   *AssetModel
-	Organizations            *OrganizationModel
-	Payment                  *PaymentModel
-	Receiver                 *ReceiverModel
-	DisbursementInstructions *DisbursementInstructionModel
-	ReceiverVerification     *ReceiverVerificationModel
-	ReceiverWallet           *ReceiverWalletModel
-	DisbursementReceivers    *DisbursementReceiverModel
-	Message                  *MessageModel
-	CircleTransferRequests   *CircleTransferRequestModel
-	CircleRecipient          *CircleRecipientModel
-	URLShortener             *URLShortenerModel
-	DBConnectionPool         db.DBConnectionPool
+	Disbursements                  *DisbursementModel
+	DisbursementTemplates          *DisbursementTemplateModel
+	DisbursementApprovals          *DisbursementApprovalModel
+	Wallets                        *WalletModel
+	Assets                         *AssetModel
+	Organizations                  *OrganizationModel
+	Payment                        *PaymentModel
+	Receiver                       *ReceiverModel
+	DisbursementInstructions       *DisbursementInstructionModel
+	ReceiverVerification           *ReceiverVerificationModel
+	ReceiverWallet                 *ReceiverWalletModel
+	DisbursementReceivers          *DisbursementReceiverModel
+	Message                        *MessageModel
+	CircleTransferRequests         *CircleTransferRequestModel
+	CircleRecipient                *CircleRecipientModel
+	URLShortener                   *URLShortenerModel
+	WebhookSubscriptions           *WebhookSubscriptionModel
+	WebhookDeliveries              *WebhookDeliveryModel
+	IdempotencyKeys                *IdempotencyKeyModel
+	DisbursementInstructionUploads *DisbursementInstructionUploadModel
+	PaymentCorrections             *PaymentCorrectionModel
+	ReceiverPortalOTPs             *ReceiverPortalOTPModel
+	RegistrationRateLimits         *RegistrationRateLimitModel
+	DBConnectionPool               db.DBConnectionPool
 }
 
 func NewModels(dbConnectionPool db.DBConnectionPool) (*Models, error) {
@@ -36,20 +45,29 @@ func NewModels(dbConnectionPool db.DBConnectionPool) (*Models, error) {
 		return nil, errors.New("dbConnectionPool is required for NewModels")
 	}
 	return &Models{
-		Disbursements:            &DisbursementModel{dbConnectionPool: dbConnectionPool},
-		Wallets:                  &WalletModel{dbConnectionPool: dbConnectionPool},
-		Assets:                   &AssetModel{dbConnectionPool: dbConnectionPool},
-		Organizations:            &OrganizationModel{dbConnectionPool: dbConnectionPool},
-		Payment:                  &PaymentModel{dbConnectionPool: dbConnectionPool},
-		Receiver:                 &ReceiverModel{},
-		DisbursementInstructions: NewDisbursementInstructionModel(dbConnectionPool),
-		ReceiverVerification:     &ReceiverVerificationModel{dbConnectionPool: dbConnectionPool},
-		ReceiverWallet:           &ReceiverWalletModel{dbConnectionPool: dbConnectionPool},
-		DisbursementReceivers:    &DisbursementReceiverModel{dbConnectionPool: dbConnectionPool},
-		Message:                  &MessageModel{dbConnectionPool: dbConnectionPool},
-		CircleTransferRequests:   &CircleTransferRequestModel{dbConnectionPool: dbConnectionPool},
-		CircleRecipient:          &CircleRecipientModel{dbConnectionPool: dbConnectionPool},
-		URLShortener:             NewURLShortenerModel(dbConnectionPool),
-		DBConnectionPool:         dbConnectionPool,
+		Disbursements:                  &DisbursementModel{dbConnectionPool: dbConnectionPool},
+		DisbursementTemplates:          &DisbursementTemplateModel{dbConnectionPool: dbConnectionPool},
+		DisbursementApprovals:          &DisbursementApprovalModel{dbConnectionPool: dbConnectionPool},
+		Wallets:                        &WalletModel{dbConnectionPool: dbConnectionPool},
+		Assets:                         &AssetModel{dbConnectionPool: dbConnectionPool},
+		Organizations:                  &OrganizationModel{dbConnectionPool: dbConnectionPool},
+		Payment:                        &PaymentModel{dbConnectionPool: dbConnectionPool},
+		Receiver:                       &ReceiverModel{},
+		DisbursementInstructions:       NewDisbursementInstructionModel(dbConnectionPool),
+		ReceiverVerification:           &ReceiverVerificationModel{dbConnectionPool: dbConnectionPool},
+		ReceiverWallet:                 &ReceiverWalletModel{dbConnectionPool: dbConnectionPool},
+		DisbursementReceivers:          &DisbursementReceiverModel{dbConnectionPool: dbConnectionPool},
+		Message:                        &MessageModel{dbConnectionPool: dbConnectionPool},
+		CircleTransferRequests:         &CircleTransferRequestModel{dbConnectionPool: dbConnectionPool},
+		CircleRecipient:                &CircleRecipientModel{dbConnectionPool: dbConnectionPool},
+		URLShortener:                   NewURLShortenerModel(dbConnectionPool),
+		WebhookSubscriptions:           &WebhookSubscriptionModel{dbConnectionPool: dbConnectionPool},
+		WebhookDeliveries:              &WebhookDeliveryModel{dbConnectionPool: dbConnectionPool},
+		IdempotencyKeys:                &IdempotencyKeyModel{dbConnectionPool: dbConnectionPool},
+		DisbursementInstructionUploads: &DisbursementInstructionUploadModel{dbConnectionPool: dbConnectionPool},
+		PaymentCorrections:             &PaymentCorrectionModel{dbConnectionPool: dbConnectionPool},
+		ReceiverPortalOTPs:             &ReceiverPortalOTPModel{dbConnectionPool: dbConnectionPool},
+		RegistrationRateLimits:         &RegistrationRateLimitModel{dbConnectionPool: dbConnectionPool},
+		DBConnectionPool:               dbConnectionPool,
 	}, nil
 }
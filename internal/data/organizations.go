@@ -5,9 +5,11 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"image"
+	"math/big"
 	"net/url"
 	"regexp"
 	"strings"
@@ -50,8 +52,33 @@ type Organization struct {
 	IsApprovalRequired     bool                   `json:"is_approval_required" db:"is_approval_required"`
 	IsLinkShortenerEnabled bool                   `json:"is_link_shortener_enabled" db:"is_link_shortener_enabled"`
 	MessageChannelPriority MessageChannelPriority `json:"message_channel_priority" db:"message_channel_priority"`
-	CreatedAt              time.Time              `json:"created_at" db:"created_at"`
-	UpdatedAt              time.Time              `json:"updated_at" db:"updated_at"`
+	// ApprovalQuorumRules sets how many approvals a disbursement needs before being started, depending on its amount.
+	// They're only enforced when IsApprovalRequired is true.
+	ApprovalQuorumRules ApprovalQuorumRules `json:"approval_quorum_rules" db:"approval_quorum_rules"`
+	// VerificationMaxAttempts is the number of failed attempts to confirm a verification value after which the receiver
+	// is locked out of the wallet registration.
+	VerificationMaxAttempts int `json:"verification_max_attempts" db:"verification_max_attempts"`
+	// VerificationLockoutMinutes is how long a receiver stays locked out after the last failed attempt. When it's zero,
+	// the receiver stays locked out until an admin resets their attempts.
+	VerificationLockoutMinutes int `json:"verification_lockout_minutes" db:"verification_lockout_minutes"`
+	// RegistrationRateLimitPerContact and RegistrationRateLimitPerIP are how many requests a contact (phone number or
+	// email) and an IP address can make to each wallet registration endpoint in a window of
+	// RegistrationRateLimitWindowMinutes. A zero limit is not enforced. The limit per IP is disabled by default, since the
+	// IP address is the remote address of the connection, which is the same for all receivers behind a proxy.
+	RegistrationRateLimitWindowMinutes int       `json:"registration_rate_limit_window_minutes" db:"registration_rate_limit_window_minutes"`
+	RegistrationRateLimitPerContact    int       `json:"registration_rate_limit_per_contact" db:"registration_rate_limit_per_contact"`
+	RegistrationRateLimitPerIP         int       `json:"registration_rate_limit_per_ip" db:"registration_rate_limit_per_ip"`
+	CreatedAt                          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt                          time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// VerificationLockoutPolicy returns the policy used to lock out the receivers of the organization that fail to confirm
+// their verification values.
+func (o Organization) VerificationLockoutPolicy() VerificationLockoutPolicy {
+	return VerificationLockoutPolicy{
+		MaxAttempts: o.VerificationMaxAttempts,
+		Duration:    time.Duration(o.VerificationLockoutMinutes) * time.Minute,
+	}
 }
 
 type OrganizationUpdate struct {
@@ -62,6 +89,14 @@ type OrganizationUpdate struct {
 	IsLinkShortenerEnabled               *bool  `json:",omitempty"`
 	ReceiverInvitationResendIntervalDays *int64 `json:",omitempty"`
 	PaymentCancellationPeriodDays        *int64 `json:",omitempty"`
+	// ApprovalQuorumRules replaces the organization rules. An empty list removes them.
+	ApprovalQuorumRules *ApprovalQuorumRules `json:",omitempty"`
+
+	VerificationMaxAttempts            *int `json:",omitempty"`
+	VerificationLockoutMinutes         *int `json:",omitempty"`
+	RegistrationRateLimitWindowMinutes *int `json:",omitempty"`
+	RegistrationRateLimitPerContact    *int `json:",omitempty"`
+	RegistrationRateLimitPerIP         *int `json:",omitempty"`
 
 	// Using pointers to accept empty strings
 	ReceiverRegistrationMessageTemplate *string `json:",omitempty"`
@@ -113,6 +148,28 @@ func (ou *OrganizationUpdate) validate() error {
 		return fmt.Errorf("invalid timezone UTC offset format. Example: +02:00 or -03:00")
 	}
 
+	if ou.ApprovalQuorumRules != nil {
+		if err := ou.ApprovalQuorumRules.Validate(); err != nil {
+			return fmt.Errorf("invalid approval quorum rules: %w", err)
+		}
+	}
+
+	if ou.VerificationMaxAttempts != nil && *ou.VerificationMaxAttempts < 1 {
+		return fmt.Errorf("verification max attempts must be greater than zero")
+	}
+	if ou.VerificationLockoutMinutes != nil && *ou.VerificationLockoutMinutes < 0 {
+		return fmt.Errorf("verification lockout minutes cannot be negative")
+	}
+	if ou.RegistrationRateLimitWindowMinutes != nil && (*ou.RegistrationRateLimitWindowMinutes < 1 || *ou.RegistrationRateLimitWindowMinutes > MaxRegistrationRateLimitWindowMinutes) {
+		return fmt.Errorf("registration rate limit window minutes must be between 1 and %d", MaxRegistrationRateLimitWindowMinutes)
+	}
+	if ou.RegistrationRateLimitPerContact != nil && *ou.RegistrationRateLimitPerContact < 0 {
+		return fmt.Errorf("registration rate limit per contact cannot be negative")
+	}
+	if ou.RegistrationRateLimitPerIP != nil && *ou.RegistrationRateLimitPerIP < 0 {
+		return fmt.Errorf("registration rate limit per IP cannot be negative")
+	}
+
 	if ou.PrivacyPolicyLink != nil && *ou.PrivacyPolicyLink != "" {
 		_, err := url.ParseRequestURI(*ou.PrivacyPolicyLink)
 		if err != nil {
@@ -128,11 +185,17 @@ func (ou *OrganizationUpdate) areAllFieldsEmpty() bool {
 		len(ou.Logo) == 0 &&
 		ou.TimezoneUTCOffset == "" &&
 		ou.IsApprovalRequired == nil &&
+		ou.ApprovalQuorumRules == nil &&
 		ou.IsLinkShortenerEnabled == nil &&
 		ou.ReceiverRegistrationMessageTemplate == nil &&
 		ou.OTPMessageTemplate == nil &&
 		ou.ReceiverInvitationResendIntervalDays == nil &&
 		ou.PaymentCancellationPeriodDays == nil &&
+		ou.VerificationMaxAttempts == nil &&
+		ou.VerificationLockoutMinutes == nil &&
+		ou.RegistrationRateLimitWindowMinutes == nil &&
+		ou.RegistrationRateLimitPerContact == nil &&
+		ou.RegistrationRateLimitPerIP == nil &&
 		ou.PrivacyPolicyLink == nil
 }
 
@@ -200,6 +263,11 @@ func (om *OrganizationModel) Update(ctx context.Context, ou *OrganizationUpdate)
 		args = append(args, *ou.IsApprovalRequired)
 	}
 
+	if ou.ApprovalQuorumRules != nil {
+		fields = append(fields, "approval_quorum_rules = ?")
+		args = append(args, *ou.ApprovalQuorumRules)
+	}
+
 	if ou.IsLinkShortenerEnabled != nil {
 		fields = append(fields, "is_link_shortener_enabled = ?")
 		args = append(args, *ou.IsLinkShortenerEnabled)
@@ -254,6 +322,22 @@ func (om *OrganizationModel) Update(ctx context.Context, ou *OrganizationUpdate)
 		}
 	}
 
+	for _, setting := range []struct {
+		column string
+		value  *int
+	}{
+		{"verification_max_attempts", ou.VerificationMaxAttempts},
+		{"verification_lockout_minutes", ou.VerificationLockoutMinutes},
+		{"registration_rate_limit_window_minutes", ou.RegistrationRateLimitWindowMinutes},
+		{"registration_rate_limit_per_contact", ou.RegistrationRateLimitPerContact},
+		{"registration_rate_limit_per_ip", ou.RegistrationRateLimitPerIP},
+	} {
+		if setting.value != nil {
+			fields = append(fields, setting.column+" = ?")
+			args = append(args, *setting.value)
+		}
+	}
+
 	query = om.dbConnectionPool.Rebind(fmt.Sprintf(query, strings.Join(fields, ", ")))
 
 	_, err := om.dbConnectionPool.ExecContext(ctx, query, args...)
@@ -269,6 +353,7 @@ type MessageChannelPriority []message.MessageChannel
 var DefaultMessageChannelPriority = MessageChannelPriority{
 	message.MessageChannelSMS,
 	message.MessageChannelEmail,
+	message.MessageChannelWhatsApp,
 }
 
 func (mcp *MessageChannelPriority) Scan(src interface{}) error {
@@ -312,3 +397,92 @@ func (mcp MessageChannelPriority) Value() (driver.Value, error) {
 }
 
 var _ driver.Valuer = MessageChannelPriority{}
+
+// ApprovalQuorumRule sets the number of distinct approvals required to start a disbursement whose total amount is at
+// least MinAmount. When Role is set, only approvals from users with that role are accepted.
+type ApprovalQuorumRule struct {
+	MinAmount         string   `json:"min_amount"`
+	RequiredApprovals int      `json:"required_approvals"`
+	Role              UserRole `json:"role,omitempty"`
+}
+
+type ApprovalQuorumRules []ApprovalQuorumRule
+
+// Validate checks that the rules have valid amounts, approvals and roles, and that no two rules have the same amount.
+func (aqr ApprovalQuorumRules) Validate() error {
+	minAmounts := map[string]bool{}
+	for i, rule := range aqr {
+		minAmount, ok := new(big.Rat).SetString(rule.MinAmount)
+		if !ok || minAmount.Sign() < 0 {
+			return fmt.Errorf("rule %d: min_amount %q must be a non-negative number", i, rule.MinAmount)
+		}
+		if minAmounts[minAmount.RatString()] {
+			return fmt.Errorf("rule %d: there is already a rule for min_amount %s", i, rule.MinAmount)
+		}
+		minAmounts[minAmount.RatString()] = true
+
+		if rule.RequiredApprovals < 1 {
+			return fmt.Errorf("rule %d: required_approvals must be greater than zero", i)
+		}
+		if rule.Role != "" && !rule.Role.IsValid() {
+			return fmt.Errorf("rule %d: invalid role %q", i, rule.Role)
+		}
+	}
+
+	return nil
+}
+
+// RuleFor returns the rule with the highest MinAmount that applies to a disbursement of the given decimal amount, or
+// nil when no rule applies. Amounts are compared as exact decimals, and an invalid amount is taken as zero.
+func (aqr ApprovalQuorumRules) RuleFor(amount string) *ApprovalQuorumRule {
+	amountRat, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		amountRat = new(big.Rat)
+	}
+
+	var selected *ApprovalQuorumRule
+	var selectedMinAmount *big.Rat
+	for i, rule := range aqr {
+		minAmount, ok := new(big.Rat).SetString(rule.MinAmount)
+		if !ok || minAmount.Cmp(amountRat) > 0 {
+			continue
+		}
+		if selected == nil || minAmount.Cmp(selectedMinAmount) > 0 {
+			selected = &aqr[i]
+			selectedMinAmount = minAmount
+		}
+	}
+
+	return selected
+}
+
+func (aqr *ApprovalQuorumRules) Scan(src interface{}) error {
+	if src == nil {
+		*aqr = nil
+		return nil
+	}
+
+	byteValue, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("unexpected type for ApprovalQuorumRules %T", src)
+	}
+
+	return json.Unmarshal(byteValue, aqr)
+}
+
+var _ sql.Scanner = (*ApprovalQuorumRules)(nil)
+
+func (aqr ApprovalQuorumRules) Value() (driver.Value, error) {
+	if aqr == nil {
+		return "[]", nil
+	}
+
+	rulesJSON, err := json.Marshal(aqr)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling approval quorum rules: %w", err)
+	}
+
+	return string(rulesJSON), nil
+}
+
+var _ driver.Valuer = ApprovalQuorumRules{}
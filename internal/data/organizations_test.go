@@ -8,6 +8,7 @@ import (
 	"image/jpeg"
 	"image/png"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +16,7 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
 	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
 )
 
 func Test_Organizations_DatabaseTriggers(t *testing.T) {
@@ -76,7 +78,11 @@ func Test_Organizations_Get(t *testing.T) {
 		assert.NotEmpty(t, gotOrganization.UpdatedAt)
 		assert.False(t, gotOrganization.IsApprovalRequired)
 		assert.Nil(t, gotOrganization.PrivacyPolicyLink)
-		assert.Equal(t, MessageChannelPriority{"SMS", "EMAIL"}, gotOrganization.MessageChannelPriority)
+		assert.Equal(t, MessageChannelPriority{"SMS", "EMAIL", "WHATSAPP"}, gotOrganization.MessageChannelPriority)
+		assert.Equal(t, VerificationLockoutPolicy{MaxAttempts: MaxAttemptsAllowed, Duration: time.Hour}, gotOrganization.VerificationLockoutPolicy())
+		assert.Equal(t, 60, gotOrganization.RegistrationRateLimitWindowMinutes)
+		assert.Equal(t, 10, gotOrganization.RegistrationRateLimitPerContact)
+		assert.Zero(t, gotOrganization.RegistrationRateLimitPerIP)
 	})
 }
 
@@ -184,6 +190,114 @@ func Test_OrganizationUpdate_validate(t *testing.T) {
 	ou.PrivacyPolicyLink = &link
 	err = ou.validate()
 	assert.Nil(t, err)
+
+	// approval quorum rules
+	ou = &OrganizationUpdate{ApprovalQuorumRules: &ApprovalQuorumRules{{MinAmount: "1000", RequiredApprovals: 0}}}
+	err = ou.validate()
+	assert.EqualError(t, err, "invalid approval quorum rules: rule 0: required_approvals must be greater than zero")
+
+	ou = &OrganizationUpdate{ApprovalQuorumRules: &ApprovalQuorumRules{}}
+	err = ou.validate()
+	assert.Nil(t, err)
+
+	// registration protection settings
+	ou = &OrganizationUpdate{VerificationMaxAttempts: utils.IntPtr(0)}
+	err = ou.validate()
+	assert.EqualError(t, err, "verification max attempts must be greater than zero")
+
+	ou = &OrganizationUpdate{VerificationLockoutMinutes: utils.IntPtr(-1)}
+	err = ou.validate()
+	assert.EqualError(t, err, "verification lockout minutes cannot be negative")
+
+	ou = &OrganizationUpdate{RegistrationRateLimitWindowMinutes: utils.IntPtr(MaxRegistrationRateLimitWindowMinutes + 1)}
+	err = ou.validate()
+	assert.EqualError(t, err, "registration rate limit window minutes must be between 1 and 1440")
+
+	ou = &OrganizationUpdate{RegistrationRateLimitPerIP: utils.IntPtr(-1)}
+	err = ou.validate()
+	assert.EqualError(t, err, "registration rate limit per IP cannot be negative")
+
+	ou = &OrganizationUpdate{
+		VerificationMaxAttempts:            utils.IntPtr(5),
+		VerificationLockoutMinutes:         utils.IntPtr(0),
+		RegistrationRateLimitWindowMinutes: utils.IntPtr(30),
+		RegistrationRateLimitPerContact:    utils.IntPtr(0),
+		RegistrationRateLimitPerIP:         utils.IntPtr(100),
+	}
+	err = ou.validate()
+	assert.Nil(t, err)
+}
+
+func Test_ApprovalQuorumRules_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rules   ApprovalQuorumRules
+		wantErr string
+	}{
+		{
+			name:    "invalid min amount",
+			rules:   ApprovalQuorumRules{{MinAmount: "ten", RequiredApprovals: 1}},
+			wantErr: `rule 0: min_amount "ten" must be a non-negative number`,
+		},
+		{
+			name:    "negative min amount",
+			rules:   ApprovalQuorumRules{{MinAmount: "-1", RequiredApprovals: 1}},
+			wantErr: `rule 0: min_amount "-1" must be a non-negative number`,
+		},
+		{
+			name:    "duplicate min amount",
+			rules:   ApprovalQuorumRules{{MinAmount: "100", RequiredApprovals: 1}, {MinAmount: "100.0", RequiredApprovals: 2}},
+			wantErr: "rule 1: there is already a rule for min_amount 100.0",
+		},
+		{
+			name:    "invalid required approvals",
+			rules:   ApprovalQuorumRules{{MinAmount: "0", RequiredApprovals: -1}},
+			wantErr: "rule 0: required_approvals must be greater than zero",
+		},
+		{
+			name:    "invalid role",
+			rules:   ApprovalQuorumRules{{MinAmount: "0", RequiredApprovals: 1, Role: "accountant"}},
+			wantErr: `rule 0: invalid role "accountant"`,
+		},
+		{
+			name: "🎉 valid rules",
+			rules: ApprovalQuorumRules{
+				{MinAmount: "0", RequiredApprovals: 1},
+				{MinAmount: "10000", RequiredApprovals: 2, Role: FinancialControllerUserRole},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rules.Validate()
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ApprovalQuorumRules_RuleFor(t *testing.T) {
+	rules := ApprovalQuorumRules{
+		{MinAmount: "10000", RequiredApprovals: 3},
+		{MinAmount: "100", RequiredApprovals: 1},
+		{MinAmount: "1000", RequiredApprovals: 2, Role: FinancialControllerUserRole},
+	}
+
+	assert.Nil(t, rules.RuleFor("99.99"))
+	assert.Equal(t, &rules[1], rules.RuleFor("100"))
+	assert.Equal(t, &rules[2], rules.RuleFor("9999.99"))
+	assert.Equal(t, &rules[0], rules.RuleFor("10000"))
+	assert.Nil(t, ApprovalQuorumRules{}.RuleFor("10000"))
+	assert.Nil(t, rules.RuleFor("invalid"))
+
+	// amounts are compared as decimals, so precision isn't lost on large amounts
+	rules = ApprovalQuorumRules{{MinAmount: "9007199254740993", RequiredApprovals: 1}}
+	assert.Nil(t, rules.RuleFor("9007199254740992.9999999"))
+	assert.Equal(t, &rules[0], rules.RuleFor("9007199254740993"))
 }
 
 func Test_Organizations_Update(t *testing.T) {
@@ -448,6 +562,30 @@ func Test_Organizations_Update(t *testing.T) {
 		assert.Nil(t, o.PaymentCancellationPeriodDays)
 	})
 
+	t.Run("updates the organization's ApprovalQuorumRules", func(t *testing.T) {
+		defer resetOrganizationInfo(t, ctx, dbConnectionPool)
+
+		o, err := organizationModel.Get(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, o.ApprovalQuorumRules)
+
+		rules := ApprovalQuorumRules{{MinAmount: "1000", RequiredApprovals: 2, Role: FinancialControllerUserRole}}
+		err = organizationModel.Update(ctx, &OrganizationUpdate{ApprovalQuorumRules: &rules})
+		require.NoError(t, err)
+
+		o, err = organizationModel.Get(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, rules, o.ApprovalQuorumRules)
+
+		// Remove the rules
+		err = organizationModel.Update(ctx, &OrganizationUpdate{ApprovalQuorumRules: &ApprovalQuorumRules{}})
+		require.NoError(t, err)
+
+		o, err = organizationModel.Get(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, o.ApprovalQuorumRules)
+	})
+
 	t.Run("updates the organization's PrivacyPolicyLink", func(t *testing.T) {
 		defer resetOrganizationInfo(t, ctx, dbConnectionPool)
 
@@ -494,7 +632,7 @@ func TestOrganizationModel_UpdateMessageChannelPriority(t *testing.T) {
 		// Verify the update
 		org, err := organizationModel.Get(ctx)
 		require.NoError(t, err)
-		assert.Equal(t, MessageChannelPriority{message.MessageChannelSMS, message.MessageChannelEmail}, org.MessageChannelPriority)
+		assert.Equal(t, MessageChannelPriority{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}, org.MessageChannelPriority)
 	})
 
 	t.Run("fails when not all channels are included", func(t *testing.T) {
@@ -508,7 +646,7 @@ func TestOrganizationModel_UpdateMessageChannelPriority(t *testing.T) {
 	t.Run("fails when duplicate channels are included", func(t *testing.T) {
 		defer resetOrganizationInfo(t, ctx, dbConnectionPool)
 
-		_, err := dbConnectionPool.ExecContext(ctx, "UPDATE organizations SET message_channel_priority = $1", MessageChannelPriority{"SMS", "EMAIL", "SMS"})
+		_, err := dbConnectionPool.ExecContext(ctx, "UPDATE organizations SET message_channel_priority = $1", MessageChannelPriority{"SMS", "EMAIL", "WHATSAPP", "SMS"})
 		require.Error(t, err)
 		assert.ErrorContains(t, err, "message_channel_priority must not contain duplicate values: {SMS}")
 	})
@@ -516,9 +654,9 @@ func TestOrganizationModel_UpdateMessageChannelPriority(t *testing.T) {
 	t.Run("fails when invalid channel is included", func(t *testing.T) {
 		defer resetOrganizationInfo(t, ctx, dbConnectionPool)
 
-		_, err := dbConnectionPool.ExecContext(ctx, "UPDATE organizations SET message_channel_priority = $1", MessageChannelPriority{"SMS", "WHATSAPP"})
+		_, err := dbConnectionPool.ExecContext(ctx, "UPDATE organizations SET message_channel_priority = $1", MessageChannelPriority{"SMS", "TELEGRAM"})
 		require.Error(t, err)
-		assert.ErrorContains(t, err, "invalid input value for enum message_channel: \"WHATSAPP\"")
+		assert.ErrorContains(t, err, "invalid input value for enum message_channel: \"TELEGRAM\"")
 	})
 }
 
@@ -530,7 +668,8 @@ func resetOrganizationInfo(t *testing.T, ctx context.Context, dbConnectionPool d
 				organizations
 			SET
 				name = 'MyCustomAid', logo = NULL, timezone_utc_offset = '+00:00',
-				receiver_registration_message_template = DEFAULT, otp_message_template = DEFAULT, message_channel_priority = '{"SMS", "EMAIL"}'`
+				receiver_registration_message_template = DEFAULT, otp_message_template = DEFAULT, message_channel_priority = '{"SMS", "EMAIL", "WHATSAPP"}',
+				approval_quorum_rules = DEFAULT`
 	_, err := dbConnectionPool.ExecContext(ctx, q)
 	require.NoError(t, err)
 }
@@ -0,0 +1,160 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+// PaymentCorrection is a change of the amount or the receiver wallet of a FAILED payment, which is retried once the
+// correction is applied. Corrections wait for the approval of a second user when the organization's approval workflow
+// is enabled.
+type PaymentCorrection struct {
+	ID                       string                  `json:"id" db:"id"`
+	PaymentID                string                  `json:"payment_id" db:"payment_id"`
+	Status                   PaymentCorrectionStatus `json:"status" db:"status"`
+	PreviousAmount           string                  `json:"previous_amount" db:"previous_amount"`
+	Amount                   string                  `json:"amount" db:"amount"`
+	PreviousReceiverWalletID string                  `json:"previous_receiver_wallet_id" db:"previous_receiver_wallet_id"`
+	ReceiverWalletID         string                  `json:"receiver_wallet_id" db:"receiver_wallet_id"`
+	Comment                  string                  `json:"comment,omitempty" db:"comment"`
+	RequestedBy              string                  `json:"requested_by" db:"requested_by"`
+	ReviewedBy               string                  `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt               *time.Time              `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt                time.Time               `json:"created_at" db:"created_at"`
+}
+
+type PaymentCorrectionStatus string
+
+const (
+	PendingApprovalPaymentCorrectionStatus PaymentCorrectionStatus = "PENDING_APPROVAL"
+	AppliedPaymentCorrectionStatus         PaymentCorrectionStatus = "APPLIED"
+	RejectedPaymentCorrectionStatus        PaymentCorrectionStatus = "REJECTED"
+)
+
+var ErrPaymentCorrectionAlreadyPending = errors.New("payment already has a correction pending approval")
+
+type PaymentCorrectionInsert struct {
+	PaymentID                string
+	Status                   PaymentCorrectionStatus
+	PreviousAmount           string
+	Amount                   string
+	PreviousReceiverWalletID string
+	ReceiverWalletID         string
+	Comment                  string
+	RequestedBy              string
+}
+
+type PaymentCorrectionModel struct {
+	dbConnectionPool db.DBConnectionPool
+}
+
+const paymentCorrectionColumns = `
+	id,
+	payment_id,
+	status,
+	previous_amount::text AS previous_amount,
+	amount::text AS amount,
+	previous_receiver_wallet_id,
+	receiver_wallet_id,
+	COALESCE(comment, '') AS comment,
+	requested_by,
+	COALESCE(reviewed_by, '') AS reviewed_by,
+	reviewed_at,
+	created_at
+`
+
+func (m *PaymentCorrectionModel) Insert(ctx context.Context, sqlExec db.SQLExecuter, insert PaymentCorrectionInsert) (*PaymentCorrection, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO
+			payment_corrections (payment_id, status, previous_amount, amount, previous_receiver_wallet_id, receiver_wallet_id, comment, requested_by)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING
+			%s
+	`, paymentCorrectionColumns)
+
+	var correction PaymentCorrection
+	err := sqlExec.GetContext(ctx, &correction, query,
+		insert.PaymentID,
+		insert.Status,
+		insert.PreviousAmount,
+		insert.Amount,
+		insert.PreviousReceiverWalletID,
+		insert.ReceiverWalletID,
+		utils.SQLNullString(insert.Comment),
+		insert.RequestedBy,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "payment_corrections_pending_unique") {
+			return nil, ErrPaymentCorrectionAlreadyPending
+		}
+		return nil, fmt.Errorf("inserting correction for payment %s: %w", insert.PaymentID, err)
+	}
+
+	return &correction, nil
+}
+
+// Get returns a correction of a payment.
+func (m *PaymentCorrectionModel) Get(ctx context.Context, sqlExec db.SQLExecuter, paymentID, correctionID string) (*PaymentCorrection, error) {
+	query := fmt.Sprintf("SELECT %s FROM payment_corrections WHERE payment_id = $1 AND id = $2", paymentCorrectionColumns)
+
+	var correction PaymentCorrection
+	err := sqlExec.GetContext(ctx, &correction, query, paymentID, correctionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("getting correction %s of payment %s: %w", correctionID, paymentID, err)
+	}
+
+	return &correction, nil
+}
+
+// GetAllByPaymentID returns all the corrections of a payment, oldest first.
+func (m *PaymentCorrectionModel) GetAllByPaymentID(ctx context.Context, sqlExec db.SQLExecuter, paymentID string) ([]*PaymentCorrection, error) {
+	query := fmt.Sprintf("SELECT %s FROM payment_corrections WHERE payment_id = $1 ORDER BY created_at ASC", paymentCorrectionColumns)
+
+	corrections := []*PaymentCorrection{}
+	err := sqlExec.SelectContext(ctx, &corrections, query, paymentID)
+	if err != nil {
+		return nil, fmt.Errorf("querying corrections of payment %s: %w", paymentID, err)
+	}
+
+	return corrections, nil
+}
+
+// Review records the decision of the user about a correction pending approval, returning ErrRecordNotFound if the
+// correction isn't pending approval anymore.
+func (m *PaymentCorrectionModel) Review(ctx context.Context, sqlExec db.SQLExecuter, correctionID, userID string, status PaymentCorrectionStatus) (*PaymentCorrection, error) {
+	query := fmt.Sprintf(`
+		UPDATE
+			payment_corrections
+		SET
+			status = $3,
+			reviewed_by = $2,
+			reviewed_at = NOW()
+		WHERE
+			id = $1
+			AND status = $4
+		RETURNING
+			%s
+	`, paymentCorrectionColumns)
+
+	var correction PaymentCorrection
+	err := sqlExec.GetContext(ctx, &correction, query, correctionID, userID, status, PendingApprovalPaymentCorrectionStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("reviewing payment correction %s: %w", correctionID, err)
+	}
+
+	return &correction, nil
+}
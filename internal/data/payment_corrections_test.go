@@ -0,0 +1,106 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+)
+
+func Test_PaymentCorrectionModel(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	wallet := CreateDefaultWalletFixture(t, ctx, dbConnectionPool)
+	asset := GetAssetFixture(t, ctx, dbConnectionPool, FixtureAssetUSDC)
+	disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{
+		Status: StartedDisbursementStatus,
+		Asset:  asset,
+		Wallet: wallet,
+	})
+	receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	receiverWallet := CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, RegisteredReceiversWalletStatus)
+	payment := CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &Payment{
+		ReceiverWallet: receiverWallet,
+		Disbursement:   disbursement,
+		Asset:          *asset,
+		Amount:         "100",
+		Status:         FailedPaymentStatus,
+	})
+
+	corrections, err := models.PaymentCorrections.GetAllByPaymentID(ctx, dbConnectionPool, payment.ID)
+	require.NoError(t, err)
+	assert.Empty(t, corrections)
+
+	insert := PaymentCorrectionInsert{
+		PaymentID:                payment.ID,
+		Status:                   PendingApprovalPaymentCorrectionStatus,
+		PreviousAmount:           "100",
+		Amount:                   "90.5",
+		PreviousReceiverWalletID: receiverWallet.ID,
+		ReceiverWalletID:         receiverWallet.ID,
+		Comment:                  "fee was deducted twice",
+		RequestedBy:              "user-1",
+	}
+
+	var pendingCorrection *PaymentCorrection
+	t.Run("🎉 inserts corrections", func(t *testing.T) {
+		pendingCorrection, err = models.PaymentCorrections.Insert(ctx, dbConnectionPool, insert)
+		require.NoError(t, err)
+		assert.NotEmpty(t, pendingCorrection.ID)
+		assert.Equal(t, payment.ID, pendingCorrection.PaymentID)
+		assert.Equal(t, PendingApprovalPaymentCorrectionStatus, pendingCorrection.Status)
+		assert.Equal(t, "100.0000000", pendingCorrection.PreviousAmount)
+		assert.Equal(t, "90.5000000", pendingCorrection.Amount)
+		assert.Equal(t, "fee was deducted twice", pendingCorrection.Comment)
+		assert.Equal(t, "user-1", pendingCorrection.RequestedBy)
+		assert.Empty(t, pendingCorrection.ReviewedBy)
+		assert.Nil(t, pendingCorrection.ReviewedAt)
+
+		correction, err := models.PaymentCorrections.Get(ctx, dbConnectionPool, payment.ID, pendingCorrection.ID)
+		require.NoError(t, err)
+		assert.Equal(t, pendingCorrection, correction)
+	})
+
+	t.Run("returns an error when the payment already has a correction pending approval", func(t *testing.T) {
+		_, err := models.PaymentCorrections.Insert(ctx, dbConnectionPool, insert)
+		assert.ErrorIs(t, err, ErrPaymentCorrectionAlreadyPending)
+	})
+
+	t.Run("returns an error when the correction doesn't belong to the payment", func(t *testing.T) {
+		_, err := models.PaymentCorrections.Get(ctx, dbConnectionPool, "other-payment", pendingCorrection.ID)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("🎉 reviews corrections pending approval", func(t *testing.T) {
+		correction, err := models.PaymentCorrections.Review(ctx, dbConnectionPool, pendingCorrection.ID, "user-2", RejectedPaymentCorrectionStatus)
+		require.NoError(t, err)
+		assert.Equal(t, RejectedPaymentCorrectionStatus, correction.Status)
+		assert.Equal(t, "user-2", correction.ReviewedBy)
+		assert.NotNil(t, correction.ReviewedAt)
+
+		_, err = models.PaymentCorrections.Review(ctx, dbConnectionPool, pendingCorrection.ID, "user-2", AppliedPaymentCorrectionStatus)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+
+		// Once reviewed, a new correction can be requested.
+		_, err = models.PaymentCorrections.Insert(ctx, dbConnectionPool, insert)
+		require.NoError(t, err)
+
+		corrections, err := models.PaymentCorrections.GetAllByPaymentID(ctx, dbConnectionPool, payment.ID)
+		require.NoError(t, err)
+		require.Len(t, corrections, 2)
+		assert.Equal(t, pendingCorrection.ID, corrections[0].ID)
+	})
+}
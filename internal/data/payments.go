@@ -32,12 +32,22 @@ type Payment struct {
 	UpdatedAt               time.Time            `json:"updated_at" db:"updated_at"`
 	ExternalPaymentID       string               `json:"external_payment_id,omitempty" db:"external_payment_id"`
 	CircleTransferRequestID *string              `json:"circle_transfer_request_id,omitempty"`
+	// ClaimableBalanceID is the ID of the claimable balance created for this payment, when the disbursement payout mode
+	// is CLAIMABLE_BALANCE.
+	ClaimableBalanceID     string                 `json:"claimable_balance_id,omitempty" db:"claimable_balance_id"`
+	ClaimableBalanceStatus ClaimableBalanceStatus `json:"claimable_balance_status,omitempty" db:"claimable_balance_status"`
+	// PathPaymentPath and SourceAmount are set for payments converted on-chain, when the disbursement has a receive
+	// asset. They hold the intermediary assets used in the conversion and the amount spent in the disbursement asset.
+	PathPaymentPath pq.StringArray `json:"path_payment_path,omitempty" db:"path_payment_path"`
+	SourceAmount    string         `json:"source_amount,omitempty" db:"source_amount"`
 }
 
 type PaymentStatusHistoryEntry struct {
 	Status        PaymentStatus `json:"status"`
 	StatusMessage string        `json:"status_message"`
 	Timestamp     time.Time     `json:"timestamp"`
+	// UserID is the ID of the user who changed the payment, when the change was made by a user.
+	UserID string `json:"user_id,omitempty"`
 }
 
 type PaymentModel struct {
@@ -64,6 +74,10 @@ type PaymentUpdate struct {
 	Status               PaymentStatus `db:"status"`
 	StatusMessage        string
 	StellarTransactionID string `db:"stellar_transaction_id"`
+	ClaimableBalanceID   string `db:"claimable_balance_id"`
+	// PathPaymentPath and SourceAmount are only set for path payments.
+	PathPaymentPath []string `db:"path_payment_path"`
+	SourceAmount    string   `db:"source_amount"`
 }
 
 type PaymentStatusHistory []PaymentStatusHistoryEntry
@@ -151,12 +165,20 @@ SELECT
 	p.created_at,
 	p.updated_at,
 	COALESCE(p.external_payment_id, '') as external_payment_id,
+	COALESCE(p.claimable_balance_id, '') as claimable_balance_id,
+	COALESCE(p.claimable_balance_status::text, '') as claimable_balance_status,
+	p.path_payment_path,
+	COALESCE(p.source_amount::text, '') as source_amount,
 	d.id as "disbursement.id",
 	d.name as "disbursement.name",
 	d.status as "disbursement.status",
 	d.created_at as "disbursement.created_at",
 	d.updated_at as "disbursement.updated_at",
 	d.registration_contact_type as "disbursement.registration_contact_type",
+	d.payout_mode as "disbursement.payout_mode",
+	COALESCE(d.receive_asset_code, '') as "disbursement.receive_asset_code",
+	COALESCE(d.receive_asset_issuer, '') as "disbursement.receive_asset_issuer",
+	d.max_slippage_bps as "disbursement.max_slippage_bps",
 	a.id as "asset.id",
 	a.code as "asset.code",
 	a.issuer as "asset.issuer",
@@ -275,6 +297,33 @@ func (p *PaymentModel) GetAll(ctx context.Context, queryParams *QueryParams, sql
 	return payments, nil
 }
 
+// StreamAll runs the same query as GetAll with QueryTypeSelectAll, but calls fn with each payment as it's read from the
+// database cursor instead of loading all of them into memory. It stops at the first error returned by fn.
+func (p *PaymentModel) StreamAll(ctx context.Context, queryParams *QueryParams, sqlExec db.SQLExecuter, fn func(Payment) error) error {
+	query, params := newPaymentQuery(basePaymentQuery, queryParams, sqlExec, QueryTypeSelectAll)
+
+	rows, err := sqlExec.QueryxContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("error querying payments: %w", err)
+	}
+	defer db.CloseRows(ctx, rows)
+
+	for rows.Next() {
+		var payment Payment
+		if err = rows.StructScan(&payment); err != nil {
+			return fmt.Errorf("error scanning payment: %w", err)
+		}
+		if err = fn(payment); err != nil {
+			return fmt.Errorf("handling payment %s: %w", payment.ID, err)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating payments: %w", err)
+	}
+
+	return nil
+}
+
 // DeleteAllDraftForDisbursement deletes all payments for a given disbursement.
 func (p *PaymentModel) DeleteAllDraftForDisbursement(ctx context.Context, sqlExec db.SQLExecuter, disbursementID string) error {
 	query := `
@@ -485,11 +534,19 @@ func (p *PaymentModel) Update(ctx context.Context, sqlExec db.SQLExecuter, payme
 		UPDATE payments
 		SET status = $1,
 			status_history = array_append(status_history, create_payment_status_history(NOW(), $1, $2)),
-			stellar_transaction_id = COALESCE($3, stellar_transaction_id)
+			stellar_transaction_id = COALESCE($3, stellar_transaction_id),
+			claimable_balance_id = COALESCE(NULLIF($5, ''), claimable_balance_id),
+			claimable_balance_status = CASE WHEN NULLIF($5, '') IS NULL THEN claimable_balance_status ELSE 'UNCLAIMED'::claimable_balance_status END,
+			path_payment_path = COALESCE($6, path_payment_path),
+			source_amount = COALESCE(NULLIF($7, '')::numeric, source_amount)
 		WHERE id = $4
 	`
 
-	result, err := sqlExec.ExecContext(ctx, query, update.Status, update.StatusMessage, update.StellarTransactionID, payment.ID)
+	var path interface{}
+	if update.PathPaymentPath != nil {
+		path = pq.Array(update.PathPaymentPath)
+	}
+	result, err := sqlExec.ExecContext(ctx, query, update.Status, update.StatusMessage, update.StellarTransactionID, payment.ID, update.ClaimableBalanceID, path, update.SourceAmount)
 	if err != nil {
 		return fmt.Errorf("error updating payment with id %s: %w", payment.ID, err)
 	}
@@ -508,6 +565,54 @@ func (p *PaymentModel) Update(ctx context.Context, sqlExec db.SQLExecuter, payme
 	return nil
 }
 
+// GetUnclaimedClaimableBalances returns up to {limit} payments whose claimable balance was created but not claimed or
+// reclaimed yet, starting with the ones that were checked the longest ago.
+func (p *PaymentModel) GetUnclaimedClaimableBalances(ctx context.Context, sqlExec db.SQLExecuter, limit int) ([]*Payment, error) {
+	query := fmt.Sprintf(`%s
+		WHERE
+			p.claimable_balance_status = $1
+		ORDER BY
+			p.updated_at ASC
+		LIMIT $2
+	`, basePaymentQuery)
+
+	payments := make([]*Payment, 0)
+	err := sqlExec.SelectContext(ctx, &payments, query, ClaimableBalanceStatusUnclaimed, limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting payments with unclaimed claimable balances: %w", err)
+	}
+
+	return payments, nil
+}
+
+// UpdateClaimableBalanceStatus updates the claimable balance status of the payment. Passing the UNCLAIMED status only
+// touches the payment's `updated_at`, so it goes to the end of the queue of balances to be checked.
+func (p *PaymentModel) UpdateClaimableBalanceStatus(ctx context.Context, sqlExec db.SQLExecuter, paymentID string, status ClaimableBalanceStatus) error {
+	query := `
+		UPDATE
+			payments
+		SET
+			claimable_balance_status = $1
+		WHERE
+			id = $2
+			AND claimable_balance_id IS NOT NULL
+	`
+
+	result, err := sqlExec.ExecContext(ctx, query, status, paymentID)
+	if err != nil {
+		return fmt.Errorf("updating claimable balance status for payment %s: %w", paymentID, err)
+	}
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting number of rows affected for payment %s: %w", paymentID, err)
+	}
+	if numRowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
 func (p *PaymentModel) RetryFailedPayments(ctx context.Context, sqlExec db.SQLExecuter, email string, paymentIDs ...string) error {
 	if len(paymentIDs) == 0 {
 		return fmt.Errorf("payment ids is required: %w", ErrMissingInput)
@@ -576,6 +681,82 @@ func (p *PaymentModel) RetryFailedPayments(ctx context.Context, sqlExec db.SQLEx
 	return nil
 }
 
+// ApplyCorrection changes the amount and the receiver wallet of a FAILED payment to the ones of the correction, adding
+// an entry to its status history with the user who made the change. The payment stays FAILED, and it's up to the
+// caller to retry it afterwards.
+func (p *PaymentModel) ApplyCorrection(ctx context.Context, sqlExec db.SQLExecuter, correction *PaymentCorrection, userID string) error {
+	if userID == "" {
+		return fmt.Errorf("user id is required: %w", ErrMissingInput)
+	}
+
+	const query = `
+		UPDATE
+			payments p
+		SET
+			amount = $2,
+			receiver_wallet_id = rw.id,
+			receiver_id = rw.receiver_id,
+			status_history = array_append(p.status_history, create_payment_status_history(NOW(), p.status, $3) || jsonb_build_object('user_id', $4::text))
+		FROM
+			receiver_wallets rw
+		WHERE
+			p.id = $1
+			AND p.status = 'FAILED'::payment_status
+			AND rw.id = $5
+		`
+
+	statusMessage := fmt.Sprintf("Payment corrected by correction %s - Amount: %s -> %s, Receiver Wallet ID: %s -> %s",
+		correction.ID, correction.PreviousAmount, correction.Amount, correction.PreviousReceiverWalletID, correction.ReceiverWalletID)
+
+	res, err := sqlExec.ExecContext(ctx, query, correction.PaymentID, correction.Amount, statusMessage, userID, correction.ReceiverWalletID)
+	if err != nil {
+		return fmt.Errorf("applying correction %s to payment %s: %w", correction.ID, correction.PaymentID, err)
+	}
+
+	numRowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting number of rows affected: %w", err)
+	}
+	if numRowsAffected != 1 {
+		return ErrMismatchNumRowsAffected
+	}
+
+	return nil
+}
+
+// ResetFailedByReceiverWalletID transitions the FAILED payments of an unregistered receiver wallet back to READY, so they
+// are sent to the Stellar account the receiver registers with next, adding an entry to their status history with the
+// user who unregistered the receiver wallet. It returns the IDs of the payments reset.
+func (p *PaymentModel) ResetFailedByReceiverWalletID(ctx context.Context, sqlExec db.SQLExecuter, receiverWalletID, userID string) ([]string, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required: %w", ErrMissingInput)
+	}
+
+	const query = `
+		UPDATE
+			payments
+		SET
+			status = 'READY'::payment_status,
+			stellar_transaction_id = '',
+			status_history = array_append(status_history, create_payment_status_history(NOW(), 'READY', CONCAT($2::text, stellar_transaction_id)) || jsonb_build_object('user_id', $3::text))
+		WHERE
+			receiver_wallet_id = $1
+			AND status = 'FAILED'::payment_status
+		RETURNING
+			id
+		`
+
+	statusMessage := fmt.Sprintf("Receiver wallet %s was unregistered - Previous Stellar Transaction ID: ", receiverWalletID)
+
+	paymentIDs := []string{}
+	err := sqlExec.SelectContext(ctx, &paymentIDs, query, receiverWalletID, statusMessage, userID)
+	if err != nil {
+		return nil, fmt.Errorf("resetting failed payments of receiver wallet %s: %w", receiverWalletID, err)
+	}
+
+	return paymentIDs, nil
+}
+
 // GetByIDs returns a list of payments for the given IDs.
 func (p *PaymentModel) GetByIDs(ctx context.Context, sqlExec db.SQLExecuter, paymentIDs []string) ([]*Payment, error) {
 	payments := []*Payment{}
@@ -645,6 +826,9 @@ func newPaymentQuery(baseQuery string, queryParams *QueryParams, sqlExec db.SQLE
 	if queryParams.Filters[FilterKeyCreatedAtBefore] != nil {
 		qb.AddCondition("p.created_at <= ?", queryParams.Filters[FilterKeyCreatedAtBefore])
 	}
+	if statusSlice, ok := queryParams.Filters[FilterKeyDisbursementStatus].([]DisbursementStatus); ok && len(statusSlice) > 0 {
+		qb.AddCondition("d.status = ANY(?)", pq.Array(statusSlice))
+	}
 
 	switch queryType {
 	case QueryTypeSelectPaginated:
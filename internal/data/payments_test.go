@@ -2,6 +2,8 @@ package data
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -448,6 +450,72 @@ func Test_PaymentModelGetAll(t *testing.T) {
 	})
 }
 
+func Test_PaymentModel_StreamAll(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+
+	asset := CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVV")
+	wallet := CreateWalletFixture(t, ctx, dbConnectionPool, "wallet1", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+	receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	receiverWallet := CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, DraftReceiversWalletStatus)
+
+	disbursementModel := DisbursementModel{dbConnectionPool: dbConnectionPool}
+	disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, &disbursementModel, &Disbursement{
+		Status: DraftDisbursementStatus,
+		Asset:  asset,
+		Wallet: wallet,
+	})
+
+	paymentModel := PaymentModel{dbConnectionPool: dbConnectionPool}
+	payment1 := CreatePaymentFixture(t, ctx, dbConnectionPool, &paymentModel, &Payment{
+		Amount:         "50",
+		Status:         DraftPaymentStatus,
+		Disbursement:   disbursement,
+		Asset:          *asset,
+		ReceiverWallet: receiverWallet,
+		UpdatedAt:      time.Date(2022, 3, 21, 23, 40, 20, 1431, time.UTC),
+	})
+	payment2 := CreatePaymentFixture(t, ctx, dbConnectionPool, &paymentModel, &Payment{
+		Amount:         "150",
+		Status:         PendingPaymentStatus,
+		Disbursement:   disbursement,
+		Asset:          *asset,
+		ReceiverWallet: receiverWallet,
+		UpdatedAt:      time.Date(2023, 3, 21, 23, 40, 20, 1431, time.UTC),
+	})
+
+	t.Run("streams the payments matching the query", func(t *testing.T) {
+		params := QueryParams{
+			SortBy:    DefaultPaymentSortField,
+			SortOrder: DefaultPaymentSortOrder,
+			Filters:   map[FilterKey]interface{}{FilterKeyStatus: []PaymentStatus{DraftPaymentStatus, PendingPaymentStatus}},
+		}
+		streamedPayments := []Payment{}
+		err := paymentModel.StreamAll(ctx, &params, dbConnectionPool, func(payment Payment) error {
+			streamedPayments = append(streamedPayments, payment)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []Payment{*payment2, *payment1}, streamedPayments)
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		calls := 0
+		err := paymentModel.StreamAll(ctx, &QueryParams{}, dbConnectionPool, func(payment Payment) error {
+			calls++
+			return errors.New("writing payment")
+		})
+		assert.ErrorContains(t, err, "writing payment")
+		assert.Equal(t, 1, calls)
+	})
+}
+
 // func Test_PaymentsModelGetByIDs(t *testing.T) {
 // 	dbt := dbtest.Open(t)
 // 	defer dbt.Close()
@@ -667,6 +735,18 @@ func Test_PaymentNewPaymentQuery(t *testing.T) {
 			expectedQuery:  "SELECT * FROM payments p WHERE 1=1 AND p.created_at >= $1 AND p.created_at <= $2",
 			expectedParams: []interface{}{"00-01-01", "00-01-31"},
 		},
+		{
+			name:      "build payment query with disbursement_status filter",
+			baseQuery: "SELECT * FROM payments p",
+			queryParams: QueryParams{
+				Filters: map[FilterKey]interface{}{
+					FilterKeyDisbursementStatus: []DisbursementStatus{StartedDisbursementStatus, CompletedDisbursementStatus},
+				},
+			},
+			queryType:      QueryTypeSelectAll,
+			expectedQuery:  "SELECT * FROM payments p WHERE 1=1 AND d.status = ANY($1)",
+			expectedParams: []interface{}{pq.Array([]DisbursementStatus{StartedDisbursementStatus, CompletedDisbursementStatus})},
+		},
 		{
 			name:      "build payment query with pagination",
 			baseQuery: "SELECT * FROM payments p",
@@ -971,6 +1051,155 @@ func Test_PaymentModelRetryFailedPayments(t *testing.T) {
 	})
 }
 
+func Test_PaymentModel_ApplyCorrection(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	wallet := CreateWalletFixture(t, ctx, dbConnectionPool, "Wallet", "https://www.wallet.com", "www.wallet.com", "wallet://")
+	asset := CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVV")
+
+	receiver1 := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	receiverWallet1 := CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver1.ID, wallet.ID, RegisteredReceiversWalletStatus)
+	receiver2 := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	receiverWallet2 := CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver2.ID, wallet.ID, RegisteredReceiversWalletStatus)
+
+	disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{
+		Wallet: wallet,
+		Asset:  asset,
+		Status: StartedDisbursementStatus,
+	})
+
+	t.Run("does not update payments when user id is empty", func(t *testing.T) {
+		err := models.Payment.ApplyCorrection(ctx, dbConnectionPool, &PaymentCorrection{PaymentID: "payment-id"}, "")
+		assert.ErrorIs(t, err, ErrMissingInput)
+	})
+
+	t.Run("returns error when the payment is not failed", func(t *testing.T) {
+		payment := CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &Payment{
+			Amount:         "1",
+			Status:         SuccessPaymentStatus,
+			Disbursement:   disbursement,
+			Asset:          *asset,
+			ReceiverWallet: receiverWallet1,
+		})
+
+		err := models.Payment.ApplyCorrection(ctx, dbConnectionPool, &PaymentCorrection{
+			PaymentID:        payment.ID,
+			Amount:           "2",
+			ReceiverWalletID: receiverWallet1.ID,
+		}, "user-id")
+		assert.ErrorIs(t, err, ErrMismatchNumRowsAffected)
+	})
+
+	t.Run("🎉 changes the amount and the receiver wallet of the payment", func(t *testing.T) {
+		payment := CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &Payment{
+			Amount:         "1",
+			Status:         FailedPaymentStatus,
+			Disbursement:   disbursement,
+			Asset:          *asset,
+			ReceiverWallet: receiverWallet1,
+		})
+
+		err := models.Payment.ApplyCorrection(ctx, dbConnectionPool, &PaymentCorrection{
+			ID:                       "correction-id",
+			PaymentID:                payment.ID,
+			PreviousAmount:           "1",
+			Amount:                   "2.5",
+			PreviousReceiverWalletID: receiverWallet1.ID,
+			ReceiverWalletID:         receiverWallet2.ID,
+		}, "user-id")
+		require.NoError(t, err)
+
+		payment, err = models.Payment.Get(ctx, payment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, FailedPaymentStatus, payment.Status)
+		assert.Equal(t, "2.5000000", payment.Amount)
+		assert.Equal(t, receiverWallet2.ID, payment.ReceiverWallet.ID)
+		assert.Equal(t, receiver2.ID, payment.ReceiverWallet.Receiver.ID)
+
+		lastEntry := payment.StatusHistory[len(payment.StatusHistory)-1]
+		assert.Equal(t, FailedPaymentStatus, lastEntry.Status)
+		assert.Equal(t, "user-id", lastEntry.UserID)
+		assert.Equal(t, fmt.Sprintf("Payment corrected by correction correction-id - Amount: 1 -> 2.5, Receiver Wallet ID: %s -> %s", receiverWallet1.ID, receiverWallet2.ID), lastEntry.StatusMessage)
+	})
+}
+
+func Test_PaymentModel_ResetFailedByReceiverWalletID(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	wallet := CreateWalletFixture(t, ctx, dbConnectionPool, "Wallet", "https://www.wallet.com", "www.wallet.com", "wallet://")
+	asset := CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVV")
+	receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	receiverWallet := CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, RegisteredReceiversWalletStatus)
+	disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{
+		Wallet: wallet,
+		Asset:  asset,
+		Status: StartedDisbursementStatus,
+	})
+
+	t.Run("does not update payments when user id is empty", func(t *testing.T) {
+		_, err := models.Payment.ResetFailedByReceiverWalletID(ctx, dbConnectionPool, receiverWallet.ID, "")
+		assert.ErrorIs(t, err, ErrMissingInput)
+	})
+
+	t.Run("🎉 transitions the failed payments of the receiver wallet to ready", func(t *testing.T) {
+		failedPayment := CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &Payment{
+			Amount:               "1",
+			Status:               FailedPaymentStatus,
+			StellarTransactionID: "stellar-transaction-id",
+			Disbursement:         disbursement,
+			Asset:                *asset,
+			ReceiverWallet:       receiverWallet,
+		})
+		successfulPayment := CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &Payment{
+			Amount:         "1",
+			Status:         SuccessPaymentStatus,
+			Disbursement:   disbursement,
+			Asset:          *asset,
+			ReceiverWallet: receiverWallet,
+		})
+
+		paymentIDs, err := models.Payment.ResetFailedByReceiverWalletID(ctx, dbConnectionPool, receiverWallet.ID, "user-id")
+		require.NoError(t, err)
+		assert.Equal(t, []string{failedPayment.ID}, paymentIDs)
+
+		payment, err := models.Payment.Get(ctx, failedPayment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, ReadyPaymentStatus, payment.Status)
+		assert.Empty(t, payment.StellarTransactionID)
+
+		lastEntry := payment.StatusHistory[len(payment.StatusHistory)-1]
+		assert.Equal(t, ReadyPaymentStatus, lastEntry.Status)
+		assert.Equal(t, "user-id", lastEntry.UserID)
+		assert.Equal(t, fmt.Sprintf("Receiver wallet %s was unregistered - Previous Stellar Transaction ID: stellar-transaction-id", receiverWallet.ID), lastEntry.StatusMessage)
+
+		payment, err = models.Payment.Get(ctx, successfulPayment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, SuccessPaymentStatus, payment.Status)
+
+		paymentIDs, err = models.Payment.ResetFailedByReceiverWalletID(ctx, dbConnectionPool, receiverWallet.ID, "user-id")
+		require.NoError(t, err)
+		assert.Empty(t, paymentIDs)
+	})
+}
+
 func Test_PaymentModelCancelPayment(t *testing.T) {
 	dbt := dbtest.Open(t)
 	defer dbt.Close()
@@ -1893,3 +2122,130 @@ func Test_PaymentModel_UpdateStatus(t *testing.T) {
 		assert.Equal(t, stellarTransactionID, paymentDB.StellarTransactionID)
 	})
 }
+
+func Test_PaymentModel_ClaimableBalances(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, outerErr := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, outerErr)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+
+	models, outerErr := NewModels(dbConnectionPool)
+	require.NoError(t, outerErr)
+
+	asset := CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVV")
+	wallet := CreateWalletFixture(t, ctx, dbConnectionPool, "wallet1", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+	disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{
+		Wallet:     wallet,
+		Status:     StartedDisbursementStatus,
+		Asset:      asset,
+		PayoutMode: PayoutModeClaimableBalance,
+	})
+	receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	rw := CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, RegisteredReceiversWalletStatus)
+
+	unclaimedPayment := CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &Payment{
+		ReceiverWallet:         rw,
+		Disbursement:           disbursement,
+		Asset:                  *asset,
+		Amount:                 "100",
+		Status:                 SuccessPaymentStatus,
+		ClaimableBalanceID:     "00000000aaaa",
+		ClaimableBalanceStatus: ClaimableBalanceStatusUnclaimed,
+	})
+	_ = CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &Payment{
+		ReceiverWallet:         rw,
+		Disbursement:           disbursement,
+		Asset:                  *asset,
+		Amount:                 "100",
+		Status:                 SuccessPaymentStatus,
+		ClaimableBalanceID:     "00000000bbbb",
+		ClaimableBalanceStatus: ClaimableBalanceStatusClaimed,
+	})
+	regularPayment := CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &Payment{
+		ReceiverWallet: rw,
+		Disbursement:   disbursement,
+		Asset:          *asset,
+		Amount:         "100",
+		Status:         SuccessPaymentStatus,
+	})
+
+	t.Run("GetUnclaimedClaimableBalances only returns the unclaimed balances", func(t *testing.T) {
+		payments, err := models.Payment.GetUnclaimedClaimableBalances(ctx, dbConnectionPool, 10)
+		require.NoError(t, err)
+		require.Len(t, payments, 1)
+		assert.Equal(t, unclaimedPayment.ID, payments[0].ID)
+		assert.Equal(t, "00000000aaaa", payments[0].ClaimableBalanceID)
+		assert.Equal(t, PayoutModeClaimableBalance, payments[0].Disbursement.PayoutMode)
+	})
+
+	t.Run("UpdateClaimableBalanceStatus returns an error for payments without claimable balances", func(t *testing.T) {
+		err := models.Payment.UpdateClaimableBalanceStatus(ctx, dbConnectionPool, regularPayment.ID, ClaimableBalanceStatusClaimed)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("UpdateClaimableBalanceStatus updates the status", func(t *testing.T) {
+		err := models.Payment.UpdateClaimableBalanceStatus(ctx, dbConnectionPool, unclaimedPayment.ID, ClaimableBalanceStatusReclaimed)
+		require.NoError(t, err)
+
+		paymentDB, err := models.Payment.Get(ctx, unclaimedPayment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, ClaimableBalanceStatusReclaimed, paymentDB.ClaimableBalanceStatus)
+
+		payments, err := models.Payment.GetUnclaimedClaimableBalances(ctx, dbConnectionPool, 10)
+		require.NoError(t, err)
+		assert.Empty(t, payments)
+	})
+}
+
+func Test_PaymentModel_Update_pathPaymentResult(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, outerErr := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, outerErr)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+
+	models, outerErr := NewModels(dbConnectionPool)
+	require.NoError(t, outerErr)
+
+	asset := CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVV")
+	wallet := CreateWalletFixture(t, ctx, dbConnectionPool, "wallet1", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+	disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{
+		Wallet:             wallet,
+		Status:             StartedDisbursementStatus,
+		Asset:              asset,
+		ReceiveAssetCode:   "BRL",
+		ReceiveAssetIssuer: "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
+		MaxSlippageBps:     100,
+	})
+	receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	rw := CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, RegisteredReceiversWalletStatus)
+	payment := CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &Payment{
+		ReceiverWallet: rw,
+		Disbursement:   disbursement,
+		Asset:          *asset,
+		Amount:         "500",
+		Status:         PendingPaymentStatus,
+	})
+	assert.Equal(t, "BRL", payment.Disbursement.ReceiveAssetCode)
+	assert.Equal(t, 100, payment.Disbursement.MaxSlippageBps)
+	assert.Empty(t, payment.PathPaymentPath)
+	assert.Empty(t, payment.SourceAmount)
+
+	err := models.Payment.Update(ctx, dbConnectionPool, payment, &PaymentUpdate{
+		Status:               SuccessPaymentStatus,
+		StellarTransactionID: "stellar-transaction-id",
+		PathPaymentPath:      []string{"native"},
+		SourceAmount:         "99.5",
+	})
+	require.NoError(t, err)
+
+	paymentDB, err := models.Payment.Get(ctx, payment.ID, dbConnectionPool)
+	require.NoError(t, err)
+	assert.Equal(t, pq.StringArray{"native"}, paymentDB.PathPaymentPath)
+	assert.Equal(t, "99.5000000", paymentDB.SourceAmount)
+}
@@ -0,0 +1,44 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+)
+
+// PayoutMode defines how the payments of a disbursement are delivered to the receivers on the Stellar network.
+type PayoutMode string
+
+const (
+	// PayoutModePayment sends the funds directly to the receiver, which requires the receiver account to exist and to
+	// have a trustline for the disbursement asset.
+	PayoutModePayment PayoutMode = "PAYMENT"
+	// PayoutModeClaimableBalance sends the funds as a claimable balance, which the receiver can claim once their
+	// account is ready to hold the asset.
+	PayoutModeClaimableBalance PayoutMode = "CLAIMABLE_BALANCE"
+)
+
+// AllPayoutModes returns all the available payout modes.
+func AllPayoutModes() []PayoutMode {
+	return []PayoutMode{PayoutModePayment, PayoutModeClaimableBalance}
+}
+
+// Validate checks if the payout mode is valid.
+func (pm PayoutMode) Validate() error {
+	if !slices.Contains(AllPayoutModes(), pm) {
+		return fmt.Errorf("invalid payout mode %q, must be one of %v", pm, AllPayoutModes())
+	}
+	return nil
+}
+
+// ClaimableBalanceStatus is the status of the claimable balance created for a payment.
+type ClaimableBalanceStatus string
+
+const (
+	// ClaimableBalanceStatusUnclaimed is the status of a claimable balance that is still waiting to be claimed.
+	ClaimableBalanceStatusUnclaimed ClaimableBalanceStatus = "UNCLAIMED"
+	// ClaimableBalanceStatusClaimed is the status of a claimable balance that was claimed by the receiver.
+	ClaimableBalanceStatusClaimed ClaimableBalanceStatus = "CLAIMED"
+	// ClaimableBalanceStatusReclaimed is the status of a claimable balance that was reclaimed by the distribution
+	// account.
+	ClaimableBalanceStatusReclaimed ClaimableBalanceStatus = "RECLAIMED"
+)
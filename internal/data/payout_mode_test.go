@@ -0,0 +1,19 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PayoutMode_Validate(t *testing.T) {
+	for _, pm := range AllPayoutModes() {
+		assert.NoError(t, pm.Validate())
+	}
+
+	err := PayoutMode("INVALID").Validate()
+	assert.EqualError(t, err, `invalid payout mode "INVALID", must be one of [PAYMENT CLAIMABLE_BALANCE]`)
+
+	err = PayoutMode("").Validate()
+	assert.EqualError(t, err, `invalid payout mode "", must be one of [PAYMENT CLAIMABLE_BALANCE]`)
+}
@@ -0,0 +1,95 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+)
+
+// MaxReceiverPortalOTPAttempts is the number of times a receiver can try to enter the receiver portal with an OTP
+// before it's discarded and a new one needs to be requested.
+const MaxReceiverPortalOTPAttempts = 5
+
+var ErrInvalidReceiverPortalOTP = errors.New("receiver portal otp is invalid or expired")
+
+// ReceiverPortalOTPModel stores the OTPs sent to receivers to access the receiver portal. Each receiver has at most one
+// OTP, which is stored hashed, replaced when a new one is requested and discarded once it's used.
+type ReceiverPortalOTPModel struct {
+	dbConnectionPool db.DBConnectionPool
+}
+
+// Upsert stores the hash of a new OTP for the receiver, replacing the previous one.
+func (m *ReceiverPortalOTPModel) Upsert(ctx context.Context, sqlExec db.SQLExecuter, receiverID, otp string) error {
+	const query = `
+		INSERT INTO
+			receiver_portal_otps (receiver_id, hashed_otp)
+		VALUES
+			($1, $2)
+		ON CONFLICT (receiver_id) DO UPDATE SET
+			hashed_otp = EXCLUDED.hashed_otp,
+			attempts = 0,
+			created_at = NOW()
+	`
+
+	hashedOTP, err := HashVerificationValue(otp)
+	if err != nil {
+		return fmt.Errorf("hashing receiver portal otp for receiver %s: %w", receiverID, err)
+	}
+
+	_, err = sqlExec.ExecContext(ctx, query, receiverID, hashedOTP)
+	if err != nil {
+		return fmt.Errorf("upserting receiver portal otp for receiver %s: %w", receiverID, err)
+	}
+
+	return nil
+}
+
+// Verify checks the OTP of a receiver, discarding it when it matches, when it's expired, or when it was attempted too
+// many times. ErrInvalidReceiverPortalOTP is returned if it doesn't match or it's no longer valid.
+func (m *ReceiverPortalOTPModel) Verify(ctx context.Context, receiverID, otp string) error {
+	// The attempts and the discarded OTPs are committed even when the OTP is invalid, so the transaction only fails on
+	// database errors.
+	isValid, err := db.RunInTransactionWithResult(ctx, m.dbConnectionPool, nil, func(dbTx db.DBTransaction) (bool, error) {
+		var stored struct {
+			HashedOTP string    `db:"hashed_otp"`
+			Attempts  int       `db:"attempts"`
+			CreatedAt time.Time `db:"created_at"`
+		}
+		err := dbTx.GetContext(ctx, &stored, "SELECT hashed_otp, attempts, created_at FROM receiver_portal_otps WHERE receiver_id = $1 FOR UPDATE", receiverID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return false, nil
+			}
+			return false, fmt.Errorf("getting receiver portal otp for receiver %s: %w", receiverID, err)
+		}
+
+		isExpired := stored.CreatedAt.Add(time.Minute * OTPExpirationTimeMinutes).Before(time.Now())
+		isMatch := CompareVerificationValue(stored.HashedOTP, otp)
+		if !isMatch && !isExpired && stored.Attempts+1 < MaxReceiverPortalOTPAttempts {
+			_, err = dbTx.ExecContext(ctx, "UPDATE receiver_portal_otps SET attempts = attempts + 1 WHERE receiver_id = $1", receiverID)
+			if err != nil {
+				return false, fmt.Errorf("updating receiver portal otp attempts for receiver %s: %w", receiverID, err)
+			}
+			return false, nil
+		}
+
+		_, err = dbTx.ExecContext(ctx, "DELETE FROM receiver_portal_otps WHERE receiver_id = $1", receiverID)
+		if err != nil {
+			return false, fmt.Errorf("deleting receiver portal otp for receiver %s: %w", receiverID, err)
+		}
+
+		return isMatch && !isExpired, nil
+	})
+	if err != nil {
+		return fmt.Errorf("verifying receiver portal otp: %w", err)
+	}
+	if !isValid {
+		return ErrInvalidReceiverPortalOTP
+	}
+
+	return nil
+}
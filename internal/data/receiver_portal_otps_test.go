@@ -0,0 +1,89 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+)
+
+func Test_ReceiverPortalOTPModel(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+
+	getAttempts := func(t *testing.T) int {
+		var attempts int
+		err := dbConnectionPool.GetContext(ctx, &attempts, "SELECT attempts FROM receiver_portal_otps WHERE receiver_id = $1", receiver.ID)
+		require.NoError(t, err)
+		return attempts
+	}
+
+	t.Run("returns an error when the receiver has no OTP", func(t *testing.T) {
+		err := models.ReceiverPortalOTPs.Verify(ctx, receiver.ID, "123456")
+		assert.ErrorIs(t, err, ErrInvalidReceiverPortalOTP)
+	})
+
+	t.Run("🎉 the OTP can only be used once", func(t *testing.T) {
+		require.NoError(t, models.ReceiverPortalOTPs.Upsert(ctx, dbConnectionPool, receiver.ID, "123456"))
+
+		var hashedOTP string
+		err := dbConnectionPool.GetContext(ctx, &hashedOTP, "SELECT hashed_otp FROM receiver_portal_otps WHERE receiver_id = $1", receiver.ID)
+		require.NoError(t, err)
+		assert.NotContains(t, hashedOTP, "123456")
+		assert.True(t, CompareVerificationValue(hashedOTP, "123456"))
+
+		err = models.ReceiverPortalOTPs.Verify(ctx, receiver.ID, "123456")
+		require.NoError(t, err)
+
+		err = models.ReceiverPortalOTPs.Verify(ctx, receiver.ID, "123456")
+		assert.ErrorIs(t, err, ErrInvalidReceiverPortalOTP)
+	})
+
+	t.Run("counts the wrong attempts and discards the OTP after too many of them", func(t *testing.T) {
+		require.NoError(t, models.ReceiverPortalOTPs.Upsert(ctx, dbConnectionPool, receiver.ID, "123456"))
+
+		for i := 1; i < MaxReceiverPortalOTPAttempts; i++ {
+			err := models.ReceiverPortalOTPs.Verify(ctx, receiver.ID, "654321")
+			require.ErrorIs(t, err, ErrInvalidReceiverPortalOTP)
+			assert.Equal(t, i, getAttempts(t))
+		}
+
+		err := models.ReceiverPortalOTPs.Verify(ctx, receiver.ID, "654321")
+		require.ErrorIs(t, err, ErrInvalidReceiverPortalOTP)
+
+		err = models.ReceiverPortalOTPs.Verify(ctx, receiver.ID, "123456")
+		assert.ErrorIs(t, err, ErrInvalidReceiverPortalOTP)
+	})
+
+	t.Run("requesting a new OTP resets the attempts", func(t *testing.T) {
+		require.NoError(t, models.ReceiverPortalOTPs.Upsert(ctx, dbConnectionPool, receiver.ID, "123456"))
+		require.ErrorIs(t, models.ReceiverPortalOTPs.Verify(ctx, receiver.ID, "654321"), ErrInvalidReceiverPortalOTP)
+		assert.Equal(t, 1, getAttempts(t))
+
+		require.NoError(t, models.ReceiverPortalOTPs.Upsert(ctx, dbConnectionPool, receiver.ID, "111111"))
+		assert.Equal(t, 0, getAttempts(t))
+	})
+
+	t.Run("returns an error when the OTP is expired", func(t *testing.T) {
+		require.NoError(t, models.ReceiverPortalOTPs.Upsert(ctx, dbConnectionPool, receiver.ID, "123456"))
+		_, err := dbConnectionPool.ExecContext(ctx, "UPDATE receiver_portal_otps SET created_at = NOW() - INTERVAL '31 minutes' WHERE receiver_id = $1", receiver.ID)
+		require.NoError(t, err)
+
+		err = models.ReceiverPortalOTPs.Verify(ctx, receiver.ID, "123456")
+		assert.ErrorIs(t, err, ErrInvalidReceiverPortalOTP)
+	})
+}
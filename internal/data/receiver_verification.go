@@ -29,6 +29,8 @@ type ReceiverVerification struct {
 	ConfirmedAt         *time.Time              `json:"confirmed_at" db:"confirmed_at"`
 	FailedAt            *time.Time              `json:"failed_at" db:"failed_at"`
 	VerificationChannel *message.MessageChannel `json:"verification_channel" db:"verification_channel"`
+	// Question is the secret question answered by the verification value, for CUSTOM_SECRET_QUESTION verifications.
+	Question *string `json:"question,omitempty" db:"question"`
 }
 
 type ReceiverVerificationModel struct {
@@ -39,8 +41,11 @@ type ReceiverVerificationInsert struct {
 	ReceiverID        string           `db:"receiver_id"`
 	VerificationField VerificationType `db:"verification_field"`
 	VerificationValue string           `db:"hashed_value"`
+	Question          string           `db:"question"`
 }
 
+// MaxAttemptsAllowed is the default number of failed attempts after which a receiver is locked out, used by
+// organizations that haven't configured their own.
 const MaxAttemptsAllowed = 15
 
 func (rvi *ReceiverVerificationInsert) Validate() error {
@@ -129,7 +134,7 @@ func (m *ReceiverVerificationModel) Insert(ctx context.Context, sqlExec db.SQLEx
 	if err != nil {
 		return "", fmt.Errorf("error validating receiver verification insert: %w", err)
 	}
-	hashedValue, err := HashVerificationValue(verificationInsert.VerificationValue)
+	hashedValue, err := verificationInsert.VerificationField.HashValue(verificationInsert.VerificationValue)
 	if err != nil {
 		return "", fmt.Errorf("error hashing verification value: %w", err)
 	}
@@ -138,11 +143,12 @@ func (m *ReceiverVerificationModel) Insert(ctx context.Context, sqlExec db.SQLEx
 		INSERT INTO receiver_verifications (
 		    receiver_id, 
 		    verification_field, 
-		    hashed_value
-		) VALUES ($1, $2, $3)
+		    hashed_value,
+		    question
+		) VALUES ($1, $2, $3, $4)
 	`
 
-	_, err = sqlExec.ExecContext(ctx, query, verificationInsert.ReceiverID, verificationInsert.VerificationField, hashedValue)
+	_, err = sqlExec.ExecContext(ctx, query, verificationInsert.ReceiverID, verificationInsert.VerificationField, hashedValue, utils.SQLNullString(verificationInsert.Question))
 	if err != nil {
 		return "", fmt.Errorf("error inserting receiver verification: %w", err)
 	}
@@ -158,7 +164,7 @@ func (m *ReceiverVerificationModel) UpdateVerificationValue(ctx context.Context,
 	verificationValue string,
 ) error {
 	log.Ctx(ctx).Infof("Calling UpdateVerificationValue for receiver %s and verification field %s", receiverID, verificationField)
-	hashedValue, err := HashVerificationValue(verificationValue)
+	hashedValue, err := verificationField.HashValue(verificationValue)
 	if err != nil {
 		return fmt.Errorf("error hashing verification value: %w", err)
 	}
@@ -177,11 +183,27 @@ func (m *ReceiverVerificationModel) UpdateVerificationValue(ctx context.Context,
 	return nil
 }
 
+// UpdateQuestion updates the secret question answered by the value of a receiver verification.
+func (m *ReceiverVerificationModel) UpdateQuestion(ctx context.Context, sqlExec db.SQLExecuter, receiverID string, verificationField VerificationType, question string) error {
+	query := `
+		UPDATE receiver_verifications
+		SET question = $1
+		WHERE receiver_id = $2 AND verification_field = $3
+	`
+
+	_, err := sqlExec.ExecContext(ctx, query, utils.SQLNullString(question), receiverID, verificationField)
+	if err != nil {
+		return fmt.Errorf("updating question of receiver verification: %w", err)
+	}
+
+	return nil
+}
+
 // UpsertVerificationValue creates or updates the receiver's verification. Even if the verification exists and is
 // already confirmed by the receiver, it will be updated.
 func (m *ReceiverVerificationModel) UpsertVerificationValue(ctx context.Context, sqlExec db.SQLExecuter, userID, receiverID string, verificationField VerificationType, verificationValue string) error {
 	log.Ctx(ctx).Infof("Calling UpsertVerificationValue for receiver %s and verification field %s", receiverID, verificationField)
-	hashedValue, err := HashVerificationValue(verificationValue)
+	hashedValue, err := verificationField.HashValue(verificationValue)
 	if err != nil {
 		return fmt.Errorf("hashing verification value: %w", err)
 	}
@@ -299,9 +321,114 @@ func (m *ReceiverVerificationModel) UpdateReceiverVerification(ctx context.Conte
 	return nil
 }
 
-// ExceededAttempts check if the number of attempts exceeded the max value.
-func (*ReceiverVerificationModel) ExceededAttempts(attempts int) bool {
-	return attempts >= MaxAttemptsAllowed
+// VerificationLockoutPolicy decides when a receiver is locked out for failing to confirm a verification value.
+type VerificationLockoutPolicy struct {
+	// MaxAttempts is the number of failed attempts after which the receiver is locked out.
+	MaxAttempts int
+	// Duration is how long the lockout lasts after the last failed attempt. When it's zero, the lockout lasts until the
+	// attempts are reset.
+	Duration time.Duration
+}
+
+// ExceededAttempts checks if the number of attempts reached the max value.
+func (p VerificationLockoutPolicy) ExceededAttempts(attempts int) bool {
+	maxAttempts := p.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = MaxAttemptsAllowed
+	}
+	return attempts >= maxAttempts
+}
+
+// LockedUntil returns when the lockout of a receiver verification ends, or nil if the lockout only ends when the
+// attempts are reset. It's only meaningful when the attempts were exceeded.
+func (p VerificationLockoutPolicy) LockedUntil(rv ReceiverVerification) *time.Time {
+	if p.Duration <= 0 || rv.FailedAt == nil {
+		return nil
+	}
+	lockedUntil := rv.FailedAt.Add(p.Duration)
+	return &lockedUntil
+}
+
+// IsLocked checks if a receiver verification is locked out at the given time.
+func (p VerificationLockoutPolicy) IsLocked(rv ReceiverVerification, now time.Time) bool {
+	if !p.ExceededAttempts(rv.Attempts) {
+		return false
+	}
+	lockedUntil := p.LockedUntil(rv)
+	return lockedUntil == nil || now.Before(*lockedUntil)
+}
+
+// LockedReceiverVerification is a receiver verification that is locked out, along with the contact info of its
+// receiver.
+type LockedReceiverVerification struct {
+	ReceiverVerification
+	PhoneNumber string     `json:"phone_number,omitempty" db:"phone_number"`
+	Email       string     `json:"email,omitempty" db:"email"`
+	LockedUntil *time.Time `json:"locked_until" db:"-"`
+}
+
+// GetAllLocked returns the receiver verifications that are locked out by the policy, the most recently failed first.
+func (m *ReceiverVerificationModel) GetAllLocked(ctx context.Context, sqlExec db.SQLExecuter, policy VerificationLockoutPolicy) ([]LockedReceiverVerification, error) {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = MaxAttemptsAllowed
+	}
+
+	query := `
+		SELECT
+			rv.*,
+			COALESCE(r.phone_number, '') AS phone_number,
+			COALESCE(r.email, '') AS email
+		FROM
+			receiver_verifications rv
+			JOIN receivers r ON rv.receiver_id = r.id
+		WHERE
+			rv.attempts >= $1
+			AND (
+				$2 = 0
+				OR rv.failed_at IS NULL
+				OR rv.failed_at > NOW() - $2 * INTERVAL '1 second'
+			)
+		ORDER BY
+			rv.failed_at DESC NULLS LAST,
+			rv.receiver_id ASC
+	`
+
+	lockedVerifications := []LockedReceiverVerification{}
+	err := sqlExec.SelectContext(ctx, &lockedVerifications, query, maxAttempts, int64(policy.Duration.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("querying locked receiver verifications: %w", err)
+	}
+
+	for i := range lockedVerifications {
+		lockedVerifications[i].LockedUntil = policy.LockedUntil(lockedVerifications[i].ReceiverVerification)
+	}
+
+	return lockedVerifications, nil
+}
+
+// ResetAttempts resets the failed attempts of all the verifications of a receiver, lifting their lockout. It returns
+// the verifications that had failed attempts.
+func (m *ReceiverVerificationModel) ResetAttempts(ctx context.Context, sqlExec db.SQLExecuter, receiverID string) ([]ReceiverVerification, error) {
+	query := `
+		UPDATE
+			receiver_verifications
+		SET
+			attempts = 0,
+			failed_at = NULL
+		WHERE
+			receiver_id = $1
+			AND attempts > 0
+		RETURNING *
+	`
+
+	receiverVerifications := []ReceiverVerification{}
+	err := sqlExec.SelectContext(ctx, &receiverVerifications, query, receiverID)
+	if err != nil {
+		return nil, fmt.Errorf("resetting attempts of receiver verifications: %w", err)
+	}
+
+	return receiverVerifications, nil
 }
 
 func HashVerificationValue(verificationValue string) (string, error) {
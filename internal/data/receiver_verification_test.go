@@ -271,6 +271,38 @@ func Test_ReceiverVerificationModel_UpdateVerificationValue(t *testing.T) {
 	assert.True(t, verified)
 }
 
+func Test_ReceiverVerificationModel_UpdateQuestion(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+
+	receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	verification := CreateReceiverVerificationFixture(t, ctx, dbConnectionPool, ReceiverVerificationInsert{
+		ReceiverID:        receiver.ID,
+		VerificationField: VerificationTypeCustomSecretQuestion,
+		VerificationValue: "Blue",
+		Question:          "What is your favorite color?",
+	})
+	require.NotNil(t, verification.Question)
+	assert.Equal(t, "What is your favorite color?", *verification.Question)
+
+	receiverVerificationModel := ReceiverVerificationModel{}
+	err = receiverVerificationModel.UpdateQuestion(ctx, dbConnectionPool, receiver.ID, VerificationTypeCustomSecretQuestion, "What is the color of your car?")
+	require.NoError(t, err)
+
+	verifications, err := receiverVerificationModel.GetByReceiverIDsAndVerificationField(ctx, dbConnectionPool, []string{receiver.ID}, VerificationTypeCustomSecretQuestion)
+	require.NoError(t, err)
+	require.Len(t, verifications, 1)
+	require.NotNil(t, verifications[0].Question)
+	assert.Equal(t, "What is the color of your car?", *verifications[0].Question)
+	assert.True(t, VerificationTypeCustomSecretQuestion.CompareValue(verifications[0].HashedValue, "Blue"))
+}
+
 func Test_ReceiverVerificationModel_UpsertVerificationValue(t *testing.T) {
 	dbt := dbtest.Open(t)
 	defer dbt.Close()
@@ -460,20 +492,74 @@ func Test_ReceiverVerificationUpdate_Validate(t *testing.T) {
 	}
 }
 
-func Test_ReceiverVerificationModel_CheckTotalAttempts(t *testing.T) {
-	receiverVerificationModel := &ReceiverVerificationModel{}
-
+func Test_VerificationLockoutPolicy_ExceededAttempts(t *testing.T) {
 	t.Run("attempts exceeded the max value", func(t *testing.T) {
-		attempts := 15
-		e := receiverVerificationModel.ExceededAttempts(attempts)
-		assert.True(t, e)
+		assert.True(t, VerificationLockoutPolicy{MaxAttempts: 15}.ExceededAttempts(15))
 	})
 
 	t.Run("attempts have not exceeded the max value", func(t *testing.T) {
-		attempts := 1
-		e := receiverVerificationModel.ExceededAttempts(attempts)
-		assert.False(t, e)
+		assert.False(t, VerificationLockoutPolicy{MaxAttempts: 15}.ExceededAttempts(1))
 	})
+
+	t.Run("uses the default max value when the policy doesn't have one", func(t *testing.T) {
+		assert.False(t, VerificationLockoutPolicy{}.ExceededAttempts(MaxAttemptsAllowed-1))
+		assert.True(t, VerificationLockoutPolicy{}.ExceededAttempts(MaxAttemptsAllowed))
+	})
+}
+
+func Test_VerificationLockoutPolicy_IsLocked(t *testing.T) {
+	now := time.Now()
+	failedAt := now.Add(-30 * time.Minute)
+
+	testCases := []struct {
+		name            string
+		policy          VerificationLockoutPolicy
+		rv              ReceiverVerification
+		wantLocked      bool
+		wantLockedUntil *time.Time
+	}{
+		{
+			name:       "not locked when the attempts weren't exceeded",
+			policy:     VerificationLockoutPolicy{MaxAttempts: 3, Duration: time.Hour},
+			rv:         ReceiverVerification{Attempts: 2, FailedAt: &failedAt},
+			wantLocked: false,
+		},
+		{
+			name:            "locked while the lockout lasts",
+			policy:          VerificationLockoutPolicy{MaxAttempts: 3, Duration: time.Hour},
+			rv:              ReceiverVerification{Attempts: 3, FailedAt: &failedAt},
+			wantLocked:      true,
+			wantLockedUntil: utils.TimePtr(failedAt.Add(time.Hour)),
+		},
+		{
+			name:            "not locked after the lockout is over",
+			policy:          VerificationLockoutPolicy{MaxAttempts: 3, Duration: 10 * time.Minute},
+			rv:              ReceiverVerification{Attempts: 3, FailedAt: &failedAt},
+			wantLocked:      false,
+			wantLockedUntil: utils.TimePtr(failedAt.Add(10 * time.Minute)),
+		},
+		{
+			name:       "locked until reset when the lockout has no duration",
+			policy:     VerificationLockoutPolicy{MaxAttempts: 3},
+			rv:         ReceiverVerification{Attempts: 3, FailedAt: &failedAt},
+			wantLocked: true,
+		},
+		{
+			name:       "locked until reset when the last failure is unknown",
+			policy:     VerificationLockoutPolicy{MaxAttempts: 3, Duration: time.Hour},
+			rv:         ReceiverVerification{Attempts: 3},
+			wantLocked: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantLocked, tc.policy.IsLocked(tc.rv, now))
+			if tc.wantLocked || tc.wantLockedUntil != nil {
+				assert.Equal(t, tc.wantLockedUntil, tc.policy.LockedUntil(tc.rv))
+			}
+		})
+	}
 }
 
 func Test_ReceiverVerificationModel_GetLatestByContactInfo(t *testing.T) {
@@ -561,3 +647,104 @@ func Test_ReceiverVerification_HashAndCompareVerificationValue(t *testing.T) {
 	compare := CompareVerificationValue(hashedVerificationInfo, verificationValue)
 	assert.True(t, compare)
 }
+
+func Test_ReceiverVerificationModel_GetAllLocked(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	receiverVerificationModel := ReceiverVerificationModel{dbConnectionPool: dbConnectionPool}
+	ctx := context.Background()
+
+	policy := VerificationLockoutPolicy{MaxAttempts: 3, Duration: time.Hour}
+	setAttempts := func(t *testing.T, receiverID string, attempts int, failedAt time.Time) {
+		t.Helper()
+		err := receiverVerificationModel.UpdateReceiverVerification(ctx, ReceiverVerificationUpdate{
+			ReceiverID:          receiverID,
+			VerificationField:   VerificationTypeDateOfBirth,
+			VerificationChannel: message.MessageChannelSMS,
+			Attempts:            utils.IntPtr(attempts),
+			FailedAt:            &failedAt,
+		}, dbConnectionPool)
+		require.NoError(t, err)
+	}
+
+	lockedReceiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	expiredReceiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	unlockedReceiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	for _, receiver := range []*Receiver{lockedReceiver, expiredReceiver, unlockedReceiver} {
+		CreateReceiverVerificationFixture(t, ctx, dbConnectionPool, ReceiverVerificationInsert{
+			ReceiverID:        receiver.ID,
+			VerificationField: VerificationTypeDateOfBirth,
+			VerificationValue: "1990-01-01",
+		})
+	}
+
+	failedAt := time.Now().Add(-10 * time.Minute)
+	setAttempts(t, lockedReceiver.ID, 3, failedAt)
+	setAttempts(t, expiredReceiver.ID, 3, time.Now().Add(-2*time.Hour))
+	setAttempts(t, unlockedReceiver.ID, 2, failedAt)
+
+	t.Run("returns the receivers whose lockout hasn't ended", func(t *testing.T) {
+		lockedVerifications, err := receiverVerificationModel.GetAllLocked(ctx, dbConnectionPool, policy)
+		require.NoError(t, err)
+
+		require.Len(t, lockedVerifications, 1)
+		assert.Equal(t, lockedReceiver.ID, lockedVerifications[0].ReceiverID)
+		assert.Equal(t, lockedReceiver.PhoneNumber, lockedVerifications[0].PhoneNumber)
+		assert.Equal(t, 3, lockedVerifications[0].Attempts)
+		require.NotNil(t, lockedVerifications[0].LockedUntil)
+		assert.WithinDuration(t, failedAt.Add(time.Hour), *lockedVerifications[0].LockedUntil, time.Second)
+	})
+
+	t.Run("returns all the receivers that exceeded the attempts when the lockout has no duration", func(t *testing.T) {
+		lockedVerifications, err := receiverVerificationModel.GetAllLocked(ctx, dbConnectionPool, VerificationLockoutPolicy{MaxAttempts: 3})
+		require.NoError(t, err)
+
+		require.Len(t, lockedVerifications, 2)
+		assert.Equal(t, lockedReceiver.ID, lockedVerifications[0].ReceiverID)
+		assert.Equal(t, expiredReceiver.ID, lockedVerifications[1].ReceiverID)
+		assert.Nil(t, lockedVerifications[0].LockedUntil)
+	})
+}
+
+func Test_ReceiverVerificationModel_ResetAttempts(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	receiverVerificationModel := ReceiverVerificationModel{dbConnectionPool: dbConnectionPool}
+	ctx := context.Background()
+
+	receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+	for _, verificationField := range []VerificationType{VerificationTypeDateOfBirth, VerificationTypePin} {
+		CreateReceiverVerificationFixture(t, ctx, dbConnectionPool, ReceiverVerificationInsert{
+			ReceiverID:        receiver.ID,
+			VerificationField: verificationField,
+			VerificationValue: "1990-01-01",
+		})
+	}
+	err = receiverVerificationModel.UpdateReceiverVerification(ctx, ReceiverVerificationUpdate{
+		ReceiverID:          receiver.ID,
+		VerificationField:   VerificationTypeDateOfBirth,
+		VerificationChannel: message.MessageChannelSMS,
+		Attempts:            utils.IntPtr(MaxAttemptsAllowed),
+		FailedAt:            utils.TimePtr(time.Now()),
+	}, dbConnectionPool)
+	require.NoError(t, err)
+
+	resetVerifications, err := receiverVerificationModel.ResetAttempts(ctx, dbConnectionPool, receiver.ID)
+	require.NoError(t, err)
+	require.Len(t, resetVerifications, 1)
+	assert.Equal(t, VerificationTypeDateOfBirth, resetVerifications[0].VerificationField)
+	assert.Equal(t, 0, resetVerifications[0].Attempts)
+	assert.Nil(t, resetVerifications[0].FailedAt)
+
+	resetVerifications, err = receiverVerificationModel.ResetAttempts(ctx, dbConnectionPool, receiver.ID)
+	require.NoError(t, err)
+	assert.Empty(t, resetVerifications)
+}
@@ -28,6 +28,7 @@ func ReceiversWalletStateMachineWithInitialState(initialState ReceiversWalletSta
 		{From: FlaggedReceiversWalletStatus.State(), To: ReadyReceiversWalletStatus.State()},      // unflagged
 		{From: RegisteredReceiversWalletStatus.State(), To: FlaggedReceiversWalletStatus.State()}, // flagged
 		{From: FlaggedReceiversWalletStatus.State(), To: RegisteredReceiversWalletStatus.State()}, // unflagged
+		{From: RegisteredReceiversWalletStatus.State(), To: ReadyReceiversWalletStatus.State()},   // unregistered by an admin
 	}
 
 	return NewStateMachine(initialState.State(), transitions)
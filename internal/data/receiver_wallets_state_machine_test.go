@@ -49,6 +49,12 @@ func Test_ReceiversWalletStatus_TransitionTo(t *testing.T) {
 			RegisteredReceiversWalletStatus,
 			false,
 		},
+		{
+			"REGISTERED to READY",
+			RegisteredReceiversWalletStatus,
+			ReadyReceiversWalletStatus,
+			false,
+		},
 		{
 			"DRAFT to REGISTERED",
 			DraftReceiversWalletStatus,
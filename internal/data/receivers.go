@@ -239,6 +239,45 @@ func (r *ReceiverModel) Count(ctx context.Context, sqlExec db.SQLExecuter, query
 func (r *ReceiverModel) GetAll(ctx context.Context, sqlExec db.SQLExecuter, queryParams *QueryParams, queryType QueryType) ([]Receiver, error) {
 	receivers := []Receiver{}
 
+	query, params := newReceiverStatsQuery(queryParams, sqlExec, queryType)
+	err := sqlExec.SelectContext(ctx, &receivers, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("querying receivers: %w", err)
+	}
+
+	return receivers, nil
+}
+
+// StreamAll runs the same query as GetAll with QueryTypeSelectAll, but calls fn with each receiver as it's read from the
+// database cursor instead of loading all of them into memory. It stops at the first error returned by fn.
+func (r *ReceiverModel) StreamAll(ctx context.Context, sqlExec db.SQLExecuter, queryParams *QueryParams, fn func(Receiver) error) error {
+	query, params := newReceiverStatsQuery(queryParams, sqlExec, QueryTypeSelectAll)
+
+	rows, err := sqlExec.QueryxContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("querying receivers: %w", err)
+	}
+	defer db.CloseRows(ctx, rows)
+
+	for rows.Next() {
+		var receiver Receiver
+		if err = rows.StructScan(&receiver); err != nil {
+			return fmt.Errorf("scanning receiver: %w", err)
+		}
+		if err = fn(receiver); err != nil {
+			return fmt.Errorf("handling receiver %s: %w", receiver.ID, err)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("iterating receivers: %w", err)
+	}
+
+	return nil
+}
+
+// newReceiverStatsQuery generates the query and parameters to get the receivers matching the given query parameters,
+// along with their payment and wallet statistics.
+func newReceiverStatsQuery(queryParams *QueryParams, sqlExec db.SQLExecuter, queryType QueryType) (string, []interface{}) {
 	baseQuery := `
 		WITH receivers_cte AS (
 			%s
@@ -309,14 +348,7 @@ func (r *ReceiverModel) GetAll(ctx context.Context, sqlExec db.SQLExecuter, quer
 	`
 
 	query := fmt.Sprintf(baseQuery, receiverQuery)
-	query, params := newReceiverQuery(query, queryParams, sqlExec, queryType)
-
-	err := sqlExec.SelectContext(ctx, &receivers, query, params...)
-	if err != nil {
-		return nil, fmt.Errorf("querying receivers: %w", err)
-	}
-
-	return receivers, nil
+	return newReceiverQuery(query, queryParams, sqlExec, queryType)
 }
 
 // newReceiverQuery generates the full query and parameters for a receiver search query
@@ -17,6 +17,7 @@ import (
 	"github.com/stellar/go/support/log"
 
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
 )
 
@@ -25,6 +26,11 @@ const OTPExpirationTimeMinutes = 30
 type ReceiversWalletStatusHistoryEntry struct {
 	Status    ReceiversWalletStatus `json:"status"`
 	Timestamp time.Time             `json:"timestamp"`
+	// UserID, StellarAddress and StellarMemo are only set when the receiver wallet was unregistered by a user, holding
+	// the user and the Stellar account the receiver wallet was registered with.
+	UserID         string `json:"user_id,omitempty"`
+	StellarAddress string `json:"stellar_address,omitempty"`
+	StellarMemo    string `json:"stellar_memo,omitempty"`
 }
 
 type ReceiversWalletStatusHistory []ReceiversWalletStatusHistoryEntry
@@ -87,6 +93,11 @@ type ReceiverWallet struct {
 	InvitedAt                         *time.Time `json:"invited_at,omitempty" db:"invited_at"`
 	LastMessageSentAt                 *time.Time `json:"last_message_sent_at,omitempty" db:"last_message_sent_at"`
 	InvitationSentAt                  *time.Time `json:"invitation_sent_at" db:"invitation_sent_at"`
+	// LastMessageStatus, LastMessageType and LastMessageErrorCode describe the last message sent to the receiver
+	// wallet, with the delivery status reported by the provider.
+	LastMessageStatus    MessageStatus         `json:"last_message_status,omitempty" db:"last_message_status"`
+	LastMessageType      message.MessengerType `json:"last_message_type,omitempty" db:"last_message_type"`
+	LastMessageErrorCode string                `json:"last_message_error_code,omitempty" db:"last_message_error_code"`
 	ReceiverWalletStats
 }
 
@@ -167,8 +178,17 @@ func (rw *ReceiverWalletModel) GetWithReceiverIds(ctx context.Context, sqlExec d
 			MAX(m.created_at) as last_message_sent_at
 		FROM receiver_wallets_cte rwc
 		LEFT JOIN messages m ON rwc.id = m.receiver_wallet_id
-		WHERE m.status = 'SUCCESS'
+		WHERE m.status IN ('SUCCESS', 'DELIVERED')
 		GROUP BY (rwc.id)
+	), receiver_wallets_last_message AS (
+		SELECT DISTINCT ON (m.receiver_wallet_id)
+			m.receiver_wallet_id,
+			m.status as last_message_status,
+			m.type as last_message_type,
+			m.error_code as last_message_error_code
+		FROM receiver_wallets_cte rwc
+		JOIN messages m ON rwc.id = m.receiver_wallet_id
+		ORDER BY m.receiver_wallet_id, m.created_at DESC
 	)
 	SELECT 
 		rwc.id,
@@ -192,10 +212,14 @@ func (rw *ReceiverWalletModel) GetWithReceiverIds(ctx context.Context, sqlExec d
 		COALESCE(rws.remaining_payments, '0') as remaining_payments,
 		rws.received_amounts,
 		rwm.invited_at as invited_at,
-		rwm.last_message_sent_at as last_message_sent_at
+		rwm.last_message_sent_at as last_message_sent_at,
+		COALESCE(rwlm.last_message_status::text, '') as last_message_status,
+		COALESCE(rwlm.last_message_type::text, '') as last_message_type,
+		COALESCE(rwlm.last_message_error_code, '') as last_message_error_code
 	FROM receiver_wallets_cte rwc
 	LEFT JOIN receiver_wallets_stats_aggregate rws ON rws.receiver_wallet_id = rwc.id
 	LEFT JOIN receiver_wallets_messages rwm ON rwm.receiver_wallet_id = rwc.id
+	LEFT JOIN receiver_wallets_last_message rwlm ON rwlm.receiver_wallet_id = rwc.id
 	ORDER BY rwc.created_at
 	`
 
@@ -551,6 +575,83 @@ func (rw *ReceiverWalletModel) RetryInvitationMessage(ctx context.Context, sqlEx
 	return &receiverWallet, nil
 }
 
+var (
+	ErrReceiverWalletNotRegistered      = errors.New("receiver wallet is not registered")
+	ErrReceiverWalletHasPendingPayments = errors.New("receiver wallet has payments pending submission to the Stellar network")
+)
+
+// Unregister transitions a REGISTERED receiver wallet back to READY so the receiver can register again, possibly with a
+// different Stellar account. The Stellar account it was registered with is kept in the status history along with the
+// user who unregistered it, and the invitation is reset so it's sent again. Receiver wallets with PENDING payments
+// can't be unregistered, since those payments may still be sent to the previous Stellar account.
+func (rw *ReceiverWalletModel) Unregister(ctx context.Context, sqlExec db.SQLExecuter, receiverWalletID, userID string) (*ReceiverWallet, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required: %w", ErrMissingInput)
+	}
+
+	var status ReceiversWalletStatus
+	err := sqlExec.GetContext(ctx, &status, "SELECT status FROM receiver_wallets WHERE id = $1 FOR UPDATE", receiverWalletID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("getting status of receiver wallet %s: %w", receiverWalletID, err)
+	}
+	if status != RegisteredReceiversWalletStatus {
+		return nil, fmt.Errorf("unregistering receiver wallet %s with status %s: %w", receiverWalletID, status, ErrReceiverWalletNotRegistered)
+	}
+
+	var numPendingPayments int
+	err = sqlExec.GetContext(ctx, &numPendingPayments, "SELECT COUNT(*) FROM payments WHERE receiver_wallet_id = $1 AND status = $2", receiverWalletID, PendingPaymentStatus)
+	if err != nil {
+		return nil, fmt.Errorf("counting pending payments of receiver wallet %s: %w", receiverWalletID, err)
+	}
+	if numPendingPayments > 0 {
+		return nil, ErrReceiverWalletHasPendingPayments
+	}
+
+	const query = `
+		UPDATE
+			receiver_wallets
+		SET
+			status = $2,
+			status_history = array_append(status_history, create_receiver_wallet_status_history(NOW(), $2) || jsonb_strip_nulls(jsonb_build_object(
+				'user_id', $3::text,
+				'stellar_address', stellar_address,
+				'stellar_memo', stellar_memo
+			))),
+			stellar_address = NULL,
+			stellar_memo = NULL,
+			stellar_memo_type = NULL,
+			otp = NULL,
+			otp_created_at = NULL,
+			otp_confirmed_at = NULL,
+			otp_confirmed_with = NULL,
+			anchor_platform_transaction_id = NULL,
+			anchor_platform_transaction_synced_at = NULL,
+			invitation_sent_at = NULL
+		WHERE
+			id = $1
+		RETURNING
+			id,
+			receiver_id as "receiver.id",
+			wallet_id as "wallet.id",
+			status,
+			status_history,
+			invitation_sent_at,
+			created_at,
+			updated_at
+	`
+
+	var receiverWallet ReceiverWallet
+	err = sqlExec.GetContext(ctx, &receiverWallet, query, receiverWalletID, ReadyReceiversWalletStatus, userID)
+	if err != nil {
+		return nil, fmt.Errorf("unregistering receiver wallet %s: %w", receiverWalletID, err)
+	}
+
+	return &receiverWallet, nil
+}
+
 func (rw *ReceiverWalletModel) UpdateInvitationSentAt(ctx context.Context, sqlExec db.SQLExecuter, receiverWalletID ...string) ([]ReceiverWallet, error) {
 	const query = `
 		UPDATE
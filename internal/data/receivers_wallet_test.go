@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 	"time"
@@ -138,6 +139,8 @@ func Test_ReceiversWalletModelGetWithReceiverId(t *testing.T) {
 				UpdatedAt:         receiverWallet1.CreatedAt,
 				InvitedAt:         &message1.CreatedAt,
 				LastMessageSentAt: &message2.CreatedAt,
+				LastMessageStatus: SuccessMessageStatus,
+				LastMessageType:   message.MessengerTypeTwilioSMS,
 				ReceiverWalletStats: ReceiverWalletStats{
 					TotalPayments:     "0",
 					PaymentsReceived:  "0",
@@ -203,6 +206,8 @@ func Test_ReceiversWalletModelGetWithReceiverId(t *testing.T) {
 				UpdatedAt:         receiverWallet1.CreatedAt,
 				InvitedAt:         &message1.CreatedAt,
 				LastMessageSentAt: &message2.CreatedAt,
+				LastMessageStatus: SuccessMessageStatus,
+				LastMessageType:   message.MessengerTypeTwilioSMS,
 				ReceiverWalletStats: ReceiverWalletStats{
 					TotalPayments:     "2",
 					PaymentsReceived:  "1",
@@ -309,6 +314,8 @@ func Test_ReceiversWalletModelGetWithReceiverId(t *testing.T) {
 				UpdatedAt:         receiverWallet1.CreatedAt,
 				InvitedAt:         &message1.CreatedAt,
 				LastMessageSentAt: &message2.CreatedAt,
+				LastMessageStatus: SuccessMessageStatus,
+				LastMessageType:   message.MessengerTypeTwilioSMS,
 				ReceiverWalletStats: ReceiverWalletStats{
 					TotalPayments:     "2",
 					PaymentsReceived:  "1",
@@ -343,6 +350,8 @@ func Test_ReceiversWalletModelGetWithReceiverId(t *testing.T) {
 				UpdatedAt:         receiverWallet2.CreatedAt,
 				InvitedAt:         &message3.CreatedAt,
 				LastMessageSentAt: &message4.CreatedAt,
+				LastMessageStatus: SuccessMessageStatus,
+				LastMessageType:   message.MessengerTypeTwilioSMS,
 				ReceiverWalletStats: ReceiverWalletStats{
 					TotalPayments:     "1",
 					PaymentsReceived:  "0",
@@ -424,6 +433,8 @@ func Test_ReceiversWalletModelGetWithReceiverId(t *testing.T) {
 				UpdatedAt:         receiverWallet.CreatedAt,
 				InvitedAt:         &message1.CreatedAt,
 				LastMessageSentAt: &message2.CreatedAt,
+				LastMessageStatus: SuccessMessageStatus,
+				LastMessageType:   message.MessengerTypeTwilioSMS,
 				ReceiverWalletStats: ReceiverWalletStats{
 					TotalPayments:     "0",
 					PaymentsReceived:  "0",
@@ -1670,6 +1681,95 @@ func Test_ReceiverWalletModel_Update(t *testing.T) {
 	})
 }
 
+func Test_ReceiverWalletModel_Unregister(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	wallet := CreateWalletFixture(t, ctx, dbConnectionPool, "Wallet", "https://www.wallet.com", "www.wallet.com", "wallet://")
+	asset := CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVV")
+	disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{
+		Wallet: wallet,
+		Asset:  asset,
+		Status: StartedDisbursementStatus,
+	})
+
+	t.Run("returns error when user id is empty", func(t *testing.T) {
+		_, err := models.ReceiverWallet.Unregister(ctx, dbConnectionPool, "receiver-wallet-id", "")
+		assert.ErrorIs(t, err, ErrMissingInput)
+	})
+
+	t.Run("returns error when receiver wallet does not exist", func(t *testing.T) {
+		_, err := models.ReceiverWallet.Unregister(ctx, dbConnectionPool, "invalid-id", "user-id")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("returns error when receiver wallet is not registered", func(t *testing.T) {
+		receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+		receiverWallet := CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, ReadyReceiversWalletStatus)
+
+		_, err := models.ReceiverWallet.Unregister(ctx, dbConnectionPool, receiverWallet.ID, "user-id")
+		assert.ErrorIs(t, err, ErrReceiverWalletNotRegistered)
+	})
+
+	t.Run("returns error when receiver wallet has pending payments", func(t *testing.T) {
+		receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+		receiverWallet := CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, RegisteredReceiversWalletStatus)
+		CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &Payment{
+			Amount:         "1",
+			Status:         PendingPaymentStatus,
+			Disbursement:   disbursement,
+			Asset:          *asset,
+			ReceiverWallet: receiverWallet,
+		})
+
+		_, err := models.ReceiverWallet.Unregister(ctx, dbConnectionPool, receiverWallet.ID, "user-id")
+		assert.ErrorIs(t, err, ErrReceiverWalletHasPendingPayments)
+	})
+
+	t.Run("🎉 unregisters the receiver wallet keeping its stellar account in the status history", func(t *testing.T) {
+		receiver := CreateReceiverFixture(t, ctx, dbConnectionPool, &Receiver{})
+		receiverWallet := CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, RegisteredReceiversWalletStatus)
+		require.NotEmpty(t, receiverWallet.StellarAddress)
+
+		updatedReceiverWallet, err := models.ReceiverWallet.Unregister(ctx, dbConnectionPool, receiverWallet.ID, "user-id")
+		require.NoError(t, err)
+		assert.Equal(t, receiverWallet.ID, updatedReceiverWallet.ID)
+		assert.Equal(t, receiver.ID, updatedReceiverWallet.Receiver.ID)
+		assert.Equal(t, wallet.ID, updatedReceiverWallet.Wallet.ID)
+		assert.Equal(t, ReadyReceiversWalletStatus, updatedReceiverWallet.Status)
+		assert.Nil(t, updatedReceiverWallet.InvitationSentAt)
+
+		lastEntry := updatedReceiverWallet.StatusHistory[len(updatedReceiverWallet.StatusHistory)-1]
+		assert.Equal(t, ReadyReceiversWalletStatus, lastEntry.Status)
+		assert.Equal(t, "user-id", lastEntry.UserID)
+		assert.Equal(t, receiverWallet.StellarAddress, lastEntry.StellarAddress)
+		assert.Equal(t, receiverWallet.StellarMemo, lastEntry.StellarMemo)
+
+		receiverWallets, err := models.ReceiverWallet.GetByReceiverIDsAndWalletID(ctx, dbConnectionPool, []string{receiver.ID}, wallet.ID)
+		require.NoError(t, err)
+		require.Len(t, receiverWallets, 1)
+		assert.Equal(t, ReadyReceiversWalletStatus, receiverWallets[0].Status)
+
+		var stellarAddress, anchorPlatformTransactionID sql.NullString
+		err = dbConnectionPool.QueryRowxContext(ctx, "SELECT stellar_address, anchor_platform_transaction_id FROM receiver_wallets WHERE id = $1", receiverWallet.ID).
+			Scan(&stellarAddress, &anchorPlatformTransactionID)
+		require.NoError(t, err)
+		assert.False(t, stellarAddress.Valid)
+		assert.False(t, anchorPlatformTransactionID.Valid)
+
+		_, err = models.ReceiverWallet.Unregister(ctx, dbConnectionPool, receiverWallet.ID, "user-id")
+		assert.ErrorIs(t, err, ErrReceiverWalletNotRegistered)
+	})
+}
+
 func Test_ReceiverWalletModel_GetByIDs(t *testing.T) {
 	dbt := dbtest.Open(t)
 	defer dbt.Close()
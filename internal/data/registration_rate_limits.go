@@ -0,0 +1,63 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+)
+
+// MaxRegistrationRateLimitWindowMinutes is the longest window an organization can use to rate limit the wallet
+// registration endpoints. Counters older than it are discarded.
+const MaxRegistrationRateLimitWindowMinutes = 24 * 60
+
+// RegistrationRateLimitHit is the state of a rate limit counter after a request was counted.
+type RegistrationRateLimitHit struct {
+	// Hits is the number of requests counted in the current window, including the last one.
+	Hits            int       `db:"hits"`
+	WindowStartedAt time.Time `db:"window_started_at"`
+}
+
+// RegistrationRateLimitModel counts the requests made to the wallet registration endpoints in fixed windows, so they
+// can be rate limited across all the instances of the SDP.
+type RegistrationRateLimitModel struct {
+	dbConnectionPool db.DBConnectionPool
+}
+
+// Hit counts a request made to the endpoint by the key, which identifies who made it, starting a new window when the
+// current one is older than the window duration.
+func (m *RegistrationRateLimitModel) Hit(ctx context.Context, endpoint, key string, window time.Duration) (*RegistrationRateLimitHit, error) {
+	const query = `
+		WITH expired_counters AS (
+			DELETE FROM
+				registration_rate_limits
+			WHERE
+				window_started_at < NOW() - $4 * INTERVAL '1 minute'
+				AND (endpoint, key) != ($1, $2)
+		)
+		INSERT INTO
+			registration_rate_limits (endpoint, key)
+		VALUES
+			($1, $2)
+		ON CONFLICT (endpoint, key) DO UPDATE SET
+			hits = CASE
+				WHEN registration_rate_limits.window_started_at <= NOW() - $3 * INTERVAL '1 second' THEN 1
+				ELSE registration_rate_limits.hits + 1
+			END,
+			window_started_at = CASE
+				WHEN registration_rate_limits.window_started_at <= NOW() - $3 * INTERVAL '1 second' THEN NOW()
+				ELSE registration_rate_limits.window_started_at
+			END
+		RETURNING
+			hits, window_started_at
+	`
+
+	var hit RegistrationRateLimitHit
+	err := m.dbConnectionPool.GetContext(ctx, &hit, query, endpoint, key, int64(window.Seconds()), MaxRegistrationRateLimitWindowMinutes)
+	if err != nil {
+		return nil, fmt.Errorf("counting registration request to %s: %w", endpoint, err)
+	}
+
+	return &hit, nil
+}
@@ -0,0 +1,71 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+)
+
+func Test_RegistrationRateLimitModel_Hit(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	t.Run("counts the requests of each endpoint and key separately", func(t *testing.T) {
+		for i := 1; i <= 3; i++ {
+			hit, err := models.RegistrationRateLimits.Hit(ctx, "otp", "ip:127.0.0.1", time.Hour)
+			require.NoError(t, err)
+			assert.Equal(t, i, hit.Hits)
+		}
+
+		hit, err := models.RegistrationRateLimits.Hit(ctx, "verification", "ip:127.0.0.1", time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, hit.Hits)
+
+		hit, err = models.RegistrationRateLimits.Hit(ctx, "otp", "ip:10.0.0.1", time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, hit.Hits)
+	})
+
+	t.Run("starts a new window when the current one is over", func(t *testing.T) {
+		_, err := dbConnectionPool.ExecContext(ctx, `
+			INSERT INTO registration_rate_limits (endpoint, key, hits, window_started_at)
+			VALUES ('otp', 'contact:expired', 10, NOW() - INTERVAL '2 hours')
+		`)
+		require.NoError(t, err)
+
+		hit, err := models.RegistrationRateLimits.Hit(ctx, "otp", "contact:expired", time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, hit.Hits)
+		assert.WithinDuration(t, time.Now(), hit.WindowStartedAt, time.Minute)
+	})
+
+	t.Run("discards the counters older than the longest window", func(t *testing.T) {
+		_, err := dbConnectionPool.ExecContext(ctx, `
+			INSERT INTO registration_rate_limits (endpoint, key, hits, window_started_at)
+			VALUES ('otp', 'contact:stale', 10, NOW() - INTERVAL '2 days')
+		`)
+		require.NoError(t, err)
+
+		_, err = models.RegistrationRateLimits.Hit(ctx, "otp", "contact:other", time.Hour)
+		require.NoError(t, err)
+
+		var count int
+		err = dbConnectionPool.GetContext(ctx, &count, "SELECT COUNT(*) FROM registration_rate_limits WHERE key = 'contact:stale'")
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
@@ -1,20 +1,147 @@
 package data
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
 type VerificationType string
 
 const (
-	VerificationTypeDateOfBirth VerificationType = "DATE_OF_BIRTH"
-	VerificationTypeYearMonth   VerificationType = "YEAR_MONTH"
-	VerificationTypePin         VerificationType = "PIN"
-	VerificationTypeNationalID  VerificationType = "NATIONAL_ID_NUMBER"
+	VerificationTypeDateOfBirth          VerificationType = "DATE_OF_BIRTH"
+	VerificationTypeYearMonth            VerificationType = "YEAR_MONTH"
+	VerificationTypePin                  VerificationType = "PIN"
+	VerificationTypeNationalID           VerificationType = "NATIONAL_ID_NUMBER"
+	VerificationTypeTaxID                VerificationType = "TAX_ID"
+	VerificationTypePhoneLast4           VerificationType = "PHONE_LAST_4"
+	VerificationTypeCustomSecretQuestion VerificationType = "CUSTOM_SECRET_QUESTION"
 )
 
+// VerificationTypeDefinition describes how the values of a verification type are validated and hashed, and how the
+// registration page asks receivers for them.
+type VerificationTypeDefinition struct {
+	Type VerificationType `json:"type"`
+	// Name is how the verification value is referred to in validation errors, e.g. "date of birth".
+	Name string `json:"-"`
+	// Label and InputType are the label and the HTML input type of the verification field in the registration page.
+	Label     string `json:"label"`
+	InputType string `json:"input_type"`
+	// Validate checks the format of a verification value.
+	Validate func(value string) error `json:"-"`
+	// Normalize returns the form of a verification value that is hashed and compared, so the different ways of writing
+	// the same value match. The value is used as is when it's nil.
+	Normalize func(value string) string `json:"-"`
+}
+
+// verificationTypeDefinitions holds the definitions of all the verification types, in the order they're listed. A new
+// verification type only needs to be defined here, and added to the `verification_type` enum in the database.
+var verificationTypeDefinitions = []VerificationTypeDefinition{
+	{
+		Type:      VerificationTypeDateOfBirth,
+		Name:      "date of birth",
+		Label:     "Date of birth",
+		InputType: "date",
+		Validate:  utils.ValidateDateOfBirthVerification,
+	},
+	{
+		Type:      VerificationTypeYearMonth,
+		Name:      "year/month",
+		Label:     "Date of birth (Year/Month)",
+		InputType: "month",
+		Validate:  utils.ValidateYearMonthVerification,
+	},
+	{
+		Type:      VerificationTypePin,
+		Name:      "pin",
+		Label:     "Pin",
+		InputType: "text",
+		Validate:  utils.ValidatePinVerification,
+	},
+	{
+		Type:      VerificationTypeNationalID,
+		Name:      "national id",
+		Label:     "National ID number",
+		InputType: "text",
+		Validate:  utils.ValidateNationalIDVerification,
+	},
+	{
+		Type:      VerificationTypeTaxID,
+		Name:      "tax id",
+		Label:     "Tax ID (country code and number, e.g. BR:529.982.247-25)",
+		InputType: "text",
+		Validate:  utils.ValidateTaxIDVerification,
+		Normalize: utils.NormalizeTaxID,
+	},
+	{
+		Type:      VerificationTypePhoneLast4,
+		Name:      "phone last 4 digits",
+		Label:     "Last 4 digits of your phone number",
+		InputType: "text",
+		Validate:  utils.ValidatePhoneLast4Verification,
+	},
+	{
+		Type:      VerificationTypeCustomSecretQuestion,
+		Name:      "secret question answer",
+		Label:     "Answer to your secret question",
+		InputType: "text",
+		Validate:  utils.ValidateSecretAnswerVerification,
+		// Answers are compared ignoring case and extra whitespace.
+		Normalize: func(value string) string {
+			return strings.ToLower(strings.Join(strings.Fields(value), " "))
+		},
+	},
+}
+
 // GetAllVerificationTypes returns all the available verification types.
 func GetAllVerificationTypes() []VerificationType {
-	return []VerificationType{
-		VerificationTypeDateOfBirth,
-		VerificationTypeYearMonth,
-		VerificationTypePin,
-		VerificationTypeNationalID,
+	verificationTypes := make([]VerificationType, 0, len(verificationTypeDefinitions))
+	for _, definition := range verificationTypeDefinitions {
+		verificationTypes = append(verificationTypes, definition.Type)
+	}
+	return verificationTypes
+}
+
+// GetAllVerificationTypeDefinitions returns the definitions of all the available verification types.
+func GetAllVerificationTypeDefinitions() []VerificationTypeDefinition {
+	return verificationTypeDefinitions
+}
+
+// Definition returns the definition of the verification type, or false if it isn't a valid verification type.
+func (vt VerificationType) Definition() (VerificationTypeDefinition, bool) {
+	for _, definition := range verificationTypeDefinitions {
+		if definition.Type == vt {
+			return definition, true
+		}
+	}
+	return VerificationTypeDefinition{}, false
+}
+
+// ValidateValue validates the format of a value of the verification type.
+func (vt VerificationType) ValidateValue(value string) error {
+	definition, ok := vt.Definition()
+	if !ok {
+		return fmt.Errorf("invalid verification type %q", vt)
 	}
+	return definition.Validate(value)
+}
+
+// NormalizeValue returns the form of a value of the verification type that is hashed and compared.
+func (vt VerificationType) NormalizeValue(value string) string {
+	definition, ok := vt.Definition()
+	if !ok || definition.Normalize == nil {
+		return value
+	}
+	return definition.Normalize(value)
+}
+
+// HashValue hashes a value of the verification type with HashVerificationValue, after normalizing it.
+func (vt VerificationType) HashValue(value string) (string, error) {
+	return HashVerificationValue(vt.NormalizeValue(value))
+}
+
+// CompareValue checks a value of the verification type against a hash returned by HashValue.
+func (vt VerificationType) CompareValue(hashedValue, value string) bool {
+	return CompareVerificationValue(hashedValue, vt.NormalizeValue(value))
 }
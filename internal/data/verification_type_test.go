@@ -0,0 +1,90 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetAllVerificationTypeDefinitions(t *testing.T) {
+	definitions := GetAllVerificationTypeDefinitions()
+	require.Len(t, definitions, len(GetAllVerificationTypes()))
+
+	for i, definition := range definitions {
+		t.Run(string(definition.Type), func(t *testing.T) {
+			assert.Equal(t, GetAllVerificationTypes()[i], definition.Type)
+			assert.NotEmpty(t, definition.Name)
+			assert.NotEmpty(t, definition.Label)
+			assert.NotEmpty(t, definition.InputType)
+			assert.NotNil(t, definition.Validate)
+
+			gotDefinition, ok := definition.Type.Definition()
+			require.True(t, ok)
+			assert.Equal(t, definition.Type, gotDefinition.Type)
+		})
+	}
+
+	_, ok := VerificationType("UNKNOWN").Definition()
+	assert.False(t, ok)
+}
+
+func Test_VerificationType_ValidateValue(t *testing.T) {
+	testCases := []struct {
+		verificationType VerificationType
+		value            string
+		wantErr          string
+	}{
+		{VerificationTypeDateOfBirth, "1990-01-01", ""},
+		{VerificationTypeDateOfBirth, "1990/01/01", "invalid date of birth format. Correct format: 1990-01-30"},
+		{VerificationTypeTaxID, "BR:529.982.247-25", ""},
+		{VerificationTypeTaxID, "BR:529.982.247-26", "invalid BR tax id: CPF check digits don't match"},
+		{VerificationTypePhoneLast4, "1234", ""},
+		{VerificationTypePhoneLast4, "12345", "invalid phone last 4 digits. Needs to be a 4 digit value"},
+		{VerificationTypeCustomSecretQuestion, "Rex", ""},
+		{VerificationTypeCustomSecretQuestion, "", "secret question answer cannot be empty"},
+		{VerificationType("UNKNOWN"), "value", `invalid verification type "UNKNOWN"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.verificationType)+"/"+tc.value, func(t *testing.T) {
+			err := tc.verificationType.ValidateValue(tc.value)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func Test_VerificationType_HashValue(t *testing.T) {
+	testCases := []struct {
+		verificationType VerificationType
+		value            string
+		matchingValue    string
+		mismatchingValue string
+	}{
+		{VerificationTypePin, "1234", "1234", "1235"},
+		{VerificationTypeNationalID, "ABC123", "ABC123", "abc123"},
+		{VerificationTypeTaxID, "BR:529.982.247-25", "br:52998224725", "BR:11144477735"},
+		{VerificationTypeCustomSecretQuestion, "The  Beatles", " the beatles ", "the rolling stones"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.verificationType), func(t *testing.T) {
+			hashedValue, err := tc.verificationType.HashValue(tc.value)
+			require.NoError(t, err)
+
+			assert.True(t, tc.verificationType.CompareValue(hashedValue, tc.value))
+			assert.True(t, tc.verificationType.CompareValue(hashedValue, tc.matchingValue))
+			assert.False(t, tc.verificationType.CompareValue(hashedValue, tc.mismatchingValue))
+		})
+	}
+
+	t.Run("values of verification types without normalization are hashed as is", func(t *testing.T) {
+		hashedValue, err := VerificationTypeDateOfBirth.HashValue("1990-01-01")
+		require.NoError(t, err)
+		assert.True(t, CompareVerificationValue(hashedValue, "1990-01-01"))
+	})
+}
@@ -0,0 +1,177 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+// WebhookDelivery is an event to be sent to a webhook subscription. Deliveries are created by database triggers when the
+// status of a payment, disbursement or receiver wallet changes, and are retried with exponential backoff until they
+// succeed or run out of attempts.
+type WebhookDelivery struct {
+	ID                 string                `json:"id" db:"id"`
+	SubscriptionID     string                `json:"subscription_id" db:"subscription_id"`
+	EventType          WebhookEventType      `json:"event_type" db:"event_type"`
+	Payload            json.RawMessage       `json:"payload" db:"payload"`
+	Status             WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts           int                   `json:"attempts" db:"attempts"`
+	NextAttemptAt      time.Time             `json:"next_attempt_at" db:"next_attempt_at"`
+	LastAttemptAt      *time.Time            `json:"last_attempt_at,omitempty" db:"last_attempt_at"`
+	LastResponseStatus *int                  `json:"last_response_status,omitempty" db:"last_response_status"`
+	LastError          string                `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt          time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time             `json:"updated_at" db:"updated_at"`
+	// The subscription fields are only loaded for the deliveries that are about to be sent.
+	SubscriptionURL             string `json:"-" db:"subscription_url"`
+	SubscriptionEncryptedSecret string `json:"-" db:"subscription_encrypted_secret"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	PendingWebhookDeliveryStatus WebhookDeliveryStatus = "PENDING"
+	SuccessWebhookDeliveryStatus WebhookDeliveryStatus = "SUCCESS"
+	FailedWebhookDeliveryStatus  WebhookDeliveryStatus = "FAILED"
+)
+
+// WebhookDeliveryAttempt is the outcome of an attempt to send a delivery.
+type WebhookDeliveryAttempt struct {
+	Status         WebhookDeliveryStatus
+	AttemptedAt    time.Time
+	NextAttemptAt  time.Time
+	ResponseStatus int
+	Error          string
+}
+
+type WebhookDeliveryModel struct {
+	dbConnectionPool db.DBConnectionPool
+}
+
+const selectWebhookDeliveryQuery = `
+	SELECT
+		wd.id,
+		wd.subscription_id,
+		wd.event_type,
+		wd.payload,
+		wd.status,
+		wd.attempts,
+		wd.next_attempt_at,
+		wd.last_attempt_at,
+		wd.last_response_status,
+		COALESCE(wd.last_error, '') AS last_error,
+		wd.created_at,
+		wd.updated_at
+	FROM
+		webhook_deliveries wd
+`
+
+// GetAllBySubscriptionID returns the delivery log of a subscription, most recent first.
+func (m *WebhookDeliveryModel) GetAllBySubscriptionID(ctx context.Context, sqlExec db.SQLExecuter, subscriptionID string, limit int) ([]*WebhookDelivery, error) {
+	deliveries := []*WebhookDelivery{}
+
+	query := fmt.Sprintf("%s %s", selectWebhookDeliveryQuery, "WHERE wd.subscription_id = $1 ORDER BY wd.created_at DESC LIMIT $2")
+	err := sqlExec.SelectContext(ctx, &deliveries, query, subscriptionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying deliveries of webhook subscription %s: %w", subscriptionID, err)
+	}
+
+	return deliveries, nil
+}
+
+// ClaimPending claims the pending deliveries due at or before now, oldest first, and returns them along with the URL and
+// secret of their subscriptions. Claimed deliveries are postponed until claimedUntil, so they're not picked up again
+// while they're being sent, and are retried after that if their attempt is never recorded. Deliveries locked by
+// another transaction are skipped.
+func (m *WebhookDeliveryModel) ClaimPending(ctx context.Context, sqlExec db.SQLExecuter, now, claimedUntil time.Time, limit int) ([]*WebhookDelivery, error) {
+	const query = `
+		WITH claimed_deliveries AS (
+			UPDATE
+				webhook_deliveries
+			SET
+				next_attempt_at = $3
+			WHERE
+				id IN (
+					SELECT id
+					FROM webhook_deliveries
+					WHERE status = $1 AND next_attempt_at <= $2
+					ORDER BY next_attempt_at ASC
+					LIMIT $4
+					FOR UPDATE SKIP LOCKED
+				)
+			RETURNING *
+		)
+		SELECT
+			wd.id,
+			wd.subscription_id,
+			wd.event_type,
+			wd.payload,
+			wd.status,
+			wd.attempts,
+			wd.next_attempt_at,
+			wd.last_attempt_at,
+			wd.last_response_status,
+			COALESCE(wd.last_error, '') AS last_error,
+			wd.created_at,
+			wd.updated_at,
+			ws.url AS subscription_url,
+			ws.encrypted_secret AS subscription_encrypted_secret
+		FROM
+			claimed_deliveries wd
+		JOIN webhook_subscriptions ws ON wd.subscription_id = ws.id
+		ORDER BY wd.created_at ASC
+	`
+
+	deliveries := []*WebhookDelivery{}
+	err := sqlExec.SelectContext(ctx, &deliveries, query, PendingWebhookDeliveryStatus, now, claimedUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("claiming pending webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// UpdateAttempt records the outcome of an attempt to send a delivery.
+func (m *WebhookDeliveryModel) UpdateAttempt(ctx context.Context, sqlExec db.SQLExecuter, id string, attempt WebhookDeliveryAttempt) error {
+	const query = `
+		UPDATE
+			webhook_deliveries
+		SET
+			status = $1,
+			attempts = attempts + 1,
+			last_attempt_at = $2,
+			next_attempt_at = COALESCE($3, next_attempt_at),
+			last_response_status = $4,
+			last_error = $5
+		WHERE
+			id = $6
+	`
+
+	var nextAttemptAt *time.Time
+	if !attempt.NextAttemptAt.IsZero() {
+		nextAttemptAt = &attempt.NextAttemptAt
+	}
+	var responseStatus *int
+	if attempt.ResponseStatus != 0 {
+		responseStatus = &attempt.ResponseStatus
+	}
+
+	result, err := sqlExec.ExecContext(ctx, query, attempt.Status, attempt.AttemptedAt, nextAttemptAt, responseStatus, utils.SQLNullString(attempt.Error), id)
+	if err != nil {
+		return fmt.Errorf("updating attempt of webhook delivery %s: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting rows affected when updating webhook delivery %s: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+)
+
+func Test_WebhookDeliveryModel(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	disbursementSubscription := CreateWebhookSubscriptionFixture(t, ctx, dbConnectionPool, models.WebhookSubscriptions, WebhookSubscriptionInsert{
+		EventTypes: WebhookEventTypes{DisbursementStatusChangedWebhookEventType},
+	})
+	paymentSubscription := CreateWebhookSubscriptionFixture(t, ctx, dbConnectionPool, models.WebhookSubscriptions, WebhookSubscriptionInsert{
+		EventTypes: WebhookEventTypes{PaymentStatusChangedWebhookEventType},
+	})
+	defer DeleteAllWebhookFixtures(t, ctx, dbConnectionPool)
+
+	disbursement := CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &Disbursement{Status: DraftDisbursementStatus})
+	err = models.Disbursements.UpdateStatus(ctx, dbConnectionPool, "user-id", disbursement.ID, ReadyDisbursementStatus)
+	require.NoError(t, err)
+
+	t.Run("🎉 status changes are enqueued for the subscriptions listening to them", func(t *testing.T) {
+		deliveries, err := models.WebhookDeliveries.GetAllBySubscriptionID(ctx, dbConnectionPool, disbursementSubscription.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, DisbursementStatusChangedWebhookEventType, deliveries[0].EventType)
+		assert.Equal(t, PendingWebhookDeliveryStatus, deliveries[0].Status)
+		assert.Zero(t, deliveries[0].Attempts)
+		assert.Contains(t, string(deliveries[0].Payload), `"previous_status": "DRAFT"`)
+		assert.Contains(t, string(deliveries[0].Payload), `"status": "READY"`)
+
+		deliveries, err = models.WebhookDeliveries.GetAllBySubscriptionID(ctx, dbConnectionPool, paymentSubscription.ID, 10)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+
+	t.Run("🎉 claims the pending deliveries and records the attempts", func(t *testing.T) {
+		dbTx, err := dbConnectionPool.BeginTxx(ctx, nil)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, dbTx.Rollback())
+		}()
+
+		claimedUntil := time.Now().Add(time.Minute)
+		deliveries, err := models.WebhookDeliveries.ClaimPending(ctx, dbTx, time.Now(), claimedUntil, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		delivery := deliveries[0]
+		assert.WithinDuration(t, claimedUntil, delivery.NextAttemptAt, time.Millisecond)
+		assert.Equal(t, disbursementSubscription.URL, delivery.SubscriptionURL)
+		assert.Equal(t, disbursementSubscription.EncryptedSecret, delivery.SubscriptionEncryptedSecret)
+
+		// Claimed deliveries are not claimed again.
+		claimedAgain, err := models.WebhookDeliveries.ClaimPending(ctx, dbTx, time.Now(), claimedUntil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimedAgain)
+
+		attemptedAt := time.Now()
+		err = models.WebhookDeliveries.UpdateAttempt(ctx, dbTx, delivery.ID, WebhookDeliveryAttempt{
+			Status:         PendingWebhookDeliveryStatus,
+			AttemptedAt:    attemptedAt,
+			NextAttemptAt:  attemptedAt.Add(time.Hour),
+			ResponseStatus: 500,
+			Error:          "unexpected status code 500",
+		})
+		require.NoError(t, err)
+
+		deliveries, err = models.WebhookDeliveries.ClaimPending(ctx, dbTx, time.Now(), claimedUntil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+
+		deliveries, err = models.WebhookDeliveries.GetAllBySubscriptionID(ctx, dbTx, disbursementSubscription.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, 1, deliveries[0].Attempts)
+		require.NotNil(t, deliveries[0].LastResponseStatus)
+		assert.Equal(t, 500, *deliveries[0].LastResponseStatus)
+		assert.Equal(t, "unexpected status code 500", deliveries[0].LastError)
+		assert.NotNil(t, deliveries[0].LastAttemptAt)
+
+		err = models.WebhookDeliveries.UpdateAttempt(ctx, dbTx, "unknown-id", WebhookDeliveryAttempt{Status: FailedWebhookDeliveryStatus})
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
@@ -0,0 +1,221 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+)
+
+// WebhookSubscription is an endpoint of the tenant that is notified about the lifecycle events it listens to. The
+// deliveries are signed with the subscription secret, which is only shown when the subscription is created and is
+// stored encrypted.
+type WebhookSubscription struct {
+	ID                 string            `json:"id" db:"id"`
+	URL                string            `json:"url" db:"url"`
+	EncryptedSecret    string            `json:"-" db:"encrypted_secret"`
+	EncrypterPublicKey string            `json:"-" db:"encrypter_public_key"`
+	EventTypes         WebhookEventTypes `json:"event_types" db:"event_types"`
+	Enabled            bool              `json:"enabled" db:"enabled"`
+	CreatedBy          string            `json:"created_by" db:"created_by"`
+	CreatedAt          time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+type WebhookEventType string
+
+const (
+	PaymentStatusChangedWebhookEventType        WebhookEventType = "payment.status_changed"
+	DisbursementStatusChangedWebhookEventType   WebhookEventType = "disbursement.status_changed"
+	ReceiverWalletStatusChangedWebhookEventType WebhookEventType = "receiver_wallet.status_changed"
+)
+
+// AllWebhookEventTypes returns the event types a webhook subscription can listen to.
+func AllWebhookEventTypes() []WebhookEventType {
+	return []WebhookEventType{
+		PaymentStatusChangedWebhookEventType,
+		DisbursementStatusChangedWebhookEventType,
+		ReceiverWalletStatusChangedWebhookEventType,
+	}
+}
+
+func (et WebhookEventType) Validate() error {
+	if !slices.Contains(AllWebhookEventTypes(), et) {
+		return fmt.Errorf("invalid webhook event type %q", et)
+	}
+	return nil
+}
+
+// WebhookEventTypes is the list of event types of a subscription, persisted as a TEXT[].
+type WebhookEventTypes []WebhookEventType
+
+// Validate checks that the list is not empty and only contains known event types.
+func (ets WebhookEventTypes) Validate() error {
+	if len(ets) == 0 {
+		return errors.New("at least one event type is required")
+	}
+	for _, et := range ets {
+		if err := et.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (ets WebhookEventTypes) Value() (driver.Value, error) {
+	eventTypes := make(pq.StringArray, 0, len(ets))
+	for _, et := range ets {
+		eventTypes = append(eventTypes, string(et))
+	}
+	return eventTypes.Value()
+}
+
+// Scan implements the sql.Scanner interface.
+func (ets *WebhookEventTypes) Scan(src interface{}) error {
+	var eventTypes pq.StringArray
+	if err := eventTypes.Scan(src); err != nil {
+		return fmt.Errorf("scanning webhook event types: %w", err)
+	}
+
+	*ets = make(WebhookEventTypes, 0, len(eventTypes))
+	for _, et := range eventTypes {
+		*ets = append(*ets, WebhookEventType(et))
+	}
+	return nil
+}
+
+type WebhookSubscriptionInsert struct {
+	URL                string
+	EncryptedSecret    string
+	EncrypterPublicKey string
+	EventTypes         WebhookEventTypes
+	CreatedBy          string
+}
+
+// WebhookSubscriptionUpdate holds the fields to update in a subscription. Zero-valued fields are left untouched.
+type WebhookSubscriptionUpdate struct {
+	URL        string            `db:"url"`
+	EventTypes WebhookEventTypes `db:"event_types"`
+	Enabled    *bool             `db:"enabled"`
+}
+
+type WebhookSubscriptionModel struct {
+	dbConnectionPool db.DBConnectionPool
+}
+
+const selectWebhookSubscriptionQuery = `
+	SELECT
+		ws.id,
+		ws.url,
+		ws.encrypted_secret,
+		ws.encrypter_public_key,
+		ws.event_types,
+		ws.enabled,
+		ws.created_by,
+		ws.created_at,
+		ws.updated_at
+	FROM
+		webhook_subscriptions ws
+`
+
+func (m *WebhookSubscriptionModel) Insert(ctx context.Context, sqlExec db.SQLExecuter, insert WebhookSubscriptionInsert) (*WebhookSubscription, error) {
+	const query = `
+		INSERT INTO
+			webhook_subscriptions (url, encrypted_secret, encrypter_public_key, event_types, created_by)
+		VALUES
+			($1, $2, $3, $4, $5)
+		RETURNING
+			id, url, encrypted_secret, encrypter_public_key, event_types, enabled, created_by, created_at, updated_at
+	`
+
+	var subscription WebhookSubscription
+	err := sqlExec.GetContext(ctx, &subscription, query, insert.URL, insert.EncryptedSecret, insert.EncrypterPublicKey, insert.EventTypes, insert.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("inserting webhook subscription for %s: %w", insert.URL, err)
+	}
+
+	return &subscription, nil
+}
+
+func (m *WebhookSubscriptionModel) Get(ctx context.Context, sqlExec db.SQLExecuter, id string) (*WebhookSubscription, error) {
+	var subscription WebhookSubscription
+
+	query := fmt.Sprintf("%s %s", selectWebhookSubscriptionQuery, "WHERE ws.id = $1")
+	err := sqlExec.GetContext(ctx, &subscription, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("querying webhook subscription ID %s: %w", id, err)
+	}
+
+	return &subscription, nil
+}
+
+// GetAll returns all the webhook subscriptions, most recent first.
+func (m *WebhookSubscriptionModel) GetAll(ctx context.Context, sqlExec db.SQLExecuter) ([]*WebhookSubscription, error) {
+	subscriptions := []*WebhookSubscription{}
+
+	query := fmt.Sprintf("%s %s", selectWebhookSubscriptionQuery, "ORDER BY ws.created_at DESC")
+	err := sqlExec.SelectContext(ctx, &subscriptions, query)
+	if err != nil {
+		return nil, fmt.Errorf("querying webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (m *WebhookSubscriptionModel) Update(ctx context.Context, sqlExec db.SQLExecuter, id string, update WebhookSubscriptionUpdate) (*WebhookSubscription, error) {
+	setClause, params := BuildSetClause(update)
+	if setClause == "" {
+		return nil, fmt.Errorf("no fields to update: %w", ErrMissingInput)
+	}
+
+	query := sqlExec.Rebind(fmt.Sprintf(`
+		UPDATE
+			webhook_subscriptions
+		SET
+			%s
+		WHERE
+			id = ?
+		RETURNING id
+	`, setClause))
+	params = append(params, id)
+
+	var updatedID string
+	err := sqlExec.GetContext(ctx, &updatedID, query, params...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("updating webhook subscription ID %s: %w", id, err)
+	}
+
+	return m.Get(ctx, sqlExec, updatedID)
+}
+
+// Delete removes a subscription along with its delivery log.
+func (m *WebhookSubscriptionModel) Delete(ctx context.Context, sqlExec db.SQLExecuter, id string) error {
+	result, err := sqlExec.ExecContext(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("deleting webhook subscription ID %s: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting rows affected when deleting webhook subscription ID %s: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
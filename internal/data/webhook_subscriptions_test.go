@@ -0,0 +1,139 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+)
+
+func Test_WebhookEventTypes_Validate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		eventTypes WebhookEventTypes
+		wantErr    string
+	}{
+		{
+			name:    "empty event types",
+			wantErr: "at least one event type is required",
+		},
+		{
+			name:       "invalid event type",
+			eventTypes: WebhookEventTypes{PaymentStatusChangedWebhookEventType, "payment.created"},
+			wantErr:    `invalid webhook event type "payment.created"`,
+		},
+		{
+			name:       "🎉 valid event types",
+			eventTypes: AllWebhookEventTypes(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.eventTypes.Validate()
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_WebhookSubscriptionModel(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	t.Run("🎉 inserts and gets a subscription", func(t *testing.T) {
+		defer DeleteAllWebhookFixtures(t, ctx, dbConnectionPool)
+
+		subscription, err := models.WebhookSubscriptions.Insert(ctx, dbConnectionPool, WebhookSubscriptionInsert{
+			URL:                "https://example.com/webhooks",
+			EncryptedSecret:    "encrypted-secret",
+			EncrypterPublicKey: "encrypter-public-key",
+			EventTypes:         WebhookEventTypes{PaymentStatusChangedWebhookEventType},
+			CreatedBy:          "user-id",
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, subscription.ID)
+		assert.Equal(t, "https://example.com/webhooks", subscription.URL)
+		assert.Equal(t, "encrypted-secret", subscription.EncryptedSecret)
+		assert.Equal(t, "encrypter-public-key", subscription.EncrypterPublicKey)
+		assert.Equal(t, WebhookEventTypes{PaymentStatusChangedWebhookEventType}, subscription.EventTypes)
+		assert.True(t, subscription.Enabled)
+		assert.Equal(t, "user-id", subscription.CreatedBy)
+
+		got, err := models.WebhookSubscriptions.Get(ctx, dbConnectionPool, subscription.ID)
+		require.NoError(t, err)
+		assert.Equal(t, subscription, got)
+	})
+
+	t.Run("returns an error when the subscription doesn't exist", func(t *testing.T) {
+		_, err := models.WebhookSubscriptions.Get(ctx, dbConnectionPool, "unknown-id")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("🎉 gets all subscriptions, most recent first", func(t *testing.T) {
+		defer DeleteAllWebhookFixtures(t, ctx, dbConnectionPool)
+
+		subscription1 := CreateWebhookSubscriptionFixture(t, ctx, dbConnectionPool, models.WebhookSubscriptions, WebhookSubscriptionInsert{})
+		subscription2 := CreateWebhookSubscriptionFixture(t, ctx, dbConnectionPool, models.WebhookSubscriptions, WebhookSubscriptionInsert{})
+
+		subscriptions, err := models.WebhookSubscriptions.GetAll(ctx, dbConnectionPool)
+		require.NoError(t, err)
+		require.Len(t, subscriptions, 2)
+		assert.Equal(t, subscription2.ID, subscriptions[0].ID)
+		assert.Equal(t, subscription1.ID, subscriptions[1].ID)
+	})
+
+	t.Run("🎉 updates a subscription", func(t *testing.T) {
+		defer DeleteAllWebhookFixtures(t, ctx, dbConnectionPool)
+
+		subscription := CreateWebhookSubscriptionFixture(t, ctx, dbConnectionPool, models.WebhookSubscriptions, WebhookSubscriptionInsert{})
+
+		_, err := models.WebhookSubscriptions.Update(ctx, dbConnectionPool, subscription.ID, WebhookSubscriptionUpdate{})
+		assert.ErrorIs(t, err, ErrMissingInput)
+
+		enabled := false
+		updated, err := models.WebhookSubscriptions.Update(ctx, dbConnectionPool, subscription.ID, WebhookSubscriptionUpdate{
+			URL:        "https://example.com/new-webhooks",
+			EventTypes: WebhookEventTypes{DisbursementStatusChangedWebhookEventType},
+			Enabled:    &enabled,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/new-webhooks", updated.URL)
+		assert.Equal(t, WebhookEventTypes{DisbursementStatusChangedWebhookEventType}, updated.EventTypes)
+		assert.False(t, updated.Enabled)
+		assert.Equal(t, subscription.EncryptedSecret, updated.EncryptedSecret)
+
+		_, err = models.WebhookSubscriptions.Update(ctx, dbConnectionPool, "unknown-id", WebhookSubscriptionUpdate{URL: "https://example.com"})
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("🎉 deletes a subscription", func(t *testing.T) {
+		defer DeleteAllWebhookFixtures(t, ctx, dbConnectionPool)
+
+		subscription := CreateWebhookSubscriptionFixture(t, ctx, dbConnectionPool, models.WebhookSubscriptions, WebhookSubscriptionInsert{})
+
+		err := models.WebhookSubscriptions.Delete(ctx, dbConnectionPool, subscription.ID)
+		require.NoError(t, err)
+
+		_, err = models.WebhookSubscriptions.Get(ctx, dbConnectionPool, subscription.ID)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+
+		err = models.WebhookSubscriptions.Delete(ctx, dbConnectionPool, subscription.ID)
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
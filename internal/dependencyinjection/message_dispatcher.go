@@ -10,8 +10,13 @@ import (
 const MessageDispatcherInstanceName = "message_dispatcher_instance"
 
 type MessageDispatcherOpts struct {
-	EmailOpts *EmailClientOptions
-	SMSOpts   *SMSClientOptions
+	EmailOpts    *EmailClientOptions
+	SMSOpts      *SMSClientOptions
+	WhatsAppOpts *WhatsAppClientOptions
+	// TenantClientConfigs, when set, makes the dispatcher use the messenger credentials configured by each tenant,
+	// decrypted with the EncryptionPassphrase.
+	TenantClientConfigs  message.ClientConfigModelInterface
+	EncryptionPassphrase string
 }
 
 func NewMessageDispatcher(ctx context.Context, opts MessageDispatcherOpts) (*message.MessageDispatcher, error) {
@@ -40,6 +45,18 @@ func NewMessageDispatcher(ctx context.Context, opts MessageDispatcherOpts) (*mes
 		dispatcher.RegisterClient(ctx, message.MessageChannelSMS, smsClient)
 	}
 
+	if opts.WhatsAppOpts != nil {
+		whatsAppClient, err := NewWhatsAppClient(*opts.WhatsAppOpts)
+		if err != nil {
+			return nil, fmt.Errorf("creating WhatsApp client: %w", err)
+		}
+		dispatcher.RegisterClient(ctx, message.MessageChannelWhatsApp, whatsAppClient)
+	}
+
+	if opts.TenantClientConfigs != nil {
+		dispatcher.UseTenantClientConfigs(opts.TenantClientConfigs, opts.EncryptionPassphrase)
+	}
+
 	SetInstance(MessageDispatcherInstanceName, dispatcher)
 	return dispatcher, nil
 }
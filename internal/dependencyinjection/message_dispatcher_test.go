@@ -101,6 +101,40 @@ func Test_NewMessageDispatcher(t *testing.T) {
 		assert.Nil(t, dispatcher)
 	})
 
+	t.Run("should create dispatcher with a WhatsApp client", func(t *testing.T) {
+		defer ClearInstancesTestHelper(t)
+
+		opts := MessageDispatcherOpts{
+			SMSOpts: &SMSClientOptions{
+				SMSType: message.MessengerTypeDryRun,
+			},
+			WhatsAppOpts: &WhatsAppClientOptions{
+				WhatsAppType: message.MessengerTypeDryRun,
+			},
+		}
+
+		dispatcher, err := NewMessageDispatcher(ctx, opts)
+		require.NoError(t, err)
+
+		whatsAppClient, err := dispatcher.GetClient(message.MessageChannelWhatsApp)
+		require.NoError(t, err)
+		assert.Equal(t, message.MessengerTypeDryRun, whatsAppClient.MessengerType())
+	})
+
+	t.Run("should return an error on invalid WhatsApp client creation", func(t *testing.T) {
+		defer ClearInstancesTestHelper(t)
+
+		opts := MessageDispatcherOpts{
+			WhatsAppOpts: &WhatsAppClientOptions{
+				WhatsAppType: message.MessengerTypeAWSSMS,
+			},
+		}
+
+		dispatcher, err := NewMessageDispatcher(ctx, opts)
+		assert.ErrorContains(t, err, `trying to create a WhatsApp client with a non-supported WhatsApp type: "AWS_SMS"`)
+		assert.Nil(t, dispatcher)
+	})
+
 	t.Run("should return an error on invalid pre-existing instance", func(t *testing.T) {
 		defer ClearInstancesTestHelper(t)
 
@@ -0,0 +1,51 @@
+package dependencyinjection
+
+import (
+	"fmt"
+
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+)
+
+const WhatsAppClientInstanceName = "whatsapp_client_instance"
+
+type WhatsAppClientOptions struct {
+	WhatsAppType     message.MessengerType
+	MessengerOptions *message.MessengerOptions
+}
+
+// buildWhatsAppClientInstanceName returns the name of the WhatsApp client instance of the given type.
+func buildWhatsAppClientInstanceName(whatsAppClientType message.MessengerType) string {
+	return fmt.Sprintf("%s-%s", WhatsAppClientInstanceName, string(whatsAppClientType))
+}
+
+// NewWhatsAppClient creates a new WhatsApp client instance, or retrives a instance that was already created before.
+func NewWhatsAppClient(opts WhatsAppClientOptions) (message.MessengerClient, error) {
+	if !opts.WhatsAppType.IsWhatsApp() {
+		return nil, fmt.Errorf("trying to create a WhatsApp client with a non-supported WhatsApp type: %q", opts.WhatsAppType)
+	}
+
+	if opts.MessengerOptions == nil {
+		opts.MessengerOptions = &message.MessengerOptions{}
+	}
+	opts.MessengerOptions.MessengerType = opts.WhatsAppType
+
+	// If there is already an instance of the service, we return the same instance
+	instanceName := buildWhatsAppClientInstanceName(opts.MessengerOptions.MessengerType)
+	if instance, ok := GetInstance(instanceName); ok {
+		if whatsAppClientInstance, ok := instance.(message.MessengerClient); ok {
+			return whatsAppClientInstance, nil
+		}
+		return nil, fmt.Errorf("trying to cast pre-existing WhatsApp client for depencency injection")
+	}
+
+	log.Infof("⚙️ Setting up WhatsApp client to: %v", opts.MessengerOptions.MessengerType)
+	messengerClient, err := message.GetClient(*opts.MessengerOptions)
+	if err != nil {
+		return nil, fmt.Errorf("creating WhatsApp client: %w", err)
+	}
+
+	SetInstance(instanceName, messengerClient)
+	return messengerClient, nil
+}
@@ -0,0 +1,53 @@
+package dependencyinjection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+)
+
+func Test_NewWhatsAppClient(t *testing.T) {
+	t.Run("should return an error on a invalid WhatsApp type", func(t *testing.T) {
+		defer ClearInstancesTestHelper(t)
+
+		gotClient, err := NewWhatsAppClient(WhatsAppClientOptions{WhatsAppType: message.MessengerTypeTwilioSMS})
+		require.Nil(t, gotClient)
+		assert.EqualError(t, err, `trying to create a WhatsApp client with a non-supported WhatsApp type: "TWILIO_SMS"`)
+	})
+
+	t.Run("should return the same instance when called twice for the same WhatsApp type", func(t *testing.T) {
+		defer ClearInstancesTestHelper(t)
+
+		opts := WhatsAppClientOptions{
+			WhatsAppType: message.MessengerTypeTwilioWhatsApp,
+			MessengerOptions: &message.MessengerOptions{
+				TwilioAccountSID:           "testtesttesttesttest",
+				TwilioAuthToken:            "testtesttesttesttest",
+				TwilioWhatsAppSenderNumber: "+14155111111",
+				TwilioWhatsAppContentSIDs: map[message.MessageTemplateType]string{
+					message.MessageTemplateTypeReceiverOTP: "HXtesttesttesttest",
+				},
+			},
+		}
+
+		client1, err := NewWhatsAppClient(opts)
+		require.NoError(t, err)
+		client2, err := NewWhatsAppClient(opts)
+		require.NoError(t, err)
+		assert.Same(t, client1, client2)
+		assert.Equal(t, message.MessengerTypeTwilioWhatsApp, client1.MessengerType())
+	})
+
+	t.Run("should return an error on a invalid pre-existing instance", func(t *testing.T) {
+		defer ClearInstancesTestHelper(t)
+
+		SetInstance(buildWhatsAppClientInstanceName(message.MessengerTypeDryRun), struct{}{})
+
+		gotClient, err := NewWhatsAppClient(WhatsAppClientOptions{WhatsAppType: message.MessengerTypeDryRun})
+		assert.Nil(t, gotClient)
+		assert.EqualError(t, err, "trying to cast pre-existing WhatsApp client for depencency injection")
+	})
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
 )
 
+// AWSSESMessageIDTag is the SES message tag holding the SDP message ID.
+const AWSSESMessageIDTag = "sdp_message_id"
+
 // awsSESInterface is used to send emails.
 type awsSESInterface interface {
 	SendEmail(input *ses.SendEmailInput) (*ses.SendEmailOutput, error)
@@ -24,6 +27,8 @@ type awsSESInterface interface {
 type awsSESClient struct {
 	emailService awsSESInterface
 	senderID     string
+	// configurationSetName is the SES configuration set whose event destinations receive the delivery events.
+	configurationSetName string
 }
 
 func (t *awsSESClient) MessengerType() MessengerType {
@@ -36,7 +41,7 @@ func (a *awsSESClient) SendMessage(message Message) error {
 		return fmt.Errorf("validating message to send an email through AWS: %w", err)
 	}
 
-	emailTemplate, err := generateAWSEmail(message, a.senderID)
+	emailTemplate, err := generateAWSEmail(message, a.senderID, a.configurationSetName)
 	if err != nil {
 		return fmt.Errorf("generating AWS SES email template: %w", err)
 	}
@@ -51,7 +56,7 @@ func (a *awsSESClient) SendMessage(message Message) error {
 }
 
 // generateAWSEmail generates the email object to send an email through AWS SES.
-func generateAWSEmail(message Message, sender string) (*ses.SendEmailInput, error) {
+func generateAWSEmail(message Message, sender, configurationSetName string) (*ses.SendEmailInput, error) {
 	emailBody := message.Body
 	var err error
 	// If the email body does not contain an HTML tag, then it is considered as a plain text email:
@@ -62,7 +67,7 @@ func generateAWSEmail(message Message, sender string) (*ses.SendEmailInput, erro
 		}
 	}
 
-	return &ses.SendEmailInput{
+	emailInput := &ses.SendEmailInput{
 		Destination: &ses.Destination{
 			CcAddresses: []*string{},
 			ToAddresses: []*string{
@@ -82,11 +87,25 @@ func generateAWSEmail(message Message, sender string) (*ses.SendEmailInput, erro
 			},
 		},
 		Source: aws.String(sender),
-	}, nil
+	}
+
+	if configurationSetName != "" {
+		emailInput.ConfigurationSetName = aws.String(configurationSetName)
+	}
+
+	// The tag is included in the events SES publishes for the email, so its delivery status can be matched with it.
+	if message.ID != "" {
+		emailInput.Tags = []*ses.MessageTag{
+			{Name: aws.String(AWSSESMessageIDTag), Value: aws.String(message.ID)},
+		}
+	}
+
+	return emailInput, nil
 }
 
-// NewAWSSESClient creates a new AWS SES client, that is used to send emails.
-func NewAWSSESClient(accessKeyID, secretAccessKey, region, senderID string) (*awsSESClient, error) {
+// NewAWSSESClient creates a new AWS SES client, that is used to send emails. The configurationSetName is optional, and
+// is needed for SES to publish the delivery events of the emails.
+func NewAWSSESClient(accessKeyID, secretAccessKey, region, senderID, configurationSetName string) (*awsSESClient, error) {
 	accessKeyID = strings.TrimSpace(accessKeyID)
 	if accessKeyID == "" {
 		return nil, fmt.Errorf("aws accessKeyID is empty")
@@ -116,8 +135,9 @@ func NewAWSSESClient(accessKeyID, secretAccessKey, region, senderID string) (*aw
 	}
 
 	return &awsSESClient{
-		senderID:     senderID,
-		emailService: ses.New(awsSession),
+		senderID:             senderID,
+		configurationSetName: strings.TrimSpace(configurationSetName),
+		emailService:         ses.New(awsSession),
 	}, nil
 }
 
@@ -29,29 +29,34 @@ func Test_NewAWSSESClient(t *testing.T) {
 	var err error
 
 	// accessKeyID cannot be empty
-	gotAWSSESClient, err = NewAWSSESClient("", "", "", "")
+	gotAWSSESClient, err = NewAWSSESClient("", "", "", "", "")
 	require.Nil(t, gotAWSSESClient)
 	require.EqualError(t, err, "aws accessKeyID is empty")
 
 	// secretAccessKey cannot be empty
-	gotAWSSESClient, err = NewAWSSESClient("accessKeyID", "", "", "")
+	gotAWSSESClient, err = NewAWSSESClient("accessKeyID", "", "", "", "")
 	require.Nil(t, gotAWSSESClient)
 	require.EqualError(t, err, "aws secretAccessKey is empty")
 
 	// region cannot be empty
-	gotAWSSESClient, err = NewAWSSESClient("accessKeyID", "secretAccessKey", "", "")
+	gotAWSSESClient, err = NewAWSSESClient("accessKeyID", "secretAccessKey", "", "", "")
 	require.Nil(t, gotAWSSESClient)
 	require.EqualError(t, err, "aws region is empty")
 
 	// [email] type needs a valid email as a sender ID:
-	gotAWSSESClient, err = NewAWSSESClient("accessKeyID", "secretAccessKey", "region", "invalid-email")
+	gotAWSSESClient, err = NewAWSSESClient("accessKeyID", "secretAccessKey", "region", "invalid-email", "")
 	require.Nil(t, gotAWSSESClient)
 	require.EqualError(t, err, "aws SES (email) senderID is invalid: the provided email is not valid")
 
 	// [email] all fields are present 🎉
-	gotAWSSESClient, err = NewAWSSESClient("accessKeyID", "secretAccessKey", "region", "foo@test.com")
+	gotAWSSESClient, err = NewAWSSESClient("accessKeyID", "secretAccessKey", "region", "foo@test.com", "")
 	require.NoError(t, err)
 	require.NotNil(t, gotAWSSESClient)
+
+	// [email] with a configuration set 🎉
+	gotAWSSESClient, err = NewAWSSESClient("accessKeyID", "secretAccessKey", "region", "foo@test.com", " sdp-delivery-events ")
+	require.NoError(t, err)
+	require.Equal(t, "sdp-delivery-events", gotAWSSESClient.configurationSetName)
 }
 
 func Test_AWSSES_SendMessage_messageIsInvalid(t *testing.T) {
@@ -63,7 +68,7 @@ func Test_AWSSES_SendMessage_messageIsInvalid(t *testing.T) {
 func Test_AWSSES_SendMessage_errorIsHandledCorrectly(t *testing.T) {
 	testSenderID := "sender@test.com"
 	message := Message{ToEmail: "foo@test.com", Title: "test title", Body: "foo bar"}
-	emailStr, err := generateAWSEmail(message, testSenderID)
+	emailStr, err := generateAWSEmail(message, testSenderID, "")
 	require.NoError(t, err)
 
 	mAWSSES := mockAWSSESClient{}
@@ -82,7 +87,7 @@ func Test_AWSSES_SendMessage_errorIsHandledCorrectly(t *testing.T) {
 func Test_AWSSES_SendMessage_success(t *testing.T) {
 	testSenderID := "sender@test.com"
 	message := Message{ToEmail: "foo@test.com", Title: "test title", Body: "foo bar"}
-	emailStr, err := generateAWSEmail(message, testSenderID)
+	emailStr, err := generateAWSEmail(message, testSenderID, "")
 	require.NoError(t, err)
 
 	mAWSSES := mockAWSSESClient{}
@@ -104,7 +109,7 @@ func Test_generateAWSEmail_success(t *testing.T) {
 		Body:    "Helo world!",
 		Title:   "title",
 	}
-	gotEmail, err := generateAWSEmail(message, "sender@test.com")
+	gotEmail, err := generateAWSEmail(message, "sender@test.com", "")
 	require.NoError(t, err)
 
 	wantHTML := `<!DOCTYPE html>
@@ -144,3 +149,25 @@ func Test_generateAWSEmail_success(t *testing.T) {
 	}
 	require.Equal(t, wantEmail, gotEmail)
 }
+
+func Test_generateAWSEmail_withMessageID(t *testing.T) {
+	message := Message{
+		ID:      "message-id",
+		ToEmail: "receiver@test.com",
+		Body:    "Helo world!",
+		Title:   "title",
+	}
+	gotEmail, err := generateAWSEmail(message, "sender@test.com", "")
+	require.NoError(t, err)
+
+	wantTags := []*ses.MessageTag{{Name: aws.String("sdp_message_id"), Value: aws.String("message-id")}}
+	require.Equal(t, wantTags, gotEmail.Tags)
+	require.Nil(t, gotEmail.ConfigurationSetName)
+}
+
+func Test_generateAWSEmail_withConfigurationSet(t *testing.T) {
+	message := Message{ToEmail: "receiver@test.com", Body: "Helo world!", Title: "title"}
+	gotEmail, err := generateAWSEmail(message, "sender@test.com", "sdp-delivery-events")
+	require.NoError(t, err)
+	require.Equal(t, aws.String("sdp-delivery-events"), gotEmail.ConfigurationSetName)
+}
@@ -0,0 +1,83 @@
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// awsSESNotification holds the fields we use from the notifications SES publishes to SNS, either through a
+// configuration set event destination (eventType) or through the identity notifications (notificationType).
+type awsSESNotification struct {
+	EventType        string `json:"eventType"`
+	NotificationType string `json:"notificationType"`
+	Mail             struct {
+		MessageID string              `json:"messageId"`
+		Tags      map[string][]string `json:"tags"`
+	} `json:"mail"`
+	Bounce *struct {
+		BounceType        string `json:"bounceType"`
+		BounceSubType     string `json:"bounceSubType"`
+		BouncedRecipients []struct {
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Reject *struct {
+		Reason string `json:"reason"`
+	} `json:"reject"`
+	Failure *struct {
+		ErrorMessage string `json:"errorMessage"`
+	} `json:"failure"`
+}
+
+// ParseAWSSESNotification parses the SES notification in the message of an SNS notification. It returns nil when the
+// notification isn't about a final status, like `Send` or `DeliveryDelay`, or when the email wasn't sent by the SDP.
+func ParseAWSSESNotification(snsMessage string) (*DeliveryStatusUpdate, error) {
+	var notification awsSESNotification
+	if err := json.Unmarshal([]byte(snsMessage), &notification); err != nil {
+		return nil, fmt.Errorf("unmarshalling SES notification: %w", err)
+	}
+
+	messageIDs := notification.Mail.Tags[AWSSESMessageIDTag]
+	if len(messageIDs) == 0 || messageIDs[0] == "" {
+		return nil, nil
+	}
+
+	update := DeliveryStatusUpdate{MessageID: messageIDs[0]}
+
+	notificationType := notification.EventType
+	if notificationType == "" {
+		notificationType = notification.NotificationType
+	}
+
+	switch notificationType {
+	case "Delivery":
+		update.Status = DeliveryStatusDelivered
+	case "Bounce":
+		update.Status = DeliveryStatusBounced
+		if notification.Bounce != nil {
+			update.ErrorCode = strings.Trim(notification.Bounce.BounceType+"/"+notification.Bounce.BounceSubType, "/")
+			if len(notification.Bounce.BouncedRecipients) > 0 {
+				update.ErrorMessage = notification.Bounce.BouncedRecipients[0].DiagnosticCode
+			}
+		}
+	case "Reject":
+		update.Status = DeliveryStatusFailed
+		update.ErrorCode = "Reject"
+		if notification.Reject != nil {
+			update.ErrorMessage = notification.Reject.Reason
+		}
+	case "Rendering Failure":
+		update.Status = DeliveryStatusFailed
+		update.ErrorCode = "RenderingFailure"
+		if notification.Failure != nil {
+			update.ErrorMessage = notification.Failure.ErrorMessage
+		}
+	case "":
+		return nil, fmt.Errorf("SES notification has no eventType or notificationType")
+	default:
+		return nil, nil
+	}
+
+	return &update, nil
+}
@@ -0,0 +1,85 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseAWSSESNotification(t *testing.T) {
+	testCases := []struct {
+		name         string
+		notification string
+		wantUpdate   *DeliveryStatusUpdate
+		wantErr      string
+	}{
+		{
+			name:         "returns an error if the notification isn't JSON",
+			notification: "not json",
+			wantErr:      "unmarshalling SES notification: invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			name:         "returns an error if the notification has no type",
+			notification: `{"mail": {"tags": {"sdp_message_id": ["message-id"]}}}`,
+			wantErr:      "SES notification has no eventType or notificationType",
+		},
+		{
+			name:         "ignores the emails not sent by the SDP",
+			notification: `{"eventType": "Delivery", "mail": {"tags": {"ses:configuration-set": ["default"]}}}`,
+		},
+		{
+			name:         "ignores the events that aren't final",
+			notification: `{"eventType": "DeliveryDelay", "mail": {"tags": {"sdp_message_id": ["message-id"]}}}`,
+		},
+		{
+			name:         "🎉 parses a delivery event",
+			notification: `{"eventType": "Delivery", "mail": {"tags": {"sdp_message_id": ["message-id"]}}}`,
+			wantUpdate:   &DeliveryStatusUpdate{MessageID: "message-id", Status: DeliveryStatusDelivered},
+		},
+		{
+			name: "🎉 parses a bounce notification",
+			notification: `{
+				"notificationType": "Bounce",
+				"mail": {"tags": {"sdp_message_id": ["message-id"]}},
+				"bounce": {
+					"bounceType": "Permanent",
+					"bounceSubType": "General",
+					"bouncedRecipients": [{"emailAddress": "receiver@test.com", "diagnosticCode": "smtp; 550 5.1.1 user unknown"}]
+				}
+			}`,
+			wantUpdate: &DeliveryStatusUpdate{
+				MessageID:    "message-id",
+				Status:       DeliveryStatusBounced,
+				ErrorCode:    "Permanent/General",
+				ErrorMessage: "smtp; 550 5.1.1 user unknown",
+			},
+		},
+		{
+			name: "🎉 parses a reject event",
+			notification: `{
+				"eventType": "Reject",
+				"mail": {"tags": {"sdp_message_id": ["message-id"]}},
+				"reject": {"reason": "Bad content"}
+			}`,
+			wantUpdate: &DeliveryStatusUpdate{
+				MessageID:    "message-id",
+				Status:       DeliveryStatusFailed,
+				ErrorCode:    "Reject",
+				ErrorMessage: "Bad content",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			update, err := ParseAWSSESNotification(tc.notification)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantUpdate, update)
+		})
+	}
+}
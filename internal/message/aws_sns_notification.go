@@ -0,0 +1,221 @@
+package message
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httpclient"
+)
+
+const (
+	AWSSNSMessageTypeNotification             = "Notification"
+	AWSSNSMessageTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	AWSSNSMessageTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// rxAWSSNSHost matches the hosts SNS signing certificates and subscription URLs are served from.
+var rxAWSSNSHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// AWSSNSMessage is the message SNS posts to an HTTP(S) subscription.
+type AWSSNSMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token,omitempty"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject,omitempty"`
+	Message          string `json:"Message"`
+	SubscribeURL     string `json:"SubscribeURL,omitempty"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+}
+
+// stringToSign builds the string SNS signs, according to
+// https://docs.aws.amazon.com/sns/latest/dg/sns-verify-signature-of-message.html.
+func (m AWSSNSMessage) stringToSign() (string, error) {
+	var fields [][2]string
+	switch m.Type {
+	case AWSSNSMessageTypeNotification:
+		fields = append(fields, [2]string{"Message", m.Message}, [2]string{"MessageId", m.MessageID})
+		if m.Subject != "" {
+			fields = append(fields, [2]string{"Subject", m.Subject})
+		}
+		fields = append(fields, [2]string{"Timestamp", m.Timestamp}, [2]string{"TopicArn", m.TopicArn}, [2]string{"Type", m.Type})
+	case AWSSNSMessageTypeSubscriptionConfirmation, AWSSNSMessageTypeUnsubscribeConfirmation:
+		fields = [][2]string{
+			{"Message", m.Message},
+			{"MessageId", m.MessageID},
+			{"SubscribeURL", m.SubscribeURL},
+			{"Timestamp", m.Timestamp},
+			{"Token", m.Token},
+			{"TopicArn", m.TopicArn},
+			{"Type", m.Type},
+		}
+	default:
+		return "", fmt.Errorf("unsupported SNS message type %q", m.Type)
+	}
+
+	var sb strings.Builder
+	for _, field := range fields {
+		sb.WriteString(field[0] + "\n" + field[1] + "\n")
+	}
+	return sb.String(), nil
+}
+
+// validateAWSSNSURL makes sure the URL is served by SNS, so a forged message can't make us trust or call other hosts.
+func validateAWSSNSURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("parsing URL: %w", err)
+	}
+
+	if u.Scheme != "https" || !rxAWSSNSHost.MatchString(u.Hostname()) {
+		return fmt.Errorf("URL %q is not an SNS URL", rawURL)
+	}
+
+	return nil
+}
+
+//go:generate mockery --name=AWSSNSMessageVerifierInterface --case=underscore --structname=MockAWSSNSMessageVerifier --filename=aws_sns_notification_mock.go --inpackage
+type AWSSNSMessageVerifierInterface interface {
+	Verify(m AWSSNSMessage) error
+	ConfirmSubscription(m AWSSNSMessage) error
+}
+
+// AWSSNSMessageVerifier verifies the signature of the messages SNS posts to the subscriptions, and confirms the
+// subscriptions.
+type AWSSNSMessageVerifier struct {
+	httpClient httpclient.HttpClientInterface
+	mu         sync.Mutex
+	// certificates caches the signing certificates by their URL.
+	certificates map[string]*x509.Certificate
+}
+
+var _ AWSSNSMessageVerifierInterface = (*AWSSNSMessageVerifier)(nil)
+
+func NewAWSSNSMessageVerifier(httpClient httpclient.HttpClientInterface) *AWSSNSMessageVerifier {
+	return &AWSSNSMessageVerifier{
+		httpClient:   httpClient,
+		certificates: make(map[string]*x509.Certificate),
+	}
+}
+
+// Verify returns an error if the message wasn't signed by SNS.
+func (v *AWSSNSMessageVerifier) Verify(m AWSSNSMessage) error {
+	stringToSign, err := m.stringToSign()
+	if err != nil {
+		return fmt.Errorf("building the string to sign: %w", err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+
+	var hash crypto.Hash
+	var hashed []byte
+	switch m.SignatureVersion {
+	case "1":
+		sum := sha1.Sum([]byte(stringToSign))
+		hash, hashed = crypto.SHA1, sum[:]
+	case "2":
+		sum := sha256.Sum256([]byte(stringToSign))
+		hash, hashed = crypto.SHA256, sum[:]
+	default:
+		return fmt.Errorf("unsupported SNS signature version %q", m.SignatureVersion)
+	}
+
+	certificate, err := v.getCertificate(m.SigningCertURL)
+	if err != nil {
+		return fmt.Errorf("getting signing certificate: %w", err)
+	}
+
+	publicKey, ok := certificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("signing certificate doesn't have an RSA public key")
+	}
+
+	if err = rsa.VerifyPKCS1v15(publicKey, hash, hashed, signature); err != nil {
+		return fmt.Errorf("verifying signature: %w", err)
+	}
+
+	return nil
+}
+
+func (v *AWSSNSMessageVerifier) getCertificate(certURL string) (*x509.Certificate, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if certificate, ok := v.certificates[certURL]; ok {
+		return certificate, nil
+	}
+
+	if err := validateAWSSNSURL(certURL); err != nil {
+		return nil, fmt.Errorf("validating signing certificate URL: %w", err)
+	}
+
+	resp, err := v.httpClient.Get(certURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetching signing certificate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching signing certificate responded with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(body)
+	if block == nil {
+		return nil, fmt.Errorf("signing certificate is not PEM encoded")
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing certificate: %w", err)
+	}
+
+	v.certificates[certURL] = certificate
+	return certificate, nil
+}
+
+// ConfirmSubscription confirms the subscription of a verified SubscriptionConfirmation message, so SNS starts posting
+// the notifications.
+func (v *AWSSNSMessageVerifier) ConfirmSubscription(m AWSSNSMessage) error {
+	if m.Type != AWSSNSMessageTypeSubscriptionConfirmation {
+		return fmt.Errorf("SNS message type %q is not %s", m.Type, AWSSNSMessageTypeSubscriptionConfirmation)
+	}
+
+	if err := validateAWSSNSURL(m.SubscribeURL); err != nil {
+		return fmt.Errorf("validating subscribe URL: %w", err)
+	}
+
+	resp, err := v.httpClient.Get(m.SubscribeURL)
+	if err != nil {
+		return fmt.Errorf("confirming subscription: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("confirming subscription responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
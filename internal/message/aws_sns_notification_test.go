@@ -0,0 +1,152 @@
+package message
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httpclient/mocks"
+)
+
+const testSigningCertURL = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-123.pem"
+
+// newTestSNSSigner creates the key SNS messages are signed with in the tests, and its certificate PEM.
+func newTestSNSSigner(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+
+	return privateKey, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+}
+
+func signTestSNSMessage(t *testing.T, privateKey *rsa.PrivateKey, m *AWSSNSMessage) {
+	t.Helper()
+
+	stringToSign, err := m.stringToSign()
+	require.NoError(t, err)
+
+	var hash crypto.Hash
+	var hashed []byte
+	if m.SignatureVersion == "1" {
+		sum := sha1.Sum([]byte(stringToSign))
+		hash, hashed = crypto.SHA1, sum[:]
+	} else {
+		sum := sha256.Sum256([]byte(stringToSign))
+		hash, hashed = crypto.SHA256, sum[:]
+	}
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, hash, hashed)
+	require.NoError(t, err)
+	m.Signature = base64.StdEncoding.EncodeToString(signature)
+}
+
+func newTestHTTPResponse(statusCode int, body []byte) *http.Response {
+	return &http.Response{StatusCode: statusCode, Body: io.NopCloser(bytes.NewReader(body))}
+}
+
+func Test_AWSSNSMessageVerifier_Verify(t *testing.T) {
+	privateKey, certPEM := newTestSNSSigner(t)
+
+	newNotification := func(signatureVersion string) AWSSNSMessage {
+		m := AWSSNSMessage{
+			Type:             AWSSNSMessageTypeNotification,
+			MessageID:        "sns-message-id",
+			TopicArn:         "arn:aws:sns:us-east-1:123456789012:ses-notifications",
+			Message:          `{"eventType": "Delivery"}`,
+			Timestamp:        "2025-02-21T10:00:00.000Z",
+			SignatureVersion: signatureVersion,
+			SigningCertURL:   testSigningCertURL,
+		}
+		signTestSNSMessage(t, privateKey, &m)
+		return m
+	}
+
+	t.Run("the signing certificate must be served by SNS", func(t *testing.T) {
+		m := newNotification("1")
+		m.SigningCertURL = "https://attacker.com/sns.us-east-1.amazonaws.com.pem"
+
+		err := NewAWSSNSMessageVerifier(mocks.NewHttpClientMock(t)).Verify(m)
+		assert.EqualError(t, err, `getting signing certificate: validating signing certificate URL: URL "https://attacker.com/sns.us-east-1.amazonaws.com.pem" is not an SNS URL`)
+	})
+
+	t.Run("the signature version must be supported", func(t *testing.T) {
+		m := newNotification("1")
+		m.SignatureVersion = "3"
+
+		err := NewAWSSNSMessageVerifier(mocks.NewHttpClientMock(t)).Verify(m)
+		assert.EqualError(t, err, `unsupported SNS signature version "3"`)
+	})
+
+	t.Run("the message must not be tampered with", func(t *testing.T) {
+		mHTTPClient := mocks.NewHttpClientMock(t)
+		mHTTPClient.On("Get", testSigningCertURL).Return(newTestHTTPResponse(http.StatusOK, certPEM), nil).Once()
+
+		m := newNotification("1")
+		m.Message = `{"eventType": "Bounce"}`
+
+		err := NewAWSSNSMessageVerifier(mHTTPClient).Verify(m)
+		assert.EqualError(t, err, "verifying signature: crypto/rsa: verification error")
+	})
+
+	t.Run("🎉 verifies the messages and caches the certificate", func(t *testing.T) {
+		mHTTPClient := mocks.NewHttpClientMock(t)
+		mHTTPClient.On("Get", testSigningCertURL).Return(newTestHTTPResponse(http.StatusOK, certPEM), nil).Once()
+
+		verifier := NewAWSSNSMessageVerifier(mHTTPClient)
+		assert.NoError(t, verifier.Verify(newNotification("1")))
+		assert.NoError(t, verifier.Verify(newNotification("2")))
+	})
+}
+
+func Test_AWSSNSMessageVerifier_ConfirmSubscription(t *testing.T) {
+	subscriptionConfirmation := AWSSNSMessage{
+		Type:         AWSSNSMessageTypeSubscriptionConfirmation,
+		SubscribeURL: "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=token",
+	}
+
+	t.Run("the message must be a subscription confirmation", func(t *testing.T) {
+		err := NewAWSSNSMessageVerifier(mocks.NewHttpClientMock(t)).ConfirmSubscription(AWSSNSMessage{Type: AWSSNSMessageTypeNotification})
+		assert.EqualError(t, err, `SNS message type "Notification" is not SubscriptionConfirmation`)
+	})
+
+	t.Run("the subscribe URL must be served by SNS", func(t *testing.T) {
+		m := subscriptionConfirmation
+		m.SubscribeURL = "http://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription"
+
+		err := NewAWSSNSMessageVerifier(mocks.NewHttpClientMock(t)).ConfirmSubscription(m)
+		assert.EqualError(t, err, `validating subscribe URL: URL "http://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription" is not an SNS URL`)
+	})
+
+	t.Run("🎉 confirms the subscription", func(t *testing.T) {
+		mHTTPClient := mocks.NewHttpClientMock(t)
+		mHTTPClient.On("Get", subscriptionConfirmation.SubscribeURL).Return(newTestHTTPResponse(http.StatusOK, nil), nil).Once()
+
+		err := NewAWSSNSMessageVerifier(mHTTPClient).ConfirmSubscription(subscriptionConfirmation)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,166 @@
+package message
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+var ErrClientConfigNotFound = errors.New("messenger client config not found")
+
+// ClientConfig holds the messenger provider credentials a tenant configured for a channel. The credentials are stored
+// encrypted, as the JSON of the MessengerOptions.
+type ClientConfig struct {
+	Channel              MessageChannel `db:"channel"`
+	MessengerType        MessengerType  `db:"messenger_type"`
+	EncryptedCredentials string         `db:"encrypted_credentials"`
+	EncrypterPublicKey   string         `db:"encrypter_public_key"`
+	UpdatedAt            time.Time      `db:"updated_at"`
+	CreatedAt            time.Time      `db:"created_at"`
+}
+
+//go:generate mockery --name=ClientConfigModelInterface --case=underscore --structname=MockClientConfigModel --filename=client_config_mock.go --inpackage
+type ClientConfigModelInterface interface {
+	Upsert(ctx context.Context, configUpsert ClientConfigUpsert) (*ClientConfig, error)
+	Get(ctx context.Context, channel MessageChannel) (*ClientConfig, error)
+	GetAll(ctx context.Context) ([]ClientConfig, error)
+	GetDecryptedOptions(ctx context.Context, channel MessageChannel, passphrase string) (*MessengerOptions, error)
+	Delete(ctx context.Context, channel MessageChannel) error
+}
+
+type ClientConfigModel struct {
+	DBConnectionPool db.DBConnectionPool
+	Encrypter        utils.PrivateKeyEncrypter
+}
+
+func NewClientConfigModel(dbConnectionPool db.DBConnectionPool) *ClientConfigModel {
+	return &ClientConfigModel{
+		DBConnectionPool: dbConnectionPool,
+		Encrypter:        &utils.DefaultPrivateKeyEncrypter{},
+	}
+}
+
+type ClientConfigUpsert struct {
+	Channel              MessageChannel
+	MessengerType        MessengerType
+	EncryptedCredentials string
+	EncrypterPublicKey   string
+}
+
+func (c ClientConfigUpsert) validate() error {
+	if !c.Channel.SupportsMessengerType(c.MessengerType) {
+		return fmt.Errorf("messenger type %q can't be used for the channel %q", c.MessengerType, c.Channel)
+	}
+
+	if c.EncryptedCredentials == "" || c.EncrypterPublicKey == "" {
+		return fmt.Errorf("encrypted_credentials and encrypter_public_key must be provided")
+	}
+
+	return nil
+}
+
+const clientConfigColumns = "channel, messenger_type, encrypted_credentials, encrypter_public_key, updated_at, created_at"
+
+// Upsert inserts or replaces the client config of the channel.
+func (m *ClientConfigModel) Upsert(ctx context.Context, configUpsert ClientConfigUpsert) (*ClientConfig, error) {
+	if err := configUpsert.validate(); err != nil {
+		return nil, fmt.Errorf("invalid messenger client config: %w", err)
+	}
+
+	query := `
+		INSERT INTO messenger_client_configs (channel, messenger_type, encrypted_credentials, encrypter_public_key)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (channel) DO UPDATE SET
+			messenger_type = EXCLUDED.messenger_type,
+			encrypted_credentials = EXCLUDED.encrypted_credentials,
+			encrypter_public_key = EXCLUDED.encrypter_public_key
+		RETURNING ` + clientConfigColumns
+
+	var config ClientConfig
+	err := m.DBConnectionPool.GetContext(ctx, &config, query, configUpsert.Channel, configUpsert.MessengerType, configUpsert.EncryptedCredentials, configUpsert.EncrypterPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("upserting messenger client config: %w", err)
+	}
+
+	return &config, nil
+}
+
+// Get retrieves the client config of the channel, or nil if the tenant didn't configure one.
+func (m *ClientConfigModel) Get(ctx context.Context, channel MessageChannel) (*ClientConfig, error) {
+	query := "SELECT " + clientConfigColumns + " FROM messenger_client_configs WHERE channel = $1"
+
+	var config ClientConfig
+	err := m.DBConnectionPool.GetContext(ctx, &config, query, channel)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting messenger client config: %w", err)
+	}
+
+	return &config, nil
+}
+
+// GetAll retrieves the client configs of all the channels the tenant configured.
+func (m *ClientConfigModel) GetAll(ctx context.Context) ([]ClientConfig, error) {
+	query := "SELECT " + clientConfigColumns + " FROM messenger_client_configs ORDER BY channel"
+
+	configs := []ClientConfig{}
+	err := m.DBConnectionPool.SelectContext(ctx, &configs, query)
+	if err != nil {
+		return nil, fmt.Errorf("getting messenger client configs: %w", err)
+	}
+
+	return configs, nil
+}
+
+// GetDecryptedOptions retrieves the client config of the channel and decrypts its credentials into the messenger
+// options used to create the client. It returns nil if the tenant didn't configure the channel.
+func (m *ClientConfigModel) GetDecryptedOptions(ctx context.Context, channel MessageChannel, passphrase string) (*MessengerOptions, error) {
+	config, err := m.Get(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("getting messenger client config: %w", err)
+	}
+	if config == nil {
+		return nil, nil
+	}
+
+	credentials, err := m.Encrypter.Decrypt(config.EncryptedCredentials, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting messenger client credentials: %w", err)
+	}
+
+	var opts MessengerOptions
+	if err = json.Unmarshal([]byte(credentials), &opts); err != nil {
+		return nil, fmt.Errorf("unmarshalling messenger client credentials: %w", err)
+	}
+	opts.MessengerType = config.MessengerType
+
+	return &opts, nil
+}
+
+// Delete removes the client config of the channel, so its messages go back to the global client.
+func (m *ClientConfigModel) Delete(ctx context.Context, channel MessageChannel) error {
+	result, err := m.DBConnectionPool.ExecContext(ctx, "DELETE FROM messenger_client_configs WHERE channel = $1", channel)
+	if err != nil {
+		return fmt.Errorf("deleting messenger client config: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting the deleted messenger client configs: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrClientConfigNotFound
+	}
+
+	return nil
+}
+
+var _ ClientConfigModelInterface = (*ClientConfigModel)(nil)
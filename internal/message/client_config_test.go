@@ -0,0 +1,96 @@
+package message
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+func Test_ClientConfigModel(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, outerErr := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, outerErr)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	ccm := NewClientConfigModel(dbConnectionPool)
+
+	kp := keypair.MustRandom()
+	encryptOpts := func(t *testing.T, opts MessengerOptions) string {
+		credentials, err := json.Marshal(opts)
+		require.NoError(t, err)
+		encryptedCredentials, err := utils.Encrypt(string(credentials), kp.Seed())
+		require.NoError(t, err)
+		return encryptedCredentials
+	}
+
+	t.Run("returns nil when the channel isn't configured", func(t *testing.T) {
+		config, err := ccm.Get(ctx, MessageChannelSMS)
+		require.NoError(t, err)
+		assert.Nil(t, config)
+
+		opts, err := ccm.GetDecryptedOptions(ctx, MessageChannelSMS, kp.Seed())
+		require.NoError(t, err)
+		assert.Nil(t, opts)
+	})
+
+	t.Run("validates the messenger type against the channel", func(t *testing.T) {
+		config, err := ccm.Upsert(ctx, ClientConfigUpsert{
+			Channel:              MessageChannelSMS,
+			MessengerType:        MessengerTypeAWSEmail,
+			EncryptedCredentials: "encrypted",
+			EncrypterPublicKey:   kp.Address(),
+		})
+		assert.Nil(t, config)
+		assert.EqualError(t, err, `invalid messenger client config: messenger type "AWS_EMAIL" can't be used for the channel "SMS"`)
+	})
+
+	t.Run("🎉 inserts, replaces, decrypts and deletes the channel config", func(t *testing.T) {
+		twilioOpts := MessengerOptions{TwilioAccountSID: "accountSID", TwilioAuthToken: "authToken", TwilioServiceSID: "serviceSID"}
+		config, err := ccm.Upsert(ctx, ClientConfigUpsert{
+			Channel:              MessageChannelSMS,
+			MessengerType:        MessengerTypeTwilioSMS,
+			EncryptedCredentials: encryptOpts(t, twilioOpts),
+			EncrypterPublicKey:   kp.Address(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, MessageChannelSMS, config.Channel)
+		assert.Equal(t, MessengerTypeTwilioSMS, config.MessengerType)
+
+		awsOpts := MessengerOptions{AWSAccessKeyID: "accessKeyID", AWSSecretAccessKey: "secretAccessKey", AWSRegion: "us-east-1", AWSSNSSenderID: "senderID"}
+		config, err = ccm.Upsert(ctx, ClientConfigUpsert{
+			Channel:              MessageChannelSMS,
+			MessengerType:        MessengerTypeAWSSMS,
+			EncryptedCredentials: encryptOpts(t, awsOpts),
+			EncrypterPublicKey:   kp.Address(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, MessengerTypeAWSSMS, config.MessengerType)
+
+		configs, err := ccm.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, configs, 1)
+		assert.Equal(t, MessengerTypeAWSSMS, configs[0].MessengerType)
+
+		opts, err := ccm.GetDecryptedOptions(ctx, MessageChannelSMS, kp.Seed())
+		require.NoError(t, err)
+		awsOpts.MessengerType = MessengerTypeAWSSMS
+		assert.Equal(t, &awsOpts, opts)
+
+		err = ccm.Delete(ctx, MessageChannelSMS)
+		require.NoError(t, err)
+
+		err = ccm.Delete(ctx, MessageChannelSMS)
+		assert.ErrorIs(t, err, ErrClientConfigNotFound)
+	})
+}
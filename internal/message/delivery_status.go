@@ -0,0 +1,28 @@
+package message
+
+// DeliveryStatus is the final status a messaging provider reports for a message it had accepted to send.
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusDelivered means the message reached the recipient.
+	DeliveryStatusDelivered DeliveryStatus = "DELIVERED"
+	// DeliveryStatusFailed means the provider couldn't deliver the message to the recipient.
+	DeliveryStatusFailed DeliveryStatus = "FAILED"
+	// DeliveryStatusBounced means the recipient's mail server rejected the email.
+	DeliveryStatusBounced DeliveryStatus = "BOUNCED"
+)
+
+// IsUndelivered returns true if the message didn't reach the recipient.
+func (ds DeliveryStatus) IsUndelivered() bool {
+	return ds == DeliveryStatusFailed || ds == DeliveryStatusBounced
+}
+
+// DeliveryStatusUpdate is the delivery status a messaging provider reported for a message.
+type DeliveryStatusUpdate struct {
+	// MessageID is the ID of the message in the SDP, as passed to the provider in Message.ID.
+	MessageID string
+	Status    DeliveryStatus
+	// ErrorCode and ErrorMessage are the provider's reason for not delivering the message, when available.
+	ErrorCode    string
+	ErrorMessage string
+}
@@ -17,6 +17,9 @@ func (c *dryRunClient) SendMessage(message Message) error {
 	fmt.Println("Recipient:", recipient)
 	fmt.Println("Subject:", message.Title)
 	fmt.Println("Content:", message.Body)
+	if message.Template != nil {
+		fmt.Println("Template:", message.Template.Type, message.Template.Variables)
+	}
 	fmt.Println(strings.Repeat("-", 79))
 
 	return nil
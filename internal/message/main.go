@@ -14,6 +14,8 @@ const (
 	MessengerTypeTwilioSMS MessengerType = "TWILIO_SMS"
 	// MessengerTypeTwilioEmail is used to send emails using Twilio SendGrid.
 	MessengerTypeTwilioEmail MessengerType = "TWILIO_EMAIL"
+	// MessengerTypeTwilioWhatsApp is used to send WhatsApp messages using Twilio.
+	MessengerTypeTwilioWhatsApp MessengerType = "TWILIO_WHATSAPP"
 	// MessengerTypeAWSSMS is used to send SMS messages using AWS SNS.
 	MessengerTypeAWSSMS MessengerType = "AWS_SMS"
 	// MessengerTypeAWSEmail is used to send emails using AWS SES.
@@ -23,7 +25,7 @@ const (
 )
 
 func (mt MessengerType) All() []MessengerType {
-	return []MessengerType{MessengerTypeTwilioSMS, MessengerTypeTwilioEmail, MessengerTypeAWSSMS, MessengerTypeAWSEmail, MessengerTypeDryRun}
+	return []MessengerType{MessengerTypeTwilioSMS, MessengerTypeTwilioEmail, MessengerTypeTwilioWhatsApp, MessengerTypeAWSSMS, MessengerTypeAWSEmail, MessengerTypeDryRun}
 }
 
 func ParseMessengerType(messengerTypeStr string) (MessengerType, error) {
@@ -45,6 +47,10 @@ func (mt MessengerType) ValidEmailTypes() []MessengerType {
 	return []MessengerType{MessengerTypeDryRun, MessengerTypeTwilioEmail, MessengerTypeAWSEmail}
 }
 
+func (mt MessengerType) ValidWhatsAppTypes() []MessengerType {
+	return []MessengerType{MessengerTypeDryRun, MessengerTypeTwilioWhatsApp}
+}
+
 func (mt MessengerType) IsSMS() bool {
 	return slices.Contains(mt.ValidSMSTypes(), mt)
 }
@@ -53,26 +59,41 @@ func (mt MessengerType) IsEmail() bool {
 	return slices.Contains(mt.ValidEmailTypes(), mt)
 }
 
+func (mt MessengerType) IsWhatsApp() bool {
+	return slices.Contains(mt.ValidWhatsAppTypes(), mt)
+}
+
 type MessengerOptions struct {
-	MessengerType MessengerType
-	Environment   string
+	MessengerType MessengerType `json:"-"`
+	Environment   string        `json:"-"`
 
 	// Twilio
-	TwilioAccountSID string
-	TwilioAuthToken  string
-	TwilioServiceSID string
+	TwilioAccountSID string `json:"twilio_account_sid,omitempty"`
+	TwilioAuthToken  string `json:"twilio_auth_token,omitempty"`
+	TwilioServiceSID string `json:"twilio_service_sid,omitempty"`
 	// Twilio Email (SendGrid)
-	TwilioSendGridAPIKey        string
-	TwilioSendGridSenderAddress string
+	TwilioSendGridAPIKey        string `json:"twilio_sendgrid_api_key,omitempty"`
+	TwilioSendGridSenderAddress string `json:"twilio_sendgrid_sender_address,omitempty"`
+	// Twilio WhatsApp
+	TwilioWhatsAppSenderNumber string `json:"twilio_whatsapp_sender_number,omitempty"`
+	// TwilioWhatsAppContentSIDs maps each message template type to the SID of the Twilio Content template approved by
+	// WhatsApp for it.
+	TwilioWhatsAppContentSIDs map[MessageTemplateType]string `json:"twilio_whatsapp_content_sids,omitempty"`
 
 	// AWS
-	AWSAccessKeyID     string
-	AWSSecretAccessKey string
-	AWSRegion          string
+	AWSAccessKeyID     string `json:"aws_access_key_id,omitempty"`
+	AWSSecretAccessKey string `json:"aws_secret_access_key,omitempty"`
+	AWSRegion          string `json:"aws_region,omitempty"`
 	// AWS SNS (SMS messages)
-	AWSSNSSenderID string
+	AWSSNSSenderID string `json:"aws_sns_sender_id,omitempty"`
 	// AWS SES (EMAIL messages)
-	AWSSESSenderID string
+	AWSSESSenderID string `json:"aws_ses_sender_id,omitempty"`
+	// AWSSESConfigurationSetName is the SES configuration set the emails are sent with, so its event destinations
+	// receive their delivery events.
+	AWSSESConfigurationSetName string `json:"aws_ses_configuration_set_name,omitempty"`
+	// AWSSESNotificationTopicARN is the ARN of the SNS topic the SES delivery events are published to. SNS messages
+	// from other topics are rejected.
+	AWSSESNotificationTopicARN string `json:"aws_ses_notification_topic_arn,omitempty"`
 }
 
 func GetClient(opts MessengerOptions) (MessengerClient, error) {
@@ -81,11 +102,13 @@ func GetClient(opts MessengerOptions) (MessengerClient, error) {
 		return NewTwilioClient(opts.TwilioAccountSID, opts.TwilioAuthToken, opts.TwilioServiceSID)
 	case MessengerTypeTwilioEmail:
 		return NewTwilioSendGridClient(opts.TwilioSendGridAPIKey, opts.TwilioSendGridSenderAddress)
+	case MessengerTypeTwilioWhatsApp:
+		return NewTwilioWhatsAppClient(opts.TwilioAccountSID, opts.TwilioAuthToken, opts.TwilioWhatsAppSenderNumber, opts.TwilioWhatsAppContentSIDs)
 
 	case MessengerTypeAWSSMS:
 		return NewAWSSNSClient(opts.AWSAccessKeyID, opts.AWSSecretAccessKey, opts.AWSRegion, opts.AWSSNSSenderID)
 	case MessengerTypeAWSEmail:
-		return NewAWSSESClient(opts.AWSAccessKeyID, opts.AWSSecretAccessKey, opts.AWSRegion, opts.AWSSESSenderID)
+		return NewAWSSESClient(opts.AWSAccessKeyID, opts.AWSSecretAccessKey, opts.AWSRegion, opts.AWSSESSenderID, opts.AWSSESConfigurationSetName)
 
 	case MessengerTypeDryRun:
 		return NewDryRunClient()
@@ -18,6 +18,7 @@ func Test_ParseMessengerType(t *testing.T) {
 		{messengerType: "TWILIO_SMS"},
 		{messengerType: "TWILIO_EMAIL"},
 		{messengerType: "tWiLiO_SMS"},
+		{messengerType: "TWILIO_WHATSAPP"},
 		{messengerType: "AWS_SMS"},
 		{messengerType: "AWS_EMAIL"},
 		{messengerType: "DRY_RUN"},
@@ -48,6 +49,19 @@ func Test_GetClient(t *testing.T) {
 	require.NoError(t, err)
 	require.IsType(t, &twilioClient{}, gotClient)
 
+	// MessengerTypeTwilioWhatsApp
+	messengerType = MessengerTypeTwilioWhatsApp
+	opts = MessengerOptions{
+		MessengerType:              messengerType,
+		TwilioAccountSID:           "accountSid",
+		TwilioAuthToken:            "authToken",
+		TwilioWhatsAppSenderNumber: "+14155111111",
+		TwilioWhatsAppContentSIDs:  map[MessageTemplateType]string{MessageTemplateTypeReceiverOTP: "HX123"},
+	}
+	gotClient, err = GetClient(opts)
+	require.NoError(t, err)
+	require.IsType(t, &twilioWhatsAppClient{}, gotClient)
+
 	// MessengerTypeAWSSMS
 	messengerType = MessengerTypeAWSSMS
 	opts = MessengerOptions{
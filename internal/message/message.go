@@ -7,11 +7,40 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
 )
 
+// MessageTemplateType identifies a pre-approved message template. Channels like WhatsApp only allow businesses to
+// start a conversation using templates approved beforehand, so the message body can't be sent as is.
+type MessageTemplateType string
+
+const (
+	// MessageTemplateTypeReceiverInvitation is the wallet registration invitation. Its variables are the organization
+	// name and the registration link, in this order.
+	MessageTemplateTypeReceiverInvitation MessageTemplateType = "RECEIVER_INVITATION"
+	// MessageTemplateTypeReceiverOTP is the wallet registration OTP. Its variables are the OTP and the organization
+	// name, in this order.
+	MessageTemplateTypeReceiverOTP MessageTemplateType = "RECEIVER_OTP"
+)
+
+// MessageTemplate is the pre-approved template a message can be sent with, through the channels that require one.
+type MessageTemplate struct {
+	Type MessageTemplateType
+	// Variables are the values of the template placeholders, in order.
+	Variables []string
+}
+
 type Message struct {
-	ToPhoneNumber string
-	ToEmail       string
-	Body          string
-	Title         string
+	// ID is optional, and identifies the message in the SDP. The clients that can report the delivery status of a
+	// message pass it to the provider, so the status can be matched with the message.
+	ID string
+	// StatusCallbackURL is optional, and is where the clients that support per-message status callbacks, like Twilio,
+	// ask the provider to report the delivery status of the message.
+	StatusCallbackURL string
+	ToPhoneNumber     string
+	ToEmail           string
+	Body              string
+	Title             string
+	// Template is optional, and the message can only be sent through the channels that require a pre-approved
+	// template, like WhatsApp, when it's set.
+	Template *MessageTemplate
 }
 
 // ValidateFor validates if the message object is valid for the given messengerType.
@@ -28,6 +57,12 @@ func (s Message) ValidateFor(messengerType MessengerType) error {
 		}
 	}
 
+	if messengerType.IsWhatsApp() {
+		if err := s.IsValidForWhatsApp(); err != nil {
+			return fmt.Errorf("invalid WhatsApp message: %w", err)
+		}
+	}
+
 	if strings.Trim(s.Body, " ") == "" {
 		return fmt.Errorf("message is empty")
 	}
@@ -46,6 +81,17 @@ func (s Message) IsValidForEmail() error {
 	return nil
 }
 
+func (s Message) IsValidForWhatsApp() error {
+	if err := utils.ValidatePhoneNumber(s.ToPhoneNumber); err != nil {
+		return fmt.Errorf("invalid phone number: %w", err)
+	}
+
+	if s.Template == nil {
+		return fmt.Errorf("template is empty")
+	}
+	return nil
+}
+
 func (s Message) SupportedChannels() []MessageChannel {
 	var supportedChannels []MessageChannel
 
@@ -57,6 +103,10 @@ func (s Message) SupportedChannels() []MessageChannel {
 		supportedChannels = append(supportedChannels, MessageChannelEmail)
 	}
 
+	if err := s.IsValidForWhatsApp(); err == nil {
+		supportedChannels = append(supportedChannels, MessageChannelWhatsApp)
+	}
+
 	return supportedChannels
 }
 
@@ -3,6 +3,8 @@ package message
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/stellar/go/support/log"
 )
@@ -10,19 +12,53 @@ import (
 type MessageChannel string
 
 const (
-	MessageChannelEmail MessageChannel = "EMAIL"
-	MessageChannelSMS   MessageChannel = "SMS"
+	MessageChannelEmail    MessageChannel = "EMAIL"
+	MessageChannelSMS      MessageChannel = "SMS"
+	MessageChannelWhatsApp MessageChannel = "WHATSAPP"
 )
 
+func (mc MessageChannel) All() []MessageChannel {
+	return []MessageChannel{MessageChannelSMS, MessageChannelEmail, MessageChannelWhatsApp}
+}
+
+func ParseMessageChannel(channelStr string) (MessageChannel, error) {
+	channel := MessageChannel(strings.ToUpper(strings.TrimSpace(channelStr)))
+	if slices.Contains(MessageChannel("").All(), channel) {
+		return channel, nil
+	}
+
+	return "", fmt.Errorf("invalid message channel %q", channelStr)
+}
+
+// SupportsMessengerType returns true if the messenger type can send the messages of the channel.
+func (mc MessageChannel) SupportsMessengerType(messengerType MessengerType) bool {
+	switch mc {
+	case MessageChannelSMS:
+		return messengerType.IsSMS()
+	case MessageChannelEmail:
+		return messengerType.IsEmail()
+	case MessageChannelWhatsApp:
+		return messengerType.IsWhatsApp()
+	default:
+		return false
+	}
+}
+
 //go:generate mockery --name MessageDispatcherInterface --case=underscore --structname=MockMessageDispatcher --inpackage
 type MessageDispatcherInterface interface {
 	RegisterClient(ctx context.Context, channel MessageChannel, client MessengerClient)
 	SendMessage(ctx context.Context, message Message, channelPriority []MessageChannel) (MessengerType, error)
+	MessengerTypeFor(ctx context.Context, message Message, channelPriority []MessageChannel) MessengerType
 	GetClient(channel MessageChannel) (MessengerClient, error)
 }
 
 type MessageDispatcher struct {
 	clients map[MessageChannel]MessengerClient
+	// clientConfigModel holds the messenger clients configured by each tenant, which take precedence over the clients
+	// registered in the dispatcher.
+	clientConfigModel    ClientConfigModelInterface
+	encryptionPassphrase string
+	clientFactory        func(opts MessengerOptions) (MessengerClient, error)
 }
 
 func NewMessageDispatcher() *MessageDispatcher {
@@ -36,33 +72,65 @@ func (d *MessageDispatcher) RegisterClient(ctx context.Context, channel MessageC
 	d.clients[channel] = client
 }
 
-func (d *MessageDispatcher) SendMessage(ctx context.Context, message Message, channelPriority []MessageChannel) (MessengerType, error) {
-	// default to the highest priority channel messenger type.
-	messengerType := d.clients[channelPriority[0]].MessengerType()
+// UseTenantClientConfigs makes the dispatcher send the messages of the tenant in the context through the messenger
+// clients the tenant configured. The channels the tenant didn't configure keep using the registered clients.
+func (d *MessageDispatcher) UseTenantClientConfigs(clientConfigModel ClientConfigModelInterface, encryptionPassphrase string) {
+	d.clientConfigModel = clientConfigModel
+	d.encryptionPassphrase = encryptionPassphrase
+	d.clientFactory = GetClient
+}
+
+// clientForChannel returns the client the tenant in the context configured for the channel, falling back to the
+// client registered in the dispatcher only when the tenant didn't configure the channel. A tenant config that can't be
+// loaded or used is an error, so the tenant messages are never sent through the global client. A nil client is returned
+// when the channel has no client.
+func (d *MessageDispatcher) clientForChannel(ctx context.Context, channel MessageChannel) (MessengerClient, error) {
+	if d.clientConfigModel != nil {
+		opts, err := d.clientConfigModel.GetDecryptedOptions(ctx, channel, d.encryptionPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("getting the tenant messenger client config for channel %q: %w", channel, err)
+		}
+		if opts != nil {
+			client, err := d.clientFactory(*opts)
+			if err != nil {
+				return nil, fmt.Errorf("creating the tenant messenger client for channel %q: %w", channel, err)
+			}
+			return client, nil
+		}
+	}
+
+	return d.clients[channel], nil
+}
 
+func (d *MessageDispatcher) SendMessage(ctx context.Context, message Message, channelPriority []MessageChannel) (MessengerType, error) {
 	supportedChannels := make(map[MessageChannel]bool)
 	for _, ch := range message.SupportedChannels() {
 		supportedChannels[ch] = true
 	}
 
 	if len(supportedChannels) == 0 {
-		return messengerType, fmt.Errorf("no valid channel found for message %s", message)
+		return d.defaultMessengerType(ctx, channelPriority), fmt.Errorf("no valid channel found for message %s", message)
 	}
 
+	var messengerType MessengerType
 	for _, channel := range channelPriority {
 		if !supportedChannels[channel] {
 			log.Ctx(ctx).Debugf("Skipping channel %q since it's not supported for the message %s", channel, message)
 			continue
 		}
 
-		client, ok := d.clients[channel]
-		if !ok {
+		client, err := d.clientForChannel(ctx, channel)
+		if err != nil {
+			log.Ctx(ctx).Errorf("Error getting the client of channel %q: %v", channel, err)
+			continue
+		}
+		if client == nil {
 			log.Ctx(ctx).Warnf("No client registered for channel %q", channel)
 			continue
 		}
 		messengerType = client.MessengerType()
 
-		err := client.SendMessage(message)
+		err = client.SendMessage(message)
 		if err == nil {
 			return messengerType, nil
 		}
@@ -70,9 +138,39 @@ func (d *MessageDispatcher) SendMessage(ctx context.Context, message Message, ch
 		log.Ctx(ctx).Errorf("Error sending %s through messenger type %s: %v", channel, messengerType, err)
 	}
 
+	if messengerType == "" {
+		messengerType = d.defaultMessengerType(ctx, channelPriority)
+	}
 	return messengerType, fmt.Errorf("unable to send message %s using any of the supported channels [%v]", message, supportedChannels)
 }
 
+// MessengerTypeFor returns the messenger type SendMessage tries to send the message through first, so the message can be
+// stored before it's sent.
+func (d *MessageDispatcher) MessengerTypeFor(ctx context.Context, message Message, channelPriority []MessageChannel) MessengerType {
+	supportedChannels := message.SupportedChannels()
+	for _, channel := range channelPriority {
+		if !slices.Contains(supportedChannels, channel) {
+			continue
+		}
+		if client, err := d.clientForChannel(ctx, channel); err == nil && client != nil {
+			return client.MessengerType()
+		}
+	}
+
+	return d.defaultMessengerType(ctx, channelPriority)
+}
+
+// defaultMessengerType returns the messenger type of the highest priority channel with a client, reported when the
+// message couldn't be sent through any client.
+func (d *MessageDispatcher) defaultMessengerType(ctx context.Context, channelPriority []MessageChannel) MessengerType {
+	for _, channel := range channelPriority {
+		if client, err := d.clientForChannel(ctx, channel); err == nil && client != nil {
+			return client.MessengerType()
+		}
+	}
+	return ""
+}
+
 func (d *MessageDispatcher) GetClient(channel MessageChannel) (MessengerClient, error) {
 	client, ok := d.clients[channel]
 	if !ok {
@@ -229,3 +229,190 @@ func Test_MessageDispatcher_SendMessage(t *testing.T) {
 		})
 	}
 }
+
+func Test_MessageDispatcher_SendMessage_channelWithoutClient(t *testing.T) {
+	ctx := context.Background()
+	dispatcher := NewMessageDispatcher()
+
+	smsClient := NewMessengerClientMock(t)
+	smsClient.On("MessengerType").Return(MessengerTypeTwilioSMS)
+	dispatcher.RegisterClient(ctx, MessageChannelSMS, smsClient)
+
+	msg := Message{
+		ToPhoneNumber: "+14152111111",
+		Body:          "Test Message",
+		Template:      &MessageTemplate{Type: MessageTemplateTypeReceiverOTP, Variables: []string{"123456", "Org"}},
+	}
+	smsClient.
+		On("SendMessage", msg).
+		Return(nil).
+		Once()
+
+	messengerType, err := dispatcher.SendMessage(ctx, msg, []MessageChannel{MessageChannelWhatsApp, MessageChannelSMS, MessageChannelEmail})
+	assert.NoError(t, err)
+	assert.Equal(t, MessengerTypeTwilioSMS, messengerType)
+}
+
+func Test_MessageDispatcher_SendMessage_whatsAppFallsBackToSMS(t *testing.T) {
+	ctx := context.Background()
+	dispatcher := NewMessageDispatcher()
+
+	whatsAppClient := NewMessengerClientMock(t)
+	whatsAppClient.On("MessengerType").Return(MessengerTypeTwilioWhatsApp)
+	dispatcher.RegisterClient(ctx, MessageChannelWhatsApp, whatsAppClient)
+
+	smsClient := NewMessengerClientMock(t)
+	smsClient.On("MessengerType").Return(MessengerTypeTwilioSMS)
+	dispatcher.RegisterClient(ctx, MessageChannelSMS, smsClient)
+
+	channelPriority := []MessageChannel{MessageChannelWhatsApp, MessageChannelSMS, MessageChannelEmail}
+
+	t.Run("skips WhatsApp when the message has no template", func(t *testing.T) {
+		msg := Message{ToPhoneNumber: "+14152111111", Body: "Test Message"}
+		smsClient.
+			On("SendMessage", msg).
+			Return(nil).
+			Once()
+
+		messengerType, err := dispatcher.SendMessage(ctx, msg, channelPriority)
+		assert.NoError(t, err)
+		assert.Equal(t, MessengerTypeTwilioSMS, messengerType)
+	})
+
+	t.Run("falls back to SMS when WhatsApp fails", func(t *testing.T) {
+		msg := Message{
+			ToPhoneNumber: "+14152111111",
+			Body:          "Test Message",
+			Template:      &MessageTemplate{Type: MessageTemplateTypeReceiverOTP, Variables: []string{"123456", "Org"}},
+		}
+		whatsAppClient.
+			On("SendMessage", msg).
+			Return(errors.New("template not approved")).
+			Once()
+		smsClient.
+			On("SendMessage", msg).
+			Return(nil).
+			Once()
+
+		messengerType, err := dispatcher.SendMessage(ctx, msg, channelPriority)
+		assert.NoError(t, err)
+		assert.Equal(t, MessengerTypeTwilioSMS, messengerType)
+	})
+}
+
+func Test_MessageDispatcher_MessengerTypeFor(t *testing.T) {
+	ctx := context.Background()
+	dispatcher := NewMessageDispatcher()
+
+	whatsAppClient := NewMessengerClientMock(t)
+	whatsAppClient.On("MessengerType").Return(MessengerTypeTwilioWhatsApp)
+	dispatcher.RegisterClient(ctx, MessageChannelWhatsApp, whatsAppClient)
+
+	emailClient := NewMessengerClientMock(t)
+	emailClient.On("MessengerType").Return(MessengerTypeAWSEmail)
+	dispatcher.RegisterClient(ctx, MessageChannelEmail, emailClient)
+
+	channelPriority := []MessageChannel{MessageChannelWhatsApp, MessageChannelSMS, MessageChannelEmail}
+
+	msg := Message{ToPhoneNumber: "+14152111111", ToEmail: "receiver@test.com", Title: "Test Title", Body: "Test Message"}
+	assert.Equal(t, MessengerTypeAWSEmail, dispatcher.MessengerTypeFor(ctx, msg, channelPriority))
+
+	msg.Template = &MessageTemplate{Type: MessageTemplateTypeReceiverInvitation, Variables: []string{"Org", "link"}}
+	assert.Equal(t, MessengerTypeTwilioWhatsApp, dispatcher.MessengerTypeFor(ctx, msg, channelPriority))
+
+	msg = Message{ToPhoneNumber: "+14152111111", Body: "Test Message"}
+	assert.Equal(t, MessengerTypeTwilioWhatsApp, dispatcher.MessengerTypeFor(ctx, msg, channelPriority))
+}
+
+func Test_MessageDispatcher_SendMessage_tenantClientConfigs(t *testing.T) {
+	ctx := context.Background()
+	smsMessage := Message{ToPhoneNumber: "+14152111111", Body: "Test Message"}
+	channelPriority := []MessageChannel{MessageChannelSMS, MessageChannelEmail}
+	tenantOpts := MessengerOptions{MessengerType: MessengerTypeTwilioSMS, TwilioAccountSID: "tenantAccountSID"}
+
+	newDispatcher := func(t *testing.T) (*MessageDispatcher, *MessengerClientMock, *MockClientConfigModel, *MessengerClientMock) {
+		dispatcher := NewMessageDispatcher()
+		globalClient := NewMessengerClientMock(t)
+		globalClient.On("MessengerType").Return(MessengerTypeAWSSMS).Maybe()
+		dispatcher.RegisterClient(ctx, MessageChannelSMS, globalClient)
+
+		mClientConfigModel := NewMockClientConfigModel(t)
+		dispatcher.UseTenantClientConfigs(mClientConfigModel, "passphrase")
+
+		tenantClient := NewMessengerClientMock(t)
+		tenantClient.On("MessengerType").Return(MessengerTypeTwilioSMS).Maybe()
+		dispatcher.clientFactory = func(opts MessengerOptions) (MessengerClient, error) {
+			if opts.TwilioAccountSID != tenantOpts.TwilioAccountSID {
+				return nil, errors.New("invalid options")
+			}
+			return tenantClient, nil
+		}
+
+		return dispatcher, globalClient, mClientConfigModel, tenantClient
+	}
+
+	t.Run("sends through the client configured by the tenant", func(t *testing.T) {
+		dispatcher, _, mClientConfigModel, tenantClient := newDispatcher(t)
+		mClientConfigModel.
+			On("GetDecryptedOptions", ctx, MessageChannelSMS, "passphrase").
+			Return(&tenantOpts, nil).
+			Once()
+		tenantClient.
+			On("SendMessage", smsMessage).
+			Return(nil).
+			Once()
+
+		messengerType, err := dispatcher.SendMessage(ctx, smsMessage, channelPriority)
+		assert.NoError(t, err)
+		assert.Equal(t, MessengerTypeTwilioSMS, messengerType)
+	})
+
+	t.Run("falls back to the global client when the tenant didn't configure the channel", func(t *testing.T) {
+		dispatcher, globalClient, mClientConfigModel, _ := newDispatcher(t)
+		mClientConfigModel.
+			On("GetDecryptedOptions", ctx, MessageChannelSMS, "passphrase").
+			Return(nil, nil).
+			Once()
+		globalClient.
+			On("SendMessage", smsMessage).
+			Return(nil).
+			Once()
+
+		messengerType, err := dispatcher.SendMessage(ctx, smsMessage, channelPriority)
+		assert.NoError(t, err)
+		assert.Equal(t, MessengerTypeAWSSMS, messengerType)
+	})
+
+	// The global client isn't expected to send anything, so the tenant messages aren't billed to the global account.
+	t.Run("fails without falling back to the global client when the tenant config can't be loaded", func(t *testing.T) {
+		dispatcher, _, mClientConfigModel, _ := newDispatcher(t)
+		mClientConfigModel.
+			On("GetDecryptedOptions", ctx, MessageChannelSMS, "passphrase").
+			Return(nil, errors.New("decrypting messenger client credentials")).
+			Twice()
+		mClientConfigModel.
+			On("GetDecryptedOptions", ctx, MessageChannelEmail, "passphrase").
+			Return(nil, nil).
+			Once()
+
+		messengerType, err := dispatcher.SendMessage(ctx, smsMessage, channelPriority)
+		assert.ErrorContains(t, err, "unable to send message")
+		assert.Empty(t, messengerType)
+	})
+
+	t.Run("fails without falling back to the global client when the tenant client can't be created", func(t *testing.T) {
+		dispatcher, _, mClientConfigModel, _ := newDispatcher(t)
+		mClientConfigModel.
+			On("GetDecryptedOptions", ctx, MessageChannelSMS, "passphrase").
+			Return(&MessengerOptions{MessengerType: MessengerTypeTwilioSMS, TwilioAccountSID: "invalidAccountSID"}, nil).
+			Twice()
+		mClientConfigModel.
+			On("GetDecryptedOptions", ctx, MessageChannelEmail, "passphrase").
+			Return(nil, nil).
+			Once()
+
+		messengerType, err := dispatcher.SendMessage(ctx, smsMessage, channelPriority)
+		assert.ErrorContains(t, err, "unable to send message")
+		assert.Empty(t, messengerType)
+	})
+}
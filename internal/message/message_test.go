@@ -76,6 +76,29 @@ func Test_message_Validate(t *testing.T) {
 			message:       Message{ToEmail: "foo@test.com", Title: "My title", Body: "foo bar"},
 			wantErr:       nil,
 		},
+		// WhatsApp types
+		{
+			name:          "WhatsApp types need a valid phone number",
+			messengerType: MessengerTypeTwilioWhatsApp,
+			message:       Message{ToPhoneNumber: "invalid-phone"},
+			wantErr:       fmt.Errorf("invalid WhatsApp message: invalid phone number: the provided phone number is not a valid E.164 number"),
+		},
+		{
+			name:          "WhatsApp types need a template",
+			messengerType: MessengerTypeTwilioWhatsApp,
+			message:       Message{ToPhoneNumber: "+14152111111", Body: "foo bar"},
+			wantErr:       fmt.Errorf("invalid WhatsApp message: template is empty"),
+		},
+		{
+			name:          "[whatsapp] all fields are present for Twilio WhatsApp 🎉",
+			messengerType: MessengerTypeTwilioWhatsApp,
+			message: Message{
+				ToPhoneNumber: "+14152111111",
+				Body:          "foo bar",
+				Template:      &MessageTemplate{Type: MessageTemplateTypeReceiverOTP, Variables: []string{"123456", "Org"}},
+			},
+			wantErr: nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -111,6 +134,15 @@ func TestMessage_SupportedChannels(t *testing.T) {
 			message:      Message{ToPhoneNumber: "+14152111111", ToEmail: "test@example.com", Title: "Test", Body: "Hello"},
 			wantChannels: []MessageChannel{MessageChannelSMS, MessageChannelEmail},
 		},
+		{
+			name: "sms and WhatsApp when the message has a template",
+			message: Message{
+				ToPhoneNumber: "+14152111111",
+				Body:          "Hello",
+				Template:      &MessageTemplate{Type: MessageTemplateTypeReceiverOTP},
+			},
+			wantChannels: []MessageChannel{MessageChannelSMS, MessageChannelWhatsApp},
+		},
 		{
 			name:         "neither sms nor e-mail",
 			message:      Message{Body: "Hello"},
@@ -43,6 +43,24 @@ func (_m *MockMessageDispatcher) GetClient(channel MessageChannel) (MessengerCli
 	return r0, r1
 }
 
+// MessengerTypeFor provides a mock function with given fields: ctx, message, channelPriority
+func (_m *MockMessageDispatcher) MessengerTypeFor(ctx context.Context, message Message, channelPriority []MessageChannel) MessengerType {
+	ret := _m.Called(ctx, message, channelPriority)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MessengerTypeFor")
+	}
+
+	var r0 MessengerType
+	if rf, ok := ret.Get(0).(func(context.Context, Message, []MessageChannel) MessengerType); ok {
+		r0 = rf(ctx, message, channelPriority)
+	} else {
+		r0 = ret.Get(0).(MessengerType)
+	}
+
+	return r0
+}
+
 // RegisterClient provides a mock function with given fields: ctx, channel, client
 func (_m *MockMessageDispatcher) RegisterClient(ctx context.Context, channel MessageChannel, client MessengerClient) {
 	_m.Called(ctx, channel, client)
@@ -34,11 +34,16 @@ func (t *twilioClient) SendMessage(message Message) error {
 		return fmt.Errorf("validating SMS message: %w", err)
 	}
 
-	resp, err := t.CreateMessage(&twilioApi.CreateMessageParams{
+	params := &twilioApi.CreateMessageParams{
 		To:                  &message.ToPhoneNumber,
 		Body:                &message.Body,
 		MessagingServiceSid: &t.senderID,
-	})
+	}
+	if message.StatusCallbackURL != "" {
+		params.SetStatusCallback(message.StatusCallbackURL)
+	}
+
+	resp, err := t.CreateMessage(params)
 	if err != nil {
 		return fmt.Errorf("sending Twilio SMS: %w", err)
 	}
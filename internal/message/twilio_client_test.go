@@ -137,3 +137,26 @@ func Test_Twilio_SendMessage_success(t *testing.T) {
 
 	mTwilioApi.AssertExpectations(t)
 }
+
+func Test_Twilio_SendMessage_withStatusCallback(t *testing.T) {
+	testPhoneNumber := "+14153333333"
+	testMessage := "foo bar"
+	testSenderID := "senderID"
+	testStatusCallbackURL := "https://tenant.sdp.com/message-status-callbacks/twilio?message_id=message-id"
+	mTwilioApi := mockTwilioApi{}
+	mTwilioApi.
+		On("CreateMessage", &twilioAPI.CreateMessageParams{
+			To:                  &testPhoneNumber,
+			Body:                &testMessage,
+			MessagingServiceSid: &testSenderID,
+			StatusCallback:      &testStatusCallbackURL,
+		}).
+		Return(&twilioAPI.ApiV2010Message{}, nil).
+		Once()
+
+	mTwilio := twilioClient{apiService: &mTwilioApi, senderID: "senderID"}
+	err := mTwilio.SendMessage(Message{ToPhoneNumber: "+14153333333", Body: "foo bar", StatusCallbackURL: testStatusCallbackURL})
+	require.NoError(t, err)
+
+	mTwilioApi.AssertExpectations(t)
+}
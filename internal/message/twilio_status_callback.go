@@ -0,0 +1,73 @@
+package message
+
+import (
+	"fmt"
+	"net/url"
+
+	twilioRequestValidator "github.com/twilio/twilio-go/client"
+)
+
+const (
+	// TwilioStatusCallbackPath is the path, relative to the tenant base URL, Twilio reports the messages status to.
+	TwilioStatusCallbackPath = "/message-status-callbacks/twilio"
+	// TwilioSignatureHeader is the header holding the signature of the requests Twilio sends to the status callbacks.
+	TwilioSignatureHeader = "X-Twilio-Signature"
+)
+
+// TwilioStatusCallbackURL returns the URL Twilio reports the status of the message with the messageID to.
+func TwilioStatusCallbackURL(tenantBaseURL, messageID string) (string, error) {
+	callbackURL, err := url.JoinPath(tenantBaseURL, TwilioStatusCallbackPath)
+	if err != nil {
+		return "", fmt.Errorf("joining the tenant base URL %q with the status callback path: %w", tenantBaseURL, err)
+	}
+
+	return callbackURL + "?" + url.Values{"message_id": {messageID}}.Encode(), nil
+}
+
+// ValidateTwilioSignature returns true if the signature of the request Twilio sent to the callbackURL, with the
+// form-encoded body, was made with any of the authTokens.
+func ValidateTwilioSignature(authTokens []string, callbackURL string, body []byte, signature string) bool {
+	if signature == "" {
+		return false
+	}
+
+	for _, authToken := range authTokens {
+		if authToken == "" {
+			continue
+		}
+
+		validator := twilioRequestValidator.NewRequestValidator(authToken)
+		if validator.ValidateBody(callbackURL, body, signature) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseTwilioStatusCallback parses the status Twilio reported for the SMS or WhatsApp message with the messageID. It
+// returns nil when the status isn't final, like `queued` or `sent`.
+func ParseTwilioStatusCallback(messageID string, form url.Values) (*DeliveryStatusUpdate, error) {
+	if messageID == "" {
+		return nil, fmt.Errorf("message ID is empty")
+	}
+
+	var status DeliveryStatus
+	switch messageStatus := form.Get("MessageStatus"); messageStatus {
+	case "delivered", "read":
+		status = DeliveryStatusDelivered
+	case "undelivered", "failed":
+		status = DeliveryStatusFailed
+	case "":
+		return nil, fmt.Errorf("MessageStatus is empty")
+	default:
+		return nil, nil
+	}
+
+	return &DeliveryStatusUpdate{
+		MessageID:    messageID,
+		Status:       status,
+		ErrorCode:    form.Get("ErrorCode"),
+		ErrorMessage: form.Get("ErrorMessage"),
+	}, nil
+}
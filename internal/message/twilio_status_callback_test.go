@@ -0,0 +1,133 @@
+package message
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TwilioStatusCallbackURL(t *testing.T) {
+	callbackURL, err := TwilioStatusCallbackURL("https://tenant.sdp.com/", "message-id")
+	require.NoError(t, err)
+	assert.Equal(t, "https://tenant.sdp.com/message-status-callbacks/twilio?message_id=message-id", callbackURL)
+
+	_, err = TwilioStatusCallbackURL("://tenant.sdp.com", "message-id")
+	assert.ErrorContains(t, err, `joining the tenant base URL "://tenant.sdp.com" with the status callback path`)
+}
+
+func Test_ValidateTwilioSignature(t *testing.T) {
+	callbackURL := "https://tenant.sdp.com/message-status-callbacks/twilio?message_id=message-id"
+	body := []byte("MessageSid=SM123&MessageStatus=delivered")
+
+	mac := hmac.New(sha1.New, []byte("auth-token"))
+	mac.Write([]byte(callbackURL + "MessageSidSM123MessageStatusdelivered"))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	testCases := []struct {
+		name        string
+		authTokens  []string
+		callbackURL string
+		signature   string
+		wantValid   bool
+	}{
+		{
+			name:        "the signature can't be empty",
+			authTokens:  []string{"auth-token"},
+			callbackURL: callbackURL,
+			signature:   "",
+			wantValid:   false,
+		},
+		{
+			name:        "the signature must be made with one of the auth tokens",
+			authTokens:  []string{"", "another-auth-token"},
+			callbackURL: callbackURL,
+			signature:   signature,
+			wantValid:   false,
+		},
+		{
+			name:        "the signature must be made for the callback URL",
+			authTokens:  []string{"auth-token"},
+			callbackURL: "https://tenant.sdp.com/message-status-callbacks/twilio?message_id=another-message-id",
+			signature:   signature,
+			wantValid:   false,
+		},
+		{
+			name:        "🎉 the signature is valid",
+			authTokens:  []string{"another-auth-token", "auth-token"},
+			callbackURL: callbackURL,
+			signature:   signature,
+			wantValid:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantValid, ValidateTwilioSignature(tc.authTokens, tc.callbackURL, body, tc.signature))
+		})
+	}
+}
+
+func Test_ParseTwilioStatusCallback(t *testing.T) {
+	t.Run("the message ID can't be empty", func(t *testing.T) {
+		update, err := ParseTwilioStatusCallback("", url.Values{"MessageStatus": {"delivered"}})
+		assert.EqualError(t, err, "message ID is empty")
+		assert.Nil(t, update)
+	})
+
+	t.Run("the message status can't be empty", func(t *testing.T) {
+		update, err := ParseTwilioStatusCallback("message-id", url.Values{})
+		assert.EqualError(t, err, "MessageStatus is empty")
+		assert.Nil(t, update)
+	})
+
+	for _, messageStatus := range []string{"queued", "sending", "sent", "accepted"} {
+		t.Run("ignores the status "+messageStatus, func(t *testing.T) {
+			update, err := ParseTwilioStatusCallback("message-id", url.Values{"MessageStatus": {messageStatus}})
+			require.NoError(t, err)
+			assert.Nil(t, update)
+		})
+	}
+
+	testCases := []struct {
+		messageStatus string
+		errorCode     string
+		wantUpdate    *DeliveryStatusUpdate
+	}{
+		{
+			messageStatus: "delivered",
+			wantUpdate:    &DeliveryStatusUpdate{MessageID: "message-id", Status: DeliveryStatusDelivered},
+		},
+		{
+			messageStatus: "read",
+			wantUpdate:    &DeliveryStatusUpdate{MessageID: "message-id", Status: DeliveryStatusDelivered},
+		},
+		{
+			messageStatus: "undelivered",
+			errorCode:     "30003",
+			wantUpdate:    &DeliveryStatusUpdate{MessageID: "message-id", Status: DeliveryStatusFailed, ErrorCode: "30003"},
+		},
+		{
+			messageStatus: "failed",
+			errorCode:     "30008",
+			wantUpdate:    &DeliveryStatusUpdate{MessageID: "message-id", Status: DeliveryStatusFailed, ErrorCode: "30008"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run("🎉 parses the status "+tc.messageStatus, func(t *testing.T) {
+			form := url.Values{"MessageStatus": {tc.messageStatus}}
+			if tc.errorCode != "" {
+				form.Set("ErrorCode", tc.errorCode)
+			}
+
+			update, err := ParseTwilioStatusCallback("message-id", form)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantUpdate, update)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go/support/log"
+	"github.com/twilio/twilio-go"
+	twilioApi "github.com/twilio/twilio-go/rest/api/v2010"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+const twilioWhatsAppAddressPrefix = "whatsapp:"
+
+// twilioWhatsAppClient sends WhatsApp messages through Twilio. WhatsApp only allows businesses to start a conversation
+// with templates approved beforehand, so the messages are sent with the Twilio Content template configured for their
+// template type, instead of their body.
+type twilioWhatsAppClient struct {
+	apiService   twilioApiInterface
+	senderNumber string
+	contentSIDs  map[MessageTemplateType]string
+}
+
+func (t *twilioWhatsAppClient) MessengerType() MessengerType {
+	return MessengerTypeTwilioWhatsApp
+}
+
+func (t *twilioWhatsAppClient) SendMessage(message Message) error {
+	err := message.ValidateFor(t.MessengerType())
+	if err != nil {
+		return fmt.Errorf("validating WhatsApp message: %w", err)
+	}
+
+	contentSID, ok := t.contentSIDs[message.Template.Type]
+	if !ok {
+		return fmt.Errorf("no approved WhatsApp template configured for %q", message.Template.Type)
+	}
+
+	// The Twilio Content placeholders are numbered, starting from 1.
+	variables := make(map[string]string, len(message.Template.Variables))
+	for i, v := range message.Template.Variables {
+		variables[strconv.Itoa(i+1)] = v
+	}
+	contentVariables, err := json.Marshal(variables)
+	if err != nil {
+		return fmt.Errorf("marshalling WhatsApp template variables: %w", err)
+	}
+
+	from := twilioWhatsAppAddressPrefix + t.senderNumber
+	to := twilioWhatsAppAddressPrefix + message.ToPhoneNumber
+	params := &twilioApi.CreateMessageParams{
+		From:             &from,
+		To:               &to,
+		ContentSid:       &contentSID,
+		ContentVariables: utils.StringPtr(string(contentVariables)),
+	}
+	if message.StatusCallbackURL != "" {
+		params.SetStatusCallback(message.StatusCallbackURL)
+	}
+
+	resp, err := t.apiService.CreateMessage(params)
+	if err != nil {
+		return fmt.Errorf("sending Twilio WhatsApp message: %w", err)
+	}
+
+	if resp.ErrorCode != nil || resp.ErrorMessage != nil {
+		var errorCode string
+		if resp.ErrorCode != nil {
+			errorCode = fmt.Sprintf("%d", *resp.ErrorCode)
+		}
+
+		var errorMessage string
+		if resp.ErrorMessage != nil {
+			errorMessage = *resp.ErrorMessage
+		}
+
+		return fmt.Errorf("sending Twilio WhatsApp message responded an error {code: %q, message: %q}", errorCode, errorMessage)
+	}
+
+	log.Debugf("Twilio sent a WhatsApp message to the phoneNumber %q", utils.TruncateString(message.ToPhoneNumber, 3))
+	return nil
+}
+
+// NewTwilioWhatsAppClient creates a Twilio WhatsApp client that sends messages from the senderNumber, using the
+// approved Twilio Content templates in contentSIDs. The template types without a content SID can't be sent.
+func NewTwilioWhatsAppClient(accountSid, authToken, senderNumber string, contentSIDs map[MessageTemplateType]string) (*twilioWhatsAppClient, error) {
+	accountSid = strings.TrimSpace(accountSid)
+	if accountSid == "" {
+		return nil, fmt.Errorf("twilio accountSid is empty")
+	}
+
+	authToken = strings.TrimSpace(authToken)
+	if authToken == "" {
+		return nil, fmt.Errorf("twilio authToken is empty")
+	}
+
+	senderNumber = strings.TrimPrefix(strings.TrimSpace(senderNumber), twilioWhatsAppAddressPrefix)
+	if err := utils.ValidatePhoneNumber(senderNumber); err != nil {
+		return nil, fmt.Errorf("twilio WhatsApp sender number is invalid: %w", err)
+	}
+
+	approvedContentSIDs := make(map[MessageTemplateType]string, len(contentSIDs))
+	for templateType, contentSID := range contentSIDs {
+		if contentSID = strings.TrimSpace(contentSID); contentSID != "" {
+			approvedContentSIDs[templateType] = contentSID
+		}
+	}
+	if len(approvedContentSIDs) == 0 {
+		return nil, fmt.Errorf("twilio WhatsApp content SIDs are empty")
+	}
+
+	return &twilioWhatsAppClient{
+		apiService: twilio.NewRestClientWithParams(twilio.ClientParams{
+			Username: accountSid,
+			Password: authToken,
+		}).Api,
+		senderNumber: senderNumber,
+		contentSIDs:  approvedContentSIDs,
+	}, nil
+}
+
+var _ MessengerClient = (*twilioWhatsAppClient)(nil)
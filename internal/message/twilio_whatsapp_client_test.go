@@ -0,0 +1,168 @@
+package message
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twilio/twilio-go"
+	twilioAPI "github.com/twilio/twilio-go/rest/api/v2010"
+)
+
+func Test_NewTwilioWhatsAppClient(t *testing.T) {
+	contentSIDs := map[MessageTemplateType]string{MessageTemplateTypeReceiverOTP: "HX123"}
+
+	testCases := []struct {
+		name         string
+		accountSid   string
+		authToken    string
+		senderNumber string
+		contentSIDs  map[MessageTemplateType]string
+		wantErr      string
+	}{
+		{
+			name:    "accountSid cannot be empty",
+			wantErr: "twilio accountSid is empty",
+		},
+		{
+			name:       "authToken cannot be empty",
+			accountSid: "accountSid",
+			authToken:  "  ",
+			wantErr:    "twilio authToken is empty",
+		},
+		{
+			name:         "senderNumber must be a valid phone number",
+			accountSid:   "accountSid",
+			authToken:    "authToken",
+			senderNumber: "invalid",
+			wantErr:      "twilio WhatsApp sender number is invalid: the provided phone number is not a valid E.164 number",
+		},
+		{
+			name:         "contentSIDs cannot be empty",
+			accountSid:   "accountSid",
+			authToken:    "authToken",
+			senderNumber: "+14155111111",
+			contentSIDs:  map[MessageTemplateType]string{MessageTemplateTypeReceiverOTP: " "},
+			wantErr:      "twilio WhatsApp content SIDs are empty",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotClient, err := NewTwilioWhatsAppClient(tc.accountSid, tc.authToken, tc.senderNumber, tc.contentSIDs)
+			assert.Nil(t, gotClient)
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+
+	t.Run("🎉 all fields are present", func(t *testing.T) {
+		gotClient, err := NewTwilioWhatsAppClient("accountSid", "authToken", "whatsapp:+14155111111", contentSIDs)
+		require.NoError(t, err)
+
+		wantClient := &twilioWhatsAppClient{
+			apiService: twilio.NewRestClientWithParams(twilio.ClientParams{
+				Username: "accountSid",
+				Password: "authToken",
+			}).Api,
+			senderNumber: "+14155111111",
+			contentSIDs:  contentSIDs,
+		}
+		assert.Equal(t, wantClient, gotClient)
+	})
+}
+
+func Test_TwilioWhatsApp_messengerType(t *testing.T) {
+	client := twilioWhatsAppClient{}
+	require.Equal(t, MessengerTypeTwilioWhatsApp, client.MessengerType())
+}
+
+func Test_TwilioWhatsApp_SendMessage(t *testing.T) {
+	validMessage := Message{
+		ToPhoneNumber: "+14153333333",
+		Body:          "123456 is your OTP",
+		Template:      &MessageTemplate{Type: MessageTemplateTypeReceiverOTP, Variables: []string{"123456", "Aid Org"}},
+	}
+	wantParams := &twilioAPI.CreateMessageParams{}
+	wantParams.SetFrom("whatsapp:+14155111111").
+		SetTo("whatsapp:+14153333333").
+		SetContentSid("HX123").
+		SetContentVariables(`{"1":"123456","2":"Aid Org"}`)
+
+	newClient := func(mTwilioApi *mockTwilioApi) *twilioWhatsAppClient {
+		return &twilioWhatsAppClient{
+			apiService:   mTwilioApi,
+			senderNumber: "+14155111111",
+			contentSIDs:  map[MessageTemplateType]string{MessageTemplateTypeReceiverOTP: "HX123"},
+		}
+	}
+
+	t.Run("the message must have a template", func(t *testing.T) {
+		err := newClient(&mockTwilioApi{}).SendMessage(Message{ToPhoneNumber: "+14153333333", Body: "foo bar"})
+		assert.EqualError(t, err, "validating WhatsApp message: invalid WhatsApp message: template is empty")
+	})
+
+	t.Run("the template type must have an approved template", func(t *testing.T) {
+		msg := validMessage
+		msg.Template = &MessageTemplate{Type: MessageTemplateTypeReceiverInvitation}
+
+		err := newClient(&mockTwilioApi{}).SendMessage(msg)
+		assert.EqualError(t, err, `no approved WhatsApp template configured for "RECEIVER_INVITATION"`)
+	})
+
+	t.Run("handles the Twilio errors", func(t *testing.T) {
+		mTwilioApi := &mockTwilioApi{}
+		mTwilioApi.
+			On("CreateMessage", wantParams).
+			Return(nil, fmt.Errorf("test twilio error")).
+			Once()
+
+		err := newClient(mTwilioApi).SendMessage(validMessage)
+		assert.EqualError(t, err, "sending Twilio WhatsApp message: test twilio error")
+		mTwilioApi.AssertExpectations(t)
+	})
+
+	t.Run("handles the errors embedded in the response", func(t *testing.T) {
+		errCode := 63016
+		errMessage := "Failed to send freeform message because you are outside the allowed window"
+		mTwilioApi := &mockTwilioApi{}
+		mTwilioApi.
+			On("CreateMessage", wantParams).
+			Return(&twilioAPI.ApiV2010Message{ErrorCode: &errCode, ErrorMessage: &errMessage}, nil).
+			Once()
+
+		err := newClient(mTwilioApi).SendMessage(validMessage)
+		assert.EqualError(t, err, `sending Twilio WhatsApp message responded an error {code: "63016", message: "Failed to send freeform message because you are outside the allowed window"}`)
+		mTwilioApi.AssertExpectations(t)
+	})
+
+	t.Run("🎉 sends the message with the approved template", func(t *testing.T) {
+		mTwilioApi := &mockTwilioApi{}
+		mTwilioApi.
+			On("CreateMessage", wantParams).
+			Return(&twilioAPI.ApiV2010Message{}, nil).
+			Once()
+
+		err := newClient(mTwilioApi).SendMessage(validMessage)
+		assert.NoError(t, err)
+		mTwilioApi.AssertExpectations(t)
+	})
+
+	t.Run("🎉 sends the message with its status callback", func(t *testing.T) {
+		msg := validMessage
+		msg.StatusCallbackURL = "https://tenant.sdp.com/message-status-callbacks/twilio?message_id=message-id"
+
+		wantParamsWithCallback := *wantParams
+		wantParamsWithCallback.SetStatusCallback(msg.StatusCallbackURL)
+
+		mTwilioApi := &mockTwilioApi{}
+		mTwilioApi.
+			On("CreateMessage", &wantParamsWithCallback).
+			Return(&twilioAPI.ApiV2010Message{}, nil).
+			Once()
+
+		err := newClient(mTwilioApi).SendMessage(msg)
+		assert.NoError(t, err)
+		mTwilioApi.AssertExpectations(t)
+	})
+}
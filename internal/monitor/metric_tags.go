@@ -14,6 +14,9 @@ const (
 	// Circle API Requests
 	CircleAPIRequestDurationTag MetricTag = "circle_api_request_duration_seconds"
 	CircleAPIRequestsTotalTag   MetricTag = "circle_api_requests_total"
+	// Receiver Registration
+	ReceiverVerificationLockoutEventsTag MetricTag = "receiver_verification_lockout_events_total"
+	ReceiverRegistrationRateLimitedTag   MetricTag = "receiver_registration_rate_limited_total"
 )
 
 func (m MetricTag) ListAll() []MetricTag {
@@ -26,5 +29,7 @@ func (m MetricTag) ListAll() []MetricTag {
 		AnchorPlatformAuthProtectionMissingCounterTag,
 		CircleAPIRequestDurationTag,
 		CircleAPIRequestsTotalTag,
+		ReceiverVerificationLockoutEventsTag,
+		ReceiverRegistrationRateLimitedTag,
 	}
 }
@@ -41,3 +41,45 @@ func (c CircleLabels) ToMap() map[string]string {
 }
 
 var CircleLabelNames = []string{"method", "endpoint", "status", "status_code", "tenant_name"}
+
+// ReceiverVerificationLockoutEvent is what happened to the lockout of a receiver verification.
+type ReceiverVerificationLockoutEvent string
+
+const (
+	// ReceiverVerificationLockoutEventLocked is when a receiver is locked out after failing too many times.
+	ReceiverVerificationLockoutEventLocked ReceiverVerificationLockoutEvent = "locked"
+	// ReceiverVerificationLockoutEventRejected is when a locked out receiver tries to register again.
+	ReceiverVerificationLockoutEventRejected ReceiverVerificationLockoutEvent = "rejected"
+	// ReceiverVerificationLockoutEventReset is when an admin resets the lockout of a receiver.
+	ReceiverVerificationLockoutEventReset ReceiverVerificationLockoutEvent = "reset"
+)
+
+type ReceiverVerificationLockoutLabels struct {
+	Event      ReceiverVerificationLockoutEvent
+	TenantName string
+}
+
+func (r ReceiverVerificationLockoutLabels) ToMap() map[string]string {
+	return map[string]string{
+		"event":       string(r.Event),
+		"tenant_name": r.TenantName,
+	}
+}
+
+var ReceiverVerificationLockoutLabelNames = []string{"event", "tenant_name"}
+
+type ReceiverRegistrationRateLimitLabels struct {
+	Endpoint   string
+	LimitType  string
+	TenantName string
+}
+
+func (r ReceiverRegistrationRateLimitLabels) ToMap() map[string]string {
+	return map[string]string{
+		"endpoint":    r.Endpoint,
+		"limit_type":  r.LimitType,
+		"tenant_name": r.TenantName,
+	}
+}
+
+var ReceiverRegistrationRateLimitLabelNames = []string{"endpoint", "limit_type", "tenant_name"}
@@ -80,4 +80,16 @@ var CounterVecMetrics = map[MetricTag]*prometheus.CounterVec{
 	},
 		CircleLabelNames,
 	),
+	ReceiverVerificationLockoutEventsTag: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "sdp", Subsystem: "receiver_registration", Name: string(ReceiverVerificationLockoutEventsTag),
+		Help: "A counter of the receivers locked out for failing their verification, the attempts rejected because of a lockout and the lockouts reset by admins",
+	},
+		ReceiverVerificationLockoutLabelNames,
+	),
+	ReceiverRegistrationRateLimitedTag: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "sdp", Subsystem: "receiver_registration", Name: string(ReceiverRegistrationRateLimitedTag),
+		Help: "A counter of the wallet registration requests rejected for exceeding a rate limit",
+	},
+		ReceiverRegistrationRateLimitLabelNames,
+	),
 }
@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing"
+)
+
+const (
+	claimableBalanceStatusJobName            = "claimable_balance_status_job"
+	claimableBalanceStatusJobIntervalSeconds = 60
+)
+
+type ClaimableBalanceStatusJobOptions struct {
+	Models              *data.Models
+	HorizonClient       horizonclient.ClientInterface
+	DistAccountResolver signing.DistributionAccountResolver
+}
+
+func NewClaimableBalanceStatusJob(opts ClaimableBalanceStatusJobOptions) Job {
+	return &claimableBalanceStatusJob{
+		jobIntervalSeconds: claimableBalanceStatusJobIntervalSeconds,
+		statusService: &services.ClaimableBalanceStatusService{
+			Models:              opts.Models,
+			HorizonClient:       opts.HorizonClient,
+			DistAccountResolver: opts.DistAccountResolver,
+		},
+	}
+}
+
+type claimableBalanceStatusJob struct {
+	jobIntervalSeconds int
+	statusService      services.ClaimableBalanceStatusServiceInterface
+}
+
+func (j claimableBalanceStatusJob) IsJobMultiTenant() bool {
+	return true
+}
+
+func (j claimableBalanceStatusJob) GetInterval() time.Duration {
+	jobIntervalSeconds := j.jobIntervalSeconds
+	if j.jobIntervalSeconds == 0 {
+		log.Warnf("job interval is not set for %s. Using default interval: %d seconds", j.GetName(), DefaultMinimumJobIntervalSeconds)
+		jobIntervalSeconds = DefaultMinimumJobIntervalSeconds
+	}
+	return time.Duration(jobIntervalSeconds) * time.Second
+}
+
+func (j claimableBalanceStatusJob) GetName() string {
+	return claimableBalanceStatusJobName
+}
+
+func (j claimableBalanceStatusJob) Execute(ctx context.Context) error {
+	err := j.statusService.SyncClaimableBalances(ctx)
+	if err != nil {
+		return fmt.Errorf("executing Job %s: %w", j.GetName(), err)
+	}
+	return nil
+}
+
+var _ Job = (*claimableBalanceStatusJob)(nil)
@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services/mocks"
+)
+
+func Test_claimableBalanceStatusJob_GetInterval(t *testing.T) {
+	job := NewClaimableBalanceStatusJob(ClaimableBalanceStatusJobOptions{})
+	require.Equal(t, claimableBalanceStatusJobIntervalSeconds*time.Second, job.GetInterval())
+}
+
+func Test_claimableBalanceStatusJob_GetName(t *testing.T) {
+	job := NewClaimableBalanceStatusJob(ClaimableBalanceStatusJobOptions{})
+	require.Equal(t, claimableBalanceStatusJobName, job.GetName())
+}
+
+func Test_claimableBalanceStatusJob_IsJobMultiTenant(t *testing.T) {
+	job := NewClaimableBalanceStatusJob(ClaimableBalanceStatusJobOptions{})
+	require.Equal(t, true, job.IsJobMultiTenant())
+}
+
+func Test_claimableBalanceStatusJob_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		prepareMocksFn  func(mStatusService *mocks.MockClaimableBalanceStatusService)
+		wantErrContains string
+	}{
+		{
+			name: "🔴 execution fails",
+			prepareMocksFn: func(mStatusService *mocks.MockClaimableBalanceStatusService) {
+				mStatusService.
+					On("SyncClaimableBalances", ctx).
+					Return(assert.AnError).
+					Once()
+			},
+			wantErrContains: "executing Job",
+		},
+		{
+			name: "🟢 execution succeeds",
+			prepareMocksFn: func(mStatusService *mocks.MockClaimableBalanceStatusService) {
+				mStatusService.
+					On("SyncClaimableBalances", ctx).
+					Return(nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mStatusService := mocks.NewMockClaimableBalanceStatusService(t)
+			tc.prepareMocksFn(mStatusService)
+			job := claimableBalanceStatusJob{
+				jobIntervalSeconds: 5,
+				statusService:      mStatusService,
+			}
+
+			err := job.Execute(ctx)
+			if tc.wantErrContains != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tc.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
+)
+
+const (
+	disbursementInstructionUploadsJobName            = "disbursement_instruction_uploads_job"
+	disbursementInstructionUploadsJobIntervalSeconds = 10
+)
+
+type DisbursementInstructionUploadsJobOptions struct {
+	Models *data.Models
+}
+
+// NewDisbursementInstructionUploadsJob creates a job that processes the asynchronous instruction uploads in chunks.
+func NewDisbursementInstructionUploadsJob(opts DisbursementInstructionUploadsJobOptions) Job {
+	return &disbursementInstructionUploadsJob{
+		jobIntervalSeconds: disbursementInstructionUploadsJobIntervalSeconds,
+		uploadService:      services.NewDisbursementInstructionUploadService(opts.Models),
+	}
+}
+
+type disbursementInstructionUploadsJob struct {
+	jobIntervalSeconds int
+	uploadService      services.DisbursementInstructionUploadServiceInterface
+}
+
+func (j disbursementInstructionUploadsJob) IsJobMultiTenant() bool {
+	return true
+}
+
+func (j disbursementInstructionUploadsJob) GetInterval() time.Duration {
+	jobIntervalSeconds := j.jobIntervalSeconds
+	if j.jobIntervalSeconds == 0 {
+		log.Warnf("job interval is not set for %s. Using default interval: %d seconds", j.GetName(), DefaultMinimumJobIntervalSeconds)
+		jobIntervalSeconds = DefaultMinimumJobIntervalSeconds
+	}
+	return time.Duration(jobIntervalSeconds) * time.Second
+}
+
+func (j disbursementInstructionUploadsJob) GetName() string {
+	return disbursementInstructionUploadsJobName
+}
+
+func (j disbursementInstructionUploadsJob) Execute(ctx context.Context) error {
+	err := j.uploadService.ProcessPendingUploads(ctx)
+	if err != nil {
+		return fmt.Errorf("executing Job %s: %w", j.GetName(), err)
+	}
+	return nil
+}
+
+var _ Job = (*disbursementInstructionUploadsJob)(nil)
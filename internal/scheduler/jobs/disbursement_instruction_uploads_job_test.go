@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services/mocks"
+)
+
+func Test_disbursementInstructionUploadsJob_GetInterval(t *testing.T) {
+	job := NewDisbursementInstructionUploadsJob(DisbursementInstructionUploadsJobOptions{})
+	require.Equal(t, disbursementInstructionUploadsJobIntervalSeconds*time.Second, job.GetInterval())
+}
+
+func Test_disbursementInstructionUploadsJob_GetName(t *testing.T) {
+	job := NewDisbursementInstructionUploadsJob(DisbursementInstructionUploadsJobOptions{})
+	require.Equal(t, disbursementInstructionUploadsJobName, job.GetName())
+}
+
+func Test_disbursementInstructionUploadsJob_IsJobMultiTenant(t *testing.T) {
+	job := NewDisbursementInstructionUploadsJob(DisbursementInstructionUploadsJobOptions{})
+	require.Equal(t, true, job.IsJobMultiTenant())
+}
+
+func Test_disbursementInstructionUploadsJob_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		prepareMocksFn  func(mUploadService *mocks.MockDisbursementInstructionUploadService)
+		wantErrContains string
+	}{
+		{
+			name: "🔴 execution fails",
+			prepareMocksFn: func(mUploadService *mocks.MockDisbursementInstructionUploadService) {
+				mUploadService.
+					On("ProcessPendingUploads", ctx).
+					Return(assert.AnError).
+					Once()
+			},
+			wantErrContains: "executing Job",
+		},
+		{
+			name: "🟢 execution succeeds",
+			prepareMocksFn: func(mUploadService *mocks.MockDisbursementInstructionUploadService) {
+				mUploadService.
+					On("ProcessPendingUploads", ctx).
+					Return(nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mUploadService := mocks.NewMockDisbursementInstructionUploadService(t)
+			tc.prepareMocksFn(mUploadService)
+			job := disbursementInstructionUploadsJob{
+				jobIntervalSeconds: 5,
+				uploadService:      mUploadService,
+			}
+
+			err := job.Execute(ctx)
+			if tc.wantErrContains != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tc.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
+)
+
+const (
+	disbursementTemplatesJobName            = "disbursement_templates_job"
+	disbursementTemplatesJobIntervalSeconds = 60
+)
+
+type DisbursementTemplatesJobOptions struct {
+	Models *data.Models
+}
+
+// NewDisbursementTemplatesJob creates a job that clones the due recurring disbursement templates into new
+// disbursements.
+func NewDisbursementTemplatesJob(opts DisbursementTemplatesJobOptions) Job {
+	return &disbursementTemplatesJob{
+		jobIntervalSeconds: disbursementTemplatesJobIntervalSeconds,
+		templateService: &services.DisbursementTemplateService{
+			Models: opts.Models,
+		},
+	}
+}
+
+type disbursementTemplatesJob struct {
+	jobIntervalSeconds int
+	templateService    services.DisbursementTemplateServiceInterface
+}
+
+func (j disbursementTemplatesJob) IsJobMultiTenant() bool {
+	return true
+}
+
+func (j disbursementTemplatesJob) GetInterval() time.Duration {
+	jobIntervalSeconds := j.jobIntervalSeconds
+	if j.jobIntervalSeconds == 0 {
+		log.Warnf("job interval is not set for %s. Using default interval: %d seconds", j.GetName(), DefaultMinimumJobIntervalSeconds)
+		jobIntervalSeconds = DefaultMinimumJobIntervalSeconds
+	}
+	return time.Duration(jobIntervalSeconds) * time.Second
+}
+
+func (j disbursementTemplatesJob) GetName() string {
+	return disbursementTemplatesJobName
+}
+
+func (j disbursementTemplatesJob) Execute(ctx context.Context) error {
+	err := j.templateService.CreateDueDisbursements(ctx)
+	if err != nil {
+		return fmt.Errorf("executing Job %s: %w", j.GetName(), err)
+	}
+	return nil
+}
+
+var _ Job = (*disbursementTemplatesJob)(nil)
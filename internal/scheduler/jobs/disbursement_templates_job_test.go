@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services/mocks"
+)
+
+func Test_disbursementTemplatesJob_GetInterval(t *testing.T) {
+	job := NewDisbursementTemplatesJob(DisbursementTemplatesJobOptions{})
+	require.Equal(t, disbursementTemplatesJobIntervalSeconds*time.Second, job.GetInterval())
+}
+
+func Test_disbursementTemplatesJob_GetName(t *testing.T) {
+	job := NewDisbursementTemplatesJob(DisbursementTemplatesJobOptions{})
+	require.Equal(t, disbursementTemplatesJobName, job.GetName())
+}
+
+func Test_disbursementTemplatesJob_IsJobMultiTenant(t *testing.T) {
+	job := NewDisbursementTemplatesJob(DisbursementTemplatesJobOptions{})
+	require.Equal(t, true, job.IsJobMultiTenant())
+}
+
+func Test_disbursementTemplatesJob_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		prepareMocksFn  func(mTemplateService *mocks.MockDisbursementTemplateService)
+		wantErrContains string
+	}{
+		{
+			name: "🔴 execution fails",
+			prepareMocksFn: func(mTemplateService *mocks.MockDisbursementTemplateService) {
+				mTemplateService.
+					On("CreateDueDisbursements", ctx).
+					Return(assert.AnError).
+					Once()
+			},
+			wantErrContains: "executing Job",
+		},
+		{
+			name: "🟢 execution succeeds",
+			prepareMocksFn: func(mTemplateService *mocks.MockDisbursementTemplateService) {
+				mTemplateService.
+					On("CreateDueDisbursements", ctx).
+					Return(nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mTemplateService := mocks.NewMockDisbursementTemplateService(t)
+			tc.prepareMocksFn(mTemplateService)
+			job := disbursementTemplatesJob{
+				jobIntervalSeconds: 5,
+				templateService:    mTemplateService,
+			}
+
+			err := job.Execute(ctx)
+			if tc.wantErrContains != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tc.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+)
+
+const (
+	idempotencyKeysCleanupJobName            = "idempotency_keys_cleanup_job"
+	idempotencyKeysCleanupJobIntervalSeconds = 3600
+)
+
+type IdempotencyKeysCleanupJobOptions struct {
+	Models *data.Models
+}
+
+// NewIdempotencyKeysCleanupJob creates a job that deletes the idempotency keys that are past their TTL.
+func NewIdempotencyKeysCleanupJob(opts IdempotencyKeysCleanupJobOptions) Job {
+	return &idempotencyKeysCleanupJob{
+		jobIntervalSeconds: idempotencyKeysCleanupJobIntervalSeconds,
+		models:             opts.Models,
+	}
+}
+
+type idempotencyKeysCleanupJob struct {
+	jobIntervalSeconds int
+	models             *data.Models
+}
+
+func (j idempotencyKeysCleanupJob) IsJobMultiTenant() bool {
+	return true
+}
+
+func (j idempotencyKeysCleanupJob) GetInterval() time.Duration {
+	jobIntervalSeconds := j.jobIntervalSeconds
+	if j.jobIntervalSeconds == 0 {
+		log.Warnf("job interval is not set for %s. Using default interval: %d seconds", j.GetName(), DefaultMinimumJobIntervalSeconds)
+		jobIntervalSeconds = DefaultMinimumJobIntervalSeconds
+	}
+	return time.Duration(jobIntervalSeconds) * time.Second
+}
+
+func (j idempotencyKeysCleanupJob) GetName() string {
+	return idempotencyKeysCleanupJobName
+}
+
+func (j idempotencyKeysCleanupJob) Execute(ctx context.Context) error {
+	deleted, err := j.models.IdempotencyKeys.DeleteExpired(ctx, j.models.DBConnectionPool, time.Now())
+	if err != nil {
+		return fmt.Errorf("executing Job %s: %w", j.GetName(), err)
+	}
+	if deleted > 0 {
+		log.Ctx(ctx).Infof("deleted %d expired idempotency keys", deleted)
+	}
+	return nil
+}
+
+var _ Job = (*idempotencyKeysCleanupJob)(nil)
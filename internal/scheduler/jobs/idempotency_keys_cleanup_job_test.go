@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+)
+
+func Test_idempotencyKeysCleanupJob_GetInterval(t *testing.T) {
+	job := NewIdempotencyKeysCleanupJob(IdempotencyKeysCleanupJobOptions{})
+	require.Equal(t, idempotencyKeysCleanupJobIntervalSeconds*time.Second, job.GetInterval())
+}
+
+func Test_idempotencyKeysCleanupJob_GetName(t *testing.T) {
+	job := NewIdempotencyKeysCleanupJob(IdempotencyKeysCleanupJobOptions{})
+	require.Equal(t, idempotencyKeysCleanupJobName, job.GetName())
+}
+
+func Test_idempotencyKeysCleanupJob_IsJobMultiTenant(t *testing.T) {
+	job := NewIdempotencyKeysCleanupJob(IdempotencyKeysCleanupJobOptions{})
+	require.Equal(t, true, job.IsJobMultiTenant())
+}
+
+func Test_idempotencyKeysCleanupJob_Execute(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	insertKey := func(t *testing.T, key string) *data.IdempotencyKey {
+		idempotencyKey, insertErr := models.IdempotencyKeys.Insert(ctx, dbConnectionPool, data.IdempotencyKeyInsert{
+			Key:                key,
+			UserID:             "user-id",
+			RequestMethod:      http.MethodPost,
+			RequestPath:        "/disbursements",
+			RequestFingerprint: "fingerprint",
+		})
+		require.NoError(t, insertErr)
+		return idempotencyKey
+	}
+
+	expiredKey := insertKey(t, "expired-key")
+	_, err = dbConnectionPool.ExecContext(ctx, "UPDATE idempotency_keys SET created_at = NOW() - $1::interval WHERE id = $2",
+		(data.IdempotencyKeyTTL + time.Hour).String(), expiredKey.ID)
+	require.NoError(t, err)
+	insertKey(t, "recent-key")
+
+	job := NewIdempotencyKeysCleanupJob(IdempotencyKeysCleanupJobOptions{Models: models})
+	require.NoError(t, job.Execute(ctx))
+
+	_, err = models.IdempotencyKeys.Get(ctx, dbConnectionPool, "user-id", "expired-key")
+	assert.ErrorIs(t, err, data.ErrRecordNotFound)
+	_, err = models.IdempotencyKeys.Get(ctx, dbConnectionPool, "user-id", "recent-key")
+	assert.NoError(t, err)
+}
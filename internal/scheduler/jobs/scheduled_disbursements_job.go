@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/crashtracker"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/events"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
+)
+
+const (
+	scheduledDisbursementsJobName            = "scheduled_disbursements_job"
+	scheduledDisbursementsJobIntervalSeconds = 60
+)
+
+type ScheduledDisbursementsJobOptions struct {
+	Models                     *data.Models
+	DistAccountResolver        signing.DistributionAccountResolver
+	DistributionAccountService services.DistributionAccountServiceInterface
+	EventProducer              events.Producer
+	CrashTrackerClient         crashtracker.CrashTrackerClient
+	AuthManager                auth.AuthManager
+}
+
+// NewScheduledDisbursementsJob creates a job that starts the scheduled disbursements whose start time was reached,
+// through the same flow used when a user starts a disbursement.
+func NewScheduledDisbursementsJob(opts ScheduledDisbursementsJobOptions) Job {
+	return &scheduledDisbursementsJob{
+		jobIntervalSeconds:  scheduledDisbursementsJobIntervalSeconds,
+		distAccountResolver: opts.DistAccountResolver,
+		disbursementStarter: &services.DisbursementManagementService{
+			Models:                     opts.Models,
+			EventProducer:              opts.EventProducer,
+			CrashTrackerClient:         opts.CrashTrackerClient,
+			DistributionAccountService: opts.DistributionAccountService,
+			AuthManager:                opts.AuthManager,
+		},
+	}
+}
+
+type scheduledDisbursementsJob struct {
+	jobIntervalSeconds  int
+	distAccountResolver signing.DistributionAccountResolver
+	disbursementStarter services.ScheduledDisbursementStarterInterface
+}
+
+func (j scheduledDisbursementsJob) IsJobMultiTenant() bool {
+	return true
+}
+
+func (j scheduledDisbursementsJob) GetInterval() time.Duration {
+	jobIntervalSeconds := j.jobIntervalSeconds
+	if j.jobIntervalSeconds == 0 {
+		log.Warnf("job interval is not set for %s. Using default interval: %d seconds", j.GetName(), DefaultMinimumJobIntervalSeconds)
+		jobIntervalSeconds = DefaultMinimumJobIntervalSeconds
+	}
+	return time.Duration(jobIntervalSeconds) * time.Second
+}
+
+func (j scheduledDisbursementsJob) GetName() string {
+	return scheduledDisbursementsJobName
+}
+
+func (j scheduledDisbursementsJob) Execute(ctx context.Context) error {
+	distributionAccount, err := j.distAccountResolver.DistributionAccountFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("resolving distribution account in Job %s: %w", j.GetName(), err)
+	}
+
+	err = j.disbursementStarter.StartScheduledDisbursements(ctx, &distributionAccount)
+	if err != nil {
+		return fmt.Errorf("executing Job %s: %w", j.GetName(), err)
+	}
+	return nil
+}
+
+var _ Job = (*scheduledDisbursementsJob)(nil)
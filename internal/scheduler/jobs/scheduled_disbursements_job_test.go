@@ -0,0 +1,100 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services/mocks"
+	sigMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing/mocks"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
+)
+
+func Test_scheduledDisbursementsJob_GetInterval(t *testing.T) {
+	job := NewScheduledDisbursementsJob(ScheduledDisbursementsJobOptions{})
+	require.Equal(t, scheduledDisbursementsJobIntervalSeconds*time.Second, job.GetInterval())
+}
+
+func Test_scheduledDisbursementsJob_GetName(t *testing.T) {
+	job := NewScheduledDisbursementsJob(ScheduledDisbursementsJobOptions{})
+	require.Equal(t, scheduledDisbursementsJobName, job.GetName())
+}
+
+func Test_scheduledDisbursementsJob_IsJobMultiTenant(t *testing.T) {
+	job := NewScheduledDisbursementsJob(ScheduledDisbursementsJobOptions{})
+	require.Equal(t, true, job.IsJobMultiTenant())
+}
+
+func Test_scheduledDisbursementsJob_Execute(t *testing.T) {
+	ctx := context.Background()
+	distAccount := schema.NewDefaultStellarTransactionAccount(keypair.MustRandom().Address())
+
+	tests := []struct {
+		name            string
+		prepareMocksFn  func(mDistAccResolver *sigMocks.MockDistributionAccountResolver, mStarter *mocks.MockScheduledDisbursementStarter)
+		wantErrContains string
+	}{
+		{
+			name: "🔴 distribution account resolution fails",
+			prepareMocksFn: func(mDistAccResolver *sigMocks.MockDistributionAccountResolver, _ *mocks.MockScheduledDisbursementStarter) {
+				mDistAccResolver.
+					On("DistributionAccountFromContext", ctx).
+					Return(schema.TransactionAccount{}, assert.AnError).
+					Once()
+			},
+			wantErrContains: "resolving distribution account",
+		},
+		{
+			name: "🔴 execution fails",
+			prepareMocksFn: func(mDistAccResolver *sigMocks.MockDistributionAccountResolver, mStarter *mocks.MockScheduledDisbursementStarter) {
+				mDistAccResolver.
+					On("DistributionAccountFromContext", ctx).
+					Return(distAccount, nil).
+					Once()
+				mStarter.
+					On("StartScheduledDisbursements", ctx, &distAccount).
+					Return(assert.AnError).
+					Once()
+			},
+			wantErrContains: "executing Job",
+		},
+		{
+			name: "🟢 execution succeeds",
+			prepareMocksFn: func(mDistAccResolver *sigMocks.MockDistributionAccountResolver, mStarter *mocks.MockScheduledDisbursementStarter) {
+				mDistAccResolver.
+					On("DistributionAccountFromContext", ctx).
+					Return(distAccount, nil).
+					Once()
+				mStarter.
+					On("StartScheduledDisbursements", ctx, &distAccount).
+					Return(nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mDistAccResolver := sigMocks.NewMockDistributionAccountResolver(t)
+			mStarter := mocks.NewMockScheduledDisbursementStarter(t)
+			tc.prepareMocksFn(mDistAccResolver, mStarter)
+			job := scheduledDisbursementsJob{
+				jobIntervalSeconds:  5,
+				distAccountResolver: mDistAccResolver,
+				disbursementStarter: mStarter,
+			}
+
+			err := job.Execute(ctx)
+			if tc.wantErrContains != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tc.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -138,6 +138,10 @@ func Test_SendReceiverWalletsSMSInvitationJob_Execute(t *testing.T) {
 	var maxInvitationSMSResendAttempts int64 = 3
 
 	messageDispatcherMock := message.NewMockMessageDispatcher(t)
+	messageDispatcherMock.
+		On("MessengerTypeFor", mock.Anything, mock.Anything, mock.Anything).
+		Return(message.MessengerTypeTwilioSMS).
+		Maybe()
 	crashTrackerClientMock := &crashtracker.MockCrashTrackerClient{}
 
 	s, err := services.NewSendReceiverWalletInviteService(
@@ -217,20 +221,28 @@ func Test_SendReceiverWalletsSMSInvitationJob_Execute(t *testing.T) {
 
 	mockErr := errors.New("unexpected error")
 	messageDispatcherMock.
-		On("SendMessage", mock.Anything, message.Message{
+		On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
 			ToPhoneNumber: receiver1.PhoneNumber,
 			ToEmail:       receiver1.Email,
 			Body:          contentWallet1,
 			Title:         titleWallet1,
-		}, []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+			Template: &message.MessageTemplate{
+				Type:      message.MessageTemplateTypeReceiverInvitation,
+				Variables: []string{walletDeepLink1.OrganizationName, deepLink1},
+			},
+		}), []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 		Return(message.MessengerTypeTwilioSMS, mockErr).
 		Once().
-		On("SendMessage", mock.Anything, message.Message{
+		On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
 			ToPhoneNumber: receiver2.PhoneNumber,
 			ToEmail:       receiver2.Email,
 			Body:          contentWallet2,
 			Title:         titleWallet2,
-		}, []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+			Template: &message.MessageTemplate{
+				Type:      message.MessageTemplateTypeReceiverInvitation,
+				Variables: []string{walletDeepLink2.OrganizationName, deepLink2},
+			},
+		}), []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 		Return(message.MessengerTypeTwilioSMS, nil).
 		Once()
 
@@ -284,3 +296,17 @@ func Test_SendReceiverWalletsSMSInvitationJob_Execute(t *testing.T) {
 	assert.Equal(t, data.SuccessMessageStatus, msg.StatusHistory[1].Status)
 	assert.Nil(t, msg.AssetID)
 }
+
+// invitationMatcher matches the invitation sent through the message dispatcher, ignoring the message ID generated by the
+// service and checking the status callback URL built with it.
+func invitationMatcher(tenantBaseURL string, want message.Message) interface{} {
+	return mock.MatchedBy(func(got message.Message) bool {
+		wantCallbackURL, err := message.TwilioStatusCallbackURL(tenantBaseURL, got.ID)
+		if err != nil || got.ID == "" || got.StatusCallbackURL != wantCallbackURL {
+			return false
+		}
+
+		got.ID, got.StatusCallbackURL = "", ""
+		return assert.ObjectsAreEqual(want, got)
+	})
+}
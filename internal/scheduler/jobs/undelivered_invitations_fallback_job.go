@@ -0,0 +1,82 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/crashtracker"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
+)
+
+const (
+	undeliveredInvitationsFallbackJobName            = "undelivered_invitations_fallback_job"
+	undeliveredInvitationsFallbackJobIntervalSeconds = 10
+)
+
+type UndeliveredInvitationsFallbackJobOptions struct {
+	Models                      *data.Models
+	MessageDispatcher           message.MessageDispatcherInterface
+	MaxInvitationResendAttempts int64
+	Sep10SigningPrivateKey      string
+	CrashTrackerClient          crashtracker.CrashTrackerClient
+}
+
+// NewUndeliveredInvitationsFallbackJob creates a job that resends the invitations the providers reported as
+// undelivered through the fallback channels of the organization.
+func NewUndeliveredInvitationsFallbackJob(opts UndeliveredInvitationsFallbackJobOptions) Job {
+	inviteService, err := services.NewSendReceiverWalletInviteService(
+		opts.Models,
+		opts.MessageDispatcher,
+		opts.Sep10SigningPrivateKey,
+		opts.MaxInvitationResendAttempts,
+		opts.CrashTrackerClient,
+	)
+	if err != nil {
+		log.Fatalf("error instantiating service: %s", err.Error())
+	}
+
+	return &undeliveredInvitationsFallbackJob{
+		jobIntervalSeconds: undeliveredInvitationsFallbackJobIntervalSeconds,
+		deliveryStatusService: &services.MessageDeliveryStatusService{
+			Models:        opts.Models,
+			InviteService: inviteService,
+		},
+	}
+}
+
+type undeliveredInvitationsFallbackJob struct {
+	jobIntervalSeconds    int
+	deliveryStatusService services.MessageDeliveryStatusServiceInterface
+}
+
+func (j undeliveredInvitationsFallbackJob) IsJobMultiTenant() bool {
+	return true
+}
+
+func (j undeliveredInvitationsFallbackJob) GetInterval() time.Duration {
+	jobIntervalSeconds := j.jobIntervalSeconds
+	if j.jobIntervalSeconds == 0 {
+		log.Warnf("job interval is not set for %s. Using default interval: %d seconds", j.GetName(), DefaultMinimumJobIntervalSeconds)
+		jobIntervalSeconds = DefaultMinimumJobIntervalSeconds
+	}
+	return time.Duration(jobIntervalSeconds) * time.Second
+}
+
+func (j undeliveredInvitationsFallbackJob) GetName() string {
+	return undeliveredInvitationsFallbackJobName
+}
+
+func (j undeliveredInvitationsFallbackJob) Execute(ctx context.Context) error {
+	err := j.deliveryStatusService.ResendUndeliveredInvitations(ctx)
+	if err != nil {
+		return fmt.Errorf("executing Job %s: %w", j.GetName(), err)
+	}
+	return nil
+}
+
+var _ Job = (*undeliveredInvitationsFallbackJob)(nil)
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services/mocks"
+)
+
+func newTestUndeliveredInvitationsFallbackJob(t *testing.T) Job {
+	return NewUndeliveredInvitationsFallbackJob(UndeliveredInvitationsFallbackJobOptions{
+		MessageDispatcher: message.NewMockMessageDispatcher(t),
+	})
+}
+
+func Test_undeliveredInvitationsFallbackJob_GetInterval(t *testing.T) {
+	job := newTestUndeliveredInvitationsFallbackJob(t)
+	require.Equal(t, undeliveredInvitationsFallbackJobIntervalSeconds*time.Second, job.GetInterval())
+}
+
+func Test_undeliveredInvitationsFallbackJob_GetName(t *testing.T) {
+	job := newTestUndeliveredInvitationsFallbackJob(t)
+	require.Equal(t, undeliveredInvitationsFallbackJobName, job.GetName())
+}
+
+func Test_undeliveredInvitationsFallbackJob_IsJobMultiTenant(t *testing.T) {
+	job := newTestUndeliveredInvitationsFallbackJob(t)
+	require.Equal(t, true, job.IsJobMultiTenant())
+}
+
+func Test_undeliveredInvitationsFallbackJob_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		prepareMocksFn  func(mDeliveryStatusService *mocks.MockMessageDeliveryStatusService)
+		wantErrContains string
+	}{
+		{
+			name: "🔴 execution fails",
+			prepareMocksFn: func(mDeliveryStatusService *mocks.MockMessageDeliveryStatusService) {
+				mDeliveryStatusService.
+					On("ResendUndeliveredInvitations", ctx).
+					Return(assert.AnError).
+					Once()
+			},
+			wantErrContains: "executing Job",
+		},
+		{
+			name: "🟢 execution succeeds",
+			prepareMocksFn: func(mDeliveryStatusService *mocks.MockMessageDeliveryStatusService) {
+				mDeliveryStatusService.
+					On("ResendUndeliveredInvitations", ctx).
+					Return(nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mDeliveryStatusService := mocks.NewMockMessageDeliveryStatusService(t)
+			tc.prepareMocksFn(mDeliveryStatusService)
+			job := undeliveredInvitationsFallbackJob{
+				jobIntervalSeconds:    5,
+				deliveryStatusService: mDeliveryStatusService,
+			}
+
+			err := job.Execute(ctx)
+			if tc.wantErrContains != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tc.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
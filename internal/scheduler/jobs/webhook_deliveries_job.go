@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
+)
+
+const (
+	webhookDeliveriesJobName            = "webhook_deliveries_job"
+	webhookDeliveriesJobIntervalSeconds = 10
+)
+
+type WebhookDeliveriesJobOptions struct {
+	Models               *data.Models
+	EncryptionPassphrase string
+}
+
+// NewWebhookDeliveriesJob creates a job that sends the pending webhook deliveries, retrying the ones that failed.
+func NewWebhookDeliveriesJob(opts WebhookDeliveriesJobOptions) Job {
+	return &webhookDeliveriesJob{
+		jobIntervalSeconds: webhookDeliveriesJobIntervalSeconds,
+		deliveryService:    services.NewWebhookDeliveryService(opts.Models, opts.EncryptionPassphrase),
+	}
+}
+
+type webhookDeliveriesJob struct {
+	jobIntervalSeconds int
+	deliveryService    services.WebhookDeliveryServiceInterface
+}
+
+func (j webhookDeliveriesJob) IsJobMultiTenant() bool {
+	return true
+}
+
+func (j webhookDeliveriesJob) GetInterval() time.Duration {
+	jobIntervalSeconds := j.jobIntervalSeconds
+	if j.jobIntervalSeconds == 0 {
+		log.Warnf("job interval is not set for %s. Using default interval: %d seconds", j.GetName(), DefaultMinimumJobIntervalSeconds)
+		jobIntervalSeconds = DefaultMinimumJobIntervalSeconds
+	}
+	return time.Duration(jobIntervalSeconds) * time.Second
+}
+
+func (j webhookDeliveriesJob) GetName() string {
+	return webhookDeliveriesJobName
+}
+
+func (j webhookDeliveriesJob) Execute(ctx context.Context) error {
+	err := j.deliveryService.SendPendingDeliveries(ctx)
+	if err != nil {
+		return fmt.Errorf("executing Job %s: %w", j.GetName(), err)
+	}
+	return nil
+}
+
+var _ Job = (*webhookDeliveriesJob)(nil)
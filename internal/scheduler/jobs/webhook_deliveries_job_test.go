@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services/mocks"
+)
+
+func Test_webhookDeliveriesJob_GetInterval(t *testing.T) {
+	job := NewWebhookDeliveriesJob(WebhookDeliveriesJobOptions{})
+	require.Equal(t, webhookDeliveriesJobIntervalSeconds*time.Second, job.GetInterval())
+}
+
+func Test_webhookDeliveriesJob_GetName(t *testing.T) {
+	job := NewWebhookDeliveriesJob(WebhookDeliveriesJobOptions{})
+	require.Equal(t, webhookDeliveriesJobName, job.GetName())
+}
+
+func Test_webhookDeliveriesJob_IsJobMultiTenant(t *testing.T) {
+	job := NewWebhookDeliveriesJob(WebhookDeliveriesJobOptions{})
+	require.Equal(t, true, job.IsJobMultiTenant())
+}
+
+func Test_webhookDeliveriesJob_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		prepareMocksFn  func(mDeliveryService *mocks.MockWebhookDeliveryService)
+		wantErrContains string
+	}{
+		{
+			name: "🔴 execution fails",
+			prepareMocksFn: func(mDeliveryService *mocks.MockWebhookDeliveryService) {
+				mDeliveryService.
+					On("SendPendingDeliveries", ctx).
+					Return(assert.AnError).
+					Once()
+			},
+			wantErrContains: "executing Job",
+		},
+		{
+			name: "🟢 execution succeeds",
+			prepareMocksFn: func(mDeliveryService *mocks.MockWebhookDeliveryService) {
+				mDeliveryService.
+					On("SendPendingDeliveries", ctx).
+					Return(nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mDeliveryService := mocks.NewMockWebhookDeliveryService(t)
+			tc.prepareMocksFn(mDeliveryService)
+			job := webhookDeliveriesJob{
+				jobIntervalSeconds: 5,
+				deliveryService:    mDeliveryService,
+			}
+
+			err := job.Execute(ctx)
+			if tc.wantErrContains != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tc.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
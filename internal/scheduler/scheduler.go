@@ -221,6 +221,55 @@ func WithCircleReconciliationJobOption(options jobs.CircleReconciliationJobOptio
 	}
 }
 
+func WithClaimableBalanceStatusJobOption(options jobs.ClaimableBalanceStatusJobOptions) SchedulerJobRegisterOption {
+	return func(s *Scheduler) {
+		j := jobs.NewClaimableBalanceStatusJob(options)
+		s.addJob(j)
+	}
+}
+
+func WithScheduledDisbursementsJobOption(options jobs.ScheduledDisbursementsJobOptions) SchedulerJobRegisterOption {
+	return func(s *Scheduler) {
+		j := jobs.NewScheduledDisbursementsJob(options)
+		s.addJob(j)
+	}
+}
+
+func WithDisbursementTemplatesJobOption(options jobs.DisbursementTemplatesJobOptions) SchedulerJobRegisterOption {
+	return func(s *Scheduler) {
+		j := jobs.NewDisbursementTemplatesJob(options)
+		s.addJob(j)
+	}
+}
+
+func WithWebhookDeliveriesJobOption(options jobs.WebhookDeliveriesJobOptions) SchedulerJobRegisterOption {
+	return func(s *Scheduler) {
+		j := jobs.NewWebhookDeliveriesJob(options)
+		s.addJob(j)
+	}
+}
+
+func WithUndeliveredInvitationsFallbackJobOption(options jobs.UndeliveredInvitationsFallbackJobOptions) SchedulerJobRegisterOption {
+	return func(s *Scheduler) {
+		j := jobs.NewUndeliveredInvitationsFallbackJob(options)
+		s.addJob(j)
+	}
+}
+
+func WithIdempotencyKeysCleanupJobOption(options jobs.IdempotencyKeysCleanupJobOptions) SchedulerJobRegisterOption {
+	return func(s *Scheduler) {
+		j := jobs.NewIdempotencyKeysCleanupJob(options)
+		s.addJob(j)
+	}
+}
+
+func WithDisbursementInstructionUploadsJobOption(options jobs.DisbursementInstructionUploadsJobOptions) SchedulerJobRegisterOption {
+	return func(s *Scheduler) {
+		j := jobs.NewDisbursementInstructionUploadsJob(options)
+		s.addJob(j)
+	}
+}
+
 func WithPaymentFromSubmitterJobOption(paymentJobInterval int, models *data.Models, tssDBConnectionPool db.DBConnectionPool) SchedulerJobRegisterOption {
 	return func(s *Scheduler) {
 		j := jobs.NewPaymentFromSubmitterJob(paymentJobInterval, models, tssDBConnectionPool)
@@ -8,15 +8,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"path/filepath"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dimchansky/utfbom"
 	"github.com/go-chi/chi/v5"
 	"github.com/gocarina/gocsv"
+	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/support/log"
 	"github.com/stellar/go/support/render/httpjson"
 
@@ -47,10 +51,34 @@ type PostDisbursementRequest struct {
 	WalletID                            string                       `json:"wallet_id"`
 	AssetID                             string                       `json:"asset_id"`
 	VerificationField                   data.VerificationType        `json:"verification_field"`
+	VerificationQuestion                string                       `json:"verification_question"`
 	RegistrationContactType             data.RegistrationContactType `json:"registration_contact_type"`
 	ReceiverRegistrationMessageTemplate string                       `json:"receiver_registration_message_template"`
+	PayoutMode                          data.PayoutMode              `json:"payout_mode"`
+	ReceiveAssetCode                    string                       `json:"receive_asset_code"`
+	ReceiveAssetIssuer                  string                       `json:"receive_asset_issuer"`
+	MaxSlippageBps                      *int                         `json:"max_slippage_bps"`
 }
 
+// maxVerificationQuestionLength is the max length of the secret question asked to the receivers.
+const maxVerificationQuestionLength = 255
+
+// validateVerificationQuestion validates the secret question, which is required by the CUSTOM_SECRET_QUESTION
+// verification field and not allowed with the other ones.
+func validateVerificationQuestion(v *validators.Validator, verificationField data.VerificationType, question string) {
+	if verificationField != data.VerificationTypeCustomSecretQuestion {
+		v.Check(question == "", "verification_question", "verification_question is only allowed with the CUSTOM_SECRET_QUESTION verification field")
+		return
+	}
+
+	v.Check(strings.TrimSpace(question) != "", "verification_question", "verification_question is required with the CUSTOM_SECRET_QUESTION verification field")
+	v.Check(len(question) <= maxVerificationQuestionLength, "verification_question", fmt.Sprintf("verification_question must have at most %d characters", maxVerificationQuestionLength))
+	v.CheckError(utils.ValidateNoHTML(question), "verification_question", "verification_question cannot contain HTML, JS or CSS")
+}
+
+// DefaultMaxSlippageBps is the max slippage used by path payment disbursements that don't set one.
+const DefaultMaxSlippageBps = 100
+
 func (d DisbursementHandler) validateRequest(req PostDisbursementRequest) *validators.Validator {
 	v := validators.NewValidator()
 
@@ -62,6 +90,22 @@ func (d DisbursementHandler) validateRequest(req PostDisbursementRequest) *valid
 		fmt.Sprintf("registration_contact_type must be one of %v", data.AllRegistrationContactTypes()),
 	)
 	v.CheckError(utils.ValidateNoHTML(req.ReceiverRegistrationMessageTemplate), "receiver_registration_message_template", "receiver_registration_message_template cannot contain HTML, JS or CSS")
+	if req.PayoutMode != "" {
+		v.CheckError(req.PayoutMode.Validate(), "payout_mode", fmt.Sprintf("payout_mode must be one of %v", data.AllPayoutModes()))
+	}
+	if req.ReceiveAssetCode != "" {
+		v.Check(len(req.ReceiveAssetCode) <= 12, "receive_asset_code", "receive_asset_code must have between 1 and 12 characters")
+		if !strings.EqualFold(req.ReceiveAssetCode, "XLM") {
+			v.Check(strkey.IsValidEd25519PublicKey(req.ReceiveAssetIssuer), "receive_asset_issuer", "receive_asset_issuer must be a valid Stellar public key")
+		}
+		v.Check(req.PayoutMode != data.PayoutModeClaimableBalance, "payout_mode", "payout_mode CLAIMABLE_BALANCE cannot be used with a receive asset")
+	} else {
+		v.Check(req.ReceiveAssetIssuer == "", "receive_asset_code", "receive_asset_code is required when receive_asset_issuer is set")
+		v.Check(req.MaxSlippageBps == nil, "max_slippage_bps", "max_slippage_bps is only allowed with a receive asset")
+	}
+	if req.MaxSlippageBps != nil {
+		v.Check(*req.MaxSlippageBps >= 0 && *req.MaxSlippageBps <= 10000, "max_slippage_bps", "max_slippage_bps must be between 0 and 10000")
+	}
 	if !req.RegistrationContactType.IncludesWalletAddress {
 		v.Check(
 			slices.Contains(data.GetAllVerificationTypes(), req.VerificationField),
@@ -73,12 +117,38 @@ func (d DisbursementHandler) validateRequest(req PostDisbursementRequest) *valid
 		v.Check(req.VerificationField == "", "verification_field", "verification_field is not allowed for this registration contact type")
 		v.Check(req.WalletID == "", "wallet_id", "wallet_id is not allowed for this registration contact type")
 	}
+	validateVerificationQuestion(v, req.VerificationField, req.VerificationQuestion)
 
 	return v
 }
 
 type PatchDisbursementStatusRequest struct {
 	Status string `json:"status"`
+	// ScheduledStartAt is required when Status is SCHEDULED. It's either an RFC3339 timestamp or a local date-time
+	// without offset (2006-01-02T15:04:05), which is interpreted in the organization's timezone.
+	ScheduledStartAt string `json:"scheduled_start_at,omitempty"`
+}
+
+// parseScheduledStartAt parses the scheduled start time of a disbursement. When the value has no UTC offset, it's
+// interpreted in the timezone configured for the organization, in the "+02:00" format.
+func parseScheduledStartAt(value, timezoneUTCOffset string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("scheduled_start_at is required")
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if timezoneUTCOffset == "" {
+		timezoneUTCOffset = "+00:00"
+	}
+	t, err := time.Parse(time.RFC3339, value+timezoneUTCOffset)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("scheduled_start_at must be in the RFC3339 format or 2006-01-02T15:04:05: %w", err)
+	}
+
+	return t, nil
 }
 
 func (d DisbursementHandler) PostDisbursement(w http.ResponseWriter, r *http.Request) {
@@ -144,13 +214,43 @@ func (d DisbursementHandler) PostDisbursement(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	var maxSlippageBps int
+	if req.ReceiveAssetCode != "" {
+		if strings.EqualFold(req.ReceiveAssetCode, asset.Code) && req.ReceiveAssetIssuer == asset.Issuer {
+			httperror.BadRequest("The receive asset must be different from the disbursement asset", nil, nil).Render(w)
+			return
+		}
+		maxSlippageBps = DefaultMaxSlippageBps
+		if req.MaxSlippageBps != nil {
+			maxSlippageBps = *req.MaxSlippageBps
+		}
+	}
+
+	// Claimable balances and path payments are only supported by Stellar distribution accounts
+	if req.PayoutMode == data.PayoutModeClaimableBalance || req.ReceiveAssetCode != "" {
+		distributionAccount, distAccErr := d.DistributionAccountResolver.DistributionAccountFromContext(ctx)
+		if distAccErr != nil {
+			httperror.InternalError(ctx, "Cannot get distribution account", distAccErr, nil).Render(w)
+			return
+		}
+		if !distributionAccount.IsStellar() {
+			httperror.BadRequest("Claimable balances and receive assets are only supported by Stellar distribution accounts", nil, nil).Render(w)
+			return
+		}
+	}
+
 	// Insert disbursement
 	disbursement := data.Disbursement{
 		Asset:                               asset,
 		Name:                                req.Name,
 		ReceiverRegistrationMessageTemplate: req.ReceiverRegistrationMessageTemplate,
 		RegistrationContactType:             req.RegistrationContactType,
+		PayoutMode:                          req.PayoutMode,
+		ReceiveAssetCode:                    req.ReceiveAssetCode,
+		ReceiveAssetIssuer:                  req.ReceiveAssetIssuer,
+		MaxSlippageBps:                      maxSlippageBps,
 		VerificationField:                   req.VerificationField,
+		VerificationQuestion:                strings.TrimSpace(req.VerificationQuestion),
 		Wallet:                              wallet,
 		Status:                              data.DraftDisbursementStatus,
 		StatusHistory: []data.DisbursementStatusHistoryEntry{{
@@ -288,30 +388,80 @@ func (d DisbursementHandler) PostDisbursementInstructions(w http.ResponseWriter,
 		return
 	}
 
-	buf, header, httpErr := parseCsvFromMultipartRequest(r)
-	if httpErr != nil {
-		httpErr.Render(w)
-		return
+	async := false
+	if asyncParam := r.URL.Query().Get(asyncInstructionsParamName); asyncParam != "" {
+		if async, err = strconv.ParseBool(asyncParam); err != nil {
+			httperror.BadRequest("", err, map[string]interface{}{asyncInstructionsParamName: "async must be a boolean"}).Render(w)
+			return
+		}
 	}
 
-	if err = validateCSVHeaders(bytes.NewReader(buf.Bytes()), disbursement.RegistrationContactType); err != nil {
-		errMsg := fmt.Sprintf("CSV columns are not valid for registration contact type %s: %s",
-			disbursement.RegistrationContactType,
-			err)
-		httperror.BadRequest(errMsg, err, nil).Render(w)
+	hasActiveUpload, err := d.Models.DisbursementInstructionUploads.HasActive(ctx, d.Models.DBConnectionPool, disbursementID)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get instruction uploads", err, nil).Render(w)
 		return
 	}
-
-	instructions, v := parseInstructionsFromCSV(ctx, bytes.NewReader(buf.Bytes()), disbursement.RegistrationContactType, disbursement.VerificationField)
-	if v != nil && v.HasErrors() {
-		httperror.BadRequest("could not parse csv file", err, v.Errors).Render(w)
+	if hasActiveUpload {
+		httperror.Conflict(errInstructionUploadInProgressMsg, nil, nil).Render(w)
 		return
 	}
 
-	disbursementUpdate := &data.DisbursementUpdate{
-		ID:          disbursementID,
-		FileName:    header.Filename,
-		FileContent: buf.Bytes(),
+	var instructions []*data.DisbursementInstruction
+	var disbursementUpdate *data.DisbursementUpdate
+	if isJSONRequest(r) {
+		var v *validators.DisbursementInstructionsValidator
+		instructions, v = parseInstructionsFromJSON(ctx, r.Body, disbursement.RegistrationContactType, disbursement.VerificationField)
+		if v != nil && v.HasErrors() {
+			httperror.BadRequest("could not parse instructions", nil, v.Errors).Render(w)
+			return
+		}
+
+		// The instructions are stored as a CSV file, so they can be downloaded the same way as uploaded files.
+		fileContent, marshalErr := gocsv.MarshalBytes(instructions)
+		if marshalErr != nil {
+			httperror.InternalError(ctx, "Cannot convert instructions to CSV", marshalErr, nil).Render(w)
+			return
+		}
+		disbursementUpdate = &data.DisbursementUpdate{
+			ID:          disbursementID,
+			FileName:    jsonInstructionsFileName,
+			FileContent: fileContent,
+		}
+	} else {
+		buf, header, httpErr := parseCsvFromMultipartRequest(r)
+		if httpErr != nil {
+			httpErr.Render(w)
+			return
+		}
+
+		if err = validateCSVHeaders(bytes.NewReader(buf.Bytes()), disbursement.RegistrationContactType); err != nil {
+			errMsg := fmt.Sprintf("CSV columns are not valid for registration contact type %s: %s",
+				disbursement.RegistrationContactType,
+				err)
+			httperror.BadRequest(errMsg, err, nil).Render(w)
+			return
+		}
+
+		disbursementUpdate = &data.DisbursementUpdate{
+			ID:          disbursementID,
+			FileName:    header.Filename,
+			FileContent: buf.Bytes(),
+		}
+
+		// The rows of asynchronous uploads are validated by the job that processes them.
+		if !async {
+			var v *validators.DisbursementInstructionsValidator
+			instructions, v = parseInstructionsFromCSV(ctx, bytes.NewReader(buf.Bytes()), disbursement.RegistrationContactType, disbursement.VerificationField)
+			if v != nil && v.HasErrors() {
+				httperror.BadRequest("could not parse csv file", err, v.Errors).Render(w)
+				return
+			}
+		}
+	}
+
+	if async {
+		d.createInstructionUpload(w, r, disbursement, disbursementUpdate)
+		return
 	}
 
 	token, ok := ctx.Value(middleware.TokenContextKey).(string)
@@ -354,8 +504,158 @@ func (d DisbursementHandler) PostDisbursementInstructions(w http.ResponseWriter,
 	httpjson.Render(w, response, httpjson.JSON)
 }
 
+const (
+	// asyncInstructionsParamName is the query parameter used to upload instructions to be processed asynchronously.
+	asyncInstructionsParamName        = "async"
+	errInstructionUploadInProgressMsg = "disbursement has an instructions upload in progress"
+)
+
+// createInstructionUpload stores the instructions file to be processed asynchronously by the
+// `disbursement_instruction_uploads_job`, and replies with the upload, which exposes the progress. A READY disbursement
+// is moved back to DRAFT until its instructions are processed, so it can't be started with a partial set of payments.
+func (d DisbursementHandler) createInstructionUpload(w http.ResponseWriter, r *http.Request, disbursement *data.Disbursement, disbursementUpdate *data.DisbursementUpdate) {
+	ctx := r.Context()
+
+	_, user, httpErr := getTokenAndUser(ctx, d.AuthManager)
+	if httpErr != nil {
+		httpErr.Render(w)
+		return
+	}
+
+	upload, err := db.RunInTransactionWithResult(ctx, d.Models.DBConnectionPool, nil, func(dbTx db.DBTransaction) (*data.DisbursementInstructionUpload, error) {
+		upload, err := d.Models.DisbursementInstructionUploads.Insert(ctx, dbTx, data.DisbursementInstructionUploadInsert{
+			DisbursementID: disbursement.ID,
+			FileName:       disbursementUpdate.FileName,
+			FileContent:    disbursementUpdate.FileContent,
+			CreatedBy:      user.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("inserting instruction upload: %w", err)
+		}
+
+		if disbursement.Status == data.ReadyDisbursementStatus {
+			if err = d.Models.Disbursements.UpdateStatus(ctx, dbTx, user.ID, disbursement.ID, data.DraftDisbursementStatus); err != nil {
+				return nil, fmt.Errorf("moving disbursement back to draft: %w", err)
+			}
+		}
+
+		return upload, nil
+	})
+	if err != nil {
+		if errors.Is(err, data.ErrRecordAlreadyExists) {
+			httperror.Conflict(errInstructionUploadInProgressMsg, err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, fmt.Sprintf("Cannot upload instructions for disbursement with ID %s", disbursement.ID), err, nil).Render(w)
+		return
+	}
+
+	httpjson.RenderStatus(w, http.StatusAccepted, upload, httpjson.JSON)
+}
+
+// GetDisbursementInstructionUploads returns the asynchronous instruction uploads of a disbursement, most recent first.
+func (d DisbursementHandler) GetDisbursementInstructionUploads(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	disbursement, err := d.Models.Disbursements.Get(ctx, d.Models.DBConnectionPool, chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound("disbursement not found", err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot get disbursement", err, nil).Render(w)
+		return
+	}
+
+	uploads, err := d.Models.DisbursementInstructionUploads.GetAllByDisbursementID(ctx, d.Models.DBConnectionPool, disbursement.ID)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get instruction uploads", err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, uploads, httpjson.JSON)
+}
+
+// GetDisbursementInstructionUpload returns an asynchronous instruction upload, with its progress, the errors found in
+// its rows and, once it's completed, the summary of what was persisted.
+func (d DisbursementHandler) GetDisbursementInstructionUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	upload, err := d.Models.DisbursementInstructionUploads.Get(ctx, d.Models.DBConnectionPool, chi.URLParam(r, "id"), chi.URLParam(r, "uploadID"))
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound("instruction upload not found", err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot get instruction upload", err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, upload, httpjson.JSON)
+}
+
+// ValidateDisbursementInstructions validates an instructions CSV file without processing it. The file goes through the
+// same header and row validations and receiver reconciliation as an upload, but nothing is written, and the response
+// reports the outcome of each row along with whether the distribution account has enough balance to pay them.
+func (d DisbursementHandler) ValidateDisbursementInstructions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	disbursement, err := d.Models.Disbursements.Get(ctx, d.Models.DBConnectionPool, chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound("disbursement not found", err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot get disbursement", err, nil).Render(w)
+		return
+	}
+
+	buf, _, httpErr := parseCsvFromMultipartRequest(r)
+	if httpErr != nil {
+		httpErr.Render(w)
+		return
+	}
+
+	if err = validateCSVHeaders(bytes.NewReader(buf.Bytes()), disbursement.RegistrationContactType); err != nil {
+		errMsg := fmt.Sprintf("CSV columns are not valid for registration contact type %s: %s",
+			disbursement.RegistrationContactType,
+			err)
+		httperror.BadRequest(errMsg, err, nil).Render(w)
+		return
+	}
+
+	instructions := []*data.DisbursementInstruction{}
+	if err = gocsv.Unmarshal(utfbom.SkipOnly(bytes.NewReader(buf.Bytes())), &instructions); err != nil {
+		httperror.BadRequest("could not parse csv file", err, map[string]interface{}{"file": "could not parse file"}).Render(w)
+		return
+	}
+	if len(instructions) == 0 {
+		httperror.BadRequest("could not parse csv file", nil, map[string]interface{}{"instructions": "no valid instructions found"}).Render(w)
+		return
+	}
+	if len(instructions) > data.MaxInstructionsPerUpload {
+		httperror.BadRequest(fmt.Sprintf("number of instructions exceeds maximum of %d", data.MaxInstructionsPerUpload), nil, nil).Render(w)
+		return
+	}
+
+	distributionAccount, err := d.DistributionAccountResolver.DistributionAccountFromContext(ctx)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get distribution account", err, nil).Render(w)
+		return
+	}
+
+	report, err := d.DisbursementManagementService.ValidateInstructions(ctx, disbursement, &distributionAccount, instructions)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot validate instructions", err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, report, httpjson.JSON)
+}
+
 // parseCsvFromMultipartRequest parses the CSV file from a multipart request and returns the file content and header,
-// or an error if the file is not a valid CSV or the MIME type is not text/csv.
+// or an error if the file is not a valid CSV or the MIME type is not text/csv. XLSX files are also accepted, and the
+// first sheet is converted to CSV, so the returned content is always CSV.
 func parseCsvFromMultipartRequest(r *http.Request) (*bytes.Buffer, *multipart.FileHeader, *httperror.HTTPError) {
 	// Parse uploaded CSV file
 	file, header, err := r.FormFile("file")
@@ -368,16 +668,22 @@ func parseCsvFromMultipartRequest(r *http.Request) (*bytes.Buffer, *multipart.Fi
 		return nil, nil, httperror.BadRequest("file name contains invalid traversal pattern", nil, nil)
 	}
 
-	if filepath.Ext(header.Filename) != ".csv" {
-		return nil, nil, httperror.BadRequest("the file extension should be .csv", nil, nil)
-	}
-
-	var buf bytes.Buffer
-	if _, err = io.Copy(&buf, file); err != nil {
-		return nil, nil, httperror.BadRequest("could not read file", err, nil)
+	switch filepath.Ext(header.Filename) {
+	case ".csv":
+		var buf bytes.Buffer
+		if _, err = io.Copy(&buf, file); err != nil {
+			return nil, nil, httperror.BadRequest("could not read file", err, nil)
+		}
+		return &buf, header, nil
+	case ".xlsx":
+		csvContent, convertErr := convertXLSXToCSV(file)
+		if convertErr != nil {
+			return nil, nil, httperror.BadRequest("could not read xlsx file", convertErr, nil)
+		}
+		return bytes.NewBuffer(csvContent), header, nil
+	default:
+		return nil, nil, httperror.BadRequest("the file extension should be .csv or .xlsx", nil, nil)
 	}
-
-	return &buf, header, nil
 }
 
 func (d DisbursementHandler) GetDisbursement(w http.ResponseWriter, r *http.Request) {
@@ -494,6 +800,30 @@ func (d DisbursementHandler) PatchDisbursementStatus(w http.ResponseWriter, r *h
 	case data.PausedDisbursementStatus:
 		err = d.DisbursementManagementService.PauseDisbursement(ctx, disbursementID, user)
 		response.Message = "Disbursement paused"
+	case data.ScheduledDisbursementStatus:
+		var organization *data.Organization
+		if organization, err = d.Models.Organizations.Get(ctx); err != nil {
+			httperror.InternalError(ctx, "Cannot get organization", err, nil).Render(w)
+			return
+		}
+
+		var scheduledStartAt time.Time
+		if scheduledStartAt, err = parseScheduledStartAt(patchRequest.ScheduledStartAt, organization.TimezoneUTCOffset); err != nil {
+			httperror.BadRequest("invalid scheduled_start_at", err, nil).Render(w)
+			return
+		}
+
+		var distributionAccount schema.TransactionAccount
+		if distributionAccount, err = d.DistributionAccountResolver.DistributionAccountFromContext(ctx); err != nil {
+			httperror.InternalError(ctx, "Cannot get distribution account", err, nil).Render(w)
+			return
+		}
+
+		err = d.DisbursementManagementService.ScheduleDisbursement(ctx, disbursementID, user, scheduledStartAt, &distributionAccount)
+		response.Message = "Disbursement scheduled"
+	case data.ReadyDisbursementStatus:
+		err = d.DisbursementManagementService.CancelDisbursementSchedule(ctx, disbursementID, user)
+		response.Message = "Disbursement schedule canceled"
 	default:
 		err = services.ErrDisbursementStatusCantBeChanged
 	}
@@ -509,8 +839,16 @@ func (d DisbursementHandler) PatchDisbursementStatus(w http.ResponseWriter, r *h
 			httperror.BadRequest(services.ErrDisbursementNotReadyToPause.Error(), err, nil).Render(w)
 		case errors.Is(err, services.ErrDisbursementStatusCantBeChanged):
 			httperror.BadRequest(services.ErrDisbursementStatusCantBeChanged.Error(), err, nil).Render(w)
+		case errors.Is(err, services.ErrDisbursementNotReadyToSchedule):
+			httperror.BadRequest(services.ErrDisbursementNotReadyToSchedule.Error(), err, nil).Render(w)
+		case errors.Is(err, services.ErrDisbursementNotScheduled):
+			httperror.BadRequest(services.ErrDisbursementNotScheduled.Error(), err, nil).Render(w)
+		case errors.Is(err, services.ErrDisbursementScheduledStartInPast):
+			httperror.BadRequest(services.ErrDisbursementScheduledStartInPast.Error(), err, nil).Render(w)
 		case errors.Is(err, services.ErrDisbursementStartedByCreator):
 			httperror.Forbidden("Disbursement can't be started by its creator. Approval by another user is required.", err, nil).Render(w)
+		case errors.Is(err, services.ErrDisbursementApprovalQuorumNotMet):
+			httperror.Forbidden(err.Error(), err, nil).Render(w)
 		case errors.Is(err, services.ErrDisbursementWalletDisabled):
 			httperror.BadRequest(services.ErrDisbursementWalletDisabled.Error(), err, nil).Render(w)
 		case errors.As(err, &insufficientBalanceErr):
@@ -526,6 +864,103 @@ func (d DisbursementHandler) PatchDisbursementStatus(w http.ResponseWriter, r *h
 	httpjson.RenderStatus(w, http.StatusOK, response, httpjson.JSON)
 }
 
+type PostDisbursementReviewRequest struct {
+	Comment string `json:"comment"`
+}
+
+// PostDisbursementApproval records the approval of the disbursement by the authenticated user.
+func (d DisbursementHandler) PostDisbursementApproval(w http.ResponseWriter, r *http.Request) {
+	d.reviewDisbursement(w, r, data.ApprovedDisbursementApprovalDecision)
+}
+
+// PostDisbursementRejection records the rejection of the disbursement by the authenticated user, moving it back to
+// DRAFT so its instructions can be fixed.
+func (d DisbursementHandler) PostDisbursementRejection(w http.ResponseWriter, r *http.Request) {
+	d.reviewDisbursement(w, r, data.RejectedDisbursementApprovalDecision)
+}
+
+func (d DisbursementHandler) reviewDisbursement(w http.ResponseWriter, r *http.Request, decision data.DisbursementApprovalDecision) {
+	ctx := r.Context()
+	disbursementID := chi.URLParam(r, "id")
+
+	// The body is optional, since approvals don't require a comment.
+	var reqBody PostDisbursementReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil && !errors.Is(err, io.EOF) {
+		httperror.BadRequest("invalid request body", err, nil).Render(w)
+		return
+	}
+
+	reqBody.Comment = strings.TrimSpace(reqBody.Comment)
+	v := validators.NewValidator()
+	v.Check(decision != data.RejectedDisbursementApprovalDecision || reqBody.Comment != "", "comment", "comment is required when rejecting a disbursement")
+	v.CheckError(utils.ValidateNoHTML(reqBody.Comment), "comment", "comment cannot contain HTML, JS or CSS")
+	if v.HasErrors() {
+		httperror.BadRequest("", nil, v.Errors).Render(w)
+		return
+	}
+
+	_, user, httpErr := getTokenAndUser(ctx, d.AuthManager)
+	if httpErr != nil {
+		httpErr.Render(w)
+		return
+	}
+
+	var approval *data.DisbursementApproval
+	var err error
+	if decision == data.ApprovedDisbursementApprovalDecision {
+		approval, err = d.DisbursementManagementService.ApproveDisbursement(ctx, disbursementID, user, reqBody.Comment)
+	} else {
+		approval, err = d.DisbursementManagementService.RejectDisbursement(ctx, disbursementID, user, reqBody.Comment)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrDisbursementNotFound):
+			httperror.NotFound(services.ErrDisbursementNotFound.Error(), err, nil).Render(w)
+		case errors.Is(err, services.ErrDisbursementApprovalWorkflowDisabled):
+			httperror.BadRequest(services.ErrDisbursementApprovalWorkflowDisabled.Error(), err, nil).Render(w)
+		case errors.Is(err, services.ErrDisbursementNotReadyToReview):
+			httperror.BadRequest(services.ErrDisbursementNotReadyToReview.Error(), err, nil).Render(w)
+		case errors.Is(err, services.ErrDisbursementReviewedByCreator):
+			httperror.Forbidden(services.ErrDisbursementReviewedByCreator.Error(), err, nil).Render(w)
+		case errors.Is(err, services.ErrDisbursementApproverRoleNotAllowed):
+			httperror.Forbidden(services.ErrDisbursementApproverRoleNotAllowed.Error(), err, nil).Render(w)
+		case errors.Is(err, data.ErrDisbursementAlreadyReviewedByUser):
+			httperror.Conflict(data.ErrDisbursementAlreadyReviewedByUser.Error(), err, nil).Render(w)
+		default:
+			msg := fmt.Sprintf("Cannot record %s decision for disbursementID=%s", decision, disbursementID)
+			httperror.InternalError(ctx, msg, err, nil).Render(w)
+		}
+		return
+	}
+
+	httpjson.RenderStatus(w, http.StatusCreated, approval, httpjson.JSON)
+}
+
+// GetDisbursementApprovals returns the approvals and rejections of the disbursement, including the ones invalidated
+// by a rejection or by new instructions.
+func (d DisbursementHandler) GetDisbursementApprovals(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	disbursementID := chi.URLParam(r, "id")
+
+	_, err := d.Models.Disbursements.Get(ctx, d.Models.DBConnectionPool, disbursementID)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound(services.ErrDisbursementNotFound.Error(), err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot get disbursement", err, nil).Render(w)
+		return
+	}
+
+	approvals, err := d.Models.DisbursementApprovals.GetAllByDisbursementID(ctx, d.Models.DBConnectionPool, disbursementID)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get disbursement approvals", err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, approvals, httpjson.JSON)
+}
+
 func (d DisbursementHandler) GetDisbursementInstructions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	disbursementID := chi.URLParam(r, "id")
@@ -585,6 +1020,49 @@ func parseInstructionsFromCSV(ctx context.Context, reader io.Reader, contactType
 	return sanitizedInstructions, nil
 }
 
+// jsonInstructionsFileName is the name of the file the instructions uploaded as JSON are stored as.
+const jsonInstructionsFileName = "instructions.csv"
+
+// isJSONRequest returns whether the request body is JSON, according to its Content-Type header.
+func isJSONRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// parseInstructionsFromJSON parses a JSON array of instructions, validating each of them the same way instructions
+// from a CSV file are validated. Errors are keyed by the index of the instruction in the array.
+func parseInstructionsFromJSON(ctx context.Context, reader io.Reader, contactType data.RegistrationContactType, verificationField data.VerificationType) ([]*data.DisbursementInstruction, *validators.DisbursementInstructionsValidator) {
+	validator := validators.NewDisbursementInstructionsValidator(contactType, verificationField)
+
+	instructions := []*data.DisbursementInstruction{}
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&instructions); err != nil {
+		log.Ctx(ctx).Errorf("error parsing json instructions: %s", err.Error())
+		validator.Errors["body"] = "the request body must be a JSON array of instructions"
+		return nil, validator
+	}
+
+	sanitizedInstructions := make([]*data.DisbursementInstruction, 0, len(instructions))
+	for i, instruction := range instructions {
+		if instruction == nil {
+			validator.Errors[fmt.Sprintf("instructions[%d]", i)] = "instruction cannot be null"
+			continue
+		}
+		sanitizedInstruction := validator.SanitizeInstruction(instruction)
+		validator.ValidateJSONInstruction(sanitizedInstruction, i)
+		sanitizedInstructions = append(sanitizedInstructions, sanitizedInstruction)
+	}
+
+	validator.Check(len(sanitizedInstructions) > 0, "instructions", "no valid instructions found")
+
+	if validator.HasErrors() {
+		return nil, validator
+	}
+
+	return sanitizedInstructions, nil
+}
+
 // validateCSVHeaders validates the headers of the CSV file to make sure we're passing the correct columns.
 func validateCSVHeaders(file io.Reader, registrationContactType data.RegistrationContactType) error {
 	const (
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
 	svcMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/services/mocks"
 	sigMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing/mocks"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
 	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
 	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
 	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
@@ -115,6 +117,130 @@ func Test_DisbursementHandler_validateRequest(t *testing.T) {
 				"receiver_registration_message_template": "receiver_registration_message_template cannot contain HTML, JS or CSS",
 			},
 		},
+		{
+			name: "🔴 payout_mode is invalid",
+			request: PostDisbursementRequest{
+				Name:                    "disbursement 1",
+				AssetID:                 "61dbfa89-943a-413c-b862-a2177384d321",
+				WalletID:                "aab4a4a9-2493-4f37-9741-01d5bd31d68b",
+				RegistrationContactType: data.RegistrationContactTypePhone,
+				VerificationField:       data.VerificationTypeDateOfBirth,
+				PayoutMode:              "invalid",
+			},
+			expectedErrors: map[string]interface{}{
+				"payout_mode": fmt.Sprintf("payout_mode must be one of %v", data.AllPayoutModes()),
+			},
+		},
+		{
+			name: "🔴 receive asset fields are invalid",
+			request: PostDisbursementRequest{
+				Name:                    "disbursement 1",
+				AssetID:                 "61dbfa89-943a-413c-b862-a2177384d321",
+				WalletID:                "aab4a4a9-2493-4f37-9741-01d5bd31d68b",
+				RegistrationContactType: data.RegistrationContactTypePhone,
+				VerificationField:       data.VerificationTypeDateOfBirth,
+				PayoutMode:              data.PayoutModeClaimableBalance,
+				ReceiveAssetCode:        "BRL",
+				ReceiveAssetIssuer:      "invalid",
+				MaxSlippageBps:          utils.IntPtr(10001),
+			},
+			expectedErrors: map[string]interface{}{
+				"receive_asset_issuer": "receive_asset_issuer must be a valid Stellar public key",
+				"payout_mode":          "payout_mode CLAIMABLE_BALANCE cannot be used with a receive asset",
+				"max_slippage_bps":     "max_slippage_bps must be between 0 and 10000",
+			},
+		},
+		{
+			name: "🔴 max_slippage_bps is not allowed without a receive asset",
+			request: PostDisbursementRequest{
+				Name:                    "disbursement 1",
+				AssetID:                 "61dbfa89-943a-413c-b862-a2177384d321",
+				WalletID:                "aab4a4a9-2493-4f37-9741-01d5bd31d68b",
+				RegistrationContactType: data.RegistrationContactTypePhone,
+				VerificationField:       data.VerificationTypeDateOfBirth,
+				MaxSlippageBps:          utils.IntPtr(100),
+			},
+			expectedErrors: map[string]interface{}{
+				"max_slippage_bps": "max_slippage_bps is only allowed with a receive asset",
+			},
+		},
+		{
+			name: "🔴 verification_question is required with CUSTOM_SECRET_QUESTION",
+			request: PostDisbursementRequest{
+				Name:                    "disbursement 1",
+				AssetID:                 "61dbfa89-943a-413c-b862-a2177384d321",
+				WalletID:                "aab4a4a9-2493-4f37-9741-01d5bd31d68b",
+				RegistrationContactType: data.RegistrationContactTypePhone,
+				VerificationField:       data.VerificationTypeCustomSecretQuestion,
+				VerificationQuestion:    "  ",
+			},
+			expectedErrors: map[string]interface{}{
+				"verification_question": "verification_question is required with the CUSTOM_SECRET_QUESTION verification field",
+			},
+		},
+		{
+			name: "🔴 verification_question contains HTML",
+			request: PostDisbursementRequest{
+				Name:                    "disbursement 1",
+				AssetID:                 "61dbfa89-943a-413c-b862-a2177384d321",
+				WalletID:                "aab4a4a9-2493-4f37-9741-01d5bd31d68b",
+				RegistrationContactType: data.RegistrationContactTypePhone,
+				VerificationField:       data.VerificationTypeCustomSecretQuestion,
+				VerificationQuestion:    "<script>alert('What is your favorite color?')</script>",
+			},
+			expectedErrors: map[string]interface{}{
+				"verification_question": "verification_question cannot contain HTML, JS or CSS",
+			},
+		},
+		{
+			name: "🔴 verification_question is only allowed with CUSTOM_SECRET_QUESTION",
+			request: PostDisbursementRequest{
+				Name:                    "disbursement 1",
+				AssetID:                 "61dbfa89-943a-413c-b862-a2177384d321",
+				WalletID:                "aab4a4a9-2493-4f37-9741-01d5bd31d68b",
+				RegistrationContactType: data.RegistrationContactTypePhone,
+				VerificationField:       data.VerificationTypeDateOfBirth,
+				VerificationQuestion:    "What is your favorite color?",
+			},
+			expectedErrors: map[string]interface{}{
+				"verification_question": "verification_question is only allowed with the CUSTOM_SECRET_QUESTION verification field",
+			},
+		},
+		{
+			name: "🟢 all fields are valid w/ CUSTOM_SECRET_QUESTION",
+			request: PostDisbursementRequest{
+				Name:                    "disbursement 1",
+				AssetID:                 "61dbfa89-943a-413c-b862-a2177384d321",
+				WalletID:                "aab4a4a9-2493-4f37-9741-01d5bd31d68b",
+				RegistrationContactType: data.RegistrationContactTypePhone,
+				VerificationField:       data.VerificationTypeCustomSecretQuestion,
+				VerificationQuestion:    "What is your favorite color?",
+			},
+		},
+		{
+			name: "🟢 all fields are valid w/ receive asset",
+			request: PostDisbursementRequest{
+				Name:                    "disbursement 1",
+				AssetID:                 "61dbfa89-943a-413c-b862-a2177384d321",
+				WalletID:                "aab4a4a9-2493-4f37-9741-01d5bd31d68b",
+				RegistrationContactType: data.RegistrationContactTypePhone,
+				VerificationField:       data.VerificationTypeDateOfBirth,
+				ReceiveAssetCode:        "BRL",
+				ReceiveAssetIssuer:      "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
+				MaxSlippageBps:          utils.IntPtr(50),
+			},
+		},
+		{
+			name: "🟢 all fields are valid w/ CLAIMABLE_BALANCE payout_mode",
+			request: PostDisbursementRequest{
+				Name:                    "disbursement 1",
+				AssetID:                 "61dbfa89-943a-413c-b862-a2177384d321",
+				WalletID:                "aab4a4a9-2493-4f37-9741-01d5bd31d68b",
+				RegistrationContactType: data.RegistrationContactTypePhone,
+				VerificationField:       data.VerificationTypeDateOfBirth,
+				PayoutMode:              data.PayoutModeClaimableBalance,
+			},
+		},
 		{
 			name: "🟢 all fields are valid",
 			request: PostDisbursementRequest{
@@ -234,7 +360,7 @@ func Test_DisbursementHandler_PostDisbursement(t *testing.T) {
 						"wallet_id": "wallet_id is required",
 						"asset_id": "asset_id is required",
 						"registration_contact_type": "registration_contact_type must be one of [EMAIL PHONE_NUMBER EMAIL_AND_WALLET_ADDRESS PHONE_NUMBER_AND_WALLET_ADDRESS]",
-						"verification_field": "verification_field must be one of [DATE_OF_BIRTH YEAR_MONTH PIN NATIONAL_ID_NUMBER]"
+						"verification_field": "verification_field must be one of [DATE_OF_BIRTH YEAR_MONTH PIN NATIONAL_ID_NUMBER TAX_ID PHONE_LAST_4 CUSTOM_SECRET_QUESTION]"
 					}
 				}`
 			},
@@ -1007,7 +1133,7 @@ func Test_DisbursementHandler_PostDisbursementInstructions(t *testing.T) {
 			},
 			actualFileName:  "file.bat",
 			expectedStatus:  http.StatusBadRequest,
-			expectedMessage: "the file extension should be .csv",
+			expectedMessage: "the file extension should be .csv or .xlsx",
 		},
 		{
 			name:           ".sh file fails",
@@ -1018,7 +1144,7 @@ func Test_DisbursementHandler_PostDisbursementInstructions(t *testing.T) {
 			},
 			actualFileName:  "file.sh",
 			expectedStatus:  http.StatusBadRequest,
-			expectedMessage: "the file extension should be .csv",
+			expectedMessage: "the file extension should be .csv or .xlsx",
 		},
 		{
 			name:           ".bash file fails",
@@ -1029,7 +1155,7 @@ func Test_DisbursementHandler_PostDisbursementInstructions(t *testing.T) {
 			},
 			actualFileName:  "file.bash",
 			expectedStatus:  http.StatusBadRequest,
-			expectedMessage: "the file extension should be .csv",
+			expectedMessage: "the file extension should be .csv or .xlsx",
 		},
 		{
 			name:           ".csv file with transversal path ..\\.. fails",
@@ -1198,6 +1324,479 @@ func Test_DisbursementHandler_PostDisbursementInstructions(t *testing.T) {
 	}
 }
 
+func Test_DisbursementHandler_PostDisbursementInstructions_JSON(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	token := "token"
+	ctx := context.WithValue(context.Background(), middleware.TokenContextKey, token)
+	authManagerMock := &auth.AuthManagerMock{}
+	authManagerMock.
+		On("GetUser", mock.Anything, token).
+		Return(&auth.User{ID: "user-id", Email: "email@email.com"}, nil)
+
+	handler := &DisbursementHandler{
+		Models:      models,
+		AuthManager: authManagerMock,
+	}
+	router := chi.NewRouter()
+	router.Post("/disbursements/{id}/instructions", handler.PostDisbursementInstructions)
+
+	wallet := data.CreateDefaultWalletFixture(t, ctx, dbConnectionPool)
+	asset := data.GetAssetFixture(t, ctx, dbConnectionPool, data.FixtureAssetUSDC)
+	disbursement := data.CreateDraftDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, data.Disbursement{
+		Name:   "json disbursement",
+		Asset:  asset,
+		Wallet: wallet,
+	})
+
+	postInstructions := func(t *testing.T, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("/disbursements/%s/instructions", disbursement.ID), strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("invalid body", func(t *testing.T) {
+		rr := postInstructions(t, `{"phone": "+380445555555"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "the request body must be a JSON array of instructions")
+	})
+
+	t.Run("invalid instructions are reported by index", func(t *testing.T) {
+		rr := postInstructions(t, `[
+			{"phone": "+380445555555", "id": "1", "amount": "100.5", "verification": "1990-01-01"},
+			{"phone": "invalid", "id": "2", "amount": "-1", "verification": "1990-01-01"}
+		]`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{
+			"error": "could not parse instructions",
+			"extras": {
+				"instructions[1] - phone": "invalid phone format. Correct format: +380445555555",
+				"instructions[1] - amount": "invalid amount. Amount must be a positive number"
+			}
+		}`, rr.Body.String())
+	})
+
+	t.Run("max instructions exceeded", func(t *testing.T) {
+		instructions := make([]data.DisbursementInstruction, data.MaxInstructionsPerDisbursement+1)
+		for i := range instructions {
+			instructions[i] = data.DisbursementInstruction{Phone: "+380445555555", ID: "123456789", Amount: "100.5", VerificationValue: "1990-01-01"}
+		}
+		body, err := json.Marshal(instructions)
+		require.NoError(t, err)
+
+		rr := postInstructions(t, string(body))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "number of instructions exceeds maximum of 10000")
+	})
+
+	t.Run("🎉 processes the instructions and stores them as CSV", func(t *testing.T) {
+		rr := postInstructions(t, `[{"phone": "+380445555555", "id": "123456789", "amount": "100.5", "verification": "1990-01-01", "payment_id": "payment-1"}]`)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		got, err := models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.ReadyDisbursementStatus, got.Status)
+		assert.Equal(t, jsonInstructionsFileName, got.FileName)
+		assert.Contains(t, string(got.FileContent), "+380445555555,,123456789,100.5,1990-01-01,payment-1,")
+
+		payments, err := models.Payment.GetAll(ctx, &data.QueryParams{}, dbConnectionPool, data.QueryTypeSelectAll)
+		require.NoError(t, err)
+		require.Len(t, payments, 1)
+		assert.Equal(t, "payment-1", payments[0].ExternalPaymentID)
+	})
+
+	authManagerMock.AssertExpectations(t)
+}
+
+func Test_DisbursementHandler_PostDisbursementInstructions_XLSX(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	token := "token"
+	ctx := context.WithValue(context.Background(), middleware.TokenContextKey, token)
+	authManagerMock := &auth.AuthManagerMock{}
+	authManagerMock.
+		On("GetUser", mock.Anything, token).
+		Return(&auth.User{ID: "user-id", Email: "email@email.com"}, nil)
+
+	handler := &DisbursementHandler{
+		Models:      models,
+		AuthManager: authManagerMock,
+	}
+	router := chi.NewRouter()
+	router.Post("/disbursements/{id}/instructions", handler.PostDisbursementInstructions)
+
+	wallet := data.CreateDefaultWalletFixture(t, ctx, dbConnectionPool)
+	asset := data.GetAssetFixture(t, ctx, dbConnectionPool, data.FixtureAssetUSDC)
+	disbursement := data.CreateDraftDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, data.Disbursement{
+		Name:   "xlsx disbursement",
+		Asset:  asset,
+		Wallet: wallet,
+	})
+
+	postXLSXInstructions := func(t *testing.T, fileContent []byte) *httptest.ResponseRecorder {
+		req, err := createInstructionsMultipartRequest(t, ctx, "", "instructions.xlsx", disbursement.ID, bytes.NewReader(fileContent))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("invalid xlsx file", func(t *testing.T) {
+		rr := postXLSXInstructions(t, []byte("phone,id,amount,verification\n"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "could not read xlsx file")
+	})
+
+	t.Run("xlsx file that inflates beyond the unzip size limit", func(t *testing.T) {
+		rr := postXLSXInstructions(t, oversizedXLSXFileContent(t))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "could not read xlsx file")
+	})
+
+	t.Run("invalid headers", func(t *testing.T) {
+		f := createXLSXFile(t, [][]interface{}{{"email", "id", "amount"}, {"receiver@stellar.org", "1", 100}})
+		rr := postXLSXInstructions(t, xlsxFileContent(t, f))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "CSV columns are not valid for registration contact type")
+	})
+
+	t.Run("🎉 processes the instructions and stores them as CSV", func(t *testing.T) {
+		f := createXLSXFile(t, [][]interface{}{
+			{"phone", "id", "amount", "verification"},
+			{"+380445555555", "123456789", 100.5, "1990-01-01"},
+		})
+		rr := postXLSXInstructions(t, xlsxFileContent(t, f))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		got, err := models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.ReadyDisbursementStatus, got.Status)
+		assert.Equal(t, "instructions.xlsx", got.FileName)
+		assert.Equal(t, "phone,id,amount,verification\n+380445555555,123456789,100.5,1990-01-01\n", string(got.FileContent))
+	})
+
+	authManagerMock.AssertExpectations(t)
+}
+
+func Test_DisbursementHandler_PostDisbursementInstructions_Async(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	token := "token"
+	ctx := context.WithValue(context.Background(), middleware.TokenContextKey, token)
+	authManagerMock := &auth.AuthManagerMock{}
+	authManagerMock.
+		On("GetUser", mock.Anything, token).
+		Return(&auth.User{ID: "user-id", Email: "email@email.com"}, nil)
+
+	handler := &DisbursementHandler{
+		Models:      models,
+		AuthManager: authManagerMock,
+	}
+	router := chi.NewRouter()
+	router.Post("/disbursements/{id}/instructions", handler.PostDisbursementInstructions)
+	router.Get("/disbursements/{id}/instructions/uploads", handler.GetDisbursementInstructionUploads)
+	router.Get("/disbursements/{id}/instructions/uploads/{uploadID}", handler.GetDisbursementInstructionUpload)
+
+	wallet := data.CreateDefaultWalletFixture(t, ctx, dbConnectionPool)
+	asset := data.GetAssetFixture(t, ctx, dbConnectionPool, data.FixtureAssetUSDC)
+
+	postAsyncInstructions := func(t *testing.T, disbursementID, query string, records [][]string) *httptest.ResponseRecorder {
+		fileContent, err := createCSVFile(t, records)
+		require.NoError(t, err)
+		req, err := createInstructionsMultipartRequest(t, ctx, "", "", disbursementID, fileContent)
+		require.NoError(t, err)
+		req.URL.RawQuery = query
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	validRecords := [][]string{
+		{"phone", "id", "amount", "verification"},
+		{"+380445555555", "123456789", "100.5", "1990-01-01"},
+	}
+
+	t.Run("invalid async param", func(t *testing.T) {
+		disbursement := data.CreateDraftDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, data.Disbursement{
+			Name:   "invalid async param",
+			Asset:  asset,
+			Wallet: wallet,
+		})
+
+		rr := postAsyncInstructions(t, disbursement.ID, "async=maybe", validRecords)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error": "The request was invalid in some way.", "extras": {"async": "async must be a boolean"}}`, rr.Body.String())
+	})
+
+	t.Run("invalid CSV headers are rejected right away", func(t *testing.T) {
+		disbursement := data.CreateDraftDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, data.Disbursement{
+			Name:   "invalid headers",
+			Asset:  asset,
+			Wallet: wallet,
+		})
+
+		rr := postAsyncInstructions(t, disbursement.ID, "async=true", [][]string{{"email", "id", "amount"}, {"receiver@stellar.org", "1", "100"}})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "CSV columns are not valid for registration contact type")
+	})
+
+	t.Run("🎉 stores the upload, moves the disbursement to draft and exposes its progress", func(t *testing.T) {
+		disbursement := data.CreateDraftDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, data.Disbursement{
+			Name:   "async disbursement",
+			Asset:  asset,
+			Wallet: wallet,
+		})
+		err = models.Disbursements.UpdateStatus(ctx, dbConnectionPool, "user-id", disbursement.ID, data.ReadyDisbursementStatus)
+		require.NoError(t, err)
+
+		rr := postAsyncInstructions(t, disbursement.ID, "async=true", validRecords)
+		require.Equal(t, http.StatusAccepted, rr.Code)
+
+		var upload data.DisbursementInstructionUpload
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &upload))
+		assert.Equal(t, disbursement.ID, upload.DisbursementID)
+		assert.Equal(t, data.PendingDisbursementInstructionUploadStatus, upload.Status)
+		assert.Equal(t, "instructions.csv", upload.FileName)
+		assert.Equal(t, "user-id", upload.CreatedBy)
+
+		got, err := models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.DraftDisbursementStatus, got.Status)
+
+		// New uploads are rejected while the upload is being processed.
+		rr = postAsyncInstructions(t, disbursement.ID, "async=true", validRecords)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), errInstructionUploadInProgressMsg)
+		rr = postAsyncInstructions(t, disbursement.ID, "", validRecords)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("/disbursements/%s/instructions/uploads/%s", disbursement.ID, upload.ID), nil)
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"status":"PENDING"`)
+		assert.NotContains(t, rr.Body.String(), "file_content")
+
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("/disbursements/%s/instructions/uploads", disbursement.ID), nil)
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var uploads []data.DisbursementInstructionUpload
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &uploads))
+		require.Len(t, uploads, 1)
+		assert.Equal(t, upload.ID, uploads[0].ID)
+	})
+
+	t.Run("upload not found", func(t *testing.T) {
+		disbursement := data.CreateDraftDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, data.Disbursement{
+			Name:   "no uploads",
+			Asset:  asset,
+			Wallet: wallet,
+		})
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("/disbursements/%s/instructions/uploads/%s", disbursement.ID, "unknown-id"), nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/disbursements/unknown-id/instructions/uploads", nil)
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	authManagerMock.AssertExpectations(t)
+}
+
+func Test_DisbursementHandler_ValidateDisbursementInstructions(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	distributionAccount := schema.NewDefaultStellarTransactionAccount("GAAHIL6ZW4QFNLCKALZ3YOIWPP4TXQ7B7J5IU7RLNVGQAV6GFDZHLDTA")
+	mockDistAccResolver := sigMocks.NewMockDistributionAccountResolver(t)
+	mockDistAccSvc := svcMocks.NewMockDistributionAccountService(t)
+
+	handler := &DisbursementHandler{
+		Models:                      models,
+		DistributionAccountResolver: mockDistAccResolver,
+		DisbursementManagementService: &services.DisbursementManagementService{
+			Models:                     models,
+			DistributionAccountService: mockDistAccSvc,
+		},
+	}
+	router := chi.NewRouter()
+	router.Post("/disbursements/{id}/instructions/validate", handler.ValidateDisbursementInstructions)
+
+	wallet := data.CreateDefaultWalletFixture(t, ctx, dbConnectionPool)
+	asset := data.GetAssetFixture(t, ctx, dbConnectionPool, data.FixtureAssetUSDC)
+	disbursement := data.CreateDraftDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, data.Disbursement{
+		Name:   "dry run disbursement",
+		Asset:  asset,
+		Wallet: wallet,
+	})
+
+	validateInstructions := func(t *testing.T, disbursementID string, records [][]string) *httptest.ResponseRecorder {
+		fileContent, err := createCSVFile(t, records)
+		require.NoError(t, err)
+		req, err := createInstructionsMultipartRequest(t, ctx, "", "", disbursementID, fileContent)
+		require.NoError(t, err)
+		req.URL.Path = fmt.Sprintf("/disbursements/%s/instructions/validate", disbursementID)
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("disbursement not found", func(t *testing.T) {
+		rr := validateInstructions(t, "unknown-id", [][]string{{"phone", "id", "amount", "verification"}})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid CSV headers", func(t *testing.T) {
+		rr := validateInstructions(t, disbursement.ID, [][]string{{"email", "id", "amount"}, {"receiver@stellar.org", "1", "100"}})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "CSV columns are not valid for registration contact type")
+	})
+
+	t.Run("no instructions", func(t *testing.T) {
+		rr := validateInstructions(t, disbursement.ID, [][]string{{"phone", "id", "amount", "verification"}})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error": "could not parse csv file", "extras": {"instructions": "no valid instructions found"}}`, rr.Body.String())
+	})
+
+	t.Run("🎉 reports the outcome of each row without writing anything", func(t *testing.T) {
+		mockDistAccResolver.
+			On("DistributionAccountFromContext", mock.Anything).
+			Return(distributionAccount, nil).
+			Once()
+		mockDistAccSvc.
+			On("GetBalance", mock.Anything, &distributionAccount, *asset).
+			Return(1000.0, nil).
+			Once()
+
+		rr := validateInstructions(t, disbursement.ID, [][]string{
+			{"phone", "id", "amount", "verification"},
+			{"+380445555555", "1", "100.5", "1990-01-01"},
+			{"invalid", "2", "100", "1990-01-01"},
+			{"+380445555555", "3", "50", "1990-01-01"},
+		})
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var report services.InstructionsValidationReport
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+		assert.False(t, report.Valid)
+		assert.Equal(t, 3, report.Summary.TotalRows)
+		assert.Equal(t, 1, report.Summary.ValidRows)
+		assert.Equal(t, 2, report.Summary.InvalidRows)
+		assert.Equal(t, 2, report.Summary.NewReceivers)
+		assert.Equal(t, 1, report.Summary.DuplicateContacts)
+		assert.Equal(t, "150.5000000", report.Summary.TotalAmount)
+		require.NotNil(t, report.Balance)
+		assert.True(t, report.Balance.Sufficient)
+		require.Len(t, report.Rows, 3)
+		assert.Equal(t, services.NewInstructionReceiverStatus, report.Rows[0].Receiver)
+		assert.Contains(t, report.Rows[1].Errors, "line 3 - phone")
+		assert.Equal(t, 2, report.Rows[2].DuplicateOfLine)
+
+		receivers, err := models.Receiver.GetByContacts(ctx, dbConnectionPool, "+380445555555")
+		require.NoError(t, err)
+		assert.Empty(t, receivers)
+	})
+}
+
+func Test_parseInstructionsFromJSON(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name             string
+		body             string
+		wantInstructions []*data.DisbursementInstruction
+		wantErrors       map[string]interface{}
+	}{
+		{
+			name:       "not an array",
+			body:       `{"phone": "+380445555555"}`,
+			wantErrors: map[string]interface{}{"body": "the request body must be a JSON array of instructions"},
+		},
+		{
+			name:       "unknown fields",
+			body:       `[{"phone": "+380445555555", "id": "1", "amount": "1", "verification": "1990-01-01", "foo": "bar"}]`,
+			wantErrors: map[string]interface{}{"body": "the request body must be a JSON array of instructions"},
+		},
+		{
+			name:       "empty array",
+			body:       `[]`,
+			wantErrors: map[string]interface{}{"instructions": "no valid instructions found"},
+		},
+		{
+			name:       "null instruction",
+			body:       `[null]`,
+			wantErrors: map[string]interface{}{"instructions[0]": "instruction cannot be null", "instructions": "no valid instructions found"},
+		},
+		{
+			name: "🎉 valid instructions are sanitized",
+			body: `[{"phone": " +380445555555 ", "id": " 1 ", "amount": "100.5", "verification": "1990-01-01"}]`,
+			wantInstructions: []*data.DisbursementInstruction{
+				{Phone: "+380445555555", ID: "1", Amount: "100.5", VerificationValue: "1990-01-01"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			instructions, v := parseInstructionsFromJSON(ctx, strings.NewReader(tc.body), data.RegistrationContactTypePhone, data.VerificationTypeDateOfBirth)
+			if tc.wantErrors != nil {
+				require.NotNil(t, v)
+				assert.Equal(t, tc.wantErrors, v.Errors)
+				assert.Nil(t, instructions)
+			} else {
+				assert.Nil(t, v)
+				assert.Equal(t, tc.wantInstructions, instructions)
+			}
+		})
+	}
+}
+
 func Test_DisbursementHandler_GetDisbursement(t *testing.T) {
 	dbt := dbtest.Open(t)
 	defer dbt.Close()
@@ -1842,6 +2441,69 @@ func Test_DisbursementHandler_PatchDisbursementStatus(t *testing.T) {
 		require.Contains(t, rr.Body.String(), services.ErrDisbursementNotFound.Error())
 	})
 
+	t.Run("invalid scheduled_start_at", func(t *testing.T) {
+		authManagerMock.
+			On("GetUser", mock.Anything, token).
+			Return(user, nil).
+			Once()
+
+		err := json.NewEncoder(reqBody).Encode(PatchDisbursementStatusRequest{Status: "SCHEDULED", ScheduledStartAt: "tomorrow"})
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPatch, fmt.Sprintf("/disbursements/%s/status", draftDisbursement.ID), reqBody)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), "invalid scheduled_start_at")
+	})
+
+	t.Run("disbursement not ready to be scheduled", func(t *testing.T) {
+		authManagerMock.
+			On("GetUser", mock.Anything, token).
+			Return(user, nil).
+			Once()
+
+		mockDistAccResolver.
+			On("DistributionAccountFromContext", mock.Anything).
+			Return(distAcc, nil).
+			Once()
+
+		scheduledStartAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+		err := json.NewEncoder(reqBody).Encode(PatchDisbursementStatusRequest{Status: "SCHEDULED", ScheduledStartAt: scheduledStartAt})
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPatch, fmt.Sprintf("/disbursements/%s/status", draftDisbursement.ID), reqBody)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), services.ErrDisbursementNotReadyToSchedule.Error())
+	})
+
+	t.Run("disbursement not scheduled", func(t *testing.T) {
+		authManagerMock.
+			On("GetUser", mock.Anything, token).
+			Return(user, nil).
+			Once()
+
+		err := json.NewEncoder(reqBody).Encode(PatchDisbursementStatusRequest{Status: "READY"})
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPatch, fmt.Sprintf("/disbursements/%s/status", draftDisbursement.ID), reqBody)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), services.ErrDisbursementNotScheduled.Error())
+	})
+
 	authManagerMock.AssertExpectations(t)
 	mockEventProducer.AssertExpectations(t)
 }
@@ -2136,3 +2798,163 @@ func buildURLWithQueryParams(baseURL, endpoint string, queryParams map[string]st
 	u.RawQuery = q.Encode()
 	return u.String()
 }
+
+func Test_parseScheduledStartAt(t *testing.T) {
+	testCases := []struct {
+		name              string
+		value             string
+		timezoneUTCOffset string
+		want              time.Time
+		wantErrContains   string
+	}{
+		{
+			name:            "empty value",
+			value:           "",
+			wantErrContains: "scheduled_start_at is required",
+		},
+		{
+			name:            "invalid value",
+			value:           "tomorrow",
+			wantErrContains: "scheduled_start_at must be in the RFC3339 format",
+		},
+		{
+			name:              "🎉 RFC3339 value ignores the organization timezone",
+			value:             "2030-01-02T15:04:05Z",
+			timezoneUTCOffset: "+02:00",
+			want:              time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:              "🎉 local value is interpreted in the organization timezone",
+			value:             "2030-01-02T15:04:05",
+			timezoneUTCOffset: "+02:00",
+			want:              time.Date(2030, 1, 2, 13, 4, 5, 0, time.UTC),
+		},
+		{
+			name:  "🎉 local value defaults to UTC",
+			value: "2030-01-02T15:04:05",
+			want:  time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseScheduledStartAt(tc.value, tc.timezoneUTCOffset)
+			if tc.wantErrContains != "" {
+				assert.ErrorContains(t, err, tc.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tc.want.Equal(got), "want %s, got %s", tc.want, got)
+		})
+	}
+}
+
+func Test_DisbursementHandler_ReviewDisbursement(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	token := "token"
+	ctx := context.WithValue(context.Background(), middleware.TokenContextKey, token)
+	creator := &auth.User{ID: "creator-user", Roles: []string{data.FinancialControllerUserRole.String()}}
+	approver := &auth.User{ID: "approver-user", Roles: []string{data.FinancialControllerUserRole.String()}}
+
+	authManagerMock := &auth.AuthManagerMock{}
+	handler := &DisbursementHandler{
+		Models:                        models,
+		AuthManager:                   authManagerMock,
+		DisbursementManagementService: &services.DisbursementManagementService{Models: models, AuthManager: authManagerMock},
+	}
+
+	r := chi.NewRouter()
+	r.Post("/disbursements/{id}/approve", handler.PostDisbursementApproval)
+	r.Post("/disbursements/{id}/reject", handler.PostDisbursementRejection)
+	r.Get("/disbursements/{id}/approvals", handler.GetDisbursementApprovals)
+
+	isApprovalRequired := true
+	err = models.Organizations.Update(ctx, &data.OrganizationUpdate{IsApprovalRequired: &isApprovalRequired})
+	require.NoError(t, err)
+
+	disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Status: data.ReadyDisbursementStatus,
+		StatusHistory: []data.DisbursementStatusHistoryEntry{
+			{Status: data.DraftDisbursementStatus, UserID: creator.ID},
+			{Status: data.ReadyDisbursementStatus, UserID: creator.ID},
+		},
+	})
+
+	sendRequest := func(t *testing.T, method, path, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(ctx, method, path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("rejection without a comment", func(t *testing.T) {
+		rr := sendRequest(t, http.MethodPost, fmt.Sprintf("/disbursements/%s/reject", disbursement.ID), `{}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "comment is required when rejecting a disbursement")
+	})
+
+	t.Run("disbursement not found", func(t *testing.T) {
+		authManagerMock.On("GetUser", mock.Anything, token).Return(approver, nil).Once()
+		rr := sendRequest(t, http.MethodPost, "/disbursements/not-found/approve", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("approval by the disbursement creator", func(t *testing.T) {
+		authManagerMock.On("GetUser", mock.Anything, token).Return(creator, nil).Once()
+		rr := sendRequest(t, http.MethodPost, fmt.Sprintf("/disbursements/%s/approve", disbursement.ID), "")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), services.ErrDisbursementReviewedByCreator.Error())
+	})
+
+	t.Run("🎉 approves the disbursement", func(t *testing.T) {
+		authManagerMock.On("GetUser", mock.Anything, token).Return(approver, nil).Once()
+		rr := sendRequest(t, http.MethodPost, fmt.Sprintf("/disbursements/%s/approve", disbursement.ID), `{"comment": "looks good"}`)
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		var approval data.DisbursementApproval
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &approval))
+		assert.Equal(t, approver.ID, approval.UserID)
+		assert.Equal(t, data.ApprovedDisbursementApprovalDecision, approval.Decision)
+		assert.Equal(t, "looks good", approval.Comment)
+	})
+
+	t.Run("approval by a user who already reviewed the disbursement", func(t *testing.T) {
+		authManagerMock.On("GetUser", mock.Anything, token).Return(approver, nil).Once()
+		rr := sendRequest(t, http.MethodPost, fmt.Sprintf("/disbursements/%s/approve", disbursement.ID), "")
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("🎉 rejects the disbursement", func(t *testing.T) {
+		authManagerMock.On("GetUser", mock.Anything, token).Return(&auth.User{ID: "rejecter-user"}, nil).Once()
+		rr := sendRequest(t, http.MethodPost, fmt.Sprintf("/disbursements/%s/reject", disbursement.ID), `{"comment": "wrong amounts"}`)
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		got, err := models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.DraftDisbursementStatus, got.Status)
+	})
+
+	t.Run("🎉 lists the approvals", func(t *testing.T) {
+		rr := sendRequest(t, http.MethodGet, fmt.Sprintf("/disbursements/%s/approvals", disbursement.ID), "")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var approvals []data.DisbursementApproval
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &approvals))
+		require.Len(t, approvals, 2)
+		assert.Equal(t, data.ApprovedDisbursementApprovalDecision, approvals[0].Decision)
+		assert.NotNil(t, approvals[0].InvalidatedAt)
+		assert.Equal(t, data.RejectedDisbursementApprovalDecision, approvals[1].Decision)
+		assert.Equal(t, "wrong amounts", approvals[1].Comment)
+	})
+
+	authManagerMock.AssertExpectations(t)
+}
@@ -0,0 +1,362 @@
+package httphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stellar/go/support/render/httpjson"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/middleware"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/validators"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
+)
+
+// DisbursementTemplatesHandler manages the recurring disbursement templates.
+type DisbursementTemplatesHandler struct {
+	Models      *data.Models
+	AuthManager auth.AuthManager
+}
+
+type PostDisbursementTemplateRequest struct {
+	Name                                string                       `json:"name"`
+	WalletID                            string                       `json:"wallet_id"`
+	AssetID                             string                       `json:"asset_id"`
+	VerificationField                   data.VerificationType        `json:"verification_field"`
+	VerificationQuestion                string                       `json:"verification_question"`
+	RegistrationContactType             data.RegistrationContactType `json:"registration_contact_type"`
+	ReceiverRegistrationMessageTemplate string                       `json:"receiver_registration_message_template"`
+	Schedule                            string                       `json:"schedule"`
+	DisbursementStatus                  data.DisbursementStatus      `json:"disbursement_status"`
+}
+
+type PatchDisbursementTemplateRequest struct {
+	Name                                string                  `json:"name"`
+	Status                              string                  `json:"status"`
+	ReceiverRegistrationMessageTemplate string                  `json:"receiver_registration_message_template"`
+	Schedule                            string                  `json:"schedule"`
+	DisbursementStatus                  data.DisbursementStatus `json:"disbursement_status"`
+}
+
+// templateDisbursementStatuses are the statuses of the disbursements created from templates.
+var templateDisbursementStatuses = []data.DisbursementStatus{data.DraftDisbursementStatus, data.ReadyDisbursementStatus}
+
+func validateTemplateSchedule(v *validators.Validator, schedule string) {
+	_, err := utils.ParseCronSchedule(schedule)
+	v.CheckError(err, "schedule", "schedule must be a valid cron expression")
+}
+
+func validateTemplateDisbursementStatus(v *validators.Validator, status data.DisbursementStatus) {
+	v.Check(
+		status == "" || slices.Contains(templateDisbursementStatuses, status),
+		"disbursement_status",
+		fmt.Sprintf("disbursement_status must be one of %v", templateDisbursementStatuses),
+	)
+}
+
+func (h DisbursementTemplatesHandler) validatePostRequest(req PostDisbursementTemplateRequest) *validators.Validator {
+	v := validators.NewValidator()
+
+	v.Check(req.Name != "", "name", "name is required")
+	v.Check(req.AssetID != "", "asset_id", "asset_id is required")
+	v.Check(
+		slices.Contains(data.AllRegistrationContactTypes(), req.RegistrationContactType),
+		"registration_contact_type",
+		fmt.Sprintf("registration_contact_type must be one of %v", data.AllRegistrationContactTypes()),
+	)
+	v.CheckError(utils.ValidateNoHTML(req.ReceiverRegistrationMessageTemplate), "receiver_registration_message_template", "receiver_registration_message_template cannot contain HTML, JS or CSS")
+	validateTemplateSchedule(v, req.Schedule)
+	validateTemplateDisbursementStatus(v, req.DisbursementStatus)
+	if !req.RegistrationContactType.IncludesWalletAddress {
+		v.Check(
+			slices.Contains(data.GetAllVerificationTypes(), req.VerificationField),
+			"verification_field",
+			fmt.Sprintf("verification_field must be one of %v", data.GetAllVerificationTypes()),
+		)
+		v.Check(req.WalletID != "", "wallet_id", "wallet_id is required")
+	} else {
+		v.Check(req.VerificationField == "", "verification_field", "verification_field is not allowed for this registration contact type")
+		v.Check(req.WalletID == "", "wallet_id", "wallet_id is not allowed for this registration contact type")
+	}
+	validateVerificationQuestion(v, req.VerificationField, req.VerificationQuestion)
+
+	return v
+}
+
+func (h DisbursementTemplatesHandler) PostDisbursementTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	token, ok := ctx.Value(middleware.TokenContextKey).(string)
+	if !ok {
+		httperror.Unauthorized("", nil, nil).Render(w)
+		return
+	}
+	user, err := h.AuthManager.GetUser(ctx, token)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get user", err, nil).Render(w)
+		return
+	}
+
+	var req PostDisbursementTemplateRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(err.Error(), err, nil).Render(w)
+		return
+	}
+	v := h.validatePostRequest(req)
+	if v.HasErrors() {
+		httperror.BadRequest("", nil, v.Errors).Render(w)
+		return
+	}
+
+	var wallet *data.Wallet
+	if req.RegistrationContactType.IncludesWalletAddress {
+		wallets, findWalletErr := h.Models.Wallets.FindWallets(ctx,
+			data.NewFilter(data.FilterUserManaged, true),
+			data.NewFilter(data.FilterEnabledWallets, true))
+		if findWalletErr != nil {
+			httperror.InternalError(ctx, "Cannot get wallets", findWalletErr, nil).Render(w)
+			return
+		}
+		if len(wallets) == 0 {
+			httperror.BadRequest("No User Managed Wallets found", nil, nil).Render(w)
+			return
+		}
+		wallet = &wallets[0]
+	} else {
+		wallet, err = h.Models.Wallets.Get(ctx, req.WalletID)
+		if err != nil {
+			httperror.BadRequest("Wallet ID could not be retrieved", err, nil).Render(w)
+			return
+		}
+	}
+	if !wallet.Enabled {
+		httperror.BadRequest("Wallet is not enabled", errors.New("wallet is not enabled"), nil).Render(w)
+		return
+	}
+
+	asset, err := h.Models.Assets.Get(ctx, req.AssetID)
+	if err != nil {
+		httperror.BadRequest("asset ID could not be retrieved", err, nil).Render(w)
+		return
+	}
+
+	nextRunAt, httpErr := h.nextRunAt(r, req.Schedule)
+	if httpErr != nil {
+		httpErr.Render(w)
+		return
+	}
+
+	template, err := h.Models.DisbursementTemplates.Insert(ctx, data.DisbursementTemplateInsert{
+		Name:                                req.Name,
+		WalletID:                            wallet.ID,
+		AssetID:                             asset.ID,
+		VerificationField:                   req.VerificationField,
+		VerificationQuestion:                strings.TrimSpace(req.VerificationQuestion),
+		RegistrationContactType:             req.RegistrationContactType,
+		ReceiverRegistrationMessageTemplate: req.ReceiverRegistrationMessageTemplate,
+		Schedule:                            req.Schedule,
+		DisbursementStatus:                  req.DisbursementStatus,
+		NextRunAt:                           nextRunAt,
+		CreatedBy:                           user.ID,
+	})
+	if err != nil {
+		if errors.Is(err, data.ErrDisbursementTemplateNameAlreadyExists) {
+			httperror.Conflict(data.ErrDisbursementTemplateNameAlreadyExists.Error(), err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot create disbursement template", err, nil).Render(w)
+		return
+	}
+
+	httpjson.RenderStatus(w, http.StatusCreated, template, httpjson.JSON)
+}
+
+// nextRunAt calculates the next occurrence of the schedule from now, in the organization's timezone.
+func (h DisbursementTemplatesHandler) nextRunAt(r *http.Request, schedule string) (time.Time, *httperror.HTTPError) {
+	ctx := r.Context()
+
+	organization, err := h.Models.Organizations.Get(ctx)
+	if err != nil {
+		return time.Time{}, httperror.InternalError(ctx, "Cannot get organization", err, nil)
+	}
+
+	nextRunAt, err := services.NextDisbursementTemplateRunAt(schedule, organization.TimezoneUTCOffset, time.Now())
+	if err != nil {
+		if errors.Is(err, services.ErrDisbursementTemplateScheduleNeverRuns) {
+			return time.Time{}, httperror.BadRequest(err.Error(), err, nil)
+		}
+		return time.Time{}, httperror.InternalError(ctx, "Cannot calculate the next run of the schedule", err, nil)
+	}
+
+	return nextRunAt, nil
+}
+
+func (h DisbursementTemplatesHandler) GetDisbursementTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templates, err := h.Models.DisbursementTemplates.GetAll(ctx, h.Models.DBConnectionPool)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot retrieve disbursement templates", err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, templates, httpjson.JSON)
+}
+
+func (h DisbursementTemplatesHandler) GetDisbursementTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	templateID := chi.URLParam(r, "id")
+
+	template, err := h.Models.DisbursementTemplates.Get(ctx, h.Models.DBConnectionPool, templateID)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound("disbursement template not found", err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot retrieve disbursement template", err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, template, httpjson.JSON)
+}
+
+// PatchDisbursementTemplate edits a template. Pausing and resuming is done through the status field, and the next run
+// is recalculated whenever the schedule changes or the template is resumed.
+func (h DisbursementTemplatesHandler) PatchDisbursementTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	templateID := chi.URLParam(r, "id")
+
+	var req PatchDisbursementTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest("invalid request body", err, nil).Render(w)
+		return
+	}
+
+	v := validators.NewValidator()
+	var status data.DisbursementTemplateStatus
+	if req.Status != "" {
+		var err error
+		status, err = data.ToDisbursementTemplateStatus(req.Status)
+		v.CheckError(err, "status", fmt.Sprintf("status must be one of %v", []data.DisbursementTemplateStatus{data.ActiveDisbursementTemplateStatus, data.PausedDisbursementTemplateStatus}))
+	}
+	if req.Schedule != "" {
+		validateTemplateSchedule(v, req.Schedule)
+	}
+	validateTemplateDisbursementStatus(v, req.DisbursementStatus)
+	v.CheckError(utils.ValidateNoHTML(req.ReceiverRegistrationMessageTemplate), "receiver_registration_message_template", "receiver_registration_message_template cannot contain HTML, JS or CSS")
+	if v.HasErrors() {
+		httperror.BadRequest("", nil, v.Errors).Render(w)
+		return
+	}
+
+	template, err := h.Models.DisbursementTemplates.Get(ctx, h.Models.DBConnectionPool, templateID)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound("disbursement template not found", err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot retrieve disbursement template", err, nil).Render(w)
+		return
+	}
+
+	update := data.DisbursementTemplateUpdate{
+		Name:                                req.Name,
+		Status:                              status,
+		ReceiverRegistrationMessageTemplate: req.ReceiverRegistrationMessageTemplate,
+		Schedule:                            req.Schedule,
+		DisbursementStatus:                  req.DisbursementStatus,
+	}
+	resumed := status == data.ActiveDisbursementTemplateStatus && template.Status != data.ActiveDisbursementTemplateStatus
+	if req.Schedule != "" || resumed {
+		schedule := template.Schedule
+		if req.Schedule != "" {
+			schedule = req.Schedule
+		}
+
+		var httpErr *httperror.HTTPError
+		if update.NextRunAt, httpErr = h.nextRunAt(r, schedule); httpErr != nil {
+			httpErr.Render(w)
+			return
+		}
+	}
+
+	template, err = h.Models.DisbursementTemplates.Update(ctx, h.Models.DBConnectionPool, templateID, update)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrMissingInput):
+			httperror.BadRequest("no fields to update", err, nil).Render(w)
+		case errors.Is(err, data.ErrDisbursementTemplateNameAlreadyExists):
+			httperror.Conflict(data.ErrDisbursementTemplateNameAlreadyExists.Error(), err, nil).Render(w)
+		default:
+			httperror.InternalError(ctx, "Cannot update disbursement template", err, nil).Render(w)
+		}
+		return
+	}
+
+	httpjson.Render(w, template, httpjson.JSON)
+}
+
+// PostDisbursementTemplateInstructions stores the instruction set cloned into each disbursement created from the
+// template. It accepts the same CSV file used in disbursements.
+func (h DisbursementTemplatesHandler) PostDisbursementTemplateInstructions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	templateID := chi.URLParam(r, "id")
+
+	template, err := h.Models.DisbursementTemplates.Get(ctx, h.Models.DBConnectionPool, templateID)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound("disbursement template not found", err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot retrieve disbursement template", err, nil).Render(w)
+		return
+	}
+
+	buf, header, httpErr := parseCsvFromMultipartRequest(r)
+	if httpErr != nil {
+		httpErr.Render(w)
+		return
+	}
+
+	if err = validateCSVHeaders(bytes.NewReader(buf.Bytes()), template.RegistrationContactType); err != nil {
+		errMsg := fmt.Sprintf("CSV columns are not valid for registration contact type %s: %s", template.RegistrationContactType, err)
+		httperror.BadRequest(errMsg, err, nil).Render(w)
+		return
+	}
+
+	instructions, v := parseInstructionsFromCSV(ctx, bytes.NewReader(buf.Bytes()), template.RegistrationContactType, template.VerificationField)
+	if v != nil && v.HasErrors() {
+		httperror.BadRequest("could not parse csv file", err, v.Errors).Render(w)
+		return
+	}
+	if len(instructions) == 0 {
+		httperror.BadRequest("the csv file has no instructions", nil, nil).Render(w)
+		return
+	}
+	if len(instructions) > data.MaxInstructionsPerDisbursement {
+		httperror.BadRequest(fmt.Sprintf("number of instructions exceeds maximum of %d", data.MaxInstructionsPerDisbursement), data.ErrMaxInstructionsExceeded, nil).Render(w)
+		return
+	}
+
+	_, err = h.Models.DisbursementTemplates.Update(ctx, h.Models.DBConnectionPool, templateID, data.DisbursementTemplateUpdate{
+		Instructions: instructions,
+		FileName:     header.Filename,
+		FileContent:  buf.Bytes(),
+	})
+	if err != nil {
+		httperror.InternalError(ctx, fmt.Sprintf("Cannot store instructions for disbursement template with ID %s", templateID), err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, map[string]string{"message": "File uploaded successfully"}, httpjson.JSON)
+}
@@ -0,0 +1,411 @@
+package httphandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/middleware"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
+)
+
+func Test_DisbursementTemplatesHandler_validatePostRequest(t *testing.T) {
+	handler := DisbursementTemplatesHandler{}
+
+	testCases := []struct {
+		name           string
+		request        PostDisbursementTemplateRequest
+		expectedErrors map[string]interface{}
+	}{
+		{
+			name:    "missing fields",
+			request: PostDisbursementTemplateRequest{},
+			expectedErrors: map[string]interface{}{
+				"name":                      "name is required",
+				"asset_id":                  "asset_id is required",
+				"registration_contact_type": fmt.Sprintf("registration_contact_type must be one of %v", data.AllRegistrationContactTypes()),
+				"schedule":                  "schedule must be a valid cron expression",
+				"verification_field":        fmt.Sprintf("verification_field must be one of %v", data.GetAllVerificationTypes()),
+				"wallet_id":                 "wallet_id is required",
+			},
+		},
+		{
+			name: "invalid schedule and disbursement status",
+			request: PostDisbursementTemplateRequest{
+				Name:                    "stipend",
+				WalletID:                "wallet-id",
+				AssetID:                 "asset-id",
+				VerificationField:       data.VerificationTypeDateOfBirth,
+				RegistrationContactType: data.RegistrationContactTypePhone,
+				Schedule:                "0 25 * * *",
+				DisbursementStatus:      data.StartedDisbursementStatus,
+			},
+			expectedErrors: map[string]interface{}{
+				"schedule":            "schedule must be a valid cron expression",
+				"disbursement_status": fmt.Sprintf("disbursement_status must be one of %v", templateDisbursementStatuses),
+			},
+		},
+		{
+			name: "🎉 valid request",
+			request: PostDisbursementTemplateRequest{
+				Name:                    "stipend",
+				WalletID:                "wallet-id",
+				AssetID:                 "asset-id",
+				VerificationField:       data.VerificationTypeDateOfBirth,
+				RegistrationContactType: data.RegistrationContactTypePhone,
+				Schedule:                "0 9 1 * *",
+				DisbursementStatus:      data.ReadyDisbursementStatus,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := handler.validatePostRequest(tc.request)
+			if len(tc.expectedErrors) == 0 {
+				assert.False(t, v.HasErrors())
+			} else {
+				assert.Equal(t, tc.expectedErrors, v.Errors)
+			}
+		})
+	}
+}
+
+func Test_DisbursementTemplatesHandler_PostDisbursementTemplate(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	token := "token"
+	ctx := context.WithValue(context.Background(), middleware.TokenContextKey, token)
+	user := &auth.User{ID: "user-id", Email: "email@email.com"}
+	authManagerMock := &auth.AuthManagerMock{}
+	authManagerMock.
+		On("GetUser", mock.Anything, token).
+		Return(user, nil)
+	defer authManagerMock.AssertExpectations(t)
+
+	handler := DisbursementTemplatesHandler{Models: models, AuthManager: authManagerMock}
+	r := chi.NewRouter()
+	r.Post("/disbursement-templates", handler.PostDisbursementTemplate)
+
+	wallet := data.CreateDefaultWalletFixture(t, ctx, dbConnectionPool)
+	asset := data.GetAssetFixture(t, ctx, dbConnectionPool, data.FixtureAssetUSDC)
+	disabledWallet := data.CreateWalletFixture(t, ctx, dbConnectionPool, "disabled wallet", "https://disabled.com", "disabled.com", "disabled://")
+	data.EnableOrDisableWalletFixtures(t, ctx, dbConnectionPool, false, disabledWallet.ID)
+
+	postTemplate := func(t *testing.T, body PostDisbursementTemplateRequest) *httptest.ResponseRecorder {
+		reqBody, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/disbursement-templates", bytes.NewReader(reqBody))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	validRequest := PostDisbursementTemplateRequest{
+		Name:                    "monthly stipend",
+		WalletID:                wallet.ID,
+		AssetID:                 asset.ID,
+		VerificationField:       data.VerificationTypeDateOfBirth,
+		RegistrationContactType: data.RegistrationContactTypePhone,
+		Schedule:                "0 9 1 * *",
+	}
+
+	t.Run("invalid request", func(t *testing.T) {
+		rr := postTemplate(t, PostDisbursementTemplateRequest{})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "schedule must be a valid cron expression")
+	})
+
+	t.Run("disabled wallet", func(t *testing.T) {
+		request := validRequest
+		request.WalletID = disabledWallet.ID
+		rr := postTemplate(t, request)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Wallet is not enabled")
+	})
+
+	t.Run("schedule that never runs", func(t *testing.T) {
+		request := validRequest
+		request.Schedule = "0 0 31 2 *"
+		rr := postTemplate(t, request)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "disbursement template schedule has no upcoming occurrences")
+	})
+
+	t.Run("🎉 creates a template", func(t *testing.T) {
+		rr := postTemplate(t, validRequest)
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		var template data.DisbursementTemplate
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &template))
+		assert.Equal(t, "monthly stipend", template.Name)
+		assert.Equal(t, data.ActiveDisbursementTemplateStatus, template.Status)
+		assert.Equal(t, data.DraftDisbursementStatus, template.DisbursementStatus)
+		assert.Equal(t, user.ID, template.CreatedBy)
+		require.NotNil(t, template.NextRunAt)
+		assert.True(t, template.NextRunAt.After(time.Now()))
+		assert.Equal(t, 1, template.NextRunAt.UTC().Day())
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		rr := postTemplate(t, validRequest)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), data.ErrDisbursementTemplateNameAlreadyExists.Error())
+	})
+}
+
+func Test_DisbursementTemplatesHandler_GetDisbursementTemplates(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	handler := DisbursementTemplatesHandler{Models: models}
+	r := chi.NewRouter()
+	r.Get("/disbursement-templates", handler.GetDisbursementTemplates)
+	r.Get("/disbursement-templates/{id}", handler.GetDisbursementTemplate)
+
+	template := data.CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, data.DisbursementTemplateInsert{Name: "stipend"}, data.DisbursementTemplateInstructions{
+		{Phone: "+380445555555", ID: "123456789", Amount: "100.5", VerificationValue: "1990-01-01"},
+	})
+
+	t.Run("🎉 lists the templates", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/disbursement-templates", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var templates []data.DisbursementTemplate
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &templates))
+		require.Len(t, templates, 1)
+		assert.Equal(t, template.ID, templates[0].ID)
+		assert.Equal(t, 1, templates[0].TotalInstructions)
+		// the instructions hold receivers' PII, so they're not returned
+		assert.NotContains(t, rr.Body.String(), "+380445555555")
+	})
+
+	t.Run("template not found", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/disbursement-templates/not-found", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("🎉 gets a template", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/disbursement-templates/"+template.ID, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var got data.DisbursementTemplate
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		assert.Equal(t, "stipend", got.Name)
+	})
+}
+
+func Test_DisbursementTemplatesHandler_PatchDisbursementTemplate(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	handler := DisbursementTemplatesHandler{Models: models}
+	r := chi.NewRouter()
+	r.Patch("/disbursement-templates/{id}", handler.PatchDisbursementTemplate)
+
+	pastRunAt := time.Now().Add(-24 * time.Hour)
+	template := data.CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, data.DisbursementTemplateInsert{
+		Name:      "stipend",
+		Schedule:  "@monthly",
+		NextRunAt: pastRunAt,
+	}, nil)
+
+	patchTemplate := func(t *testing.T, id, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPatch, "/disbursement-templates/"+id, strings.NewReader(body))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("invalid request", func(t *testing.T) {
+		rr := patchTemplate(t, template.ID, `{"status": "DELETED", "schedule": "never"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "status must be one of [ACTIVE PAUSED]")
+		assert.Contains(t, rr.Body.String(), "schedule must be a valid cron expression")
+	})
+
+	t.Run("template not found", func(t *testing.T) {
+		rr := patchTemplate(t, "not-found", `{"name": "new name"}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("no fields to update", func(t *testing.T) {
+		rr := patchTemplate(t, template.ID, `{}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "no fields to update")
+	})
+
+	t.Run("🎉 pauses the template", func(t *testing.T) {
+		rr := patchTemplate(t, template.ID, `{"status": "PAUSED"}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var got data.DisbursementTemplate
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		assert.Equal(t, data.PausedDisbursementTemplateStatus, got.Status)
+		assert.True(t, pastRunAt.Equal(*got.NextRunAt))
+	})
+
+	t.Run("🎉 resumes the template, recalculating its next run", func(t *testing.T) {
+		rr := patchTemplate(t, template.ID, `{"status": "ACTIVE"}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var got data.DisbursementTemplate
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		assert.Equal(t, data.ActiveDisbursementTemplateStatus, got.Status)
+		assert.True(t, got.NextRunAt.After(time.Now()))
+	})
+
+	t.Run("🎉 edits the template", func(t *testing.T) {
+		rr := patchTemplate(t, template.ID, `{"name": "weekly stipend", "schedule": "@weekly", "disbursement_status": "READY"}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var got data.DisbursementTemplate
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		assert.Equal(t, "weekly stipend", got.Name)
+		assert.Equal(t, "@weekly", got.Schedule)
+		assert.Equal(t, data.ReadyDisbursementStatus, got.DisbursementStatus)
+		assert.Equal(t, time.Sunday, got.NextRunAt.UTC().Weekday())
+	})
+}
+
+func Test_DisbursementTemplatesHandler_PostDisbursementTemplateInstructions(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	handler := DisbursementTemplatesHandler{Models: models}
+	r := chi.NewRouter()
+	r.Post("/disbursement-templates/{id}/instructions", handler.PostDisbursementTemplateInstructions)
+
+	template := data.CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, data.DisbursementTemplateInsert{}, nil)
+
+	testCases := []struct {
+		name            string
+		templateID      string
+		csvRecords      [][]string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:            "template not found",
+			templateID:      "not-found",
+			csvRecords:      [][]string{{"phone", "id", "amount", "verification"}},
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: "disbursement template not found",
+		},
+		{
+			name:            "invalid headers",
+			templateID:      template.ID,
+			csvRecords:      [][]string{{"email", "id", "amount", "verification"}, {"a@b.com", "1", "10", "1990-01-01"}},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "CSV columns are not valid for registration contact type PHONE_NUMBER",
+		},
+		{
+			name:            "no instructions",
+			templateID:      template.ID,
+			csvRecords:      [][]string{{"phone", "id", "amount", "verification"}},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "the csv file has no instructions",
+		},
+		{
+			name:       "🎉 stores the instructions",
+			templateID: template.ID,
+			csvRecords: [][]string{
+				{"phone", "id", "amount", "verification"},
+				{"+380445555555", "123456789", "100.5", "1990-01-01"},
+				{"+380445555556", "123456780", "50", "1990-01-02"},
+			},
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "File uploaded successfully",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fileContent, err := createCSVFile(t, tc.csvRecords)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			writer := multipart.NewWriter(&buf)
+			part, err := writer.CreateFormFile("file", "instructions.csv")
+			require.NoError(t, err)
+			_, err = io.Copy(part, fileContent)
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("/disbursement-templates/%s/instructions", tc.templateID), &buf)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tc.expectedMessage)
+		})
+	}
+
+	updatedTemplate, err := models.DisbursementTemplates.Get(ctx, dbConnectionPool, template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updatedTemplate.TotalInstructions)
+	assert.Equal(t, "instructions.csv", updatedTemplate.FileName)
+	assert.Equal(t, "+380445555556", updatedTemplate.Instructions[1].Phone)
+}
@@ -1,12 +1,10 @@
 package httphandler
 
 import (
-	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
-	"github.com/gocarina/gocsv"
-
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/validators"
@@ -16,11 +14,68 @@ type ExportHandler struct {
 	Models *data.Models
 }
 
+// exportFormat is the file format of an export, chosen with the `format` query parameter. Exports are CSV by default.
+type exportFormat string
+
+const (
+	csvExportFormat  exportFormat = "csv"
+	xlsxExportFormat exportFormat = "xlsx"
+)
+
+// parseExportFormat returns the format requested for an export, adding an error to the validator if it's not supported.
+func parseExportFormat(r *http.Request, validator *validators.Validator) exportFormat {
+	format := exportFormat(strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format"))))
+	switch format {
+	case "":
+		return csvExportFormat
+	case csvExportFormat, xlsxExportFormat:
+		return format
+	default:
+		validator.AddError("format", "invalid format. valid values are 'csv' and 'xlsx'")
+		return ""
+	}
+}
+
+// The columns of the XLSX exports written as numbers and dates, named after the CSV columns.
+var (
+	disbursementXLSXCellTypes = map[string]xlsxCellType{
+		"MaxSlippageBps":     xlsxNumberCell,
+		"TotalPayments":      xlsxNumberCell,
+		"SuccessfulPayments": xlsxNumberCell,
+		"FailedPayments":     xlsxNumberCell,
+		"CanceledPayments":   xlsxNumberCell,
+		"RemainingPayments":  xlsxNumberCell,
+		"AmountDisbursed":    xlsxNumberCell,
+		"TotalAmount":        xlsxNumberCell,
+		"AverageAmount":      xlsxNumberCell,
+		"CreatedAt":          xlsxDateCell,
+		"UpdatedAt":          xlsxDateCell,
+		"ScheduledStartAt":   xlsxDateCell,
+	}
+	paymentXLSXCellTypes = map[string]xlsxCellType{
+		"Amount":    xlsxNumberCell,
+		"CreatedAt": xlsxDateCell,
+		"UpdatedAt": xlsxDateCell,
+	}
+	receiverXLSXCellTypes = map[string]xlsxCellType{
+		"TotalPayments":      xlsxNumberCell,
+		"SuccessfulPayments": xlsxNumberCell,
+		"FailedPayments":     xlsxNumberCell,
+		"CanceledPayments":   xlsxNumberCell,
+		"RemainingPayments":  xlsxNumberCell,
+		"RegisteredWallets":  xlsxNumberCell,
+		"CreatedAt":          xlsxDateCell,
+		"UpdatedAt":          xlsxDateCell,
+	}
+)
+
 func (e ExportHandler) ExportDisbursements(rw http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	validator := validators.NewDisbursementQueryValidator()
 	queryParams := validator.ParseParametersFromRequest(r)
+	format := parseExportFormat(r, validator.Validator)
+	columns := parseExportFields[data.Disbursement](r, validator.Validator)
 
 	if validator.HasErrors() {
 		httperror.BadRequest("Request invalid", nil, validator.Errors).Render(rw)
@@ -33,20 +88,9 @@ func (e ExportHandler) ExportDisbursements(rw http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	disbursements, err := e.Models.Disbursements.GetAll(ctx, e.Models.DBConnectionPool, queryParams, data.QueryTypeSelectAll)
-	if err != nil {
-		httperror.InternalError(ctx, "Failed to get disbursements", err, nil).Render(rw)
-		return
-	}
-
-	fileName := fmt.Sprintf("disbursements_%s.csv", time.Now().Format("2006-01-02-15-04-05"))
-	rw.Header().Set("Content-Type", "text/csv")
-	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
-
-	if err := gocsv.Marshal(disbursements, rw); err != nil {
-		httperror.InternalError(ctx, "Failed to write CSV", err, nil).Render(rw)
-		return
-	}
+	streamExport(ctx, rw, "disbursements", format, columns, disbursementXLSXCellTypes, func(write func(*data.Disbursement) error) error {
+		return e.Models.Disbursements.StreamAll(ctx, e.Models.DBConnectionPool, queryParams, write)
+	})
 }
 
 type PaymentCSV struct {
@@ -66,11 +110,32 @@ type PaymentCSV struct {
 	CircleTransferRequestID *string
 }
 
+func newPaymentCSV(payment data.Payment) *PaymentCSV {
+	return &PaymentCSV{
+		ID:                      payment.ID,
+		Amount:                  payment.Amount,
+		StellarTransactionID:    payment.StellarTransactionID,
+		Status:                  payment.Status,
+		DisbursementID:          payment.Disbursement.ID,
+		Asset:                   payment.Asset,
+		Wallet:                  payment.ReceiverWallet.Wallet,
+		ReceiverID:              payment.ReceiverWallet.Receiver.ID,
+		ReceiverWalletAddress:   payment.ReceiverWallet.StellarAddress,
+		ReceiverWalletStatus:    payment.ReceiverWallet.Status,
+		CreatedAt:               payment.CreatedAt,
+		UpdatedAt:               payment.UpdatedAt,
+		ExternalPaymentID:       payment.ExternalPaymentID,
+		CircleTransferRequestID: payment.CircleTransferRequestID,
+	}
+}
+
 func (e ExportHandler) ExportPayments(rw http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	validator := validators.NewPaymentQueryValidator()
 	queryParams := validator.ParseParametersFromRequest(r)
+	format := parseExportFormat(r, validator.Validator)
+	columns := parseExportFields[PaymentCSV](r, validator.Validator)
 
 	if validator.HasErrors() {
 		httperror.BadRequest("Request invalid", nil, validator.Errors).Render(rw)
@@ -83,42 +148,11 @@ func (e ExportHandler) ExportPayments(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	payments, err := e.Models.Payment.GetAll(ctx, queryParams, e.Models.DBConnectionPool, data.QueryTypeSelectAll)
-	if err != nil {
-		httperror.InternalError(ctx, "Failed to get payments", err, nil).Render(rw)
-		return
-	}
-
-	// Convert payments to PaymentCSV
-	paymentCSVs := make([]*PaymentCSV, 0, len(payments))
-	for _, payment := range payments {
-		paymentCSV := &PaymentCSV{
-			ID:                      payment.ID,
-			Amount:                  payment.Amount,
-			StellarTransactionID:    payment.StellarTransactionID,
-			Status:                  payment.Status,
-			DisbursementID:          payment.Disbursement.ID,
-			Asset:                   payment.Asset,
-			Wallet:                  payment.ReceiverWallet.Wallet,
-			ReceiverID:              payment.ReceiverWallet.Receiver.ID,
-			ReceiverWalletAddress:   payment.ReceiverWallet.StellarAddress,
-			ReceiverWalletStatus:    payment.ReceiverWallet.Status,
-			CreatedAt:               payment.CreatedAt,
-			UpdatedAt:               payment.UpdatedAt,
-			ExternalPaymentID:       payment.ExternalPaymentID,
-			CircleTransferRequestID: payment.CircleTransferRequestID,
-		}
-		paymentCSVs = append(paymentCSVs, paymentCSV)
-	}
-
-	fileName := fmt.Sprintf("payments_%s.csv", time.Now().Format("2006-01-02-15-04-05"))
-	rw.Header().Set("Content-Type", "text/csv")
-	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
-
-	if err := gocsv.Marshal(paymentCSVs, rw); err != nil {
-		httperror.InternalError(ctx, "Failed to write CSV", err, nil).Render(rw)
-		return
-	}
+	streamExport(ctx, rw, "payments", format, columns, paymentXLSXCellTypes, func(write func(*PaymentCSV) error) error {
+		return e.Models.Payment.StreamAll(ctx, queryParams, e.Models.DBConnectionPool, func(payment data.Payment) error {
+			return write(newPaymentCSV(payment))
+		})
+	})
 }
 
 func (e ExportHandler) ExportReceivers(rw http.ResponseWriter, r *http.Request) {
@@ -126,6 +160,8 @@ func (e ExportHandler) ExportReceivers(rw http.ResponseWriter, r *http.Request)
 
 	validator := validators.NewReceiverQueryValidator()
 	queryParams := validator.ParseParametersFromRequest(r)
+	format := parseExportFormat(r, validator.Validator)
+	columns := parseExportFields[data.Receiver](r, validator.Validator)
 	if validator.HasErrors() {
 		httperror.BadRequest("Request invalid", nil, validator.Errors).Render(rw)
 		return
@@ -137,18 +173,9 @@ func (e ExportHandler) ExportReceivers(rw http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	receivers, err := e.Models.Receiver.GetAll(ctx, e.Models.DBConnectionPool, queryParams, data.QueryTypeSelectAll)
-	if err != nil {
-		httperror.InternalError(ctx, "Failed to get receivers", err, nil).Render(rw)
-		return
-	}
-
-	fileName := fmt.Sprintf("receivers_%s.csv", time.Now().Format("2006-01-02-15-04-05"))
-	rw.Header().Set("Content-Type", "text/csv")
-	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
-
-	if err := gocsv.Marshal(receivers, rw); err != nil {
-		httperror.InternalError(ctx, "Failed to write CSV", err, nil).Render(rw)
-		return
-	}
+	streamExport(ctx, rw, "receivers", format, columns, receiverXLSXCellTypes, func(write func(*data.Receiver) error) error {
+		return e.Models.Receiver.StreamAll(ctx, e.Models.DBConnectionPool, queryParams, func(receiver data.Receiver) error {
+			return write(&receiver)
+		})
+	})
 }
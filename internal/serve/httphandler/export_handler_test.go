@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
 
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
 	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
@@ -131,6 +133,66 @@ func Test_ExportHandler_ExportDisbursements(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("success - returns XLSX with typed cells", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/exports/disbursements?format=xlsx&status=started", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, xlsxContentType, rr.Header().Get("Content-Type"))
+		today := time.Now().Format("2006-01-02")
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), fmt.Sprintf("attachment; filename=disbursements_%s", today))
+		assert.True(t, strings.HasSuffix(rr.Header().Get("Content-Disposition"), ".xlsx"))
+
+		f, err := excelize.OpenReader(rr.Body)
+		require.NoError(t, err)
+		defer f.Close()
+		sheet := f.GetSheetName(0)
+
+		rows, err := f.GetRows(sheet)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, "Name", rows[0][1])
+		assert.Equal(t, disbursement1.Name, rows[1][1])
+		assert.Equal(t, "2023-03-21 23:40:20", rows[1][slices.Index(rows[0], "CreatedAt")])
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/exports/disbursements?format=pdf", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error": "Request invalid", "extras": {"format": "invalid format. valid values are 'csv' and 'xlsx'"}}`, rr.Body.String())
+	})
+
+	t.Run("success - returns CSV with the selected fields", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/exports/disbursements?fields=status,Name&status=started", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		rows, err := csv.NewReader(strings.NewReader(rr.Body.String())).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"Status", "Name"},
+			{string(disbursement1.Status), disbursement1.Name},
+		}, rows)
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/exports/disbursements?fields=Name,FileContent", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "invalid fields FileContent. valid fields are ID, Name,")
+	})
 }
 
 func Test_ExportHandler_ExportPayments(t *testing.T) {
@@ -0,0 +1,276 @@
+package httphandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/stellar/go/support/log"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/validators"
+)
+
+// exportBatchSize is the number of records marshaled and flushed to the response at once.
+const exportBatchSize = 500
+
+// exportWriteTimeout is how long an export has to write each batch of records. The write deadline is extended after
+// every batch, so the server WriteTimeout doesn't cut off exports that take longer than it to stream.
+const exportWriteTimeout = 35 * time.Second
+
+// exportColumns returns the columns of the export of records of type T, named after their CSV headers.
+func exportColumns[T any]() []string {
+	recorder := &csvHeaderRecorder{}
+	// Marshaling no records only writes the header, and it can only fail if T isn't a struct.
+	if err := gocsv.MarshalCSV([]*T{}, recorder); err != nil {
+		return nil
+	}
+	return recorder.header
+}
+
+// csvHeaderRecorder is a gocsv.CSVWriter that keeps the last row written to it.
+type csvHeaderRecorder struct {
+	header []string
+}
+
+var _ gocsv.CSVWriter = (*csvHeaderRecorder)(nil)
+
+func (r *csvHeaderRecorder) Write(row []string) error {
+	r.header = slices.Clone(row)
+	return nil
+}
+
+func (r *csvHeaderRecorder) Flush() {}
+
+func (r *csvHeaderRecorder) Error() error {
+	return nil
+}
+
+// parseExportFields returns the indexes of the columns selected for an export of records of type T with the `fields`
+// query parameter, in the order they were requested, adding an error to the validator if any of them doesn't exist.
+// Fields are matched case-insensitively against the column names, and nil is returned when all the columns are
+// exported.
+func parseExportFields[T any](r *http.Request, validator *validators.Validator) []int {
+	fieldsParam := strings.TrimSpace(r.URL.Query().Get("fields"))
+	if fieldsParam == "" {
+		return nil
+	}
+
+	columns := exportColumns[T]()
+	indexes := []int{}
+	invalidFields := []string{}
+	for _, field := range strings.Split(fieldsParam, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		index := slices.IndexFunc(columns, func(column string) bool {
+			return strings.EqualFold(column, field)
+		})
+		switch {
+		case index == -1:
+			invalidFields = append(invalidFields, field)
+		case !slices.Contains(indexes, index):
+			indexes = append(indexes, index)
+		}
+	}
+
+	if len(invalidFields) > 0 {
+		validator.AddError("fields", fmt.Sprintf("invalid fields %s. valid fields are %s", strings.Join(invalidFields, ", "), strings.Join(columns, ", ")))
+		return nil
+	}
+	if len(indexes) == 0 {
+		validator.AddError("fields", "at least one field should be selected")
+		return nil
+	}
+
+	return indexes
+}
+
+// columnsWriter writes only the selected columns of the rows marshaled by gocsv, or all of them if none is selected.
+type columnsWriter struct {
+	gocsv.CSVWriter
+	columns []int
+	row     []string
+}
+
+func (w *columnsWriter) Write(row []string) error {
+	if w.columns == nil {
+		return w.CSVWriter.Write(row)
+	}
+
+	w.row = w.row[:0]
+	for _, column := range w.columns {
+		w.row = append(w.row, row[column])
+	}
+	return w.CSVWriter.Write(w.row)
+}
+
+// exportWriter writes the records of an export in batches as they're passed to Write, so the export is never loaded
+// into memory at once. CSV exports are flushed to the client after each batch. XLSX rows are handed to the excelize
+// stream writer, which moves them to a temporary file once they grow too large, and the file is written when the
+// writer is closed, since it can't be zipped before its last row.
+type exportWriter[T any] struct {
+	rw       http.ResponseWriter
+	rc       *http.ResponseController
+	fileName string
+	format   exportFormat
+	out      gocsv.CSVWriter
+	batch    []*T
+	// xlsxFile and xlsxSheet are only set for XLSX exports.
+	xlsxFile      *excelize.File
+	xlsxSheet     *xlsxSheetWriter
+	headerWritten bool
+	// started is whether the response was started, after which errors can't be rendered anymore.
+	started bool
+}
+
+// newExportWriter creates a writer of an export named after the export and the current time, with the selected columns
+// of the records. The columns in cellTypes are written as typed cells in XLSX exports.
+func newExportWriter[T any](rw http.ResponseWriter, name string, format exportFormat, columns []int, cellTypes map[string]xlsxCellType) (*exportWriter[T], error) {
+	w := &exportWriter[T]{
+		rw:       rw,
+		rc:       http.NewResponseController(rw),
+		fileName: fmt.Sprintf("%s_%s.%s", name, time.Now().Format("2006-01-02-15-04-05"), format),
+		format:   format,
+		batch:    make([]*T, 0, exportBatchSize),
+	}
+
+	if format != xlsxExportFormat {
+		w.out = &columnsWriter{CSVWriter: gocsv.DefaultCSVWriter(rw), columns: columns}
+		return w, nil
+	}
+
+	w.xlsxFile = excelize.NewFile()
+	sheetWriter, err := newXLSXSheetWriter(w.xlsxFile, cellTypes)
+	if err != nil {
+		_ = w.xlsxFile.Close()
+		return nil, fmt.Errorf("creating xlsx sheet writer: %w", err)
+	}
+	w.xlsxSheet = sheetWriter
+	w.out = &columnsWriter{CSVWriter: sheetWriter, columns: columns}
+
+	return w, nil
+}
+
+// Write adds a record to the export, writing the current batch once it's full.
+func (w *exportWriter[T]) Write(record *T) error {
+	w.batch = append(w.batch, record)
+	if len(w.batch) < exportBatchSize {
+		return nil
+	}
+	return w.writeBatch()
+}
+
+func (w *exportWriter[T]) writeBatch() error {
+	if err := w.extendWriteDeadline(); err != nil {
+		return err
+	}
+	if w.format == csvExportFormat && !w.started {
+		w.setHeaders("text/csv")
+	}
+
+	var err error
+	if w.headerWritten {
+		err = gocsv.MarshalCSVWithoutHeaders(w.batch, w.out)
+	} else {
+		err = gocsv.MarshalCSV(w.batch, w.out)
+		w.headerWritten = true
+	}
+	if err != nil {
+		return fmt.Errorf("marshaling records: %w", err)
+	}
+	w.batch = w.batch[:0]
+
+	if flusher, ok := w.rw.(http.Flusher); ok && w.format == csvExportFormat {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// Close writes the records left in the current batch, and the XLSX file for XLSX exports. The header is written even
+// if there are no records.
+func (w *exportWriter[T]) Close() error {
+	if len(w.batch) > 0 || !w.headerWritten {
+		if err := w.writeBatch(); err != nil {
+			return err
+		}
+	}
+
+	if w.xlsxFile == nil {
+		return nil
+	}
+	if err := w.xlsxSheet.Close(); err != nil {
+		return fmt.Errorf("closing xlsx sheet: %w", err)
+	}
+	if err := w.extendWriteDeadline(); err != nil {
+		return err
+	}
+	w.setHeaders(xlsxContentType)
+	if err := w.xlsxFile.Write(w.rw); err != nil {
+		return fmt.Errorf("writing xlsx file: %w", err)
+	}
+	return nil
+}
+
+// extendWriteDeadline gives the export exportWriteTimeout more to write its response. Response writers that don't
+// support deadlines, like the ones used in tests, are left as they are.
+func (w *exportWriter[T]) extendWriteDeadline() error {
+	err := w.rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("extending write deadline: %w", err)
+	}
+	return nil
+}
+
+func (w *exportWriter[T]) setHeaders(contentType string) {
+	w.rw.Header().Set("Content-Type", contentType)
+	w.rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", w.fileName))
+	w.started = true
+}
+
+// release removes the temporary files of the XLSX exports.
+func (w *exportWriter[T]) release(ctx context.Context) {
+	if w.xlsxFile == nil {
+		return
+	}
+	if err := w.xlsxFile.Close(); err != nil {
+		log.Ctx(ctx).Errorf("closing xlsx export %s: %v", w.fileName, err)
+	}
+	w.xlsxFile = nil
+}
+
+// streamExport writes an export with the records passed to write by stream, usually as they're read from a database
+// cursor. Errors are rendered as long as nothing was written to the response. After that, the status can't be changed
+// anymore, so the error is logged and the connection is aborted, letting the client know the export is incomplete
+// instead of ending it as if it was successful.
+func streamExport[T any](ctx context.Context, rw http.ResponseWriter, name string, format exportFormat, columns []int, cellTypes map[string]xlsxCellType, stream func(write func(*T) error) error) {
+	w, err := newExportWriter[T](rw, name, format, columns, cellTypes)
+	if err != nil {
+		httperror.InternalError(ctx, fmt.Sprintf("Failed to export %s", name), err, nil).Render(rw)
+		return
+	}
+	defer w.release(ctx)
+
+	err = stream(w.Write)
+	if err == nil {
+		err = w.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if !w.started {
+		httperror.InternalError(ctx, fmt.Sprintf("Failed to export %s", name), err, nil).Render(rw)
+		return
+	}
+	log.Ctx(ctx).Errorf("export %s was interrupted: %v", w.fileName, err)
+	panic(http.ErrAbortHandler)
+}
@@ -0,0 +1,276 @@
+package httphandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/support/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/validators"
+)
+
+type exportTestRecord struct {
+	Name      string
+	Amount    string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func Test_exportColumns(t *testing.T) {
+	assert.Equal(t, []string{"Name", "Amount", "CreatedAt", "UpdatedAt"}, exportColumns[exportTestRecord]())
+	assert.Contains(t, exportColumns[PaymentCSV](), "Receiver.ID")
+}
+
+func Test_parseExportFields(t *testing.T) {
+	testCases := []struct {
+		name        string
+		fields      string
+		wantColumns []int
+		wantErrors  map[string]interface{}
+	}{
+		{
+			name:        "no fields exports all the columns",
+			fields:      "",
+			wantColumns: nil,
+		},
+		{
+			name:        "fields are matched case-insensitively in the requested order",
+			fields:      "createdat, Name",
+			wantColumns: []int{2, 0},
+		},
+		{
+			name:        "repeated fields are only exported once",
+			fields:      "Name,Amount,name",
+			wantColumns: []int{0, 1},
+		},
+		{
+			name:   "invalid fields",
+			fields: "Name,Email,Phone",
+			wantErrors: map[string]interface{}{
+				"fields": "invalid fields Email, Phone. valid fields are Name, Amount, CreatedAt, UpdatedAt",
+			},
+		},
+		{
+			name:       "empty fields",
+			fields:     " , ",
+			wantErrors: map[string]interface{}{"fields": "at least one field should be selected"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/exports?fields="+strings.ReplaceAll(tc.fields, " ", "%20"), nil)
+			validator := validators.NewValidator()
+
+			columns := parseExportFields[exportTestRecord](r, validator)
+			assert.Equal(t, tc.wantColumns, columns)
+			if tc.wantErrors == nil {
+				assert.False(t, validator.HasErrors())
+			} else {
+				assert.Equal(t, tc.wantErrors, validator.Errors)
+			}
+		})
+	}
+}
+
+func Test_streamExport_csv(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2024, 3, 21, 23, 40, 20, 0, time.UTC)
+
+	t.Run("records are written in batches with the selected columns", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		recordsCount := exportBatchSize*2 + 1
+		streamExport(ctx, rr, "records", csvExportFormat, []int{1, 0}, nil, func(write func(*exportTestRecord) error) error {
+			for i := 0; i < recordsCount; i++ {
+				if err := write(&exportTestRecord{Name: fmt.Sprintf("record %d", i), Amount: "1.5", CreatedAt: createdAt}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, rr.Flushed)
+		assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), "attachment; filename=records_")
+		assert.True(t, strings.HasSuffix(rr.Header().Get("Content-Disposition"), ".csv"))
+
+		rows, err := csv.NewReader(rr.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, recordsCount+1)
+		assert.Equal(t, []string{"Amount", "Name"}, rows[0])
+		assert.Equal(t, []string{"1.5", "record 0"}, rows[1])
+		assert.Equal(t, []string{"1.5", fmt.Sprintf("record %d", recordsCount-1)}, rows[recordsCount])
+	})
+
+	t.Run("the header is written when there are no records", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		streamExport(ctx, rr, "records", csvExportFormat, nil, nil, func(write func(*exportTestRecord) error) error {
+			return nil
+		})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "Name,Amount,CreatedAt,UpdatedAt\n", rr.Body.String())
+	})
+
+	t.Run("errors before the response is started are rendered", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		streamExport(ctx, rr, "records", csvExportFormat, nil, nil, func(write func(*exportTestRecord) error) error {
+			if err := write(&exportTestRecord{Name: "record"}); err != nil {
+				return err
+			}
+			return errors.New("connection lost")
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.JSONEq(t, `{"error": "Failed to export records"}`, rr.Body.String())
+		assert.NotContains(t, rr.Header().Get("Content-Disposition"), "attachment")
+	})
+
+	t.Run("errors after the response is started are logged and abort the response", func(t *testing.T) {
+		getEntries := log.DefaultLogger.StartTest(log.ErrorLevel)
+
+		rr := httptest.NewRecorder()
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			streamExport(ctx, rr, "records", csvExportFormat, nil, nil, func(write func(*exportTestRecord) error) error {
+				for i := 0; i < exportBatchSize; i++ {
+					if err := write(&exportTestRecord{Name: "record"}); err != nil {
+						return err
+					}
+				}
+				return errors.New("connection lost")
+			})
+		})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		rows, err := csv.NewReader(rr.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Len(t, rows, exportBatchSize+1)
+
+		entries := getEntries()
+		require.Len(t, entries, 1)
+		assert.Contains(t, entries[0].Message, "was interrupted: connection lost")
+	})
+}
+
+// deadlineRecorder is a response recorder that keeps the write deadlines set through an http.ResponseController.
+type deadlineRecorder struct {
+	*httptest.ResponseRecorder
+	deadlines []time.Time
+}
+
+func (r *deadlineRecorder) SetWriteDeadline(deadline time.Time) error {
+	r.deadlines = append(r.deadlines, deadline)
+	return nil
+}
+
+func Test_streamExport_extendsWriteDeadline(t *testing.T) {
+	ctx := context.Background()
+
+	for _, format := range []exportFormat{csvExportFormat, xlsxExportFormat} {
+		t.Run(string(format), func(t *testing.T) {
+			rr := &deadlineRecorder{ResponseRecorder: httptest.NewRecorder()}
+			startedAt := time.Now()
+			streamExport(ctx, rr, "records", format, nil, nil, func(write func(*exportTestRecord) error) error {
+				for i := 0; i < exportBatchSize*2+1; i++ {
+					if err := write(&exportTestRecord{Name: "record"}); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			wantDeadlines := 3
+			if format == xlsxExportFormat {
+				// the deadline is extended once more before writing the XLSX file
+				wantDeadlines = 4
+			}
+			require.Len(t, rr.deadlines, wantDeadlines)
+			for _, deadline := range rr.deadlines {
+				assert.False(t, deadline.Before(startedAt.Add(exportWriteTimeout)))
+			}
+		})
+	}
+}
+
+func Test_streamExport_xlsx(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2024, 3, 21, 23, 40, 20, 0, time.UTC)
+	records := []*exportTestRecord{
+		{Name: "first", Amount: "100.5", CreatedAt: createdAt},
+		{Name: "second", Amount: "invalid", CreatedAt: createdAt},
+	}
+	cellTypes := map[string]xlsxCellType{
+		"Amount":    xlsxNumberCell,
+		"CreatedAt": xlsxDateCell,
+		"UpdatedAt": xlsxDateCell,
+	}
+
+	rr := httptest.NewRecorder()
+	streamExport(ctx, rr, "records", xlsxExportFormat, nil, cellTypes, func(write func(*exportTestRecord) error) error {
+		for _, record := range records {
+			if err := write(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, xlsxContentType, rr.Header().Get("Content-Type"))
+	assert.True(t, strings.HasSuffix(rr.Header().Get("Content-Disposition"), ".xlsx"))
+
+	f, err := excelize.OpenReader(bytes.NewReader(rr.Body.Bytes()))
+	require.NoError(t, err)
+	defer f.Close()
+	sheet := f.GetSheetName(0)
+
+	rows, err := f.GetRows(sheet)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, []string{"Name", "Amount", "CreatedAt", "UpdatedAt"}, rows[0])
+	assert.Equal(t, []string{"first", "100.5", "2024-03-21 23:40:20"}, rows[1])
+	assert.Equal(t, []string{"second", "invalid", "2024-03-21 23:40:20"}, rows[2])
+
+	// Numbers and dates are written without a cell type, while the values that can't be converted are kept as text.
+	cellType, err := f.GetCellType(sheet, "B2")
+	require.NoError(t, err)
+	assert.Equal(t, excelize.CellTypeUnset, cellType)
+	cellType, err = f.GetCellType(sheet, "B3")
+	require.NoError(t, err)
+	assert.Equal(t, excelize.CellTypeInlineString, cellType)
+
+	createdAtSerial, err := f.GetCellValue(sheet, "C2", excelize.Options{RawCellValue: true})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(createdAtSerial, "45372.98634259"), createdAtSerial)
+
+	t.Run("the selected columns keep their cell types", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		streamExport(ctx, rr, "records", xlsxExportFormat, []int{1}, cellTypes, func(write func(*exportTestRecord) error) error {
+			return write(records[0])
+		})
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		f, err := excelize.OpenReader(bytes.NewReader(rr.Body.Bytes()))
+		require.NoError(t, err)
+		defer f.Close()
+
+		rows, err := f.GetRows(f.GetSheetName(0))
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"Amount"}, {"100.5"}}, rows)
+		cellType, err := f.GetCellType(f.GetSheetName(0), "A2")
+		require.NoError(t, err)
+		assert.Equal(t, excelize.CellTypeUnset, cellType)
+	})
+}
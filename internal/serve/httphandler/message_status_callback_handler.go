@@ -0,0 +1,168 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/support/render/httpjson"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
+)
+
+// MessageStatusCallbackHandler receives the delivery status the messaging providers report for the messages sent by
+// the tenant.
+type MessageStatusCallbackHandler struct {
+	DeliveryStatusService services.MessageDeliveryStatusServiceInterface
+	SNSMessageVerifier    message.AWSSNSMessageVerifierInterface
+	ClientConfigModel     message.ClientConfigModelInterface
+	EncryptionPassphrase  string
+	// TwilioAuthToken is the auth token of the Twilio account configured for the whole instance.
+	TwilioAuthToken string
+	// AWSSESNotificationTopicARN is the SNS topic of the SES events configured for the whole instance.
+	AWSSESNotificationTopicARN string
+}
+
+// PostTwilio handles the status callbacks Twilio sends for the SMS and WhatsApp messages.
+func (h MessageStatusCallbackHandler) PostTwilio(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	currentTenant, err := tenant.GetTenantFromContext(ctx)
+	if err != nil || currentTenant.BaseURL == nil {
+		httperror.InternalError(ctx, "Cannot retrieve the tenant from the context", err, nil).Render(w)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		httperror.BadRequest("Cannot read the request body", err, nil).Render(w)
+		return
+	}
+
+	// Twilio signs the request with the exact URL the message was sent with.
+	messageID := r.URL.Query().Get("message_id")
+	callbackURL, err := message.TwilioStatusCallbackURL(*currentTenant.BaseURL, messageID)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot build the status callback URL", err, nil).Render(w)
+		return
+	}
+
+	signature := r.Header.Get(message.TwilioSignatureHeader)
+	if !message.ValidateTwilioSignature(h.twilioAuthTokens(ctx), callbackURL, body, signature) {
+		httperror.Forbidden("Invalid Twilio signature", nil, nil).Render(w)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		httperror.BadRequest("The request was invalid in some way.", err, nil).Render(w)
+		return
+	}
+
+	update, err := message.ParseTwilioStatusCallback(messageID, form)
+	if err != nil {
+		httperror.BadRequest("The request was invalid in some way.", err, nil).Render(w)
+		return
+	}
+
+	h.updateDeliveryStatus(ctx, w, update)
+}
+
+// twilioAuthTokens returns the auth tokens of the Twilio accounts the tenant's messages can be sent through.
+func (h MessageStatusCallbackHandler) twilioAuthTokens(ctx context.Context) []string {
+	authTokens := []string{h.TwilioAuthToken}
+	for _, channel := range []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelWhatsApp} {
+		opts, err := h.ClientConfigModel.GetDecryptedOptions(ctx, channel, h.EncryptionPassphrase)
+		if err != nil {
+			log.Ctx(ctx).Errorf("Getting the tenant messenger client config for channel %q: %v", channel, err)
+			continue
+		}
+		if opts != nil {
+			authTokens = append(authTokens, opts.TwilioAuthToken)
+		}
+	}
+
+	return authTokens
+}
+
+// PostAWSSES handles the SES event notifications SNS publishes for the emails. The SNS subscription is confirmed
+// automatically.
+func (h MessageStatusCallbackHandler) PostAWSSES(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var snsMessage message.AWSSNSMessage
+	if err := json.NewDecoder(r.Body).Decode(&snsMessage); err != nil {
+		httperror.BadRequest("The request was invalid in some way.", err, nil).Render(w)
+		return
+	}
+
+	// Anyone can subscribe a topic they own to this endpoint, so only the configured topics are trusted.
+	if !slices.Contains(h.awsSESNotificationTopicARNs(ctx), snsMessage.TopicArn) {
+		httperror.Forbidden("SNS topic is not allowed", nil, nil).Render(w)
+		return
+	}
+
+	if err := h.SNSMessageVerifier.Verify(snsMessage); err != nil {
+		httperror.Forbidden("Invalid SNS message signature", err, nil).Render(w)
+		return
+	}
+
+	switch snsMessage.Type {
+	case message.AWSSNSMessageTypeSubscriptionConfirmation:
+		if err := h.SNSMessageVerifier.ConfirmSubscription(snsMessage); err != nil {
+			httperror.InternalError(ctx, "Cannot confirm the SNS subscription", err, nil).Render(w)
+			return
+		}
+		log.Ctx(ctx).Infof("Confirmed the SNS subscription to topic %s", snsMessage.TopicArn)
+
+	case message.AWSSNSMessageTypeNotification:
+		update, err := message.ParseAWSSESNotification(snsMessage.Message)
+		if err != nil {
+			httperror.BadRequest("The request was invalid in some way.", err, nil).Render(w)
+			return
+		}
+		h.updateDeliveryStatus(ctx, w, update)
+		return
+
+	default:
+		log.Ctx(ctx).Debugf("Ignoring the SNS message of type %q", snsMessage.Type)
+	}
+
+	httpjson.RenderStatus(w, http.StatusOK, map[string]string{"message": "ok"}, httpjson.JSON)
+}
+
+// awsSESNotificationTopicARNs returns the SNS topics the SES events of the tenant's emails can be published to.
+func (h MessageStatusCallbackHandler) awsSESNotificationTopicARNs(ctx context.Context) []string {
+	var topicARNs []string
+	if h.AWSSESNotificationTopicARN != "" {
+		topicARNs = append(topicARNs, h.AWSSESNotificationTopicARN)
+	}
+
+	opts, err := h.ClientConfigModel.GetDecryptedOptions(ctx, message.MessageChannelEmail, h.EncryptionPassphrase)
+	if err != nil {
+		log.Ctx(ctx).Errorf("Getting the tenant messenger client config for channel %q: %v", message.MessageChannelEmail, err)
+	} else if opts != nil && opts.AWSSESNotificationTopicARN != "" {
+		topicARNs = append(topicARNs, opts.AWSSESNotificationTopicARN)
+	}
+
+	return topicARNs
+}
+
+// updateDeliveryStatus stores the delivery status update, if any, and renders the response.
+func (h MessageStatusCallbackHandler) updateDeliveryStatus(ctx context.Context, w http.ResponseWriter, update *message.DeliveryStatusUpdate) {
+	if update != nil {
+		if err := h.DeliveryStatusService.UpdateDeliveryStatus(ctx, *update); err != nil {
+			httperror.InternalError(ctx, "Cannot update the message delivery status", err, nil).Render(w)
+			return
+		}
+	}
+
+	httpjson.RenderStatus(w, http.StatusOK, map[string]string{"message": "ok"}, httpjson.JSON)
+}
@@ -0,0 +1,277 @@
+package httphandler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services/mocks"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
+)
+
+func signTwilioRequest(t *testing.T, authToken, callbackURL, body string) string {
+	t.Helper()
+
+	// Twilio signs the URL followed by the form params sorted by name, with their values.
+	formParams := strings.Split(body, "&")
+	sort.Strings(formParams)
+	params := ""
+	for _, param := range formParams {
+		params += strings.Replace(param, "=", "", 1)
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(callbackURL + params))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func Test_MessageStatusCallbackHandler_PostTwilio(t *testing.T) {
+	tenantBaseURL := "https://tenant.sdp.com"
+	ctx := tenant.SaveTenantInContext(context.Background(), &tenant.Tenant{ID: "tenant-id", BaseURL: &tenantBaseURL})
+	callbackURL := "https://tenant.sdp.com/message-status-callbacks/twilio?message_id=message-id"
+	deliveredBody := "MessageSid=SM123&MessageStatus=delivered"
+
+	testCases := []struct {
+		name           string
+		body           string
+		signature      string
+		prepareMocksFn func(mClientConfigModel *message.MockClientConfigModel, mService *mocks.MockMessageDeliveryStatusService)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:      "returns forbidden if the signature is invalid",
+			body:      deliveredBody,
+			signature: signTwilioRequest(t, "another-auth-token", callbackURL, deliveredBody),
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel, _ *mocks.MockMessageDeliveryStatusService) {
+				mClientConfigModel.On("GetDecryptedOptions", mock.Anything, mock.Anything, "passphrase").Return(nil, nil).Twice()
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantBody:       `{"error": "Invalid Twilio signature"}`,
+		},
+		{
+			name:      "returns bad request if the status is missing",
+			body:      "MessageSid=SM123",
+			signature: signTwilioRequest(t, "auth-token", callbackURL, "MessageSid=SM123"),
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel, _ *mocks.MockMessageDeliveryStatusService) {
+				mClientConfigModel.On("GetDecryptedOptions", mock.Anything, mock.Anything, "passphrase").Return(nil, nil).Twice()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"error": "The request was invalid in some way."}`,
+		},
+		{
+			name:      "ignores the statuses that aren't final",
+			body:      "MessageSid=SM123&MessageStatus=sent",
+			signature: signTwilioRequest(t, "auth-token", callbackURL, "MessageSid=SM123&MessageStatus=sent"),
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel, _ *mocks.MockMessageDeliveryStatusService) {
+				mClientConfigModel.On("GetDecryptedOptions", mock.Anything, mock.Anything, "passphrase").Return(nil, nil).Twice()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"message": "ok"}`,
+		},
+		{
+			name:      "returns an internal error if the status can't be updated",
+			body:      deliveredBody,
+			signature: signTwilioRequest(t, "auth-token", callbackURL, deliveredBody),
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel, mService *mocks.MockMessageDeliveryStatusService) {
+				mClientConfigModel.On("GetDecryptedOptions", mock.Anything, mock.Anything, "passphrase").Return(nil, nil).Twice()
+				mService.On("UpdateDeliveryStatus", mock.Anything, mock.Anything).Return(errors.New("db error")).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"error": "Cannot update the message delivery status"}`,
+		},
+		{
+			name:      "🎉 updates the status signed with the instance auth token",
+			body:      deliveredBody,
+			signature: signTwilioRequest(t, "auth-token", callbackURL, deliveredBody),
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel, mService *mocks.MockMessageDeliveryStatusService) {
+				mClientConfigModel.On("GetDecryptedOptions", mock.Anything, mock.Anything, "passphrase").Return(nil, nil).Twice()
+				mService.
+					On("UpdateDeliveryStatus", mock.Anything, message.DeliveryStatusUpdate{MessageID: "message-id", Status: message.DeliveryStatusDelivered}).
+					Return(nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"message": "ok"}`,
+		},
+		{
+			name:      "🎉 updates the status signed with the tenant auth token",
+			body:      "MessageSid=SM123&MessageStatus=undelivered&ErrorCode=30003",
+			signature: signTwilioRequest(t, "tenant-auth-token", callbackURL, "MessageSid=SM123&MessageStatus=undelivered&ErrorCode=30003"),
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel, mService *mocks.MockMessageDeliveryStatusService) {
+				mClientConfigModel.
+					On("GetDecryptedOptions", mock.Anything, message.MessageChannelSMS, "passphrase").
+					Return(&message.MessengerOptions{MessengerType: message.MessengerTypeTwilioSMS, TwilioAuthToken: "tenant-auth-token"}, nil).
+					Once().
+					On("GetDecryptedOptions", mock.Anything, message.MessageChannelWhatsApp, "passphrase").
+					Return(nil, errors.New("db error")).
+					Once()
+				mService.
+					On("UpdateDeliveryStatus", mock.Anything, message.DeliveryStatusUpdate{MessageID: "message-id", Status: message.DeliveryStatusFailed, ErrorCode: "30003"}).
+					Return(nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"message": "ok"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mClientConfigModel := message.NewMockClientConfigModel(t)
+			mService := mocks.NewMockMessageDeliveryStatusService(t)
+			tc.prepareMocksFn(mClientConfigModel, mService)
+			handler := MessageStatusCallbackHandler{
+				DeliveryStatusService: mService,
+				ClientConfigModel:     mClientConfigModel,
+				EncryptionPassphrase:  "passphrase",
+				TwilioAuthToken:       "auth-token",
+			}
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/message-status-callbacks/twilio?message_id=message-id", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set(message.TwilioSignatureHeader, tc.signature)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(handler.PostTwilio).ServeHTTP(rr, req)
+			assert.Equal(t, tc.wantStatusCode, rr.Code)
+			assert.JSONEq(t, tc.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func Test_MessageStatusCallbackHandler_PostAWSSES(t *testing.T) {
+	ctx := context.Background()
+	const topicARN = "arn:aws:sns:us-east-1:123456789012:sdp-ses-events"
+	const tenantTopicARN = "arn:aws:sns:us-east-1:210987654321:tenant-ses-events"
+	deliveryNotification := `{
+		"Type": "Notification",
+		"MessageId": "sns-message-id",
+		"TopicArn": "` + topicARN + `",
+		"Message": "{\"eventType\": \"Delivery\", \"mail\": {\"tags\": {\"sdp_message_id\": [\"message-id\"]}}}"
+	}`
+
+	testCases := []struct {
+		name           string
+		body           string
+		tenantOptions  *message.MessengerOptions
+		prepareMocksFn func(mVerifier *message.MockAWSSNSMessageVerifier, mService *mocks.MockMessageDeliveryStatusService)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "returns bad request if the body isn't JSON",
+			body:           "not json",
+			prepareMocksFn: func(*message.MockAWSSNSMessageVerifier, *mocks.MockMessageDeliveryStatusService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"error": "The request was invalid in some way."}`,
+		},
+		{
+			name:           "returns forbidden if the topic isn't allowed",
+			body:           `{"Type": "Notification", "TopicArn": "arn:aws:sns:us-east-1:999999999999:attacker-topic"}`,
+			prepareMocksFn: func(*message.MockAWSSNSMessageVerifier, *mocks.MockMessageDeliveryStatusService) {},
+			wantStatusCode: http.StatusForbidden,
+			wantBody:       `{"error": "SNS topic is not allowed"}`,
+		},
+		{
+			name: "returns forbidden if the message wasn't signed by SNS",
+			body: deliveryNotification,
+			prepareMocksFn: func(mVerifier *message.MockAWSSNSMessageVerifier, _ *mocks.MockMessageDeliveryStatusService) {
+				mVerifier.On("Verify", mock.Anything).Return(errors.New("verifying signature")).Once()
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantBody:       `{"error": "Invalid SNS message signature"}`,
+		},
+		{
+			name: "🎉 confirms the subscription",
+			body: `{"Type": "SubscriptionConfirmation", "TopicArn": "` + topicARN + `", "SubscribeURL": "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription"}`,
+			prepareMocksFn: func(mVerifier *message.MockAWSSNSMessageVerifier, _ *mocks.MockMessageDeliveryStatusService) {
+				mVerifier.On("Verify", mock.Anything).Return(nil).Once()
+				mVerifier.
+					On("ConfirmSubscription", message.AWSSNSMessage{
+						Type:         message.AWSSNSMessageTypeSubscriptionConfirmation,
+						TopicArn:     topicARN,
+						SubscribeURL: "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription",
+					}).
+					Return(nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"message": "ok"}`,
+		},
+		{
+			name: "returns an internal error if the subscription can't be confirmed",
+			body: `{"Type": "SubscriptionConfirmation", "TopicArn": "` + topicARN + `"}`,
+			prepareMocksFn: func(mVerifier *message.MockAWSSNSMessageVerifier, _ *mocks.MockMessageDeliveryStatusService) {
+				mVerifier.On("Verify", mock.Anything).Return(nil).Once()
+				mVerifier.On("ConfirmSubscription", mock.Anything).Return(errors.New("unexpected status")).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"error": "Cannot confirm the SNS subscription"}`,
+		},
+		{
+			name: "🎉 updates the status of the notified email",
+			body: deliveryNotification,
+			prepareMocksFn: func(mVerifier *message.MockAWSSNSMessageVerifier, mService *mocks.MockMessageDeliveryStatusService) {
+				mVerifier.On("Verify", mock.Anything).Return(nil).Once()
+				mService.
+					On("UpdateDeliveryStatus", mock.Anything, message.DeliveryStatusUpdate{MessageID: "message-id", Status: message.DeliveryStatusDelivered}).
+					Return(nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"message": "ok"}`,
+		},
+		{
+			name:          "🎉 accepts the topic configured for the tenant",
+			body:          `{"Type": "UnsubscribeConfirmation", "TopicArn": "` + tenantTopicARN + `"}`,
+			tenantOptions: &message.MessengerOptions{AWSSESNotificationTopicARN: tenantTopicARN},
+			prepareMocksFn: func(mVerifier *message.MockAWSSNSMessageVerifier, _ *mocks.MockMessageDeliveryStatusService) {
+				mVerifier.On("Verify", mock.Anything).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"message": "ok"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mVerifier := message.NewMockAWSSNSMessageVerifier(t)
+			mService := mocks.NewMockMessageDeliveryStatusService(t)
+			mClientConfigModel := message.NewMockClientConfigModel(t)
+			mClientConfigModel.
+				On("GetDecryptedOptions", mock.Anything, message.MessageChannelEmail, "passphrase").
+				Return(tc.tenantOptions, nil).
+				Maybe()
+			tc.prepareMocksFn(mVerifier, mService)
+			handler := MessageStatusCallbackHandler{
+				DeliveryStatusService:      mService,
+				SNSMessageVerifier:         mVerifier,
+				ClientConfigModel:          mClientConfigModel,
+				EncryptionPassphrase:       "passphrase",
+				AWSSESNotificationTopicARN: topicARN,
+			}
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/message-status-callbacks/aws-ses", strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(handler.PostAWSSES).ServeHTTP(rr, req)
+			assert.Equal(t, tc.wantStatusCode, rr.Code)
+			assert.JSONEq(t, tc.wantBody, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/support/render/httpjson"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+// MessengerConfigHandler lets a tenant send its messages through its own messaging provider accounts, instead of the
+// ones configured for the whole instance.
+type MessengerConfigHandler struct {
+	Models               *data.Models
+	ClientConfigModel    message.ClientConfigModelInterface
+	ClientFactory        func(opts message.MessengerOptions) (message.MessengerClient, error)
+	Encrypter            utils.PrivateKeyEncrypter
+	EncryptionPassphrase string
+}
+
+type MessengerConfigResponse struct {
+	Channel       message.MessageChannel `json:"channel"`
+	MessengerType message.MessengerType  `json:"messenger_type"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+func newMessengerConfigResponse(config message.ClientConfig) MessengerConfigResponse {
+	return MessengerConfigResponse{
+		Channel:       config.Channel,
+		MessengerType: config.MessengerType,
+		CreatedAt:     config.CreatedAt,
+		UpdatedAt:     config.UpdatedAt,
+	}
+}
+
+type PutMessengerConfigRequest struct {
+	MessengerType string                   `json:"messenger_type"`
+	Credentials   message.MessengerOptions `json:"credentials"`
+}
+
+type TestMessengerConfigRequest struct {
+	PhoneNumber string `json:"phone_number"`
+	Email       string `json:"email"`
+}
+
+// validate validates the request for the channel being tested.
+func (r TestMessengerConfigRequest) validate(channel message.MessageChannel) error {
+	if channel == message.MessageChannelEmail {
+		if err := utils.ValidateEmail(r.Email); err != nil {
+			return fmt.Errorf("email is invalid: %w", err)
+		}
+		return nil
+	}
+
+	if err := utils.ValidatePhoneNumber(r.PhoneNumber); err != nil {
+		return fmt.Errorf("phone_number is invalid: %w", err)
+	}
+	return nil
+}
+
+// GetAll lists the channels with messaging provider credentials configured by the tenant. The credentials themselves
+// are never returned.
+func (h MessengerConfigHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	configs, err := h.ClientConfigModel.GetAll(ctx)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot retrieve the messenger configurations", err, nil).Render(w)
+		return
+	}
+
+	response := make([]MessengerConfigResponse, 0, len(configs))
+	for _, config := range configs {
+		response = append(response, newMessengerConfigResponse(config))
+	}
+
+	httpjson.Render(w, response, httpjson.JSON)
+}
+
+// Put sets the messaging provider credentials of a channel, which are stored encrypted.
+func (h MessengerConfigHandler) Put(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	channel, err := message.ParseMessageChannel(chi.URLParam(r, "channel"))
+	if err != nil {
+		httperror.BadRequest("Invalid message channel", err, nil).Render(w)
+		return
+	}
+
+	var putRequest PutMessengerConfigRequest
+	if err = json.NewDecoder(r.Body).Decode(&putRequest); err != nil {
+		httperror.BadRequest("Request body is not valid", err, nil).Render(w)
+		return
+	}
+
+	opts, err := validatePutMessengerConfigRequest(channel, putRequest)
+	if err != nil {
+		extras := map[string]interface{}{"validation_error": err.Error()}
+		httperror.BadRequest("Request body is not valid", err, extras).Render(w)
+		return
+	}
+
+	// Creating the client validates that all the credentials it needs were provided.
+	if _, err = h.ClientFactory(opts); err != nil {
+		extras := map[string]interface{}{"validation_error": err.Error()}
+		httperror.BadRequest("Request body is not valid", err, extras).Render(w)
+		return
+	}
+
+	credentials, err := json.Marshal(opts)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot marshal the credentials", err, nil).Render(w)
+		return
+	}
+
+	kp, err := keypair.ParseFull(h.EncryptionPassphrase)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot parse the encryption keypair", err, nil).Render(w)
+		return
+	}
+
+	encryptedCredentials, err := h.Encrypter.Encrypt(string(credentials), kp.Seed())
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot encrypt the credentials", err, nil).Render(w)
+		return
+	}
+
+	config, err := h.ClientConfigModel.Upsert(ctx, message.ClientConfigUpsert{
+		Channel:              channel,
+		MessengerType:        opts.MessengerType,
+		EncryptedCredentials: encryptedCredentials,
+		EncrypterPublicKey:   kp.Address(),
+	})
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot save the messenger configuration", err, nil).Render(w)
+		return
+	}
+
+	log.Ctx(ctx).Infof("[MessengerConfig] %s channel configured to use the tenant's %s credentials", channel, opts.MessengerType)
+	httpjson.Render(w, newMessengerConfigResponse(*config), httpjson.JSON)
+}
+
+func validatePutMessengerConfigRequest(channel message.MessageChannel, putRequest PutMessengerConfigRequest) (message.MessengerOptions, error) {
+	messengerType, err := message.ParseMessengerType(putRequest.MessengerType)
+	if err != nil {
+		return message.MessengerOptions{}, fmt.Errorf("messenger_type is invalid: %w", err)
+	}
+
+	if messengerType == message.MessengerTypeDryRun {
+		return message.MessengerOptions{}, fmt.Errorf("messenger_type %s can't be configured for a tenant", messengerType)
+	}
+
+	if !channel.SupportsMessengerType(messengerType) {
+		return message.MessengerOptions{}, fmt.Errorf("messenger_type %s can't be used for the %s channel", messengerType, channel)
+	}
+
+	opts := putRequest.Credentials
+	opts.MessengerType = messengerType
+	return opts, nil
+}
+
+// Delete removes the messaging provider credentials of a channel, so its messages are sent through the clients
+// configured for the whole instance again.
+func (h MessengerConfigHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	channel, err := message.ParseMessageChannel(chi.URLParam(r, "channel"))
+	if err != nil {
+		httperror.BadRequest("Invalid message channel", err, nil).Render(w)
+		return
+	}
+
+	if err = h.ClientConfigModel.Delete(ctx, channel); err != nil {
+		if errors.Is(err, message.ErrClientConfigNotFound) {
+			httperror.NotFound(fmt.Sprintf("The %s channel has no messenger configuration", channel), err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot delete the messenger configuration", err, nil).Render(w)
+		return
+	}
+
+	httpjson.RenderStatus(w, http.StatusNoContent, nil, httpjson.JSON)
+}
+
+// Test sends a test message through the messaging provider credentials configured for a channel, so the tenant can
+// check them before any receiver depends on them.
+func (h MessengerConfigHandler) Test(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	channel, err := message.ParseMessageChannel(chi.URLParam(r, "channel"))
+	if err != nil {
+		httperror.BadRequest("Invalid message channel", err, nil).Render(w)
+		return
+	}
+
+	var testRequest TestMessengerConfigRequest
+	if err = json.NewDecoder(r.Body).Decode(&testRequest); err != nil {
+		httperror.BadRequest("Request body is not valid", err, nil).Render(w)
+		return
+	}
+
+	if err = testRequest.validate(channel); err != nil {
+		extras := map[string]interface{}{"validation_error": err.Error()}
+		httperror.BadRequest("Request body is not valid", err, extras).Render(w)
+		return
+	}
+
+	opts, err := h.ClientConfigModel.GetDecryptedOptions(ctx, channel, h.EncryptionPassphrase)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot retrieve the messenger configuration", err, nil).Render(w)
+		return
+	}
+	if opts == nil {
+		httperror.NotFound(fmt.Sprintf("The %s channel has no messenger configuration", channel), nil, nil).Render(w)
+		return
+	}
+
+	client, err := h.ClientFactory(*opts)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot create the messenger client", err, nil).Render(w)
+		return
+	}
+
+	organization, err := h.Models.Organizations.Get(ctx)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot retrieve organization", err, nil).Render(w)
+		return
+	}
+
+	msg := message.Message{
+		Body: fmt.Sprintf("This is a test message from %s, sent to check its %s messaging configuration.", organization.Name, channel),
+	}
+	switch channel {
+	case message.MessageChannelEmail:
+		msg.ToEmail = testRequest.Email
+		msg.Title = "Test message from " + organization.Name
+	case message.MessageChannelWhatsApp:
+		// WhatsApp only sends pre-approved templates, so the OTP template is sent with a placeholder code.
+		msg.ToPhoneNumber = testRequest.PhoneNumber
+		msg.Template = &message.MessageTemplate{
+			Type:      message.MessageTemplateTypeReceiverOTP,
+			Variables: []string{"000000", organization.Name},
+		}
+	default:
+		msg.ToPhoneNumber = testRequest.PhoneNumber
+	}
+
+	if err = client.SendMessage(msg); err != nil {
+		extras := map[string]interface{}{"provider_error": err.Error()}
+		httperror.BadRequest("Failed to send the test message with the configured credentials", err, extras).Render(w)
+		return
+	}
+
+	httpjson.Render(w, map[string]string{"message": "Test message sent"}, httpjson.JSON)
+}
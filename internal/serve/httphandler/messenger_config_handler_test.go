@@ -0,0 +1,437 @@
+package httphandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/testutils"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+func Test_MessengerConfigHandler_GetAll(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2025, 2, 20, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		prepareMocksFn func(mClientConfigModel *message.MockClientConfigModel)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "returns an internal error if the configs can't be retrieved",
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel) {
+				mClientConfigModel.On("GetAll", mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"error": "Cannot retrieve the messenger configurations"}`,
+		},
+		{
+			name: "returns an empty list if no channel was configured",
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel) {
+				mClientConfigModel.On("GetAll", mock.Anything).Return([]message.ClientConfig{}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `[]`,
+		},
+		{
+			name: "returns the configs without their credentials",
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel) {
+				mClientConfigModel.
+					On("GetAll", mock.Anything).
+					Return([]message.ClientConfig{
+						{
+							Channel:              message.MessageChannelSMS,
+							MessengerType:        message.MessengerTypeTwilioSMS,
+							EncryptedCredentials: "encrypted",
+							EncrypterPublicKey:   "public-key",
+							CreatedAt:            createdAt,
+							UpdatedAt:            createdAt,
+						},
+					}, nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `[{
+				"channel": "SMS",
+				"messenger_type": "TWILIO_SMS",
+				"created_at": "2025-02-20T10:00:00Z",
+				"updated_at": "2025-02-20T10:00:00Z"
+			}]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mClientConfigModel := message.NewMockClientConfigModel(t)
+			tc.prepareMocksFn(mClientConfigModel)
+			handler := MessengerConfigHandler{ClientConfigModel: mClientConfigModel}
+
+			r := chi.NewRouter()
+			r.Get("/organization/messenger-configs", handler.GetAll)
+
+			rr := testutils.Request(t, ctx, r, "/organization/messenger-configs", http.MethodGet, nil)
+			assert.Equal(t, tc.wantStatusCode, rr.Code)
+			assert.JSONEq(t, tc.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func Test_MessengerConfigHandler_Put(t *testing.T) {
+	ctx := context.Background()
+	kp := keypair.MustRandom()
+	encrypter := &utils.DefaultPrivateKeyEncrypter{}
+	createdAt := time.Date(2025, 2, 20, 10, 0, 0, 0, time.UTC)
+
+	validBody := `{
+		"messenger_type": "TWILIO_SMS",
+		"credentials": {
+			"twilio_account_sid": "account-sid",
+			"twilio_auth_token": "auth-token",
+			"twilio_service_sid": "service-sid"
+		}
+	}`
+
+	testCases := []struct {
+		name           string
+		channel        string
+		body           string
+		prepareMocksFn func(t *testing.T, mClientConfigModel *message.MockClientConfigModel)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "returns bad request if the channel is invalid",
+			channel:        "TELEGRAM",
+			body:           validBody,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"error": "Invalid message channel"}`,
+		},
+		{
+			name:           "returns bad request if the messenger type is invalid",
+			channel:        "SMS",
+			body:           `{"messenger_type": "CARRIER_PIGEON", "credentials": {}}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"error": "Request body is not valid",
+				"extras": {"validation_error": "messenger_type is invalid: invalid message sender type \"CARRIER_PIGEON\""}
+			}`,
+		},
+		{
+			name:           "returns bad request if the messenger type is DRY_RUN",
+			channel:        "SMS",
+			body:           `{"messenger_type": "DRY_RUN", "credentials": {}}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"error": "Request body is not valid",
+				"extras": {"validation_error": "messenger_type DRY_RUN can't be configured for a tenant"}
+			}`,
+		},
+		{
+			name:           "returns bad request if the messenger type can't be used for the channel",
+			channel:        "EMAIL",
+			body:           validBody,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"error": "Request body is not valid",
+				"extras": {"validation_error": "messenger_type TWILIO_SMS can't be used for the EMAIL channel"}
+			}`,
+		},
+		{
+			name:           "returns bad request if the credentials are incomplete",
+			channel:        "SMS",
+			body:           `{"messenger_type": "TWILIO_SMS", "credentials": {"twilio_account_sid": "account-sid"}}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"error": "Request body is not valid",
+				"extras": {"validation_error": "twilio authToken is empty"}
+			}`,
+		},
+		{
+			name:    "returns an internal error if the config can't be saved",
+			channel: "SMS",
+			body:    validBody,
+			prepareMocksFn: func(t *testing.T, mClientConfigModel *message.MockClientConfigModel) {
+				mClientConfigModel.
+					On("Upsert", mock.Anything, mock.AnythingOfType("message.ClientConfigUpsert")).
+					Return(nil, errors.New("db error")).
+					Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"error": "Cannot save the messenger configuration"}`,
+		},
+		{
+			name:    "🎉 saves the encrypted credentials",
+			channel: "SMS",
+			body:    validBody,
+			prepareMocksFn: func(t *testing.T, mClientConfigModel *message.MockClientConfigModel) {
+				mClientConfigModel.
+					On("Upsert", mock.Anything, mock.AnythingOfType("message.ClientConfigUpsert")).
+					Run(func(args mock.Arguments) {
+						configUpsert := args.Get(1).(message.ClientConfigUpsert)
+						assert.Equal(t, message.MessageChannelSMS, configUpsert.Channel)
+						assert.Equal(t, message.MessengerTypeTwilioSMS, configUpsert.MessengerType)
+						assert.Equal(t, kp.Address(), configUpsert.EncrypterPublicKey)
+
+						credentials, err := encrypter.Decrypt(configUpsert.EncryptedCredentials, kp.Seed())
+						require.NoError(t, err)
+						assert.JSONEq(t, `{
+							"twilio_account_sid": "account-sid",
+							"twilio_auth_token": "auth-token",
+							"twilio_service_sid": "service-sid"
+						}`, credentials)
+					}).
+					Return(&message.ClientConfig{
+						Channel:       message.MessageChannelSMS,
+						MessengerType: message.MessengerTypeTwilioSMS,
+						CreatedAt:     createdAt,
+						UpdatedAt:     createdAt,
+					}, nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"channel": "SMS",
+				"messenger_type": "TWILIO_SMS",
+				"created_at": "2025-02-20T10:00:00Z",
+				"updated_at": "2025-02-20T10:00:00Z"
+			}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mClientConfigModel := message.NewMockClientConfigModel(t)
+			if tc.prepareMocksFn != nil {
+				tc.prepareMocksFn(t, mClientConfigModel)
+			}
+			handler := MessengerConfigHandler{
+				ClientConfigModel:    mClientConfigModel,
+				ClientFactory:        message.GetClient,
+				Encrypter:            encrypter,
+				EncryptionPassphrase: kp.Seed(),
+			}
+
+			r := chi.NewRouter()
+			r.Put("/organization/messenger-configs/{channel}", handler.Put)
+
+			url := "/organization/messenger-configs/" + tc.channel
+			rr := testutils.Request(t, ctx, r, url, http.MethodPut, strings.NewReader(tc.body))
+			assert.Equal(t, tc.wantStatusCode, rr.Code)
+			assert.JSONEq(t, tc.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func Test_MessengerConfigHandler_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name           string
+		channel        string
+		prepareMocksFn func(mClientConfigModel *message.MockClientConfigModel)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "returns bad request if the channel is invalid",
+			channel:        "TELEGRAM",
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"error": "Invalid message channel"}`,
+		},
+		{
+			name:    "returns not found if the channel has no config",
+			channel: "EMAIL",
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel) {
+				mClientConfigModel.On("Delete", mock.Anything, message.MessageChannelEmail).Return(message.ErrClientConfigNotFound).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"error": "The EMAIL channel has no messenger configuration"}`,
+		},
+		{
+			name:    "returns an internal error if the config can't be deleted",
+			channel: "EMAIL",
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel) {
+				mClientConfigModel.On("Delete", mock.Anything, message.MessageChannelEmail).Return(errors.New("db error")).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"error": "Cannot delete the messenger configuration"}`,
+		},
+		{
+			name:    "🎉 deletes the config",
+			channel: "EMAIL",
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel) {
+				mClientConfigModel.On("Delete", mock.Anything, message.MessageChannelEmail).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mClientConfigModel := message.NewMockClientConfigModel(t)
+			if tc.prepareMocksFn != nil {
+				tc.prepareMocksFn(mClientConfigModel)
+			}
+			handler := MessengerConfigHandler{ClientConfigModel: mClientConfigModel}
+
+			r := chi.NewRouter()
+			r.Delete("/organization/messenger-configs/{channel}", handler.Delete)
+
+			url := "/organization/messenger-configs/" + tc.channel
+			rr := testutils.Request(t, ctx, r, url, http.MethodDelete, nil)
+			assert.Equal(t, tc.wantStatusCode, rr.Code)
+			if tc.wantBody != "" {
+				assert.JSONEq(t, tc.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func Test_MessengerConfigHandler_Test(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	smsOpts := &message.MessengerOptions{MessengerType: message.MessengerTypeTwilioSMS}
+	whatsAppOpts := &message.MessengerOptions{MessengerType: message.MessengerTypeTwilioWhatsApp}
+
+	testCases := []struct {
+		name           string
+		channel        string
+		body           string
+		prepareMocksFn func(mClientConfigModel *message.MockClientConfigModel, mClient *message.MessengerClientMock)
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "returns bad request if the phone number is invalid",
+			channel:        "SMS",
+			body:           `{"phone_number": "invalid"}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"error": "Request body is not valid",
+				"extras": {"validation_error": "phone_number is invalid: the provided phone number is not a valid E.164 number"}
+			}`,
+		},
+		{
+			name:           "returns bad request if the email is invalid",
+			channel:        "EMAIL",
+			body:           `{"email": "invalid"}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"error": "Request body is not valid",
+				"extras": {"validation_error": "email is invalid: the provided email is not valid"}
+			}`,
+		},
+		{
+			name:    "returns not found if the channel has no config",
+			channel: "SMS",
+			body:    `{"phone_number": "+14155555555"}`,
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel, mClient *message.MessengerClientMock) {
+				mClientConfigModel.On("GetDecryptedOptions", mock.Anything, message.MessageChannelSMS, "passphrase").Return(nil, nil).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"error": "The SMS channel has no messenger configuration"}`,
+		},
+		{
+			name:    "returns bad request if the provider fails to send the message",
+			channel: "SMS",
+			body:    `{"phone_number": "+14155555555"}`,
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel, mClient *message.MessengerClientMock) {
+				mClientConfigModel.On("GetDecryptedOptions", mock.Anything, message.MessageChannelSMS, "passphrase").Return(smsOpts, nil).Once()
+				mClient.On("SendMessage", mock.AnythingOfType("message.Message")).Return(fmt.Errorf("authentication failed")).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"error": "Failed to send the test message with the configured credentials",
+				"extras": {"provider_error": "authentication failed"}
+			}`,
+		},
+		{
+			name:    "🎉 sends a test SMS",
+			channel: "SMS",
+			body:    `{"phone_number": "+14155555555"}`,
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel, mClient *message.MessengerClientMock) {
+				mClientConfigModel.On("GetDecryptedOptions", mock.Anything, message.MessageChannelSMS, "passphrase").Return(smsOpts, nil).Once()
+				mClient.
+					On("SendMessage", message.Message{
+						ToPhoneNumber: "+14155555555",
+						Body:          "This is a test message from MyCustomAid, sent to check its SMS messaging configuration.",
+					}).
+					Return(nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"message": "Test message sent"}`,
+		},
+		{
+			name:    "🎉 sends a test WhatsApp message with the OTP template",
+			channel: "WHATSAPP",
+			body:    `{"phone_number": "+14155555555"}`,
+			prepareMocksFn: func(mClientConfigModel *message.MockClientConfigModel, mClient *message.MessengerClientMock) {
+				mClientConfigModel.On("GetDecryptedOptions", mock.Anything, message.MessageChannelWhatsApp, "passphrase").Return(whatsAppOpts, nil).Once()
+				mClient.
+					On("SendMessage", message.Message{
+						ToPhoneNumber: "+14155555555",
+						Body:          "This is a test message from MyCustomAid, sent to check its WHATSAPP messaging configuration.",
+						Template: &message.MessageTemplate{
+							Type:      message.MessageTemplateTypeReceiverOTP,
+							Variables: []string{"000000", "MyCustomAid"},
+						},
+					}).
+					Return(nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"message": "Test message sent"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mClientConfigModel := message.NewMockClientConfigModel(t)
+			mClient := message.NewMessengerClientMock(t)
+			if tc.prepareMocksFn != nil {
+				tc.prepareMocksFn(mClientConfigModel, mClient)
+			}
+			handler := MessengerConfigHandler{
+				Models:            models,
+				ClientConfigModel: mClientConfigModel,
+				ClientFactory: func(opts message.MessengerOptions) (message.MessengerClient, error) {
+					return mClient, nil
+				},
+				EncryptionPassphrase: "passphrase",
+			}
+
+			r := chi.NewRouter()
+			r.Post("/organization/messenger-configs/{channel}/test", handler.Test)
+
+			url := "/organization/messenger-configs/" + tc.channel + "/test"
+			rr := testutils.Request(t, ctx, r, url, http.MethodPost, strings.NewReader(tc.body))
+			assert.Equal(t, tc.wantStatusCode, rr.Code)
+			assert.JSONEq(t, tc.wantBody, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package httphandler
+
+import (
+	"context"
+	"time"
+
+	"github.com/stellar/go/support/log"
+
+	txSubStore "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store"
+	txSubUtils "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/utils"
+)
+
+// PaymentStellarTransaction is a transaction submitted to the Stellar network by the Transaction Submission Service
+// (TSS) for a payment, with its XDRs decoded.
+type PaymentStellarTransaction struct {
+	ID                     string              `json:"id"`
+	Status                 string              `json:"status"`
+	StatusMessage          string              `json:"status_message,omitempty"`
+	AttemptsCount          int                 `json:"attempts_count"`
+	StellarTransactionHash string              `json:"stellar_transaction_hash,omitempty"`
+	DistributionAccount    string              `json:"distribution_account,omitempty"`
+	ChannelAccount         string              `json:"channel_account,omitempty"`
+	OperationIndex         *int32              `json:"operation_index,omitempty"`
+	XDRSent                string              `json:"xdr_sent,omitempty"`
+	XDRReceived            string              `json:"xdr_received,omitempty"`
+	ResultCodes            *StellarResultCodes `json:"result_codes,omitempty"`
+	CreatedAt              *time.Time          `json:"created_at"`
+	StartedAt              *time.Time          `json:"started_at,omitempty"`
+	SentAt                 *time.Time          `json:"sent_at,omitempty"`
+	CompletedAt            *time.Time          `json:"completed_at,omitempty"`
+	SyncedAt               *time.Time          `json:"synced_at,omitempty"`
+	// StatusHistory has an entry for each status change of the transaction, with the XDRs of the attempt at that time.
+	StatusHistory []PaymentStellarTransactionStatus `json:"status_history"`
+}
+
+type PaymentStellarTransactionStatus struct {
+	Status                 string              `json:"status"`
+	StatusMessage          string              `json:"status_message,omitempty"`
+	Timestamp              time.Time           `json:"timestamp"`
+	StellarTransactionHash string              `json:"stellar_transaction_hash,omitempty"`
+	ChannelAccount         string              `json:"channel_account,omitempty"`
+	XDRSent                string              `json:"xdr_sent,omitempty"`
+	XDRReceived            string              `json:"xdr_received,omitempty"`
+	ResultCodes            *StellarResultCodes `json:"result_codes,omitempty"`
+}
+
+// StellarResultCodes are the result codes decoded from the result XDR of a transaction, in the same format used by Horizon.
+type StellarResultCodes struct {
+	Transaction      string   `json:"transaction"`
+	InnerTransaction string   `json:"inner_transaction,omitempty"`
+	Operations       []string `json:"operations,omitempty"`
+	// Operation is the result code of the operation of the payment, which is the only one unless the transaction was
+	// submitted in a batch with other payments.
+	Operation string `json:"operation,omitempty"`
+}
+
+// newPaymentStellarTransaction converts a TSS transaction into its API representation. XDRs that can't be decoded are
+// still returned as they are, without the fields decoded from them.
+func newPaymentStellarTransaction(ctx context.Context, tx *txSubStore.Transaction) PaymentStellarTransaction {
+	opIndex := 0
+	var operationIndex *int32
+	if tx.OperationIndex.Valid {
+		opIndex = int(tx.OperationIndex.Int32)
+		operationIndex = &tx.OperationIndex.Int32
+	}
+
+	paymentTx := PaymentStellarTransaction{
+		ID:                     tx.ID,
+		Status:                 string(tx.Status),
+		StatusMessage:          tx.StatusMessage.String,
+		AttemptsCount:          tx.AttemptsCount,
+		StellarTransactionHash: tx.StellarTransactionHash.String,
+		DistributionAccount:    tx.DistributionAccount.String,
+		ChannelAccount:         decodeChannelAccount(ctx, tx.ID, tx.XDRSent.String),
+		OperationIndex:         operationIndex,
+		XDRSent:                tx.XDRSent.String,
+		XDRReceived:            tx.XDRReceived.String,
+		ResultCodes:            decodeResultCodes(ctx, tx.ID, tx.XDRReceived.String, opIndex),
+		CreatedAt:              tx.CreatedAt,
+		StartedAt:              tx.StartedAt,
+		SentAt:                 tx.SentAt,
+		CompletedAt:            tx.CompletedAt,
+		SyncedAt:               tx.SyncedAt,
+		StatusHistory:          make([]PaymentStellarTransactionStatus, 0, len(tx.StatusHistory)),
+	}
+
+	for _, entry := range tx.StatusHistory {
+		paymentTx.StatusHistory = append(paymentTx.StatusHistory, PaymentStellarTransactionStatus{
+			Status:                 entry.Status,
+			StatusMessage:          entry.StatusMessage,
+			Timestamp:              entry.Timestamp,
+			StellarTransactionHash: entry.StellarTransactionHash,
+			ChannelAccount:         decodeChannelAccount(ctx, tx.ID, entry.XDRSent),
+			XDRSent:                entry.XDRSent,
+			XDRReceived:            entry.XDRReceived,
+			ResultCodes:            decodeResultCodes(ctx, tx.ID, entry.XDRReceived, opIndex),
+		})
+	}
+
+	return paymentTx
+}
+
+func decodeChannelAccount(ctx context.Context, txID, xdrSent string) string {
+	if xdrSent == "" {
+		return ""
+	}
+
+	channelAccount, err := txSubUtils.TransactionSourceAccount(xdrSent)
+	if err != nil {
+		log.Ctx(ctx).Warnf("decoding channel account of transaction %s: %v", txID, err)
+		return ""
+	}
+	return channelAccount
+}
+
+func decodeResultCodes(ctx context.Context, txID, xdrReceived string, opIndex int) *StellarResultCodes {
+	if xdrReceived == "" {
+		return nil
+	}
+
+	decodedCodes, err := txSubUtils.DecodeResultCodes(xdrReceived)
+	if err != nil {
+		log.Ctx(ctx).Warnf("decoding result codes of transaction %s: %v", txID, err)
+		return nil
+	}
+
+	resultCodes := &StellarResultCodes{
+		Transaction:      decodedCodes.TransactionCode,
+		InnerTransaction: decodedCodes.InnerTransactionCode,
+		Operations:       decodedCodes.OperationCodes,
+	}
+	if opIndex < len(decodedCodes.OperationCodes) {
+		resultCodes.Operation = decodedCodes.OperationCodes[opIndex]
+	}
+	return resultCodes
+}
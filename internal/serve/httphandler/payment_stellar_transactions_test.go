@@ -0,0 +1,260 @@
+package httphandler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	txSubStore "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store"
+	txSubStoreMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store/mocks"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
+)
+
+// createPaymentTransactionXDRs returns the envelope XDR of a transaction sent from the channel account, and the result
+// XDR of a batch where the payment at index 1 failed with the given code.
+func createPaymentTransactionXDRs(t *testing.T, channelAccount *keypair.Full, paymentCode xdr.PaymentResultCode) (string, string) {
+	t.Helper()
+
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: channelAccount.Address(), Sequence: 1},
+		Operations: []txnbuild.Operation{
+			&txnbuild.Payment{Destination: keypair.MustRandom().Address(), Amount: "1", Asset: txnbuild.NativeAsset{}},
+		},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, channelAccount)
+	require.NoError(t, err)
+	envelopeXDR, err := tx.Base64()
+	require.NoError(t, err)
+
+	opResults := []xdr.OperationResult{
+		{
+			Code: xdr.OperationResultCodeOpInner,
+			Tr: &xdr.OperationResultTr{
+				Type:          xdr.OperationTypePayment,
+				PaymentResult: &xdr.PaymentResult{Code: xdr.PaymentResultCodePaymentSuccess},
+			},
+		},
+		{
+			Code: xdr.OperationResultCodeOpInner,
+			Tr: &xdr.OperationResultTr{
+				Type:          xdr.OperationTypePayment,
+				PaymentResult: &xdr.PaymentResult{Code: paymentCode},
+			},
+		},
+	}
+	resultXDR, err := xdr.MarshalBase64(xdr.TransactionResult{
+		Result: xdr.TransactionResultResult{
+			Code:    xdr.TransactionResultCodeTxFailed,
+			Results: &opResults,
+		},
+	})
+	require.NoError(t, err)
+
+	return envelopeXDR, resultXDR
+}
+
+func Test_newPaymentStellarTransaction(t *testing.T) {
+	ctx := context.Background()
+	channelAccount := keypair.MustRandom()
+	envelopeXDR, resultXDR := createPaymentTransactionXDRs(t, channelAccount, xdr.PaymentResultCodePaymentNoTrust)
+	createdAt := time.Date(2024, 3, 21, 23, 40, 20, 0, time.UTC)
+
+	t.Run("🎉 decodes the channel account and the result codes of the transaction and its attempts", func(t *testing.T) {
+		tx := &txSubStore.Transaction{
+			ID:                     "tx-id",
+			Status:                 txSubStore.TransactionStatusError,
+			StatusMessage:          sql.NullString{String: "transaction failed", Valid: true},
+			AttemptsCount:          2,
+			StellarTransactionHash: sql.NullString{String: "tx-hash", Valid: true},
+			XDRSent:                sql.NullString{String: envelopeXDR, Valid: true},
+			XDRReceived:            sql.NullString{String: resultXDR, Valid: true},
+			OperationIndex:         sql.NullInt32{Int32: 1, Valid: true},
+			CreatedAt:              &createdAt,
+			StatusHistory: txSubStore.TransactionStatusHistory{
+				{Status: string(txSubStore.TransactionStatusPending), Timestamp: createdAt},
+				{Status: string(txSubStore.TransactionStatusProcessing), Timestamp: createdAt, XDRSent: envelopeXDR, XDRReceived: "invalid"},
+				{Status: string(txSubStore.TransactionStatusError), Timestamp: createdAt, XDRSent: envelopeXDR, XDRReceived: resultXDR},
+			},
+		}
+
+		paymentTx := newPaymentStellarTransaction(ctx, tx)
+
+		wantResultCodes := &StellarResultCodes{
+			Transaction: "tx_failed",
+			Operations:  []string{"op_success", "op_no_trust"},
+			Operation:   "op_no_trust",
+		}
+		assert.Equal(t, "tx-id", paymentTx.ID)
+		assert.Equal(t, "ERROR", paymentTx.Status)
+		assert.Equal(t, "transaction failed", paymentTx.StatusMessage)
+		assert.Equal(t, 2, paymentTx.AttemptsCount)
+		assert.Equal(t, channelAccount.Address(), paymentTx.ChannelAccount)
+		assert.Equal(t, int32(1), *paymentTx.OperationIndex)
+		assert.Equal(t, wantResultCodes, paymentTx.ResultCodes)
+
+		require.Len(t, paymentTx.StatusHistory, 3)
+		assert.Empty(t, paymentTx.StatusHistory[0].ChannelAccount)
+		assert.Nil(t, paymentTx.StatusHistory[0].ResultCodes)
+		assert.Equal(t, channelAccount.Address(), paymentTx.StatusHistory[1].ChannelAccount)
+		assert.Nil(t, paymentTx.StatusHistory[1].ResultCodes)
+		assert.Equal(t, "invalid", paymentTx.StatusHistory[1].XDRReceived)
+		assert.Equal(t, wantResultCodes, paymentTx.StatusHistory[2].ResultCodes)
+	})
+
+	t.Run("🎉 the operation code is the first one for transactions that weren't batched", func(t *testing.T) {
+		tx := &txSubStore.Transaction{
+			ID:          "tx-id",
+			Status:      txSubStore.TransactionStatusError,
+			XDRReceived: sql.NullString{String: resultXDR, Valid: true},
+			CreatedAt:   &createdAt,
+		}
+
+		paymentTx := newPaymentStellarTransaction(ctx, tx)
+		assert.Nil(t, paymentTx.OperationIndex)
+		assert.Equal(t, "op_success", paymentTx.ResultCodes.Operation)
+		assert.Empty(t, paymentTx.StatusHistory)
+	})
+}
+
+func Test_PaymentsHandler_GetPaymentTransactions(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	tnt := tenant.Tenant{ID: "tenant-id"}
+	ctx := tenant.SaveTenantInContext(context.Background(), &tnt)
+
+	asset := data.CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVV")
+	wallet := data.CreateWalletFixture(t, ctx, dbConnectionPool, "wallet1", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+	receiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+	receiverWallet := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, data.DraftReceiversWalletStatus)
+	disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Status: data.StartedDisbursementStatus,
+		Asset:  asset,
+		Wallet: wallet,
+	})
+	payment := data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+		Amount:         "50",
+		Status:         data.FailedPaymentStatus,
+		Disbursement:   disbursement,
+		Asset:          *asset,
+		ReceiverWallet: receiverWallet,
+	})
+
+	channelAccount := keypair.MustRandom()
+	envelopeXDR, resultXDR := createPaymentTransactionXDRs(t, channelAccount, xdr.PaymentResultCodePaymentUnderfunded)
+	firstCreatedAt := time.Date(2024, 3, 21, 23, 40, 20, 0, time.UTC)
+	secondCreatedAt := firstCreatedAt.Add(time.Hour)
+
+	testCases := []struct {
+		name           string
+		paymentID      string
+		setupMocks     func(mTransactionStore *txSubStoreMocks.MockTransactionStore)
+		wantStatusCode int
+		assertBody     func(t *testing.T, body []byte)
+	}{
+		{
+			name:           "🔴 payment not found",
+			paymentID:      "unknown-id",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:      "🔴 error getting the transactions",
+			paymentID: payment.ID,
+			setupMocks: func(mTransactionStore *txSubStoreMocks.MockTransactionStore) {
+				mTransactionStore.
+					On("GetAllByPaymentIDs", mock.Anything, []string{payment.ID}).
+					Return(nil, errors.New("connection refused")).
+					Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:      "🎉 returns the transactions of the tenant, oldest first",
+			paymentID: payment.ID,
+			setupMocks: func(mTransactionStore *txSubStoreMocks.MockTransactionStore) {
+				mTransactionStore.
+					On("GetAllByPaymentIDs", mock.Anything, []string{payment.ID}).
+					Return([]*txSubStore.Transaction{
+						{ID: "second-tx", ExternalID: payment.ID, TenantID: tnt.ID, Status: txSubStore.TransactionStatusPending, CreatedAt: &secondCreatedAt},
+						{ID: "other-tenant-tx", ExternalID: payment.ID, TenantID: "other-tenant-id", Status: txSubStore.TransactionStatusError, CreatedAt: &firstCreatedAt},
+						{
+							ID:            "first-tx",
+							ExternalID:    payment.ID,
+							TenantID:      tnt.ID,
+							Status:        txSubStore.TransactionStatusError,
+							AttemptsCount: 1,
+							XDRSent:       sql.NullString{String: envelopeXDR, Valid: true},
+							XDRReceived:   sql.NullString{String: resultXDR, Valid: true},
+							CreatedAt:     &firstCreatedAt,
+						},
+					}, nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+			assertBody: func(t *testing.T, body []byte) {
+				var paymentTxs []PaymentStellarTransaction
+				require.NoError(t, json.Unmarshal(body, &paymentTxs))
+				require.Len(t, paymentTxs, 2)
+				assert.Equal(t, "first-tx", paymentTxs[0].ID)
+				assert.Equal(t, channelAccount.Address(), paymentTxs[0].ChannelAccount)
+				assert.Equal(t, "op_underfunded", paymentTxs[0].ResultCodes.Operation)
+				assert.Equal(t, "second-tx", paymentTxs[1].ID)
+				assert.Nil(t, paymentTxs[1].ResultCodes)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mTransactionStore := txSubStoreMocks.NewMockTransactionStore(t)
+			if tc.setupMocks != nil {
+				tc.setupMocks(mTransactionStore)
+			}
+			handler := PaymentsHandler{
+				Models:           models,
+				DBConnectionPool: dbConnectionPool,
+				TransactionStore: mTransactionStore,
+			}
+			r := chi.NewRouter()
+			r.Get("/payments/{id}/transactions", handler.GetPaymentTransactions)
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("/payments/%s/transactions", tc.paymentID), nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantStatusCode, rr.Code)
+			if tc.assertBody != nil {
+				tc.assertBody(t, rr.Body.Bytes())
+			}
+		})
+	}
+}
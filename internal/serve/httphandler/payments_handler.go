@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stellar/go/support/http/httpdecode"
@@ -23,7 +25,9 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/validators"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing"
+	txSubStore "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
 	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
 	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
 )
@@ -35,6 +39,8 @@ type PaymentsHandler struct {
 	EventProducer               events.Producer
 	CrashTrackerClient          crashtracker.CrashTrackerClient
 	DistributionAccountResolver signing.DistributionAccountResolver
+	DistributionAccountService  services.DistributionAccountServiceInterface
+	TransactionStore            txSubStore.TransactionStore
 }
 
 type RetryPaymentsRequest struct {
@@ -110,6 +116,48 @@ func (p PaymentsHandler) GetPayment(w http.ResponseWriter, r *http.Request) {
 	httpjson.RenderStatus(w, http.StatusOK, payments[0], httpjson.JSON)
 }
 
+// GetPaymentTransactions returns the transactions submitted to the Stellar network for a payment by the Transaction
+// Submission Service, oldest first, with the attempts of each one and their result codes decoded.
+func (p PaymentsHandler) GetPaymentTransactions(w http.ResponseWriter, r *http.Request) {
+	paymentID := chi.URLParam(r, "id")
+	ctx := r.Context()
+
+	_, err := p.Models.Payment.Get(ctx, paymentID, p.DBConnectionPool)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound(fmt.Sprintf("Cannot retrieve payment with ID: %s", paymentID), err, nil).Render(w)
+		} else {
+			httperror.InternalError(ctx, fmt.Sprintf("Cannot retrieve payment with id %s", paymentID), err, nil).Render(w)
+		}
+		return
+	}
+
+	tnt, err := tenant.GetTenantFromContext(ctx)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot retrieve tenant from context", err, nil).Render(w)
+		return
+	}
+
+	transactions, err := p.TransactionStore.GetAllByPaymentIDs(ctx, []string{paymentID})
+	if err != nil {
+		httperror.InternalError(ctx, fmt.Sprintf("Cannot retrieve transactions of payment with id %s", paymentID), err, nil).Render(w)
+		return
+	}
+	slices.SortStableFunc(transactions, func(a, b *txSubStore.Transaction) int {
+		return a.CreatedAt.Compare(*b.CreatedAt)
+	})
+
+	paymentTransactions := make([]PaymentStellarTransaction, 0, len(transactions))
+	for _, tx := range transactions {
+		if tx.TenantID != tnt.ID {
+			continue
+		}
+		paymentTransactions = append(paymentTransactions, newPaymentStellarTransaction(ctx, tx))
+	}
+
+	httpjson.Render(w, paymentTransactions, httpjson.JSON)
+}
+
 func (p PaymentsHandler) GetPayments(w http.ResponseWriter, r *http.Request) {
 	validator := validators.NewPaymentQueryValidator()
 	queryParams := validator.ParseParametersFromRequest(r)
@@ -307,7 +355,7 @@ func (p PaymentsHandler) PatchPaymentStatus(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	paymentManagementService := services.NewPaymentManagementService(p.Models, p.DBConnectionPool)
+	paymentManagementService := services.NewPaymentManagementService(p.Models, p.DBConnectionPool, p.DistributionAccountService)
 	response := UpdatePaymentStatusResponseBody{}
 
 	ctx := r.Context()
@@ -338,3 +386,203 @@ func (p PaymentsHandler) PatchPaymentStatus(w http.ResponseWriter, r *http.Reque
 
 	httpjson.RenderStatus(w, http.StatusOK, response, httpjson.JSON)
 }
+
+type PostPaymentCorrectionRequest struct {
+	Amount           string `json:"amount"`
+	ReceiverWalletID string `json:"receiver_wallet_id"`
+	Comment          string `json:"comment"`
+}
+
+func (r *PostPaymentCorrectionRequest) validate() *httperror.HTTPError {
+	r.Amount = strings.TrimSpace(r.Amount)
+	r.ReceiverWalletID = strings.TrimSpace(r.ReceiverWalletID)
+	r.Comment = strings.TrimSpace(r.Comment)
+
+	v := validators.NewValidator()
+	v.Check(r.Amount != "" || r.ReceiverWalletID != "", "body", "amount or receiver_wallet_id should be provided")
+	if r.Amount != "" {
+		v.CheckError(utils.ValidateAmount(r.Amount), "amount", "")
+	}
+	v.CheckError(utils.ValidateNoHTML(r.Comment), "comment", "comment cannot contain HTML, JS or CSS")
+	if v.HasErrors() {
+		return httperror.BadRequest("", nil, v.Errors)
+	}
+
+	return nil
+}
+
+// PostPaymentCorrection corrects the amount or the receiver wallet of a FAILED payment. The correction is applied and the
+// payment retried right away, unless the approval workflow is enabled for the organization, in which case it waits for
+// the approval of another user.
+func (p PaymentsHandler) PostPaymentCorrection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	paymentID := chi.URLParam(r, "id")
+
+	var reqBody PostPaymentCorrectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		httperror.BadRequest("invalid request body", err, nil).Render(w)
+		return
+	}
+	if httpErr := reqBody.validate(); httpErr != nil {
+		httpErr.Render(w)
+		return
+	}
+
+	_, user, httpErr := getTokenAndUser(ctx, p.AuthManager)
+	if httpErr != nil {
+		httpErr.Render(w)
+		return
+	}
+
+	distributionAccount, err := p.DistributionAccountResolver.DistributionAccountFromContext(ctx)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get distribution account", err, nil).Render(w)
+		return
+	}
+
+	paymentManagementService := services.NewPaymentManagementService(p.Models, p.DBConnectionPool, p.DistributionAccountService)
+	correction, err := paymentManagementService.CorrectPayment(ctx, paymentID, user, services.PaymentCorrectionRequest{
+		Amount:           reqBody.Amount,
+		ReceiverWalletID: reqBody.ReceiverWalletID,
+		Comment:          reqBody.Comment,
+	}, &distributionAccount)
+	if err != nil {
+		p.renderPaymentCorrectionError(ctx, w, paymentID, err)
+		return
+	}
+
+	if correction.Status == data.AppliedPaymentCorrectionStatus {
+		p.produceRetriedPaymentsEvent(ctx, correction.PaymentID)
+	}
+
+	httpjson.RenderStatus(w, http.StatusCreated, correction, httpjson.JSON)
+}
+
+// PostPaymentCorrectionApproval applies a correction pending approval and retries the payment. Corrections can't be
+// approved by the user who requested them.
+func (p PaymentsHandler) PostPaymentCorrectionApproval(w http.ResponseWriter, r *http.Request) {
+	p.reviewPaymentCorrection(w, r, data.AppliedPaymentCorrectionStatus)
+}
+
+// PostPaymentCorrectionRejection rejects a correction pending approval, leaving the payment unchanged.
+func (p PaymentsHandler) PostPaymentCorrectionRejection(w http.ResponseWriter, r *http.Request) {
+	p.reviewPaymentCorrection(w, r, data.RejectedPaymentCorrectionStatus)
+}
+
+func (p PaymentsHandler) reviewPaymentCorrection(w http.ResponseWriter, r *http.Request, status data.PaymentCorrectionStatus) {
+	ctx := r.Context()
+	paymentID := chi.URLParam(r, "id")
+	correctionID := chi.URLParam(r, "correctionID")
+
+	_, user, httpErr := getTokenAndUser(ctx, p.AuthManager)
+	if httpErr != nil {
+		httpErr.Render(w)
+		return
+	}
+
+	paymentManagementService := services.NewPaymentManagementService(p.Models, p.DBConnectionPool, p.DistributionAccountService)
+	var correction *data.PaymentCorrection
+	var err error
+	if status == data.AppliedPaymentCorrectionStatus {
+		var distributionAccount schema.TransactionAccount
+		if distributionAccount, err = p.DistributionAccountResolver.DistributionAccountFromContext(ctx); err != nil {
+			httperror.InternalError(ctx, "Cannot get distribution account", err, nil).Render(w)
+			return
+		}
+		correction, err = paymentManagementService.ApprovePaymentCorrection(ctx, paymentID, correctionID, user, &distributionAccount)
+	} else {
+		correction, err = paymentManagementService.RejectPaymentCorrection(ctx, paymentID, correctionID, user)
+	}
+	if err != nil {
+		p.renderPaymentCorrectionError(ctx, w, paymentID, err)
+		return
+	}
+
+	if correction.Status == data.AppliedPaymentCorrectionStatus {
+		p.produceRetriedPaymentsEvent(ctx, correction.PaymentID)
+	}
+
+	httpjson.Render(w, correction, httpjson.JSON)
+}
+
+// paymentCorrectionBadRequestErrors are the errors of payment corrections rendered as bad requests.
+var paymentCorrectionBadRequestErrors = []error{
+	services.ErrPaymentNotFailed,
+	services.ErrPaymentCorrectionWithoutChanges,
+	services.ErrPaymentCorrectionInvalidAmount,
+	services.ErrPaymentCorrectionInvalidReceiverWallet,
+	services.ErrPaymentCorrectionNotPending,
+}
+
+func (p PaymentsHandler) renderPaymentCorrectionError(ctx context.Context, w http.ResponseWriter, paymentID string, err error) {
+	for _, badRequestErr := range paymentCorrectionBadRequestErrors {
+		if errors.Is(err, badRequestErr) {
+			httperror.BadRequest(badRequestErr.Error(), err, nil).Render(w)
+			return
+		}
+	}
+
+	var insufficientBalanceErr services.InsufficientBalanceError
+	switch {
+	case errors.Is(err, services.ErrPaymentNotFound):
+		httperror.NotFound(services.ErrPaymentNotFound.Error(), err, nil).Render(w)
+	case errors.As(err, &insufficientBalanceErr):
+		httperror.Conflict(insufficientBalanceErr.Error(), err, nil).Render(w)
+	case errors.Is(err, services.ErrPaymentCorrectionNotFound):
+		httperror.NotFound(services.ErrPaymentCorrectionNotFound.Error(), err, nil).Render(w)
+	case errors.Is(err, services.ErrPaymentCorrectionReviewedByRequester):
+		httperror.Forbidden(services.ErrPaymentCorrectionReviewedByRequester.Error(), err, nil).Render(w)
+	case errors.Is(err, data.ErrPaymentCorrectionAlreadyPending):
+		httperror.Conflict(data.ErrPaymentCorrectionAlreadyPending.Error(), err, nil).Render(w)
+	default:
+		msg := fmt.Sprintf("Cannot correct payment ID %s", paymentID)
+		httperror.InternalError(ctx, msg, err, nil).Render(w)
+	}
+}
+
+// produceRetriedPaymentsEvent sends the payments that are READY to be paid again to the TSS. It's called after the
+// changes are committed, so failures are only reported and the payments are left READY.
+func (p PaymentsHandler) produceRetriedPaymentsEvent(ctx context.Context, paymentIDs ...string) {
+	payments, err := p.Models.Payment.GetReadyByID(ctx, p.DBConnectionPool, paymentIDs...)
+	if err != nil {
+		p.CrashTrackerClient.LogAndReportErrors(ctx, err, "getting ready payments by IDs")
+		return
+	}
+	if len(payments) == 0 {
+		return
+	}
+
+	msg, err := p.buildPaymentsReadyEventMessage(ctx, payments)
+	if err != nil {
+		p.CrashTrackerClient.LogAndReportErrors(ctx, err, "building event message for payment retry")
+		return
+	}
+
+	if err = events.ProduceEvents(ctx, p.EventProducer, msg); err != nil {
+		p.CrashTrackerClient.LogAndReportErrors(ctx, err, "writing retry payment message on the event producer")
+	}
+}
+
+// GetPaymentCorrections returns all the corrections of the payment, oldest first.
+func (p PaymentsHandler) GetPaymentCorrections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	paymentID := chi.URLParam(r, "id")
+
+	_, err := p.Models.Payment.Get(ctx, paymentID, p.DBConnectionPool)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound(services.ErrPaymentNotFound.Error(), err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot get payment", err, nil).Render(w)
+		return
+	}
+
+	corrections, err := p.Models.PaymentCorrections.GetAllByPaymentID(ctx, p.DBConnectionPool, paymentID)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get payment corrections", err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, corrections, httpjson.JSON)
+}
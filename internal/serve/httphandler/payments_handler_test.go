@@ -29,6 +29,7 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httpresponse"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/middleware"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
+	svcMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/services/mocks"
 	sigMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing/mocks"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
 	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
@@ -1764,3 +1765,225 @@ func Test_PaymentsHandler_PatchPaymentStatus(t *testing.T) {
 
 	authManagerMock.AssertExpectations(t)
 }
+
+func Test_PostPaymentCorrectionRequest_validate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		request    PostPaymentCorrectionRequest
+		wantExtras map[string]interface{}
+	}{
+		{
+			name:       "amount or receiver wallet is required",
+			request:    PostPaymentCorrectionRequest{Comment: "fix"},
+			wantExtras: map[string]interface{}{"body": "amount or receiver_wallet_id should be provided"},
+		},
+		{
+			name:       "invalid amount",
+			request:    PostPaymentCorrectionRequest{Amount: "-1"},
+			wantExtras: map[string]interface{}{"amount": "the provided amount must be greater than zero"},
+		},
+		{
+			name:       "comment with HTML",
+			request:    PostPaymentCorrectionRequest{ReceiverWalletID: "rw-id", Comment: "<script>alert(1)</script>"},
+			wantExtras: map[string]interface{}{"comment": "comment cannot contain HTML, JS or CSS"},
+		},
+		{
+			name:    "🎉 valid amount",
+			request: PostPaymentCorrectionRequest{Amount: " 10.5 "},
+		},
+		{
+			name:    "🎉 valid receiver wallet",
+			request: PostPaymentCorrectionRequest{ReceiverWalletID: "rw-id"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpErr := tc.request.validate()
+			if tc.wantExtras == nil {
+				assert.Nil(t, httpErr)
+			} else {
+				require.NotNil(t, httpErr)
+				assert.Equal(t, tc.wantExtras, httpErr.Extras)
+			}
+		})
+	}
+}
+
+func Test_PaymentsHandler_PaymentCorrections(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	tnt := tenant.Tenant{ID: "tenant-id"}
+	ctx := tenant.SaveTenantInContext(context.Background(), &tnt)
+	ctx = context.WithValue(ctx, middleware.TokenContextKey, "mytoken")
+
+	wallet := data.CreateWalletFixture(t, ctx, dbConnectionPool, "Wallet", "https://www.wallet.com", "www.wallet.com", "wallet://")
+	asset := data.CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVV")
+	receiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+	receiverWallet := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, data.RegisteredReceiversWalletStatus)
+	disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Wallet: wallet,
+		Asset:  asset,
+		Status: data.StartedDisbursementStatus,
+	})
+
+	requester := &auth.User{ID: "requester-id", Email: "requester@test.com"}
+	approver := &auth.User{ID: "approver-id", Email: "approver@test.com"}
+	distributionAccount := schema.TransactionAccount{Type: schema.DistributionAccountStellarEnv, Address: "GAAHIL6ZW4QFNLCKALZ3YOIWPP4TXQ7B7J5IU7RLNVGQAV6GFDZHLDTA"}
+
+	newHandler := func(t *testing.T, user *auth.User, expectRetry bool) PaymentsHandler {
+		authManagerMock := auth.NewAuthManagerMock(t)
+		authManagerMock.
+			On("GetUser", mock.Anything, "mytoken").
+			Return(user, nil).
+			Once()
+		distAccountResolverMock := sigMocks.NewMockDistributionAccountResolver(t)
+		distAccountResolverMock.
+			On("DistributionAccountFromContext", mock.Anything).
+			Return(distributionAccount, nil).
+			Maybe()
+		handler := PaymentsHandler{
+			Models:                      models,
+			DBConnectionPool:            dbConnectionPool,
+			AuthManager:                 authManagerMock,
+			DistributionAccountResolver: distAccountResolverMock,
+			DistributionAccountService:  svcMocks.NewMockDistributionAccountService(t),
+		}
+		if expectRetry {
+			eventProducerMock := events.NewMockProducer(t)
+			eventProducerMock.
+				On("WriteMessages", mock.Anything, mock.AnythingOfType("[]events.Message")).
+				Return(nil).
+				Once()
+			handler.EventProducer = eventProducerMock
+		}
+		return handler
+	}
+
+	serve := func(handler PaymentsHandler, method, path, body string) (int, string) {
+		r := chi.NewRouter()
+		r.Get("/payments/{id}/corrections", handler.GetPaymentCorrections)
+		r.Post("/payments/{id}/corrections", handler.PostPaymentCorrection)
+		r.Post("/payments/{id}/corrections/{correctionID}/approve", handler.PostPaymentCorrectionApproval)
+		r.Post("/payments/{id}/corrections/{correctionID}/reject", handler.PostPaymentCorrectionRejection)
+
+		req, err := http.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Code, rr.Body.String()
+	}
+
+	createFailedPayment := func(t *testing.T) *data.Payment {
+		return data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+			Amount:         "100",
+			Status:         data.FailedPaymentStatus,
+			Disbursement:   disbursement,
+			ReceiverWallet: receiverWallet,
+			Asset:          *asset,
+		})
+	}
+
+	t.Run("returns BadRequest when the payment is not failed", func(t *testing.T) {
+		payment := data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+			Amount:         "100",
+			Status:         data.SuccessPaymentStatus,
+			Disbursement:   disbursement,
+			ReceiverWallet: receiverWallet,
+			Asset:          *asset,
+		})
+
+		code, body := serve(newHandler(t, requester, false), http.MethodPost, "/payments/"+payment.ID+"/corrections", `{"amount": "90"}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.JSONEq(t, `{"error": "only failed payments can be corrected"}`, body)
+	})
+
+	t.Run("returns NotFound when the payment doesn't exist", func(t *testing.T) {
+		code, body := serve(newHandler(t, requester, false), http.MethodPost, "/payments/invalid-id/corrections", `{"amount": "90"}`)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.JSONEq(t, `{"error": "payment not found"}`, body)
+	})
+
+	t.Run("returns Conflict when the distribution account balance can't cover an increased amount", func(t *testing.T) {
+		data.DisableDisbursementApproval(t, ctx, models.Organizations)
+		payment := createFailedPayment(t)
+
+		handler := newHandler(t, requester, false)
+		distAccountServiceMock := svcMocks.NewMockDistributionAccountService(t)
+		distAccountServiceMock.
+			On("GetBalance", mock.Anything, &distributionAccount, *asset).
+			Return(100.0, nil).
+			Once()
+		handler.DistributionAccountService = distAccountServiceMock
+
+		code, body := serve(handler, http.MethodPost, "/payments/"+payment.ID+"/corrections", `{"amount": "150"}`)
+		assert.Equal(t, http.StatusConflict, code)
+		assert.Contains(t, body, "insufficient to fulfill new amount (150.00)")
+
+		paymentDB, err := models.Payment.Get(ctx, payment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, data.FailedPaymentStatus, paymentDB.Status)
+		assert.Equal(t, "100.0000000", paymentDB.Amount)
+	})
+
+	t.Run("🎉 applies the correction and retries the payment when the approval workflow is disabled", func(t *testing.T) {
+		data.DisableDisbursementApproval(t, ctx, models.Organizations)
+		payment := createFailedPayment(t)
+
+		code, body := serve(newHandler(t, requester, true), http.MethodPost, "/payments/"+payment.ID+"/corrections", `{"amount": "90", "comment": "fee was deducted twice"}`)
+		require.Equal(t, http.StatusCreated, code, body)
+
+		var correction data.PaymentCorrection
+		require.NoError(t, json.Unmarshal([]byte(body), &correction))
+		assert.Equal(t, data.AppliedPaymentCorrectionStatus, correction.Status)
+		assert.Equal(t, "90.0000000", correction.Amount)
+		assert.Equal(t, "fee was deducted twice", correction.Comment)
+
+		paymentDB, err := models.Payment.Get(ctx, payment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, data.ReadyPaymentStatus, paymentDB.Status)
+		assert.Equal(t, "90.0000000", paymentDB.Amount)
+
+		code, body = serve(PaymentsHandler{Models: models, DBConnectionPool: dbConnectionPool}, http.MethodGet, "/payments/"+payment.ID+"/corrections", "")
+		require.Equal(t, http.StatusOK, code)
+		var corrections []data.PaymentCorrection
+		require.NoError(t, json.Unmarshal([]byte(body), &corrections))
+		require.Len(t, corrections, 1)
+		assert.Equal(t, correction.ID, corrections[0].ID)
+	})
+
+	t.Run("🎉 requires the approval of another user when the approval workflow is enabled", func(t *testing.T) {
+		data.EnableDisbursementApproval(t, ctx, models.Organizations)
+		defer data.DisableDisbursementApproval(t, ctx, models.Organizations)
+		payment := createFailedPayment(t)
+
+		code, body := serve(newHandler(t, requester, false), http.MethodPost, "/payments/"+payment.ID+"/corrections", `{"amount": "90"}`)
+		require.Equal(t, http.StatusCreated, code, body)
+		var correction data.PaymentCorrection
+		require.NoError(t, json.Unmarshal([]byte(body), &correction))
+		assert.Equal(t, data.PendingApprovalPaymentCorrectionStatus, correction.Status)
+
+		approvePath := fmt.Sprintf("/payments/%s/corrections/%s/approve", payment.ID, correction.ID)
+		code, body = serve(newHandler(t, requester, false), http.MethodPost, approvePath, "")
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.JSONEq(t, `{"error": "payment correction can't be reviewed by the user who requested it"}`, body)
+
+		code, body = serve(newHandler(t, approver, true), http.MethodPost, approvePath, "")
+		require.Equal(t, http.StatusOK, code, body)
+		require.NoError(t, json.Unmarshal([]byte(body), &correction))
+		assert.Equal(t, data.AppliedPaymentCorrectionStatus, correction.Status)
+		assert.Equal(t, approver.ID, correction.ReviewedBy)
+
+		rejectPath := fmt.Sprintf("/payments/%s/corrections/%s/reject", payment.ID, correction.ID)
+		code, body = serve(newHandler(t, approver, false), http.MethodPost, rejectPath, "")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.JSONEq(t, `{"error": "payment correction is not pending approval"}`, body)
+	})
+}
@@ -52,15 +52,21 @@ type ProfileHandler struct {
 }
 
 type PatchOrganizationProfileRequest struct {
-	OrganizationName                    string  `json:"organization_name"`
-	TimezoneUTCOffset                   string  `json:"timezone_utc_offset"`
-	IsApprovalRequired                  *bool   `json:"is_approval_required"`
-	IsLinkShortenerEnabled              *bool   `json:"is_link_shortener_enabled"`
-	ReceiverInvitationResendInterval    *int64  `json:"receiver_invitation_resend_interval_days"`
-	PaymentCancellationPeriodDays       *int64  `json:"payment_cancellation_period_days"`
-	ReceiverRegistrationMessageTemplate *string `json:"receiver_registration_message_template"`
-	OTPMessageTemplate                  *string `json:"otp_message_template"`
-	PrivacyPolicyLink                   *string `json:"privacy_policy_link"`
+	OrganizationName                    string                    `json:"organization_name"`
+	TimezoneUTCOffset                   string                    `json:"timezone_utc_offset"`
+	IsApprovalRequired                  *bool                     `json:"is_approval_required"`
+	ApprovalQuorumRules                 *data.ApprovalQuorumRules `json:"approval_quorum_rules"`
+	IsLinkShortenerEnabled              *bool                     `json:"is_link_shortener_enabled"`
+	ReceiverInvitationResendInterval    *int64                    `json:"receiver_invitation_resend_interval_days"`
+	PaymentCancellationPeriodDays       *int64                    `json:"payment_cancellation_period_days"`
+	ReceiverRegistrationMessageTemplate *string                   `json:"receiver_registration_message_template"`
+	OTPMessageTemplate                  *string                   `json:"otp_message_template"`
+	PrivacyPolicyLink                   *string                   `json:"privacy_policy_link"`
+	VerificationMaxAttempts             *int                      `json:"verification_max_attempts"`
+	VerificationLockoutMinutes          *int                      `json:"verification_lockout_minutes"`
+	RegistrationRateLimitWindowMinutes  *int                      `json:"registration_rate_limit_window_minutes"`
+	RegistrationRateLimitPerContact     *int                      `json:"registration_rate_limit_per_contact"`
+	RegistrationRateLimitPerIP          *int                      `json:"registration_rate_limit_per_ip"`
 }
 
 func (r *PatchOrganizationProfileRequest) AreAllFieldsEmpty() bool {
@@ -163,9 +169,31 @@ func (h ProfileHandler) PatchOrganizationProfile(rw http.ResponseWriter, req *ht
 		}
 		validator.CheckError(utils.ValidateURLScheme(*reqBody.PrivacyPolicyLink, schemes...), "privacy_policy_link", "")
 	}
+	if reqBody.ApprovalQuorumRules != nil {
+		validator.CheckError(reqBody.ApprovalQuorumRules.Validate(), "approval_quorum_rules", "")
+	}
 	if reqBody.ReceiverRegistrationMessageTemplate != nil {
 		validator.CheckError(utils.ValidateNoHTML(*reqBody.ReceiverRegistrationMessageTemplate), "receiver_registration_message_template", "receiver_registration_message_template cannot contain HTML, JS or CSS")
 	}
+	if reqBody.VerificationMaxAttempts != nil {
+		validator.Check(*reqBody.VerificationMaxAttempts > 0, "verification_max_attempts", "verification_max_attempts must be greater than zero")
+	}
+	if reqBody.VerificationLockoutMinutes != nil {
+		validator.Check(*reqBody.VerificationLockoutMinutes >= 0, "verification_lockout_minutes", "verification_lockout_minutes cannot be negative")
+	}
+	if reqBody.RegistrationRateLimitWindowMinutes != nil {
+		validator.Check(
+			*reqBody.RegistrationRateLimitWindowMinutes > 0 && *reqBody.RegistrationRateLimitWindowMinutes <= data.MaxRegistrationRateLimitWindowMinutes,
+			"registration_rate_limit_window_minutes",
+			fmt.Sprintf("registration_rate_limit_window_minutes must be between 1 and %d", data.MaxRegistrationRateLimitWindowMinutes),
+		)
+	}
+	if reqBody.RegistrationRateLimitPerContact != nil {
+		validator.Check(*reqBody.RegistrationRateLimitPerContact >= 0, "registration_rate_limit_per_contact", "registration_rate_limit_per_contact cannot be negative")
+	}
+	if reqBody.RegistrationRateLimitPerIP != nil {
+		validator.Check(*reqBody.RegistrationRateLimitPerIP >= 0, "registration_rate_limit_per_ip", "registration_rate_limit_per_ip cannot be negative")
+	}
 	if validator.HasErrors() {
 		httperror.BadRequest("", nil, validator.Errors).Render(rw)
 		return
@@ -176,12 +204,18 @@ func (h ProfileHandler) PatchOrganizationProfile(rw http.ResponseWriter, req *ht
 		Logo:                                 fileContentBytes,
 		TimezoneUTCOffset:                    reqBody.TimezoneUTCOffset,
 		IsApprovalRequired:                   reqBody.IsApprovalRequired,
+		ApprovalQuorumRules:                  reqBody.ApprovalQuorumRules,
 		IsLinkShortenerEnabled:               reqBody.IsLinkShortenerEnabled,
 		ReceiverRegistrationMessageTemplate:  reqBody.ReceiverRegistrationMessageTemplate,
 		OTPMessageTemplate:                   reqBody.OTPMessageTemplate,
 		ReceiverInvitationResendIntervalDays: reqBody.ReceiverInvitationResendInterval,
 		PaymentCancellationPeriodDays:        reqBody.PaymentCancellationPeriodDays,
 		PrivacyPolicyLink:                    reqBody.PrivacyPolicyLink,
+		VerificationMaxAttempts:              reqBody.VerificationMaxAttempts,
+		VerificationLockoutMinutes:           reqBody.VerificationLockoutMinutes,
+		RegistrationRateLimitWindowMinutes:   reqBody.RegistrationRateLimitWindowMinutes,
+		RegistrationRateLimitPerContact:      reqBody.RegistrationRateLimitPerContact,
+		RegistrationRateLimitPerIP:           reqBody.RegistrationRateLimitPerIP,
 	}
 	requestDict, err := utils.ConvertType[data.OrganizationUpdate, map[string]interface{}](organizationUpdate)
 	if err != nil {
@@ -376,11 +410,17 @@ func (h ProfileHandler) GetOrganizationInfo(rw http.ResponseWriter, req *http.Re
 		"distribution_account_public_key": distributionAccount.Address, // TODO: deprecate `distribution_account_public_key`
 		"timezone_utc_offset":             org.TimezoneUTCOffset,
 		"is_approval_required":            org.IsApprovalRequired,
+		"approval_quorum_rules":           org.ApprovalQuorumRules,
 		"is_link_shortener_enabled":       org.IsLinkShortenerEnabled,
 		"receiver_invitation_resend_interval_days": 0,
 		"payment_cancellation_period_days":         0,
 		"privacy_policy_link":                      org.PrivacyPolicyLink,
 		"message_channel_priority":                 org.MessageChannelPriority,
+		"verification_max_attempts":                org.VerificationMaxAttempts,
+		"verification_lockout_minutes":             org.VerificationLockoutMinutes,
+		"registration_rate_limit_window_minutes":   org.RegistrationRateLimitWindowMinutes,
+		"registration_rate_limit_per_contact":      org.RegistrationRateLimitPerContact,
+		"registration_rate_limit_per_ip":           org.RegistrationRateLimitPerIP,
 	}
 
 	if org.ReceiverRegistrationMessageTemplate != data.DefaultReceiverRegistrationMessageTemplate {
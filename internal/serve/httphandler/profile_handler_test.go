@@ -300,6 +300,60 @@ func Test_ProfileHandler_PatchOrganizationProfile_Failures(t *testing.T) {
 				}
 			}`,
 		},
+		{
+			name:  "returns BadRequest when the approval_quorum_rules are invalid",
+			token: "token",
+			mockAuthManagerFn: func(authManagerMock *auth.AuthManagerMock) {
+				authManagerMock.
+					On("GetUser", mock.Anything, "token").
+					Return(user, nil).
+					Once()
+			},
+			getRequestFn: func(t *testing.T, ctx context.Context) *http.Request {
+				reqBody := `{
+					"approval_quorum_rules": [{"min_amount": "1000", "required_approvals": 0}]
+				}`
+				return createOrganizationProfileMultipartRequest(t, ctx, url, "", "", reqBody, new(bytes.Buffer))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantRespBody: `{
+				"error": "The request was invalid in some way.",
+				"extras": {
+					"approval_quorum_rules": "rule 0: required_approvals must be greater than zero"
+				}
+			}`,
+		},
+		{
+			name:  "returns BadRequest when the registration protection settings are invalid",
+			token: "token",
+			mockAuthManagerFn: func(authManagerMock *auth.AuthManagerMock) {
+				authManagerMock.
+					On("GetUser", mock.Anything, "token").
+					Return(user, nil).
+					Once()
+			},
+			getRequestFn: func(t *testing.T, ctx context.Context) *http.Request {
+				reqBody := `{
+					"verification_max_attempts": 0,
+					"verification_lockout_minutes": -1,
+					"registration_rate_limit_window_minutes": 1441,
+					"registration_rate_limit_per_contact": -1,
+					"registration_rate_limit_per_ip": -1
+				}`
+				return createOrganizationProfileMultipartRequest(t, ctx, url, "", "", reqBody, new(bytes.Buffer))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantRespBody: `{
+				"error": "The request was invalid in some way.",
+				"extras": {
+					"verification_max_attempts": "verification_max_attempts must be greater than zero",
+					"verification_lockout_minutes": "verification_lockout_minutes cannot be negative",
+					"registration_rate_limit_window_minutes": "registration_rate_limit_window_minutes must be between 1 and 1440",
+					"registration_rate_limit_per_contact": "registration_rate_limit_per_contact cannot be negative",
+					"registration_rate_limit_per_ip": "registration_rate_limit_per_ip cannot be negative"
+				}
+			}`,
+		},
 		{
 			name:  "returns BadRequest when receiver_registration_message_template contains HTML",
 			token: "token",
@@ -499,6 +553,52 @@ func Test_ProfileHandler_PatchOrganizationProfile_Successful(t *testing.T) {
 			},
 			wantLogEntries: []string{"[PatchOrganizationProfile] - userID user-id will update the organization fields [IsApprovalRequired='true', Logo='...', Name='My Org Name', OTPMessageTemplate='Here's your OTP Code to complete your registration. MyOrg 👋', PaymentCancellationPeriodDays='2', PrivacyPolicyLink='https://example.com/privacy-policy', ReceiverInvitationResendIntervalDays='2', ReceiverRegistrationMessageTemplate='My custom receiver wallet registration invite. MyOrg 👋', TimezoneUTCOffset='-03:00']"},
 		},
+		{
+			name:  "🎉 successfully updates the organization's approval quorum rules",
+			token: "token",
+			mockAuthManagerFn: func(authManagerMock *auth.AuthManagerMock) {
+				authManagerMock.
+					On("GetUser", mock.Anything, "token").
+					Return(user, nil).
+					Once()
+			},
+			getRequestFn: func(t *testing.T, ctx context.Context) *http.Request {
+				reqBody := `{
+					"approval_quorum_rules": [{"min_amount": "1000", "required_approvals": 2, "role": "financial_controller"}]
+				}`
+				return createOrganizationProfileMultipartRequest(t, ctx, url, "", "", reqBody, new(bytes.Buffer))
+			},
+			resultingFieldsToCompare: map[string]interface{}{
+				"ApprovalQuorumRules": data.ApprovalQuorumRules{{MinAmount: "1000", RequiredApprovals: 2, Role: data.FinancialControllerUserRole}},
+			},
+		},
+		{
+			name:  "🎉 successfully updates the organization's registration protection settings",
+			token: "token",
+			mockAuthManagerFn: func(authManagerMock *auth.AuthManagerMock) {
+				authManagerMock.
+					On("GetUser", mock.Anything, "token").
+					Return(user, nil).
+					Once()
+			},
+			getRequestFn: func(t *testing.T, ctx context.Context) *http.Request {
+				reqBody := `{
+					"verification_max_attempts": 5,
+					"verification_lockout_minutes": 0,
+					"registration_rate_limit_window_minutes": 30,
+					"registration_rate_limit_per_contact": 3,
+					"registration_rate_limit_per_ip": 0
+				}`
+				return createOrganizationProfileMultipartRequest(t, ctx, url, "", "", reqBody, new(bytes.Buffer))
+			},
+			resultingFieldsToCompare: map[string]interface{}{
+				"VerificationMaxAttempts":            5,
+				"VerificationLockoutMinutes":         0,
+				"RegistrationRateLimitWindowMinutes": 30,
+				"RegistrationRateLimitPerContact":    3,
+				"RegistrationRateLimitPerIP":         0,
+			},
+		},
 		{
 			name:  "🎉 successfully updates organization back to its default values",
 			token: "token",
@@ -1257,11 +1357,17 @@ func Test_ProfileHandler_GetOrganizationInfo(t *testing.T) {
 				"distribution_account_public_key": %q,
 				"timezone_utc_offset": "+00:00",
 				"is_approval_required": false,
+				"approval_quorum_rules": [],
 				"is_link_shortener_enabled": false,
 				"privacy_policy_link": null,
 				"receiver_invitation_resend_interval_days": 0,
 				"payment_cancellation_period_days": 0,
-				"message_channel_priority": ["SMS", "EMAIL"]
+				"message_channel_priority": ["SMS", "EMAIL", "WHATSAPP"],
+				"verification_max_attempts": 15,
+				"verification_lockout_minutes": 60,
+				"registration_rate_limit_window_minutes": 60,
+				"registration_rate_limit_per_contact": 10,
+				"registration_rate_limit_per_ip": 0
 			}
 		`, *currentTenant.BaseURL, newDistAccountJSON(t, *currentTenant.DistributionAccountAddress), *currentTenant.DistributionAccountAddress)
 
@@ -1297,12 +1403,18 @@ func Test_ProfileHandler_GetOrganizationInfo(t *testing.T) {
 				"distribution_account_public_key": %q,
 				"timezone_utc_offset": "+00:00",
 				"is_approval_required":false,
+				"approval_quorum_rules": [],
 				"is_link_shortener_enabled": false,
 				"receiver_registration_message_template": "My custom receiver wallet registration invite. MyOrg 👋",
 				"receiver_invitation_resend_interval_days": 0,
 				"payment_cancellation_period_days": 0,
 				"privacy_policy_link": null,
-				"message_channel_priority": ["SMS", "EMAIL"]
+				"message_channel_priority": ["SMS", "EMAIL", "WHATSAPP"],
+				"verification_max_attempts": 15,
+				"verification_lockout_minutes": 60,
+				"registration_rate_limit_window_minutes": 60,
+				"registration_rate_limit_per_contact": 10,
+				"registration_rate_limit_per_ip": 0
 			}
 		`, *currentTenant.BaseURL, newDistAccountJSON(t, *currentTenant.DistributionAccountAddress), *currentTenant.DistributionAccountAddress)
 
@@ -1334,13 +1446,19 @@ func Test_ProfileHandler_GetOrganizationInfo(t *testing.T) {
 				"distribution_account_public_key": %q,
 				"timezone_utc_offset": "+00:00",
 				"is_approval_required":false,
+				"approval_quorum_rules": [],
 				"is_link_shortener_enabled": false,
 				"receiver_registration_message_template": "My custom receiver wallet registration invite. MyOrg 👋",
 				"otp_message_template": "Here's your OTP Code to complete your registration. MyOrg 👋",
 				"receiver_invitation_resend_interval_days": 0,
 				"payment_cancellation_period_days": 0,
 				"privacy_policy_link": null,
-				"message_channel_priority": ["SMS", "EMAIL"]
+				"message_channel_priority": ["SMS", "EMAIL", "WHATSAPP"],
+				"verification_max_attempts": 15,
+				"verification_lockout_minutes": 60,
+				"registration_rate_limit_window_minutes": 60,
+				"registration_rate_limit_per_contact": 10,
+				"registration_rate_limit_per_ip": 0
 			}
 		`, *currentTenant.BaseURL, newDistAccountJSON(t, *currentTenant.DistributionAccountAddress), *currentTenant.DistributionAccountAddress)
 
@@ -1378,11 +1496,17 @@ func Test_ProfileHandler_GetOrganizationInfo(t *testing.T) {
 				"distribution_account_public_key": %q,
 				"timezone_utc_offset": "+00:00",
 				"is_approval_required":false,
+				"approval_quorum_rules": [],
 				"is_link_shortener_enabled": false,
 				"receiver_invitation_resend_interval_days": 2,
 				"payment_cancellation_period_days": 0,
 				"privacy_policy_link": null,
-				"message_channel_priority": ["SMS", "EMAIL"]
+				"message_channel_priority": ["SMS", "EMAIL", "WHATSAPP"],
+				"verification_max_attempts": 15,
+				"verification_lockout_minutes": 60,
+				"registration_rate_limit_window_minutes": 60,
+				"registration_rate_limit_per_contact": 10,
+				"registration_rate_limit_per_ip": 0
 			}
 		`, *currentTenant.BaseURL, newDistAccountJSON(t, *currentTenant.DistributionAccountAddress), *currentTenant.DistributionAccountAddress)
 
@@ -1420,11 +1544,17 @@ func Test_ProfileHandler_GetOrganizationInfo(t *testing.T) {
 				"distribution_account_public_key": %q,
 				"timezone_utc_offset": "+00:00",
 				"is_approval_required":false,
+				"approval_quorum_rules": [],
 				"is_link_shortener_enabled": false,
 				"receiver_invitation_resend_interval_days": 0,
 				"payment_cancellation_period_days": 5,
 				"privacy_policy_link": null,
-				"message_channel_priority": ["SMS", "EMAIL"]
+				"message_channel_priority": ["SMS", "EMAIL", "WHATSAPP"],
+				"verification_max_attempts": 15,
+				"verification_lockout_minutes": 60,
+				"registration_rate_limit_window_minutes": 60,
+				"registration_rate_limit_per_contact": 10,
+				"registration_rate_limit_per_ip": 0
 			}
 		`, *currentTenant.BaseURL, newDistAccountJSON(t, *currentTenant.DistributionAccountAddress), *currentTenant.DistributionAccountAddress)
 
@@ -1462,11 +1592,17 @@ func Test_ProfileHandler_GetOrganizationInfo(t *testing.T) {
 				"distribution_account_public_key": %q,
 				"timezone_utc_offset": "+00:00",
 				"is_approval_required":false,
+				"approval_quorum_rules": [],
 				"is_link_shortener_enabled": false,
 				"receiver_invitation_resend_interval_days": 0,
 				"payment_cancellation_period_days": 0,
 				"privacy_policy_link": "https://example.com/privacy-policy",
-				"message_channel_priority": ["SMS", "EMAIL"]
+				"message_channel_priority": ["SMS", "EMAIL", "WHATSAPP"],
+				"verification_max_attempts": 15,
+				"verification_lockout_minutes": 60,
+				"registration_rate_limit_window_minutes": 60,
+				"registration_rate_limit_per_contact": 10,
+				"registration_rate_limit_per_ip": 0
 			}
 		`, *currentTenant.BaseURL, newDistAccountJSON(t, *currentTenant.DistributionAccountAddress), *currentTenant.DistributionAccountAddress)
 
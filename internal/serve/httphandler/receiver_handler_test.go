@@ -176,6 +176,8 @@ func Test_ReceiverHandlerGet(t *testing.T) {
 					"invitation_sent_at": null,
 					"invited_at": %q,
 					"last_message_sent_at": %q,
+					"last_message_status": "SUCCESS",
+					"last_message_type": "TWILIO_SMS",
 					"total_payments": "1",
 					"payments_received": "1",
 					"failed_payments": "0",
@@ -280,6 +282,8 @@ func Test_ReceiverHandlerGet(t *testing.T) {
 					"invitation_sent_at": null,
 					"invited_at": %q,
 					"last_message_sent_at": %q,
+					"last_message_status": "SUCCESS",
+					"last_message_type": "TWILIO_SMS",
 					"total_payments": "1",
 					"payments_received": "1",
 					"failed_payments": "0",
@@ -314,6 +318,8 @@ func Test_ReceiverHandlerGet(t *testing.T) {
 					"invitation_sent_at": null,
 					"invited_at": %q,
 					"last_message_sent_at": %q,
+					"last_message_status": "SUCCESS",
+					"last_message_type": "TWILIO_SMS",
 					"total_payments": "1",
 					"payments_received": "0",
 					"failed_payments": "0",
@@ -678,6 +684,8 @@ func Test_ReceiverHandler_GetReceivers_Success(t *testing.T) {
 								"invitation_sent_at": null,
 								"invited_at": %q,
 								"last_message_sent_at": %q,
+								"last_message_status": "SUCCESS",
+								"last_message_type": "TWILIO_SMS",
 								"total_payments": "0",
 								"payments_received": "0",
 								"failed_payments": "0",
@@ -728,6 +736,8 @@ func Test_ReceiverHandler_GetReceivers_Success(t *testing.T) {
 								"invitation_sent_at": null,
 								"invited_at": %q,
 								"last_message_sent_at": %q,
+								"last_message_status": "SUCCESS",
+								"last_message_type": "TWILIO_SMS",
 								"total_payments": "1",
 								"payments_received": "0",
 								"failed_payments": "0",
@@ -786,6 +796,8 @@ func Test_ReceiverHandler_GetReceivers_Success(t *testing.T) {
 								"invitation_sent_at": null,
 								"invited_at": %q,
 								"last_message_sent_at": %q,
+								"last_message_status": "SUCCESS",
+								"last_message_type": "TWILIO_SMS",
 								"total_payments": "1",
 								"payments_received": "1",
 								"failed_payments": "0",
@@ -928,6 +940,8 @@ func Test_ReceiverHandler_GetReceivers_Success(t *testing.T) {
 								"invitation_sent_at": null,
 								"invited_at": %q,
 								"last_message_sent_at": %q,
+								"last_message_status": "SUCCESS",
+								"last_message_type": "TWILIO_SMS",
 								"total_payments": "1",
 								"payments_received": "1",
 								"failed_payments": "0",
@@ -999,6 +1013,8 @@ func Test_ReceiverHandler_GetReceivers_Success(t *testing.T) {
 								"invitation_sent_at": null,
 								"invited_at": %q,
 								"last_message_sent_at": %q,
+								"last_message_status": "SUCCESS",
+								"last_message_type": "TWILIO_SMS",
 								"total_payments": "0",
 								"payments_received": "0",
 								"failed_payments": "0",
@@ -1057,6 +1073,8 @@ func Test_ReceiverHandler_GetReceivers_Success(t *testing.T) {
 								"invitation_sent_at": null,
 								"invited_at": %q,
 								"last_message_sent_at": %q,
+								"last_message_status": "SUCCESS",
+								"last_message_type": "TWILIO_SMS",
 								"total_payments": "0",
 								"payments_received": "0",
 								"failed_payments": "0",
@@ -1145,6 +1163,8 @@ func Test_ReceiverHandler_GetReceivers_Success(t *testing.T) {
 								"invitation_sent_at": null,
 								"invited_at": %q,
 								"last_message_sent_at": %q,
+								"last_message_status": "SUCCESS",
+								"last_message_type": "TWILIO_SMS",
 								"total_payments": "0",
 								"payments_received": "0",
 								"failed_payments": "0",
@@ -1217,6 +1237,8 @@ func Test_ReceiverHandler_GetReceivers_Success(t *testing.T) {
 								"invitation_sent_at": null,
 								"invited_at": %q,
 								"last_message_sent_at": %q,
+								"last_message_status": "SUCCESS",
+								"last_message_type": "TWILIO_SMS",
 								"total_payments": "1",
 								"payments_received": "0",
 								"failed_payments": "0",
@@ -1275,6 +1297,8 @@ func Test_ReceiverHandler_GetReceivers_Success(t *testing.T) {
 								"invitation_sent_at": null,
 								"invited_at": %q,
 								"last_message_sent_at": %q,
+								"last_message_status": "SUCCESS",
+								"last_message_type": "TWILIO_SMS",
 								"total_payments": "1",
 								"payments_received": "1",
 								"failed_payments": "0",
@@ -1512,6 +1536,8 @@ func Test_ReceiverHandler_BuildReceiversResponse(t *testing.T) {
 					"invitation_sent_at": null,
 					"invited_at": %q,
 					"last_message_sent_at": %q,
+					"last_message_status": "SUCCESS",
+					"last_message_type": "TWILIO_SMS",
 					"total_payments": "0",
 					"payments_received": "0",
 					"failed_payments": "0",
@@ -1552,6 +1578,8 @@ func Test_ReceiverHandler_BuildReceiversResponse(t *testing.T) {
 					"invitation_sent_at": null,
 					"invited_at": %q,
 					"last_message_sent_at": %q,
+					"last_message_status": "SUCCESS",
+					"last_message_type": "TWILIO_SMS",
 					"total_payments": "0",
 					"payments_received": "0",
 					"failed_payments": "0",
@@ -1591,7 +1619,10 @@ func Test_ReceiverHandler_GetReceiverVerificatioTypes(t *testing.T) {
 		"DATE_OF_BIRTH",
 		"YEAR_MONTH",
 		"PIN",
-		"NATIONAL_ID_NUMBER"
+		"NATIONAL_ID_NUMBER",
+		"TAX_ID",
+		"PHONE_LAST_4",
+		"CUSTOM_SECRET_QUESTION"
 	]`
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 	assert.JSONEq(t, expectedBody, string(respBody))
@@ -0,0 +1,291 @@
+package httphandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	htmlTpl "github.com/stellar/stellar-disbursement-platform-backend/internal/htmltemplate"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+// ReceiverPortalHandler serves the receiver portal, where receivers can see the payments they were sent after
+// authenticating with an OTP sent to their phone number or email. The OTP is sent the same way as during the SEP-24
+// registration, with the organization's OTP message template, and the OTP requests are subject to the same rate limits.
+type ReceiverPortalHandler struct {
+	ReceiverSendOTPHandler
+	ReCAPTCHASiteKey  string
+	ReCAPTCHADisabled bool
+}
+
+type ReceiverPortalData struct {
+	OrganizationName  string
+	PrivacyPolicyLink string
+	ReCAPTCHASiteKey  string
+	// ContactInfo is set once the OTP was requested, and it's sent back with the OTP to access the payments.
+	ContactInfo string
+	Message     string
+	Error       string
+}
+
+type ReceiverPortalPaymentsData struct {
+	OrganizationName     string
+	PrivacyPolicyLink    string
+	TruncatedContactInfo string
+	Payments             []ReceiverPortalPayment
+}
+
+type ReceiverPortalPayment struct {
+	Date                   string
+	DisbursementName       string
+	Amount                 string
+	AssetCode              string
+	Status                 data.PaymentStatus
+	StellarTransactionHash string
+}
+
+// receiverPortalDisbursementStatuses are the statuses of the disbursements whose payments are shown in the receiver
+// portal. The payments of disbursements that weren't started yet can still change, so they're not shown.
+var receiverPortalDisbursementStatuses = []data.DisbursementStatus{
+	data.StartedDisbursementStatus,
+	data.PausedDisbursementStatus,
+	data.CompletedDisbursementStatus,
+}
+
+// GetReceiverPortal serves the page where receivers request an OTP to access the portal.
+func (h ReceiverPortalHandler) GetReceiverPortal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tmplData, _, err := h.newReceiverPortalData(ctx)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get organization", err, nil).Render(w)
+		return
+	}
+
+	renderHTMLTemplate(ctx, w, http.StatusOK, "receiver_portal.tmpl", tmplData)
+}
+
+// PostReceiverPortalOTP sends an OTP to the receiver with the phone number or email submitted, and serves the page
+// where the OTP is entered. The page is the same whether the receiver exists or not, so it can't be used to find out
+// which contacts are registered.
+func (h ReceiverPortalHandler) PostReceiverPortalOTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tmplData, organization, err := h.newReceiverPortalData(ctx)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get organization", err, nil).Render(w)
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		tmplData.Error = "The request was invalid, please try again."
+		renderHTMLTemplate(ctx, w, http.StatusBadRequest, "receiver_portal.tmpl", tmplData)
+		return
+	}
+
+	if !h.ReCAPTCHADisabled {
+		isValid, reCAPTCHAErr := h.ReCAPTCHAValidator.IsTokenValid(ctx, r.PostForm.Get("g-recaptcha-response"))
+		if reCAPTCHAErr != nil {
+			httperror.InternalError(ctx, "Cannot validate reCAPTCHA token", reCAPTCHAErr, nil).Render(w)
+			return
+		}
+		if !isValid {
+			log.Ctx(ctx).Errorf("reCAPTCHA token is invalid")
+			tmplData.Error = "The reCAPTCHA validation failed, please try again."
+			renderHTMLTemplate(ctx, w, http.StatusBadRequest, "receiver_portal.tmpl", tmplData)
+			return
+		}
+	}
+
+	contactType, contactInfo, ok := parseReceiverPortalContactInfo(r.PostForm.Get("contact_info"))
+	if !ok {
+		tmplData.Error = "Please enter a valid phone number or email."
+		renderHTMLTemplate(ctx, w, http.StatusBadRequest, "receiver_portal.tmpl", tmplData)
+		return
+	}
+
+	if httpErr := h.RateLimiter.Check(ctx, r, organization, RegistrationEndpointReceiverPortalOTP, contactInfo); httpErr != nil {
+		if httpErr.StatusCode != http.StatusTooManyRequests {
+			httpErr.Render(w)
+			return
+		}
+		tmplData.Error = httpErr.Message
+		renderHTMLTemplate(ctx, w, http.StatusTooManyRequests, "receiver_portal.tmpl", tmplData)
+		return
+	}
+
+	receiver, err := h.getReceiverByContactInfo(ctx, contactInfo)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get receiver", err, nil).Render(w)
+		return
+	}
+
+	if receiver == nil {
+		log.Ctx(ctx).Warnf("Could not find a receiver for %s %s", utils.Humanize(string(contactType)), utils.TruncateString(contactInfo, 3))
+	} else {
+		otp, otpErr := utils.RandomString(6, utils.NumberBytes)
+		if otpErr != nil {
+			httperror.InternalError(ctx, "Cannot generate OTP for receiver", otpErr, nil).Render(w)
+			return
+		}
+		if otpErr = h.Models.ReceiverPortalOTPs.Upsert(ctx, h.Models.DBConnectionPool, receiver.ID, otp); otpErr != nil {
+			httperror.InternalError(ctx, "Cannot store OTP for receiver", otpErr, nil).Render(w)
+			return
+		}
+		if otpErr = h.sendOTP(ctx, contactType, contactInfo, otp); otpErr != nil {
+			httperror.InternalError(ctx, "Failed to send OTP message", otpErr, nil).Render(w)
+			return
+		}
+	}
+
+	tmplData.ContactInfo = contactInfo
+	tmplData.Message = newReceiverSendOTPResponseBody(contactType, "", "").Message
+	renderHTMLTemplate(ctx, w, http.StatusOK, "receiver_portal.tmpl", tmplData)
+}
+
+// PostReceiverPortalPayments verifies the OTP of the receiver and serves the page with the payments of the started
+// disbursements sent to them, newest first. The OTP can only be used once, so a new one is needed to see the payments
+// again.
+func (h ReceiverPortalHandler) PostReceiverPortalPayments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tmplData, _, err := h.newReceiverPortalData(ctx)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get organization", err, nil).Render(w)
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		tmplData.Error = "The request was invalid, please try again."
+		renderHTMLTemplate(ctx, w, http.StatusBadRequest, "receiver_portal.tmpl", tmplData)
+		return
+	}
+
+	_, contactInfo, ok := parseReceiverPortalContactInfo(r.PostForm.Get("contact_info"))
+	if !ok {
+		tmplData.Error = "Please enter a valid phone number or email."
+		renderHTMLTemplate(ctx, w, http.StatusBadRequest, "receiver_portal.tmpl", tmplData)
+		return
+	}
+	tmplData.ContactInfo = contactInfo
+
+	receiver, err := h.getReceiverByContactInfo(ctx, contactInfo)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get receiver", err, nil).Render(w)
+		return
+	}
+	if receiver != nil {
+		err = h.Models.ReceiverPortalOTPs.Verify(ctx, receiver.ID, strings.TrimSpace(r.PostForm.Get("otp")))
+	}
+	if receiver == nil || errors.Is(err, data.ErrInvalidReceiverPortalOTP) {
+		tmplData.Error = "The code is invalid or expired, please request a new one if the problem persists."
+		renderHTMLTemplate(ctx, w, http.StatusUnauthorized, "receiver_portal.tmpl", tmplData)
+		return
+	}
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot verify OTP for receiver", err, nil).Render(w)
+		return
+	}
+
+	payments, err := h.Models.Payment.GetAll(ctx, &data.QueryParams{
+		Filters: map[data.FilterKey]interface{}{
+			data.FilterKeyReceiverID:         receiver.ID,
+			data.FilterKeyDisbursementStatus: receiverPortalDisbursementStatuses,
+		},
+		SortBy:    data.SortFieldCreatedAt,
+		SortOrder: data.SortOrderDESC,
+	}, h.Models.DBConnectionPool, data.QueryTypeSelectAll)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get receiver payments", err, nil).Render(w)
+		return
+	}
+
+	paymentsData := ReceiverPortalPaymentsData{
+		OrganizationName:     tmplData.OrganizationName,
+		PrivacyPolicyLink:    tmplData.PrivacyPolicyLink,
+		TruncatedContactInfo: utils.TruncateString(contactInfo, 3),
+		Payments:             make([]ReceiverPortalPayment, 0, len(payments)),
+	}
+	for _, payment := range payments {
+		portalPayment := ReceiverPortalPayment{
+			Date:                   payment.CreatedAt.Format(time.DateOnly),
+			Amount:                 payment.Amount,
+			AssetCode:              payment.Asset.Code,
+			Status:                 payment.Status,
+			StellarTransactionHash: payment.StellarTransactionID,
+		}
+		if payment.Disbursement != nil {
+			portalPayment.DisbursementName = payment.Disbursement.Name
+		}
+		paymentsData.Payments = append(paymentsData.Payments, portalPayment)
+	}
+
+	renderHTMLTemplate(ctx, w, http.StatusOK, "receiver_portal_payments.tmpl", paymentsData)
+}
+
+func (h ReceiverPortalHandler) newReceiverPortalData(ctx context.Context) (ReceiverPortalData, *data.Organization, error) {
+	organization, err := h.Models.Organizations.Get(ctx)
+	if err != nil {
+		return ReceiverPortalData{}, nil, err
+	}
+
+	tmplData := ReceiverPortalData{OrganizationName: organization.Name}
+	if organization.PrivacyPolicyLink != nil {
+		tmplData.PrivacyPolicyLink = *organization.PrivacyPolicyLink
+	}
+	if !h.ReCAPTCHADisabled {
+		tmplData.ReCAPTCHASiteKey = h.ReCAPTCHASiteKey
+	}
+
+	return tmplData, organization, nil
+}
+
+// getReceiverByContactInfo returns the receiver with the phone number or email, or nil if there's none.
+func (h ReceiverPortalHandler) getReceiverByContactInfo(ctx context.Context, contactInfo string) (*data.Receiver, error) {
+	receivers, err := h.Models.Receiver.GetByContacts(ctx, h.Models.DBConnectionPool, contactInfo)
+	if err != nil {
+		return nil, err
+	}
+	if len(receivers) == 0 {
+		return nil, nil
+	}
+	return receivers[0], nil
+}
+
+// parseReceiverPortalContactInfo returns the contact type and the normalized contact info entered in the receiver
+// portal, which is an email if it has an `@` and a phone number otherwise.
+func parseReceiverPortalContactInfo(contactInfo string) (data.ReceiverContactType, string, bool) {
+	contactInfo = utils.TrimAndLower(contactInfo)
+
+	request := ReceiverSendOTPRequest{PhoneNumber: contactInfo}
+	contactType := data.ReceiverContactTypeSMS
+	if strings.Contains(contactInfo, "@") {
+		request = ReceiverSendOTPRequest{Email: contactInfo}
+		contactType = data.ReceiverContactTypeEmail
+	}
+
+	if v := request.validateContactInfo(); v.HasErrors() {
+		return "", "", false
+	}
+	return contactType, contactInfo, true
+}
+
+func renderHTMLTemplate(ctx context.Context, w http.ResponseWriter, statusCode int, templateName string, tmplData interface{}) {
+	page, err := htmlTpl.ExecuteHTMLTemplate(templateName, tmplData)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot process the html template for request", err, nil).Render(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(statusCode)
+	if _, err = w.Write([]byte(page)); err != nil {
+		log.Ctx(ctx).Errorf("writing html content to response: %v", err)
+	}
+}
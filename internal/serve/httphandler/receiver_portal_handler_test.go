@@ -0,0 +1,189 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/validators"
+)
+
+func Test_parseReceiverPortalContactInfo(t *testing.T) {
+	testCases := []struct {
+		contactInfo     string
+		wantContactType data.ReceiverContactType
+		wantContactInfo string
+		wantOK          bool
+	}{
+		{" Receiver@Test.com ", data.ReceiverContactTypeEmail, "receiver@test.com", true},
+		{"+380443973607", data.ReceiverContactTypeSMS, "+380443973607", true},
+		{"invalid@", "", "", false},
+		{"12345", "", "", false},
+		{"", "", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.contactInfo, func(t *testing.T) {
+			contactType, contactInfo, ok := parseReceiverPortalContactInfo(tc.contactInfo)
+			assert.Equal(t, tc.wantContactType, contactType)
+			assert.Equal(t, tc.wantContactInfo, contactInfo)
+			assert.Equal(t, tc.wantOK, ok)
+		})
+	}
+}
+
+func Test_ReceiverPortalHandler(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	wallet := data.CreateDefaultWalletFixture(t, ctx, dbConnectionPool)
+	asset := data.GetAssetFixture(t, ctx, dbConnectionPool, data.FixtureAssetUSDC)
+	disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Name:   "March payroll",
+		Status: data.StartedDisbursementStatus,
+		Asset:  asset,
+		Wallet: wallet,
+	})
+	receiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{Email: "receiver@test.com"})
+	receiverWallet := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, data.RegisteredReceiversWalletStatus)
+	data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+		ReceiverWallet:       receiverWallet,
+		Disbursement:         disbursement,
+		Asset:                *asset,
+		Amount:               "120",
+		Status:               data.SuccessPaymentStatus,
+		StellarTransactionID: "3d3d2e6e1cf2e2b9f3a1c4c1e2d7f2a8c5e6b1f0a9d8c7b6a5f4e3d2c1b0a9f8",
+	})
+	draftDisbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Name:   "April payroll",
+		Status: data.DraftDisbursementStatus,
+		Asset:  asset,
+		Wallet: wallet,
+	})
+	data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+		ReceiverWallet: receiverWallet,
+		Disbursement:   draftDisbursement,
+		Asset:          *asset,
+		Amount:         "130",
+		Status:         data.DraftPaymentStatus,
+	})
+
+	newHandler := func(dispatcher message.MessageDispatcherInterface) ReceiverPortalHandler {
+		return ReceiverPortalHandler{
+			ReceiverSendOTPHandler: ReceiverSendOTPHandler{
+				Models:             models,
+				MessageDispatcher:  dispatcher,
+				ReCAPTCHAValidator: validators.NewReCAPTCHAValidatorMock(t),
+			},
+			ReCAPTCHADisabled: true,
+		}
+	}
+
+	postForm := func(handlerFunc http.HandlerFunc, form url.Values) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/receiver-portal", strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+		handlerFunc.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("🎉 serves the page to request an OTP", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/receiver-portal", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(newHandler(nil).GetReceiverPortal).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), `action="/receiver-portal/otp"`)
+	})
+
+	t.Run("returns the same page with an error when the contact info is invalid", func(t *testing.T) {
+		rr := postForm(newHandler(nil).PostReceiverPortalOTP, url.Values{"contact_info": {"invalid"}})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Please enter a valid phone number or email.")
+	})
+
+	t.Run("doesn't send an OTP when the receiver doesn't exist", func(t *testing.T) {
+		rr := postForm(newHandler(message.NewMockMessageDispatcher(t)).PostReceiverPortalOTP, url.Values{"contact_info": {"unknown@test.com"}})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "if your email is registered, you&#39;ll receive an OTP")
+		assert.Contains(t, rr.Body.String(), `action="/receiver-portal/payments"`)
+	})
+
+	t.Run("🎉 sends an OTP and lists the payments once it's verified", func(t *testing.T) {
+		var sentOTP string
+		mockMessageDispatcher := message.NewMockMessageDispatcher(t)
+		mockMessageDispatcher.
+			On("SendMessage", mock.Anything, mock.AnythingOfType("message.Message"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				msg := args.Get(1).(message.Message)
+				assert.Equal(t, "receiver@test.com", msg.ToEmail)
+				sentOTP = strings.TrimPrefix(msg.Title, "Your One-Time Password: ")
+			}).
+			Return(message.MessengerTypeAWSEmail, nil).
+			Once()
+		handler := newHandler(mockMessageDispatcher)
+
+		rr := postForm(handler.PostReceiverPortalOTP, url.Values{"contact_info": {"Receiver@test.com"}})
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Len(t, sentOTP, 6)
+
+		rr = postForm(handler.PostReceiverPortalPayments, url.Values{"contact_info": {"receiver@test.com"}, "otp": {"000000"}})
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "The code is invalid or expired")
+
+		rr = postForm(handler.PostReceiverPortalPayments, url.Values{"contact_info": {"receiver@test.com"}, "otp": {sentOTP}})
+		require.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Contains(t, body, "March payroll")
+		assert.Contains(t, body, "120.0000000 USDC")
+		assert.Contains(t, body, string(data.SuccessPaymentStatus))
+		assert.Contains(t, body, "3d3d2e6e1cf2e2b9f3a1c4c1e2d7f2a8c5e6b1f0a9d8c7b6a5f4e3d2c1b0a9f8")
+		// The payments of disbursements that weren't started aren't shown.
+		assert.NotContains(t, body, "April payroll")
+
+		// The OTP can't be used again.
+		rr = postForm(handler.PostReceiverPortalPayments, url.Values{"contact_info": {"receiver@test.com"}, "otp": {sentOTP}})
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("returns the same page with an error when the OTP requests are rate limited", func(t *testing.T) {
+		limitPerContact := 1
+		err := models.Organizations.Update(ctx, &data.OrganizationUpdate{RegistrationRateLimitPerContact: &limitPerContact})
+		require.NoError(t, err)
+
+		handler := newHandler(message.NewMockMessageDispatcher(t))
+		handler.RateLimiter = &RegistrationRateLimiter{Models: models}
+
+		rr := postForm(handler.PostReceiverPortalOTP, url.Values{"contact_info": {"limited@test.com"}})
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rr = postForm(handler.PostReceiverPortalOTP, url.Values{"contact_info": {"limited@test.com"}})
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "Too many requests, please try again later.")
+	})
+}
@@ -1,6 +1,7 @@
 package httphandler
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -29,6 +30,8 @@ type ReceiverRegistrationData struct {
 	PrivacyPolicyLink    string
 	OrganizationName     string
 	TruncatedContactInfo string
+	// VerificationFields is the JSON with the label and the input type of each verification type in the registration page.
+	VerificationFields string
 }
 
 // ServeHTTP will serve the SEP-24 deposit page needed to register users.
@@ -74,12 +77,19 @@ func (h ReceiverRegistrationHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 	if organization.PrivacyPolicyLink != nil {
 		privacyPolicyLink = *organization.PrivacyPolicyLink
 	}
+	verificationFields, err := json.Marshal(data.GetAllVerificationTypeDefinitions())
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot encode verification fields", err, nil).Render(w)
+		return
+	}
+
 	tmplData := ReceiverRegistrationData{
-		StellarAccount:    sep24Claims.SEP10StellarAccount(),
-		JWTToken:          token,
-		ReCAPTCHASiteKey:  h.ReCAPTCHASiteKey,
-		PrivacyPolicyLink: privacyPolicyLink,
-		OrganizationName:  organization.Name,
+		StellarAccount:     sep24Claims.SEP10StellarAccount(),
+		JWTToken:           token,
+		ReCAPTCHASiteKey:   h.ReCAPTCHASiteKey,
+		PrivacyPolicyLink:  privacyPolicyLink,
+		OrganizationName:   organization.Name,
+		VerificationFields: string(verificationFields),
 	}
 
 	htmlTemplateName := "receiver_register.tmpl"
@@ -0,0 +1,126 @@
+package httphandler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/httprate"
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/monitor"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
+)
+
+const (
+	RegistrationEndpointOTP               = "otp"
+	RegistrationEndpointVerification      = "verification"
+	RegistrationEndpointReceiverPortalOTP = "receiver_portal_otp"
+
+	registrationRateLimitTypeContact = "contact"
+	registrationRateLimitTypeIP      = "ip"
+)
+
+// RegistrationRateLimiter limits how many requests each contact and IP address can make to the wallet registration
+// and receiver portal OTP endpoints, using the limits configured by the organization. The requests are counted in the database, so the limits
+// are shared by all the instances of the SDP.
+type RegistrationRateLimiter struct {
+	Models         *data.Models
+	MonitorService monitor.MonitorServiceInterface
+}
+
+// Check counts the request against the rate limits of its IP address and of the contact info, returning a "429 Too
+// Many Requests" error when any of them is exceeded. The contact info is skipped when it's empty.
+func (l *RegistrationRateLimiter) Check(ctx context.Context, r *http.Request, organization *data.Organization, endpoint, contactInfo string) *httperror.HTTPError {
+	if l == nil {
+		return nil
+	}
+
+	window := time.Duration(organization.RegistrationRateLimitWindowMinutes) * time.Minute
+	ip, err := httprate.KeyByIP(r)
+	if err != nil {
+		return httperror.InternalError(ctx, "Cannot resolve the request IP address", err, nil)
+	}
+
+	limits := []struct {
+		limitType string
+		key       string
+		limit     int
+	}{
+		{registrationRateLimitTypeIP, registrationRateLimitTypeIP + ":" + ip, organization.RegistrationRateLimitPerIP},
+		{registrationRateLimitTypeContact, registrationRateLimitTypeContact + ":" + hashContactInfo(contactInfo), organization.RegistrationRateLimitPerContact},
+	}
+	for _, rateLimit := range limits {
+		if rateLimit.limit <= 0 || (rateLimit.limitType == registrationRateLimitTypeContact && contactInfo == "") {
+			continue
+		}
+
+		hit, err := l.Models.RegistrationRateLimits.Hit(ctx, endpoint, rateLimit.key, window)
+		if err != nil {
+			return httperror.InternalError(ctx, "Cannot check the rate limits", err, nil)
+		}
+		if hit.Hits <= rateLimit.limit {
+			continue
+		}
+
+		log.Ctx(ctx).Warnf("wallet registration %s rate limit per %s exceeded: %d requests in the current window", endpoint, rateLimit.limitType, hit.Hits)
+		l.monitorRateLimited(ctx, endpoint, rateLimit.limitType)
+
+		retryAfter := time.Until(hit.WindowStartedAt.Add(window))
+		return httperror.NewHTTPError(http.StatusTooManyRequests, "Too many requests, please try again later.", nil, map[string]interface{}{
+			"retry_after_seconds": int(math.Ceil(math.Max(retryAfter.Seconds(), 1))),
+		})
+	}
+
+	return nil
+}
+
+func (l *RegistrationRateLimiter) monitorRateLimited(ctx context.Context, endpoint, limitType string) {
+	if l.MonitorService == nil {
+		return
+	}
+
+	labels := monitor.ReceiverRegistrationRateLimitLabels{
+		Endpoint:   endpoint,
+		LimitType:  limitType,
+		TenantName: tenantNameFromContext(ctx),
+	}
+	if err := l.MonitorService.MonitorCounters(monitor.ReceiverRegistrationRateLimitedTag, labels.ToMap()); err != nil {
+		log.Ctx(ctx).Errorf("monitoring counter: %v", err)
+	}
+}
+
+// hashContactInfo hashes the contact info used as a rate limit key, so the rate limit counters don't hold PII.
+func hashContactInfo(contactInfo string) string {
+	hash := sha256.Sum256([]byte(contactInfo))
+	return hex.EncodeToString(hash[:])
+}
+
+// monitorReceiverVerificationLockout records a lockout event of a receiver verification, when the monitor service is
+// set.
+func monitorReceiverVerificationLockout(ctx context.Context, monitorService monitor.MonitorServiceInterface, event monitor.ReceiverVerificationLockoutEvent) {
+	if monitorService == nil {
+		return
+	}
+
+	labels := monitor.ReceiverVerificationLockoutLabels{
+		Event:      event,
+		TenantName: tenantNameFromContext(ctx),
+	}
+	if err := monitorService.MonitorCounters(monitor.ReceiverVerificationLockoutEventsTag, labels.ToMap()); err != nil {
+		log.Ctx(ctx).Errorf("monitoring counter: %v", err)
+	}
+}
+
+func tenantNameFromContext(ctx context.Context) string {
+	t, err := tenant.GetTenantFromContext(ctx)
+	if err != nil {
+		return ""
+	}
+	return t.Name
+}
@@ -0,0 +1,102 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/monitor"
+	monitorMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/monitor/mocks"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
+)
+
+func Test_RegistrationRateLimiter_Check(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	ctx := tenant.SaveTenantInContext(context.Background(), &tenant.Tenant{Name: "aid-org"})
+	newRequest := func(remoteAddr string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/wallet-registration/otp", nil)
+		req.RemoteAddr = remoteAddr
+		return req
+	}
+
+	t.Run("does nothing when the rate limiter isn't set", func(t *testing.T) {
+		var rateLimiter *RegistrationRateLimiter
+		httpErr := rateLimiter.Check(ctx, newRequest("10.0.0.1:1234"), &data.Organization{RegistrationRateLimitPerIP: 1}, RegistrationEndpointOTP, "+14155555555")
+		assert.Nil(t, httpErr)
+	})
+
+	t.Run("rejects the requests of a contact over its limit", func(t *testing.T) {
+		mMonitorService := monitorMocks.NewMockMonitorService(t)
+		mMonitorService.
+			On("MonitorCounters", monitor.ReceiverRegistrationRateLimitedTag, monitor.ReceiverRegistrationRateLimitLabels{
+				Endpoint:   RegistrationEndpointOTP,
+				LimitType:  "contact",
+				TenantName: "aid-org",
+			}.ToMap()).
+			Return(nil).
+			Once()
+		rateLimiter := &RegistrationRateLimiter{Models: models, MonitorService: mMonitorService}
+		organization := &data.Organization{RegistrationRateLimitWindowMinutes: 60, RegistrationRateLimitPerContact: 2}
+
+		for i, remoteAddr := range []string{"10.0.0.2:1234", "10.0.0.3:1234"} {
+			httpErr := rateLimiter.Check(ctx, newRequest(remoteAddr), organization, RegistrationEndpointOTP, "+14155550001")
+			assert.Nil(t, httpErr, "request %d", i)
+		}
+
+		httpErr := rateLimiter.Check(ctx, newRequest("10.0.0.4:1234"), organization, RegistrationEndpointOTP, "+14155550001")
+		require.NotNil(t, httpErr)
+		assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+		assert.Equal(t, "Too many requests, please try again later.", httpErr.Message)
+		assert.Positive(t, httpErr.Extras["retry_after_seconds"])
+
+		// Other contacts and endpoints have their own limits:
+		assert.Nil(t, rateLimiter.Check(ctx, newRequest("10.0.0.4:1234"), organization, RegistrationEndpointOTP, "+14155550002"))
+		assert.Nil(t, rateLimiter.Check(ctx, newRequest("10.0.0.4:1234"), organization, RegistrationEndpointVerification, "+14155550001"))
+	})
+
+	t.Run("rejects the requests of an IP address over its limit", func(t *testing.T) {
+		mMonitorService := monitorMocks.NewMockMonitorService(t)
+		mMonitorService.
+			On("MonitorCounters", monitor.ReceiverRegistrationRateLimitedTag, monitor.ReceiverRegistrationRateLimitLabels{
+				Endpoint:   RegistrationEndpointVerification,
+				LimitType:  "ip",
+				TenantName: "aid-org",
+			}.ToMap()).
+			Return(nil).
+			Once()
+		rateLimiter := &RegistrationRateLimiter{Models: models, MonitorService: mMonitorService}
+		organization := &data.Organization{RegistrationRateLimitWindowMinutes: 60, RegistrationRateLimitPerIP: 1}
+
+		httpErr := rateLimiter.Check(ctx, newRequest("10.0.0.5:1234"), organization, RegistrationEndpointVerification, "+14155550003")
+		assert.Nil(t, httpErr)
+
+		httpErr = rateLimiter.Check(ctx, newRequest("10.0.0.5:4321"), organization, RegistrationEndpointVerification, "+14155550004")
+		require.NotNil(t, httpErr)
+		assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+	})
+
+	t.Run("doesn't enforce the limits set to zero", func(t *testing.T) {
+		rateLimiter := &RegistrationRateLimiter{Models: models}
+		organization := &data.Organization{RegistrationRateLimitWindowMinutes: 60}
+
+		for i := 0; i < 3; i++ {
+			httpErr := rateLimiter.Check(ctx, newRequest("10.0.0.6:1234"), organization, RegistrationEndpointOTP, "+14155550005")
+			assert.Nil(t, httpErr)
+		}
+	})
+}
@@ -102,6 +102,7 @@ func Test_ReceiverRegistrationHandler_ServeHTTP(t *testing.T) {
 		assert.Contains(t, string(respBody), `<span id="recaptcha-site-key" data-sitekey="reCAPTCHASiteKey" style="display: none"/>`)
 		assert.Contains(t, string(respBody), `<link rel="preload" href="https://www.google.com/recaptcha/api.js" as="script" />`)
 		assert.Contains(t, string(respBody), `<p>Your data is processed by MyCustomAid in accordance with their <a href="http://www.test.com/privacy-policy"><b>Privacy Policy</b></a></p>`)
+		assert.Contains(t, string(respBody), `{&#34;type&#34;:&#34;DATE_OF_BIRTH&#34;,&#34;label&#34;:&#34;Date of birth&#34;,&#34;input_type&#34;:&#34;date&#34;}`)
 	})
 
 	// Create a receiver wallet
@@ -8,6 +8,7 @@ import (
 	"html/template"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/stellar/go/support/log"
 	"github.com/stellar/go/support/render/httpjson"
@@ -15,6 +16,7 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/anchorplatform"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/monitor"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/validators"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
@@ -30,6 +32,9 @@ type ReceiverSendOTPHandler struct {
 	Models             *data.Models
 	MessageDispatcher  message.MessageDispatcherInterface
 	ReCAPTCHAValidator validators.ReCAPTCHAValidator
+	// RateLimiter enforces the organization rate limits on the endpoint. They aren't enforced when it's nil.
+	RateLimiter    *RegistrationRateLimiter
+	MonitorService monitor.MonitorServiceInterface
 }
 
 type ReceiverSendOTPData struct {
@@ -69,6 +74,8 @@ func (r ReceiverSendOTPRequest) validateContactInfo() validators.Validator {
 type ReceiverSendOTPResponseBody struct {
 	Message           string                `json:"message"`
 	VerificationField data.VerificationType `json:"verification_field"`
+	// VerificationQuestion is the secret question asked to the receiver, when VerificationField is CUSTOM_SECRET_QUESTION.
+	VerificationQuestion string `json:"verification_question,omitempty"`
 }
 
 func (h ReceiverSendOTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -129,19 +136,31 @@ func (h ReceiverSendOTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		httperror.InternalError(ctx, "Unexpected contact info", nil, nil).Render(w)
 		return
 	}
-	verificationField, httpErr := h.handleOTPForReceiver(ctx, contactType, contactInfo, sep24Claims.ClientDomainClaim)
+
+	organization, err := h.Models.Organizations.Get(ctx)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot retrieve organization", err, nil).Render(w)
+		return
+	}
+	if httpErr := h.RateLimiter.Check(ctx, r, organization, RegistrationEndpointOTP, contactInfo); httpErr != nil {
+		httpErr.Render(w)
+		return
+	}
+
+	verificationField, verificationQuestion, httpErr := h.handleOTPForReceiver(ctx, contactType, contactInfo, sep24Claims.ClientDomainClaim, organization.VerificationLockoutPolicy())
 	if httpErr != nil {
 		httpErr.Render(w)
 		return
 	}
 
-	response := newReceiverSendOTPResponseBody(contactType, verificationField)
+	response := newReceiverSendOTPResponseBody(contactType, verificationField, verificationQuestion)
 	httpjson.RenderStatus(w, http.StatusOK, response, httpjson.JSON)
 }
 
-// newReceiverSendOTPResponseBody creates a new ReceiverSendOTPResponseBody based on the OTP registration type and verification field.
-func newReceiverSendOTPResponseBody(contactType data.ReceiverContactType, verificationField data.VerificationType) ReceiverSendOTPResponseBody {
-	resp := ReceiverSendOTPResponseBody{VerificationField: verificationField}
+// newReceiverSendOTPResponseBody creates a new ReceiverSendOTPResponseBody based on the OTP registration type, verification
+// field and verification question.
+func newReceiverSendOTPResponseBody(contactType data.ReceiverContactType, verificationField data.VerificationType, verificationQuestion string) ReceiverSendOTPResponseBody {
+	resp := ReceiverSendOTPResponseBody{VerificationField: verificationField, VerificationQuestion: verificationQuestion}
 
 	switch contactType {
 	case data.ReceiverContactTypeSMS:
@@ -154,12 +173,15 @@ func newReceiverSendOTPResponseBody(contactType data.ReceiverContactType, verifi
 }
 
 // handleOTPReceiver handles the OTP generation and sending for a receiver with the provided contactType and contactInfo.
+// No OTP is sent to receivers that are locked out, since they couldn't complete their registration with it. It returns the
+// verification field and, for secret questions, the question the receiver should answer.
 func (h ReceiverSendOTPHandler) handleOTPForReceiver(
 	ctx context.Context,
 	contactType data.ReceiverContactType,
 	contactInfo string,
 	sep24ClientDomain string,
-) (data.VerificationType, *httperror.HTTPError) {
+	lockoutPolicy data.VerificationLockoutPolicy,
+) (data.VerificationType, string, *httperror.HTTPError) {
 	var err error
 	placeholderVerificationField := data.VerificationTypeDateOfBirth
 	truncatedContactInfo := utils.TruncateString(contactInfo, 3)
@@ -169,33 +191,43 @@ func (h ReceiverSendOTPHandler) handleOTPForReceiver(
 	receiverVerification, err := h.Models.ReceiverVerification.GetLatestByContactInfo(ctx, contactInfo)
 	if err != nil {
 		log.Ctx(ctx).Warnf("Could not find ANY receiver verification for %s %s: %v", contactTypeStr, truncatedContactInfo, err)
-		return placeholderVerificationField, nil
+		return placeholderVerificationField, "", nil
+	}
+	if lockoutPolicy.IsLocked(*receiverVerification, time.Now()) {
+		log.Ctx(ctx).Warnf("Not sending OTP to %s %s because the receiver is locked out", contactTypeStr, truncatedContactInfo)
+		monitorReceiverVerificationLockout(ctx, h.MonitorService, monitor.ReceiverVerificationLockoutEventRejected)
+		return placeholderVerificationField, "", nil
 	}
 
 	// Generate a new 6 digits OTP
 	newOTP, err := utils.RandomString(6, utils.NumberBytes)
 	if err != nil {
-		return placeholderVerificationField, httperror.InternalError(ctx, "Cannot generate OTP for receiver wallet", err, nil)
+		return placeholderVerificationField, "", httperror.InternalError(ctx, "Cannot generate OTP for receiver wallet", err, nil)
 	}
 
 	// Update OTP for receiver wallet
 	numberOfUpdatedRows, err := h.Models.ReceiverWallet.UpdateOTPByReceiverContactInfoAndWalletDomain(ctx, contactInfo, sep24ClientDomain, newOTP)
 	if err != nil && !errors.Is(err, data.ErrRecordNotFound) {
-		return placeholderVerificationField, httperror.InternalError(ctx, "Cannot update OTP for receiver wallet", err, nil)
+		return placeholderVerificationField, "", httperror.InternalError(ctx, "Cannot update OTP for receiver wallet", err, nil)
 	}
 	if numberOfUpdatedRows < 1 {
 		log.Ctx(ctx).Warnf("Could not find a match between %s (%s) and client domain (%s)", contactTypeStr, truncatedContactInfo, sep24ClientDomain)
-		return placeholderVerificationField, nil
+		return placeholderVerificationField, "", nil
 	}
 
 	// Send OTP message
 	err = h.sendOTP(ctx, contactType, contactInfo, newOTP)
 	if err != nil {
 		err = fmt.Errorf("sending OTP message: %w", err)
-		return placeholderVerificationField, httperror.InternalError(ctx, "Failed to send OTP message, reason: "+err.Error(), err, nil)
+		return placeholderVerificationField, "", httperror.InternalError(ctx, "Failed to send OTP message, reason: "+err.Error(), err, nil)
+	}
+
+	var verificationQuestion string
+	if receiverVerification.Question != nil {
+		verificationQuestion = *receiverVerification.Question
 	}
 
-	return receiverVerification.VerificationField, nil
+	return receiverVerification.VerificationField, verificationQuestion, nil
 }
 
 // sendOTP sends an OTP through the provided contact type to the provided contact information.
@@ -230,6 +262,11 @@ func (h ReceiverSendOTPHandler) sendOTP(ctx context.Context, contactType data.Re
 	switch contactType {
 	case data.ReceiverContactTypeSMS:
 		msg.ToPhoneNumber = contactInfo
+		// The WhatsApp channel can only send the OTP through its pre-approved template.
+		msg.Template = &message.MessageTemplate{
+			Type:      message.MessageTemplateTypeReceiverOTP,
+			Variables: []string{otp, organization.Name},
+		}
 	case data.ReceiverContactTypeEmail:
 		msg.ToEmail = contactInfo
 		msg.Title = "Your One-Time Password: " + otp
@@ -316,7 +316,7 @@ func Test_ReceiverSendOTPHandler_ServeHTTP_otpHandlerIsCalled(t *testing.T) {
 							On("SendMessage",
 								mock.Anything,
 								mock.AnythingOfType("message.Message"),
-								[]message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+								[]message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 							Return(messengerType, errors.New("failed calling message dispatcher")).
 							Once().
 							Run(func(args mock.Arguments) {
@@ -368,7 +368,7 @@ func Test_ReceiverSendOTPHandler_ServeHTTP_otpHandlerIsCalled(t *testing.T) {
 							On("SendMessage",
 								mock.Anything,
 								mock.AnythingOfType("message.Message"),
-								[]message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+								[]message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 							Return(messengerType, nil).
 							Once().
 							Run(func(args mock.Arguments) {
@@ -441,7 +441,7 @@ func Test_newReceiverSendOTPResponseBody(t *testing.T) {
 	for _, otpType := range data.GetAllReceiverContactTypes() {
 		for _, verificationType := range data.GetAllVerificationTypes() {
 			t.Run(fmt.Sprintf("%s/%s", otpType, verificationType), func(t *testing.T) {
-				gotBody := newReceiverSendOTPResponseBody(otpType, verificationType)
+				gotBody := newReceiverSendOTPResponseBody(otpType, verificationType, "")
 				wantBody := ReceiverSendOTPResponseBody{
 					Message:           fmt.Sprintf("if your %s is registered, you'll receive an OTP", utils.Humanize(string(otpType))),
 					VerificationField: verificationType,
@@ -450,6 +450,16 @@ func Test_newReceiverSendOTPResponseBody(t *testing.T) {
 			})
 		}
 	}
+
+	t.Run("with verification question", func(t *testing.T) {
+		gotBody := newReceiverSendOTPResponseBody(data.ReceiverContactTypeSMS, data.VerificationTypeCustomSecretQuestion, "What is your favorite color?")
+		wantBody := ReceiverSendOTPResponseBody{
+			Message:              "if your phone number is registered, you'll receive an OTP",
+			VerificationField:    data.VerificationTypeCustomSecretQuestion,
+			VerificationQuestion: "What is your favorite color?",
+		}
+		require.Equal(t, wantBody, gotBody)
+	})
 }
 
 func Test_ReceiverSendOTPHandler_sendOTP(t *testing.T) {
@@ -507,7 +517,14 @@ func Test_ReceiverSendOTPHandler_sendOTP(t *testing.T) {
 				var messengerType message.MessengerType
 				switch contactType {
 				case data.ReceiverContactTypeSMS:
-					expectedMsg = message.Message{ToPhoneNumber: phoneNumber, Body: tc.wantMessage}
+					expectedMsg = message.Message{
+						ToPhoneNumber: phoneNumber,
+						Body:          tc.wantMessage,
+						Template: &message.MessageTemplate{
+							Type:      message.MessageTemplateTypeReceiverOTP,
+							Variables: []string{otp, organization.Name},
+						},
+					}
 					contactInfo = phoneNumber
 					messengerType = message.MessengerTypeTwilioSMS
 				case data.ReceiverContactTypeEmail:
@@ -521,7 +538,7 @@ func Test_ReceiverSendOTPHandler_sendOTP(t *testing.T) {
 					On("SendMessage",
 						mock.Anything,
 						expectedMsg,
-						[]message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail})
+						[]message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp})
 				if !tc.shouldDispatcherFail {
 					mockCall.Return(messengerType, nil).Once()
 				} else {
@@ -563,14 +580,16 @@ func Test_ReceiverSendOTPHandler_handleOTPForReceiver(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name                  string
-		contactInfo           func(r data.Receiver, contactType data.ReceiverContactType) string
-		dateOfBirth           string
-		sep24ClientDomain     string
-		prepareMocksFn        func(t *testing.T, mockMessageDispatcher *message.MockMessageDispatcher)
-		assertLogsFn          func(t *testing.T, contactType data.ReceiverContactType, r data.Receiver, entries []logrus.Entry)
-		wantVerificationField data.VerificationType
-		wantHttpErr           func(contactType data.ReceiverContactType, r data.Receiver) *httperror.HTTPError
+		name                     string
+		contactInfo              func(r data.Receiver, contactType data.ReceiverContactType) string
+		dateOfBirth              string
+		sep24ClientDomain        string
+		isLockedOut              bool
+		prepareMocksFn           func(t *testing.T, mockMessageDispatcher *message.MockMessageDispatcher)
+		assertLogsFn             func(t *testing.T, contactType data.ReceiverContactType, r data.Receiver, entries []logrus.Entry)
+		wantVerificationField    data.VerificationType
+		wantVerificationQuestion string
+		wantHttpErr              func(contactType data.ReceiverContactType, r data.Receiver) *httperror.HTTPError
 	}{
 		{
 			name: "🟡 false positive if GetLatestByContactInfo returns no results",
@@ -613,6 +632,21 @@ func Test_ReceiverSendOTPHandler_handleOTPForReceiver(t *testing.T) {
 			},
 			wantVerificationField: data.VerificationTypeDateOfBirth,
 		},
+		{
+			name: "🟡 false positive if the receiver is locked out",
+			contactInfo: func(r data.Receiver, contactType data.ReceiverContactType) string {
+				return r.ContactByType(contactType)
+			},
+			sep24ClientDomain: "correct.test",
+			isLockedOut:       true,
+			assertLogsFn: func(t *testing.T, contactType data.ReceiverContactType, r data.Receiver, entries []logrus.Entry) {
+				contactTypeStr := utils.Humanize(string(contactType))
+				truncatedContactInfo := utils.TruncateString(r.ContactByType(contactType), 3)
+				wantLog := fmt.Sprintf("Not sending OTP to %s %s because the receiver is locked out", contactTypeStr, truncatedContactInfo)
+				assert.Contains(t, entries[0].Message, wantLog)
+			},
+			wantVerificationField: data.VerificationTypeDateOfBirth,
+		},
 		{
 			name: "🔴 error if sendOTP fails",
 			contactInfo: func(r data.Receiver, contactType data.ReceiverContactType) string {
@@ -624,7 +658,7 @@ func Test_ReceiverSendOTPHandler_handleOTPForReceiver(t *testing.T) {
 					On("SendMessage",
 						mock.Anything,
 						mock.AnythingOfType("message.Message"),
-						[]message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+						[]message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 					Return(message.MessengerTypeTwilioSMS, errors.New("error sending message")).
 					Once()
 			},
@@ -647,12 +681,13 @@ func Test_ReceiverSendOTPHandler_handleOTPForReceiver(t *testing.T) {
 					On("SendMessage",
 						mock.Anything,
 						mock.AnythingOfType("message.Message"),
-						[]message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+						[]message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 					Return(message.MessengerTypeTwilioSMS, nil).
 					Once()
 			},
-			wantVerificationField: data.VerificationTypePin,
-			wantHttpErr:           nil,
+			wantVerificationField:    data.VerificationTypeCustomSecretQuestion,
+			wantVerificationQuestion: "What is your favorite color?",
+			wantHttpErr:              nil,
 		},
 	}
 
@@ -690,24 +725,36 @@ func Test_ReceiverSendOTPHandler_handleOTPForReceiver(t *testing.T) {
 				receiverWithWallet := data.CreateReceiverFixture(t, ctx, dbConnectionPool, receiverWithWalletInsert)
 				_ = data.CreateReceiverVerificationFixture(t, ctx, dbConnectionPool, data.ReceiverVerificationInsert{
 					ReceiverID:        receiverWithWallet.ID,
-					VerificationField: data.VerificationTypePin,
-					VerificationValue: "123456",
+					VerificationField: data.VerificationTypeCustomSecretQuestion,
+					VerificationValue: "Blue",
+					Question:          "What is your favorite color?",
 				})
 				_ = data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiverWithWallet.ID, wallet.ID, data.RegisteredReceiversWalletStatus)
+				if tc.isLockedOut {
+					err = models.ReceiverVerification.UpdateReceiverVerification(ctx, data.ReceiverVerificationUpdate{
+						ReceiverID:          receiverWithWallet.ID,
+						VerificationField:   data.VerificationTypeCustomSecretQuestion,
+						VerificationChannel: message.MessageChannelSMS,
+						Attempts:            utils.IntPtr(data.MaxAttemptsAllowed),
+						FailedAt:            utils.TimePtr(time.Now()),
+					}, dbConnectionPool)
+					require.NoError(t, err)
+				}
 
 				getEntries := log.DefaultLogger.StartTest(logrus.DebugLevel)
 
 				contactInfo := tc.contactInfo(*receiverWithWallet, contactType)
-				verificationField, httpErr := handler.handleOTPForReceiver(ctx, contactType, contactInfo, tc.sep24ClientDomain)
+				lockoutPolicy := data.VerificationLockoutPolicy{MaxAttempts: data.MaxAttemptsAllowed, Duration: time.Hour}
+				verificationField, verificationQuestion, httpErr := handler.handleOTPForReceiver(ctx, contactType, contactInfo, tc.sep24ClientDomain, lockoutPolicy)
 				if tc.wantHttpErr != nil {
 					wantHTTPErr := tc.wantHttpErr(contactType, *receiverWithWallet)
 					require.NotNil(t, httpErr)
 					assert.Equal(t, *wantHTTPErr, *httpErr)
-					assert.Equal(t, tc.wantVerificationField, verificationField)
 				} else {
 					require.Nil(t, httpErr)
-					assert.Equal(t, tc.wantVerificationField, verificationField)
 				}
+				assert.Equal(t, tc.wantVerificationField, verificationField)
+				assert.Equal(t, tc.wantVerificationQuestion, verificationQuestion)
 
 				entries := getEntries()
 				if tc.assertLogsFn != nil {
@@ -0,0 +1,101 @@
+package httphandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/support/render/httpjson"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/monitor"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
+)
+
+// ReceiverVerificationLockoutsHandler lets admins see the receivers locked out of the wallet registration for failing
+// their verification too many times, and lift their lockouts.
+type ReceiverVerificationLockoutsHandler struct {
+	Models         *data.Models
+	MonitorService monitor.MonitorServiceInterface
+}
+
+type ResetReceiverVerificationAttemptsResponse struct {
+	ReceiverID    string                      `json:"receiver_id"`
+	Verifications []data.ReceiverVerification `json:"verifications"`
+}
+
+// GetLockedReceiverVerifications lists the receiver verifications that are locked out by the organization lockout
+// policy.
+func (h ReceiverVerificationLockoutsHandler) GetLockedReceiverVerifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	organization, err := h.Models.Organizations.Get(ctx)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot retrieve organization", err, nil).Render(w)
+		return
+	}
+
+	lockedVerifications, err := h.Models.ReceiverVerification.GetAllLocked(ctx, h.Models.DBConnectionPool, organization.VerificationLockoutPolicy())
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot retrieve locked receiver verifications", err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, lockedVerifications, httpjson.JSON)
+}
+
+// ResetReceiverVerificationAttempts resets the failed verification attempts of a receiver, lifting their lockout.
+func (h ReceiverVerificationLockoutsHandler) ResetReceiverVerificationAttempts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	receiverID := chi.URLParam(r, "id")
+
+	organization, err := h.Models.Organizations.Get(ctx)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot retrieve organization", err, nil).Render(w)
+		return
+	}
+
+	lockoutPolicy := organization.VerificationLockoutPolicy()
+	now := time.Now()
+	var lockedFields []data.VerificationType
+	resetVerifications, err := db.RunInTransactionWithResult(ctx, h.Models.DBConnectionPool, nil, func(dbTx db.DBTransaction) ([]data.ReceiverVerification, error) {
+		if _, innerErr := h.Models.Receiver.Get(ctx, dbTx, receiverID); innerErr != nil {
+			return nil, fmt.Errorf("getting receiver by ID: %w", innerErr)
+		}
+
+		receiverVerifications, innerErr := h.Models.ReceiverVerification.GetAllByReceiverId(ctx, dbTx, receiverID)
+		if innerErr != nil {
+			return nil, fmt.Errorf("getting receiver verifications for receiver ID: %w", innerErr)
+		}
+		lockedFields = nil
+		for _, rv := range receiverVerifications {
+			if lockoutPolicy.IsLocked(rv, now) {
+				lockedFields = append(lockedFields, rv.VerificationField)
+			}
+		}
+
+		return h.Models.ReceiverVerification.ResetAttempts(ctx, dbTx, receiverID)
+	})
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound(fmt.Sprintf("could not retrieve receiver with ID: %s", receiverID), err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot reset receiver verification attempts", err, nil).Render(w)
+		return
+	}
+
+	for _, verificationField := range lockedFields {
+		log.Ctx(ctx).Infof("reset the lockout of the %s verification of receiver %s", verificationField, receiverID)
+		monitorReceiverVerificationLockout(ctx, h.MonitorService, monitor.ReceiverVerificationLockoutEventReset)
+	}
+
+	httpjson.Render(w, ResetReceiverVerificationAttemptsResponse{
+		ReceiverID:    receiverID,
+		Verifications: resetVerifications,
+	}, httpjson.JSON)
+}
@@ -0,0 +1,120 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/monitor"
+	monitorMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/monitor/mocks"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
+)
+
+func Test_ReceiverVerificationLockoutsHandler(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+	ctx := tenant.SaveTenantInContext(context.Background(), &tenant.Tenant{Name: "aid-org"})
+
+	lockedReceiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+	unlockedReceiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+	for _, receiver := range []*data.Receiver{lockedReceiver, unlockedReceiver} {
+		data.CreateReceiverVerificationFixture(t, ctx, dbConnectionPool, data.ReceiverVerificationInsert{
+			ReceiverID:        receiver.ID,
+			VerificationField: data.VerificationTypeDateOfBirth,
+			VerificationValue: "1990-01-01",
+		})
+	}
+	failedAt := time.Now().Add(-time.Minute)
+	err = models.ReceiverVerification.UpdateReceiverVerification(ctx, data.ReceiverVerificationUpdate{
+		ReceiverID:          lockedReceiver.ID,
+		VerificationField:   data.VerificationTypeDateOfBirth,
+		VerificationChannel: message.MessageChannelSMS,
+		Attempts:            utils.IntPtr(data.MaxAttemptsAllowed),
+		FailedAt:            &failedAt,
+	}, dbConnectionPool)
+	require.NoError(t, err)
+
+	newRouter := func(mMonitorService monitor.MonitorServiceInterface) *chi.Mux {
+		handler := ReceiverVerificationLockoutsHandler{Models: models, MonitorService: mMonitorService}
+		r := chi.NewRouter()
+		r.Get("/receivers/verifications/locked", handler.GetLockedReceiverVerifications)
+		r.Post("/receivers/{id}/verifications/reset", handler.ResetReceiverVerificationAttempts)
+		return r
+	}
+
+	doRequest := func(t *testing.T, r *chi.Mux, method, route string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(ctx, method, route, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("lists the locked receivers", func(t *testing.T) {
+		rr := doRequest(t, newRouter(nil), http.MethodGet, "/receivers/verifications/locked")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var lockedVerifications []data.LockedReceiverVerification
+		err := json.Unmarshal(rr.Body.Bytes(), &lockedVerifications)
+		require.NoError(t, err)
+		require.Len(t, lockedVerifications, 1)
+		assert.Equal(t, lockedReceiver.ID, lockedVerifications[0].ReceiverID)
+		assert.Equal(t, lockedReceiver.PhoneNumber, lockedVerifications[0].PhoneNumber)
+		require.NotNil(t, lockedVerifications[0].LockedUntil)
+		assert.WithinDuration(t, failedAt.Add(time.Hour), *lockedVerifications[0].LockedUntil, time.Second)
+	})
+
+	t.Run("returns an error when resetting a receiver that doesn't exist", func(t *testing.T) {
+		rr := doRequest(t, newRouter(nil), http.MethodPost, "/receivers/invalid-id/verifications/reset")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.JSONEq(t, `{"error": "could not retrieve receiver with ID: invalid-id"}`, rr.Body.String())
+	})
+
+	t.Run("🎉 resets the lockout of a receiver", func(t *testing.T) {
+		mMonitorService := monitorMocks.NewMockMonitorService(t)
+		mMonitorService.
+			On("MonitorCounters", monitor.ReceiverVerificationLockoutEventsTag, monitor.ReceiverVerificationLockoutLabels{
+				Event:      monitor.ReceiverVerificationLockoutEventReset,
+				TenantName: "aid-org",
+			}.ToMap()).
+			Return(nil).
+			Once()
+
+		rr := doRequest(t, newRouter(mMonitorService), http.MethodPost, fmt.Sprintf("/receivers/%s/verifications/reset", lockedReceiver.ID))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp ResetReceiverVerificationAttemptsResponse
+		err := json.Unmarshal(rr.Body.Bytes(), &resp)
+		require.NoError(t, err)
+		assert.Equal(t, lockedReceiver.ID, resp.ReceiverID)
+		require.Len(t, resp.Verifications, 1)
+		assert.Equal(t, 0, resp.Verifications[0].Attempts)
+		assert.Nil(t, resp.Verifications[0].FailedAt)
+
+		rr = doRequest(t, newRouter(nil), http.MethodGet, "/receivers/verifications/locked")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `[]`, rr.Body.String())
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/stellar/go/support/log"
 	"github.com/stellar/go/support/render/httpjson"
 
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
@@ -15,6 +16,7 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/events"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/events/schemas"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
 	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
 )
 
@@ -26,10 +28,23 @@ type RetryInvitationMessageResponse struct {
 	InvitationSentAt *time.Time `json:"invitation_sent_at"`
 }
 
+type UnregisterReceiverWalletResponse struct {
+	ID               string                            `json:"id"`
+	ReceiverID       string                            `json:"receiver_id"`
+	WalletID         string                            `json:"wallet_id"`
+	Status           data.ReceiversWalletStatus        `json:"status"`
+	StatusHistory    data.ReceiversWalletStatusHistory `json:"status_history"`
+	ResetPaymentIDs  []string                          `json:"reset_payment_ids"`
+	CreatedAt        time.Time                         `json:"created_at"`
+	UpdatedAt        time.Time                         `json:"updated_at"`
+	InvitationSentAt *time.Time                        `json:"invitation_sent_at"`
+}
+
 type ReceiverWalletsHandler struct {
 	Models             *data.Models
 	EventProducer      events.Producer
 	CrashTrackerClient crashtracker.CrashTrackerClient
+	AuthManager        auth.AuthManager
 }
 
 func (h ReceiverWalletsHandler) RetryInvitation(rw http.ResponseWriter, req *http.Request) {
@@ -87,3 +102,91 @@ func (h ReceiverWalletsHandler) RetryInvitation(rw http.ResponseWriter, req *htt
 
 	httpjson.RenderStatus(rw, http.StatusOK, response, httpjson.JSON)
 }
+
+// UnregisterReceiverWallet transitions a REGISTERED receiver wallet back to READY, for when the receiver lost access to
+// the Stellar account they registered with. The previous Stellar account and the user are kept in the receiver wallet's
+// status history, its FAILED payments go back to READY, and the invitation is sent again so the receiver can register.
+func (h ReceiverWalletsHandler) UnregisterReceiverWallet(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	_, user, httpErr := getTokenAndUser(ctx, h.AuthManager)
+	if httpErr != nil {
+		httpErr.Render(rw)
+		return
+	}
+
+	tnt, err := tenant.GetTenantFromContext(ctx)
+	if err != nil {
+		httperror.Forbidden("", err, nil).Render(rw)
+		return
+	}
+
+	receiverWalletID := chi.URLParam(req, "receiver_wallet_id")
+
+	var msg *events.Message
+	var resetPaymentIDs []string
+	receiverWallet, err := db.RunInTransactionWithResult(ctx, h.Models.DBConnectionPool, nil, func(dbTx db.DBTransaction) (*data.ReceiverWallet, error) {
+		receiverWallet, err := h.Models.ReceiverWallet.Unregister(ctx, dbTx, receiverWalletID, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("unregistering receiver wallet ID %s: %w", receiverWalletID, err)
+		}
+
+		resetPaymentIDs, err = h.Models.Payment.ResetFailedByReceiverWalletID(ctx, dbTx, receiverWalletID, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("resetting failed payments of receiver wallet ID %s: %w", receiverWalletID, err)
+		}
+
+		// The Circle recipient is bound to the previous Stellar account, so a new one is needed after the receiver registers again.
+		if tnt.DistributionAccountType.IsCircle() {
+			if err = h.Models.CircleRecipient.Delete(ctx, dbTx, receiverWalletID); err != nil {
+				return nil, fmt.Errorf("deleting circle recipient of receiver wallet ID %s: %w", receiverWalletID, err)
+			}
+		}
+
+		eventData := []schemas.EventReceiverWalletInvitationData{{ReceiverWalletID: receiverWalletID}}
+		msg, err = events.NewMessage(ctx, events.ReceiverWalletNewInvitationTopic, receiverWalletID, events.RetryReceiverWalletInvitationType, eventData)
+		if err != nil {
+			return nil, fmt.Errorf("creating event producer message: %w", err)
+		}
+		err = msg.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("validating event producer message %+v: %w", msg, err)
+		}
+
+		return receiverWallet, nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			httperror.NotFound("", err, nil).Render(rw)
+		case errors.Is(err, data.ErrReceiverWalletNotRegistered):
+			httperror.BadRequest("Only registered receiver wallets can be unregistered", err, nil).Render(rw)
+		case errors.Is(err, data.ErrReceiverWalletHasPendingPayments):
+			httperror.Conflict("The receiver wallet has payments pending submission to the Stellar network, please try again once they are completed", err, nil).Render(rw)
+		default:
+			httperror.InternalError(ctx, "", fmt.Errorf("unregistering receiver wallet: %w", err), nil).Render(rw)
+		}
+		return
+	}
+
+	log.Ctx(ctx).Infof("[UnregisterReceiverWallet] User %s unregistered receiver wallet %s, resetting payments %v", user.ID, receiverWalletID, resetPaymentIDs)
+
+	err = events.ProduceEvents(ctx, h.EventProducer, msg)
+	if err != nil {
+		h.CrashTrackerClient.LogAndReportErrors(ctx, err, "writing receiver wallet invitation message on the event producer")
+	}
+
+	response := UnregisterReceiverWalletResponse{
+		ID:               receiverWallet.ID,
+		ReceiverID:       receiverWallet.Receiver.ID,
+		WalletID:         receiverWallet.Wallet.ID,
+		Status:           receiverWallet.Status,
+		StatusHistory:    receiverWallet.StatusHistory,
+		ResetPaymentIDs:  resetPaymentIDs,
+		CreatedAt:        receiverWallet.CreatedAt,
+		UpdatedAt:        receiverWallet.UpdatedAt,
+		InvitationSentAt: receiverWallet.InvitationSentAt,
+	}
+
+	httpjson.RenderStatus(rw, http.StatusOK, response, httpjson.JSON)
+}
@@ -2,6 +2,7 @@ package httphandler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -21,6 +22,8 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/events"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/events/schemas"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/middleware"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
 	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
 )
 
@@ -231,3 +234,136 @@ func Test_RetryInvitation(t *testing.T) {
 		assert.Equal(t, fmt.Sprintf("event producer is nil, could not publish messages %+v", []events.Message{msg}), entries[0].Message)
 	})
 }
+
+func Test_UnregisterReceiverWallet(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+	tnt := tenant.Tenant{ID: "tenant-id"}
+	ctx := tenant.SaveTenantInContext(context.Background(), &tnt)
+	ctx = context.WithValue(ctx, middleware.TokenContextKey, "mytoken")
+
+	user := &auth.User{ID: "user-id", Email: "user@test.com"}
+	wallet := data.CreateWalletFixture(t, ctx, dbConnectionPool, "wallet", "https://www.wallet.com", "www.wallet.com", "wallet://")
+	asset := data.GetAssetFixture(t, ctx, dbConnectionPool, data.FixtureAssetUSDC)
+	disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Wallet: wallet,
+		Asset:  asset,
+		Status: data.StartedDisbursementStatus,
+	})
+
+	newRouter := func(t *testing.T, eventProducer events.Producer) *chi.Mux {
+		authManagerMock := auth.NewAuthManagerMock(t)
+		authManagerMock.
+			On("GetUser", mock.Anything, "mytoken").
+			Return(user, nil).
+			Once()
+		handler := ReceiverWalletsHandler{
+			Models:        models,
+			EventProducer: eventProducer,
+			AuthManager:   authManagerMock,
+		}
+		r := chi.NewRouter()
+		r.Post("/receivers/wallets/{receiver_wallet_id}/unregister", handler.UnregisterReceiverWallet)
+		return r
+	}
+
+	unregister := func(t *testing.T, r *chi.Mux, receiverWalletID string) *httptest.ResponseRecorder {
+		route := fmt.Sprintf("/receivers/wallets/%s/unregister", receiverWalletID)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, route, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("returns error when receiver wallet does not exist", func(t *testing.T) {
+		rr := unregister(t, newRouter(t, nil), "invalid_id")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.JSONEq(t, `{ "error": "Resource not found." }`, rr.Body.String())
+	})
+
+	t.Run("returns error when receiver wallet is not registered", func(t *testing.T) {
+		receiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+		rw := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, data.ReadyReceiversWalletStatus)
+
+		rr := unregister(t, newRouter(t, nil), rw.ID)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{ "error": "Only registered receiver wallets can be unregistered" }`, rr.Body.String())
+	})
+
+	t.Run("returns error when receiver wallet has pending payments", func(t *testing.T) {
+		receiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+		rw := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, data.RegisteredReceiversWalletStatus)
+		data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+			Amount:         "1",
+			Status:         data.PendingPaymentStatus,
+			Disbursement:   disbursement,
+			Asset:          *asset,
+			ReceiverWallet: rw,
+		})
+
+		rr := unregister(t, newRouter(t, nil), rw.ID)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.JSONEq(t, `{ "error": "The receiver wallet has payments pending submission to the Stellar network, please try again once they are completed" }`, rr.Body.String())
+	})
+
+	t.Run("🎉 unregisters the receiver wallet, resets its failed payments and sends the invitation again", func(t *testing.T) {
+		receiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+		rw := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, data.RegisteredReceiversWalletStatus)
+		failedPayment := data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+			Amount:         "1",
+			Status:         data.FailedPaymentStatus,
+			Disbursement:   disbursement,
+			Asset:          *asset,
+			ReceiverWallet: rw,
+		})
+
+		eventProducerMock := events.NewMockProducer(t)
+		eventProducerMock.
+			On("WriteMessages", mock.Anything, []events.Message{
+				{
+					Topic:    events.ReceiverWalletNewInvitationTopic,
+					Key:      rw.ID,
+					TenantID: tnt.ID,
+					Type:     events.RetryReceiverWalletInvitationType,
+					Data: []schemas.EventReceiverWalletInvitationData{
+						{ReceiverWalletID: rw.ID},
+					},
+				},
+			}).
+			Return(nil).
+			Once()
+
+		rr := unregister(t, newRouter(t, eventProducerMock), rw.ID)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response UnregisterReceiverWalletResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, rw.ID, response.ID)
+		assert.Equal(t, receiver.ID, response.ReceiverID)
+		assert.Equal(t, wallet.ID, response.WalletID)
+		assert.Equal(t, data.ReadyReceiversWalletStatus, response.Status)
+		assert.Equal(t, []string{failedPayment.ID}, response.ResetPaymentIDs)
+		assert.Nil(t, response.InvitationSentAt)
+
+		lastEntry := response.StatusHistory[len(response.StatusHistory)-1]
+		assert.Equal(t, data.ReadyReceiversWalletStatus, lastEntry.Status)
+		assert.Equal(t, user.ID, lastEntry.UserID)
+		assert.Equal(t, rw.StellarAddress, lastEntry.StellarAddress)
+
+		payment, err := models.Payment.Get(ctx, failedPayment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, data.ReadyPaymentStatus, payment.Status)
+	})
+}
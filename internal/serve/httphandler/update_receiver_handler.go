@@ -40,16 +40,11 @@ func createVerificationInsert(updateReceiverInfo *validators.UpdateReceiverReque
 	}
 
 	for _, verificationField := range data.GetAllVerificationTypes() {
-		switch verificationField {
-		case data.VerificationTypeDateOfBirth:
-			appendNewVerificationValue(verificationField, updateReceiverInfo.DateOfBirth)
-		case data.VerificationTypeYearMonth:
-			appendNewVerificationValue(verificationField, updateReceiverInfo.YearMonth)
-		case data.VerificationTypePin:
-			appendNewVerificationValue(verificationField, updateReceiverInfo.Pin)
-		case data.VerificationTypeNationalID:
-			appendNewVerificationValue(verificationField, updateReceiverInfo.NationalID)
+		verificationValue, ok := updateReceiverInfo.Verifications[verificationField]
+		if !ok {
+			verificationValue = updateReceiverInfo.LegacyVerificationValue(verificationField)
 		}
+		appendNewVerificationValue(verificationField, verificationValue)
 	}
 
 	return receiverVerifications
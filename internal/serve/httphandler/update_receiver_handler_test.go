@@ -90,6 +90,22 @@ func Test_UpdateReceiverHandler_createVerificationInsert(t *testing.T) {
 			},
 			want: []data.ReceiverVerificationInsert{verificationDOB, verificationPIN, verificationNationalID},
 		},
+		{
+			name: "insert receiver verification values of any type, which take precedence over their own fields",
+			updateReceiverRequest: validators.UpdateReceiverRequest{
+				DateOfBirth: "1990-12-31",
+				Pin:         "123",
+				Verifications: map[data.VerificationType]string{
+					data.VerificationTypeTaxID:       "BR:529.982.247-25",
+					data.VerificationTypeDateOfBirth: "1999-01-01",
+				},
+			},
+			want: []data.ReceiverVerificationInsert{
+				verificationDOB,
+				verificationPIN,
+				{ReceiverID: receiverID, VerificationField: data.VerificationTypeTaxID, VerificationValue: "BR:529.982.247-25"},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
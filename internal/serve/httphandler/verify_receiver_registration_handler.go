@@ -19,6 +19,7 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/events"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/events/schemas"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/monitor"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/validators"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing"
@@ -54,6 +55,9 @@ type VerifyReceiverRegistrationHandler struct {
 	EventProducer               events.Producer
 	CrashTrackerClient          crashtracker.CrashTrackerClient
 	DistributionAccountResolver signing.DistributionAccountResolver
+	// RateLimiter enforces the organization rate limits on the endpoint. They aren't enforced when it's nil.
+	RateLimiter    *RegistrationRateLimiter
+	MonitorService monitor.MonitorServiceInterface
 }
 
 // validate validates the request [header, body, body.reCAPTCHA_token], and returns the decoded payload, or an http error.
@@ -107,13 +111,14 @@ func (v VerifyReceiverRegistrationHandler) validate(r *http.Request) (reqObj dat
 // processReceiverVerificationPII processes the receiver verification entry to make sure the verification value
 // provided matches the one saved in the database for the given user (phone number). It returns an error if:
 // - there is no receiver verification entry for the given receiverID and verificationType
-// - the number of attempts to confirm the verification value has already exceeded the max value
+// - the receiver is locked out for exceeding the max number of attempts to confirm the verification value
 // - the payload verification value does not match the one saved in the database
 func (v VerifyReceiverRegistrationHandler) processReceiverVerificationPII(
 	ctx context.Context,
 	dbTx db.DBTransaction,
 	receiver data.Receiver,
 	receiverRegistrationRequest data.ReceiverRegistrationRequest,
+	lockoutPolicy data.VerificationLockoutPolicy,
 ) error {
 	now := time.Now()
 
@@ -131,12 +136,17 @@ func (v VerifyReceiverRegistrationHandler) processReceiverVerificationPII(
 	}
 	receiverVerification := receiverVerifications[0]
 
-	// STEP 2: check if the number of attempts to confirm the verification value has already exceeded the max value
-	if v.Models.ReceiverVerification.ExceededAttempts(receiverVerification.Attempts) {
-		// TODO: the application currently can't recover from a max attempts exceeded error.
+	// STEP 2: check if the receiver is locked out for exceeding the max number of attempts to confirm the verification value
+	if lockoutPolicy.IsLocked(*receiverVerification, now) {
+		monitorReceiverVerificationLockout(ctx, v.MonitorService, monitor.ReceiverVerificationLockoutEventRejected)
 		err = fmt.Errorf("the number of attempts to confirm the verification value exceeded the max attempts")
 		return &ErrorVerificationAttemptsExceeded{cause: err}
 	}
+	attempts := receiverVerification.Attempts
+	if lockoutPolicy.ExceededAttempts(attempts) {
+		// The lockout is over, so the receiver gets a new round of attempts.
+		attempts = 0
+	}
 
 	// STEP 3: check if the payload verification value matches the one saved in the database
 	rvu := data.ReceiverVerificationUpdate{
@@ -153,10 +163,10 @@ func (v VerifyReceiverRegistrationHandler) processReceiverVerificationPII(
 		return &ErrorInformationNotFound{cause: err}
 	}
 
-	if !data.CompareVerificationValue(receiverVerification.HashedValue, receiverRegistrationRequest.VerificationValue) {
+	if !receiverVerification.VerificationField.CompareValue(receiverVerification.HashedValue, receiverRegistrationRequest.VerificationValue) {
 		baseErrMsg := fmt.Sprintf("%s value does not match for receiver with id %s", receiverRegistrationRequest.VerificationField, receiver.ID)
 		// update the receiver verification with the confirmation that the value was checked
-		rvu.Attempts = utils.IntPtr(receiverVerification.Attempts + 1)
+		rvu.Attempts = utils.IntPtr(attempts + 1)
 		rvu.FailedAt = &now
 
 		// this update is done using the DBConnectionPool and not dbTx because we don't want to rollback these changes after returning the error
@@ -165,6 +175,10 @@ func (v VerifyReceiverRegistrationHandler) processReceiverVerificationPII(
 			err = fmt.Errorf("%s: %w", baseErrMsg, updateErr)
 		} else {
 			err = fmt.Errorf("%s", baseErrMsg)
+			if lockoutPolicy.ExceededAttempts(*rvu.Attempts) {
+				log.Ctx(ctx).Warnf("receiver with id %s was locked out after %d failed attempts", receiver.ID, *rvu.Attempts)
+				monitorReceiverVerificationLockout(ctx, v.MonitorService, monitor.ReceiverVerificationLockoutEventLocked)
+			}
 		}
 
 		return &ErrorInformationNotFound{cause: err}
@@ -300,6 +314,16 @@ func (v VerifyReceiverRegistrationHandler) VerifyReceiverRegistration(w http.Res
 
 	truncatedContactInfo := utils.TruncateString(contactInfo, 3)
 
+	organization, err := v.Models.Organizations.Get(ctx)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot retrieve organization", err, nil).Render(w)
+		return
+	}
+	if httpErr = v.RateLimiter.Check(ctx, r, organization, RegistrationEndpointVerification, contactInfo); httpErr != nil {
+		httpErr.Render(w)
+		return
+	}
+
 	opts := db.TransactionOptions{
 		DBConnectionPool: v.Models.DBConnectionPool,
 		AtomicFunctionWithPostCommit: func(dbTx db.DBTransaction) (postCommitFn db.PostCommitFunction, err error) {
@@ -316,7 +340,7 @@ func (v VerifyReceiverRegistrationHandler) VerifyReceiverRegistration(w http.Res
 
 			// STEP 3: process receiverVerification PII info that matches the pair [receiverID, verificationType]
 			receiver := receivers[0]
-			err = v.processReceiverVerificationPII(ctx, dbTx, *receiver, receiverRegistrationRequest)
+			err = v.processReceiverVerificationPII(ctx, dbTx, *receiver, receiverRegistrationRequest, organization.VerificationLockoutPolicy())
 			if err != nil {
 				return nil, fmt.Errorf("processing receiver verification entry for receiver with contact info %s: %w", truncatedContactInfo, err)
 			}
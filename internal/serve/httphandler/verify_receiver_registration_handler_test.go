@@ -28,6 +28,8 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/events"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/events/schemas"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/monitor"
+	monitorMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/monitor/mocks"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/validators"
 	sigMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing/mocks"
@@ -227,6 +229,8 @@ func Test_VerifyReceiverRegistrationHandler_processReceiverVerificationPII(t *te
 	defer data.DeleteAllReceiversFixtures(t, ctx, dbConnectionPool)
 	defer data.DeleteAllReceiverVerificationFixtures(t, ctx, dbConnectionPool)
 
+	lockoutPolicy := data.VerificationLockoutPolicy{MaxAttempts: data.MaxAttemptsAllowed, Duration: time.Hour}
+
 	testCases := []struct {
 		name                      string
 		receiver                  data.Receiver
@@ -315,7 +319,7 @@ func Test_VerifyReceiverRegistrationHandler_processReceiverVerificationPII(t *te
 				receiverVerificationInitial = receiverVerifications[0]
 			}
 
-			err = handler.processReceiverVerificationPII(ctx, dbTx, tc.receiver, tc.registrationRequest)
+			err = handler.processReceiverVerificationPII(ctx, dbTx, tc.receiver, tc.registrationRequest, lockoutPolicy)
 
 			if tc.wantErrContains == "" {
 				receiverVerifications, err = models.ReceiverVerification.GetByReceiverIDsAndVerificationField(ctx, dbTx, []string{tc.receiver.ID}, tc.registrationRequest.VerificationField)
@@ -336,6 +340,68 @@ func Test_VerifyReceiverRegistrationHandler_processReceiverVerificationPII(t *te
 			}
 		})
 	}
+
+	t.Run("receivers whose lockout is over get a new round of attempts", func(t *testing.T) {
+		receiverWithExpiredLockout := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+		data.CreateReceiverVerificationFixture(t, ctx, dbConnectionPool, data.ReceiverVerificationInsert{
+			ReceiverID:        receiverWithExpiredLockout.ID,
+			VerificationField: data.VerificationTypeDateOfBirth,
+			VerificationValue: "1990-01-01",
+		})
+		err = models.ReceiverVerification.UpdateReceiverVerification(ctx, data.ReceiverVerificationUpdate{
+			ReceiverID:          receiverWithExpiredLockout.ID,
+			VerificationField:   data.VerificationTypeDateOfBirth,
+			Attempts:            utils.IntPtr(data.MaxAttemptsAllowed),
+			FailedAt:            utils.TimePtr(time.Now().Add(-2 * time.Hour)),
+			VerificationChannel: message.MessageChannelSMS,
+		}, dbConnectionPool)
+		require.NoError(t, err)
+
+		mMonitorService := monitorMocks.NewMockMonitorService(t)
+		lockoutHandler := *handler
+		lockoutHandler.MonitorService = mMonitorService
+		processVerification := func(t *testing.T, registrationRequest data.ReceiverRegistrationRequest, policy data.VerificationLockoutPolicy) error {
+			dbTx, err := dbConnectionPool.BeginTxx(ctx, nil)
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, dbTx.Rollback())
+			}()
+			return lockoutHandler.processReceiverVerificationPII(ctx, dbTx, *receiverWithExpiredLockout, registrationRequest, policy)
+		}
+
+		registrationRequest := data.ReceiverRegistrationRequest{
+			PhoneNumber:       receiverWithExpiredLockout.PhoneNumber,
+			VerificationField: data.VerificationTypeDateOfBirth,
+			VerificationValue: "1990-11-11",
+		}
+		err = processVerification(t, registrationRequest, lockoutPolicy)
+		require.ErrorContains(t, err, "DATE_OF_BIRTH value does not match for receiver with id "+receiverWithExpiredLockout.ID)
+
+		receiverVerifications, err := models.ReceiverVerification.GetByReceiverIDsAndVerificationField(ctx, dbConnectionPool, []string{receiverWithExpiredLockout.ID}, data.VerificationTypeDateOfBirth)
+		require.NoError(t, err)
+		require.Len(t, receiverVerifications, 1)
+		assert.Equal(t, 1, receiverVerifications[0].Attempts)
+
+		t.Run("and are locked out again once they exceed them", func(t *testing.T) {
+			mMonitorService.
+				On("MonitorCounters", monitor.ReceiverVerificationLockoutEventsTag, monitor.ReceiverVerificationLockoutLabels{Event: monitor.ReceiverVerificationLockoutEventLocked}.ToMap()).
+				Return(nil).
+				Once()
+			mMonitorService.
+				On("MonitorCounters", monitor.ReceiverVerificationLockoutEventsTag, monitor.ReceiverVerificationLockoutLabels{Event: monitor.ReceiverVerificationLockoutEventRejected}.ToMap()).
+				Return(nil).
+				Once()
+
+			policy := data.VerificationLockoutPolicy{MaxAttempts: 2, Duration: time.Hour}
+			err = processVerification(t, registrationRequest, policy)
+			require.ErrorContains(t, err, "DATE_OF_BIRTH value does not match")
+
+			registrationRequest.VerificationValue = "1990-01-01"
+			err = processVerification(t, registrationRequest, policy)
+			var errorVerificationAttemptsExceeded *ErrorVerificationAttemptsExceeded
+			require.ErrorAs(t, err, &errorVerificationAttemptsExceeded)
+		})
+	})
 }
 
 func Test_VerifyReceiverRegistrationHandler_processReceiverWalletOTP(t *testing.T) {
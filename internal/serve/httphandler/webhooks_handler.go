@@ -0,0 +1,236 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/render/httpjson"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/validators"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
+)
+
+const (
+	webhookSecretLength             = 32
+	defaultWebhookDeliveriesLimit   = 50
+	maxWebhookDeliveriesLimit       = 200
+	webhookDeliveriesLimitParamName = "limit"
+)
+
+// WebhooksHandler manages the webhook subscriptions of the tenant and exposes their delivery log.
+type WebhooksHandler struct {
+	Models               *data.Models
+	AuthManager          auth.AuthManager
+	Encrypter            utils.PrivateKeyEncrypter
+	EncryptionPassphrase string
+	utils.NetworkType
+}
+
+type PostWebhookRequest struct {
+	URL        string                 `json:"url"`
+	EventTypes data.WebhookEventTypes `json:"event_types"`
+}
+
+type PatchWebhookRequest struct {
+	URL        string                 `json:"url"`
+	EventTypes data.WebhookEventTypes `json:"event_types"`
+	Enabled    *bool                  `json:"enabled"`
+}
+
+// PostWebhookResponse is the only response that includes the subscription secret, used to verify the deliveries.
+type PostWebhookResponse struct {
+	*data.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+func (h WebhooksHandler) validateURL(v *validators.Validator, url string) {
+	schemes := []string{"https"}
+	if !h.IsPubnet() {
+		schemes = append(schemes, "http")
+	}
+	v.CheckError(utils.ValidateURLScheme(url, schemes...), "url", fmt.Sprintf("url must be a valid URL with one of the schemes %v", schemes))
+	v.CheckError(utils.ValidatePublicURLHost(url), "url", "url must not point to a local or private address")
+}
+
+func (h WebhooksHandler) PostWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, user, httpErr := getTokenAndUser(ctx, h.AuthManager)
+	if httpErr != nil {
+		httpErr.Render(w)
+		return
+	}
+
+	var req PostWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest("invalid request body", err, nil).Render(w)
+		return
+	}
+
+	v := validators.NewValidator()
+	h.validateURL(v, req.URL)
+	v.CheckError(req.EventTypes.Validate(), "event_types", fmt.Sprintf("event_types must be a non-empty list of %v", data.AllWebhookEventTypes()))
+	if v.HasErrors() {
+		httperror.BadRequest("", nil, v.Errors).Render(w)
+		return
+	}
+
+	secret, err := utils.RandomString(webhookSecretLength)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot generate webhook secret", err, nil).Render(w)
+		return
+	}
+
+	kp, err := keypair.ParseFull(h.EncryptionPassphrase)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot parse the encryption keypair", err, nil).Render(w)
+		return
+	}
+
+	encryptedSecret, err := h.Encrypter.Encrypt(secret, kp.Seed())
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot encrypt the webhook secret", err, nil).Render(w)
+		return
+	}
+
+	subscription, err := h.Models.WebhookSubscriptions.Insert(ctx, h.Models.DBConnectionPool, data.WebhookSubscriptionInsert{
+		URL:                req.URL,
+		EncryptedSecret:    encryptedSecret,
+		EncrypterPublicKey: kp.Address(),
+		EventTypes:         req.EventTypes,
+		CreatedBy:          user.ID,
+	})
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot create webhook", err, nil).Render(w)
+		return
+	}
+
+	httpjson.RenderStatus(w, http.StatusCreated, PostWebhookResponse{WebhookSubscription: subscription, Secret: secret}, httpjson.JSON)
+}
+
+func (h WebhooksHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	subscriptions, err := h.Models.WebhookSubscriptions.GetAll(ctx, h.Models.DBConnectionPool)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get webhooks", err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, subscriptions, httpjson.JSON)
+}
+
+func (h WebhooksHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	subscription, err := h.Models.WebhookSubscriptions.Get(ctx, h.Models.DBConnectionPool, chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound("webhook not found", err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot get webhook", err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, subscription, httpjson.JSON)
+}
+
+// PatchWebhook edits a webhook. Disabled webhooks stop receiving new deliveries.
+func (h WebhooksHandler) PatchWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req PatchWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest("invalid request body", err, nil).Render(w)
+		return
+	}
+
+	v := validators.NewValidator()
+	v.Check(req.URL != "" || req.EventTypes != nil || req.Enabled != nil, "body", "at least one of url, event_types or enabled is required")
+	if req.URL != "" {
+		h.validateURL(v, req.URL)
+	}
+	if req.EventTypes != nil {
+		v.CheckError(req.EventTypes.Validate(), "event_types", fmt.Sprintf("event_types must be a non-empty list of %v", data.AllWebhookEventTypes()))
+	}
+	if v.HasErrors() {
+		httperror.BadRequest("", nil, v.Errors).Render(w)
+		return
+	}
+
+	subscription, err := h.Models.WebhookSubscriptions.Update(ctx, h.Models.DBConnectionPool, chi.URLParam(r, "id"), data.WebhookSubscriptionUpdate{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Enabled:    req.Enabled,
+	})
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound("webhook not found", err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot update webhook", err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, subscription, httpjson.JSON)
+}
+
+func (h WebhooksHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := h.Models.WebhookSubscriptions.Delete(ctx, h.Models.DBConnectionPool, chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound("webhook not found", err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot delete webhook", err, nil).Render(w)
+		return
+	}
+
+	httpjson.RenderStatus(w, http.StatusNoContent, nil, httpjson.JSON)
+}
+
+// GetWebhookDeliveries returns the delivery log of a webhook, most recent first.
+func (h WebhooksHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit := defaultWebhookDeliveriesLimit
+	if limitParam := r.URL.Query().Get(webhookDeliveriesLimitParamName); limitParam != "" {
+		parsedLimit, err := strconv.Atoi(limitParam)
+		if err != nil || parsedLimit < 1 || parsedLimit > maxWebhookDeliveriesLimit {
+			httperror.BadRequest("", err, map[string]interface{}{
+				webhookDeliveriesLimitParamName: fmt.Sprintf("limit must be an integer between 1 and %d", maxWebhookDeliveriesLimit),
+			}).Render(w)
+			return
+		}
+		limit = parsedLimit
+	}
+
+	subscription, err := h.Models.WebhookSubscriptions.Get(ctx, h.Models.DBConnectionPool, chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			httperror.NotFound("webhook not found", err, nil).Render(w)
+			return
+		}
+		httperror.InternalError(ctx, "Cannot get webhook", err, nil).Render(w)
+		return
+	}
+
+	deliveries, err := h.Models.WebhookDeliveries.GetAllBySubscriptionID(ctx, h.Models.DBConnectionPool, subscription.ID, limit)
+	if err != nil {
+		httperror.InternalError(ctx, "Cannot get webhook deliveries", err, nil).Render(w)
+		return
+	}
+
+	httpjson.Render(w, deliveries, httpjson.JSON)
+}
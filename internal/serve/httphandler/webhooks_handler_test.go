@@ -0,0 +1,248 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/middleware"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
+)
+
+func Test_WebhooksHandler_validation(t *testing.T) {
+	token := "token"
+	ctx := context.WithValue(context.Background(), middleware.TokenContextKey, token)
+	authManagerMock := &auth.AuthManagerMock{}
+	authManagerMock.
+		On("GetUser", mock.Anything, token).
+		Return(&auth.User{ID: "user-id"}, nil)
+
+	testCases := []struct {
+		name         string
+		networkType  utils.NetworkType
+		method       string
+		body         string
+		wantContains string
+	}{
+		{
+			name:         "POST with invalid url",
+			networkType:  utils.TestnetNetworkType,
+			method:       http.MethodPost,
+			body:         `{"url": "not-a-url", "event_types": ["payment.status_changed"]}`,
+			wantContains: "url must be a valid URL with one of the schemes [https http]",
+		},
+		{
+			name:         "POST with http url on pubnet",
+			networkType:  utils.PubnetNetworkType,
+			method:       http.MethodPost,
+			body:         `{"url": "http://example.com/webhooks", "event_types": ["payment.status_changed"]}`,
+			wantContains: "url must be a valid URL with one of the schemes [https]",
+		},
+		{
+			name:         "POST with a localhost url",
+			networkType:  utils.TestnetNetworkType,
+			method:       http.MethodPost,
+			body:         `{"url": "http://localhost:8000/webhooks", "event_types": ["payment.status_changed"]}`,
+			wantContains: "url must not point to a local or private address",
+		},
+		{
+			name:         "PATCH with a cloud metadata url",
+			networkType:  utils.TestnetNetworkType,
+			method:       http.MethodPatch,
+			body:         `{"url": "http://169.254.169.254/latest/meta-data"}`,
+			wantContains: "url must not point to a local or private address",
+		},
+		{
+			name:         "POST without event types",
+			networkType:  utils.TestnetNetworkType,
+			method:       http.MethodPost,
+			body:         `{"url": "https://example.com/webhooks"}`,
+			wantContains: "event_types must be a non-empty list of",
+		},
+		{
+			name:         "POST with unknown event types",
+			networkType:  utils.TestnetNetworkType,
+			method:       http.MethodPost,
+			body:         `{"url": "https://example.com/webhooks", "event_types": ["payment.created"]}`,
+			wantContains: "event_types must be a non-empty list of",
+		},
+		{
+			name:         "PATCH without fields",
+			networkType:  utils.TestnetNetworkType,
+			method:       http.MethodPatch,
+			body:         `{}`,
+			wantContains: "at least one of url, event_types or enabled is required",
+		},
+		{
+			name:         "PATCH with empty event types",
+			networkType:  utils.TestnetNetworkType,
+			method:       http.MethodPatch,
+			body:         `{"event_types": []}`,
+			wantContains: "event_types must be a non-empty list of",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := WebhooksHandler{AuthManager: authManagerMock, NetworkType: tc.networkType}
+			r := chi.NewRouter()
+			r.Post("/webhooks", handler.PostWebhook)
+			r.Patch("/webhooks/{id}", handler.PatchWebhook)
+
+			url := "/webhooks"
+			if tc.method == http.MethodPatch {
+				url = "/webhooks/1234"
+			}
+			req, err := http.NewRequestWithContext(ctx, tc.method, url, strings.NewReader(tc.body))
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Contains(t, rr.Body.String(), tc.wantContains)
+		})
+	}
+}
+
+func Test_WebhooksHandler(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	token := "token"
+	ctx := context.WithValue(context.Background(), middleware.TokenContextKey, token)
+	authManagerMock := &auth.AuthManagerMock{}
+	authManagerMock.
+		On("GetUser", mock.Anything, token).
+		Return(&auth.User{ID: "user-id"}, nil)
+	defer authManagerMock.AssertExpectations(t)
+	defer data.DeleteAllWebhookFixtures(t, ctx, dbConnectionPool)
+
+	encryptionKP := keypair.MustRandom()
+	encrypter := &utils.DefaultPrivateKeyEncrypter{}
+	handler := WebhooksHandler{
+		Models:               models,
+		AuthManager:          authManagerMock,
+		Encrypter:            encrypter,
+		EncryptionPassphrase: encryptionKP.Seed(),
+		NetworkType:          utils.PubnetNetworkType,
+	}
+	r := chi.NewRouter()
+	r.Get("/webhooks", handler.GetWebhooks)
+	r.Post("/webhooks", handler.PostWebhook)
+	r.Get("/webhooks/{id}", handler.GetWebhook)
+	r.Patch("/webhooks/{id}", handler.PatchWebhook)
+	r.Delete("/webhooks/{id}", handler.DeleteWebhook)
+	r.Get("/webhooks/{id}/deliveries", handler.GetWebhookDeliveries)
+
+	do := func(t *testing.T, method, url, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	var subscription PostWebhookResponse
+	t.Run("🎉 creates a webhook and returns its secret", func(t *testing.T) {
+		rr := do(t, http.MethodPost, "/webhooks", `{"url": "https://example.com/webhooks", "event_types": ["disbursement.status_changed"]}`)
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &subscription))
+		assert.NotEmpty(t, subscription.ID)
+		assert.Equal(t, "https://example.com/webhooks", subscription.URL)
+		assert.Equal(t, data.WebhookEventTypes{data.DisbursementStatusChangedWebhookEventType}, subscription.EventTypes)
+		assert.True(t, subscription.Enabled)
+		assert.Equal(t, "user-id", subscription.CreatedBy)
+		assert.Len(t, subscription.Secret, webhookSecretLength)
+
+		stored, err := models.WebhookSubscriptions.Get(ctx, dbConnectionPool, subscription.ID)
+		require.NoError(t, err)
+		assert.NotEqual(t, subscription.Secret, stored.EncryptedSecret)
+		assert.Equal(t, encryptionKP.Address(), stored.EncrypterPublicKey)
+		decryptedSecret, err := encrypter.Decrypt(stored.EncryptedSecret, encryptionKP.Seed())
+		require.NoError(t, err)
+		assert.Equal(t, subscription.Secret, decryptedSecret)
+	})
+
+	t.Run("🎉 lists and gets the webhooks without their secret", func(t *testing.T) {
+		rr := do(t, http.MethodGet, "/webhooks", "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var subscriptions []data.WebhookSubscription
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &subscriptions))
+		require.Len(t, subscriptions, 1)
+		assert.Equal(t, subscription.ID, subscriptions[0].ID)
+		assert.NotContains(t, rr.Body.String(), subscription.Secret)
+
+		rr = do(t, http.MethodGet, "/webhooks/"+subscription.ID, "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), subscription.Secret)
+
+		rr = do(t, http.MethodGet, "/webhooks/not-found", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("🎉 updates a webhook", func(t *testing.T) {
+		rr := do(t, http.MethodPatch, "/webhooks/"+subscription.ID, `{"enabled": false, "event_types": ["payment.status_changed"]}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var updated data.WebhookSubscription
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &updated))
+		assert.False(t, updated.Enabled)
+		assert.Equal(t, data.WebhookEventTypes{data.PaymentStatusChangedWebhookEventType}, updated.EventTypes)
+		assert.Equal(t, subscription.URL, updated.URL)
+
+		rr = do(t, http.MethodPatch, "/webhooks/not-found", `{"enabled": true}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("🎉 gets the delivery log of a webhook", func(t *testing.T) {
+		rr := do(t, http.MethodPatch, "/webhooks/"+subscription.ID, `{"enabled": true, "event_types": ["disbursement.status_changed"]}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{Status: data.DraftDisbursementStatus})
+		err := models.Disbursements.UpdateStatus(ctx, dbConnectionPool, "user-id", disbursement.ID, data.ReadyDisbursementStatus)
+		require.NoError(t, err)
+
+		rr = do(t, http.MethodGet, "/webhooks/"+subscription.ID+"/deliveries", "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var deliveries []data.WebhookDelivery
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &deliveries))
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, data.DisbursementStatusChangedWebhookEventType, deliveries[0].EventType)
+		assert.Equal(t, data.PendingWebhookDeliveryStatus, deliveries[0].Status)
+
+		rr = do(t, http.MethodGet, "/webhooks/"+subscription.ID+"/deliveries?limit=0", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = do(t, http.MethodGet, "/webhooks/not-found/deliveries", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("🎉 deletes a webhook", func(t *testing.T) {
+		rr := do(t, http.MethodDelete, "/webhooks/"+subscription.ID, "")
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+
+		rr = do(t, http.MethodDelete, "/webhooks/"+subscription.ID, "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
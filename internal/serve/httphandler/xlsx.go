@@ -0,0 +1,284 @@
+package httphandler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/xuri/excelize/v2"
+)
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+const (
+	// xlsxUnzipSizeLimit is the max uncompressed size of the uploaded XLSX files, so a small upload can't inflate into
+	// gigabytes. It's well above the size of a sheet with the max number of instructions.
+	xlsxUnzipSizeLimit = 50 * 1024 * 1024
+	// xlsxUnzipXMLSizeLimit is the max size of a sheet unzipped in memory, above which it's unzipped to a temp file.
+	xlsxUnzipXMLSizeLimit = 16 * 1024 * 1024
+)
+
+// convertXLSXToCSV converts the first sheet of an XLSX file to CSV, so it goes through the same header validation and
+// parsing as the uploaded CSV files. Cells are read with their raw values, so numbers keep their full precision
+// regardless of how they're displayed, except for the cells formatted as dates, which are written as YYYY-MM-DD.
+func convertXLSXToCSV(fileContent io.Reader) ([]byte, error) {
+	f, err := excelize.OpenReader(fileContent, excelize.Options{
+		UnzipSizeLimit:    xlsxUnzipSizeLimit,
+		UnzipXMLSizeLimit: xlsxUnzipXMLSizeLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("opening xlsx file: %w", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("xlsx file has no sheets")
+	}
+	sheet := sheets[0]
+
+	rows, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("reading rows of sheet %s: %w", sheet, err)
+	}
+
+	date1904 := false
+	if props, propsErr := f.GetWorkbookProps(); propsErr == nil && props.Date1904 != nil {
+		date1904 = *props.Date1904
+	}
+
+	// Rows are padded to the same number of columns, since trailing empty cells are omitted.
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+
+	isDateStyle := map[int]bool{}
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	for i, row := range rows {
+		if isEmptyXLSXRow(row) {
+			continue
+		}
+
+		record := make([]string, columns)
+		for j, value := range row {
+			record[j] = value
+			if i == 0 || value == "" {
+				continue
+			}
+
+			serial, parseErr := strconv.ParseFloat(value, 64)
+			if parseErr != nil {
+				continue
+			}
+			cell, cellErr := excelize.CoordinatesToCellName(j+1, i+1)
+			if cellErr != nil {
+				return nil, fmt.Errorf("getting name of cell (%d,%d): %w", j+1, i+1, cellErr)
+			}
+			isDate, styleErr := isXLSXDateCell(f, sheet, cell, isDateStyle)
+			if styleErr != nil {
+				return nil, fmt.Errorf("getting style of cell %s: %w", cell, styleErr)
+			}
+			if isDate {
+				date, dateErr := excelize.ExcelDateToTime(serial, date1904)
+				if dateErr != nil {
+					return nil, fmt.Errorf("converting cell %s to date: %w", cell, dateErr)
+				}
+				record[j] = date.Format(time.DateOnly)
+			}
+		}
+
+		if err = writer.Write(record); err != nil {
+			return nil, fmt.Errorf("writing csv record: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return nil, fmt.Errorf("flushing csv: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func isEmptyXLSXRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isXLSXDateCell returns whether a numeric cell is formatted as a date, caching the result by style.
+func isXLSXDateCell(f *excelize.File, sheet, cell string, isDateStyle map[int]bool) (bool, error) {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return false, fmt.Errorf("getting cell style: %w", err)
+	}
+	if isDate, ok := isDateStyle[styleID]; ok {
+		return isDate, nil
+	}
+
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return false, fmt.Errorf("getting style %d: %w", styleID, err)
+	}
+	isDate := isXLSXDateNumFmt(style)
+	isDateStyle[styleID] = isDate
+
+	return isDate, nil
+}
+
+// isXLSXDateNumFmt returns whether the number format of a style is one of the built-in date formats, or a custom format
+// with year or day tokens outside of quoted literals.
+func isXLSXDateNumFmt(style *excelize.Style) bool {
+	if style.CustomNumFmt != nil {
+		inLiteral := false
+		for _, r := range strings.ToLower(*style.CustomNumFmt) {
+			switch {
+			case r == '"':
+				inLiteral = !inLiteral
+			case !inLiteral && (r == 'y' || r == 'd'):
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case style.NumFmt >= 14 && style.NumFmt <= 17, style.NumFmt == 22:
+		return true
+	case style.NumFmt >= 27 && style.NumFmt <= 31, style.NumFmt >= 34 && style.NumFmt <= 36:
+		return true
+	case style.NumFmt >= 50 && style.NumFmt <= 58:
+		return true
+	default:
+		return false
+	}
+}
+
+// xlsxCellType is the type of the cells of an exported XLSX column.
+type xlsxCellType int
+
+const (
+	xlsxTextCell xlsxCellType = iota
+	xlsxNumberCell
+	xlsxDateCell
+)
+
+// xlsxDateNumFmt is the number format of the exported date cells.
+const xlsxDateNumFmt = "yyyy-mm-dd hh:mm:ss"
+
+// xlsxSheetWriter writes the rows marshaled by gocsv into an XLSX sheet, so the XLSX exports have the same columns as
+// the CSV ones. The cells of the columns in cellTypes are written as numbers or dates, and the others as text.
+type xlsxSheetWriter struct {
+	streamWriter *excelize.StreamWriter
+	cellTypes    map[string]xlsxCellType
+	dateStyleID  int
+	header       []string
+	rowNumber    int
+	err          error
+}
+
+var _ gocsv.CSVWriter = (*xlsxSheetWriter)(nil)
+
+func (w *xlsxSheetWriter) Write(row []string) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	w.rowNumber++
+	values := make([]interface{}, len(row))
+	for i, value := range row {
+		values[i] = value
+	}
+	if w.header == nil {
+		// The row is copied, since its slice is reused by gocsv for the following rows.
+		w.header = slices.Clone(row)
+	} else {
+		for i, value := range row {
+			values[i] = w.typedCellValue(w.header[i], value)
+		}
+	}
+
+	cell, err := excelize.CoordinatesToCellName(1, w.rowNumber)
+	if err != nil {
+		w.err = fmt.Errorf("getting name of row %d: %w", w.rowNumber, err)
+		return w.err
+	}
+	if err = w.streamWriter.SetRow(cell, values); err != nil {
+		w.err = fmt.Errorf("writing row %d: %w", w.rowNumber, err)
+	}
+	return w.err
+}
+
+// typedCellValue converts a value to the type of its column. Empty values are left blank, and values that can't be
+// converted are kept as text.
+func (w *xlsxSheetWriter) typedCellValue(column, value string) interface{} {
+	if value == "" {
+		return nil
+	}
+
+	switch w.cellTypes[column] {
+	case xlsxNumberCell:
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case xlsxDateCell:
+		if date, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			// Zero times are left blank, since dates before 1900 can't be represented.
+			if date.IsZero() {
+				return nil
+			}
+			return excelize.Cell{StyleID: w.dateStyleID, Value: date.UTC()}
+		}
+	}
+	return value
+}
+
+func (w *xlsxSheetWriter) Flush() {}
+
+func (w *xlsxSheetWriter) Error() error {
+	return w.err
+}
+
+// newXLSXSheetWriter creates a writer of the first sheet of the file, which must be closed once all the rows are
+// written.
+func newXLSXSheetWriter(f *excelize.File, cellTypes map[string]xlsxCellType) (*xlsxSheetWriter, error) {
+	streamWriter, err := f.NewStreamWriter(f.GetSheetName(0))
+	if err != nil {
+		return nil, fmt.Errorf("creating stream writer: %w", err)
+	}
+
+	dateNumFmt := xlsxDateNumFmt
+	dateStyleID, err := f.NewStyle(&excelize.Style{CustomNumFmt: &dateNumFmt})
+	if err != nil {
+		return nil, fmt.Errorf("creating date style: %w", err)
+	}
+
+	return &xlsxSheetWriter{
+		streamWriter: streamWriter,
+		cellTypes:    cellTypes,
+		dateStyleID:  dateStyleID,
+	}, nil
+}
+
+// Close ends the sheet, so the file can be written.
+func (w *xlsxSheetWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if err := w.streamWriter.Flush(); err != nil {
+		return fmt.Errorf("flushing stream writer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package httphandler
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+// createXLSXFile creates an XLSX file whose first sheet has the given rows.
+func createXLSXFile(t *testing.T, rows [][]interface{}) *excelize.File {
+	t.Helper()
+
+	f := excelize.NewFile()
+	sheet := f.GetSheetName(0)
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		require.NoError(t, err)
+		require.NoError(t, f.SetSheetRow(sheet, cell, &row))
+	}
+	return f
+}
+
+func xlsxFileContent(t *testing.T, f *excelize.File) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, f.Write(&buf))
+	return buf.Bytes()
+}
+
+// oversizedXLSXFileContent returns a valid XLSX file with an extra highly compressible part, so it inflates beyond
+// xlsxUnzipSizeLimit.
+func oversizedXLSXFileContent(t *testing.T) []byte {
+	t.Helper()
+
+	content := xlsxFileContent(t, createXLSXFile(t, [][]interface{}{{"phone", "id", "amount", "verification"}}))
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for _, file := range zipReader.File {
+		require.NoError(t, zipWriter.Copy(file))
+	}
+	padding, err := zipWriter.Create("xl/media/padding.bin")
+	require.NoError(t, err)
+	_, err = io.CopyN(padding, zeroReader{}, xlsxUnzipSizeLimit+1)
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+
+	return buf.Bytes()
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func Test_convertXLSXToCSV(t *testing.T) {
+	t.Run("cells are converted with their raw values, and dates as YYYY-MM-DD", func(t *testing.T) {
+		f := createXLSXFile(t, [][]interface{}{
+			{"phone", "id", "amount", "verification"},
+			{"+380445555555", "1", 100.5, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{},
+			{"+380445555556", "2", 0.1234567, "1990-01-02"},
+			{"+380445555557", "3"},
+		})
+		dateStyle, err := f.NewStyle(&excelize.Style{NumFmt: 14})
+		require.NoError(t, err)
+		sheet := f.GetSheetName(0)
+		require.NoError(t, f.SetCellStyle(sheet, "D2", "D2", dateStyle))
+		// Amounts keep their precision regardless of how they're displayed.
+		amountStyle, err := f.NewStyle(&excelize.Style{NumFmt: 2})
+		require.NoError(t, err)
+		require.NoError(t, f.SetCellStyle(sheet, "C2", "C4", amountStyle))
+
+		csvContent, err := convertXLSXToCSV(bytes.NewReader(xlsxFileContent(t, f)))
+		require.NoError(t, err)
+		assert.Equal(t, "phone,id,amount,verification\n"+
+			"+380445555555,1,100.5,1990-01-01\n"+
+			"+380445555556,2,0.1234567,1990-01-02\n"+
+			"+380445555557,3,,\n", string(csvContent))
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		_, err := convertXLSXToCSV(bytes.NewReader([]byte("phone,id,amount\n")))
+		assert.ErrorContains(t, err, "opening xlsx file")
+	})
+
+	t.Run("files that inflate beyond the unzip size limit are rejected", func(t *testing.T) {
+		content := oversizedXLSXFileContent(t)
+		require.Less(t, len(content), 1024*1024)
+
+		_, err := convertXLSXToCSV(bytes.NewReader(content))
+		assert.ErrorContains(t, err, "unzip size exceeds")
+	})
+}
+
+func Test_isXLSXDateNumFmt(t *testing.T) {
+	customFmt := func(format string) *string { return &format }
+
+	testCases := []struct {
+		name  string
+		style *excelize.Style
+		want  bool
+	}{
+		{name: "general", style: &excelize.Style{}, want: false},
+		{name: "built-in number", style: &excelize.Style{NumFmt: 2}, want: false},
+		{name: "built-in date", style: &excelize.Style{NumFmt: 14}, want: true},
+		{name: "built-in date-time", style: &excelize.Style{NumFmt: 22}, want: true},
+		{name: "custom date", style: &excelize.Style{CustomNumFmt: customFmt("yyyy-mm-dd")}, want: true},
+		{name: "custom number", style: &excelize.Style{CustomNumFmt: customFmt("#,##0.00")}, want: false},
+		{name: "custom number with a quoted literal", style: &excelize.Style{CustomNumFmt: customFmt(`0.00 "days"`)}, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, isXLSXDateNumFmt(tc.style))
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httperror"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// maxIdempotentRequestBodySize limits the size of the requests buffered to compute their fingerprint.
+	maxIdempotentRequestBodySize = 10 * 1024 * 1024
+)
+
+// IdempotencyMiddleware makes requests sent with an `Idempotency-Key` header safe to retry. The first request with a
+// key is processed and its response is stored for the tenant user that sent it. Retries with the same key and
+// payload replay the stored response, while a different payload with the same key is rejected. Server errors and panics
+// are not stored, so the request can be retried. Requests without the header are processed as usual.
+func IdempotencyMiddleware(models *data.Models, authManager auth.AuthManager) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			key := req.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(rw, req)
+				return
+			}
+
+			ctx := req.Context()
+			if len(key) > maxIdempotencyKeyLength {
+				httperror.BadRequest(fmt.Sprintf("%s must be at most %d characters long", IdempotencyKeyHeader, maxIdempotencyKeyLength), nil, nil).Render(rw)
+				return
+			}
+
+			token, ok := ctx.Value(TokenContextKey).(string)
+			if !ok {
+				httperror.Unauthorized("", nil, nil).Render(rw)
+				return
+			}
+			userID, err := authManager.GetUserID(ctx, token)
+			if err != nil {
+				httperror.Unauthorized("", err, nil).Render(rw)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(rw, req.Body, maxIdempotentRequestBodySize))
+			if err != nil {
+				httperror.BadRequest("could not read the request body", err, nil).Render(rw)
+				return
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint, err := requestFingerprint(req.Header.Get("Content-Type"), body)
+			if err != nil {
+				httperror.BadRequest("could not read the request body", err, nil).Render(rw)
+				return
+			}
+
+			idempotencyKey, err := reserveIdempotencyKey(ctx, models, data.IdempotencyKeyInsert{
+				Key:                key,
+				UserID:             userID,
+				RequestMethod:      req.Method,
+				RequestPath:        req.URL.RequestURI(),
+				RequestFingerprint: fingerprint,
+			})
+			var httpErr *httperror.HTTPError
+			if errors.As(err, &httpErr) {
+				httpErr.Render(rw)
+				return
+			} else if err != nil {
+				httperror.InternalError(ctx, "", err, nil).Render(rw)
+				return
+			}
+
+			if idempotencyKey.IsCompleted() {
+				replayIdempotentResponse(rw, idempotencyKey)
+				return
+			}
+
+			// The key is released or completed even if the client disconnects while the request is processed.
+			storeCtx := context.WithoutCancel(ctx)
+			defer func() {
+				if p := recover(); p != nil {
+					releaseIdempotencyKey(storeCtx, models, idempotencyKey.ID)
+					panic(p)
+				}
+			}()
+
+			var responseBody bytes.Buffer
+			mw := middleware.NewWrapResponseWriter(rw, req.ProtoMajor)
+			mw.Tee(&responseBody)
+			next.ServeHTTP(mw, req)
+
+			// Handlers that don't write a response reply with a 200 status.
+			responseStatus := mw.Status()
+			if responseStatus == 0 {
+				responseStatus = http.StatusOK
+			}
+
+			if responseStatus >= http.StatusInternalServerError {
+				releaseIdempotencyKey(storeCtx, models, idempotencyKey.ID)
+				return
+			}
+
+			err = models.IdempotencyKeys.Complete(storeCtx, models.DBConnectionPool, idempotencyKey.ID, responseStatus, mw.Header().Get("Content-Type"), responseBody.Bytes())
+			if err != nil {
+				log.Ctx(ctx).Errorf("storing the response of idempotency key %s: %v", idempotencyKey.ID, err)
+			}
+		})
+	}
+}
+
+// releaseIdempotencyKey deletes the key of a request that failed, so it can be retried with the same key.
+func releaseIdempotencyKey(ctx context.Context, models *data.Models, id string) {
+	if err := models.IdempotencyKeys.Delete(ctx, models.DBConnectionPool, id); err != nil {
+		log.Ctx(ctx).Errorf("releasing idempotency key %s: %v", id, err)
+	}
+}
+
+// reserveIdempotencyKey creates the idempotency key of a new request, or returns the existing key when the request is
+// a retry. Expired and abandoned keys are replaced, and an HTTP error is returned when the key was used for a different
+// request or its request is still being processed.
+func reserveIdempotencyKey(ctx context.Context, models *data.Models, insert data.IdempotencyKeyInsert) (*data.IdempotencyKey, error) {
+	dbConnectionPool := models.DBConnectionPool
+
+	idempotencyKey, err := models.IdempotencyKeys.Insert(ctx, dbConnectionPool, insert)
+	if !errors.Is(err, data.ErrRecordAlreadyExists) {
+		return idempotencyKey, err
+	}
+
+	idempotencyKey, err = models.IdempotencyKeys.Get(ctx, dbConnectionPool, insert.UserID, insert.Key)
+	if err != nil {
+		return nil, fmt.Errorf("getting idempotency key: %w", err)
+	}
+
+	now := time.Now()
+	if idempotencyKey.IsExpired(now) || idempotencyKey.IsAbandoned(now) {
+		if err = models.IdempotencyKeys.Delete(ctx, dbConnectionPool, idempotencyKey.ID); err != nil {
+			return nil, fmt.Errorf("deleting expired or abandoned idempotency key: %w", err)
+		}
+		idempotencyKey, err = models.IdempotencyKeys.Insert(ctx, dbConnectionPool, insert)
+		if errors.Is(err, data.ErrRecordAlreadyExists) {
+			return nil, httperror.Conflict("A request with this Idempotency-Key is already being processed", err, nil)
+		}
+		return idempotencyKey, err
+	}
+
+	if !idempotencyKey.Matches(insert.RequestMethod, insert.RequestPath, insert.RequestFingerprint) {
+		return nil, httperror.UnprocessableEntity("This Idempotency-Key was already used with a different request", nil, nil)
+	}
+	if !idempotencyKey.IsCompleted() {
+		return nil, httperror.Conflict("A request with this Idempotency-Key is already being processed", nil, nil)
+	}
+
+	return idempotencyKey, nil
+}
+
+func replayIdempotentResponse(rw http.ResponseWriter, idempotencyKey *data.IdempotencyKey) {
+	if idempotencyKey.ResponseContentType != "" {
+		rw.Header().Set("Content-Type", idempotencyKey.ResponseContentType)
+	}
+	rw.Header().Set(IdempotentReplayedHeader, strconv.FormatBool(true))
+	rw.WriteHeader(*idempotencyKey.ResponseStatus)
+	_, _ = rw.Write(idempotencyKey.ResponseBody)
+}
+
+// requestFingerprint hashes the request body. Multipart bodies are hashed by their parts, since the boundary that
+// separates them is usually random on each request.
+func requestFingerprint(contentType string, body []byte) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		hash := sha256.Sum256(body)
+		return hex.EncodeToString(hash[:]), nil
+	}
+
+	hash := sha256.New()
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for parts := 0; ; parts++ {
+		part, partErr := reader.NextPart()
+		if errors.Is(partErr, io.EOF) {
+			if parts == 0 {
+				// Not a multipart body, despite its content type.
+				return requestFingerprint("", body)
+			}
+			break
+		}
+		if partErr != nil {
+			return "", fmt.Errorf("reading multipart body: %w", partErr)
+		}
+
+		fmt.Fprintf(hash, "%q;%q;", part.FormName(), part.FileName())
+		partHash := sha256.New()
+		if _, err = io.Copy(partHash, part); err != nil {
+			return "", fmt.Errorf("reading multipart part %s: %w", part.FormName(), err)
+		}
+		hash.Write(partHash.Sum(nil))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
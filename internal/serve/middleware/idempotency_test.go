@@ -0,0 +1,242 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
+)
+
+func Test_requestFingerprint(t *testing.T) {
+	multipartBody := func(t *testing.T, fileContent string) (string, []byte) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile("file", "instructions.csv")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(fileContent))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		return writer.FormDataContentType(), body.Bytes()
+	}
+
+	t.Run("JSON bodies are hashed as they are", func(t *testing.T) {
+		fingerprint1, err := requestFingerprint("application/json", []byte(`{"name":"disbursement"}`))
+		require.NoError(t, err)
+		fingerprint2, err := requestFingerprint("application/json", []byte(`{"name":"disbursement"}`))
+		require.NoError(t, err)
+		fingerprint3, err := requestFingerprint("application/json", []byte(`{"name":"another disbursement"}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, fingerprint1, fingerprint2)
+		assert.NotEqual(t, fingerprint1, fingerprint3)
+	})
+
+	t.Run("multipart bodies are hashed regardless of their boundary", func(t *testing.T) {
+		contentType1, body1 := multipartBody(t, "phone,id,amount\n+380445555555,1,10\n")
+		contentType2, body2 := multipartBody(t, "phone,id,amount\n+380445555555,1,10\n")
+		contentType3, body3 := multipartBody(t, "phone,id,amount\n+380445555555,1,20\n")
+		require.NotEqual(t, contentType1, contentType2)
+
+		fingerprint1, err := requestFingerprint(contentType1, body1)
+		require.NoError(t, err)
+		fingerprint2, err := requestFingerprint(contentType2, body2)
+		require.NoError(t, err)
+		fingerprint3, err := requestFingerprint(contentType3, body3)
+		require.NoError(t, err)
+
+		assert.Equal(t, fingerprint1, fingerprint2)
+		assert.NotEqual(t, fingerprint1, fingerprint3)
+	})
+
+	t.Run("bodies without parts are hashed as they are", func(t *testing.T) {
+		fingerprint1, err := requestFingerprint("multipart/form-data; boundary=abc", []byte("not multipart"))
+		require.NoError(t, err)
+		fingerprint2, err := requestFingerprint("multipart/form-data; boundary=abc", []byte("still not multipart"))
+		require.NoError(t, err)
+
+		assert.NotEqual(t, fingerprint1, fingerprint2)
+	})
+
+	t.Run("truncated multipart bodies return an error", func(t *testing.T) {
+		_, err := requestFingerprint("multipart/form-data; boundary=abc", []byte("--abc\r\nContent-Disposition: form-data; name=\"file\"\r\n\r\ndata"))
+		assert.ErrorContains(t, err, "reading multipart part file")
+	})
+}
+
+func Test_IdempotencyMiddleware(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), TokenContextKey, "token")
+	authManagerMock := &auth.AuthManagerMock{}
+	authManagerMock.
+		On("GetUserID", mock.Anything, "token").
+		Return("user-id", nil)
+	defer authManagerMock.AssertExpectations(t)
+
+	calls := 0
+	responseStatus := http.StatusCreated
+	panicMessage := ""
+	var onCall func()
+	r := chi.NewRouter()
+	r.With(IdempotencyMiddleware(models, authManagerMock)).Post("/disbursements", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		if onCall != nil {
+			onCall()
+		}
+		if panicMessage != "" {
+			panic(panicMessage)
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(responseStatus)
+		_, _ = fmt.Fprintf(rw, `{"call":%d}`, calls)
+	})
+
+	postWithContext := func(t *testing.T, ctx context.Context, key, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/disbursements", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+	post := func(t *testing.T, key, body string) *httptest.ResponseRecorder {
+		return postWithContext(t, ctx, key, body)
+	}
+
+	t.Run("requests without the header are not deduplicated", func(t *testing.T) {
+		calls = 0
+		assert.JSONEq(t, `{"call":1}`, post(t, "", `{"name":"d1"}`).Body.String())
+		assert.JSONEq(t, `{"call":2}`, post(t, "", `{"name":"d1"}`).Body.String())
+	})
+
+	t.Run("keys that are too long are rejected", func(t *testing.T) {
+		rr := post(t, strings.Repeat("a", maxIdempotencyKeyLength+1), `{"name":"d1"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("🎉 retries replay the stored response", func(t *testing.T) {
+		calls = 0
+		rr := post(t, "key-1", `{"name":"d1"}`)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		assert.JSONEq(t, `{"call":1}`, rr.Body.String())
+		assert.Empty(t, rr.Header().Get(IdempotentReplayedHeader))
+
+		rr = post(t, "key-1", `{"name":"d1"}`)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		assert.JSONEq(t, `{"call":1}`, rr.Body.String())
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "true", rr.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("a different payload with the same key is rejected", func(t *testing.T) {
+		rr := post(t, "key-1", `{"name":"d2"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), "This Idempotency-Key was already used with a different request")
+	})
+
+	t.Run("a request still being processed is rejected", func(t *testing.T) {
+		fingerprint, err := requestFingerprint("application/json", []byte(`{"name":"d1"}`))
+		require.NoError(t, err)
+		_, err = models.IdempotencyKeys.Insert(ctx, dbConnectionPool, data.IdempotencyKeyInsert{
+			Key:                "key-in-progress",
+			UserID:             "user-id",
+			RequestMethod:      http.MethodPost,
+			RequestPath:        "/disbursements",
+			RequestFingerprint: fingerprint,
+		})
+		require.NoError(t, err)
+
+		rr := post(t, "key-in-progress", `{"name":"d1"}`)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), "A request with this Idempotency-Key is already being processed")
+	})
+
+	t.Run("server errors are not stored, so the request can be retried", func(t *testing.T) {
+		calls = 0
+		responseStatus = http.StatusInternalServerError
+		rr := post(t, "key-2", `{"name":"d1"}`)
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+		responseStatus = http.StatusCreated
+		rr = post(t, "key-2", `{"name":"d1"}`)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.JSONEq(t, `{"call":2}`, rr.Body.String())
+		assert.Empty(t, rr.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("🎉 requests that were abandoned for longer than the lease are processed again", func(t *testing.T) {
+		calls = 0
+		fingerprint, err := requestFingerprint("application/json", []byte(`{"name":"d1"}`))
+		require.NoError(t, err)
+		idempotencyKey, err := models.IdempotencyKeys.Insert(ctx, dbConnectionPool, data.IdempotencyKeyInsert{
+			Key:                "key-abandoned",
+			UserID:             "user-id",
+			RequestMethod:      http.MethodPost,
+			RequestPath:        "/disbursements",
+			RequestFingerprint: fingerprint,
+		})
+		require.NoError(t, err)
+		_, err = dbConnectionPool.ExecContext(ctx, "UPDATE idempotency_keys SET created_at = NOW() - $1::interval WHERE id = $2",
+			(data.IdempotencyKeyLeaseDuration + time.Minute).String(), idempotencyKey.ID)
+		require.NoError(t, err)
+
+		rr := post(t, "key-abandoned", `{"name":"d1"}`)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.JSONEq(t, `{"call":1}`, rr.Body.String())
+	})
+
+	t.Run("panics release the key, so the request can be retried", func(t *testing.T) {
+		calls = 0
+		panicMessage = "unexpected failure"
+		assert.PanicsWithValue(t, "unexpected failure", func() {
+			post(t, "key-panic", `{"name":"d1"}`)
+		})
+
+		panicMessage = ""
+		rr := post(t, "key-panic", `{"name":"d1"}`)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.JSONEq(t, `{"call":2}`, rr.Body.String())
+	})
+
+	t.Run("🎉 the response is stored even if the client disconnects", func(t *testing.T) {
+		calls = 0
+		requestCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		onCall = cancel
+		rr := postWithContext(t, requestCtx, "key-cancelled", `{"name":"d1"}`)
+		onCall = nil
+		assert.Equal(t, http.StatusCreated, rr.Code)
+
+		rr = post(t, "key-cancelled", `{"name":"d1"}`)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.JSONEq(t, `{"call":1}`, rr.Body.String())
+		assert.Equal(t, "true", rr.Header().Get(IdempotentReplayedHeader))
+	})
+}
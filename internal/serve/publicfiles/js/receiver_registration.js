@@ -10,13 +10,6 @@ const CurrentSection = Object.freeze({
   EMAIL_ADDRESS: "emailAddress",        // SECTION 2.2 (w/ email)
   PASSCODE: "passcode",                 // SECTION 3
 });
-
-const VerificationField = Object.freeze({
-  DATE_OF_BIRTH: "DATE_OF_BIRTH",
-  YEAR_MONTH: "YEAR_MONTH",
-  NATIONAL_ID_NUMBER: "NATIONAL_ID_NUMBER",
-  PIN: "PIN",
-});
 // ------------------------------ END: ENUMS ------------------------------
 
 
@@ -34,6 +27,7 @@ const WalletRegistration = {
   contactMethod: "",
   currentSection: CurrentSection.SELECT_OTP_METHOD,
   verificationField: "",
+  verificationFieldConfigs: [],
 
   setSection(section) {
     this.currentSection = section;
@@ -137,6 +131,7 @@ const WalletRegistration = {
 // ------------------------------ START: INITIALIZATION ------------------------------
 window.onload = () => {
   WalletRegistration.jwtToken = document.querySelector("#jwt-token").dataset.jwtToken
+  WalletRegistration.verificationFieldConfigs = JSON.parse(document.querySelector("#verification-fields").dataset.verificationFields || "[]");
   WalletRegistration.privacyPolicyLink = document.querySelector("[data-privacy-policy-link]")?.innerHTML || "";
   WalletRegistration.intlTelInput = phoneNumberInit();
 
@@ -277,23 +272,17 @@ async function handleContactInfoSubmitted() {
   WalletRegistration.toggleButtonsEnabled(false);
   if (WalletRegistration.validateContactValue() === -1) return;
 
-  function showNextPage(verificationField) {
+  function showNextPage(verificationField, verificationQuestion) {
     const verificationFieldTitle = document.querySelector("label[for='verification']");
     const verificationFieldInput = document.querySelector("#verification");
     WalletRegistration.verificationField = verificationField;
 
-    const inputFeldConfigMap = {
-      [VerificationField.DATE_OF_BIRTH]: { name: "date_of_birth", type: "date", label: "Date of birth" },
-      [VerificationField.YEAR_MONTH]: { name: "year_month", type: "month", label: "Date of birth (Year/Month)" },
-      [VerificationField.NATIONAL_ID_NUMBER]: { name: "national_id_number", type: "text", label: "National ID number" },
-      [VerificationField.PIN]: { name: "pin", type: "text", label: "Pin" },
-    };
-
-    const inputFieldConfig = inputFeldConfigMap[verificationField];
+    const inputFieldConfig = WalletRegistration.verificationFieldConfigs.find((config) => config.type === verificationField);
     if (inputFieldConfig) {
-      verificationFieldTitle.textContent = inputFieldConfig.label;
-      verificationFieldInput.name = inputFieldConfig.name;
-      verificationFieldInput.type = inputFieldConfig.type;
+      // Secret questions are labeled with the question set in the receiver's disbursement.
+      verificationFieldTitle.textContent = verificationQuestion || inputFieldConfig.label;
+      verificationFieldInput.name = inputFieldConfig.type.toLowerCase();
+      verificationFieldInput.type = inputFieldConfig.input_type;
     }
 
     WalletRegistration.setSection(CurrentSection.PASSCODE);
@@ -434,7 +423,7 @@ async function sendOtp(onSuccess, onError) {
       throw new Error(data.error || "Something went wrong, please try again later.");
     }
 
-    onSuccess(data.verification_field);
+    onSuccess(data.verification_field, data.verification_question);
   } catch (error) {
     onError(error);
   }
@@ -31,6 +31,7 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/validators"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/services"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine"
+	txSubStore "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
 	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
 	authUtils "github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/utils"
@@ -58,6 +59,7 @@ type ServeOptions struct {
 	MonitorService                  monitor.MonitorServiceInterface
 	MtnDBConnectionPool             db.DBConnectionPool
 	AdminDBConnectionPool           db.DBConnectionPool
+	TSSDBConnectionPool             db.DBConnectionPool
 	EC256PrivateKey                 string
 	Models                          *data.Models
 	CorsAllowedOrigins              []string
@@ -92,6 +94,9 @@ type ServeOptions struct {
 	SingleTenantMode                bool
 	CircleService                   circle.ServiceInterface
 	CircleAPIType                   circle.APIType
+	TwilioAuthToken                 string
+	AWSSESNotificationTopicARN      string
+	MessageDeliveryStatusService    services.MessageDeliveryStatusServiceInterface
 }
 
 // SetupDependencies uses the serve options to setup the dependencies for the server.
@@ -226,6 +231,8 @@ func handleHTTP(o ServeOptions) *chi.Mux {
 	mux.Group(func(r chi.Router) {
 		r.Use(middleware.AuthenticateMiddleware(authManager, o.tenantManager))
 		r.Use(middleware.EnsureTenantMiddleware)
+		// Makes the mutating endpoints it's applied to safe to retry with an `Idempotency-Key` header.
+		idempotencyMiddleware := middleware.IdempotencyMiddleware(o.Models, authManager)
 
 		r.With(middleware.AnyRoleMiddleware(authManager, data.GetAllRoles()...)).Route("/statistics", func(r chi.Router) {
 			statisticsHandler := httphandler.StatisticsHandler{DBConnectionPool: o.MtnDBConnectionPool}
@@ -263,18 +270,27 @@ func handleHTTP(o ServeOptions) *chi.Mux {
 					DistributionAccountService: o.DistributionAccountService,
 				},
 			}
-			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole), idempotencyMiddleware).
 				Post("/", handler.PostDisbursement)
 
 			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
 				Delete("/{id}", handler.DeleteDisbursement)
 
-			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole), idempotencyMiddleware).
 				Post("/{id}/instructions", handler.PostDisbursementInstructions)
 
 			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
 				Get("/{id}/instructions", handler.GetDisbursementInstructions)
 
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+				Get("/{id}/instructions/uploads", handler.GetDisbursementInstructionUploads)
+
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+				Get("/{id}/instructions/uploads/{uploadID}", handler.GetDisbursementInstructionUpload)
+
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+				Post("/{id}/instructions/validate", handler.ValidateDisbursementInstructions)
+
 			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole, data.BusinessUserRole)).
 				Get("/", handler.GetDisbursements)
 
@@ -284,8 +300,54 @@ func handleHTTP(o ServeOptions) *chi.Mux {
 			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole, data.BusinessUserRole)).
 				Get("/{id}/receivers", handler.GetDisbursementReceivers)
 
-			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole), idempotencyMiddleware).
 				Patch("/{id}/status", handler.PatchDisbursementStatus)
+
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+				Post("/{id}/approve", handler.PostDisbursementApproval)
+
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+				Post("/{id}/reject", handler.PostDisbursementRejection)
+
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole, data.BusinessUserRole)).
+				Get("/{id}/approvals", handler.GetDisbursementApprovals)
+		})
+
+		r.Route("/disbursement-templates", func(r chi.Router) {
+			templatesHandler := httphandler.DisbursementTemplatesHandler{
+				Models:      o.Models,
+				AuthManager: authManager,
+			}
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole, data.BusinessUserRole)).
+				Get("/", templatesHandler.GetDisbursementTemplates)
+
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole, data.BusinessUserRole)).
+				Get("/{id}", templatesHandler.GetDisbursementTemplate)
+
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+				Post("/", templatesHandler.PostDisbursementTemplate)
+
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+				Patch("/{id}", templatesHandler.PatchDisbursementTemplate)
+
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+				Post("/{id}/instructions", templatesHandler.PostDisbursementTemplateInstructions)
+		})
+
+		r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.DeveloperUserRole)).Route("/webhooks", func(r chi.Router) {
+			webhooksHandler := httphandler.WebhooksHandler{
+				Models:               o.Models,
+				AuthManager:          authManager,
+				Encrypter:            &utils.DefaultPrivateKeyEncrypter{},
+				EncryptionPassphrase: o.DistAccEncryptionPassphrase,
+				NetworkType:          o.NetworkType,
+			}
+			r.Get("/", webhooksHandler.GetWebhooks)
+			r.Post("/", webhooksHandler.PostWebhook)
+			r.Get("/{id}", webhooksHandler.GetWebhook)
+			r.Patch("/{id}", webhooksHandler.PatchWebhook)
+			r.Delete("/{id}", webhooksHandler.DeleteWebhook)
+			r.Get("/{id}/deliveries", webhooksHandler.GetWebhookDeliveries)
 		})
 
 		r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole, data.BusinessUserRole)).Route("/payments", func(r chi.Router) {
@@ -296,12 +358,22 @@ func handleHTTP(o ServeOptions) *chi.Mux {
 				EventProducer:               o.EventProducer,
 				CrashTrackerClient:          o.CrashTrackerClient,
 				DistributionAccountResolver: o.SubmitterEngine.DistributionAccountResolver,
+				DistributionAccountService:  o.DistributionAccountService,
+				TransactionStore:            txSubStore.NewTransactionModel(o.TSSDBConnectionPool),
 			}
 			r.Get("/", paymentsHandler.GetPayments)
 			r.Get("/{id}", paymentsHandler.GetPayment)
-			r.Patch("/retry", paymentsHandler.RetryPayments)
+			r.Get("/{id}/transactions", paymentsHandler.GetPaymentTransactions)
+			r.With(idempotencyMiddleware).Patch("/retry", paymentsHandler.RetryPayments)
 			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
 				Patch("/{id}/status", paymentsHandler.PatchPaymentStatus)
+			r.Get("/{id}/corrections", paymentsHandler.GetPaymentCorrections)
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole), idempotencyMiddleware).
+				Post("/{id}/corrections", paymentsHandler.PostPaymentCorrection)
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+				Post("/{id}/corrections/{correctionID}/approve", paymentsHandler.PostPaymentCorrectionApproval)
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+				Post("/{id}/corrections/{correctionID}/reject", paymentsHandler.PostPaymentCorrectionRejection)
 		})
 
 		r.Route("/receivers", func(r chi.Router) {
@@ -326,9 +398,21 @@ func handleHTTP(o ServeOptions) *chi.Mux {
 				Models:             o.Models,
 				CrashTrackerClient: o.CrashTrackerClient,
 				EventProducer:      o.EventProducer,
+				AuthManager:        authManager,
 			}
 			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
 				Patch("/wallets/{receiver_wallet_id}", receiverWalletHandler.RetryInvitation)
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole)).
+				Post("/wallets/{receiver_wallet_id}/unregister", receiverWalletHandler.UnregisterReceiverWallet)
+
+			lockoutsHandler := httphandler.ReceiverVerificationLockoutsHandler{
+				Models:         o.Models,
+				MonitorService: o.MonitorService,
+			}
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole, data.FinancialControllerUserRole)).
+				Get("/verifications/locked", lockoutsHandler.GetLockedReceiverVerifications)
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole)).
+				Post("/{id}/verifications/reset", lockoutsHandler.ResetReceiverVerificationAttempts)
 		})
 
 		r.
@@ -408,6 +492,21 @@ func handleHTTP(o ServeOptions) *chi.Mux {
 					DistributionAccountResolver: o.SubmitterEngine.DistributionAccountResolver,
 					MonitorService:              o.MonitorService,
 				}.Patch)
+
+			messengerConfigHandler := httphandler.MessengerConfigHandler{
+				Models:               o.Models,
+				ClientConfigModel:    message.NewClientConfigModel(o.MtnDBConnectionPool),
+				ClientFactory:        message.GetClient,
+				Encrypter:            &utils.DefaultPrivateKeyEncrypter{},
+				EncryptionPassphrase: o.DistAccEncryptionPassphrase,
+			}
+			r.With(middleware.AnyRoleMiddleware(authManager, data.OwnerUserRole)).
+				Route("/messenger-configs", func(r chi.Router) {
+					r.Get("/", messengerConfigHandler.GetAll)
+					r.Put("/{channel}", messengerConfigHandler.Put)
+					r.Delete("/{channel}", messengerConfigHandler.Delete)
+					r.Post("/{channel}/test", messengerConfigHandler.Test)
+				})
 		})
 
 		balancesHandler := httphandler.BalancesHandler{
@@ -461,6 +560,39 @@ func handleHTTP(o ServeOptions) *chi.Mux {
 		}.ServeHTTP)
 
 		r.Get("/r/{code}", httphandler.URLShortenerHandler{Models: o.Models}.HandleRedirect)
+
+		r.Route("/receiver-portal", func(r chi.Router) {
+			receiverPortalHandler := httphandler.ReceiverPortalHandler{
+				ReceiverSendOTPHandler: httphandler.ReceiverSendOTPHandler{
+					Models:             o.Models,
+					MessageDispatcher:  o.MessageDispatcher,
+					ReCAPTCHAValidator: reCAPTCHAValidator,
+					RateLimiter: &httphandler.RegistrationRateLimiter{
+						Models:         o.Models,
+						MonitorService: o.MonitorService,
+					},
+					MonitorService: o.MonitorService,
+				},
+				ReCAPTCHASiteKey:  o.ReCAPTCHASiteKey,
+				ReCAPTCHADisabled: o.DisableReCAPTCHA,
+			}
+			r.Get("/", receiverPortalHandler.GetReceiverPortal)
+			r.Post("/otp", receiverPortalHandler.PostReceiverPortalOTP)
+			r.Post("/payments", receiverPortalHandler.PostReceiverPortalPayments)
+		})
+
+		r.Route("/message-status-callbacks", func(r chi.Router) {
+			messageStatusCallbackHandler := httphandler.MessageStatusCallbackHandler{
+				DeliveryStatusService:      o.MessageDeliveryStatusService,
+				SNSMessageVerifier:         message.NewAWSSNSMessageVerifier(httpclient.DefaultClient()),
+				ClientConfigModel:          message.NewClientConfigModel(o.MtnDBConnectionPool),
+				EncryptionPassphrase:       o.DistAccEncryptionPassphrase,
+				TwilioAuthToken:            o.TwilioAuthToken,
+				AWSSESNotificationTopicARN: o.AWSSESNotificationTopicARN,
+			}
+			r.Post("/twilio", messageStatusCallbackHandler.PostTwilio)
+			r.Post("/aws-ses", messageStatusCallbackHandler.PostAWSSES)
+		})
 	})
 
 	// SEP-24 and miscellaneous endpoints that are tenant-unaware
@@ -492,10 +624,16 @@ func handleHTTP(o ServeOptions) *chi.Mux {
 			}.ServeHTTP) // This loads the SEP-24 PII registration webpage.
 
 			sep24HeaderTokenAuthenticationMiddleware := anchorplatform.SEP24HeaderTokenAuthenticateMiddleware(o.sep24JWTManager, o.NetworkPassphrase, o.tenantManager, o.SingleTenantMode)
+			registrationRateLimiter := &httphandler.RegistrationRateLimiter{
+				Models:         o.Models,
+				MonitorService: o.MonitorService,
+			}
 			r.With(sep24HeaderTokenAuthenticationMiddleware).Post("/otp", httphandler.ReceiverSendOTPHandler{
 				Models:             o.Models,
 				MessageDispatcher:  o.MessageDispatcher,
 				ReCAPTCHAValidator: reCAPTCHAValidator,
+				RateLimiter:        registrationRateLimiter,
+				MonitorService:     o.MonitorService,
 			}.ServeHTTP)
 			r.With(sep24HeaderTokenAuthenticationMiddleware).Post("/verification", httphandler.VerifyReceiverRegistrationHandler{
 				AnchorPlatformAPIService:    o.AnchorPlatformAPIService,
@@ -505,6 +643,8 @@ func handleHTTP(o ServeOptions) *chi.Mux {
 				EventProducer:               o.EventProducer,
 				CrashTrackerClient:          o.CrashTrackerClient,
 				DistributionAccountResolver: o.SubmitterEngine.DistributionAccountResolver,
+				RateLimiter:                 registrationRateLimiter,
+				MonitorService:              o.MonitorService,
 			}.VerifyReceiverRegistration)
 		})
 
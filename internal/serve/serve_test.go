@@ -393,6 +393,8 @@ func Test_handleHTTP_unauthenticatedEndpoints(t *testing.T) {
 		{http.MethodPost, "/forgot-password"},
 		{http.MethodPost, "/reset-password"},
 		{http.MethodGet, "/r/123"},
+		{http.MethodGet, "/receiver-portal"},
+		{http.MethodPost, "/message-status-callbacks/aws-ses"},
 	}
 	for _, endpoint := range unauthenticatedEndpoints {
 		t.Run(fmt.Sprintf("%s %s", endpoint.method, endpoint.path), func(t *testing.T) {
@@ -439,21 +441,48 @@ func Test_handleHTTP_authenticatedEndpoints(t *testing.T) {
 		{http.MethodPost, "/disbursements"},
 		{http.MethodPost, "/disbursements/1234/instructions"},
 		{http.MethodGet, "/disbursements/1234/instructions"},
+		{http.MethodGet, "/disbursements/1234/instructions/uploads"},
+		{http.MethodGet, "/disbursements/1234/instructions/uploads/5678"},
+		{http.MethodPost, "/disbursements/1234/instructions/validate"},
 		{http.MethodGet, "/disbursements"},
 		{http.MethodGet, "/disbursements/1234"},
 		{http.MethodGet, "/disbursements/1234/receivers"},
 		{http.MethodPatch, "/disbursements/1234/status"},
+		{http.MethodPost, "/disbursements/1234/approve"},
+		{http.MethodPost, "/disbursements/1234/reject"},
+		{http.MethodGet, "/disbursements/1234/approvals"},
 		{http.MethodDelete, "/disbursements/1234"},
+		// Disbursement templates
+		{http.MethodGet, "/disbursement-templates"},
+		{http.MethodGet, "/disbursement-templates/1234"},
+		{http.MethodPost, "/disbursement-templates"},
+		{http.MethodPatch, "/disbursement-templates/1234"},
+		{http.MethodPost, "/disbursement-templates/1234/instructions"},
+		// Webhooks
+		{http.MethodGet, "/webhooks"},
+		{http.MethodPost, "/webhooks"},
+		{http.MethodGet, "/webhooks/1234"},
+		{http.MethodPatch, "/webhooks/1234"},
+		{http.MethodDelete, "/webhooks/1234"},
+		{http.MethodGet, "/webhooks/1234/deliveries"},
 		// Payments
 		{http.MethodGet, "/payments"},
 		{http.MethodGet, "/payments/1234"},
+		{http.MethodGet, "/payments/1234/transactions"},
 		{http.MethodPatch, "/payments/retry"},
 		{http.MethodPatch, "/payments/1234/status"},
+		{http.MethodGet, "/payments/1234/corrections"},
+		{http.MethodPost, "/payments/1234/corrections"},
+		{http.MethodPost, "/payments/1234/corrections/5678/approve"},
+		{http.MethodPost, "/payments/1234/corrections/5678/reject"},
 		// Receivers
 		{http.MethodGet, "/receivers"},
 		{http.MethodGet, "/receivers/1234"},
 		{http.MethodPatch, "/receivers/1234"},
 		{http.MethodPatch, "/receivers/wallets/1234"},
+		{http.MethodPost, "/receivers/wallets/1234/unregister"},
+		{http.MethodGet, "/receivers/verifications/locked"},
+		{http.MethodPost, "/receivers/1234/verifications/reset"},
 		{http.MethodGet, "/receivers/verification-types"},
 		// Receiver Contact Types
 		{http.MethodGet, "/registration-contact-types"},
@@ -476,6 +505,10 @@ func Test_handleHTTP_authenticatedEndpoints(t *testing.T) {
 		{http.MethodPatch, "/organization"},
 		{http.MethodGet, "/organization/logo"},
 		{http.MethodPatch, "/organization/circle-config"},
+		{http.MethodGet, "/organization/messenger-configs"},
+		{http.MethodPut, "/organization/messenger-configs/SMS"},
+		{http.MethodDelete, "/organization/messenger-configs/SMS"},
+		{http.MethodPost, "/organization/messenger-configs/SMS/test"},
 		// Balances
 		{http.MethodGet, "/balances"},
 		// Exports
@@ -24,43 +24,45 @@ func NewDisbursementInstructionsValidator(contactType data.RegistrationContactTy
 	}
 }
 
+// ValidateInstruction validates an instruction parsed from the given line of a CSV file.
 func (iv *DisbursementInstructionsValidator) ValidateInstruction(instruction *data.DisbursementInstruction, lineNumber int) {
+	iv.validateInstruction(instruction, fmt.Sprintf("line %d", lineNumber))
+}
+
+// ValidateJSONInstruction validates an instruction parsed from the given index of a JSON array.
+func (iv *DisbursementInstructionsValidator) ValidateJSONInstruction(instruction *data.DisbursementInstruction, index int) {
+	iv.validateInstruction(instruction, fmt.Sprintf("instructions[%d]", index))
+}
+
+func (iv *DisbursementInstructionsValidator) validateInstruction(instruction *data.DisbursementInstruction, row string) {
 	// 1. Validate required fields
-	iv.Check(instruction.ID != "", fmt.Sprintf("line %d - id", lineNumber), "id cannot be empty")
-	iv.CheckError(utils.ValidateAmount(instruction.Amount), fmt.Sprintf("line %d - amount", lineNumber), "invalid amount. Amount must be a positive number")
+	iv.Check(instruction.ID != "", fmt.Sprintf("%s - id", row), "id cannot be empty")
+	iv.CheckError(utils.ValidateAmount(instruction.Amount), fmt.Sprintf("%s - amount", row), "invalid amount. Amount must be a positive number")
 
 	// 2. Validate Contact fields
 	switch iv.contactType.ReceiverContactType {
 	case data.ReceiverContactTypeEmail:
-		iv.Check(instruction.Email != "", fmt.Sprintf("line %d - email", lineNumber), "email cannot be empty")
+		iv.Check(instruction.Email != "", fmt.Sprintf("%s - email", row), "email cannot be empty")
 		if instruction.Email != "" {
-			iv.CheckError(utils.ValidateEmail(instruction.Email), fmt.Sprintf("line %d - email", lineNumber), "invalid email format")
+			iv.CheckError(utils.ValidateEmail(instruction.Email), fmt.Sprintf("%s - email", row), "invalid email format")
 		}
 	case data.ReceiverContactTypeSMS:
-		iv.Check(instruction.Phone != "", fmt.Sprintf("line %d - phone", lineNumber), "phone cannot be empty")
+		iv.Check(instruction.Phone != "", fmt.Sprintf("%s - phone", row), "phone cannot be empty")
 		if instruction.Phone != "" {
-			iv.CheckError(utils.ValidatePhoneNumber(instruction.Phone), fmt.Sprintf("line %d - phone", lineNumber), "invalid phone format. Correct format: +380445555555")
+			iv.CheckError(utils.ValidatePhoneNumber(instruction.Phone), fmt.Sprintf("%s - phone", row), "invalid phone format. Correct format: +380445555555")
 		}
 	}
 
 	// 3. Validate WalletAddress field
 	if iv.contactType.IncludesWalletAddress {
-		iv.Check(instruction.WalletAddress != "", fmt.Sprintf("line %d - wallet address", lineNumber), "wallet address cannot be empty")
+		iv.Check(instruction.WalletAddress != "", fmt.Sprintf("%s - wallet address", row), "wallet address cannot be empty")
 		if instruction.WalletAddress != "" {
-			iv.Check(strkey.IsValidEd25519PublicKey(instruction.WalletAddress), fmt.Sprintf("line %d - wallet address", lineNumber), "invalid wallet address. Must be a valid Stellar public key")
+			iv.Check(strkey.IsValidEd25519PublicKey(instruction.WalletAddress), fmt.Sprintf("%s - wallet address", row), "invalid wallet address. Must be a valid Stellar public key")
 		}
 	} else {
 		// 4. Validate verification field
-		verification := instruction.VerificationValue
-		switch iv.verificationField {
-		case data.VerificationTypeDateOfBirth:
-			iv.CheckError(utils.ValidateDateOfBirthVerification(verification), fmt.Sprintf("line %d - date of birth", lineNumber), "")
-		case data.VerificationTypeYearMonth:
-			iv.CheckError(utils.ValidateYearMonthVerification(verification), fmt.Sprintf("line %d - year/month", lineNumber), "")
-		case data.VerificationTypePin:
-			iv.CheckError(utils.ValidatePinVerification(verification), fmt.Sprintf("line %d - pin", lineNumber), "")
-		case data.VerificationTypeNationalID:
-			iv.CheckError(utils.ValidateNationalIDVerification(verification), fmt.Sprintf("line %d - national id", lineNumber), "")
+		if definition, ok := iv.verificationField.Definition(); ok {
+			iv.CheckError(definition.Validate(instruction.VerificationValue), fmt.Sprintf("%s - %s", row, definition.Name), "")
 		}
 	}
 }
@@ -238,6 +238,38 @@ func Test_DisbursementInstructionsValidator_ValidateAndGetInstruction(t *testing
 				"line 3 - national id": "invalid national id. Cannot have more than 50 characters in national id",
 			},
 		},
+		{
+			name: "error if TAX_ID is invalid - check digits don't match",
+			instruction: &data.DisbursementInstruction{
+				Phone:             "+380445555555",
+				ID:                "123456789",
+				Amount:            "100.5",
+				VerificationValue: "BR:529.982.247-26",
+			},
+			lineNumber:        3,
+			contactType:       data.RegistrationContactTypePhone,
+			verificationField: data.VerificationTypeTaxID,
+			hasErrors:         true,
+			expectedErrors: map[string]interface{}{
+				"line 3 - tax id": "invalid BR tax id: CPF check digits don't match",
+			},
+		},
+		{
+			name: "error if PHONE_LAST_4 is invalid",
+			instruction: &data.DisbursementInstruction{
+				Phone:             "+380445555555",
+				ID:                "123456789",
+				Amount:            "100.5",
+				VerificationValue: "55555",
+			},
+			lineNumber:        3,
+			contactType:       data.RegistrationContactTypePhone,
+			verificationField: data.VerificationTypePhoneLast4,
+			hasErrors:         true,
+			expectedErrors: map[string]interface{}{
+				"line 3 - phone last 4 digits": "invalid phone last 4 digits. Needs to be a 4 digit value",
+			},
+		},
 		{
 			name: "error when WalletAddress is empty for WalletAddress contact type",
 			instruction: &data.DisbursementInstruction{
@@ -376,6 +408,19 @@ func Test_DisbursementInstructionsValidator_ValidateAndGetInstruction(t *testing
 	}
 }
 
+func Test_DisbursementInstructionsValidator_ValidateJSONInstruction(t *testing.T) {
+	iv := NewDisbursementInstructionsValidator(data.RegistrationContactTypePhone, data.VerificationTypeDateOfBirth)
+	iv.ValidateJSONInstruction(&data.DisbursementInstruction{
+		Phone:             "+380445555555",
+		Amount:            "100.5",
+		VerificationValue: "1990-01-01",
+	}, 4)
+
+	assert.Equal(t, map[string]interface{}{
+		"instructions[4] - id": "id cannot be empty",
+	}, iv.Errors)
+}
+
 func Test_DisbursementInstructionsValidator_SanitizeInstruction(t *testing.T) {
 	externalPaymentID := "123456789"
 	externalPaymentIDWithSpaces := "  123456789  "
@@ -50,15 +50,8 @@ func (rv *ReceiverRegistrationValidator) ValidateReceiver(receiverInfo *data.Rec
 	vf := rv.validateAndGetVerificationType(verificationField)
 
 	// validate verification fields
-	switch vf {
-	case data.VerificationTypeDateOfBirth:
-		rv.CheckError(utils.ValidateDateOfBirthVerification(verification), "verification", "")
-	case data.VerificationTypeYearMonth:
-		rv.CheckError(utils.ValidateYearMonthVerification(verification), "verification", "")
-	case data.VerificationTypePin:
-		rv.CheckError(utils.ValidatePinVerification(verification), "verification", "")
-	case data.VerificationTypeNationalID:
-		rv.CheckError(utils.ValidateNationalIDVerification(verification), "verification", "")
+	if vf != "" {
+		rv.CheckError(vf.ValidateValue(verification), "verification", "")
 	}
 
 	receiverInfo.PhoneNumber = phone
@@ -89,7 +89,7 @@ func Test_ReceiverRegistrationValidator_ValidateReceiver(t *testing.T) {
 				VerificationField: "mock_type",
 			},
 			expectedValidationErrors: map[string]interface{}{
-				"verification_field": "invalid parameter. valid values are: [DATE_OF_BIRTH YEAR_MONTH PIN NATIONAL_ID_NUMBER]",
+				"verification_field": "invalid parameter. valid values are: [DATE_OF_BIRTH YEAR_MONTH PIN NATIONAL_ID_NUMBER TAX_ID PHONE_LAST_4 CUSTOM_SECRET_QUESTION]",
 			},
 		},
 		{
@@ -204,6 +204,22 @@ func Test_ReceiverRegistrationValidator_ValidateReceiver(t *testing.T) {
 				VerificationField: data.VerificationTypeNationalID,
 			},
 		},
+		{
+			name: "🎉 successfully validates receiver values [TAX_ID]",
+			receiverInfo: data.ReceiverRegistrationRequest{
+				PhoneNumber:       "+380445555555",
+				OTP:               "123456",
+				VerificationValue: " BR:529.982.247-25 ",
+				VerificationField: "tax_id",
+			},
+			expectedValidationErrors: map[string]interface{}{},
+			expectedReceiver: data.ReceiverRegistrationRequest{
+				PhoneNumber:       "+380445555555",
+				OTP:               "123456",
+				VerificationValue: "BR:529.982.247-25",
+				VerificationField: data.VerificationTypeTaxID,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -245,6 +261,6 @@ func Test_ReceiverRegistrationValidator_ValidateAndGetVerificationType(t *testin
 		actual := validator.validateAndGetVerificationType(invalidStatus)
 		assert.Empty(t, actual)
 		assert.Equal(t, 1, len(validator.Errors))
-		assert.Equal(t, "invalid parameter. valid values are: [DATE_OF_BIRTH YEAR_MONTH PIN NATIONAL_ID_NUMBER]", validator.Errors["verification_field"])
+		assert.Equal(t, "invalid parameter. valid values are: [DATE_OF_BIRTH YEAR_MONTH PIN NATIONAL_ID_NUMBER TAX_ID PHONE_LAST_4 CUSTOM_SECRET_QUESTION]", validator.Errors["verification_field"])
 	})
 }
@@ -1,8 +1,10 @@
 package validators
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
 )
 
@@ -12,11 +14,32 @@ type UpdateReceiverRequest struct {
 	YearMonth   string `json:"year_month"`
 	Pin         string `json:"pin"`
 	NationalID  string `json:"national_id"`
+	// Verifications holds the values of any verification type, keyed by the type, and takes precedence over the
+	// fields above.
+	Verifications map[data.VerificationType]string `json:"verifications"`
 	// receivers fields:
 	Email       string `json:"email"`
 	PhoneNumber string `json:"phone_number"`
 	ExternalID  string `json:"external_id"`
 }
+
+// LegacyVerificationValue returns the value of a verification type set with its own field in the request, rather than
+// in Verifications.
+func (ur UpdateReceiverRequest) LegacyVerificationValue(verificationField data.VerificationType) string {
+	switch verificationField {
+	case data.VerificationTypeDateOfBirth:
+		return ur.DateOfBirth
+	case data.VerificationTypeYearMonth:
+		return ur.YearMonth
+	case data.VerificationTypePin:
+		return ur.Pin
+	case data.VerificationTypeNationalID:
+		return ur.NationalID
+	default:
+		return ""
+	}
+}
+
 type UpdateReceiverValidator struct {
 	*Validator
 }
@@ -30,7 +53,10 @@ func NewUpdateReceiverValidator() *UpdateReceiverValidator {
 
 // ValidateReceiver validates if the infos present in the ReceiverRegistrationRequest are valids.
 func (ur *UpdateReceiverValidator) ValidateReceiver(updateReceiverRequest *UpdateReceiverRequest) {
-	ur.Check(*updateReceiverRequest != UpdateReceiverRequest{}, "body", "request body is empty")
+	if len(updateReceiverRequest.Verifications) == 0 {
+		updateReceiverRequest.Verifications = nil
+	}
+	ur.Check(!utils.IsEmpty(*updateReceiverRequest), "body", "request body is empty")
 
 	if ur.HasErrors() {
 		return
@@ -59,6 +85,18 @@ func (ur *UpdateReceiverValidator) ValidateReceiver(updateReceiverRequest *Updat
 		ur.CheckError(utils.ValidateNationalIDVerification(nationalID), "national_id", "")
 	}
 
+	for verificationField, value := range updateReceiverRequest.Verifications {
+		definition, ok := verificationField.Definition()
+		if !ok {
+			ur.Check(false, "verifications", fmt.Sprintf("invalid verification type %s. valid values are: %v", verificationField, data.GetAllVerificationTypes()))
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		ur.CheckError(definition.Validate(value), fmt.Sprintf("verifications.%s", verificationField), "")
+		updateReceiverRequest.Verifications[verificationField] = value
+	}
+
 	if updateReceiverRequest.Email != "" {
 		ur.Check(utils.ValidateEmail(email) == nil, "email", "invalid email format")
 	}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
 )
 
 func Test_UpdateReceiverValidator_ValidateReceiver2(t *testing.T) {
@@ -55,6 +57,33 @@ func Test_UpdateReceiverValidator_ValidateReceiver2(t *testing.T) {
 				"national_id": "national id cannot be empty",
 			},
 		},
+		{
+			name: "[TAX_ID] Verifications value is invalid",
+			request: UpdateReceiverRequest{
+				Verifications: map[data.VerificationType]string{data.VerificationTypeTaxID: "BR:529.982.247-26"},
+			},
+			expectedErrors: map[string]interface{}{
+				"verifications.TAX_ID": "invalid BR tax id: CPF check digits don't match",
+			},
+		},
+		{
+			name: "Verifications type is invalid",
+			request: UpdateReceiverRequest{
+				Verifications: map[data.VerificationType]string{"UNKNOWN": "value"},
+			},
+			expectedErrors: map[string]interface{}{
+				"verifications": "invalid verification type UNKNOWN. valid values are: [DATE_OF_BIRTH YEAR_MONTH PIN NATIONAL_ID_NUMBER TAX_ID PHONE_LAST_4 CUSTOM_SECRET_QUESTION]",
+			},
+		},
+		{
+			name: "Empty verifications",
+			request: UpdateReceiverRequest{
+				Verifications: map[data.VerificationType]string{},
+			},
+			expectedErrors: map[string]interface{}{
+				"body": "request body is empty",
+			},
+		},
 		{
 			name: "e-mail is invalid",
 			request: UpdateReceiverRequest{
@@ -92,6 +121,10 @@ func Test_UpdateReceiverValidator_ValidateReceiver2(t *testing.T) {
 				Email:       "receiver@email.com",
 				PhoneNumber: "+14155556666",
 				ExternalID:  "externalID",
+				Verifications: map[data.VerificationType]string{
+					data.VerificationTypePhoneLast4:           " 6666 ",
+					data.VerificationTypeCustomSecretQuestion: "The Beatles",
+				},
 			},
 			expectedErrors: map[string]interface{}{},
 		},
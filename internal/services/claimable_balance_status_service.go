@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
+)
+
+const claimableBalanceStatusBatchSize = 100
+
+//go:generate mockery --name=ClaimableBalanceStatusServiceInterface --case=underscore --structname=MockClaimableBalanceStatusService --filename=claimable_balance_status_service.go
+type ClaimableBalanceStatusServiceInterface interface {
+	SyncClaimableBalances(ctx context.Context) error
+}
+
+type ClaimableBalanceStatusService struct {
+	Models              *data.Models
+	HorizonClient       horizonclient.ClientInterface
+	DistAccountResolver signing.DistributionAccountResolver
+}
+
+var _ ClaimableBalanceStatusServiceInterface = (*ClaimableBalanceStatusService)(nil)
+
+// SyncClaimableBalances checks in Horizon whether the unclaimed claimable balances of the tenant in the context were
+// claimed by their receivers or reclaimed by the distribution account, and updates the payments accordingly.
+func (s *ClaimableBalanceStatusService) SyncClaimableBalances(ctx context.Context) error {
+	distAcc, err := s.DistAccountResolver.DistributionAccountFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting distribution account from context: %w", err)
+	}
+	if !distAcc.IsStellar() {
+		log.Ctx(ctx).Debugf("Distribution account is not of type %q, skipping claimable balance sync...", schema.StellarPlatform)
+		return nil
+	}
+
+	payments, err := s.Models.Payment.GetUnclaimedClaimableBalances(ctx, s.Models.DBConnectionPool, claimableBalanceStatusBatchSize)
+	if err != nil {
+		return fmt.Errorf("getting unclaimed claimable balances: %w", err)
+	}
+
+	var syncErrors []error
+	for _, payment := range payments {
+		status, syncErr := s.getClaimableBalanceStatus(payment.ClaimableBalanceID, distAcc.Address)
+		if syncErr != nil {
+			syncErrors = append(syncErrors, fmt.Errorf("getting status of claimable balance %s: %w", payment.ClaimableBalanceID, syncErr))
+			continue
+		}
+
+		syncErr = s.Models.Payment.UpdateClaimableBalanceStatus(ctx, s.Models.DBConnectionPool, payment.ID, status)
+		if syncErr != nil {
+			syncErrors = append(syncErrors, fmt.Errorf("updating claimable balance status of payment %s: %w", payment.ID, syncErr))
+			continue
+		}
+
+		if status != data.ClaimableBalanceStatusUnclaimed {
+			log.Ctx(ctx).Infof("Claimable balance %s of payment %s is now %s", payment.ClaimableBalanceID, payment.ID, status)
+		}
+	}
+
+	if len(syncErrors) > 0 {
+		return fmt.Errorf("attempted to sync %d claimable balances but failed on %d: %v", len(payments), len(syncErrors), syncErrors)
+	}
+
+	return nil
+}
+
+// getClaimableBalanceStatus looks for the operation that claimed the claimable balance in Horizon. A claim submitted by
+// the distribution account means the balance was reclaimed, any other claimant means it was claimed by the receiver.
+func (s *ClaimableBalanceStatusService) getClaimableBalanceStatus(balanceID, distributionAddress string) (data.ClaimableBalanceStatus, error) {
+	opsPage, err := s.HorizonClient.Operations(horizonclient.OperationRequest{
+		ForClaimableBalance: balanceID,
+		Order:               horizonclient.OrderDesc,
+		Limit:               10,
+	})
+	if err != nil {
+		return "", fmt.Errorf("getting operations from Horizon: %w", err)
+	}
+
+	for _, op := range opsPage.Embedded.Records {
+		claimOp, ok := op.(operations.ClaimClaimableBalance)
+		if !ok || !claimOp.TransactionSuccessful {
+			continue
+		}
+		if claimOp.Claimant == distributionAddress {
+			return data.ClaimableBalanceStatusReclaimed, nil
+		}
+		return data.ClaimableBalanceStatusClaimed, nil
+	}
+
+	return data.ClaimableBalanceStatusUnclaimed, nil
+}
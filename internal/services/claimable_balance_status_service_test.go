@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	sigMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing/mocks"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
+)
+
+func Test_ClaimableBalanceStatusService_SyncClaimableBalances(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	distAccount := schema.NewStellarEnvTransactionAccount("GAAHIL6ZW4QFNLCKALZ3YOIWPP4TXQ7B7J5IU7RLNVGQAV6GFDZHLDTA")
+	circleDistAccount := schema.TransactionAccount{
+		CircleWalletID: "circle-wallet-id",
+		Type:           schema.DistributionAccountCircleDBVault,
+		Status:         schema.AccountStatusActive,
+	}
+
+	t.Run("returns error when getting distribution account from context fails", func(t *testing.T) {
+		mDistAccountResolver := sigMocks.NewMockDistributionAccountResolver(t)
+		mDistAccountResolver.
+			On("DistributionAccountFromContext", mock.Anything).
+			Return(schema.TransactionAccount{}, assert.AnError).
+			Once()
+
+		svc := ClaimableBalanceStatusService{Models: models, DistAccountResolver: mDistAccountResolver}
+		err := svc.SyncClaimableBalances(ctx)
+		assert.ErrorContains(t, err, "getting distribution account from context")
+	})
+
+	t.Run("skips the sync when the distribution account is not a Stellar account", func(t *testing.T) {
+		mDistAccountResolver := sigMocks.NewMockDistributionAccountResolver(t)
+		mDistAccountResolver.
+			On("DistributionAccountFromContext", mock.Anything).
+			Return(circleDistAccount, nil).
+			Once()
+
+		svc := ClaimableBalanceStatusService{Models: models, DistAccountResolver: mDistAccountResolver}
+		err := svc.SyncClaimableBalances(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("updates the status of the claimed, reclaimed and unclaimed balances", func(t *testing.T) {
+		wallet := data.CreateWalletFixture(t, ctx, dbConnectionPool, "My Wallet", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+		asset := data.CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GABC65XJDMXTGPNZRCI6V3KOKKWVK55UEKGQLONRIVYPMEJNNQ45YOEE")
+		disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+			Status:     data.StartedDisbursementStatus,
+			Asset:      asset,
+			Wallet:     wallet,
+			PayoutMode: data.PayoutModeClaimableBalance,
+		})
+		receiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+		rw := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, data.RegisteredReceiversWalletStatus)
+
+		createPayment := func(balanceID string) *data.Payment {
+			return data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+				ReceiverWallet:         rw,
+				Disbursement:           disbursement,
+				Asset:                  *asset,
+				Amount:                 "100",
+				Status:                 data.SuccessPaymentStatus,
+				ClaimableBalanceID:     balanceID,
+				ClaimableBalanceStatus: data.ClaimableBalanceStatusUnclaimed,
+			})
+		}
+		claimedPayment := createPayment("00000000aaaa")
+		reclaimedPayment := createPayment("00000000bbbb")
+		unclaimedPayment := createPayment("00000000cccc")
+
+		mDistAccountResolver := sigMocks.NewMockDistributionAccountResolver(t)
+		mDistAccountResolver.
+			On("DistributionAccountFromContext", mock.Anything).
+			Return(distAccount, nil).
+			Once()
+
+		mHorizonClient := &horizonclient.MockClient{}
+		defer mHorizonClient.AssertExpectations(t)
+		opsPageWithClaim := func(claimant string) operations.OperationsPage {
+			page := operations.OperationsPage{}
+			page.Embedded.Records = []operations.Operation{
+				operations.ClaimClaimableBalance{
+					Base:     operations.Base{TransactionSuccessful: true},
+					Claimant: claimant,
+				},
+				operations.CreateClaimableBalance{Base: operations.Base{TransactionSuccessful: true}},
+			}
+			return page
+		}
+		mHorizonClient.
+			On("Operations", horizonclient.OperationRequest{ForClaimableBalance: claimedPayment.ClaimableBalanceID, Order: horizonclient.OrderDesc, Limit: 10}).
+			Return(opsPageWithClaim("GBSNN2SPYZB2A5RPDTO3BLX4TP5KNYI7UMUABUS3TYWWEWAAM2D7CMMW"), nil).
+			Once()
+		mHorizonClient.
+			On("Operations", horizonclient.OperationRequest{ForClaimableBalance: reclaimedPayment.ClaimableBalanceID, Order: horizonclient.OrderDesc, Limit: 10}).
+			Return(opsPageWithClaim(distAccount.Address), nil).
+			Once()
+		mHorizonClient.
+			On("Operations", horizonclient.OperationRequest{ForClaimableBalance: unclaimedPayment.ClaimableBalanceID, Order: horizonclient.OrderDesc, Limit: 10}).
+			Return(operations.OperationsPage{}, nil).
+			Once()
+
+		svc := ClaimableBalanceStatusService{
+			Models:              models,
+			HorizonClient:       mHorizonClient,
+			DistAccountResolver: mDistAccountResolver,
+		}
+		err := svc.SyncClaimableBalances(ctx)
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			paymentID  string
+			wantStatus data.ClaimableBalanceStatus
+		}{
+			{claimedPayment.ID, data.ClaimableBalanceStatusClaimed},
+			{reclaimedPayment.ID, data.ClaimableBalanceStatusReclaimed},
+			{unclaimedPayment.ID, data.ClaimableBalanceStatusUnclaimed},
+		} {
+			payment, err := models.Payment.Get(ctx, tc.paymentID, dbConnectionPool)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, payment.ClaimableBalanceStatus)
+		}
+	})
+}
+
+func Test_ClaimableBalanceStatusService_getClaimableBalanceStatus(t *testing.T) {
+	const distributionAddress = "GAAHIL6ZW4QFNLCKALZ3YOIWPP4TXQ7B7J5IU7RLNVGQAV6GFDZHLDTA"
+	const balanceID = "00000000aaaa"
+	request := horizonclient.OperationRequest{ForClaimableBalance: balanceID, Order: horizonclient.OrderDesc, Limit: 10}
+
+	t.Run("returns error when Horizon fails", func(t *testing.T) {
+		mHorizonClient := &horizonclient.MockClient{}
+		defer mHorizonClient.AssertExpectations(t)
+		mHorizonClient.On("Operations", request).Return(operations.OperationsPage{}, assert.AnError).Once()
+
+		svc := ClaimableBalanceStatusService{HorizonClient: mHorizonClient}
+		_, err := svc.getClaimableBalanceStatus(balanceID, distributionAddress)
+		assert.ErrorContains(t, err, "getting operations from Horizon")
+	})
+
+	t.Run("ignores failed claims", func(t *testing.T) {
+		page := operations.OperationsPage{}
+		page.Embedded.Records = []operations.Operation{
+			operations.ClaimClaimableBalance{Base: operations.Base{TransactionSuccessful: false}, Claimant: distributionAddress},
+		}
+		mHorizonClient := &horizonclient.MockClient{}
+		defer mHorizonClient.AssertExpectations(t)
+		mHorizonClient.On("Operations", request).Return(page, nil).Once()
+
+		svc := ClaimableBalanceStatusService{HorizonClient: mHorizonClient}
+		status, err := svc.getClaimableBalanceStatus(balanceID, distributionAddress)
+		require.NoError(t, err)
+		assert.Equal(t, data.ClaimableBalanceStatusUnclaimed, status)
+	})
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/dimchansky/utfbom"
+	"github.com/gocarina/gocsv"
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/validators"
+)
+
+const (
+	// DisbursementInstructionUploadChunkSize is the number of instructions persisted in each transaction.
+	DisbursementInstructionUploadChunkSize = 1000
+	// DisbursementInstructionUploadMaxStepsPerExecution limits the chunks processed on each execution, so a large
+	// upload doesn't hold back the uploads of other tenants.
+	DisbursementInstructionUploadMaxStepsPerExecution = 20
+
+	// maxDisbursementInstructionUploadErrors limits the number of errors stored for an upload.
+	maxDisbursementInstructionUploadErrors = 1000
+	// stellarAmountPrecision is the number of decimal places of Stellar amounts.
+	stellarAmountPrecision = 7
+)
+
+//go:generate mockery --name=DisbursementInstructionUploadServiceInterface --case=underscore --structname=MockDisbursementInstructionUploadService --filename=disbursement_instruction_upload_service.go
+type DisbursementInstructionUploadServiceInterface interface {
+	ProcessPendingUploads(ctx context.Context) error
+}
+
+// DisbursementInstructionUploadService processes the asynchronous instruction uploads of a tenant. The rows of an
+// upload are validated first, and then persisted in chunks of DisbursementInstructionUploadChunkSize, each in its own
+// transaction, recording the progress of the upload as they're persisted.
+type DisbursementInstructionUploadService struct {
+	Models *data.Models
+}
+
+var _ DisbursementInstructionUploadServiceInterface = (*DisbursementInstructionUploadService)(nil)
+
+func NewDisbursementInstructionUploadService(models *data.Models) *DisbursementInstructionUploadService {
+	return &DisbursementInstructionUploadService{Models: models}
+}
+
+// ProcessPendingUploads processes up to DisbursementInstructionUploadMaxStepsPerExecution steps of the uploads that are
+// PENDING or PROCESSING, oldest first. Each step either validates an upload or persists one of its chunks.
+func (s *DisbursementInstructionUploadService) ProcessPendingUploads(ctx context.Context) error {
+	// The instructions are parsed once per execution, rather than once per chunk.
+	instructionsByUploadID := map[string][]*data.DisbursementInstruction{}
+
+	for i := 0; i < DisbursementInstructionUploadMaxStepsPerExecution; i++ {
+		processed, err := s.processNextStep(ctx, instructionsByUploadID)
+		if err != nil {
+			return fmt.Errorf("processing instruction uploads: %w", err)
+		}
+		if !processed {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// processNextStep locks the next active upload and validates it, if it's PENDING, or persists its next chunk. It
+// returns false when there are no uploads to process.
+func (s *DisbursementInstructionUploadService) processNextStep(ctx context.Context, instructionsByUploadID map[string][]*data.DisbursementInstruction) (bool, error) {
+	var upload *data.DisbursementInstructionUpload
+	var chunkErr error
+
+	err := db.RunInTransaction(ctx, s.Models.DBConnectionPool, nil, func(dbTx db.DBTransaction) error {
+		var err error
+		upload, err = s.Models.DisbursementInstructionUploads.GetNextActiveForUpdate(ctx, dbTx)
+		if err != nil {
+			return fmt.Errorf("getting next instruction upload: %w", err)
+		}
+
+		disbursement, err := s.Models.Disbursements.Get(ctx, dbTx, upload.DisbursementID)
+		if err != nil {
+			return fmt.Errorf("getting disbursement %s: %w", upload.DisbursementID, err)
+		}
+
+		instructions, ok := instructionsByUploadID[upload.ID]
+		if !ok {
+			var uploadErrors data.DisbursementInstructionUploadErrors
+			var failedRows int
+			instructions, uploadErrors, failedRows = parseUploadInstructions(ctx, upload.FileContent, disbursement)
+			if len(uploadErrors) > 0 {
+				log.Ctx(ctx).Infof("instruction upload %s of disbursement %s is invalid: %d rows failed", upload.ID, disbursement.ID, failedRows)
+				now := time.Now()
+				return s.Models.DisbursementInstructionUploads.Update(ctx, dbTx, upload.ID, data.DisbursementInstructionUploadUpdate{
+					Status:      data.FailedDisbursementInstructionUploadStatus,
+					TotalRows:   len(instructions),
+					FailedRows:  failedRows,
+					Errors:      uploadErrors,
+					CompletedAt: &now,
+				})
+			}
+			instructionsByUploadID[upload.ID] = instructions
+		}
+
+		if upload.Status == data.PendingDisbursementInstructionUploadStatus {
+			now := time.Now()
+			return s.Models.DisbursementInstructionUploads.Update(ctx, dbTx, upload.ID, data.DisbursementInstructionUploadUpdate{
+				Status:    data.ProcessingDisbursementInstructionUploadStatus,
+				TotalRows: len(instructions),
+				StartedAt: &now,
+			})
+		}
+
+		chunkErr = s.processChunk(ctx, dbTx, upload, disbursement, instructions)
+		return chunkErr
+	})
+	if errors.Is(err, data.ErrRecordNotFound) && upload == nil {
+		return false, nil
+	}
+
+	if errors.Is(chunkErr, data.ErrReceiverVerificationMismatch) || errors.Is(chunkErr, data.ErrReceiverWalletAddressMismatch) {
+		if err = s.failChunk(ctx, upload, chunkErr); err != nil {
+			return true, fmt.Errorf("failing instruction upload %s: %w", upload.ID, err)
+		}
+		delete(instructionsByUploadID, upload.ID)
+		return true, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("processing instruction upload: %w", err)
+	}
+
+	// The instructions of finished uploads are no longer needed.
+	if upload.Status == data.ProcessingDisbursementInstructionUploadStatus {
+		if _, end := chunkBounds(upload.ProcessedRows, upload.TotalRows); end == upload.TotalRows {
+			delete(instructionsByUploadID, upload.ID)
+		}
+	}
+
+	return true, nil
+}
+
+// processChunk persists the next chunk of instructions of an upload and records the progress.
+func (s *DisbursementInstructionUploadService) processChunk(ctx context.Context, dbTx db.DBTransaction, upload *data.DisbursementInstructionUpload, disbursement *data.Disbursement, instructions []*data.DisbursementInstruction) error {
+	start, end := chunkBounds(upload.ProcessedRows, len(instructions))
+
+	result, err := s.Models.DisbursementInstructions.ProcessChunk(ctx, dbTx, data.DisbursementInstructionsChunkOpts{
+		UserID:       upload.CreatedBy,
+		Instructions: instructions[start:end],
+		Disbursement: disbursement,
+		DisbursementUpdate: &data.DisbursementUpdate{
+			ID:          disbursement.ID,
+			FileName:    upload.FileName,
+			FileContent: upload.FileContent,
+		},
+		IsFirstChunk: start == 0,
+		IsLastChunk:  end == len(instructions),
+	})
+	if err != nil {
+		return fmt.Errorf("processing rows %d to %d of instruction upload %s: %w", start, end, upload.ID, err)
+	}
+
+	summary := upload.Summary
+	if summary == nil {
+		summary = &data.DisbursementInstructionUploadSummary{}
+	}
+	totalAmount := sumInstructionAmounts(instructions[start:end])
+	if previousAmount, ok := new(big.Rat).SetString(summary.TotalAmount); ok {
+		totalAmount.Add(totalAmount, previousAmount)
+	}
+	summary = &data.DisbursementInstructionUploadSummary{
+		ReceiversCreated: summary.ReceiversCreated + result.ReceiversCreated,
+		PaymentsCreated:  summary.PaymentsCreated + result.PaymentsCreated,
+		TotalAmount:      totalAmount.FloatString(stellarAmountPrecision),
+	}
+
+	update := data.DisbursementInstructionUploadUpdate{
+		ProcessedRows: end,
+		Summary:       summary,
+	}
+	if end == len(instructions) {
+		now := time.Now()
+		update.Status = data.CompletedDisbursementInstructionUploadStatus
+		update.CompletedAt = &now
+		log.Ctx(ctx).Infof("instruction upload %s of disbursement %s completed with %d instructions", upload.ID, disbursement.ID, end)
+	}
+	if err = s.Models.DisbursementInstructionUploads.Update(ctx, dbTx, upload.ID, update); err != nil {
+		return fmt.Errorf("updating progress of instruction upload %s: %w", upload.ID, err)
+	}
+
+	return nil
+}
+
+// failChunk marks an upload as FAILED because one of the instructions of its next chunk doesn't match the existing
+// receiver data. The payments persisted by the previous chunks are deleted, so the disbursement is left without a
+// partial set of payments.
+func (s *DisbursementInstructionUploadService) failChunk(ctx context.Context, upload *data.DisbursementInstructionUpload, chunkErr error) error {
+	start, end := chunkBounds(upload.ProcessedRows, upload.TotalRows)
+	// Lines are numbered from 2, since the first line of the file is its header.
+	linesKey := fmt.Sprintf("lines %d-%d", start+2, end+1)
+
+	// The message is taken from the innermost error describing the mismatch, without the context it was wrapped with.
+	message := chunkErr.Error()
+	for err := chunkErr; err != nil; err = errors.Unwrap(err) {
+		if errors.Is(errors.Unwrap(err), data.ErrReceiverVerificationMismatch) || errors.Is(errors.Unwrap(err), data.ErrReceiverWalletAddressMismatch) {
+			message = err.Error()
+		}
+	}
+
+	return db.RunInTransaction(ctx, s.Models.DBConnectionPool, nil, func(dbTx db.DBTransaction) error {
+		if err := s.Models.Payment.DeleteAllDraftForDisbursement(ctx, dbTx, upload.DisbursementID); err != nil {
+			return fmt.Errorf("deleting draft payments of disbursement %s: %w", upload.DisbursementID, err)
+		}
+
+		now := time.Now()
+		return s.Models.DisbursementInstructionUploads.Update(ctx, dbTx, upload.ID, data.DisbursementInstructionUploadUpdate{
+			Status:      data.FailedDisbursementInstructionUploadStatus,
+			FailedRows:  end - start,
+			Errors:      data.DisbursementInstructionUploadErrors{linesKey: message},
+			CompletedAt: &now,
+		})
+	})
+}
+
+// chunkBounds returns the bounds of the chunk that starts after the processed rows.
+func chunkBounds(processedRows, totalRows int) (int, int) {
+	return processedRows, min(processedRows+DisbursementInstructionUploadChunkSize, totalRows)
+}
+
+// parseUploadInstructions parses and validates the instructions of an uploaded CSV file, the same way the instructions
+// of synchronous uploads are validated. It also rejects the rows that repeat the receiver of a previous row, since the
+// chunks of an upload can't repeat receivers. The errors are keyed by the line and field they refer to, and are capped
+// at maxDisbursementInstructionUploadErrors, while the number of rows with errors is returned in full.
+func parseUploadInstructions(ctx context.Context, fileContent []byte, disbursement *data.Disbursement) ([]*data.DisbursementInstruction, data.DisbursementInstructionUploadErrors, int) {
+	instructions := []*data.DisbursementInstruction{}
+	if err := gocsv.Unmarshal(utfbom.SkipOnly(bytes.NewReader(fileContent)), &instructions); err != nil {
+		log.Ctx(ctx).Errorf("error parsing csv file: %s", err.Error())
+		return nil, data.DisbursementInstructionUploadErrors{"file": "could not parse file"}, 0
+	}
+
+	if len(instructions) == 0 {
+		return nil, data.DisbursementInstructionUploadErrors{"instructions": "no valid instructions found"}, 0
+	}
+	if len(instructions) > data.MaxInstructionsPerUpload {
+		return instructions, data.DisbursementInstructionUploadErrors{
+			"instructions": fmt.Sprintf("number of instructions exceeds maximum of %d", data.MaxInstructionsPerUpload),
+		}, 0
+	}
+
+	uploadErrors := data.DisbursementInstructionUploadErrors{}
+	addError := func(key, message string) {
+		if len(uploadErrors) < maxDisbursementInstructionUploadErrors {
+			uploadErrors[key] = message
+		}
+	}
+
+	failedRows := 0
+	rows := validateInstructionRows(instructions, disbursement)
+	sanitizedInstructions := make([]*data.DisbursementInstruction, 0, len(rows))
+	for _, row := range rows {
+		if len(row.errors) > 0 {
+			failedRows++
+			for key, message := range row.errors {
+				addError(key, fmt.Sprint(message))
+			}
+		}
+		sanitizedInstructions = append(sanitizedInstructions, row.instruction)
+	}
+
+	return sanitizedInstructions, uploadErrors, failedRows
+}
+
+// instructionRow is a sanitized and validated row of an instructions file.
+type instructionRow struct {
+	lineNumber  int
+	instruction *data.DisbursementInstruction
+	errors      map[string]interface{}
+	// duplicateOfLine is the line of a previous row with the same receiver, if any.
+	duplicateOfLine int
+}
+
+// validateInstructionRows sanitizes and validates each of the instructions the same way the instructions of synchronous
+// uploads are validated, also rejecting the rows that repeat the receiver of a previous row.
+func validateInstructionRows(instructions []*data.DisbursementInstruction, disbursement *data.Disbursement) []*instructionRow {
+	rows := make([]*instructionRow, 0, len(instructions))
+	lineByContact := make(map[string]int, len(instructions))
+	for i, instruction := range instructions {
+		lineNumber := i + 2 // +1 for header row, +1 for 0-index
+		validator := validators.NewDisbursementInstructionsValidator(disbursement.RegistrationContactType, disbursement.VerificationField)
+		sanitizedInstruction := validator.SanitizeInstruction(instruction)
+		validator.ValidateInstruction(sanitizedInstruction, lineNumber)
+		if _, ok := new(big.Rat).SetString(sanitizedInstruction.Amount); !ok {
+			validator.AddError(fmt.Sprintf("line %d - amount", lineNumber), "invalid amount. Amount must be a positive number")
+		}
+
+		row := &instructionRow{lineNumber: lineNumber, instruction: sanitizedInstruction}
+		if !validator.HasErrors() {
+			contact, _ := sanitizedInstruction.Contact()
+			if previousLine, repeated := lineByContact[contact]; repeated {
+				row.duplicateOfLine = previousLine
+				validator.AddError(fmt.Sprintf("line %d - contact", lineNumber), fmt.Sprintf("receiver was already included on line %d", previousLine))
+			} else {
+				lineByContact[contact] = lineNumber
+			}
+		}
+		row.errors = validator.Errors
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// sumInstructionAmounts returns the sum of the amounts of the instructions, which must have been validated.
+func sumInstructionAmounts(instructions []*data.DisbursementInstruction) *big.Rat {
+	total := new(big.Rat)
+	for _, instruction := range instructions {
+		if amount, ok := new(big.Rat).SetString(instruction.Amount); ok {
+			total.Add(total, amount)
+		}
+	}
+	return total
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+)
+
+func Test_parseUploadInstructions(t *testing.T) {
+	ctx := context.Background()
+	disbursement := &data.Disbursement{
+		RegistrationContactType: data.RegistrationContactTypePhone,
+		VerificationField:       data.VerificationTypeDateOfBirth,
+	}
+
+	testCases := []struct {
+		name             string
+		fileContent      string
+		wantInstructions []*data.DisbursementInstruction
+		wantErrors       data.DisbursementInstructionUploadErrors
+		wantFailedRows   int
+	}{
+		{
+			name:        "🔴 the file can't be parsed",
+			fileContent: "",
+			wantErrors:  data.DisbursementInstructionUploadErrors{"file": "could not parse file"},
+		},
+		{
+			name:        "🔴 the file has no instructions",
+			fileContent: "phone,id,amount,verification\n",
+			wantErrors:  data.DisbursementInstructionUploadErrors{"instructions": "no valid instructions found"},
+		},
+		{
+			name: "🔴 invalid and repeated rows are reported by line",
+			fileContent: "phone,id,amount,verification\n" +
+				"+380445555555,1,100.5,1990-01-01\n" +
+				"invalid,2,-1,1990-01-02\n" +
+				"+380445555555,3,200,1990-01-03\n",
+			wantErrors: data.DisbursementInstructionUploadErrors{
+				"line 3 - phone":   "invalid phone format. Correct format: +380445555555",
+				"line 3 - amount":  "invalid amount. Amount must be a positive number",
+				"line 4 - contact": "receiver was already included on line 2",
+			},
+			wantFailedRows: 2,
+		},
+		{
+			name: "🎉 the instructions are sanitized",
+			fileContent: "phone,id,amount,verification\n" +
+				" +380445555555 ,1,100.5,1990-01-01\n" +
+				"+380445555556,2,200,1990-01-02\n",
+			wantInstructions: []*data.DisbursementInstruction{
+				{Phone: "+380445555555", ID: "1", Amount: "100.5", VerificationValue: "1990-01-01"},
+				{Phone: "+380445555556", ID: "2", Amount: "200", VerificationValue: "1990-01-02"},
+			},
+			wantErrors: data.DisbursementInstructionUploadErrors{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			instructions, uploadErrors, failedRows := parseUploadInstructions(ctx, []byte(tc.fileContent), disbursement)
+			assert.Equal(t, tc.wantErrors, uploadErrors)
+			assert.Equal(t, tc.wantFailedRows, failedRows)
+			if tc.wantInstructions != nil {
+				assert.Equal(t, tc.wantInstructions, instructions)
+			}
+		})
+	}
+
+	t.Run("🔴 the stored errors are capped", func(t *testing.T) {
+		var fileContent strings.Builder
+		fileContent.WriteString("phone,id,amount,verification\n")
+		for i := 0; i < maxDisbursementInstructionUploadErrors+1; i++ {
+			fileContent.WriteString(fmt.Sprintf("invalid,%d,100,1990-01-01\n", i))
+		}
+
+		_, uploadErrors, failedRows := parseUploadInstructions(ctx, []byte(fileContent.String()), disbursement)
+		assert.Len(t, uploadErrors, maxDisbursementInstructionUploadErrors)
+		assert.Equal(t, maxDisbursementInstructionUploadErrors+1, failedRows)
+	})
+}
+
+func Test_chunkBounds(t *testing.T) {
+	start, end := chunkBounds(0, 2500)
+	assert.Equal(t, 0, start)
+	assert.Equal(t, DisbursementInstructionUploadChunkSize, end)
+
+	start, end = chunkBounds(2000, 2500)
+	assert.Equal(t, 2000, start)
+	assert.Equal(t, 2500, end)
+}
+
+func Test_sumInstructionAmounts(t *testing.T) {
+	total := sumInstructionAmounts([]*data.DisbursementInstruction{
+		{Amount: "0.1"},
+		{Amount: "0.2"},
+		{Amount: "100.0000001"},
+	})
+	assert.Equal(t, "100.3000001", total.FloatString(stellarAmountPrecision))
+}
+
+func Test_DisbursementInstructionUploadService_ProcessPendingUploads(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+	service := NewDisbursementInstructionUploadService(models)
+
+	cleanup := func() {
+		data.DeleteAllFixtures(t, ctx, dbConnectionPool)
+	}
+
+	createUpload := func(t *testing.T, disbursement *data.Disbursement, fileContent string) *data.DisbursementInstructionUpload {
+		upload, err := models.DisbursementInstructionUploads.Insert(ctx, dbConnectionPool, data.DisbursementInstructionUploadInsert{
+			DisbursementID: disbursement.ID,
+			FileName:       "instructions.csv",
+			FileContent:    []byte(fileContent),
+			CreatedBy:      "user-id",
+		})
+		require.NoError(t, err)
+		return upload
+	}
+
+	countPayments := func(t *testing.T, disbursement *data.Disbursement) int {
+		var count int
+		err := dbConnectionPool.GetContext(ctx, &count, "SELECT COUNT(*) FROM payments WHERE disbursement_id = $1", disbursement.ID)
+		require.NoError(t, err)
+		return count
+	}
+
+	getUpload := func(t *testing.T, upload *data.DisbursementInstructionUpload) *data.DisbursementInstructionUpload {
+		upload, err := models.DisbursementInstructionUploads.Get(ctx, dbConnectionPool, upload.DisbursementID, upload.ID)
+		require.NoError(t, err)
+		return upload
+	}
+
+	t.Run("🎉 does nothing when there are no uploads", func(t *testing.T) {
+		require.NoError(t, service.ProcessPendingUploads(ctx))
+	})
+
+	t.Run("🎉 the upload is validated and then persisted in chunks", func(t *testing.T) {
+		defer cleanup()
+
+		totalRows := DisbursementInstructionUploadChunkSize + 500
+		var fileContent strings.Builder
+		fileContent.WriteString("phone,id,amount,verification\n")
+		for i := 0; i < totalRows; i++ {
+			fileContent.WriteString(fmt.Sprintf("+38044555%04d,%d,1.5,1990-01-01\n", i, i))
+		}
+
+		disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{Status: data.DraftDisbursementStatus})
+		upload := createUpload(t, disbursement, fileContent.String())
+		instructionsByUploadID := map[string][]*data.DisbursementInstruction{}
+
+		processed, err := service.processNextStep(ctx, instructionsByUploadID)
+		require.NoError(t, err)
+		assert.True(t, processed)
+		upload = getUpload(t, upload)
+		assert.Equal(t, data.ProcessingDisbursementInstructionUploadStatus, upload.Status)
+		assert.Equal(t, totalRows, upload.TotalRows)
+		assert.Zero(t, upload.ProcessedRows)
+		assert.NotNil(t, upload.StartedAt)
+
+		processed, err = service.processNextStep(ctx, instructionsByUploadID)
+		require.NoError(t, err)
+		assert.True(t, processed)
+		upload = getUpload(t, upload)
+		assert.Equal(t, data.ProcessingDisbursementInstructionUploadStatus, upload.Status)
+		assert.Equal(t, DisbursementInstructionUploadChunkSize, upload.ProcessedRows)
+
+		processed, err = service.processNextStep(ctx, instructionsByUploadID)
+		require.NoError(t, err)
+		assert.True(t, processed)
+		upload = getUpload(t, upload)
+		assert.Equal(t, data.CompletedDisbursementInstructionUploadStatus, upload.Status)
+		assert.Equal(t, totalRows, upload.ProcessedRows)
+		assert.NotNil(t, upload.CompletedAt)
+		assert.Equal(t, &data.DisbursementInstructionUploadSummary{
+			ReceiversCreated: totalRows,
+			PaymentsCreated:  totalRows,
+			TotalAmount:      "2250.0000000",
+		}, upload.Summary)
+		assert.Empty(t, instructionsByUploadID)
+
+		disbursement, err = models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.ReadyDisbursementStatus, disbursement.Status)
+		assert.Equal(t, "instructions.csv", disbursement.FileName)
+		assert.Equal(t, totalRows, countPayments(t, disbursement))
+
+		processed, err = service.processNextStep(ctx, instructionsByUploadID)
+		require.NoError(t, err)
+		assert.False(t, processed)
+	})
+
+	t.Run("🔴 an invalid upload fails without persisting its instructions", func(t *testing.T) {
+		defer cleanup()
+
+		disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{Status: data.DraftDisbursementStatus})
+		upload := createUpload(t, disbursement, "phone,id,amount,verification\n+380445555555,1,100,1990-01-01\ninvalid,2,100,1990-01-02\n")
+
+		require.NoError(t, service.ProcessPendingUploads(ctx))
+
+		upload = getUpload(t, upload)
+		assert.Equal(t, data.FailedDisbursementInstructionUploadStatus, upload.Status)
+		assert.Equal(t, 2, upload.TotalRows)
+		assert.Equal(t, 1, upload.FailedRows)
+		assert.Equal(t, data.DisbursementInstructionUploadErrors{"line 3 - phone": "invalid phone format. Correct format: +380445555555"}, upload.Errors)
+		assert.NotNil(t, upload.CompletedAt)
+
+		disbursement, err = models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.DraftDisbursementStatus, disbursement.Status)
+		assert.Zero(t, countPayments(t, disbursement))
+	})
+
+	t.Run("🔴 a verification mismatch fails the upload and drops its payments", func(t *testing.T) {
+		defer cleanup()
+
+		receiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{PhoneNumber: "+380445555555"})
+		verification := data.CreateReceiverVerificationFixture(t, ctx, dbConnectionPool, data.ReceiverVerificationInsert{
+			ReceiverID:        receiver.ID,
+			VerificationField: data.VerificationTypeDateOfBirth,
+			VerificationValue: "1990-01-01",
+		})
+		_, err = dbConnectionPool.ExecContext(ctx, "UPDATE receiver_verifications SET confirmed_at = NOW() WHERE receiver_id = $1 AND verification_field = $2", receiver.ID, verification.VerificationField)
+		require.NoError(t, err)
+
+		disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{Status: data.DraftDisbursementStatus})
+		upload := createUpload(t, disbursement, "phone,id,amount,verification\n+380445555555,1,100,1990-12-31\n")
+
+		require.NoError(t, service.ProcessPendingUploads(ctx))
+
+		upload = getUpload(t, upload)
+		assert.Equal(t, data.FailedDisbursementInstructionUploadStatus, upload.Status)
+		assert.Equal(t, 1, upload.FailedRows)
+		require.Contains(t, upload.Errors, "lines 2-2")
+		assert.True(t, strings.HasPrefix(upload.Errors["lines 2-2"], data.ErrReceiverVerificationMismatch.Error()))
+
+		disbursement, err = models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.DraftDisbursementStatus, disbursement.Status)
+		assert.Zero(t, countPayments(t, disbursement))
+	})
+}
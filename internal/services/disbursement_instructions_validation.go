@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
+)
+
+// InstructionReceiverStatus is whether the receiver of an instruction already exists.
+type InstructionReceiverStatus string
+
+const (
+	NewInstructionReceiverStatus      InstructionReceiverStatus = "NEW"
+	ExistingInstructionReceiverStatus InstructionReceiverStatus = "EXISTING"
+)
+
+// InstructionMatchStatus is how the verification value or wallet address of an instruction compares to the data of
+// an existing receiver.
+type InstructionMatchStatus string
+
+const (
+	// NewInstructionMatchStatus means the receiver has no data to compare to, so it will be created.
+	NewInstructionMatchStatus InstructionMatchStatus = "NEW"
+	// MatchInstructionMatchStatus means the instruction matches the data of the receiver.
+	MatchInstructionMatchStatus InstructionMatchStatus = "MATCH"
+	// UpdateInstructionMatchStatus means the verification value doesn't match a verification that wasn't confirmed by
+	// the receiver yet, so it will be updated.
+	UpdateInstructionMatchStatus InstructionMatchStatus = "UPDATE"
+	// MismatchInstructionMatchStatus means the instruction doesn't match a verification confirmed by the receiver, or
+	// the wallet address registered for them, so the instructions would be rejected.
+	MismatchInstructionMatchStatus InstructionMatchStatus = "MISMATCH"
+)
+
+// InstructionsValidationReport is the outcome of validating a disbursement instructions file without processing it.
+type InstructionsValidationReport struct {
+	// Valid is whether the instructions would be accepted and the distribution account can pay them.
+	Valid   bool                           `json:"valid"`
+	Summary InstructionsValidationSummary  `json:"summary"`
+	Balance *InstructionsValidationBalance `json:"balance,omitempty"`
+	Rows    []*InstructionValidationRow    `json:"rows"`
+}
+
+type InstructionsValidationSummary struct {
+	TotalRows               int    `json:"total_rows"`
+	ValidRows               int    `json:"valid_rows"`
+	InvalidRows             int    `json:"invalid_rows"`
+	NewReceivers            int    `json:"new_receivers"`
+	ExistingReceivers       int    `json:"existing_receivers"`
+	VerificationUpdates     int    `json:"verification_updates"`
+	VerificationMismatches  int    `json:"verification_mismatches"`
+	WalletAddressMismatches int    `json:"wallet_address_mismatches"`
+	DuplicateContacts       int    `json:"duplicate_contacts"`
+	TotalAmount             string `json:"total_amount"`
+}
+
+// InstructionsValidationBalance compares the total amount of the instructions against the balance of the distribution
+// account that isn't already committed to payments in progress.
+type InstructionsValidationBalance struct {
+	AssetCode          string `json:"asset_code"`
+	AssetIssuer        string `json:"asset_issuer,omitempty"`
+	AvailableBalance   string `json:"available_balance"`
+	TotalPendingAmount string `json:"total_pending_amount"`
+	Sufficient         bool   `json:"sufficient"`
+}
+
+type InstructionValidationRow struct {
+	Line          int                       `json:"line"`
+	ID            string                    `json:"id"`
+	Contact       string                    `json:"contact,omitempty"`
+	Amount        string                    `json:"amount"`
+	Receiver      InstructionReceiverStatus `json:"receiver,omitempty"`
+	ReceiverID    string                    `json:"receiver_id,omitempty"`
+	Verification  InstructionMatchStatus    `json:"verification,omitempty"`
+	WalletAddress InstructionMatchStatus    `json:"wallet_address,omitempty"`
+	// DuplicateOfLine is the line of a previous row with the same receiver, if any.
+	DuplicateOfLine int                    `json:"duplicate_of_line,omitempty"`
+	Errors          map[string]interface{} `json:"errors,omitempty"`
+	Valid           bool                   `json:"valid"`
+}
+
+// ValidateInstructions runs the instructions of a disbursement through the same validations and receiver
+// reconciliation they would go through when uploaded, without writing anything. Unlike an upload, it doesn't stop at
+// the first invalid row, and reports the outcome of each row instead, along with whether the distribution account has
+// enough balance to pay them.
+func (s *DisbursementManagementService) ValidateInstructions(ctx context.Context, disbursement *data.Disbursement, distributionAccount *schema.TransactionAccount, instructions []*data.DisbursementInstruction) (*InstructionsValidationReport, error) {
+	rows := validateInstructionRows(instructions, disbursement)
+
+	reconcilableInstructions := make([]*data.DisbursementInstruction, 0, len(rows))
+	for _, row := range rows {
+		if isReconcilable(row) {
+			reconcilableInstructions = append(reconcilableInstructions, row.instruction)
+		}
+	}
+	existingReceivers, err := s.Models.DisbursementInstructions.GetExistingReceivers(ctx, s.Models.DBConnectionPool, reconcilableInstructions, disbursement)
+	if err != nil {
+		return nil, fmt.Errorf("getting existing receivers of the instructions: %w", err)
+	}
+
+	report := &InstructionsValidationReport{
+		Summary: InstructionsValidationSummary{TotalRows: len(rows)},
+		Rows:    make([]*InstructionValidationRow, 0, len(rows)),
+	}
+	totalAmount := new(big.Rat)
+	for _, row := range rows {
+		reportRow := &InstructionValidationRow{
+			Line:            row.lineNumber,
+			ID:              row.instruction.ID,
+			Amount:          row.instruction.Amount,
+			DuplicateOfLine: row.duplicateOfLine,
+			Errors:          row.errors,
+		}
+		if reportRow.DuplicateOfLine != 0 {
+			report.Summary.DuplicateContacts++
+		}
+
+		if isReconcilable(row) {
+			reportRow.Contact, _ = row.instruction.Contact()
+			reconcileInstructionRow(reportRow, row.instruction, existingReceivers[reportRow.Contact], disbursement)
+			if amount, ok := new(big.Rat).SetString(row.instruction.Amount); ok {
+				totalAmount.Add(totalAmount, amount)
+			}
+
+			if reportRow.Receiver == NewInstructionReceiverStatus {
+				report.Summary.NewReceivers++
+			} else {
+				report.Summary.ExistingReceivers++
+			}
+			switch {
+			case reportRow.Verification == UpdateInstructionMatchStatus:
+				report.Summary.VerificationUpdates++
+			case reportRow.Verification == MismatchInstructionMatchStatus:
+				report.Summary.VerificationMismatches++
+			case reportRow.WalletAddress == MismatchInstructionMatchStatus:
+				report.Summary.WalletAddressMismatches++
+			}
+		}
+
+		reportRow.Valid = len(reportRow.Errors) == 0
+		if reportRow.Valid {
+			report.Summary.ValidRows++
+		} else {
+			report.Summary.InvalidRows++
+		}
+		report.Rows = append(report.Rows, reportRow)
+	}
+	report.Summary.TotalAmount = totalAmount.FloatString(stellarAmountPrecision)
+
+	report.Balance, err = s.getInstructionsValidationBalance(ctx, disbursement, distributionAccount, totalAmount)
+	if err != nil {
+		return nil, fmt.Errorf("validating balance for the instructions: %w", err)
+	}
+
+	report.Valid = report.Summary.InvalidRows == 0 && (report.Balance == nil || report.Balance.Sufficient)
+	return report, nil
+}
+
+// isReconcilable returns whether the fields of a row are valid, so it can be reconciled with the existing receivers.
+// Rows that repeat a receiver are still reconciled, since their fields are valid.
+func isReconcilable(row *instructionRow) bool {
+	return len(row.errors) == 0 || row.duplicateOfLine != 0
+}
+
+// reconcileInstructionRow fills in how the instruction of a row compares to the existing receiver, the same way it's
+// compared when the instructions are processed, adding an error to the row when the instruction would be rejected.
+func reconcileInstructionRow(row *InstructionValidationRow, instruction *data.DisbursementInstruction, existingReceiver *data.ExistingInstructionReceiver, disbursement *data.Disbursement) {
+	row.Receiver = NewInstructionReceiverStatus
+	if existingReceiver != nil {
+		row.Receiver = ExistingInstructionReceiverStatus
+		row.ReceiverID = existingReceiver.Receiver.ID
+	}
+
+	if disbursement.RegistrationContactType.IncludesWalletAddress {
+		switch {
+		case existingReceiver == nil || existingReceiver.ReceiverWallet == nil || existingReceiver.ReceiverWallet.StellarAddress == "":
+			row.WalletAddress = NewInstructionMatchStatus
+		case existingReceiver.ReceiverWallet.StellarAddress == instruction.WalletAddress:
+			row.WalletAddress = MatchInstructionMatchStatus
+		default:
+			row.WalletAddress = MismatchInstructionMatchStatus
+			addRowError(row, fmt.Sprintf("line %d - wallet address", row.Line), "wallet address doesn't match the one registered for the receiver")
+		}
+		return
+	}
+
+	switch {
+	case existingReceiver == nil || existingReceiver.Verification == nil:
+		row.Verification = NewInstructionMatchStatus
+	case existingReceiver.Verification.VerificationField.CompareValue(existingReceiver.Verification.HashedValue, instruction.VerificationValue):
+		row.Verification = MatchInstructionMatchStatus
+	case existingReceiver.Verification.ConfirmedAt == nil:
+		row.Verification = UpdateInstructionMatchStatus
+	default:
+		row.Verification = MismatchInstructionMatchStatus
+		addRowError(row, fmt.Sprintf("line %d - verification", row.Line), "verification doesn't match the one confirmed by the receiver")
+	}
+}
+
+func addRowError(row *InstructionValidationRow, key, message string) {
+	if row.Errors == nil {
+		row.Errors = map[string]interface{}{}
+	}
+	row.Errors[key] = message
+}
+
+// getInstructionsValidationBalance compares the total amount of the instructions against the balance of the
+// distribution account, minus the payments in progress, the same way the balance is validated when the disbursement is
+// started. The total amount of path payment disbursements is denominated in the receive asset, so it's converted into
+// the max amount of the disbursement asset that can be spent to pay it.
+func (s *DisbursementManagementService) getInstructionsValidationBalance(ctx context.Context, disbursement *data.Disbursement, distributionAccount *schema.TransactionAccount, totalAmount *big.Rat) (*InstructionsValidationBalance, error) {
+	availableBalance, err := s.DistributionAccountService.GetBalance(ctx, distributionAccount, *disbursement.Asset)
+	if err != nil {
+		return nil, fmt.Errorf("getting balance for asset (%s,%s) on distribution account %v: %w", disbursement.Asset.Code, disbursement.Asset.Issuer, distributionAccount, err)
+	}
+
+	totalPendingAmount, err := getTotalPendingAmount(ctx, s.Models, s.DistributionAccountService, s.Models.DBConnectionPool, distributionAccount, *disbursement.Asset, disbursement.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting total pending amount: %w", err)
+	}
+
+	if disbursement.ReceiveAssetCode != "" {
+		sendMax, sendAmountErr := estimateSendAmount(ctx, s.DistributionAccountService, distributionAccount, disbursement, totalAmount.FloatString(stellarAmountPrecision))
+		if sendAmountErr != nil {
+			return nil, fmt.Errorf("getting the amount spent by the instructions: %w", sendAmountErr)
+		}
+		totalAmount = new(big.Rat).SetFloat64(sendMax)
+	}
+
+	available := new(big.Rat).SetFloat64(availableBalance)
+	pending := new(big.Rat).SetFloat64(totalPendingAmount)
+	if available == nil || pending == nil {
+		return nil, fmt.Errorf("invalid balance %f or pending amount %f", availableBalance, totalPendingAmount)
+	}
+	required := new(big.Rat).Add(pending, totalAmount)
+
+	return &InstructionsValidationBalance{
+		AssetCode:          disbursement.Asset.Code,
+		AssetIssuer:        disbursement.Asset.Issuer,
+		AvailableBalance:   available.FloatString(stellarAmountPrecision),
+		TotalPendingAmount: pending.FloatString(stellarAmountPrecision),
+		Sufficient:         available.Cmp(required) >= 0,
+	}, nil
+}
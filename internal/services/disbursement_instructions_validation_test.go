@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services/mocks"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
+)
+
+func Test_reconcileInstructionRow(t *testing.T) {
+	hashedValue, err := data.HashVerificationValue("1990-01-01")
+	require.NoError(t, err)
+	confirmedAt := time.Now()
+
+	phoneDisbursement := &data.Disbursement{
+		RegistrationContactType: data.RegistrationContactTypePhone,
+		VerificationField:       data.VerificationTypeDateOfBirth,
+	}
+	walletDisbursement := &data.Disbursement{
+		RegistrationContactType: data.RegistrationContactTypePhoneAndWalletAddress,
+	}
+	receiver := &data.Receiver{ID: "receiver-id", PhoneNumber: "+380445555555"}
+	walletAddress := "GBLTXF46JTCGMWFJASQLVXMMA36IPYTDCN4EN73HRXCGDCGYBZM3A444"
+
+	testCases := []struct {
+		name              string
+		disbursement      *data.Disbursement
+		instruction       *data.DisbursementInstruction
+		existingReceiver  *data.ExistingInstructionReceiver
+		wantReceiver      InstructionReceiverStatus
+		wantVerification  InstructionMatchStatus
+		wantWalletAddress InstructionMatchStatus
+		wantErrors        map[string]interface{}
+	}{
+		{
+			name:             "🎉 new receiver",
+			disbursement:     phoneDisbursement,
+			instruction:      &data.DisbursementInstruction{VerificationValue: "1990-01-01"},
+			wantReceiver:     NewInstructionReceiverStatus,
+			wantVerification: NewInstructionMatchStatus,
+		},
+		{
+			name:             "🎉 existing receiver without verification",
+			disbursement:     phoneDisbursement,
+			instruction:      &data.DisbursementInstruction{VerificationValue: "1990-01-01"},
+			existingReceiver: &data.ExistingInstructionReceiver{Receiver: receiver},
+			wantReceiver:     ExistingInstructionReceiverStatus,
+			wantVerification: NewInstructionMatchStatus,
+		},
+		{
+			name:         "🎉 existing receiver with a matching verification",
+			disbursement: phoneDisbursement,
+			instruction:  &data.DisbursementInstruction{VerificationValue: "1990-01-01"},
+			existingReceiver: &data.ExistingInstructionReceiver{
+				Receiver:     receiver,
+				Verification: &data.ReceiverVerification{HashedValue: hashedValue, ConfirmedAt: &confirmedAt},
+			},
+			wantReceiver:     ExistingInstructionReceiverStatus,
+			wantVerification: MatchInstructionMatchStatus,
+		},
+		{
+			name:         "🎉 existing receiver with a different unconfirmed verification",
+			disbursement: phoneDisbursement,
+			instruction:  &data.DisbursementInstruction{VerificationValue: "1990-01-02"},
+			existingReceiver: &data.ExistingInstructionReceiver{
+				Receiver:     receiver,
+				Verification: &data.ReceiverVerification{HashedValue: hashedValue},
+			},
+			wantReceiver:     ExistingInstructionReceiverStatus,
+			wantVerification: UpdateInstructionMatchStatus,
+		},
+		{
+			name:         "🔴 existing receiver with a different confirmed verification",
+			disbursement: phoneDisbursement,
+			instruction:  &data.DisbursementInstruction{VerificationValue: "1990-01-02"},
+			existingReceiver: &data.ExistingInstructionReceiver{
+				Receiver:     receiver,
+				Verification: &data.ReceiverVerification{HashedValue: hashedValue, ConfirmedAt: &confirmedAt},
+			},
+			wantReceiver:     ExistingInstructionReceiverStatus,
+			wantVerification: MismatchInstructionMatchStatus,
+			wantErrors:       map[string]interface{}{"line 2 - verification": "verification doesn't match the one confirmed by the receiver"},
+		},
+		{
+			name:         "🎉 existing receiver with the same wallet address",
+			disbursement: walletDisbursement,
+			instruction:  &data.DisbursementInstruction{WalletAddress: walletAddress},
+			existingReceiver: &data.ExistingInstructionReceiver{
+				Receiver:       receiver,
+				ReceiverWallet: &data.ReceiverWallet{StellarAddress: walletAddress},
+			},
+			wantReceiver:      ExistingInstructionReceiverStatus,
+			wantWalletAddress: MatchInstructionMatchStatus,
+		},
+		{
+			name:         "🔴 existing receiver with a different wallet address",
+			disbursement: walletDisbursement,
+			instruction:  &data.DisbursementInstruction{WalletAddress: walletAddress},
+			existingReceiver: &data.ExistingInstructionReceiver{
+				Receiver:       receiver,
+				ReceiverWallet: &data.ReceiverWallet{StellarAddress: "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG"},
+			},
+			wantReceiver:      ExistingInstructionReceiverStatus,
+			wantWalletAddress: MismatchInstructionMatchStatus,
+			wantErrors:        map[string]interface{}{"line 2 - wallet address": "wallet address doesn't match the one registered for the receiver"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			row := &InstructionValidationRow{Line: 2}
+			reconcileInstructionRow(row, tc.instruction, tc.existingReceiver, tc.disbursement)
+
+			assert.Equal(t, tc.wantReceiver, row.Receiver)
+			assert.Equal(t, tc.wantVerification, row.Verification)
+			assert.Equal(t, tc.wantWalletAddress, row.WalletAddress)
+			assert.Equal(t, tc.wantErrors, row.Errors)
+			if tc.existingReceiver != nil {
+				assert.Equal(t, receiver.ID, row.ReceiverID)
+			}
+		})
+	}
+}
+
+func Test_DisbursementManagementService_ValidateInstructions(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	distributionAccount := schema.NewDefaultStellarTransactionAccount("GAAHIL6ZW4QFNLCKALZ3YOIWPP4TXQ7B7J5IU7RLNVGQAV6GFDZHLDTA")
+	disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{Status: data.DraftDisbursementStatus})
+
+	confirmedReceiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{PhoneNumber: "+380445555555"})
+	data.CreateReceiverVerificationFixture(t, ctx, dbConnectionPool, data.ReceiverVerificationInsert{
+		ReceiverID:        confirmedReceiver.ID,
+		VerificationField: data.VerificationTypeDateOfBirth,
+		VerificationValue: "1990-01-01",
+	})
+	_, err = dbConnectionPool.ExecContext(ctx, "UPDATE receiver_verifications SET confirmed_at = NOW() WHERE receiver_id = $1", confirmedReceiver.ID)
+	require.NoError(t, err)
+
+	instructions := []*data.DisbursementInstruction{
+		{Phone: "+380445555555", ID: "1", Amount: "100.5", VerificationValue: "1990-01-02"},
+		{Phone: "+380445555556", ID: "2", Amount: "200", VerificationValue: "1990-01-01"},
+		{Phone: "invalid", ID: "3", Amount: "1", VerificationValue: "1990-01-01"},
+		{Phone: "+380445555556", ID: "4", Amount: "50", VerificationValue: "1990-01-01"},
+	}
+
+	testCases := []struct {
+		name             string
+		availableBalance float64
+		wantSufficient   bool
+	}{
+		{
+			name:             "🎉 the balance is sufficient",
+			availableBalance: 1000,
+			wantSufficient:   true,
+		},
+		{
+			name:             "🔴 the balance is insufficient",
+			availableBalance: 300,
+			wantSufficient:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mDistributionAccountService := mocks.NewMockDistributionAccountService(t)
+			mDistributionAccountService.
+				On("GetBalance", ctx, &distributionAccount, *disbursement.Asset).
+				Return(tc.availableBalance, nil).
+				Once()
+			service := &DisbursementManagementService{
+				Models:                     models,
+				DistributionAccountService: mDistributionAccountService,
+			}
+
+			report, err := service.ValidateInstructions(ctx, disbursement, &distributionAccount, instructions)
+			require.NoError(t, err)
+
+			assert.False(t, report.Valid)
+			assert.Equal(t, InstructionsValidationSummary{
+				TotalRows:              4,
+				ValidRows:              1,
+				InvalidRows:            3,
+				NewReceivers:           2,
+				ExistingReceivers:      1,
+				VerificationMismatches: 1,
+				DuplicateContacts:      1,
+				TotalAmount:            "350.5000000",
+			}, report.Summary)
+			assert.Equal(t, tc.wantSufficient, report.Balance.Sufficient)
+			assert.Equal(t, "0.0000000", report.Balance.TotalPendingAmount)
+
+			require.Len(t, report.Rows, 4)
+			assert.Equal(t, ExistingInstructionReceiverStatus, report.Rows[0].Receiver)
+			assert.Equal(t, confirmedReceiver.ID, report.Rows[0].ReceiverID)
+			assert.Equal(t, MismatchInstructionMatchStatus, report.Rows[0].Verification)
+			assert.False(t, report.Rows[0].Valid)
+			assert.Equal(t, NewInstructionReceiverStatus, report.Rows[1].Receiver)
+			assert.True(t, report.Rows[1].Valid)
+			assert.Contains(t, report.Rows[2].Errors, "line 4 - phone")
+			assert.Empty(t, report.Rows[2].Receiver)
+			assert.Equal(t, 3, report.Rows[3].DuplicateOfLine)
+		})
+	}
+
+	t.Run("🎉 nothing is written", func(t *testing.T) {
+		receivers, err := models.Receiver.GetByContacts(ctx, dbConnectionPool, "+380445555556")
+		require.NoError(t, err)
+		assert.Empty(t, receivers)
+	})
+
+	t.Run("🎉 the balance of path payment disbursements is validated against the estimated send amount", func(t *testing.T) {
+		pathPaymentDisbursement := *disbursement
+		pathPaymentDisbursement.ReceiveAssetCode = "EURC"
+		pathPaymentDisbursement.MaxSlippageBps = 100
+		receiveAsset := data.Asset{Code: "EURC"}
+
+		mDistributionAccountService := mocks.NewMockDistributionAccountService(t)
+		mDistributionAccountService.
+			On("GetBalance", ctx, &distributionAccount, *disbursement.Asset).
+			Return(150.0, nil).
+			Once()
+		mDistributionAccountService.
+			On("EstimatePathPaymentSendMax", ctx, &distributionAccount, *disbursement.Asset, receiveAsset, "200.0000000", 100).
+			Return(202.0, nil).
+			Once()
+		service := &DisbursementManagementService{
+			Models:                     models,
+			DistributionAccountService: mDistributionAccountService,
+		}
+
+		report, err := service.ValidateInstructions(ctx, &pathPaymentDisbursement, &distributionAccount, instructions[1:2])
+		require.NoError(t, err)
+		assert.True(t, report.Valid)
+		require.NotNil(t, report.Balance)
+		assert.False(t, report.Balance.Sufficient)
+	})
+}
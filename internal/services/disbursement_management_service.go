@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/stellar/go/support/log"
 	"golang.org/x/exp/maps"
@@ -49,6 +51,16 @@ var (
 
 	ErrDisbursementStatusCantBeChanged = errors.New("disbursement status can't be changed to the requested status")
 	ErrDisbursementStartedByCreator    = errors.New("disbursement can't be started by its creator")
+
+	ErrDisbursementNotReadyToSchedule   = errors.New("disbursement is not ready to be scheduled")
+	ErrDisbursementNotScheduled         = errors.New("disbursement is not scheduled")
+	ErrDisbursementScheduledStartInPast = errors.New("disbursement scheduled start time must be in the future")
+
+	ErrDisbursementApprovalWorkflowDisabled = errors.New("approval workflow is not enabled for the organization")
+	ErrDisbursementNotReadyToReview         = errors.New("disbursement is not ready to be reviewed")
+	ErrDisbursementReviewedByCreator        = errors.New("disbursement can't be reviewed by its creator")
+	ErrDisbursementApproverRoleNotAllowed   = errors.New("user role is not allowed to approve this disbursement")
+	ErrDisbursementApprovalQuorumNotMet     = errors.New("disbursement does not have the required number of approvals")
 )
 
 type InsufficientBalanceError struct {
@@ -77,6 +89,9 @@ func (s *DisbursementManagementService) AppendUserMetadata(ctx context.Context,
 	users := map[string]*auth.User{}
 	for _, d := range disbursements {
 		for _, entry := range d.StatusHistory {
+			if entry.IsApprovalDecision() {
+				continue
+			}
 			if entry.Status == data.DraftDisbursementStatus || entry.Status == data.StartedDisbursementStatus {
 				if entry.UserID != "" {
 					users[entry.UserID] = nil
@@ -108,7 +123,7 @@ func (s *DisbursementManagementService) AppendUserMetadata(ctx context.Context,
 		}
 
 		for _, entry := range d.StatusHistory {
-			if entry.Status != data.DraftDisbursementStatus && entry.Status != data.StartedDisbursementStatus {
+			if entry.IsApprovalDecision() || (entry.Status != data.DraftDisbursementStatus && entry.Status != data.StartedDisbursementStatus) {
 				continue
 			}
 			userInfo, ok := users[entry.UserID]
@@ -219,18 +234,9 @@ func (s *DisbursementManagementService) StartDisbursement(ctx context.Context, d
 			}
 
 			// 3. Check if approval Workflow is enabled for this organization
-			organization, err := s.Models.Organizations.Get(ctx)
+			err = s.validateApprover(ctx, disbursement, user)
 			if err != nil {
-				return nil, fmt.Errorf("error getting organization: %w", err)
-			}
-
-			if organization.IsApprovalRequired {
-				// check that the user starting the disbursement isn't the same as the one who created it
-				for _, sh := range disbursement.StatusHistory {
-					if sh.UserID == user.ID && (sh.Status == data.DraftDisbursementStatus || sh.Status == data.ReadyDisbursementStatus) {
-						return nil, ErrDisbursementStartedByCreator
-					}
-				}
+				return nil, err
 			}
 
 			// 4. Check if there is enough balance from the distribution wallet for this disbursement along with any pending disbursements
@@ -315,6 +321,283 @@ func (s *DisbursementManagementService) StartDisbursement(ctx context.Context, d
 	return db.RunInTransactionWithPostCommit(ctx, &opts)
 }
 
+// validateApprover checks that, when the approval workflow is enabled for the organization, the user starting or
+// scheduling the disbursement is not the one who created it, and that the disbursement has the approvals required by
+// the organization quorum rules for its amount.
+func (s *DisbursementManagementService) validateApprover(ctx context.Context, disbursement *data.Disbursement, user *auth.User) error {
+	organization, err := s.Models.Organizations.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting organization: %w", err)
+	}
+
+	if !organization.IsApprovalRequired {
+		return nil
+	}
+
+	if isDisbursementCreator(disbursement.StatusHistory, user.ID) {
+		return ErrDisbursementStartedByCreator
+	}
+
+	rule := organization.ApprovalQuorumRules.RuleFor(disbursementTotalAmount(disbursement))
+	if rule == nil {
+		return nil
+	}
+
+	// only the approvals of the active users that currently have the rule role count towards the quorum
+	var approverIDs []string
+	if rule.Role != "" {
+		approvers, err := s.AuthManager.GetActiveUsersWithRole(ctx, rule.Role.String())
+		if err != nil {
+			return fmt.Errorf("getting approvers with role %s: %w", rule.Role, err)
+		}
+		approverIDs = make([]string, 0, len(approvers))
+		for _, approver := range approvers {
+			approverIDs = append(approverIDs, approver.ID)
+		}
+	}
+
+	approvals, err := s.Models.DisbursementApprovals.CountActiveApprovals(ctx, s.Models.DBConnectionPool, disbursement.ID, approverIDs)
+	if err != nil {
+		return fmt.Errorf("counting disbursement approvals: %w", err)
+	}
+	if approvals < rule.RequiredApprovals {
+		return fmt.Errorf("%w: %d of %d approvals", ErrDisbursementApprovalQuorumNotMet, approvals, rule.RequiredApprovals)
+	}
+
+	return nil
+}
+
+// isDisbursementCreator returns whether the user created the disbursement or uploaded its instructions.
+func isDisbursementCreator(statusHistory data.DisbursementStatusHistory, userID string) bool {
+	for _, sh := range statusHistory {
+		if sh.IsApprovalDecision() {
+			continue
+		}
+		if sh.UserID == userID && (sh.Status == data.DraftDisbursementStatus || sh.Status == data.ReadyDisbursementStatus) {
+			return true
+		}
+	}
+	return false
+}
+
+// disbursementTotalAmount returns the total amount of the disbursement, or zero when its statistics weren't loaded.
+func disbursementTotalAmount(disbursement *data.Disbursement) string {
+	if disbursement.DisbursementStats == nil {
+		return "0"
+	}
+	return disbursement.TotalAmount
+}
+
+// ApproveDisbursement records the approval of a READY or SCHEDULED disbursement by the user, counting towards the
+// quorum required to start it.
+func (s *DisbursementManagementService) ApproveDisbursement(ctx context.Context, disbursementID string, user *auth.User, comment string) (*data.DisbursementApproval, error) {
+	return s.reviewDisbursement(ctx, disbursementID, user, data.ApprovedDisbursementApprovalDecision, comment)
+}
+
+// RejectDisbursement records the rejection of a READY or SCHEDULED disbursement by the user, moving it back to DRAFT
+// and invalidating the approvals it had received.
+func (s *DisbursementManagementService) RejectDisbursement(ctx context.Context, disbursementID string, user *auth.User, comment string) (*data.DisbursementApproval, error) {
+	return s.reviewDisbursement(ctx, disbursementID, user, data.RejectedDisbursementApprovalDecision, comment)
+}
+
+func (s *DisbursementManagementService) reviewDisbursement(ctx context.Context, disbursementID string, user *auth.User, decision data.DisbursementApprovalDecision, comment string) (*data.DisbursementApproval, error) {
+	organization, err := s.Models.Organizations.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting organization: %w", err)
+	}
+	if !organization.IsApprovalRequired {
+		return nil, ErrDisbursementApprovalWorkflowDisabled
+	}
+
+	return db.RunInTransactionWithResult(ctx, s.Models.DBConnectionPool, nil, func(dbTx db.DBTransaction) (*data.DisbursementApproval, error) {
+		disbursement, err := s.Models.Disbursements.GetWithStatistics(ctx, disbursementID)
+		if err != nil {
+			if errors.Is(err, data.ErrRecordNotFound) {
+				return nil, ErrDisbursementNotFound
+			}
+			return nil, fmt.Errorf("getting disbursement with id %s: %w", disbursementID, err)
+		}
+
+		if disbursement.Status != data.ReadyDisbursementStatus && disbursement.Status != data.ScheduledDisbursementStatus {
+			return nil, ErrDisbursementNotReadyToReview
+		}
+		if isDisbursementCreator(disbursement.StatusHistory, user.ID) {
+			return nil, ErrDisbursementReviewedByCreator
+		}
+
+		if decision == data.ApprovedDisbursementApprovalDecision {
+			rule := organization.ApprovalQuorumRules.RuleFor(disbursementTotalAmount(disbursement))
+			if rule != nil && rule.Role != "" && !slices.Contains(user.Roles, rule.Role.String()) {
+				return nil, ErrDisbursementApproverRoleNotAllowed
+			}
+		}
+
+		approval, err := s.Models.DisbursementApprovals.Insert(ctx, dbTx, data.DisbursementApprovalInsert{
+			DisbursementID: disbursementID,
+			UserID:         user.ID,
+			Decision:       decision,
+			Comment:        comment,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("inserting disbursement approval: %w", err)
+		}
+
+		toStatus := disbursement.Status
+		if decision == data.RejectedDisbursementApprovalDecision {
+			toStatus = data.DraftDisbursementStatus
+
+			if err = s.Models.DisbursementApprovals.InvalidateAll(ctx, dbTx, disbursementID); err != nil {
+				return nil, fmt.Errorf("invalidating disbursement approvals: %w", err)
+			}
+			if disbursement.Status == data.ScheduledDisbursementStatus {
+				if err = s.Models.Disbursements.UpdateScheduledStartAt(ctx, dbTx, disbursementID, nil); err != nil {
+					return nil, fmt.Errorf("clearing disbursement scheduled start time: %w", err)
+				}
+			}
+		}
+
+		err = s.Models.Disbursements.RecordApprovalDecision(ctx, dbTx, user.ID, disbursementID, decision, disbursement.Status, toStatus)
+		if err != nil {
+			return nil, fmt.Errorf("recording approval decision: %w", err)
+		}
+
+		return approval, nil
+	})
+}
+
+// ScheduleDisbursement schedules a ready disbursement to be started automatically at scheduledStartAt. The same
+// validations done when starting a disbursement are done when scheduling it, and they're repeated when the scheduled
+// start time is reached.
+func (s *DisbursementManagementService) ScheduleDisbursement(ctx context.Context, disbursementID string, user *auth.User, scheduledStartAt time.Time, distributionAccount *schema.TransactionAccount) error {
+	if !scheduledStartAt.After(time.Now()) {
+		return ErrDisbursementScheduledStartInPast
+	}
+
+	return db.RunInTransaction(ctx, s.Models.DBConnectionPool, nil, func(dbTx db.DBTransaction) error {
+		disbursement, err := s.Models.Disbursements.GetWithStatistics(ctx, disbursementID)
+		if err != nil {
+			if errors.Is(err, data.ErrRecordNotFound) {
+				return ErrDisbursementNotFound
+			}
+			return fmt.Errorf("getting disbursement with id %s: %w", disbursementID, err)
+		}
+
+		if !disbursement.Wallet.Enabled {
+			return ErrDisbursementWalletDisabled
+		}
+		if disbursement.Status != data.ReadyDisbursementStatus {
+			return ErrDisbursementNotReadyToSchedule
+		}
+
+		err = s.validateApprover(ctx, disbursement, user)
+		if err != nil {
+			return err
+		}
+
+		err = s.validateBalanceForDisbursement(ctx, dbTx, distributionAccount, disbursement)
+		if err != nil {
+			return fmt.Errorf("validating balance for disbursement: %w", err)
+		}
+
+		err = s.Models.Disbursements.UpdateStatus(ctx, dbTx, user.ID, disbursementID, data.ScheduledDisbursementStatus)
+		if err != nil {
+			return fmt.Errorf("updating disbursement status to scheduled for disbursement with id %s: %w", disbursementID, err)
+		}
+
+		err = s.Models.Disbursements.UpdateScheduledStartAt(ctx, dbTx, disbursementID, &scheduledStartAt)
+		if err != nil {
+			return fmt.Errorf("updating scheduled start time for disbursement with id %s: %w", disbursementID, err)
+		}
+
+		return nil
+	})
+}
+
+// CancelDisbursementSchedule moves a scheduled disbursement back to ready, so it's no longer started automatically.
+func (s *DisbursementManagementService) CancelDisbursementSchedule(ctx context.Context, disbursementID string, user *auth.User) error {
+	return db.RunInTransaction(ctx, s.Models.DBConnectionPool, nil, func(dbTx db.DBTransaction) error {
+		disbursement, err := s.Models.Disbursements.Get(ctx, dbTx, disbursementID)
+		if err != nil {
+			if errors.Is(err, data.ErrRecordNotFound) {
+				return ErrDisbursementNotFound
+			}
+			return fmt.Errorf("getting disbursement with id %s: %w", disbursementID, err)
+		}
+
+		if disbursement.Status != data.ScheduledDisbursementStatus {
+			return ErrDisbursementNotScheduled
+		}
+
+		err = s.Models.Disbursements.UpdateStatus(ctx, dbTx, user.ID, disbursementID, data.ReadyDisbursementStatus)
+		if err != nil {
+			return fmt.Errorf("updating disbursement status to ready for disbursement with id %s: %w", disbursementID, err)
+		}
+
+		err = s.Models.Disbursements.UpdateScheduledStartAt(ctx, dbTx, disbursementID, nil)
+		if err != nil {
+			return fmt.Errorf("clearing scheduled start time for disbursement with id %s: %w", disbursementID, err)
+		}
+
+		return nil
+	})
+}
+
+// StartScheduledDisbursements starts the scheduled disbursements whose start time was reached. Each disbursement is
+// started on behalf of the user who scheduled it, so the approval workflow and the balance validation are applied
+// exactly as if that user had started it. Disbursements that fail to start remain scheduled and are retried in the
+// next execution.
+func (s *DisbursementManagementService) StartScheduledDisbursements(ctx context.Context, distributionAccount *schema.TransactionAccount) error {
+	disbursements, err := s.Models.Disbursements.GetDueScheduled(ctx, s.Models.DBConnectionPool, time.Now())
+	if err != nil {
+		return fmt.Errorf("getting due scheduled disbursements: %w", err)
+	}
+
+	var startErrors []error
+	for _, disbursement := range disbursements {
+		schedulerID, ok := scheduledByUserID(disbursement.StatusHistory)
+		if !ok {
+			startErrors = append(startErrors, fmt.Errorf("finding the user who scheduled disbursement %s", disbursement.ID))
+			continue
+		}
+
+		err = s.StartDisbursement(ctx, disbursement.ID, &auth.User{ID: schedulerID}, distributionAccount)
+		if err != nil {
+			startErrors = append(startErrors, fmt.Errorf("starting scheduled disbursement %s: %w", disbursement.ID, err))
+			continue
+		}
+		log.Ctx(ctx).Infof("Started scheduled disbursement %s", disbursement.ID)
+	}
+
+	if len(startErrors) > 0 {
+		return fmt.Errorf("attempted to start %d scheduled disbursements but failed on %d: %w", len(disbursements), len(startErrors), errors.Join(startErrors...))
+	}
+
+	return nil
+}
+
+// ScheduledDisbursementStarterInterface starts the scheduled disbursements whose start time was reached.
+//
+//go:generate mockery --name=ScheduledDisbursementStarterInterface --case=underscore --structname=MockScheduledDisbursementStarter --filename=scheduled_disbursement_starter.go
+type ScheduledDisbursementStarterInterface interface {
+	StartScheduledDisbursements(ctx context.Context, distributionAccount *schema.TransactionAccount) error
+}
+
+var _ ScheduledDisbursementStarterInterface = (*DisbursementManagementService)(nil)
+
+// scheduledByUserID returns the ID of the user who last scheduled the disbursement. The approvals recorded while the
+// disbursement was scheduled are skipped, since their user is the reviewer.
+func scheduledByUserID(statusHistory data.DisbursementStatusHistory) (string, bool) {
+	for i := len(statusHistory) - 1; i >= 0; i-- {
+		if statusHistory[i].IsApprovalDecision() {
+			continue
+		}
+		if statusHistory[i].Status == data.ScheduledDisbursementStatus {
+			return statusHistory[i].UserID, statusHistory[i].UserID != ""
+		}
+	}
+	return "", false
+}
+
 func (s *DisbursementManagementService) validateBalanceForDisbursement(
 	ctx context.Context,
 	dbTx db.DBTransaction,
@@ -331,56 +614,110 @@ func (s *DisbursementManagementService) validateBalanceForDisbursement(
 			err)
 	}
 
-	disbursementAmount, err := strconv.ParseFloat(disbursement.TotalAmount, 64)
+	disbursementAmount, err := estimateSendAmount(ctx, s.DistributionAccountService, distributionAccount, disbursement, disbursement.TotalAmount)
 	if err != nil {
-		return fmt.Errorf(
-			"cannot convert total amount %s for disbursement id %s into float: %w",
-			disbursement.TotalAmount,
-			disbursement.ID,
-			err,
-		)
+		return fmt.Errorf("getting the amount spent by disbursement %s: %w", disbursement.ID, err)
+	}
+
+	totalPendingAmount, err := getTotalPendingAmount(ctx, s.Models, s.DistributionAccountService, dbTx, distributionAccount, *disbursement.Asset, disbursement.ID)
+	if err != nil {
+		return fmt.Errorf("getting total pending amount: %w", err)
+	}
+
+	if (availableBalance - (disbursementAmount + totalPendingAmount)) < 0 {
+		err = InsufficientBalanceError{
+			DisbursementAsset:   *disbursement.Asset,
+			DistributionAddress: distributionAccount.ID(),
+			DisbursementID:      disbursement.ID,
+			AvailableBalance:    availableBalance,
+			DisbursementAmount:  disbursementAmount,
+			TotalPendingAmount:  totalPendingAmount,
+		}
+		log.Ctx(ctx).Error(err)
+		return err
 	}
+	return err
+}
 
+// estimateSendAmount returns the amount of the disbursement asset spent to pay the given amount. Path payment amounts
+// are denominated in the receive asset, so the amount spent is estimated from the cheapest path found in Horizon,
+// increased by the disbursement's max slippage.
+func estimateSendAmount(ctx context.Context, distributionAccountService DistributionAccountServiceInterface, distributionAccount *schema.TransactionAccount, disbursement *data.Disbursement, amount string) (float64, error) {
+	if disbursement.ReceiveAssetCode == "" {
+		sendAmount, err := strconv.ParseFloat(amount, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot convert amount %s into float: %w", amount, err)
+		}
+		return sendAmount, nil
+	}
+
+	receiveAsset := data.Asset{Code: disbursement.ReceiveAssetCode, Issuer: disbursement.ReceiveAssetIssuer}
+	sendMax, err := distributionAccountService.EstimatePathPaymentSendMax(ctx, distributionAccount, *disbursement.Asset, receiveAsset, amount, disbursement.MaxSlippageBps)
+	if err != nil {
+		return 0, fmt.Errorf("estimating the max amount spent to pay %s %s: %w", amount, disbursement.ReceiveAssetCode, err)
+	}
+	return sendMax, nil
+}
+
+// getTotalPendingAmount returns the amount of the payments in progress that will be paid with the given asset,
+// excluding the payments of excludedDisbursementID. The path payments in progress are quoted once per disbursement, for
+// the sum of their amounts.
+func getTotalPendingAmount(
+	ctx context.Context,
+	models *data.Models,
+	distributionAccountService DistributionAccountServiceInterface,
+	sqlExec db.SQLExecuter,
+	distributionAccount *schema.TransactionAccount,
+	asset data.Asset,
+	excludedDisbursementID string,
+) (float64, error) {
 	totalPendingAmount := 0.0
-	incompletePayments, err := s.Models.Payment.GetAll(ctx, &data.QueryParams{
+	incompletePayments, err := models.Payment.GetAll(ctx, &data.QueryParams{
 		Filters: map[data.FilterKey]interface{}{
 			data.FilterKeyStatus: data.PaymentInProgressStatuses(),
 		},
-	}, dbTx, data.QueryTypeSelectAll)
+	}, sqlExec, data.QueryTypeSelectAll)
 	if err != nil {
-		return fmt.Errorf("cannot retrieve incomplete payments: %w", err)
+		return 0, fmt.Errorf("cannot retrieve incomplete payments: %w", err)
 	}
 
+	pathPaymentDisbursements := map[string]*data.Disbursement{}
+	pathPaymentAmounts := map[string]float64{}
 	for _, ip := range incompletePayments {
-		if ip.Disbursement.ID == disbursement.ID || !ip.Asset.Equals(*disbursement.Asset) {
+		if ip.Disbursement.ID == excludedDisbursementID || !ip.Asset.Equals(asset) {
 			continue
 		}
 
 		paymentAmount, parsePaymentAmountErr := strconv.ParseFloat(ip.Amount, 64)
 		if parsePaymentAmountErr != nil {
-			return fmt.Errorf(
+			return 0, fmt.Errorf(
 				"cannot convert amount %s for paymment id %s into float: %w",
 				ip.Amount,
 				ip.ID,
-				err,
+				parsePaymentAmountErr,
 			)
 		}
+
+		if ip.Disbursement.ReceiveAssetCode != "" {
+			pathPaymentDisbursements[ip.Disbursement.ID] = ip.Disbursement
+			pathPaymentAmounts[ip.Disbursement.ID] += paymentAmount
+			continue
+		}
 		totalPendingAmount += paymentAmount
 	}
 
-	if (availableBalance - (disbursementAmount + totalPendingAmount)) < 0 {
-		err = InsufficientBalanceError{
-			DisbursementAsset:   *disbursement.Asset,
-			DistributionAddress: distributionAccount.ID(),
-			DisbursementID:      disbursement.ID,
-			AvailableBalance:    availableBalance,
-			DisbursementAmount:  disbursementAmount,
-			TotalPendingAmount:  totalPendingAmount,
+	for disbursementID, pendingDisbursement := range pathPaymentDisbursements {
+		pendingDisbursement := *pendingDisbursement
+		pendingDisbursement.Asset = &asset
+		receiveAmount := strconv.FormatFloat(pathPaymentAmounts[disbursementID], 'f', stellarAmountPrecision, 64)
+		sendMax, sendAmountErr := estimateSendAmount(ctx, distributionAccountService, distributionAccount, &pendingDisbursement, receiveAmount)
+		if sendAmountErr != nil {
+			return 0, fmt.Errorf("getting the amount spent by the pending payments of disbursement %s: %w", disbursementID, sendAmountErr)
 		}
-		log.Ctx(ctx).Error(err)
-		return err
+		totalPendingAmount += sendMax
 	}
-	return err
+
+	return totalPendingAmount, nil
 }
 
 // PauseDisbursement pauses a disbursement and all its payments.
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stellar/go/clients/horizonclient"
 	"github.com/stellar/go/protocols/horizon"
@@ -1500,3 +1501,564 @@ func Test_DisbursementManagementService_validateBalanceForDisbursement(t *testin
 		})
 	}
 }
+
+func Test_DisbursementManagementService_validateBalanceForDisbursement_pathPayments(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, outerErr := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, outerErr)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+
+	models, outerErr := data.NewModels(dbConnectionPool)
+	require.NoError(t, outerErr)
+	asset := data.CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVV")
+	receiveAsset := data.Asset{Code: "BRL", Issuer: "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG"}
+	wallet := data.CreateWalletFixture(t, ctx, dbConnectionPool, "wallet1", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+	receiverReady := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+	rwReady := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiverReady.ID, wallet.ID, data.ReadyReceiversWalletStatus)
+	disbursementOld := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Wallet:             wallet,
+		Status:             data.StartedDisbursementStatus,
+		Asset:              asset,
+		ReceiveAssetCode:   receiveAsset.Code,
+		ReceiveAssetIssuer: receiveAsset.Issuer,
+		MaxSlippageBps:     200,
+	})
+	for _, amount := range []string{"10", "15"} {
+		_ = data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+			ReceiverWallet: rwReady,
+			Disbursement:   disbursementOld,
+			Asset:          *asset,
+			Amount:         amount,
+			Status:         data.PendingPaymentStatus,
+		})
+	}
+	disbursementNew := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Wallet:             wallet,
+		Status:             data.ReadyDisbursementStatus,
+		Asset:              asset,
+		ReceiveAssetCode:   receiveAsset.Code,
+		ReceiveAssetIssuer: receiveAsset.Issuer,
+		MaxSlippageBps:     100,
+	})
+	_ = data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+		ReceiverWallet: rwReady,
+		Disbursement:   disbursementNew,
+		Asset:          *asset,
+		Amount:         "90",
+		Status:         data.DraftPaymentStatus,
+	})
+	disbursementNew, err := models.Disbursements.GetWithStatistics(ctx, disbursementNew.ID)
+	require.NoError(t, err)
+
+	distributionAccount := schema.NewDefaultStellarTransactionAccount("GAAHIL6ZW4QFNLCKALZ3YOIWPP4TXQ7B7J5IU7RLNVGQAV6GFDZHLDTA")
+
+	testCases := []struct {
+		name                string
+		prepareMocksFn      func(mDistAccService *mocks.MockDistributionAccountService)
+		expectedErrContains string
+	}{
+		{
+			name: "🔴 returns an error if the send amount can't be estimated",
+			prepareMocksFn: func(mDistAccService *mocks.MockDistributionAccountService) {
+				mDistAccService.
+					On("GetBalance", ctx, &distributionAccount, *asset).
+					Return(1000.0, nil).
+					Once()
+				mDistAccService.
+					On("EstimatePathPaymentSendMax", ctx, &distributionAccount, *asset, receiveAsset, "90.00", 100).
+					Return(0.0, errors.New("no path found")).
+					Once()
+			},
+			expectedErrContains: fmt.Sprintf("getting the amount spent by disbursement %s: estimating the max amount spent to pay 90.00 BRL: no path found", disbursementNew.ID),
+		},
+		{
+			name: "🔴 insufficient balance for the estimated send amounts",
+			prepareMocksFn: func(mDistAccService *mocks.MockDistributionAccountService) {
+				mDistAccService.
+					On("GetBalance", ctx, &distributionAccount, *asset).
+					Return(25.0, nil).
+					Once()
+				mDistAccService.
+					On("EstimatePathPaymentSendMax", ctx, &distributionAccount, *asset, receiveAsset, "90.00", 100).
+					Return(18.18, nil).
+					Once()
+				mDistAccService.
+					On("EstimatePathPaymentSendMax", ctx, &distributionAccount, *asset, receiveAsset, "25.0000000", 200).
+					Return(10.2, nil).
+					Once()
+			},
+			expectedErrContains: InsufficientBalanceError{
+				DisbursementAsset:   *asset,
+				DistributionAddress: distributionAccount.ID(),
+				DisbursementID:      disbursementNew.ID,
+				AvailableBalance:    25.0,
+				DisbursementAmount:  18.18,
+				TotalPendingAmount:  10.2,
+			}.Error(),
+		},
+		{
+			name: "🟢 successfully validates the balance for the estimated send amounts",
+			prepareMocksFn: func(mDistAccService *mocks.MockDistributionAccountService) {
+				mDistAccService.
+					On("GetBalance", ctx, &distributionAccount, *asset).
+					Return(30.0, nil).
+					Once()
+				mDistAccService.
+					On("EstimatePathPaymentSendMax", ctx, &distributionAccount, *asset, receiveAsset, "90.00", 100).
+					Return(18.18, nil).
+					Once()
+				mDistAccService.
+					On("EstimatePathPaymentSendMax", ctx, &distributionAccount, *asset, receiveAsset, "25.0000000", 200).
+					Return(10.2, nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dbTx, err := dbConnectionPool.BeginTxx(ctx, nil)
+			require.NoError(t, err)
+			defer func() {
+				err = dbTx.Rollback()
+				require.NoError(t, err)
+			}()
+
+			mDistAccService := mocks.NewMockDistributionAccountService(t)
+			tc.prepareMocksFn(mDistAccService)
+			svc := &DisbursementManagementService{
+				Models:                     models,
+				DistributionAccountService: mDistAccService,
+			}
+
+			err = svc.validateBalanceForDisbursement(ctx, dbTx, &distributionAccount, disbursementNew)
+			if tc.expectedErrContains != "" {
+				require.ErrorContains(t, err, tc.expectedErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_DisbursementManagementService_ScheduleDisbursement(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	asset := data.CreateAssetFixture(t, ctx, dbConnectionPool, assets.EURCAssetCode, assets.EURCAssetIssuerTestnet)
+	wallet := data.CreateDefaultWalletFixture(t, ctx, dbConnectionPool)
+
+	distributionAccPubKey := "GAAHIL6ZW4QFNLCKALZ3YOIWPP4TXQ7B7J5IU7RLNVGQAV6GFDZHLDTA"
+	distributionAcc := schema.NewDefaultStellarTransactionAccount(distributionAccPubKey)
+
+	ownerUser := &auth.User{ID: "owner-user", Email: "owner@test.com"}
+	financialUser := &auth.User{ID: "financial-user", Email: "financial@test.com"}
+	scheduledStartAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	newService := func(t *testing.T, expectBalanceCheck bool) *DisbursementManagementService {
+		mHorizonClient := &horizonclient.MockClient{}
+		t.Cleanup(func() { mHorizonClient.AssertExpectations(t) })
+		if expectBalanceCheck {
+			mHorizonClient.
+				On("AccountDetail", horizonclient.AccountRequest{AccountID: distributionAccPubKey}).
+				Return(horizon.Account{
+					Balances: []horizon.Balance{
+						{Balance: "10000000", Asset: base.Asset{Code: asset.Code, Issuer: asset.Issuer}},
+					},
+				}, nil).
+				Once()
+		}
+		distAccSvc, err := NewDistributionAccountService(DistributionAccountServiceOptions{
+			HorizonClient: mHorizonClient,
+			CircleService: &circle.Service{},
+			NetworkType:   utils.TestnetNetworkType,
+		})
+		require.NoError(t, err)
+		return &DisbursementManagementService{
+			Models:                     models,
+			DistributionAccountService: distAccSvc,
+		}
+	}
+
+	createReadyDisbursement := func(t *testing.T) *data.Disbursement {
+		return data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+			Name:   "ready disbursement",
+			Status: data.ReadyDisbursementStatus,
+			Asset:  asset,
+			Wallet: wallet,
+			StatusHistory: []data.DisbursementStatusHistoryEntry{
+				{UserID: ownerUser.ID, Status: data.DraftDisbursementStatus},
+				{UserID: ownerUser.ID, Status: data.ReadyDisbursementStatus},
+			},
+		})
+	}
+
+	t.Run("scheduled start time in the past", func(t *testing.T) {
+		defer data.DeleteAllDisbursementFixtures(t, ctx, dbConnectionPool)
+		disbursement := createReadyDisbursement(t)
+
+		err := newService(t, false).ScheduleDisbursement(ctx, disbursement.ID, ownerUser, time.Now().Add(-time.Minute), &distributionAcc)
+		require.ErrorIs(t, err, ErrDisbursementScheduledStartInPast)
+	})
+
+	t.Run("disbursement not found", func(t *testing.T) {
+		err := newService(t, false).ScheduleDisbursement(ctx, "not-found", ownerUser, scheduledStartAt, &distributionAcc)
+		require.ErrorIs(t, err, ErrDisbursementNotFound)
+	})
+
+	t.Run("disbursement not ready", func(t *testing.T) {
+		defer data.DeleteAllDisbursementFixtures(t, ctx, dbConnectionPool)
+		disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+			Status: data.DraftDisbursementStatus,
+			Asset:  asset,
+			Wallet: wallet,
+		})
+
+		err := newService(t, false).ScheduleDisbursement(ctx, disbursement.ID, ownerUser, scheduledStartAt, &distributionAcc)
+		require.ErrorIs(t, err, ErrDisbursementNotReadyToSchedule)
+	})
+
+	t.Run("approval workflow rejects the creator", func(t *testing.T) {
+		defer data.DeleteAllDisbursementFixtures(t, ctx, dbConnectionPool)
+		data.EnableDisbursementApproval(t, ctx, models.Organizations)
+		defer data.DisableDisbursementApproval(t, ctx, models.Organizations)
+		disbursement := createReadyDisbursement(t)
+
+		err := newService(t, false).ScheduleDisbursement(ctx, disbursement.ID, ownerUser, scheduledStartAt, &distributionAcc)
+		require.ErrorIs(t, err, ErrDisbursementStartedByCreator)
+	})
+
+	t.Run("🎉 successfully schedules and cancels the schedule of a disbursement", func(t *testing.T) {
+		defer data.DeleteAllDisbursementFixtures(t, ctx, dbConnectionPool)
+		data.EnableDisbursementApproval(t, ctx, models.Organizations)
+		defer data.DisableDisbursementApproval(t, ctx, models.Organizations)
+		disbursement := createReadyDisbursement(t)
+		service := newService(t, true)
+
+		err := service.ScheduleDisbursement(ctx, disbursement.ID, financialUser, scheduledStartAt, &distributionAcc)
+		require.NoError(t, err)
+
+		scheduledDisbursement, err := models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.ScheduledDisbursementStatus, scheduledDisbursement.Status)
+		require.NotNil(t, scheduledDisbursement.ScheduledStartAt)
+		assert.True(t, scheduledStartAt.Equal(*scheduledDisbursement.ScheduledStartAt))
+		assert.Equal(t, financialUser.ID, scheduledDisbursement.StatusHistory[2].UserID)
+
+		err = service.CancelDisbursementSchedule(ctx, disbursement.ID, financialUser)
+		require.NoError(t, err)
+
+		canceledDisbursement, err := models.Disbursements.Get(ctx, dbConnectionPool, disbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.ReadyDisbursementStatus, canceledDisbursement.Status)
+		assert.Nil(t, canceledDisbursement.ScheduledStartAt)
+
+		err = service.CancelDisbursementSchedule(ctx, disbursement.ID, financialUser)
+		require.ErrorIs(t, err, ErrDisbursementNotScheduled)
+	})
+}
+
+func Test_DisbursementManagementService_StartScheduledDisbursements(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	tnt := tenant.Tenant{ID: "tenant-id"}
+	ctx = tenant.SaveTenantInContext(ctx, &tnt)
+
+	asset := data.CreateAssetFixture(t, ctx, dbConnectionPool, assets.EURCAssetCode, assets.EURCAssetIssuerTestnet)
+	wallet := data.CreateDefaultWalletFixture(t, ctx, dbConnectionPool)
+
+	distributionAccPubKey := "GAAHIL6ZW4QFNLCKALZ3YOIWPP4TXQ7B7J5IU7RLNVGQAV6GFDZHLDTA"
+	distributionAcc := schema.NewDefaultStellarTransactionAccount(distributionAccPubKey)
+
+	pastStartAt := time.Now().Add(-time.Minute)
+	futureStartAt := time.Now().Add(time.Hour)
+	statusHistory := []data.DisbursementStatusHistoryEntry{
+		{UserID: "owner-user", Status: data.DraftDisbursementStatus},
+		{UserID: "owner-user", Status: data.ReadyDisbursementStatus},
+		{UserID: "financial-user", Status: data.ScheduledDisbursementStatus},
+	}
+	dueDisbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Name:             "due disbursement",
+		Status:           data.ScheduledDisbursementStatus,
+		ScheduledStartAt: &pastStartAt,
+		Asset:            asset,
+		Wallet:           wallet,
+		StatusHistory:    statusHistory,
+	})
+	notDueDisbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Name:             "not due disbursement",
+		Status:           data.ScheduledDisbursementStatus,
+		ScheduledStartAt: &futureStartAt,
+		Asset:            asset,
+		Wallet:           wallet,
+		StatusHistory:    statusHistory,
+	})
+
+	receiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+	rwRegistered := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, data.RegisteredReceiversWalletStatus)
+	data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+		ReceiverWallet: rwRegistered,
+		Disbursement:   dueDisbursement,
+		Asset:          *asset,
+		Amount:         "100",
+		Status:         data.DraftPaymentStatus,
+	})
+
+	data.EnableDisbursementApproval(t, ctx, models.Organizations)
+	defer data.DisableDisbursementApproval(t, ctx, models.Organizations)
+
+	mHorizonClient := &horizonclient.MockClient{}
+	defer mHorizonClient.AssertExpectations(t)
+	mHorizonClient.
+		On("AccountDetail", horizonclient.AccountRequest{AccountID: distributionAccPubKey}).
+		Return(horizon.Account{
+			Balances: []horizon.Balance{
+				{Balance: "10000000", Asset: base.Asset{Code: asset.Code, Issuer: asset.Issuer}},
+			},
+		}, nil).
+		Once()
+	distAccSvc, err := NewDistributionAccountService(DistributionAccountServiceOptions{
+		HorizonClient: mHorizonClient,
+		CircleService: &circle.Service{},
+		NetworkType:   utils.TestnetNetworkType,
+	})
+	require.NoError(t, err)
+
+	mEventProducer := events.NewMockProducer(t)
+	mEventProducer.
+		On("WriteMessages", ctx, mock.AnythingOfType("[]events.Message")).
+		Return(nil).
+		Once()
+
+	service := &DisbursementManagementService{
+		Models:                     models,
+		EventProducer:              mEventProducer,
+		DistributionAccountService: distAccSvc,
+	}
+
+	err = service.StartScheduledDisbursements(ctx, &distributionAcc)
+	require.NoError(t, err)
+
+	startedDisbursement, err := models.Disbursements.Get(ctx, dbConnectionPool, dueDisbursement.ID)
+	require.NoError(t, err)
+	assert.Equal(t, data.StartedDisbursementStatus, startedDisbursement.Status)
+	assert.Equal(t, "financial-user", startedDisbursement.StatusHistory[3].UserID)
+
+	stillScheduledDisbursement, err := models.Disbursements.Get(ctx, dbConnectionPool, notDueDisbursement.ID)
+	require.NoError(t, err)
+	assert.Equal(t, data.ScheduledDisbursementStatus, stillScheduledDisbursement.Status)
+}
+
+func Test_scheduledByUserID(t *testing.T) {
+	userID, ok := scheduledByUserID(data.DisbursementStatusHistory{
+		{UserID: "owner-user", Status: data.DraftDisbursementStatus},
+		{UserID: "first-scheduler", Status: data.ScheduledDisbursementStatus},
+		{UserID: "owner-user", Status: data.ReadyDisbursementStatus},
+		{UserID: "second-scheduler", Status: data.ScheduledDisbursementStatus},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "second-scheduler", userID)
+
+	userID, ok = scheduledByUserID(data.DisbursementStatusHistory{
+		{UserID: "owner-user", Status: data.ReadyDisbursementStatus},
+		{UserID: "scheduler-user", Status: data.ScheduledDisbursementStatus},
+		{UserID: "approver-user", Status: data.ScheduledDisbursementStatus, ApprovalDecision: data.ApprovedDisbursementApprovalDecision},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "scheduler-user", userID)
+
+	_, ok = scheduledByUserID(data.DisbursementStatusHistory{
+		{UserID: "owner-user", Status: data.ReadyDisbursementStatus},
+	})
+	assert.False(t, ok)
+}
+
+func Test_DisbursementManagementService_ReviewDisbursement(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	passwordEncrypter := auth.NewDefaultPasswordEncrypter()
+	// the approvers are stored as auth users, since only the approvals of active users with the rule role count
+	service := DisbursementManagementService{
+		Models:      models,
+		AuthManager: auth.NewAuthManager(auth.WithDefaultAuthenticatorOption(dbConnectionPool, passwordEncrypter, 0)),
+	}
+	createUser := func(role data.UserRole) *auth.User {
+		return auth.CreateRandomAuthUserFixture(t, ctx, dbConnectionPool, passwordEncrypter, false, role.String()).ToUser()
+	}
+	creator := createUser(data.FinancialControllerUserRole)
+	owner := createUser(data.OwnerUserRole)
+	controller1 := createUser(data.FinancialControllerUserRole)
+	controller2 := createUser(data.FinancialControllerUserRole)
+	controller3 := createUser(data.FinancialControllerUserRole)
+
+	createDisbursement := func(t *testing.T, status data.DisbursementStatus) *data.Disbursement {
+		statusHistory := []data.DisbursementStatusHistoryEntry{
+			{Status: data.DraftDisbursementStatus, UserID: creator.ID},
+		}
+		if status != data.DraftDisbursementStatus {
+			statusHistory = append(statusHistory, data.DisbursementStatusHistoryEntry{Status: data.ReadyDisbursementStatus, UserID: creator.ID})
+		}
+		return data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+			Status:        status,
+			StatusHistory: statusHistory,
+		})
+	}
+
+	readyDisbursement := createDisbursement(t, data.ReadyDisbursementStatus)
+
+	t.Run("returns an error if the approval workflow is disabled", func(t *testing.T) {
+		_, err := service.ApproveDisbursement(ctx, readyDisbursement.ID, controller1, "")
+		require.ErrorIs(t, err, ErrDisbursementApprovalWorkflowDisabled)
+	})
+
+	isApprovalRequired := true
+	rules := data.ApprovalQuorumRules{{MinAmount: "0", RequiredApprovals: 2, Role: data.FinancialControllerUserRole}}
+	err = models.Organizations.Update(ctx, &data.OrganizationUpdate{IsApprovalRequired: &isApprovalRequired, ApprovalQuorumRules: &rules})
+	require.NoError(t, err)
+
+	t.Run("returns an error if the disbursement doesn't exist", func(t *testing.T) {
+		_, err := service.ApproveDisbursement(ctx, "not-found-id", controller1, "")
+		require.ErrorIs(t, err, ErrDisbursementNotFound)
+	})
+
+	t.Run("returns an error if the disbursement is not READY or SCHEDULED", func(t *testing.T) {
+		draftDisbursement := createDisbursement(t, data.DraftDisbursementStatus)
+		_, err := service.ApproveDisbursement(ctx, draftDisbursement.ID, controller1, "")
+		require.ErrorIs(t, err, ErrDisbursementNotReadyToReview)
+	})
+
+	t.Run("returns an error if the disbursement is reviewed by its creator", func(t *testing.T) {
+		_, err := service.ApproveDisbursement(ctx, readyDisbursement.ID, creator, "")
+		require.ErrorIs(t, err, ErrDisbursementReviewedByCreator)
+	})
+
+	t.Run("returns an error if the approver doesn't have the role required by the quorum rule", func(t *testing.T) {
+		_, err := service.ApproveDisbursement(ctx, readyDisbursement.ID, owner, "")
+		require.ErrorIs(t, err, ErrDisbursementApproverRoleNotAllowed)
+	})
+
+	t.Run("🎉 collects approvals until the quorum is met", func(t *testing.T) {
+		err := service.validateApprover(ctx, readyDisbursement, owner)
+		require.ErrorIs(t, err, ErrDisbursementApprovalQuorumNotMet)
+		assert.EqualError(t, err, "disbursement does not have the required number of approvals: 0 of 2 approvals")
+
+		approval, err := service.ApproveDisbursement(ctx, readyDisbursement.ID, controller1, "looks good")
+		require.NoError(t, err)
+		assert.Equal(t, data.ApprovedDisbursementApprovalDecision, approval.Decision)
+		assert.Equal(t, controller1.ID, approval.UserID)
+		assert.Equal(t, "looks good", approval.Comment)
+
+		_, err = service.ApproveDisbursement(ctx, readyDisbursement.ID, controller1, "")
+		require.ErrorIs(t, err, data.ErrDisbursementAlreadyReviewedByUser)
+
+		err = service.validateApprover(ctx, readyDisbursement, owner)
+		require.ErrorIs(t, err, ErrDisbursementApprovalQuorumNotMet)
+
+		_, err = service.ApproveDisbursement(ctx, readyDisbursement.ID, controller2, "")
+		require.NoError(t, err)
+
+		err = service.validateApprover(ctx, readyDisbursement, owner)
+		require.NoError(t, err)
+
+		disbursement, err := models.Disbursements.Get(ctx, dbConnectionPool, readyDisbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.ReadyDisbursementStatus, disbursement.Status)
+		require.Len(t, disbursement.StatusHistory, 4)
+		assert.Equal(t, controller2.ID, disbursement.StatusHistory[3].UserID)
+		assert.Equal(t, data.ReadyDisbursementStatus, disbursement.StatusHistory[3].Status)
+		assert.Equal(t, data.ApprovedDisbursementApprovalDecision, disbursement.StatusHistory[3].ApprovalDecision)
+
+		// the approvers aren't taken as the disbursement creators
+		assert.NoError(t, service.validateApprover(ctx, disbursement, controller1))
+	})
+
+	t.Run("🎉 the approvals of deactivated users don't count towards the quorum", func(t *testing.T) {
+		_, err := dbConnectionPool.ExecContext(ctx, "UPDATE auth_users SET is_active = false WHERE id = $1", controller2.ID)
+		require.NoError(t, err)
+
+		err = service.validateApprover(ctx, readyDisbursement, owner)
+		require.ErrorIs(t, err, ErrDisbursementApprovalQuorumNotMet)
+		assert.EqualError(t, err, "disbursement does not have the required number of approvals: 1 of 2 approvals")
+
+		_, err = dbConnectionPool.ExecContext(ctx, "UPDATE auth_users SET is_active = true WHERE id = $1", controller2.ID)
+		require.NoError(t, err)
+
+		err = service.validateApprover(ctx, readyDisbursement, owner)
+		require.NoError(t, err)
+	})
+
+	t.Run("🎉 rejecting moves the disbursement back to DRAFT and invalidates its approvals", func(t *testing.T) {
+		approval, err := service.RejectDisbursement(ctx, readyDisbursement.ID, controller3, "wrong amounts")
+		require.NoError(t, err)
+		assert.Equal(t, data.RejectedDisbursementApprovalDecision, approval.Decision)
+
+		disbursement, err := models.Disbursements.Get(ctx, dbConnectionPool, readyDisbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.DraftDisbursementStatus, disbursement.Status)
+		lastEntry := disbursement.StatusHistory[len(disbursement.StatusHistory)-1]
+		assert.Equal(t, controller3.ID, lastEntry.UserID)
+		assert.Equal(t, data.RejectedDisbursementApprovalDecision, lastEntry.ApprovalDecision)
+
+		count, err := models.DisbursementApprovals.CountActiveApprovals(ctx, dbConnectionPool, readyDisbursement.ID, nil)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		// the user who rejected the disbursement isn't taken as its creator
+		assert.False(t, isDisbursementCreator(disbursement.StatusHistory, controller3.ID))
+	})
+
+	t.Run("🎉 rejecting a SCHEDULED disbursement clears its schedule", func(t *testing.T) {
+		scheduledDisbursement := createDisbursement(t, data.ScheduledDisbursementStatus)
+		scheduledStartAt := time.Now().Add(time.Hour)
+		err := models.Disbursements.UpdateScheduledStartAt(ctx, dbConnectionPool, scheduledDisbursement.ID, &scheduledStartAt)
+		require.NoError(t, err)
+
+		_, err = service.RejectDisbursement(ctx, scheduledDisbursement.ID, controller1, "not this month")
+		require.NoError(t, err)
+
+		disbursement, err := models.Disbursements.Get(ctx, dbConnectionPool, scheduledDisbursement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.DraftDisbursementStatus, disbursement.Status)
+		assert.Nil(t, disbursement.ScheduledStartAt)
+	})
+}
+
+func Test_isDisbursementCreator(t *testing.T) {
+	statusHistory := data.DisbursementStatusHistory{
+		{UserID: "creator-user", Status: data.DraftDisbursementStatus},
+		{UserID: "uploader-user", Status: data.ReadyDisbursementStatus},
+		{UserID: "approver-user", Status: data.ReadyDisbursementStatus, ApprovalDecision: data.ApprovedDisbursementApprovalDecision},
+		{UserID: "rejecter-user", Status: data.DraftDisbursementStatus, ApprovalDecision: data.RejectedDisbursementApprovalDecision},
+	}
+
+	assert.True(t, isDisbursementCreator(statusHistory, "creator-user"))
+	assert.True(t, isDisbursementCreator(statusHistory, "uploader-user"))
+	assert.False(t, isDisbursementCreator(statusHistory, "approver-user"))
+	assert.False(t, isDisbursementCreator(statusHistory, "rejecter-user"))
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+//go:generate mockery --name=DisbursementTemplateServiceInterface --case=underscore --structname=MockDisbursementTemplateService --filename=disbursement_template_service.go
+type DisbursementTemplateServiceInterface interface {
+	CreateDueDisbursements(ctx context.Context) error
+}
+
+// DisbursementTemplateService clones recurring disbursement templates into new disbursements.
+type DisbursementTemplateService struct {
+	Models *data.Models
+}
+
+var _ DisbursementTemplateServiceInterface = (*DisbursementTemplateService)(nil)
+
+var ErrDisbursementTemplateScheduleNeverRuns = errors.New("disbursement template schedule has no upcoming occurrences")
+
+// NextDisbursementTemplateRunAt returns the next occurrence of a template schedule after the given time. The schedule
+// is evaluated in the organization's timezone, in the "+02:00" format.
+func NextDisbursementTemplateRunAt(schedule, timezoneUTCOffset string, after time.Time) (time.Time, error) {
+	cronSchedule, err := utils.ParseCronSchedule(schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing schedule: %w", err)
+	}
+
+	loc, err := utils.ParseUTCOffset(timezoneUTCOffset)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing organization timezone: %w", err)
+	}
+
+	next := cronSchedule.Next(after.In(loc))
+	if next.IsZero() {
+		return time.Time{}, ErrDisbursementTemplateScheduleNeverRuns
+	}
+
+	return next, nil
+}
+
+// CreateDueDisbursements creates a disbursement for each active template whose next run time was reached, processing
+// the template instructions the same way they're processed when uploaded to a disbursement. Templates are advanced to
+// their next occurrence after now, so occurrences missed while the job wasn't running are collapsed into a single one.
+func (s *DisbursementTemplateService) CreateDueDisbursements(ctx context.Context) error {
+	organization, err := s.Models.Organizations.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("getting organization: %w", err)
+	}
+
+	now := time.Now()
+	templates, err := s.Models.DisbursementTemplates.GetDue(ctx, s.Models.DBConnectionPool, now)
+	if err != nil {
+		return fmt.Errorf("getting due disbursement templates: %w", err)
+	}
+
+	var templateErrors []error
+	for _, template := range templates {
+		err = s.createDisbursementFromTemplate(ctx, template, organization.TimezoneUTCOffset)
+		if err != nil {
+			templateErrors = append(templateErrors, fmt.Errorf("creating disbursement from template %s: %w", template.ID, err))
+			continue
+		}
+
+		update := data.DisbursementTemplateUpdate{LastRunAt: now}
+		update.NextRunAt, err = NextDisbursementTemplateRunAt(template.Schedule, organization.TimezoneUTCOffset, now)
+		if errors.Is(err, ErrDisbursementTemplateScheduleNeverRuns) {
+			log.Ctx(ctx).Warnf("Pausing disbursement template %s because its schedule %q has no upcoming occurrences", template.ID, template.Schedule)
+			update.Status = data.PausedDisbursementTemplateStatus
+		} else if err != nil {
+			templateErrors = append(templateErrors, fmt.Errorf("calculating next run of template %s: %w", template.ID, err))
+			continue
+		}
+
+		_, err = s.Models.DisbursementTemplates.Update(ctx, s.Models.DBConnectionPool, template.ID, update)
+		if err != nil {
+			templateErrors = append(templateErrors, fmt.Errorf("advancing template %s: %w", template.ID, err))
+			continue
+		}
+	}
+
+	if len(templateErrors) > 0 {
+		return fmt.Errorf("attempted to process %d disbursement templates but failed on %d: %w", len(templates), len(templateErrors), errors.Join(templateErrors...))
+	}
+
+	return nil
+}
+
+// createDisbursementFromTemplate creates the disbursement of the template's current occurrence. The disbursement name
+// includes the occurrence time, so an occurrence that was already created isn't created again.
+func (s *DisbursementTemplateService) createDisbursementFromTemplate(ctx context.Context, template *data.DisbursementTemplate, timezoneUTCOffset string) error {
+	if !template.Wallet.Enabled {
+		return ErrDisbursementWalletDisabled
+	}
+
+	loc, err := utils.ParseUTCOffset(timezoneUTCOffset)
+	if err != nil {
+		return fmt.Errorf("parsing organization timezone: %w", err)
+	}
+
+	disbursement := &data.Disbursement{
+		Name:                                fmt.Sprintf("%s - %s", template.Name, template.NextRunAt.In(loc).Format("2006-01-02 15:04")),
+		Wallet:                              template.Wallet,
+		Asset:                               template.Asset,
+		VerificationField:                   template.VerificationField,
+		VerificationQuestion:                template.VerificationQuestion,
+		RegistrationContactType:             template.RegistrationContactType,
+		ReceiverRegistrationMessageTemplate: template.ReceiverRegistrationMessageTemplate,
+		Status:                              data.DraftDisbursementStatus,
+		StatusHistory: []data.DisbursementStatusHistoryEntry{{
+			Timestamp: time.Now(),
+			Status:    data.DraftDisbursementStatus,
+			UserID:    template.CreatedBy,
+		}},
+	}
+	disbursement.ID, err = s.Models.Disbursements.Insert(ctx, disbursement)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordAlreadyExists) {
+			log.Ctx(ctx).Warnf("Disbursement %q of template %s already exists, skipping...", disbursement.Name, template.ID)
+			return nil
+		}
+		return fmt.Errorf("inserting disbursement: %w", err)
+	}
+
+	err = s.Models.DisbursementInstructions.ProcessAll(ctx, data.DisbursementInstructionsOpts{
+		UserID:       template.CreatedBy,
+		Instructions: template.Instructions,
+		Disbursement: disbursement,
+		DisbursementUpdate: &data.DisbursementUpdate{
+			ID:          disbursement.ID,
+			FileName:    template.FileName,
+			FileContent: template.FileContent,
+		},
+		MaxNumberOfInstructions: data.MaxInstructionsPerDisbursement,
+		KeepDraft:               template.DisbursementStatus == data.DraftDisbursementStatus,
+	})
+	if err != nil {
+		// Remove the empty disbursement, so the occurrence is retried in the next execution.
+		if deleteErr := s.Models.Disbursements.Delete(ctx, s.Models.DBConnectionPool, disbursement.ID); deleteErr != nil {
+			log.Ctx(ctx).Errorf("deleting disbursement %s after failing to process its instructions: %v", disbursement.ID, deleteErr)
+		}
+		return fmt.Errorf("processing instructions: %w", err)
+	}
+
+	log.Ctx(ctx).Infof("Created disbursement %s from template %s", disbursement.ID, template.ID)
+	return nil
+}
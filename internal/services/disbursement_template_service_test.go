@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+)
+
+func Test_NextDisbursementTemplateRunAt(t *testing.T) {
+	after := time.Date(2025, time.January, 15, 10, 30, 0, 0, time.UTC)
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		_, err := NextDisbursementTemplateRunAt("every day", "", after)
+		assert.ErrorContains(t, err, "parsing schedule")
+	})
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		_, err := NextDisbursementTemplateRunAt("@daily", "UTC+2", after)
+		assert.ErrorContains(t, err, "parsing organization timezone")
+	})
+
+	t.Run("schedule never runs", func(t *testing.T) {
+		_, err := NextDisbursementTemplateRunAt("0 0 30 2 *", "", after)
+		assert.ErrorIs(t, err, ErrDisbursementTemplateScheduleNeverRuns)
+	})
+
+	t.Run("🎉 evaluates the schedule in the organization timezone", func(t *testing.T) {
+		next, err := NextDisbursementTemplateRunAt("0 9 1 * *", "-03:00", after)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, time.February, 1, 12, 0, 0, 0, time.UTC), next.UTC())
+	})
+}
+
+func Test_DisbursementTemplateService_CreateDueDisbursements(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	service := &DisbursementTemplateService{Models: models}
+
+	instructions := data.DisbursementTemplateInstructions{
+		{Phone: "+380445555555", ID: "123456789", Amount: "100.5", VerificationValue: "1990-01-01"},
+		{Phone: "+380445555556", ID: "123456780", Amount: "50", VerificationValue: "1990-01-02"},
+	}
+	occurrence := time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name               string
+		disbursementStatus data.DisbursementStatus
+		wantStatus         data.DisbursementStatus
+	}{
+		{
+			name:               "🎉 creates a DRAFT disbursement",
+			disbursementStatus: data.DraftDisbursementStatus,
+			wantStatus:         data.DraftDisbursementStatus,
+		},
+		{
+			name:               "🎉 creates a READY disbursement",
+			disbursementStatus: data.ReadyDisbursementStatus,
+			wantStatus:         data.ReadyDisbursementStatus,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer data.DeleteAllPaymentsFixtures(t, ctx, dbConnectionPool)
+			defer data.DeleteAllDisbursementFixtures(t, ctx, dbConnectionPool)
+			defer data.DeleteAllDisbursementTemplateFixtures(t, ctx, dbConnectionPool)
+
+			template := data.CreateDisbursementTemplateFixture(t, ctx, dbConnectionPool, models.DisbursementTemplates, data.DisbursementTemplateInsert{
+				Name:               "stipend",
+				Schedule:           "@monthly",
+				DisbursementStatus: tc.disbursementStatus,
+				NextRunAt:          occurrence,
+			}, instructions)
+
+			err := service.CreateDueDisbursements(ctx)
+			require.NoError(t, err)
+
+			disbursement, err := models.Disbursements.GetByName(ctx, dbConnectionPool, "stipend - 2025-01-01 09:00")
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, disbursement.Status)
+			assert.Equal(t, template.Wallet.ID, disbursement.Wallet.ID)
+			assert.Equal(t, template.Asset.ID, disbursement.Asset.ID)
+			assert.Equal(t, template.FileName, disbursement.FileName)
+
+			disbursementWithStats, err := models.Disbursements.GetWithStatistics(ctx, disbursement.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 2, disbursementWithStats.TotalPayments)
+
+			advancedTemplate, err := models.DisbursementTemplates.Get(ctx, dbConnectionPool, template.ID)
+			require.NoError(t, err)
+			require.NotNil(t, advancedTemplate.LastRunAt)
+			require.NotNil(t, advancedTemplate.NextRunAt)
+			assert.True(t, advancedTemplate.NextRunAt.After(time.Now()))
+			assert.Equal(t, 1, advancedTemplate.NextRunAt.UTC().Day())
+
+			// running again doesn't create a new disbursement, since the template is no longer due
+			err = service.CreateDueDisbursements(ctx)
+			require.NoError(t, err)
+			disbursements, err := models.Disbursements.GetAll(ctx, dbConnectionPool, &data.QueryParams{}, data.QueryTypeSelectAll)
+			require.NoError(t, err)
+			assert.Len(t, disbursements, 1)
+		})
+	}
+}
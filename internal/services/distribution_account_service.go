@@ -11,6 +11,7 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/circle"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/services/assets"
+	tssUtils "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/utils"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
 	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
 )
@@ -19,6 +20,9 @@ import (
 type DistributionAccountServiceInterface interface {
 	GetBalances(context context.Context, account *schema.TransactionAccount) (map[data.Asset]float64, error)
 	GetBalance(context context.Context, account *schema.TransactionAccount, asset data.Asset) (float64, error)
+	// EstimatePathPaymentSendMax returns the maximum amount of sendAsset that a path payment delivering receiveAmount of
+	// receiveAsset can spend, which is the cheapest quoted source amount increased by maxSlippageBps.
+	EstimatePathPaymentSendMax(ctx context.Context, account *schema.TransactionAccount, sendAsset, receiveAsset data.Asset, receiveAmount string, maxSlippageBps int) (float64, error)
 }
 
 type DistributionAccountServiceOptions struct {
@@ -65,6 +69,7 @@ func NewDistributionAccountService(opts DistributionAccountServiceOptions) (*Dis
 	strategies := map[schema.AccountType]DistributionAccountServiceInterface{
 		schema.DistributionAccountStellarEnv:     stellarDistributionAccSvc,
 		schema.DistributionAccountStellarDBVault: stellarDistributionAccSvc,
+		schema.DistributionAccountStellarRemote:  stellarDistributionAccSvc,
 		schema.DistributionAccountCircleDBVault:  circleDistributionAccSvc,
 	}
 	return &DistributionAccountService{strategies: strategies}, nil
@@ -78,6 +83,10 @@ func (s *DistributionAccountService) GetBalances(ctx context.Context, account *s
 	return s.strategies[account.Type].GetBalances(ctx, account)
 }
 
+func (s *DistributionAccountService) EstimatePathPaymentSendMax(ctx context.Context, account *schema.TransactionAccount, sendAsset, receiveAsset data.Asset, receiveAmount string, maxSlippageBps int) (float64, error) {
+	return s.strategies[account.Type].EstimatePathPaymentSendMax(ctx, account, sendAsset, receiveAsset, receiveAmount, maxSlippageBps)
+}
+
 var _ DistributionAccountServiceInterface = (*DistributionAccountService)(nil)
 
 type StellarDistributionAccountService struct {
@@ -138,6 +147,45 @@ func (s *StellarDistributionAccountService) GetBalance(ctx context.Context, acco
 	return 0, fmt.Errorf("balance for asset %s not found for distribution account", asset)
 }
 
+func (s *StellarDistributionAccountService) EstimatePathPaymentSendMax(_ context.Context, _ *schema.TransactionAccount, sendAsset, receiveAsset data.Asset, receiveAmount string, maxSlippageBps int) (float64, error) {
+	sourceAsset := "native"
+	if !sendAsset.IsNative() {
+		sourceAsset = fmt.Sprintf("%s:%s", sendAsset.Code, sendAsset.Issuer)
+	}
+	var receiveAssetIssuer string
+	if !receiveAsset.IsNative() {
+		receiveAssetIssuer = receiveAsset.Issuer
+	}
+
+	pathsPage, err := s.horizonClient.Paths(horizonclient.PathsRequest{
+		DestinationAssetType:   tssUtils.HorizonAssetType(receiveAsset.Code),
+		DestinationAssetCode:   receiveAsset.Code,
+		DestinationAssetIssuer: receiveAssetIssuer,
+		DestinationAmount:      receiveAmount,
+		SourceAssets:           sourceAsset,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("getting paths from Horizon: %w", err)
+	}
+
+	bestPath, err := tssUtils.CheapestPath(pathsPage.Embedded.Records)
+	if err != nil {
+		return 0, fmt.Errorf("finding a path from %s to %s: %w", sourceAsset, receiveAsset.Code, err)
+	}
+
+	sendMax, err := tssUtils.ApplySlippage(bestPath.SourceAmount, maxSlippageBps)
+	if err != nil {
+		return 0, fmt.Errorf("applying slippage to the source amount: %w", err)
+	}
+
+	sendMaxFloat, err := strconv.ParseFloat(sendMax, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing send max to float: %w", err)
+	}
+
+	return sendMaxFloat, nil
+}
+
 type CircleDistributionAccountService struct {
 	CircleService circle.ServiceInterface
 	NetworkType   utils.NetworkType
@@ -191,3 +239,7 @@ func (s *CircleDistributionAccountService) GetBalance(ctx context.Context, accou
 
 	return assetBalance, nil
 }
+
+func (s *CircleDistributionAccountService) EstimatePathPaymentSendMax(_ context.Context, _ *schema.TransactionAccount, _, _ data.Asset, _ string, _ int) (float64, error) {
+	return 0, fmt.Errorf("path payments are not supported by Circle distribution accounts")
+}
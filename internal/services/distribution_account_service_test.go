@@ -225,6 +225,101 @@ func Test_StellarDistributionAccountService_GetBalance(t *testing.T) {
 	}
 }
 
+func Test_StellarDistributionAccountService_EstimatePathPaymentSendMax(t *testing.T) {
+	ctx := context.Background()
+	distAcc := schema.NewStellarEnvTransactionAccount(keypair.MustRandom().Address())
+
+	usdcAsset := assets.USDCAssetTestnet
+	eurcAsset := assets.EURCAssetTestnet
+	nativeAsset := data.Asset{Code: assets.XLMAssetCode}
+
+	testCases := []struct {
+		name            string
+		sendAsset       data.Asset
+		receiveAsset    data.Asset
+		pathsRequest    horizonclient.PathsRequest
+		paths           []horizon.Path
+		horizonErr      error
+		expectedSendMax float64
+		expectedErr     string
+	}{
+		{
+			name:         "🔴returns an error if Horizon fails",
+			sendAsset:    usdcAsset,
+			receiveAsset: eurcAsset,
+			pathsRequest: horizonclient.PathsRequest{
+				DestinationAssetType:   horizonclient.AssetType4,
+				DestinationAssetCode:   eurcAsset.Code,
+				DestinationAssetIssuer: eurcAsset.Issuer,
+				DestinationAmount:      "100",
+				SourceAssets:           "USDC:" + usdcAsset.Issuer,
+			},
+			horizonErr:  errors.New("horizon error"),
+			expectedErr: "getting paths from Horizon: horizon error",
+		},
+		{
+			name:         "🔴returns an error if no path is found",
+			sendAsset:    usdcAsset,
+			receiveAsset: eurcAsset,
+			pathsRequest: horizonclient.PathsRequest{
+				DestinationAssetType:   horizonclient.AssetType4,
+				DestinationAssetCode:   eurcAsset.Code,
+				DestinationAssetIssuer: eurcAsset.Issuer,
+				DestinationAmount:      "100",
+				SourceAssets:           "USDC:" + usdcAsset.Issuer,
+			},
+			expectedErr: "finding a path from USDC:" + usdcAsset.Issuer + " to EURC: no path found",
+		},
+		{
+			name:         "🟢applies the slippage to the cheapest path",
+			sendAsset:    usdcAsset,
+			receiveAsset: eurcAsset,
+			pathsRequest: horizonclient.PathsRequest{
+				DestinationAssetType:   horizonclient.AssetType4,
+				DestinationAssetCode:   eurcAsset.Code,
+				DestinationAssetIssuer: eurcAsset.Issuer,
+				DestinationAmount:      "100",
+				SourceAssets:           "USDC:" + usdcAsset.Issuer,
+			},
+			paths:           []horizon.Path{{SourceAmount: "110"}, {SourceAmount: "108"}},
+			expectedSendMax: 109.08,
+		},
+		{
+			name:         "🟢quotes native assets",
+			sendAsset:    nativeAsset,
+			receiveAsset: eurcAsset,
+			pathsRequest: horizonclient.PathsRequest{
+				DestinationAssetType:   horizonclient.AssetType4,
+				DestinationAssetCode:   eurcAsset.Code,
+				DestinationAssetIssuer: eurcAsset.Issuer,
+				DestinationAmount:      "100",
+				SourceAssets:           "native",
+			},
+			paths:           []horizon.Path{{SourceAmount: "400"}},
+			expectedSendMax: 404,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mHorizonClient := &horizonclient.MockClient{}
+			defer mHorizonClient.AssertExpectations(t)
+			pathsPage := horizon.PathsPage{}
+			pathsPage.Embedded.Records = tc.paths
+			mHorizonClient.On("Paths", tc.pathsRequest).Return(pathsPage, tc.horizonErr).Once()
+
+			svc := StellarDistributionAccountService{horizonClient: mHorizonClient}
+			sendMax, err := svc.EstimatePathPaymentSendMax(ctx, &distAcc, tc.sendAsset, tc.receiveAsset, "100", 100)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedSendMax, sendMax)
+			}
+		})
+	}
+}
+
 func Test_CircleDistributionAccountService_GetBalances(t *testing.T) {
 	ctx := context.Background()
 	circleDistAcc := schema.TransactionAccount{
@@ -464,6 +559,10 @@ func Test_NewDistributionAccountService(t *testing.T) {
 			accountType: schema.DistributionAccountStellarDBVault,
 			expectedSvc: stellarDistributionAccSvc,
 		},
+		{
+			accountType: schema.DistributionAccountStellarRemote,
+			expectedSvc: stellarDistributionAccSvc,
+		},
 		{
 			accountType: schema.DistributionAccountCircleDBVault,
 			expectedSvc: circleDistributionAccSvc,
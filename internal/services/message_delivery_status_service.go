@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+)
+
+const (
+	// MessageFallbackBatchSize is the maximum number of undelivered invitations resent by each run of the job.
+	MessageFallbackBatchSize = 20
+	// messageFallbackClaimDuration is how long claimed invitations are held before they can be claimed again. The
+	// invitations whose fallback isn't completed, e.g. because the job crashed, are retried after that.
+	messageFallbackClaimDuration = 5 * time.Minute
+)
+
+//go:generate mockery --name=MessageDeliveryStatusServiceInterface --case=underscore --structname=MockMessageDeliveryStatusService --filename=message_delivery_status_service.go
+type MessageDeliveryStatusServiceInterface interface {
+	UpdateDeliveryStatus(ctx context.Context, update message.DeliveryStatusUpdate) error
+	ResendUndeliveredInvitations(ctx context.Context) error
+}
+
+type MessageDeliveryStatusService struct {
+	Models *data.Models
+	// InviteService is only needed to resend the undelivered invitations.
+	InviteService SendReceiverWalletInviteServiceInterface
+}
+
+var _ MessageDeliveryStatusServiceInterface = (*MessageDeliveryStatusService)(nil)
+
+// UpdateDeliveryStatus stores the delivery status a provider reported for a message of the tenant in the context. The
+// invitations that couldn't be delivered are queued to be resent by ResendUndeliveredInvitations.
+func (s *MessageDeliveryStatusService) UpdateDeliveryStatus(ctx context.Context, update message.DeliveryStatusUpdate) error {
+	status, err := data.MessageStatusFromDeliveryStatus(update.Status)
+	if err != nil {
+		return fmt.Errorf("mapping the delivery status: %w", err)
+	}
+
+	_, err = s.Models.Message.UpdateDeliveryStatus(ctx, s.Models.DBConnectionPool, update.MessageID, status, update.ErrorCode, update.ErrorMessage)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			log.Ctx(ctx).Warnf("Message %s not found or already updated, ignoring its %s delivery status", update.MessageID, update.Status)
+			return nil
+		}
+		return fmt.Errorf("updating the delivery status of message %s: %w", update.MessageID, err)
+	}
+
+	return nil
+}
+
+// ResendUndeliveredInvitations resends the queued undelivered invitations of the tenant in the context through the
+// fallback channels of the organization. The invitations that can't be resent stay queued and are retried later.
+func (s *MessageDeliveryStatusService) ResendUndeliveredInvitations(ctx context.Context) error {
+	now := time.Now()
+	msgs, err := s.Models.Message.ClaimFallbackRequests(ctx, s.Models.DBConnectionPool, now, now.Add(messageFallbackClaimDuration), MessageFallbackBatchSize)
+	if err != nil {
+		return fmt.Errorf("claiming the undelivered invitations: %w", err)
+	}
+
+	for _, msg := range msgs {
+		if err = s.InviteService.SendInviteThroughFallback(ctx, msg); err != nil {
+			log.Ctx(ctx).Errorf("Resending the undelivered message %s through a fallback channel: %v", msg.ID, err)
+			continue
+		}
+
+		if err = s.Models.Message.CompleteFallbackRequest(ctx, s.Models.DBConnectionPool, msg.ID); err != nil {
+			return fmt.Errorf("completing the fallback of message %s: %w", msg.ID, err)
+		}
+	}
+
+	return nil
+}
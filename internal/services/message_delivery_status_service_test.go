@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/message"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services/mocks"
+)
+
+func Test_MessageDeliveryStatusService_UpdateDeliveryStatus(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	wallet := data.CreateWalletFixture(t, ctx, dbConnectionPool, "My Wallet", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+	asset := data.CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GABC65XJDMXTGPNZRCI6V3KOKKWVK55UEKGQLONRIVYPMEJNNQ45YOEE")
+	receiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+	rw := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, data.ReadyReceiversWalletStatus)
+
+	createSentInvitation := func(t *testing.T) *data.Message {
+		return data.CreateMessageFixture(t, ctx, dbConnectionPool, &data.Message{
+			Type:             message.MessengerTypeTwilioSMS,
+			AssetID:          &asset.ID,
+			ReceiverID:       receiver.ID,
+			WalletID:         wallet.ID,
+			ReceiverWalletID: &rw.ID,
+			Status:           data.SuccessMessageStatus,
+		})
+	}
+
+	t.Run("returns an error if the delivery status is unknown", func(t *testing.T) {
+		svc := MessageDeliveryStatusService{Models: models}
+		err := svc.UpdateDeliveryStatus(ctx, message.DeliveryStatusUpdate{MessageID: "message-id", Status: "UNKNOWN"})
+		assert.EqualError(t, err, `mapping the delivery status: unknown delivery status "UNKNOWN"`)
+	})
+
+	t.Run("ignores the unknown messages", func(t *testing.T) {
+		svc := MessageDeliveryStatusService{Models: models}
+		err := svc.UpdateDeliveryStatus(ctx, message.DeliveryStatusUpdate{MessageID: "unknown-id", Status: message.DeliveryStatusFailed})
+		assert.NoError(t, err)
+	})
+
+	t.Run("🎉 updates the delivered message", func(t *testing.T) {
+		defer data.DeleteAllMessagesFixtures(t, ctx, dbConnectionPool)
+		sentMsg := createSentInvitation(t)
+
+		svc := MessageDeliveryStatusService{Models: models}
+		err := svc.UpdateDeliveryStatus(ctx, message.DeliveryStatusUpdate{MessageID: sentMsg.ID, Status: message.DeliveryStatusDelivered})
+		require.NoError(t, err)
+
+		var status data.MessageStatus
+		err = dbConnectionPool.GetContext(ctx, &status, "SELECT status FROM messages WHERE id = $1", sentMsg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, data.DeliveredMessageStatus, status)
+	})
+
+	t.Run("🎉 updates the undelivered message and queues its fallback", func(t *testing.T) {
+		defer data.DeleteAllMessagesFixtures(t, ctx, dbConnectionPool)
+		sentMsg := createSentInvitation(t)
+
+		svc := MessageDeliveryStatusService{Models: models}
+		err := svc.UpdateDeliveryStatus(ctx, message.DeliveryStatusUpdate{
+			MessageID:    sentMsg.ID,
+			Status:       message.DeliveryStatusBounced,
+			ErrorCode:    "Permanent/General",
+			ErrorMessage: "smtp; 550 5.1.1 user unknown",
+		})
+		require.NoError(t, err)
+
+		var msg data.Message
+		err = dbConnectionPool.GetContext(ctx, &msg, "SELECT * FROM messages WHERE id = $1", sentMsg.ID)
+		require.NoError(t, err)
+		require.NotNil(t, msg.ErrorCode)
+		assert.Equal(t, "Permanent/General", *msg.ErrorCode)
+		assert.NotNil(t, msg.FallbackRequestedAt)
+	})
+}
+
+func Test_MessageDeliveryStatusService_ResendUndeliveredInvitations(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	wallet := data.CreateWalletFixture(t, ctx, dbConnectionPool, "My Wallet", "https://www.wallet.com", "www.wallet.com", "wallet1://")
+	asset := data.CreateAssetFixture(t, ctx, dbConnectionPool, "USDC", "GABC65XJDMXTGPNZRCI6V3KOKKWVK55UEKGQLONRIVYPMEJNNQ45YOEE")
+	receiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+	rw := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, data.ReadyReceiversWalletStatus)
+
+	createUndeliveredInvitation := func(t *testing.T) *data.Message {
+		sentMsg := data.CreateMessageFixture(t, ctx, dbConnectionPool, &data.Message{
+			Type:             message.MessengerTypeTwilioSMS,
+			AssetID:          &asset.ID,
+			ReceiverID:       receiver.ID,
+			WalletID:         wallet.ID,
+			ReceiverWalletID: &rw.ID,
+			Status:           data.SuccessMessageStatus,
+		})
+		msg, err := models.Message.UpdateDeliveryStatus(ctx, dbConnectionPool, sentMsg.ID, data.FailureMessageStatus, "30003", "")
+		require.NoError(t, err)
+		return msg
+	}
+
+	isQueued := func(t *testing.T, messageID string) bool {
+		var queued bool
+		err := dbConnectionPool.GetContext(ctx, &queued, "SELECT fallback_requested_at IS NOT NULL FROM messages WHERE id = $1", messageID)
+		require.NoError(t, err)
+		return queued
+	}
+
+	t.Run("🎉 resends the undelivered invitations through a fallback channel", func(t *testing.T) {
+		defer data.DeleteAllMessagesFixtures(t, ctx, dbConnectionPool)
+		undeliveredMsg := createUndeliveredInvitation(t)
+
+		mInviteService := &mocks.MockSendReceiverWalletInviteService{}
+		mInviteService.
+			On("SendInviteThroughFallback", ctx, mock.MatchedBy(func(msg *data.Message) bool {
+				return msg.ID == undeliveredMsg.ID && msg.Status == data.FailureMessageStatus
+			})).
+			Return(nil).
+			Once()
+
+		svc := MessageDeliveryStatusService{Models: models, InviteService: mInviteService}
+		err := svc.ResendUndeliveredInvitations(ctx)
+		require.NoError(t, err)
+
+		assert.False(t, isQueued(t, undeliveredMsg.ID))
+		mInviteService.AssertExpectations(t)
+	})
+
+	t.Run("keeps the invitation queued if the fallback fails", func(t *testing.T) {
+		defer data.DeleteAllMessagesFixtures(t, ctx, dbConnectionPool)
+		undeliveredMsg := createUndeliveredInvitation(t)
+
+		mInviteService := &mocks.MockSendReceiverWalletInviteService{}
+		mInviteService.On("SendInviteThroughFallback", ctx, mock.Anything).Return(assert.AnError).Once()
+
+		svc := MessageDeliveryStatusService{Models: models, InviteService: mInviteService}
+		err := svc.ResendUndeliveredInvitations(ctx)
+		require.NoError(t, err)
+
+		assert.True(t, isQueued(t, undeliveredMsg.ID))
+		mInviteService.AssertExpectations(t)
+	})
+}
@@ -16,6 +16,34 @@ type MockDistributionAccountService struct {
 	mock.Mock
 }
 
+// EstimatePathPaymentSendMax provides a mock function with given fields: ctx, account, sendAsset, receiveAsset, receiveAmount, maxSlippageBps
+func (_m *MockDistributionAccountService) EstimatePathPaymentSendMax(ctx context.Context, account *schema.TransactionAccount, sendAsset data.Asset, receiveAsset data.Asset, receiveAmount string, maxSlippageBps int) (float64, error) {
+	ret := _m.Called(ctx, account, sendAsset, receiveAsset, receiveAmount, maxSlippageBps)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimatePathPaymentSendMax")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.TransactionAccount, data.Asset, data.Asset, string, int) (float64, error)); ok {
+		return rf(ctx, account, sendAsset, receiveAsset, receiveAmount, maxSlippageBps)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.TransactionAccount, data.Asset, data.Asset, string, int) float64); ok {
+		r0 = rf(ctx, account, sendAsset, receiveAsset, receiveAmount, maxSlippageBps)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *schema.TransactionAccount, data.Asset, data.Asset, string, int) error); ok {
+		r1 = rf(ctx, account, sendAsset, receiveAsset, receiveAmount, maxSlippageBps)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBalance provides a mock function with given fields: _a0, account, asset
 func (_m *MockDistributionAccountService) GetBalance(_a0 context.Context, account *schema.TransactionAccount, asset data.Asset) (float64, error) {
 	ret := _m.Called(_a0, account, asset)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/events/schemas"
 )
 
@@ -16,3 +17,8 @@ func (s *MockSendReceiverWalletInviteService) SendInvite(ctx context.Context, re
 	args := s.Called(ctx, receiverWalletsReq)
 	return args.Error(0)
 }
+
+func (s *MockSendReceiverWalletInviteService) SendInviteThroughFallback(ctx context.Context, undeliveredMsg *data.Message) error {
+	args := s.Called(ctx, undeliveredMsg)
+	return args.Error(0)
+}
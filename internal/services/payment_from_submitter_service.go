@@ -135,6 +135,9 @@ func (s PaymentFromSubmitterService) syncPaymentWithTransaction(ctx context.Cont
 		Status:               toStatus,
 		StatusMessage:        transaction.StatusMessage.String,
 		StellarTransactionID: transaction.StellarTransactionHash.String,
+		ClaimableBalanceID:   transaction.ClaimableBalanceID.String,
+		PathPaymentPath:      transaction.PathPaymentPath,
+		SourceAmount:         transaction.SourceAmount.String,
 	}
 	err = s.sdpModels.Payment.Update(ctx, sdpDBTx, payment, paymentUpdate)
 	if err != nil {
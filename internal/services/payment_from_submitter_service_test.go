@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"strconv"
 	"testing"
 
 	"github.com/google/uuid"
@@ -223,7 +222,7 @@ func Test_PaymentFromSubmitterService_SyncBatchTransactions(t *testing.T) {
 			ExternalID:  paymentID,
 			AssetCode:   asset.Code,
 			AssetIssuer: asset.Issuer,
-			Amount:      100,
+			Amount:      "100.0000000",
 			Destination: rw1.StellarAddress,
 			TenantID:    tenantID,
 		})
@@ -427,7 +426,7 @@ func Test_PaymentFromSubmitterService_SyncTransaction(t *testing.T) {
 			ExternalID:  paymentID,
 			AssetCode:   asset.Code,
 			AssetIssuer: asset.Issuer,
-			Amount:      100,
+			Amount:      "100.0000000",
 			Destination: rw1.StellarAddress,
 			TenantID:    uuid.NewString(),
 		})
@@ -454,14 +453,11 @@ func createTSSTxs(t *testing.T, testCtx *testContext, payments ...*data.Payment)
 	paymentsQuantity := len(payments)
 	transactionsToCreate := make([]txSubStore.Transaction, 0, paymentsQuantity)
 	for _, payment := range payments {
-		amount, err := strconv.ParseFloat(payment.Amount, 64)
-		require.NoError(t, err)
-
 		transactionsToCreate = append(transactionsToCreate, txSubStore.Transaction{
 			ExternalID:  payment.ID,
 			AssetCode:   payment.Asset.Code,
 			AssetIssuer: payment.Asset.Issuer,
-			Amount:      amount,
+			Amount:      payment.Amount,
 			Destination: payment.ReceiverWallet.StellarAddress,
 			TenantID:    testCtx.tenantID,
 		})
@@ -5,27 +5,49 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/stellar/go/amount"
+
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
 )
 
 // PaymentManagementService is a service for managing disbursements.
 type PaymentManagementService struct {
-	models           *data.Models
-	dbConnectionPool db.DBConnectionPool
+	models                     *data.Models
+	dbConnectionPool           db.DBConnectionPool
+	distributionAccountService DistributionAccountServiceInterface
 }
 
 var (
 	ErrPaymentNotFound            = errors.New("payment not found")
 	ErrPaymentNotReadyToCancel    = errors.New("payment is not ready to be canceled")
 	ErrPaymentStatusCantBeChanged = errors.New("payment status can't be changed to the requested status")
+
+	ErrPaymentNotFailed                       = errors.New("only failed payments can be corrected")
+	ErrPaymentCorrectionWithoutChanges        = errors.New("payment correction doesn't change the amount or the receiver wallet of the payment")
+	ErrPaymentCorrectionInvalidAmount         = errors.New("payment correction amount must be a positive number with up to 7 decimal places")
+	ErrPaymentCorrectionInvalidReceiverWallet = errors.New("payment correction receiver wallet must be registered in the wallet of the payment disbursement")
+	ErrPaymentCorrectionNotFound              = errors.New("payment correction not found")
+	ErrPaymentCorrectionNotPending            = errors.New("payment correction is not pending approval")
+	ErrPaymentCorrectionReviewedByRequester   = errors.New("payment correction can't be reviewed by the user who requested it")
 )
 
+// PaymentCorrectionRequest is a correction of a failed payment. Empty fields keep the current values of the payment.
+type PaymentCorrectionRequest struct {
+	Amount           string
+	ReceiverWalletID string
+	Comment          string
+}
+
 // NewPaymentManagementService is a factory function for creating a new PaymentManagementService.
-func NewPaymentManagementService(models *data.Models, dbConnectionPool db.DBConnectionPool) *PaymentManagementService {
+func NewPaymentManagementService(models *data.Models, dbConnectionPool db.DBConnectionPool, distributionAccountService DistributionAccountServiceInterface) *PaymentManagementService {
 	return &PaymentManagementService{
-		models:           models,
-		dbConnectionPool: dbConnectionPool,
+		models:                     models,
+		dbConnectionPool:           dbConnectionPool,
+		distributionAccountService: distributionAccountService,
 	}
 }
 
@@ -59,3 +81,245 @@ func (s *PaymentManagementService) CancelPayment(ctx context.Context, paymentID
 		return nil
 	})
 }
+
+// CorrectPayment requests a correction of the amount or the receiver wallet of a FAILED payment. When the approval
+// workflow is enabled for the organization, or the correction changes the receiver wallet, the correction is left
+// PENDING_APPROVAL until it's reviewed by another user. Otherwise it's applied right away and the payment is moved to
+// READY to be retried.
+func (s *PaymentManagementService) CorrectPayment(ctx context.Context, paymentID string, user *auth.User, request PaymentCorrectionRequest, distributionAccount *schema.TransactionAccount) (*data.PaymentCorrection, error) {
+	organization, err := s.models.Organizations.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting organization: %w", err)
+	}
+
+	return db.RunInTransactionWithResult(ctx, s.dbConnectionPool, nil, func(dbTx db.DBTransaction) (*data.PaymentCorrection, error) {
+		payment, err := s.getFailedPayment(ctx, dbTx, paymentID)
+		if err != nil {
+			return nil, err
+		}
+
+		insert := data.PaymentCorrectionInsert{
+			PaymentID:                paymentID,
+			Status:                   data.AppliedPaymentCorrectionStatus,
+			PreviousAmount:           payment.Amount,
+			Amount:                   payment.Amount,
+			PreviousReceiverWalletID: payment.ReceiverWallet.ID,
+			ReceiverWalletID:         payment.ReceiverWallet.ID,
+			Comment:                  request.Comment,
+			RequestedBy:              user.ID,
+		}
+		if request.Amount != "" {
+			insert.Amount = request.Amount
+		}
+		if request.ReceiverWalletID != "" {
+			insert.ReceiverWalletID = request.ReceiverWalletID
+		}
+		// Paying someone else than the original receiver always requires a second user.
+		if organization.IsApprovalRequired || insert.ReceiverWalletID != payment.ReceiverWallet.ID {
+			insert.Status = data.PendingApprovalPaymentCorrectionStatus
+		}
+
+		changed, err := s.validateCorrection(ctx, dbTx, payment, insert.Amount, insert.ReceiverWalletID)
+		if err != nil {
+			return nil, err
+		}
+		if !changed {
+			return nil, ErrPaymentCorrectionWithoutChanges
+		}
+
+		correction, err := s.models.PaymentCorrections.Insert(ctx, dbTx, insert)
+		if err != nil {
+			return nil, fmt.Errorf("inserting payment correction: %w", err)
+		}
+
+		if correction.Status == data.AppliedPaymentCorrectionStatus {
+			if err = s.validateCorrectionBalance(ctx, dbTx, distributionAccount, payment, correction.Amount); err != nil {
+				return nil, err
+			}
+			if err = s.applyCorrection(ctx, dbTx, correction, user); err != nil {
+				return nil, err
+			}
+		}
+
+		return correction, nil
+	})
+}
+
+// ApprovePaymentCorrection applies a correction pending approval, moving the payment to READY to be retried. The
+// correction can't be approved by the user who requested it.
+func (s *PaymentManagementService) ApprovePaymentCorrection(ctx context.Context, paymentID, correctionID string, user *auth.User, distributionAccount *schema.TransactionAccount) (*data.PaymentCorrection, error) {
+	return s.reviewPaymentCorrection(ctx, paymentID, correctionID, user, data.AppliedPaymentCorrectionStatus, distributionAccount)
+}
+
+// RejectPaymentCorrection rejects a correction pending approval, leaving the payment unchanged.
+func (s *PaymentManagementService) RejectPaymentCorrection(ctx context.Context, paymentID, correctionID string, user *auth.User) (*data.PaymentCorrection, error) {
+	return s.reviewPaymentCorrection(ctx, paymentID, correctionID, user, data.RejectedPaymentCorrectionStatus, nil)
+}
+
+func (s *PaymentManagementService) reviewPaymentCorrection(ctx context.Context, paymentID, correctionID string, user *auth.User, status data.PaymentCorrectionStatus, distributionAccount *schema.TransactionAccount) (*data.PaymentCorrection, error) {
+	return db.RunInTransactionWithResult(ctx, s.dbConnectionPool, nil, func(dbTx db.DBTransaction) (*data.PaymentCorrection, error) {
+		correction, err := s.models.PaymentCorrections.Get(ctx, dbTx, paymentID, correctionID)
+		if err != nil {
+			if errors.Is(err, data.ErrRecordNotFound) {
+				return nil, ErrPaymentCorrectionNotFound
+			}
+			return nil, fmt.Errorf("getting payment correction %s: %w", correctionID, err)
+		}
+		if correction.RequestedBy == user.ID {
+			return nil, ErrPaymentCorrectionReviewedByRequester
+		}
+
+		correction, err = s.models.PaymentCorrections.Review(ctx, dbTx, correctionID, user.ID, status)
+		if err != nil {
+			if errors.Is(err, data.ErrRecordNotFound) {
+				return nil, ErrPaymentCorrectionNotPending
+			}
+			return nil, fmt.Errorf("reviewing payment correction %s: %w", correctionID, err)
+		}
+
+		if status != data.AppliedPaymentCorrectionStatus {
+			return correction, nil
+		}
+
+		// The payment and the receiver wallet may have changed since the correction was requested.
+		payment, err := s.getFailedPayment(ctx, dbTx, paymentID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = s.validateCorrection(ctx, dbTx, payment, correction.Amount, correction.ReceiverWalletID); err != nil {
+			return nil, err
+		}
+		if err = s.validateCorrectionBalance(ctx, dbTx, distributionAccount, payment, correction.Amount); err != nil {
+			return nil, err
+		}
+
+		if err = s.applyCorrection(ctx, dbTx, correction, user); err != nil {
+			return nil, err
+		}
+
+		return correction, nil
+	})
+}
+
+func (s *PaymentManagementService) getFailedPayment(ctx context.Context, dbTx db.DBTransaction, paymentID string) (*data.Payment, error) {
+	payment, err := s.models.Payment.Get(ctx, paymentID, dbTx)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("getting payment with id %s: %w", paymentID, err)
+	}
+
+	if payment.Status != data.FailedPaymentStatus {
+		return nil, ErrPaymentNotFailed
+	}
+
+	return payment, nil
+}
+
+// validateCorrection checks the amount and the receiver wallet a payment is being corrected to, returning whether any
+// of them is different from the current ones. The receiver wallet must be REGISTERED in the wallet of the disbursement,
+// which is the wallet the payment is sent through.
+func (s *PaymentManagementService) validateCorrection(ctx context.Context, dbTx db.DBTransaction, payment *data.Payment, correctedAmount, receiverWalletID string) (bool, error) {
+	newAmount, err := amount.ParseInt64(correctedAmount)
+	if err != nil || newAmount <= 0 {
+		return false, ErrPaymentCorrectionInvalidAmount
+	}
+	currentAmount, err := amount.ParseInt64(payment.Amount)
+	if err != nil {
+		return false, fmt.Errorf("parsing amount of payment %s: %w", payment.ID, err)
+	}
+
+	if receiverWalletID == payment.ReceiverWallet.ID {
+		return newAmount != currentAmount, nil
+	}
+
+	receiverWallets, err := s.models.ReceiverWallet.GetByIDs(ctx, dbTx, receiverWalletID)
+	if err != nil {
+		return false, fmt.Errorf("getting receiver wallet %s: %w", receiverWalletID, err)
+	}
+	for _, rw := range receiverWallets {
+		if rw.ID != receiverWalletID {
+			continue
+		}
+		if rw.Status != data.RegisteredReceiversWalletStatus || rw.Wallet.ID != payment.ReceiverWallet.Wallet.ID {
+			return false, ErrPaymentCorrectionInvalidReceiverWallet
+		}
+		return true, nil
+	}
+
+	return false, ErrPaymentCorrectionInvalidReceiverWallet
+}
+
+// validateCorrectionBalance checks that the distribution account can pay the corrected amount, along with the payments
+// in progress, when the correction increases the amount of the payment.
+func (s *PaymentManagementService) validateCorrectionBalance(ctx context.Context, dbTx db.DBTransaction, distributionAccount *schema.TransactionAccount, payment *data.Payment, correctedAmount string) error {
+	newAmount, err := amount.ParseInt64(correctedAmount)
+	if err != nil {
+		return ErrPaymentCorrectionInvalidAmount
+	}
+	currentAmount, err := amount.ParseInt64(payment.Amount)
+	if err != nil {
+		return fmt.Errorf("parsing amount of payment %s: %w", payment.ID, err)
+	}
+	if newAmount <= currentAmount {
+		return nil
+	}
+
+	availableBalance, err := s.distributionAccountService.GetBalance(ctx, distributionAccount, payment.Asset)
+	if err != nil {
+		return fmt.Errorf("getting balance for asset (%s,%s) on distribution account %v: %w", payment.Asset.Code, payment.Asset.Issuer, distributionAccount, err)
+	}
+
+	disbursement := *payment.Disbursement
+	disbursement.Asset = &payment.Asset
+	correctedSendAmount, err := estimateSendAmount(ctx, s.distributionAccountService, distributionAccount, &disbursement, correctedAmount)
+	if err != nil {
+		return fmt.Errorf("getting the amount spent by the corrected payment %s: %w", payment.ID, err)
+	}
+
+	totalPendingAmount, err := getTotalPendingAmount(ctx, s.models, s.distributionAccountService, dbTx, distributionAccount, payment.Asset, "")
+	if err != nil {
+		return fmt.Errorf("getting total pending amount: %w", err)
+	}
+
+	if availableBalance-(correctedSendAmount+totalPendingAmount) < 0 {
+		return InsufficientBalanceError{
+			DisbursementAsset:   payment.Asset,
+			DistributionAddress: distributionAccount.ID(),
+			DisbursementID:      disbursement.ID,
+			AvailableBalance:    availableBalance,
+			DisbursementAmount:  correctedSendAmount,
+			TotalPendingAmount:  totalPendingAmount,
+		}
+	}
+
+	return nil
+}
+
+// applyCorrection changes the payment according to the correction, auditing the change with the user applying it, and
+// retries the payment.
+func (s *PaymentManagementService) applyCorrection(ctx context.Context, dbTx db.DBTransaction, correction *data.PaymentCorrection, user *auth.User) error {
+	err := s.models.Payment.ApplyCorrection(ctx, dbTx, correction, user.ID)
+	if err != nil {
+		return fmt.Errorf("applying payment correction %s: %w", correction.ID, err)
+	}
+
+	err = s.models.Payment.RetryFailedPayments(ctx, dbTx, user.Email, correction.PaymentID)
+	if err != nil {
+		return fmt.Errorf("retrying corrected payment %s: %w", correction.PaymentID, err)
+	}
+
+	tnt, err := tenant.GetTenantFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting tenant from context: %w", err)
+	}
+	if tnt.DistributionAccountType.IsCircle() {
+		_, err = s.models.CircleRecipient.ResetRecipientsForRetryIfNeeded(ctx, dbTx, correction.PaymentID)
+		if err != nil {
+			return fmt.Errorf("resetting circle recipients for retry if needed: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
 	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/middleware"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/services/mocks"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-auth/pkg/auth"
+	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
 )
 
 func Test_PaymentManagementService_CancelPayment(t *testing.T) {
@@ -26,7 +31,7 @@ func Test_PaymentManagementService_CancelPayment(t *testing.T) {
 	token := "token"
 	ctx := context.WithValue(context.Background(), middleware.TokenContextKey, token)
 
-	service := NewPaymentManagementService(models, models.DBConnectionPool)
+	service := NewPaymentManagementService(models, models.DBConnectionPool, nil)
 
 	// create fixtures
 	wallet := data.CreateDefaultWalletFixture(t, ctx, dbConnectionPool)
@@ -107,3 +112,224 @@ func Test_PaymentManagementService_CancelPayment(t *testing.T) {
 		require.Equal(t, data.CanceledPaymentStatus, payment.Status)
 	})
 }
+
+func Test_PaymentManagementService_CorrectPayment(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, outerErr := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, outerErr)
+	defer dbConnectionPool.Close()
+
+	models, outerErr := data.NewModels(dbConnectionPool)
+	require.NoError(t, outerErr)
+
+	tnt := &tenant.Tenant{ID: "95e788b6-c80e-4975-9d12-141001fe6e44", Name: "test-tenant", DistributionAccountType: schema.DistributionAccountStellarDBVault}
+	ctx := tenant.SaveTenantInContext(context.Background(), tnt)
+
+	distributionAccount := schema.NewDefaultStellarTransactionAccount("GAAHIL6ZW4QFNLCKALZ3YOIWPP4TXQ7B7J5IU7RLNVGQAV6GFDZHLDTA")
+	mDistAccService := &mocks.MockDistributionAccountService{}
+	defer mDistAccService.AssertExpectations(t)
+	service := NewPaymentManagementService(models, models.DBConnectionPool, mDistAccService)
+	requester := &auth.User{ID: "requester-id", Email: "requester@test.com"}
+	approver := &auth.User{ID: "approver-id", Email: "approver@test.com"}
+
+	wallet := data.CreateWalletFixture(t, ctx, dbConnectionPool, "Wallet", "https://www.wallet.com", "www.wallet.com", "wallet://")
+	otherWallet := data.CreateWalletFixture(t, ctx, dbConnectionPool, "Other Wallet", "https://www.other-wallet.com", "www.other-wallet.com", "other-wallet://")
+	asset := data.GetAssetFixture(t, ctx, dbConnectionPool, data.FixtureAssetUSDC)
+	disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Status: data.StartedDisbursementStatus,
+		Asset:  asset,
+		Wallet: wallet,
+	})
+
+	receiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+	receiverWallet := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, wallet.ID, data.RegisteredReceiversWalletStatus)
+	otherReceiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+	otherReceiverWallet := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, otherReceiver.ID, wallet.ID, data.RegisteredReceiversWalletStatus)
+	otherWalletReceiverWallet := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver.ID, otherWallet.ID, data.RegisteredReceiversWalletStatus)
+	unregisteredReceiver := data.CreateReceiverFixture(t, ctx, dbConnectionPool, &data.Receiver{})
+	unregisteredReceiverWallet := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, unregisteredReceiver.ID, wallet.ID, data.ReadyReceiversWalletStatus)
+
+	createPayment := func(t *testing.T, status data.PaymentStatus) *data.Payment {
+		return data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+			ReceiverWallet: receiverWallet,
+			Disbursement:   disbursement,
+			Asset:          *asset,
+			Amount:         "100",
+			Status:         status,
+		})
+	}
+
+	t.Run("payment doesn't exist", func(t *testing.T) {
+		_, err := service.CorrectPayment(ctx, "5e1f1c7f5b6c9c0001c1b1b1", requester, PaymentCorrectionRequest{Amount: "90"}, &distributionAccount)
+		require.ErrorIs(t, err, ErrPaymentNotFound)
+	})
+
+	t.Run("payment is not failed", func(t *testing.T) {
+		payment := createPayment(t, data.SuccessPaymentStatus)
+
+		_, err := service.CorrectPayment(ctx, payment.ID, requester, PaymentCorrectionRequest{Amount: "90"}, &distributionAccount)
+		require.ErrorIs(t, err, ErrPaymentNotFailed)
+	})
+
+	t.Run("invalid corrections", func(t *testing.T) {
+		payment := createPayment(t, data.FailedPaymentStatus)
+
+		testCases := []struct {
+			name    string
+			request PaymentCorrectionRequest
+			wantErr error
+		}{
+			{"same amount", PaymentCorrectionRequest{Amount: "100.0"}, ErrPaymentCorrectionWithoutChanges},
+			{"same receiver wallet", PaymentCorrectionRequest{ReceiverWalletID: receiverWallet.ID}, ErrPaymentCorrectionWithoutChanges},
+			{"amount with too many decimal places", PaymentCorrectionRequest{Amount: "1.12345678"}, ErrPaymentCorrectionInvalidAmount},
+			{"receiver wallet doesn't exist", PaymentCorrectionRequest{ReceiverWalletID: "invalid-id"}, ErrPaymentCorrectionInvalidReceiverWallet},
+			{"receiver wallet is not registered", PaymentCorrectionRequest{ReceiverWalletID: unregisteredReceiverWallet.ID}, ErrPaymentCorrectionInvalidReceiverWallet},
+			{"receiver wallet is in another wallet", PaymentCorrectionRequest{ReceiverWalletID: otherWalletReceiverWallet.ID}, ErrPaymentCorrectionInvalidReceiverWallet},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := service.CorrectPayment(ctx, payment.ID, requester, tc.request, &distributionAccount)
+				require.ErrorIs(t, err, tc.wantErr)
+			})
+		}
+	})
+
+	t.Run("corrections that increase the amount are only applied if the balance is sufficient", func(t *testing.T) {
+		data.DisableDisbursementApproval(t, ctx, models.Organizations)
+		payment := createPayment(t, data.FailedPaymentStatus)
+
+		mDistAccService.
+			On("GetBalance", ctx, &distributionAccount, *asset).
+			Return(110.0, nil).
+			Once()
+		_, err := service.CorrectPayment(ctx, payment.ID, requester, PaymentCorrectionRequest{Amount: "120"}, &distributionAccount)
+		var insufficientBalanceErr InsufficientBalanceError
+		require.ErrorAs(t, err, &insufficientBalanceErr)
+		assert.Equal(t, disbursement.ID, insufficientBalanceErr.DisbursementID)
+		assert.Equal(t, 110.0, insufficientBalanceErr.AvailableBalance)
+		assert.Equal(t, 120.0, insufficientBalanceErr.DisbursementAmount)
+		assert.Zero(t, insufficientBalanceErr.TotalPendingAmount)
+
+		unchangedPayment, err := models.Payment.Get(ctx, payment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, data.FailedPaymentStatus, unchangedPayment.Status)
+		assert.Equal(t, "100.0000000", unchangedPayment.Amount)
+
+		mDistAccService.
+			On("GetBalance", ctx, &distributionAccount, *asset).
+			Return(120.0, nil).
+			Once()
+		correction, err := service.CorrectPayment(ctx, payment.ID, requester, PaymentCorrectionRequest{Amount: "120"}, &distributionAccount)
+		require.NoError(t, err)
+		assert.Equal(t, data.AppliedPaymentCorrectionStatus, correction.Status)
+	})
+
+	t.Run("🎉 corrections are applied right away when the approval workflow is disabled", func(t *testing.T) {
+		data.DisableDisbursementApproval(t, ctx, models.Organizations)
+		payment := createPayment(t, data.FailedPaymentStatus)
+
+		correction, err := service.CorrectPayment(ctx, payment.ID, requester, PaymentCorrectionRequest{
+			Amount:  "90",
+			Comment: "wrong amount",
+		}, &distributionAccount)
+		require.NoError(t, err)
+		assert.Equal(t, data.AppliedPaymentCorrectionStatus, correction.Status)
+		assert.Equal(t, "100.0000000", correction.PreviousAmount)
+		assert.Equal(t, "90.0000000", correction.Amount)
+		assert.Equal(t, receiverWallet.ID, correction.PreviousReceiverWalletID)
+		assert.Equal(t, receiverWallet.ID, correction.ReceiverWalletID)
+		assert.Equal(t, requester.ID, correction.RequestedBy)
+
+		payment, err = models.Payment.Get(ctx, payment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, data.ReadyPaymentStatus, payment.Status)
+		assert.Equal(t, "90.0000000", payment.Amount)
+		correctionEntry := payment.StatusHistory[len(payment.StatusHistory)-2]
+		assert.Equal(t, data.FailedPaymentStatus, correctionEntry.Status)
+		assert.Equal(t, requester.ID, correctionEntry.UserID)
+	})
+
+	t.Run("🎉 corrections of the receiver wallet always wait for the approval of another user", func(t *testing.T) {
+		data.DisableDisbursementApproval(t, ctx, models.Organizations)
+		payment := createPayment(t, data.FailedPaymentStatus)
+
+		correction, err := service.CorrectPayment(ctx, payment.ID, requester, PaymentCorrectionRequest{
+			ReceiverWalletID: otherReceiverWallet.ID,
+			Comment:          "wrong receiver",
+		}, &distributionAccount)
+		require.NoError(t, err)
+		assert.Equal(t, data.PendingApprovalPaymentCorrectionStatus, correction.Status)
+		assert.Equal(t, otherReceiverWallet.ID, correction.ReceiverWalletID)
+
+		_, err = service.ApprovePaymentCorrection(ctx, payment.ID, correction.ID, requester, &distributionAccount)
+		require.ErrorIs(t, err, ErrPaymentCorrectionReviewedByRequester)
+
+		correction, err = service.ApprovePaymentCorrection(ctx, payment.ID, correction.ID, approver, &distributionAccount)
+		require.NoError(t, err)
+		assert.Equal(t, data.AppliedPaymentCorrectionStatus, correction.Status)
+
+		payment, err = models.Payment.Get(ctx, payment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, data.ReadyPaymentStatus, payment.Status)
+		assert.Equal(t, otherReceiverWallet.ID, payment.ReceiverWallet.ID)
+	})
+
+	t.Run("🎉 corrections wait for the approval of another user when the approval workflow is enabled", func(t *testing.T) {
+		data.EnableDisbursementApproval(t, ctx, models.Organizations)
+		defer data.DisableDisbursementApproval(t, ctx, models.Organizations)
+		payment := createPayment(t, data.FailedPaymentStatus)
+
+		correction, err := service.CorrectPayment(ctx, payment.ID, requester, PaymentCorrectionRequest{Amount: "90"}, &distributionAccount)
+		require.NoError(t, err)
+		assert.Equal(t, data.PendingApprovalPaymentCorrectionStatus, correction.Status)
+
+		_, err = service.CorrectPayment(ctx, payment.ID, requester, PaymentCorrectionRequest{Amount: "80"}, &distributionAccount)
+		require.ErrorIs(t, err, data.ErrPaymentCorrectionAlreadyPending)
+
+		pendingPayment, err := models.Payment.Get(ctx, payment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, data.FailedPaymentStatus, pendingPayment.Status)
+		assert.Equal(t, "100.0000000", pendingPayment.Amount)
+
+		_, err = service.ApprovePaymentCorrection(ctx, payment.ID, correction.ID, requester, &distributionAccount)
+		require.ErrorIs(t, err, ErrPaymentCorrectionReviewedByRequester)
+
+		correction, err = service.ApprovePaymentCorrection(ctx, payment.ID, correction.ID, approver, &distributionAccount)
+		require.NoError(t, err)
+		assert.Equal(t, data.AppliedPaymentCorrectionStatus, correction.Status)
+		assert.Equal(t, approver.ID, correction.ReviewedBy)
+
+		_, err = service.RejectPaymentCorrection(ctx, payment.ID, correction.ID, approver)
+		require.ErrorIs(t, err, ErrPaymentCorrectionNotPending)
+
+		correctedPayment, err := models.Payment.Get(ctx, payment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, data.ReadyPaymentStatus, correctedPayment.Status)
+		assert.Equal(t, "90.0000000", correctedPayment.Amount)
+		correctionEntry := correctedPayment.StatusHistory[len(correctedPayment.StatusHistory)-2]
+		assert.Equal(t, approver.ID, correctionEntry.UserID)
+	})
+
+	t.Run("🎉 rejected corrections leave the payment unchanged", func(t *testing.T) {
+		data.EnableDisbursementApproval(t, ctx, models.Organizations)
+		defer data.DisableDisbursementApproval(t, ctx, models.Organizations)
+		payment := createPayment(t, data.FailedPaymentStatus)
+
+		correction, err := service.CorrectPayment(ctx, payment.ID, requester, PaymentCorrectionRequest{Amount: "90"}, &distributionAccount)
+		require.NoError(t, err)
+
+		_, err = service.RejectPaymentCorrection(ctx, payment.ID, "invalid-id", approver)
+		require.ErrorIs(t, err, ErrPaymentCorrectionNotFound)
+
+		correction, err = service.RejectPaymentCorrection(ctx, payment.ID, correction.ID, approver)
+		require.NoError(t, err)
+		assert.Equal(t, data.RejectedPaymentCorrectionStatus, correction.Status)
+
+		rejectedPayment, err := models.Payment.Get(ctx, payment.ID, dbConnectionPool)
+		require.NoError(t, err)
+		assert.Equal(t, data.FailedPaymentStatus, rejectedPayment.Status)
+		assert.Equal(t, "100.0000000", rejectedPayment.Amount)
+	})
+}
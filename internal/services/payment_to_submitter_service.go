@@ -15,6 +15,7 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing"
 	txSubStore "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
 	"github.com/stellar/stellar-disbursement-platform-backend/stellar-multitenant/pkg/tenant"
 )
 
@@ -200,6 +201,11 @@ func validatePaymentReadyForSending(p *data.Payment) error {
 	if strings.TrimSpace(p.ReceiverWallet.StellarAddress) == "" {
 		return fmt.Errorf("payment receiver wallet stellar address is empty for payment %s", p.ID)
 	}
+	// 6. payment.ReceiverWallet.StellarMemo and StellarMemoType are used as transaction.Memo and transaction.MemoType
+	memo := schema.Memo{Value: p.ReceiverWallet.StellarMemo, Type: schema.MemoType(p.ReceiverWallet.StellarMemoType)}
+	if err := memo.Validate(); err != nil {
+		return fmt.Errorf("payment receiver wallet memo is invalid for payment %s: %w", p.ID, err)
+	}
 
 	return nil
 }
@@ -3,7 +3,6 @@ package services
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"testing"
 	"time"
 
@@ -343,7 +342,7 @@ func Test_PaymentToSubmitterService_SendPaymentsMethods(t *testing.T) {
 					assert.Equal(t, txSubStore.TransactionStatusPending, tx.Status)
 					assert.Equal(t, expectedPayments[tx.ExternalID].Asset.Code, tx.AssetCode)
 					assert.Equal(t, expectedPayments[tx.ExternalID].Asset.Issuer, tx.AssetIssuer)
-					assert.Equal(t, expectedPayments[tx.ExternalID].Amount, strconv.FormatFloat(tx.Amount, 'f', 7, 32))
+					assert.Equal(t, expectedPayments[tx.ExternalID].Amount, tx.Amount)
 					assert.Equal(t, expectedPayments[tx.ExternalID].ReceiverWallet.StellarAddress, tx.Destination)
 					assert.Equal(t, expectedPayments[tx.ExternalID].ID, tx.ExternalID)
 					assert.Equal(t, testTenant.ID, tx.TenantID)
@@ -512,6 +511,49 @@ func Test_PaymentToSubmitterService_ValidatePaymentReadyForSending(t *testing.T)
 			},
 			expectedError: "payment receiver wallet stellar address is empty for payment 123",
 		},
+		{
+			name: "payment receiver wallet memo is invalid",
+			payment: &data.Payment{
+				ID:     "123",
+				Status: data.ReadyPaymentStatus,
+				ReceiverWallet: &data.ReceiverWallet{
+					Status:          data.RegisteredReceiversWalletStatus,
+					StellarAddress:  "GBLTXF46JTCGMWFJASQLVXMMA36IPYTDCN4EN73HRXCGDCGYBZM3A444",
+					StellarMemo:     "not-a-number",
+					StellarMemoType: "id",
+				},
+				Disbursement: &data.Disbursement{
+					Status: data.StartedDisbursementStatus,
+				},
+				Asset: data.Asset{
+					Code:   "USDC",
+					Issuer: "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN",
+				},
+				Amount: "100.0",
+			},
+			expectedError: `payment receiver wallet memo is invalid for payment 123: id memo "not-a-number" is not a valid uint64: strconv.ParseUint: parsing "not-a-number": invalid syntax`,
+		},
+		{
+			name: "🎉 payment with a valid memo is ready for sending",
+			payment: &data.Payment{
+				ID:     "123",
+				Status: data.ReadyPaymentStatus,
+				ReceiverWallet: &data.ReceiverWallet{
+					Status:          data.RegisteredReceiversWalletStatus,
+					StellarAddress:  "GBLTXF46JTCGMWFJASQLVXMMA36IPYTDCN4EN73HRXCGDCGYBZM3A444",
+					StellarMemo:     "123456",
+					StellarMemoType: "id",
+				},
+				Disbursement: &data.Disbursement{
+					Status: data.StartedDisbursementStatus,
+				},
+				Asset: data.Asset{
+					Code:   "USDC",
+					Issuer: "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN",
+				},
+				Amount: "100.0",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
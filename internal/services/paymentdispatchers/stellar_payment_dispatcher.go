@@ -3,7 +3,6 @@ package paymentdispatchers
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/stellar/go/support/log"
 
@@ -56,20 +55,26 @@ var _ PaymentDispatcherInterface = (*StellarPaymentDispatcher)(nil)
 func (s *StellarPaymentDispatcher) sendPaymentsToTSS(ctx context.Context, sdpDBTx, tssDBTx db.DBTransaction, tenantID string, pendingPayments []*data.Payment) error {
 	var transactions []txSubStore.Transaction
 	for _, payment := range pendingPayments {
-		// TODO: change TSS to use string amount [SDP-483]
-		amount, err := strconv.ParseFloat(payment.Amount, 64)
-		if err != nil {
-			return fmt.Errorf("parsing payment amount %s for payment ID %s: %w", payment.Amount, payment.ID, err)
-		}
-
 		transaction := txSubStore.Transaction{
 			ExternalID:  payment.ID,
 			AssetCode:   payment.Asset.Code,
 			AssetIssuer: payment.Asset.Issuer,
-			Amount:      amount,
+			Amount:      payment.Amount,
 			Destination: payment.ReceiverWallet.StellarAddress,
+			Memo:        payment.ReceiverWallet.StellarMemo,
+			MemoType:    schema.MemoType(payment.ReceiverWallet.StellarMemoType),
 			TenantID:    tenantID,
 		}
+		if payment.Disbursement != nil {
+			if payment.Disbursement.PayoutMode == data.PayoutModeClaimableBalance {
+				transaction.ClaimableBalance = true
+			}
+			if payment.Disbursement.ReceiveAssetCode != "" {
+				transaction.DestinationAssetCode = payment.Disbursement.ReceiveAssetCode
+				transaction.DestinationAssetIssuer = payment.Disbursement.ReceiveAssetIssuer
+				transaction.MaxSlippageBps = payment.Disbursement.MaxSlippageBps
+			}
+		}
 		transactions = append(transactions, transaction)
 	}
 
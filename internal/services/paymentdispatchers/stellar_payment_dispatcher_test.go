@@ -3,7 +3,6 @@ package paymentdispatchers
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,6 +51,33 @@ func Test_StellarPaymentDispatcher_DispatchPayments(t *testing.T) {
 		Amount:         "100",
 		Status:         data.ReadyPaymentStatus,
 	})
+	cbDisbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Wallet:     disbursement.Wallet,
+		Asset:      disbursement.Asset,
+		PayoutMode: data.PayoutModeClaimableBalance,
+	})
+	cbPayment := data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+		ReceiverWallet: rw1Registered,
+		Disbursement:   cbDisbursement,
+		Asset:          *disbursement.Asset,
+		Amount:         "100",
+		Status:         data.ReadyPaymentStatus,
+	})
+
+	ppDisbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{
+		Wallet:             disbursement.Wallet,
+		Asset:              disbursement.Asset,
+		ReceiveAssetCode:   "BRL",
+		ReceiveAssetIssuer: "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
+		MaxSlippageBps:     50,
+	})
+	ppPayment := data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+		ReceiverWallet: rw1Registered,
+		Disbursement:   ppDisbursement,
+		Asset:          *disbursement.Asset,
+		Amount:         "500",
+		Status:         data.ReadyPaymentStatus,
+	})
 
 	tests := []struct {
 		name               string
@@ -81,11 +107,16 @@ func Test_StellarPaymentDispatcher_DispatchPayments(t *testing.T) {
 			},
 		},
 		{
-			name: "unable to parse payment amount",
+			name: "invalid payment amount",
 			paymentsToDispatch: []*data.Payment{
-				{ID: "123", Amount: "invalid-amount"},
+				{
+					ID:             "123",
+					Amount:         "1.12345678",
+					Asset:          *disbursement.Asset,
+					ReceiverWallet: rw1Registered,
+				},
 			},
-			wantErr: fmt.Errorf("parsing payment amount invalid-amount for payment ID 123: strconv.ParseFloat: parsing \"invalid-amount\": invalid syntax"),
+			wantErr: fmt.Errorf(`validating transaction for insertion: amount "1.12345678" is not a valid Stellar amount: more than 7 significant digits: 1.12345678`),
 			fnSetup: func(t *testing.T, mDistAccountResolver *mocks.MockDistributionAccountResolver) {
 				mDistAccountResolver.On("DistributionAccountFromContext", ctx).
 					Return(schema.TransactionAccount{Type: schema.DistributionAccountStellarEnv}, nil).
@@ -116,10 +147,47 @@ func Test_StellarPaymentDispatcher_DispatchPayments(t *testing.T) {
 				assert.Equal(t, txSubStore.TransactionStatusPending, tx.Status)
 				assert.Equal(t, payment1.Asset.Code, tx.AssetCode)
 				assert.Equal(t, payment1.Asset.Issuer, tx.AssetIssuer)
-				assert.Equal(t, payment1.Amount, strconv.FormatFloat(tx.Amount, 'f', 7, 32))
+				assert.Equal(t, payment1.Amount, tx.Amount)
 				assert.Equal(t, payment1.ReceiverWallet.StellarAddress, tx.Destination)
+				assert.Equal(t, payment1.ReceiverWallet.StellarMemo, tx.Memo)
+				assert.Equal(t, schema.MemoType(payment1.ReceiverWallet.StellarMemoType), tx.MemoType)
 				assert.Equal(t, payment1.ID, tx.ExternalID)
 				assert.Equal(t, "tenant-id", tx.TenantID)
+				assert.False(t, tx.ClaimableBalance)
+			},
+		},
+		{
+			name:               "success posting claimable balance transfer to Stellar",
+			paymentsToDispatch: []*data.Payment{cbPayment},
+			wantErr:            nil,
+			fnSetup: func(t *testing.T, mDistAccountResolver *mocks.MockDistributionAccountResolver) {
+				mDistAccountResolver.On("DistributionAccountFromContext", ctx).
+					Return(schema.TransactionAccount{Type: schema.DistributionAccountStellarEnv}, nil).
+					Once()
+			},
+			fnAsserts: func(t *testing.T, sqlExecuter db.SQLExecuter) {
+				transactions, assertErr := tssModel.GetAllByPaymentIDs(ctx, []string{cbPayment.ID})
+				require.NoError(t, assertErr)
+				require.Len(t, transactions, 1)
+				assert.True(t, transactions[0].ClaimableBalance)
+			},
+		},
+		{
+			name:               "success posting path payment transfer to Stellar",
+			paymentsToDispatch: []*data.Payment{ppPayment},
+			wantErr:            nil,
+			fnSetup: func(t *testing.T, mDistAccountResolver *mocks.MockDistributionAccountResolver) {
+				mDistAccountResolver.On("DistributionAccountFromContext", ctx).
+					Return(schema.TransactionAccount{Type: schema.DistributionAccountStellarEnv}, nil).
+					Once()
+			},
+			fnAsserts: func(t *testing.T, sqlExecuter db.SQLExecuter) {
+				transactions, assertErr := tssModel.GetAllByPaymentIDs(ctx, []string{ppPayment.ID})
+				require.NoError(t, assertErr)
+				require.Len(t, transactions, 1)
+				assert.Equal(t, "BRL", transactions[0].DestinationAssetCode)
+				assert.Equal(t, "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG", transactions[0].DestinationAssetIssuer)
+				assert.Equal(t, 50, transactions[0].MaxSlippageBps)
 			},
 		},
 	}
@@ -10,10 +10,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/support/log"
 
-	"github.com/stellar/stellar-disbursement-platform-backend/db"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/crashtracker"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/events/schemas"
@@ -24,6 +24,7 @@ import (
 
 type SendReceiverWalletInviteServiceInterface interface {
 	SendInvite(ctx context.Context, receiverWalletInvitationData ...schemas.EventReceiverWalletInvitationData) error
+	SendInviteThroughFallback(ctx context.Context, undeliveredMsg *data.Message) error
 }
 
 type SendReceiverWalletInviteService struct {
@@ -50,6 +51,33 @@ func (s SendReceiverWalletInviteService) validate() error {
 // So the receiver who has a Stellar Address pending registration (status:READY) in this wallet will receive both invites for USDC and EUROC.
 // This would not impact the user receiving both token amounts. It's only for the registration process.
 func (s SendReceiverWalletInviteService) SendInvite(ctx context.Context, receiverWalletInvitationData ...schemas.EventReceiverWalletInvitationData) error {
+	return s.sendInvites(ctx, nil, receiverWalletInvitationData...)
+}
+
+// SendInviteThroughFallback resends the invitation the provider reported as undelivered through the channels that come
+// after the failed one in the organization's MessageChannelPriority. The automatic resend rules don't apply to it, since
+// the receiver never got the original invitation.
+func (s SendReceiverWalletInviteService) SendInviteThroughFallback(ctx context.Context, undeliveredMsg *data.Message) error {
+	if undeliveredMsg.ReceiverWalletID == nil || undeliveredMsg.AssetID == nil {
+		log.Ctx(ctx).Debugf("message %s is not a receiver wallet invitation, so it won't be resent through a fallback channel", undeliveredMsg.ID)
+		return nil
+	}
+
+	fallback := &fallbackInvitation{
+		assetID:             *undeliveredMsg.AssetID,
+		failedMessengerType: undeliveredMsg.Type,
+	}
+	return s.sendInvites(ctx, fallback, schemas.EventReceiverWalletInvitationData{ReceiverWalletID: *undeliveredMsg.ReceiverWalletID})
+}
+
+// fallbackInvitation holds the invitation that couldn't be delivered through the failedMessengerType, and is being
+// resent through the following channels.
+type fallbackInvitation struct {
+	assetID             string
+	failedMessengerType message.MessengerType
+}
+
+func (s SendReceiverWalletInviteService) sendInvites(ctx context.Context, fallback *fallbackInvitation, receiverWalletInvitationData ...schemas.EventReceiverWalletInvitationData) error {
 	if s.Models == nil {
 		return fmt.Errorf("SendReceiverWalletInviteService.Models cannot be nil")
 	}
@@ -104,11 +132,14 @@ func (s SendReceiverWalletInviteService) SendInvite(ctx context.Context, receive
 		return fmt.Errorf("getting all assets: %w", err)
 	}
 
-	msgsToInsert := []*data.MessageInsert{}
 	receiverWalletIDs := []string{}
 	// TODO: improve this code adding go routines
 	for _, rwa := range receiverWalletsAsset {
-		if !s.shouldSendInvitation(ctx, organization, &rwa) {
+		if fallback != nil {
+			if rwa.Asset.ID != fallback.assetID {
+				continue
+			}
+		} else if !s.shouldSendInvitation(ctx, organization, &rwa) {
 			continue
 		}
 
@@ -155,56 +186,100 @@ func (s SendReceiverWalletInviteService) SendInvite(ctx context.Context, receive
 		msg := message.Message{Body: content.String()}
 		if rwa.ReceiverWallet.Receiver.PhoneNumber != "" {
 			msg.ToPhoneNumber = rwa.ReceiverWallet.Receiver.PhoneNumber
+			// The WhatsApp channel can only send the invitation through its pre-approved template.
+			msg.Template = &message.MessageTemplate{
+				Type:      message.MessageTemplateTypeReceiverInvitation,
+				Variables: []string{organization.Name, registrationLink},
+			}
 		}
 		if rwa.ReceiverWallet.Receiver.Email != "" {
 			msg.ToEmail = rwa.ReceiverWallet.Receiver.Email
 			msg.Title = "You have a payment waiting for you from " + organization.Name
 		}
 
+		channelPriority := []message.MessageChannel(organization.MessageChannelPriority)
+		if fallback != nil {
+			channelPriority = fallbackChannelPriority(channelPriority, fallback.failedMessengerType, msg)
+			if len(channelPriority) == 0 {
+				log.Ctx(ctx).Warnf("no fallback channel left to resend the invitation to receiver wallet ID %s, undelivered through messenger type %s", rwa.ReceiverWallet.ID, fallback.failedMessengerType)
+				continue
+			}
+		}
+
+		// The message ID is passed to the providers, so they can report its delivery status back.
+		msg.ID = uuid.NewString()
+		msg.StatusCallbackURL, err = message.TwilioStatusCallbackURL(*currentTenant.BaseURL, msg.ID)
+		if err != nil {
+			return fmt.Errorf("building the status callback URL for message %s: %w", msg.ID, err)
+		}
+
+		// The message is stored before it's sent, since the provider can report its delivery status before the send
+		// returns.
 		msgToInsert := &data.MessageInsert{
+			ID:               msg.ID,
+			Type:             s.messageDispatcher.MessengerTypeFor(ctx, msg, channelPriority),
 			AssetID:          &rwa.Asset.ID,
 			ReceiverID:       rwa.ReceiverWallet.Receiver.ID,
 			WalletID:         wallet.ID,
 			ReceiverWalletID: &rwa.ReceiverWallet.ID,
 			TextEncrypted:    msg.Body,
 			TitleEncrypted:   msg.Title,
+			Status:           data.PendingMessageStatus,
+		}
+		if err = s.Models.Message.BulkInsert(ctx, s.Models.DBConnectionPool, []*data.MessageInsert{msgToInsert}); err != nil {
+			return fmt.Errorf("inserting message %s in the database: %w", msgToInsert.ID, err)
 		}
 
-		if messengerType, sendErr := s.messageDispatcher.SendMessage(ctx, msg, organization.MessageChannelPriority); sendErr != nil {
+		msgStatus := data.SuccessMessageStatus
+		messengerType, sendErr := s.messageDispatcher.SendMessage(ctx, msg, channelPriority)
+		if sendErr != nil {
 			errMsg := fmt.Sprintf(
 				"error sending message to receiver ID %s for receiver wallet ID %s using messenger type %s",
 				rwa.ReceiverWallet.Receiver.ID, rwa.ReceiverWallet.ID, messengerType,
 			)
 			// call crash tracker client to log and report error
 			s.crashTrackerClient.LogAndReportErrors(ctx, sendErr, errMsg)
-			msgToInsert.Status = data.FailureMessageStatus
-			msgToInsert.Type = messengerType
-		} else {
-			msgToInsert.Status = data.SuccessMessageStatus
-			msgToInsert.Type = messengerType
+			msgStatus = data.FailureMessageStatus
 		}
 
-		msgsToInsert = append(msgsToInsert, msgToInsert)
+		if err = s.Models.Message.UpdateSendResult(ctx, s.Models.DBConnectionPool, msg.ID, messengerType, msgStatus); err != nil {
+			return fmt.Errorf("updating the send result of message %s: %w", msg.ID, err)
+		}
 
 		// We don't want to update the `invitation_sent_at` for receiver wallets for which we've already sent the invitation message
 		// because there's no way to calculate how many times we've resent the invitation message since
 		// the first invitation if we update it.
-		if rwa.ReceiverWallet.InvitationSentAt == nil && msgToInsert.Status == data.SuccessMessageStatus {
+		if rwa.ReceiverWallet.InvitationSentAt == nil && msgStatus == data.SuccessMessageStatus {
 			receiverWalletIDs = append(receiverWalletIDs, rwa.ReceiverWallet.ID)
 		}
 	}
 
-	return db.RunInTransaction(ctx, s.Models.DBConnectionPool, nil, func(dbTx db.DBTransaction) error {
-		if _, err := s.Models.ReceiverWallet.UpdateInvitationSentAt(ctx, dbTx, receiverWalletIDs...); err != nil {
-			return fmt.Errorf("updating receiver wallets' invitation sent at: %w", err)
-		}
+	if _, err = s.Models.ReceiverWallet.UpdateInvitationSentAt(ctx, s.Models.DBConnectionPool, receiverWalletIDs...); err != nil {
+		return fmt.Errorf("updating receiver wallets' invitation sent at: %w", err)
+	}
 
-		if err := s.Models.Message.BulkInsert(ctx, dbTx, msgsToInsert); err != nil {
-			return fmt.Errorf("inserting messages in the database: %w", err)
-		}
+	return nil
+}
 
-		return nil
+// fallbackChannelPriority returns the channels of the channelPriority that come after the one the failedMessengerType
+// sent the message through, and that are able to send the msg.
+func fallbackChannelPriority(channelPriority []message.MessageChannel, failedMessengerType message.MessengerType, msg message.Message) []message.MessageChannel {
+	failedChannelIndex := slices.IndexFunc(channelPriority, func(channel message.MessageChannel) bool {
+		return channel.SupportsMessengerType(failedMessengerType)
 	})
+	if failedChannelIndex == -1 {
+		return nil
+	}
+
+	supportedChannels := msg.SupportedChannels()
+	var fallbackChannels []message.MessageChannel
+	for _, channel := range channelPriority[failedChannelIndex+1:] {
+		if slices.Contains(supportedChannels, channel) {
+			fallbackChannels = append(fallbackChannels, channel)
+		}
+	}
+
+	return fallbackChannels
 }
 
 func (s SendReceiverWalletInviteService) GetRegistrationLink(ctx context.Context, wdl WalletDeepLink, isLinkShortenerEnabled bool) (string, error) {
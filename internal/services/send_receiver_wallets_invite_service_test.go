@@ -65,6 +65,10 @@ func Test_SendReceiverWalletInviteService_SendInvite(t *testing.T) {
 
 	stellarSecretKey := "SBUSPEKAZKLZSWHRSJ2HWDZUK6I3IVDUWA7JJZSGBLZ2WZIUJI7FPNB5"
 	messageDispatcherMock := message.NewMockMessageDispatcher(t)
+	messageDispatcherMock.
+		On("MessengerTypeFor", mock.Anything, mock.Anything, mock.Anything).
+		Return(message.MessengerTypeTwilioSMS).
+		Maybe()
 
 	mockCrashTrackerClient := &crashtracker.MockCrashTrackerClient{}
 
@@ -158,20 +162,28 @@ func Test_SendReceiverWalletInviteService_SendInvite(t *testing.T) {
 
 		mockErr := errors.New("unexpected error")
 		messageDispatcherMock.
-			On("SendMessage", mock.Anything, message.Message{
+			On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
 				ToPhoneNumber: receiver1.PhoneNumber,
 				ToEmail:       receiver1.Email,
 				Body:          contentWallet1,
 				Title:         titleWallet1,
-			}, []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+				Template: &message.MessageTemplate{
+					Type:      message.MessageTemplateTypeReceiverInvitation,
+					Variables: []string{walletDeepLink1.OrganizationName, deepLink1},
+				},
+			}), []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 			Return(message.MessengerTypeTwilioSMS, errors.New("unexpected error")).
 			Once().
-			On("SendMessage", mock.Anything, message.Message{
+			On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
 				ToPhoneNumber: receiver2.PhoneNumber,
 				ToEmail:       receiver2.Email,
 				Body:          contentWallet2,
 				Title:         titleWallet2,
-			}, []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+				Template: &message.MessageTemplate{
+					Type:      message.MessageTemplateTypeReceiverInvitation,
+					Variables: []string{walletDeepLink2.OrganizationName, deepLink2},
+				},
+			}), []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 			Return(message.MessengerTypeTwilioSMS, nil).
 			Once()
 
@@ -303,17 +315,21 @@ func Test_SendReceiverWalletInviteService_SendInvite(t *testing.T) {
 		titleWallet2 := "You have a payment waiting for you from " + walletDeepLink2.OrganizationName
 
 		messageDispatcherMock.
-			On("SendMessage", mock.Anything, message.Message{
+			On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
 				ToPhoneNumber: receiverPhoneOnly.PhoneNumber,
 				Body:          contentWallet1,
-			}, []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+				Template: &message.MessageTemplate{
+					Type:      message.MessageTemplateTypeReceiverInvitation,
+					Variables: []string{walletDeepLink1.OrganizationName, deepLink1},
+				},
+			}), []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 			Return(message.MessengerTypeTwilioSMS, nil).
 			Once().
-			On("SendMessage", mock.Anything, message.Message{
+			On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
 				ToEmail: receiverEmailOnly.Email,
 				Body:    contentWallet2,
 				Title:   titleWallet2,
-			}, []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+			}), []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 			Return(message.MessengerTypeAWSEmail, nil).
 			Once()
 
@@ -441,20 +457,28 @@ func Test_SendReceiverWalletInviteService_SendInvite(t *testing.T) {
 		titleWallet2 := "You have a payment waiting for you from " + walletDeepLink2.OrganizationName
 
 		messageDispatcherMock.
-			On("SendMessage", mock.Anything, message.Message{
+			On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
 				ToPhoneNumber: receiver1.PhoneNumber,
 				ToEmail:       receiver1.Email,
 				Body:          contentWallet1,
 				Title:         titleWallet1,
-			}, []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+				Template: &message.MessageTemplate{
+					Type:      message.MessageTemplateTypeReceiverInvitation,
+					Variables: []string{walletDeepLink1.OrganizationName, deepLink1},
+				},
+			}), []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 			Return(message.MessengerTypeTwilioSMS, nil).
 			Once().
-			On("SendMessage", mock.Anything, message.Message{
+			On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
 				ToPhoneNumber: receiver2.PhoneNumber,
 				ToEmail:       receiver2.Email,
 				Body:          contentWallet2,
 				Title:         titleWallet2,
-			}, []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+				Template: &message.MessageTemplate{
+					Type:      message.MessageTemplateTypeReceiverInvitation,
+					Variables: []string{walletDeepLink2.OrganizationName, deepLink2},
+				},
+			}), []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 			Return(message.MessengerTypeTwilioSMS, nil).
 			Once()
 
@@ -739,12 +763,16 @@ func Test_SendReceiverWalletInviteService_SendInvite(t *testing.T) {
 		titleWallet1 := "You have a payment waiting for you from " + walletDeepLink1.OrganizationName
 
 		messageDispatcherMock.
-			On("SendMessage", mock.Anything, message.Message{
+			On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
 				ToPhoneNumber: receiver1.PhoneNumber,
 				ToEmail:       receiver1.Email,
 				Body:          contentWallet1,
 				Title:         titleWallet1,
-			}, []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+				Template: &message.MessageTemplate{
+					Type:      message.MessageTemplateTypeReceiverInvitation,
+					Variables: []string{walletDeepLink1.OrganizationName, deepLink1},
+				},
+			}), []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 			Return(message.MessengerTypeTwilioSMS, nil).
 			Once()
 
@@ -859,20 +887,28 @@ func Test_SendReceiverWalletInviteService_SendInvite(t *testing.T) {
 		titleDisbursement4 := "You have a payment waiting for you from " + walletDeepLink2.OrganizationName
 
 		messageDispatcherMock.
-			On("SendMessage", mock.Anything, message.Message{
+			On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
 				ToPhoneNumber: receiver1.PhoneNumber,
 				ToEmail:       receiver1.Email,
 				Body:          contentDisbursement3,
 				Title:         titleDisbursement3,
-			}, []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+				Template: &message.MessageTemplate{
+					Type:      message.MessageTemplateTypeReceiverInvitation,
+					Variables: []string{walletDeepLink1.OrganizationName, deepLink1},
+				},
+			}), []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 			Return(message.MessengerTypeTwilioSMS, nil).
 			Once().
-			On("SendMessage", mock.Anything, message.Message{
+			On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
 				ToPhoneNumber: receiver2.PhoneNumber,
 				ToEmail:       receiver2.Email,
 				Body:          contentDisbursement4,
 				Title:         titleDisbursement4,
-			}, []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+				Template: &message.MessageTemplate{
+					Type:      message.MessageTemplateTypeReceiverInvitation,
+					Variables: []string{walletDeepLink2.OrganizationName, deepLink2},
+				},
+			}), []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 			Return(message.MessengerTypeTwilioSMS, nil).
 			Once()
 
@@ -992,12 +1028,16 @@ func Test_SendReceiverWalletInviteService_SendInvite(t *testing.T) {
 		titleDisbursement := "You have a payment waiting for you from " + walletDeepLink1.OrganizationName
 
 		messageDispatcherMock.
-			On("SendMessage", mock.Anything, message.Message{
+			On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
 				ToPhoneNumber: receiver1.PhoneNumber,
 				ToEmail:       receiver1.Email,
 				Body:          contentDisbursement,
 				Title:         titleDisbursement,
-			}, []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail}).
+				Template: &message.MessageTemplate{
+					Type:      message.MessageTemplateTypeReceiverInvitation,
+					Variables: []string{walletDeepLink1.OrganizationName, deepLink1},
+				},
+			}), []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}).
 			Return(message.MessengerTypeTwilioSMS, nil).
 			Once()
 
@@ -1044,9 +1084,183 @@ func Test_SendReceiverWalletInviteService_SendInvite(t *testing.T) {
 		assert.Nil(t, msg.AssetID)
 	})
 
+	t.Run("resends the undelivered invitation through the fallback channels", func(t *testing.T) {
+		s, err := NewSendReceiverWalletInviteService(models, messageDispatcherMock, stellarSecretKey, 3, mockCrashTrackerClient)
+		require.NoError(t, err)
+
+		data.DeleteAllPaymentsFixtures(t, ctx, dbConnectionPool)
+		data.DeleteAllMessagesFixtures(t, ctx, dbConnectionPool)
+		data.DeleteAllReceiverWalletsFixtures(t, ctx, dbConnectionPool)
+
+		err = models.Organizations.Update(ctx, &data.OrganizationUpdate{ReceiverRegistrationMessageTemplate: new(string)})
+		require.NoError(t, err)
+
+		rec1RW := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver1.ID, wallet1.ID, data.ReadyReceiversWalletStatus)
+		_ = data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+			Status:         data.ReadyPaymentStatus,
+			Disbursement:   disbursement1,
+			Asset:          *asset1,
+			ReceiverWallet: rec1RW,
+			Amount:         "1",
+		})
+
+		// The invitation was just sent, so it wouldn't be resent automatically.
+		const q = "UPDATE receiver_wallets SET invitation_sent_at = NOW() WHERE id = $1"
+		_, err = dbConnectionPool.ExecContext(ctx, q, rec1RW.ID)
+		require.NoError(t, err)
+
+		undeliveredMsg := data.CreateMessageFixture(t, ctx, dbConnectionPool, &data.Message{
+			Type:             message.MessengerTypeTwilioSMS,
+			AssetID:          &asset1.ID,
+			ReceiverID:       receiver1.ID,
+			WalletID:         wallet1.ID,
+			ReceiverWalletID: &rec1RW.ID,
+			Status:           data.FailureMessageStatus,
+		})
+
+		walletDeepLink1 := WalletDeepLink{
+			DeepLink:         wallet1.DeepLinkSchema,
+			TenantBaseURL:    tenantBaseURL,
+			OrganizationName: "MyCustomAid",
+			AssetCode:        asset1.Code,
+			AssetIssuer:      asset1.Issuer,
+		}
+		deepLink1, err := walletDeepLink1.GetSignedRegistrationLink(stellarSecretKey)
+		require.NoError(t, err)
+		contentWallet1 := fmt.Sprintf("You have a payment waiting for you from the MyCustomAid. Click %s to register.", deepLink1)
+		titleWallet1 := "You have a payment waiting for you from " + walletDeepLink1.OrganizationName
+
+		messageDispatcherMock.
+			On("SendMessage", mock.Anything, invitationMatcher(tenantBaseURL, message.Message{
+				ToPhoneNumber: receiver1.PhoneNumber,
+				ToEmail:       receiver1.Email,
+				Body:          contentWallet1,
+				Title:         titleWallet1,
+				Template: &message.MessageTemplate{
+					Type:      message.MessageTemplateTypeReceiverInvitation,
+					Variables: []string{walletDeepLink1.OrganizationName, deepLink1},
+				},
+			}), []message.MessageChannel{message.MessageChannelEmail, message.MessageChannelWhatsApp}).
+			Return(message.MessengerTypeAWSEmail, nil).
+			Once()
+
+		err = s.SendInviteThroughFallback(ctx, undeliveredMsg)
+		require.NoError(t, err)
+
+		const msgQuery = `
+			SELECT
+				type, status, receiver_wallet_id, text_encrypted
+			FROM
+				messages
+			WHERE
+				receiver_wallet_id = $1 AND id != $2
+		`
+		var msg data.Message
+		err = dbConnectionPool.GetContext(ctx, &msg, msgQuery, rec1RW.ID, undeliveredMsg.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, message.MessengerTypeAWSEmail, msg.Type)
+		assert.Equal(t, data.SuccessMessageStatus, msg.Status)
+		assert.Equal(t, rec1RW.ID, *msg.ReceiverWalletID)
+		assert.Equal(t, contentWallet1, msg.TextEncrypted)
+	})
+
+	t.Run("doesn't resend the undelivered invitation when there's no fallback channel left", func(t *testing.T) {
+		s, err := NewSendReceiverWalletInviteService(models, messageDispatcherMock, stellarSecretKey, 3, mockCrashTrackerClient)
+		require.NoError(t, err)
+
+		data.DeleteAllPaymentsFixtures(t, ctx, dbConnectionPool)
+		data.DeleteAllMessagesFixtures(t, ctx, dbConnectionPool)
+		data.DeleteAllReceiverWalletsFixtures(t, ctx, dbConnectionPool)
+
+		rec1RW := data.CreateReceiverWalletFixture(t, ctx, dbConnectionPool, receiver1.ID, wallet1.ID, data.ReadyReceiversWalletStatus)
+		_ = data.CreatePaymentFixture(t, ctx, dbConnectionPool, models.Payment, &data.Payment{
+			Status:         data.ReadyPaymentStatus,
+			Disbursement:   disbursement1,
+			Asset:          *asset1,
+			ReceiverWallet: rec1RW,
+			Amount:         "1",
+		})
+
+		undeliveredMsg := data.CreateMessageFixture(t, ctx, dbConnectionPool, &data.Message{
+			Type:             message.MessengerTypeTwilioWhatsApp,
+			AssetID:          &asset1.ID,
+			ReceiverID:       receiver1.ID,
+			WalletID:         wallet1.ID,
+			ReceiverWalletID: &rec1RW.ID,
+			Status:           data.FailureMessageStatus,
+		})
+
+		err = s.SendInviteThroughFallback(ctx, undeliveredMsg)
+		require.NoError(t, err)
+
+		var count int
+		err = dbConnectionPool.GetContext(ctx, &count, "SELECT COUNT(*) FROM messages")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("doesn't resend the messages that aren't invitations", func(t *testing.T) {
+		s, err := NewSendReceiverWalletInviteService(models, messageDispatcherMock, stellarSecretKey, 3, mockCrashTrackerClient)
+		require.NoError(t, err)
+
+		err = s.SendInviteThroughFallback(ctx, &data.Message{ID: "message-id", Type: message.MessengerTypeTwilioSMS})
+		require.NoError(t, err)
+	})
+
 	messageDispatcherMock.AssertExpectations(t)
 }
 
+func Test_fallbackChannelPriority(t *testing.T) {
+	channelPriority := []message.MessageChannel{message.MessageChannelSMS, message.MessageChannelEmail, message.MessageChannelWhatsApp}
+	msg := message.Message{
+		ToPhoneNumber: "+14155556666",
+		ToEmail:       "receiver@test.com",
+		Title:         "title",
+		Body:          "body",
+		Template:      &message.MessageTemplate{Type: message.MessageTemplateTypeReceiverInvitation},
+	}
+	phoneOnlyMsg := message.Message{ToPhoneNumber: "+14155556666", Body: "body"}
+
+	testCases := []struct {
+		name                string
+		failedMessengerType message.MessengerType
+		msg                 message.Message
+		wantChannels        []message.MessageChannel
+	}{
+		{
+			name:                "returns the channels after the failed one",
+			failedMessengerType: message.MessengerTypeTwilioSMS,
+			msg:                 msg,
+			wantChannels:        []message.MessageChannel{message.MessageChannelEmail, message.MessageChannelWhatsApp},
+		},
+		{
+			name:                "skips the channels that can't send the message",
+			failedMessengerType: message.MessengerTypeTwilioSMS,
+			msg:                 phoneOnlyMsg,
+			wantChannels:        nil,
+		},
+		{
+			name:                "returns nothing after the last channel",
+			failedMessengerType: message.MessengerTypeTwilioWhatsApp,
+			msg:                 msg,
+			wantChannels:        nil,
+		},
+		{
+			name:                "returns nothing if the failed channel isn't in the priority",
+			failedMessengerType: message.MessengerType("UNKNOWN"),
+			msg:                 msg,
+			wantChannels:        nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantChannels, fallbackChannelPriority(channelPriority, tc.failedMessengerType, tc.msg))
+		})
+	}
+}
+
 func Test_SendReceiverWalletInviteService_shouldSendInvitation(t *testing.T) {
 	var maxInvitationResendAttempts int64 = 3
 	s := SendReceiverWalletInviteService{maxInvitationResendAttempts: maxInvitationResendAttempts}
@@ -1674,3 +1888,17 @@ func Test_WalletDeepLink_GetSignedRegistrationLink(t *testing.T) {
 		require.True(t, isValid)
 	})
 }
+
+// invitationMatcher matches the invitation sent through the message dispatcher, ignoring the message ID generated by the
+// service and checking the status callback URL built with it.
+func invitationMatcher(tenantBaseURL string, want message.Message) interface{} {
+	return mock.MatchedBy(func(got message.Message) bool {
+		wantCallbackURL, err := message.TwilioStatusCallbackURL(tenantBaseURL, got.ID)
+		if err != nil || got.ID == "" || got.StatusCallbackURL != wantCallbackURL {
+			return false
+		}
+
+		got.ID, got.StatusCallbackURL = "", ""
+		return assert.ObjectsAreEqual(want, got)
+	})
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httpclient"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+const (
+	// WebhookDeliveryMaxAttempts is the number of attempts after which a delivery is marked as failed.
+	WebhookDeliveryMaxAttempts = 8
+	// WebhookDeliveryBatchSize is the maximum number of deliveries sent on each execution.
+	WebhookDeliveryBatchSize = 50
+	// WebhookDeliveryTimeout is the maximum time to wait for a subscriber to respond.
+	WebhookDeliveryTimeout = 10 * time.Second
+
+	WebhookSignatureHeader  = "X-SDP-Signature"
+	WebhookEventTypeHeader  = "X-SDP-Event-Type"
+	WebhookDeliveryIDHeader = "X-SDP-Delivery-ID"
+
+	// webhookDeliveryClaimDuration is how long claimed deliveries are held before they can be claimed again, which is
+	// long enough to send a whole batch. Deliveries whose attempt isn't recorded, e.g. because the job crashed, are
+	// retried after that.
+	webhookDeliveryClaimDuration = WebhookDeliveryBatchSize * WebhookDeliveryTimeout
+)
+
+//go:generate mockery --name=WebhookDeliveryServiceInterface --case=underscore --structname=MockWebhookDeliveryService --filename=webhook_delivery_service.go
+type WebhookDeliveryServiceInterface interface {
+	SendPendingDeliveries(ctx context.Context) error
+}
+
+// WebhookDeliveryService sends the pending webhook deliveries of a tenant to their subscriptions.
+type WebhookDeliveryService struct {
+	Models               *data.Models
+	HTTPClient           httpclient.HttpClientInterface
+	Encrypter            utils.PrivateKeyEncrypter
+	EncryptionPassphrase string
+}
+
+var _ WebhookDeliveryServiceInterface = (*WebhookDeliveryService)(nil)
+
+func NewWebhookDeliveryService(models *data.Models, encryptionPassphrase string) *WebhookDeliveryService {
+	return &WebhookDeliveryService{
+		Models:               models,
+		HTTPClient:           NewWebhookHTTPClient(),
+		Encrypter:            &utils.DefaultPrivateKeyEncrypter{},
+		EncryptionPassphrase: encryptionPassphrase,
+	}
+}
+
+// NewWebhookHTTPClient returns the client used to send the deliveries. The subscription URLs are provided by the
+// tenant users, so the client only connects to public IPs and doesn't follow redirects, to prevent requests to internal
+// services.
+func NewWebhookHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: WebhookDeliveryTimeout,
+		Control: utils.PublicIPDialerControl,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would resolve and connect to the subscription host itself, skipping the dialer control.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   WebhookDeliveryTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// SignWebhookPayload returns the value of the signature header of a delivery: the timestamp it was sent at and the
+// hex-encoded HMAC-SHA256 of "<timestamp>.<payload>", keyed with the subscription secret. Subscribers should compute
+// the same signature and reject deliveries whose timestamp is too old, to prevent replays.
+func SignWebhookPayload(secret string, timestamp time.Time, payload []byte) string {
+	unixTimestamp := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unixTimestamp + "."))
+	mac.Write(payload)
+
+	return fmt.Sprintf("t=%s,v1=%s", unixTimestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// SendPendingDeliveries sends the pending deliveries that are due. Deliveries that fail are retried with exponential
+// backoff until they reach WebhookDeliveryMaxAttempts, after which they're marked as failed. The deliveries are claimed
+// before they're sent, so no database transaction is kept open while waiting for the subscribers.
+func (s *WebhookDeliveryService) SendPendingDeliveries(ctx context.Context) error {
+	now := time.Now()
+	deliveries, err := s.Models.WebhookDeliveries.ClaimPending(ctx, s.Models.DBConnectionPool, now, now.Add(webhookDeliveryClaimDuration), WebhookDeliveryBatchSize)
+	if err != nil {
+		return fmt.Errorf("claiming pending webhook deliveries: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	attempts := make([]data.WebhookDeliveryAttempt, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		attempts = append(attempts, s.send(ctx, delivery))
+	}
+
+	err = db.RunInTransaction(ctx, s.Models.DBConnectionPool, nil, func(dbTx db.DBTransaction) error {
+		for i, delivery := range deliveries {
+			if err = s.Models.WebhookDeliveries.UpdateAttempt(ctx, dbTx, delivery.ID, attempts[i]); err != nil {
+				return fmt.Errorf("updating attempt of webhook delivery %s: %w", delivery.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("recording webhook delivery attempts: %w", err)
+	}
+
+	return nil
+}
+
+// send posts a delivery to its subscription and returns the outcome of the attempt.
+func (s *WebhookDeliveryService) send(ctx context.Context, delivery *data.WebhookDelivery) data.WebhookDeliveryAttempt {
+	attempt := data.WebhookDeliveryAttempt{AttemptedAt: time.Now()}
+
+	responseStatus, err := s.post(ctx, delivery, attempt.AttemptedAt)
+	attempt.ResponseStatus = responseStatus
+	if err == nil {
+		attempt.Status = data.SuccessWebhookDeliveryStatus
+		return attempt
+	}
+
+	attempt.Error = err.Error()
+	attempts := delivery.Attempts + 1
+	if attempts >= WebhookDeliveryMaxAttempts {
+		log.Ctx(ctx).Warnf("Webhook delivery %s failed after %d attempts: %v", delivery.ID, attempts, err)
+		attempt.Status = data.FailedWebhookDeliveryStatus
+		return attempt
+	}
+
+	backoff, err := utils.ExponentialBackoffInSeconds(attempts)
+	if err != nil {
+		log.Ctx(ctx).Errorf("calculating backoff of webhook delivery %s: %v", delivery.ID, err)
+		attempt.Status = data.FailedWebhookDeliveryStatus
+		return attempt
+	}
+	attempt.Status = data.PendingWebhookDeliveryStatus
+	attempt.NextAttemptAt = attempt.AttemptedAt.Add(backoff)
+
+	return attempt
+}
+
+// subscriptionSecret returns the decrypted secret of the delivery subscription.
+func (s *WebhookDeliveryService) subscriptionSecret(delivery *data.WebhookDelivery) (string, error) {
+	secret, err := s.Encrypter.Decrypt(delivery.SubscriptionEncryptedSecret, s.EncryptionPassphrase)
+	if err != nil {
+		return "", fmt.Errorf("decrypting the subscription secret: %w", err)
+	}
+	return secret, nil
+}
+
+// post sends the signed delivery payload and returns the response status code, or an error if the delivery wasn't
+// acknowledged with a 2xx status. The response body isn't read, since it's controlled by the subscriber.
+func (s *WebhookDeliveryService) post(ctx context.Context, delivery *data.WebhookDelivery, sentAt time.Time) (int, error) {
+	secret, err := s.subscriptionSecret(delivery)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.SubscriptionURL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, sentAt, delivery.Payload))
+	req.Header.Set(WebhookEventTypeHeader, string(delivery.EventType))
+	req.Header.Set(WebhookDeliveryIDHeader, delivery.ID)
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	httpclientMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httpclient/mocks"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+func Test_SignWebhookPayload(t *testing.T) {
+	timestamp := time.Unix(1700000000, 0)
+	payload := []byte(`{"event_type":"payment.status_changed"}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(payload)))
+	wantSignature := "t=1700000000,v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, wantSignature, SignWebhookPayload("secret", timestamp, payload))
+	assert.NotEqual(t, wantSignature, SignWebhookPayload("another-secret", timestamp, payload))
+}
+
+func Test_NewWebhookHTTPClient(t *testing.T) {
+	client := NewWebhookHTTPClient()
+
+	t.Run("redirects are not followed", func(t *testing.T) {
+		assert.ErrorIs(t, client.CheckRedirect(nil, nil), http.ErrUseLastResponse)
+	})
+
+	t.Run("connections to non-public IPs are rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		resp, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+		if resp != nil {
+			resp.Body.Close()
+		}
+		assert.ErrorIs(t, err, utils.ErrNonPublicIP)
+	})
+}
+
+func Test_WebhookDeliveryService_send(t *testing.T) {
+	ctx := context.Background()
+	encryptionKP := keypair.MustRandom()
+	encrypter := &utils.DefaultPrivateKeyEncrypter{}
+	encryptedSecret, err := encrypter.Encrypt("secret", encryptionKP.Seed())
+	require.NoError(t, err)
+	delivery := &data.WebhookDelivery{
+		ID:                          "delivery-id",
+		EventType:                   data.PaymentStatusChangedWebhookEventType,
+		Payload:                     []byte(`{"event_type":"payment.status_changed"}`),
+		SubscriptionURL:             "https://example.com/webhooks",
+		SubscriptionEncryptedSecret: encryptedSecret,
+	}
+	newService := func(httpClient *httpclientMocks.HttpClientMock) *WebhookDeliveryService {
+		return &WebhookDeliveryService{HTTPClient: httpClient, Encrypter: encrypter, EncryptionPassphrase: encryptionKP.Seed()}
+	}
+	signedWithSecret := func(secret string) func(req *http.Request) bool {
+		return func(req *http.Request) bool {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			signature := req.Header.Get(WebhookSignatureHeader)
+			timestamp, err := strconv.ParseInt(strings.TrimPrefix(strings.Split(signature, ",")[0], "t="), 10, 64)
+			require.NoError(t, err)
+			return req.Method == http.MethodPost &&
+				req.URL.String() == delivery.SubscriptionURL &&
+				string(body) == string(delivery.Payload) &&
+				req.Header.Get(WebhookEventTypeHeader) == string(delivery.EventType) &&
+				req.Header.Get(WebhookDeliveryIDHeader) == delivery.ID &&
+				signature == SignWebhookPayload(secret, time.Unix(timestamp, 0), delivery.Payload)
+		}
+	}
+
+	t.Run("🎉 the delivery succeeds on a 2xx response", func(t *testing.T) {
+		httpClientMock := httpclientMocks.NewHttpClientMock(t)
+		httpClientMock.
+			On("Do", mock.MatchedBy(signedWithSecret("secret"))).
+			Return(&http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))}, nil).
+			Once()
+
+		service := newService(httpClientMock)
+		attempt := service.send(ctx, delivery)
+		assert.Equal(t, data.SuccessWebhookDeliveryStatus, attempt.Status)
+		assert.Equal(t, http.StatusNoContent, attempt.ResponseStatus)
+		assert.Empty(t, attempt.Error)
+		assert.True(t, attempt.NextAttemptAt.IsZero())
+	})
+
+	t.Run("the delivery is retried with exponential backoff on an unexpected response", func(t *testing.T) {
+		httpClientMock := httpclientMocks.NewHttpClientMock(t)
+		httpClientMock.
+			On("Do", mock.Anything).
+			Return(&http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader("boom"))}, nil).
+			Once()
+
+		service := newService(httpClientMock)
+		retriedDelivery := *delivery
+		retriedDelivery.Attempts = 2
+		attempt := service.send(ctx, &retriedDelivery)
+		assert.Equal(t, data.PendingWebhookDeliveryStatus, attempt.Status)
+		assert.Equal(t, http.StatusInternalServerError, attempt.ResponseStatus)
+		assert.Equal(t, "unexpected status code 500", attempt.Error)
+		assert.Equal(t, 8*time.Second, attempt.NextAttemptAt.Sub(attempt.AttemptedAt))
+	})
+
+	t.Run("the delivery fails after the maximum number of attempts", func(t *testing.T) {
+		httpClientMock := httpclientMocks.NewHttpClientMock(t)
+		httpClientMock.
+			On("Do", mock.Anything).
+			Return(nil, errors.New("connection refused")).
+			Once()
+
+		service := newService(httpClientMock)
+		lastDelivery := *delivery
+		lastDelivery.Attempts = WebhookDeliveryMaxAttempts - 1
+		attempt := service.send(ctx, &lastDelivery)
+		assert.Equal(t, data.FailedWebhookDeliveryStatus, attempt.Status)
+		assert.Zero(t, attempt.ResponseStatus)
+		assert.Equal(t, "sending request: connection refused", attempt.Error)
+		assert.True(t, attempt.NextAttemptAt.IsZero())
+	})
+
+	t.Run("the delivery is retried if the secret can't be decrypted", func(t *testing.T) {
+		service := newService(httpclientMocks.NewHttpClientMock(t))
+		service.EncryptionPassphrase = keypair.MustRandom().Seed()
+		attempt := service.send(ctx, delivery)
+		assert.Equal(t, data.PendingWebhookDeliveryStatus, attempt.Status)
+		assert.Contains(t, attempt.Error, "decrypting the subscription secret")
+	})
+}
+
+func Test_WebhookDeliveryService_SendPendingDeliveries(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	models, err := data.NewModels(dbConnectionPool)
+	require.NoError(t, err)
+
+	subscription := data.CreateWebhookSubscriptionFixture(t, ctx, dbConnectionPool, models.WebhookSubscriptions, data.WebhookSubscriptionInsert{
+		EventTypes: data.WebhookEventTypes{data.DisbursementStatusChangedWebhookEventType},
+	})
+	defer data.DeleteAllWebhookFixtures(t, ctx, dbConnectionPool)
+
+	disbursement := data.CreateDisbursementFixture(t, ctx, dbConnectionPool, models.Disbursements, &data.Disbursement{Status: data.DraftDisbursementStatus})
+	err = models.Disbursements.UpdateStatus(ctx, dbConnectionPool, "user-id", disbursement.ID, data.ReadyDisbursementStatus)
+	require.NoError(t, err)
+
+	httpClientMock := httpclientMocks.NewHttpClientMock(t)
+	httpClientMock.
+		On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == subscription.URL
+		})).
+		Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil).
+		Once()
+
+	service := &WebhookDeliveryService{Models: models, HTTPClient: httpClientMock}
+	err = service.SendPendingDeliveries(ctx)
+	require.NoError(t, err)
+
+	deliveries, err := models.WebhookDeliveries.GetAllBySubscriptionID(ctx, dbConnectionPool, subscription.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, data.SuccessWebhookDeliveryStatus, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+
+	// Sent deliveries are not sent again.
+	err = service.SendPendingDeliveries(ctx)
+	require.NoError(t, err)
+}
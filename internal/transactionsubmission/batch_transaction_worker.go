@@ -0,0 +1,571 @@
+package transactionsubmission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/txnbuild"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/crashtracker"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/events"
+	sdpMonitor "github.com/stellar/stellar-disbursement-platform-backend/internal/monitor"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine"
+	tssMonitor "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/monitor"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/utils"
+	tssUtils "github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+type TxBatchJob store.ChannelTransactionBatchBundle
+
+func (job TxBatchJob) String() string {
+	txIDs := make([]string, 0, len(job.Transactions))
+	for _, tx := range job.Transactions {
+		txIDs = append(txIDs, tx.ID)
+	}
+	return fmt.Sprintf("TxBatchJob{ChannelAccount: %q, Transactions: %v, LockedUntilLedgerNumber: \"%d\"}", job.ChannelAccount.PublicKey, txIDs, job.LockedUntilLedgerNumber)
+}
+
+// BatchTransactionWorker processes a batch of transactions, submitting them together in a single Stellar transaction
+// with one operation per transaction.
+type BatchTransactionWorker struct {
+	tw TransactionWorker
+}
+
+func NewBatchTransactionWorker(
+	dbConnectionPool db.DBConnectionPool,
+	txModel *store.TransactionModel,
+	chAccModel *store.ChannelAccountModel,
+	engine *engine.SubmitterEngine,
+	crashTrackerClient crashtracker.CrashTrackerClient,
+	txProcessingLimiter engine.TransactionProcessingLimiter,
+	monitorSvc tssMonitor.TSSMonitorService,
+	eventProducer events.Producer,
+) (BatchTransactionWorker, error) {
+	tw, err := NewTransactionWorker(dbConnectionPool, txModel, chAccModel, engine, crashTrackerClient, txProcessingLimiter, monitorSvc, eventProducer)
+	if err != nil {
+		return BatchTransactionWorker{}, err
+	}
+
+	return BatchTransactionWorker{tw: tw}, nil
+}
+
+// updateContextLogger will update the context logger with the batch job details.
+func (bw *BatchTransactionWorker) updateContextLogger(ctx context.Context, job *TxBatchJob) context.Context {
+	txIDs := make([]string, 0, len(job.Transactions))
+	for _, tx := range job.Transactions {
+		txIDs = append(txIDs, tx.ID)
+	}
+
+	labels := map[string]interface{}{
+		// Instance info
+		"app_version":     bw.tw.monitorSvc.Version,
+		"git_commit_hash": bw.tw.monitorSvc.GitCommitHash,
+		// Job info
+		"event_id": bw.tw.jobUUID,
+		// Batch info
+		"channel_account": job.ChannelAccount.PublicKey,
+		"tx_ids":          strings.Join(txIDs, ","),
+		"batch_size":      len(job.Transactions),
+	}
+	if len(job.Transactions) > 0 {
+		labels["tenant_id"] = job.Transactions[0].TenantID
+		if job.Transactions[0].StellarTransactionHash.Valid {
+			labels["tx_hash"] = job.Transactions[0].StellarTransactionHash.String
+		}
+	}
+
+	return log.Set(ctx, log.Ctx(ctx).WithFields(labels))
+}
+
+func (bw *BatchTransactionWorker) Run(ctx context.Context, job *TxBatchJob) {
+	ctx = bw.updateContextLogger(ctx, job)
+	err := bw.runJob(ctx, job)
+	if err != nil {
+		bw.tw.crashTrackerClient.LogAndReportErrors(ctx, err, "unexpected TSS error")
+	}
+}
+
+func (bw *BatchTransactionWorker) runJob(ctx context.Context, job *TxBatchJob) error {
+	err := bw.validateJob(job)
+	if err != nil {
+		return fmt.Errorf("validating batch job: %w", err)
+	}
+
+	if job.Transactions[0].StellarTransactionHash.Valid {
+		return bw.reconcileSubmittedBatch(ctx, job)
+	}
+	return bw.processBatchSubmission(ctx, job)
+}
+
+// validateJob will check if the batch job is valid for processing or reconciliation. All transactions in the batch
+// must belong to the same tenant, and either none or all of them must have been submitted in the same Stellar
+// transaction.
+func (bw *BatchTransactionWorker) validateJob(job *TxBatchJob) error {
+	if job == nil {
+		return fmt.Errorf("batch job cannot be nil")
+	}
+
+	if len(job.Transactions) == 0 || len(job.Transactions) > store.MaxTransactionsPerBatch {
+		return fmt.Errorf("batch job must have between 1 and %d transactions, got %d", store.MaxTransactionsPerBatch, len(job.Transactions))
+	}
+
+	currentLedgerNumber, err := bw.tw.engine.LedgerNumberTracker.GetLedgerNumber()
+	if err != nil {
+		return fmt.Errorf("getting current ledger number: %w", err)
+	}
+
+	if !job.ChannelAccount.IsLocked(int32(currentLedgerNumber)) {
+		return fmt.Errorf("channel account should be locked")
+	}
+
+	firstTx := job.Transactions[0]
+	allowedStatuses := []store.TransactionStatus{store.TransactionStatusPending, store.TransactionStatusProcessing}
+	for _, tx := range job.Transactions {
+		if !slices.Contains(allowedStatuses, tx.Status) {
+			return fmt.Errorf("invalid status %v for transaction %s", tx.Status, tx.ID)
+		}
+
+		if !tx.IsLocked(int32(currentLedgerNumber)) {
+			return fmt.Errorf("transaction %s should be locked", tx.ID)
+		}
+
+		if tx.TenantID != firstTx.TenantID {
+			return fmt.Errorf("all transactions in a batch must belong to the same tenant")
+		}
+
+		if tx.StellarTransactionHash != firstTx.StellarTransactionHash {
+			return fmt.Errorf("all transactions in a batch must share the same Stellar transaction hash")
+		}
+
+		if tx.StellarMemo() != firstTx.StellarMemo() {
+			return fmt.Errorf("all transactions in a batch must share the same memo")
+		}
+	}
+
+	return nil
+}
+
+func (bw *BatchTransactionWorker) processBatchSubmission(ctx context.Context, job *TxBatchJob) error {
+	log.Ctx(ctx).Infof("🚧 Processing batch submission for job %v...", job)
+
+	for _, tx := range job.Transactions {
+		bw.tw.monitorSvc.LogAndMonitorTransaction(ctx, tx, sdpMonitor.PaymentProcessingStartedTag, tssMonitor.TxMetadata{
+			EventID:          bw.tw.jobUUID,
+			SrcChannelAcc:    job.ChannelAccount.PublicKey,
+			PaymentEventType: sdpMonitor.PaymentProcessingStartedLabel,
+		})
+	}
+
+	// STEP 1: prepare batch for processing
+	feeBumpTx, err := bw.prepareForSubmission(ctx, job)
+	if err != nil {
+		return fmt.Errorf("preparing batch for processing: %w", err)
+	}
+
+	// STEP 2: submit batch
+	resp, err := bw.tw.engine.HorizonClient.SubmitFeeBumpTransactionWithOptions(feeBumpTx, horizonclient.SubmitTxOpts{SkipMemoRequiredCheck: true})
+	if err != nil {
+		err = bw.handleFailedBatch(ctx, job, resp, utils.NewHorizonErrorWrapper(err))
+		if err != nil {
+			return fmt.Errorf("handling failed batch: %w", err)
+		}
+		return nil
+	}
+
+	err = bw.handleSuccessfulBatch(ctx, job, resp)
+	if err != nil {
+		return fmt.Errorf("handling successful batch: %w", err)
+	}
+
+	for _, tx := range job.Transactions {
+		eventType := sdpMonitor.PaymentProcessingSuccessfulLabel
+		if tx.AttemptsCount > 1 {
+			eventType = sdpMonitor.PaymentReprocessingSuccessfulLabel
+		}
+		bw.tw.monitorSvc.LogAndMonitorTransaction(ctx, tx, sdpMonitor.PaymentTransactionSuccessfulTag, tssMonitor.TxMetadata{
+			EventID:          bw.tw.jobUUID,
+			SrcChannelAcc:    job.ChannelAccount.PublicKey,
+			PaymentEventType: eventType,
+		})
+	}
+
+	return nil
+}
+
+// prepareForSubmission builds and signs the batch transaction, and saves its hash and XDR in all the transactions of the
+// batch before it gets submitted.
+func (bw *BatchTransactionWorker) prepareForSubmission(ctx context.Context, job *TxBatchJob) (*txnbuild.FeeBumpTransaction, error) {
+	feeBumpTx, err := bw.buildAndSignBatchTransaction(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("building batch transaction: %w", err)
+	}
+
+	feeBumpTxHash, err := feeBumpTx.HashHex(bw.tw.engine.SignatureService.NetworkPassphrase())
+	if err != nil {
+		return nil, fmt.Errorf("hashing transaction for job %v: %w", job, err)
+	}
+
+	sentXDR, err := feeBumpTx.Base64()
+	if err != nil {
+		return nil, fmt.Errorf("getting envelopeXDR for job %v: %w", job, err)
+	}
+
+	txIDs := make([]string, 0, len(job.Transactions))
+	for _, tx := range job.Transactions {
+		txIDs = append(txIDs, tx.ID)
+	}
+	updatedTxs, err := bw.tw.txModel.UpdateStellarTransactionHashAndXDRSentForBatch(ctx, bw.tw.dbConnectionPool, txIDs, feeBumpTxHash, sentXDR)
+	if err != nil {
+		return nil, fmt.Errorf("saving transaction metadata for job %v: %w", job, err)
+	}
+	bw.replaceTransactions(job, updatedTxs)
+
+	return feeBumpTx, nil
+}
+
+// buildAndSignBatchTransaction builds & signs a Stellar transaction with one payment operation per transaction in the
+// batch, wrapped in a fee-bump transaction.
+func (bw *BatchTransactionWorker) buildAndSignBatchTransaction(ctx context.Context, job *TxBatchJob) (*txnbuild.FeeBumpTransaction, error) {
+	firstTx := job.Transactions[0]
+	distributionAccount, err := bw.tw.resolveStellarDistributionAccount(ctx, firstTx.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	memo, err := firstTx.StellarMemo().ToTxnbuildMemo()
+	if err != nil {
+		return nil, fmt.Errorf("building memo for job %v: %w", job, err)
+	}
+
+	operations := make([]txnbuild.Operation, 0, len(job.Transactions))
+	for _, tx := range job.Transactions {
+		operation, opErr := bw.tw.buildTransactionOperation(&tx, distributionAccount.Address)
+		if opErr != nil {
+			return nil, fmt.Errorf("building operation for transaction %s: %w", tx.ID, opErr)
+		}
+		operations = append(operations, operation)
+	}
+
+	feeBumpTx, err := bw.tw.buildAndSignFeeBumpTransaction(ctx, job.ChannelAccount.PublicKey, job.LockedUntilLedgerNumber, distributionAccount, memo, operations)
+	if err != nil {
+		return nil, fmt.Errorf("building transaction for job %v: %w", job, err)
+	}
+
+	return feeBumpTx, nil
+}
+
+// replaceTransactions replaces the transactions in the job with their updated versions, keeping the original order.
+func (bw *BatchTransactionWorker) replaceTransactions(job *TxBatchJob, updatedTxs []*store.Transaction) {
+	updatedTxsByID := make(map[string]*store.Transaction, len(updatedTxs))
+	for _, updatedTx := range updatedTxs {
+		updatedTxsByID[updatedTx.ID] = updatedTx
+	}
+	for i, tx := range job.Transactions {
+		if updatedTx, ok := updatedTxsByID[tx.ID]; ok {
+			job.Transactions[i] = *updatedTx
+		}
+	}
+}
+
+// saveResponseXDRIfPresent saves the result XDR in all the transactions of the batch.
+func (bw *BatchTransactionWorker) saveResponseXDRIfPresent(ctx context.Context, job *TxBatchJob, resp horizon.Transaction) error {
+	if tssUtils.IsEmpty(resp) {
+		return nil
+	}
+
+	for i, tx := range job.Transactions {
+		updatedTx, err := bw.tw.txModel.UpdateStellarTransactionXDRReceived(ctx, tx.ID, resp.ResultXdr)
+		if err != nil {
+			return fmt.Errorf("updating XDRReceived(%s) for transaction %s: %w", resp.ResultXdr, tx.ID, err)
+		}
+		job.Transactions[i] = *updatedTx
+	}
+
+	return nil
+}
+
+// handleSuccessfulBatch will wrap up the job when the batch transaction has been successfully submitted to the network,
+// marking all of its transactions as SUCCESS.
+func (bw *BatchTransactionWorker) handleSuccessfulBatch(ctx context.Context, job *TxBatchJob, hTxResp horizon.Transaction) error {
+	err := bw.saveResponseXDRIfPresent(ctx, job, hTxResp)
+	if err != nil {
+		return fmt.Errorf("saving response XDR: %w", err)
+	}
+	if !hTxResp.Successful {
+		return fmt.Errorf("transaction was not successful for some reason")
+	}
+
+	for i, tx := range job.Transactions {
+		updatedTx, cbErr := bw.tw.saveClaimableBalanceIDIfNeeded(ctx, tx, hTxResp.ResultXdr, i)
+		if cbErr != nil {
+			return fmt.Errorf("saving claimable balance ID: %w", cbErr)
+		}
+
+		updatedTx, ppErr := bw.tw.savePathPaymentResultIfNeeded(ctx, *updatedTx, hTxResp.ResultXdr, i)
+		if ppErr != nil {
+			return fmt.Errorf("saving path payment result: %w", ppErr)
+		}
+		job.Transactions[i] = *updatedTx
+	}
+
+	// Building the payment completed events before updating the transaction statuses. This way, if a message fails to
+	// be built, the transactions will be marked for reprocessing -> reconciliation and the events will be re-tried.
+	msgs := make([]*events.Message, 0, len(job.Transactions))
+	for _, tx := range job.Transactions {
+		msg, msgErr := bw.tw.buildPaymentCompletedEvent(events.PaymentCompletedSuccessType, &tx, data.SuccessPaymentStatus, "")
+		if msgErr != nil {
+			return fmt.Errorf("building payment completed event for transaction %s: %w", tx.ID, msgErr)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	for i, tx := range job.Transactions {
+		updatedTx, updateErr := bw.tw.txModel.UpdateStatusToSuccess(ctx, tx)
+		if updateErr != nil {
+			return utils.NewTransactionStatusUpdateError("SUCCESS", tx.ID, false, updateErr)
+		}
+		job.Transactions[i] = *updatedTx
+	}
+
+	err = events.ProduceEvents(ctx, bw.tw.eventProducer, msgs...)
+	if err != nil {
+		return fmt.Errorf("producing payment completed events for job %v: %w", job, err)
+	}
+
+	err = bw.unlockJob(ctx, job)
+	if err != nil {
+		return fmt.Errorf("unlocking job: %w", err)
+	}
+
+	log.Ctx(ctx).Infof("🎉 Successfully processed batch job %v", job)
+
+	return nil
+}
+
+// handleFailedBatch will wrap up the job when the batch transaction was submitted to the network but failed.
+//
+// When Horizon returns result codes, the transaction was rejected and none of its operations were applied, so the batch
+// is split: transactions whose operations failed with a definitive error are marked as ERROR, and the remaining ones
+// are pushed back to the queue to be re-batched and retried. Any other error unlocks the job, so the batch can be
+// reconciled later on, in the same way it's done for single transactions.
+func (bw *BatchTransactionWorker) handleFailedBatch(ctx context.Context, job *TxBatchJob, hTxResp horizon.Transaction, hErr *utils.HorizonErrorWrapper) error {
+	log.Ctx(ctx).Errorf("🔴 Error processing batch job: %v", hErr)
+
+	err := bw.saveResponseXDRIfPresent(ctx, job, hTxResp)
+	if err != nil {
+		return fmt.Errorf("saving response XDR: %w", err)
+	}
+
+	isHorizonErr := hErr != nil && hErr.IsHorizonError()
+	defer func() {
+		for _, tx := range job.Transactions {
+			bw.tw.monitorSvc.LogAndMonitorTransaction(ctx, tx, sdpMonitor.PaymentErrorTag, tssMonitor.TxMetadata{
+				EventID:          bw.tw.jobUUID,
+				SrcChannelAcc:    job.ChannelAccount.PublicKey,
+				IsHorizonErr:     isHorizonErr,
+				PaymentEventType: sdpMonitor.PaymentMarkedForReprocessingLabel,
+			})
+		}
+	}()
+
+	if isHorizonErr {
+		bw.tw.txProcessingLimiter.AdjustLimitIfNeeded(hErr)
+
+		if hErr.ShouldMarkAsError() && !hErr.IsDestinationAccountNotReady() {
+			bw.tw.crashTrackerClient.LogAndReportErrors(ctx, hErr, "batch transaction error - cannot be retried")
+		} else if hErr.IsBadSequence() {
+			bw.tw.crashTrackerClient.LogAndReportErrors(ctx, hErr, "tx_bad_seq detected!")
+		}
+
+		if hErr.HasResultCodes() {
+			return bw.splitFailedBatch(ctx, job, hErr.HasDefinitiveTransactionError(), hErr.ResultCodes.OperationCodes, hErr.Error())
+		}
+	}
+
+	err = bw.unlockJob(ctx, job)
+	if err != nil {
+		return fmt.Errorf("unlocking job: %w", err)
+	}
+
+	return nil
+}
+
+// splitFailedBatch handles a batch whose Stellar transaction was definitely not applied. Transactions are marked as
+// ERROR if the whole transaction failed with a definitive error, or if their own operation failed with a definitive
+// error. The other transactions are pushed back to the queue, so they can be retried in a new batch.
+func (bw *BatchTransactionWorker) splitFailedBatch(ctx context.Context, job *TxBatchJob, isDefinitiveTxErr bool, opCodes []string, errMsg string) error {
+	var failedTxs, retryTxs []store.Transaction
+	failedMsgs := map[string]string{}
+	for i, tx := range job.Transactions {
+		// The batch may have been loaded partially, so the operation index saved upon submission takes precedence.
+		opIndex := i
+		if tx.OperationIndex.Valid {
+			opIndex = int(tx.OperationIndex.Int32)
+		}
+		opCode := ""
+		if opIndex < len(opCodes) {
+			opCode = opCodes[opIndex]
+		}
+
+		switch {
+		case isDefinitiveTxErr:
+			failedTxs = append(failedTxs, tx)
+			failedMsgs[tx.ID] = errMsg
+		case utils.IsDefinitiveOperationErrorCode(opCode):
+			failedTxs = append(failedTxs, tx)
+			failedMsgs[tx.ID] = fmt.Sprintf("%s - operation %d failed with %s", errMsg, opIndex, opCode)
+		default:
+			retryTxs = append(retryTxs, tx)
+		}
+	}
+
+	// Building the payment completed events before updating the transaction statuses, so that a failure here leaves the
+	// batch to be reconciled later.
+	msgs := make([]*events.Message, 0, len(failedTxs))
+	for _, tx := range failedTxs {
+		msg, err := bw.tw.buildPaymentCompletedEvent(events.PaymentCompletedErrorType, &tx, data.FailedPaymentStatus, failedMsgs[tx.ID])
+		if err != nil {
+			return fmt.Errorf("building payment completed event for transaction %s: %w", tx.ID, err)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	for _, tx := range failedTxs {
+		_, err := bw.tw.txModel.UpdateStatusToError(ctx, tx, failedMsgs[tx.ID])
+		if err != nil {
+			return fmt.Errorf("updating transaction %s status to error: %w", tx.ID, err)
+		}
+	}
+
+	err := events.ProduceEvents(ctx, bw.tw.eventProducer, msgs...)
+	if err != nil {
+		return fmt.Errorf("producing payment completed events for job %v: %w", job, err)
+	}
+
+	for _, tx := range retryTxs {
+		_, err = bw.tw.txModel.PrepareTransactionForReprocessing(ctx, bw.tw.dbConnectionPool, tx.ID)
+		if err != nil {
+			return fmt.Errorf("pushing back transaction %s to queue: %w", tx.ID, err)
+		}
+	}
+
+	if len(failedTxs) > 0 {
+		log.Ctx(ctx).Warnf("Batch job %v failed: %d transaction(s) marked as ERROR and %d pushed back to the queue", job, len(failedTxs), len(retryTxs))
+	}
+
+	// The transactions pushed back to the queue were already unlocked by PrepareTransactionForReprocessing.
+	_, err = bw.tw.chAccModel.Unlock(ctx, bw.tw.dbConnectionPool, job.ChannelAccount.PublicKey)
+	if err != nil {
+		return fmt.Errorf("unlocking channel account: %w", err)
+	}
+
+	for _, tx := range failedTxs {
+		_, err = bw.tw.txModel.Unlock(ctx, bw.tw.dbConnectionPool, tx.ID)
+		if err != nil {
+			return fmt.Errorf("unlocking transaction %s: %w", tx.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileSubmittedBatch will check the status of a previously submitted batch and handle it accordingly.
+func (bw *BatchTransactionWorker) reconcileSubmittedBatch(ctx context.Context, job *TxBatchJob) error {
+	log.Ctx(ctx).Infof("🔍 Reconciling previously submitted batch %v...", job)
+
+	txHash := job.Transactions[0].StellarTransactionHash.String
+	txDetail, err := bw.tw.engine.HorizonClient.TransactionDetail(txHash)
+	hWrapperErr := utils.NewHorizonErrorWrapper(err)
+	switch {
+	case err == nil && txDetail.Successful:
+		err = bw.handleSuccessfulBatch(ctx, job, txDetail)
+		if err != nil {
+			bw.logAndMonitorReconciliation(ctx, job, sdpMonitor.PaymentReconciliationFailureTag, sdpMonitor.PaymentReconciliationUnexpectedErrorLabel, false, err.Error())
+			return fmt.Errorf("handling successful batch: %w", err)
+		}
+		bw.logAndMonitorReconciliation(ctx, job, sdpMonitor.PaymentReconciliationSuccessfulTag, sdpMonitor.PaymentReconciliationTransactionSuccessfulLabel, false, "")
+		return nil
+
+	case err == nil && !txDetail.Successful:
+		// The transaction made it to the ledger but failed, so none of its operations were applied.
+		log.Ctx(ctx).Warnf("Previous batch transaction failed on-chain, splitting %v...", job)
+		err = bw.saveResponseXDRIfPresent(ctx, job, txDetail)
+		if err != nil {
+			return fmt.Errorf("saving response XDR: %w", err)
+		}
+
+		var opCodes []string
+		opCodes, err = utils.OperationResultCodes(txDetail.ResultXdr)
+		if err != nil {
+			return fmt.Errorf("parsing operation result codes: %w", err)
+		}
+
+		err = bw.splitFailedBatch(ctx, job, false, opCodes, fmt.Sprintf("transaction %s failed", txHash))
+		if err != nil {
+			return fmt.Errorf("splitting failed batch: %w", err)
+		}
+		bw.logAndMonitorReconciliation(ctx, job, sdpMonitor.PaymentReconciliationSuccessfulTag, sdpMonitor.PaymentReconciliationMarkedForReprocessingLabel, false, "")
+		return nil
+
+	case !hWrapperErr.IsNotFound():
+		log.Ctx(ctx).Warnf("received unexpected horizon error: %v", hWrapperErr)
+		bw.logAndMonitorReconciliation(ctx, job, sdpMonitor.PaymentReconciliationFailureTag, sdpMonitor.PaymentReconciliationUnexpectedErrorLabel, true, hWrapperErr.Error())
+		return fmt.Errorf("unexpected error: %w", hWrapperErr)
+	}
+
+	log.Ctx(ctx).Warnf("Previous batch transaction didn't make through, marking %v for resubmission...", job)
+
+	for _, tx := range job.Transactions {
+		_, err = bw.tw.txModel.PrepareTransactionForReprocessing(ctx, bw.tw.dbConnectionPool, tx.ID)
+		if err != nil {
+			return fmt.Errorf("pushing back transaction %s to queue: %w", tx.ID, err)
+		}
+	}
+
+	err = bw.unlockJob(ctx, job)
+	if err != nil {
+		return fmt.Errorf("unlocking job: %w", err)
+	}
+
+	bw.logAndMonitorReconciliation(ctx, job, sdpMonitor.PaymentReconciliationSuccessfulTag, sdpMonitor.PaymentReconciliationMarkedForReprocessingLabel, false, "")
+
+	return nil
+}
+
+func (bw *BatchTransactionWorker) logAndMonitorReconciliation(ctx context.Context, job *TxBatchJob, tag sdpMonitor.MetricTag, eventType string, isHorizonErr bool, errStack string) {
+	for _, tx := range job.Transactions {
+		bw.tw.monitorSvc.LogAndMonitorTransaction(ctx, tx, tag, tssMonitor.TxMetadata{
+			EventID:          bw.tw.jobUUID,
+			SrcChannelAcc:    job.ChannelAccount.PublicKey,
+			IsHorizonErr:     isHorizonErr,
+			ErrStack:         errStack,
+			PaymentEventType: eventType,
+		})
+	}
+}
+
+// unlockJob will unlock the channel account and all the transactions of the batch.
+func (bw *BatchTransactionWorker) unlockJob(ctx context.Context, job *TxBatchJob) error {
+	_, err := bw.tw.chAccModel.Unlock(ctx, bw.tw.dbConnectionPool, job.ChannelAccount.PublicKey)
+	if err != nil {
+		return fmt.Errorf("unlocking channel account: %w", err)
+	}
+
+	for _, tx := range job.Transactions {
+		_, err = bw.tw.txModel.Unlock(ctx, bw.tw.dbConnectionPool, tx.ID)
+		if err != nil && !errors.Is(err, store.ErrRecordNotFound) {
+			return fmt.Errorf("unlocking transaction %s: %w", tx.ID, err)
+		}
+	}
+
+	return nil
+}
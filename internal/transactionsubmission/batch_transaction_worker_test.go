@@ -0,0 +1,320 @@
+package transactionsubmission
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/data"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/events"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/events/schemas"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine"
+	preconditionsMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/preconditions/mocks"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
+)
+
+// createTxBatchJobFixture is used to create the resources needed for a TxBatchJob with {numTxs} transactions of the
+// same tenant, and return a locked TxBatchJob with these resources.
+func createTxBatchJobFixture(t *testing.T, ctx context.Context, dbConnectionPool db.DBConnectionPool, numTxs int, currentLedger, lockedToLedger int, tenantID string) TxBatchJob {
+	t.Helper()
+
+	txModel := store.NewTransactionModel(dbConnectionPool)
+	chAccModel := store.NewChannelAccountModel(dbConnectionPool)
+
+	transactions := store.CreateTransactionFixturesNew(t, ctx, dbConnectionPool, numTxs, store.TransactionFixture{
+		AssetCode:          "USDC",
+		AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+		DestinationAddress: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
+		Status:             store.TransactionStatusProcessing,
+		Amount:             "1.0000000",
+		TenantID:           tenantID,
+	})
+	lockedTxs := make([]store.Transaction, 0, numTxs)
+	for _, tx := range transactions {
+		lockedTx, err := txModel.Lock(ctx, dbConnectionPool, tx.ID, int32(currentLedger), int32(lockedToLedger))
+		require.NoError(t, err)
+		lockedTxs = append(lockedTxs, *lockedTx)
+	}
+
+	chAcc := store.CreateChannelAccountFixturesEncrypted(t, ctx, dbConnectionPool, encrypter, chAccEncryptionPassphrase, 1)[0]
+	chAcc, err := chAccModel.Lock(ctx, dbConnectionPool, chAcc.PublicKey, int32(currentLedger), int32(lockedToLedger))
+	require.NoError(t, err)
+
+	return TxBatchJob{ChannelAccount: *chAcc, Transactions: lockedTxs, LockedUntilLedgerNumber: lockedToLedger}
+}
+
+func Test_TxBatchJob_String(t *testing.T) {
+	job := TxBatchJob{
+		ChannelAccount:          store.ChannelAccount{PublicKey: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX"},
+		Transactions:            []store.Transaction{{ID: "tx-1"}, {ID: "tx-2"}},
+		LockedUntilLedgerNumber: 10,
+	}
+
+	wantStr := `TxBatchJob{ChannelAccount: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX", Transactions: [tx-1 tx-2], LockedUntilLedgerNumber: "10"}`
+	assert.Equal(t, wantStr, job.String())
+}
+
+func Test_BatchTransactionWorker_validateJob(t *testing.T) {
+	const currentLedger = 1
+	lockedTo := sql.NullInt32{Int32: 2, Valid: true}
+	lockedChAcc := store.ChannelAccount{PublicKey: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX", LockedUntilLedgerNumber: lockedTo}
+	newTx := func(id string) store.Transaction {
+		return store.Transaction{ID: id, TenantID: "tenant-id", Status: store.TransactionStatusProcessing, LockedUntilLedgerNumber: lockedTo}
+	}
+
+	testCases := []struct {
+		name            string
+		job             *TxBatchJob
+		wantErrContains string
+	}{
+		{
+			name:            "returns an error if the job is nil",
+			wantErrContains: "batch job cannot be nil",
+		},
+		{
+			name:            "returns an error if the job has no transactions",
+			job:             &TxBatchJob{ChannelAccount: lockedChAcc},
+			wantErrContains: "batch job must have between 1 and 100 transactions, got 0",
+		},
+		{
+			name:            "returns an error if the channel account is not locked",
+			job:             &TxBatchJob{Transactions: []store.Transaction{newTx("1")}},
+			wantErrContains: "channel account should be locked",
+		},
+		{
+			name: "returns an error if a transaction has an invalid status",
+			job: &TxBatchJob{ChannelAccount: lockedChAcc, Transactions: []store.Transaction{
+				newTx("1"),
+				{ID: "2", TenantID: "tenant-id", Status: store.TransactionStatusSuccess, LockedUntilLedgerNumber: lockedTo},
+			}},
+			wantErrContains: "invalid status SUCCESS for transaction 2",
+		},
+		{
+			name: "returns an error if a transaction is not locked",
+			job: &TxBatchJob{ChannelAccount: lockedChAcc, Transactions: []store.Transaction{
+				newTx("1"),
+				{ID: "2", TenantID: "tenant-id", Status: store.TransactionStatusProcessing},
+			}},
+			wantErrContains: "transaction 2 should be locked",
+		},
+		{
+			name: "returns an error if the transactions belong to different tenants",
+			job: &TxBatchJob{ChannelAccount: lockedChAcc, Transactions: []store.Transaction{
+				newTx("1"),
+				{ID: "2", TenantID: "another-tenant-id", Status: store.TransactionStatusProcessing, LockedUntilLedgerNumber: lockedTo},
+			}},
+			wantErrContains: "all transactions in a batch must belong to the same tenant",
+		},
+		{
+			name: "returns an error if the transactions have different memos",
+			job: &TxBatchJob{ChannelAccount: lockedChAcc, Transactions: []store.Transaction{
+				newTx("1"),
+				{ID: "2", TenantID: "tenant-id", Status: store.TransactionStatusProcessing, LockedUntilLedgerNumber: lockedTo, Memo: "123", MemoType: schema.MemoTypeID},
+			}},
+			wantErrContains: "all transactions in a batch must share the same memo",
+		},
+		{
+			name: "🎉 successfully validates the batch job",
+			job:  &TxBatchJob{ChannelAccount: lockedChAcc, Transactions: []store.Transaction{newTx("1"), newTx("2")}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mLedgerNumberTracker := preconditionsMocks.NewMockLedgerNumberTracker(t)
+			mLedgerNumberTracker.On("GetLedgerNumber").Return(currentLedger, nil).Maybe()
+			bw := BatchTransactionWorker{tw: TransactionWorker{
+				engine: &engine.SubmitterEngine{LedgerNumberTracker: mLedgerNumberTracker},
+			}}
+
+			err := bw.validateJob(tc.job)
+			if tc.wantErrContains != "" {
+				assert.ErrorContains(t, err, tc.wantErrContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_BatchTransactionWorker_splitFailedBatch(t *testing.T) {
+	dbt := dbtest.OpenWithTSSMigrationsOnly(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	const currentLedger = 1
+	const lockedToLedger = 2
+
+	testCases := []struct {
+		name              string
+		isDefinitiveTxErr bool
+		opCodes           []string
+		wantStatuses      []store.TransactionStatus
+	}{
+		{
+			name:              "marks all transactions as ERROR if the transaction error is definitive",
+			isDefinitiveTxErr: true,
+			wantStatuses:      []store.TransactionStatus{store.TransactionStatusError, store.TransactionStatusError, store.TransactionStatusError},
+		},
+		{
+			name:         "marks only the transactions with definitive operation errors as ERROR",
+			opCodes:      []string{"op_success", "op_no_destination", "op_success"},
+			wantStatuses: []store.TransactionStatus{store.TransactionStatusProcessing, store.TransactionStatusError, store.TransactionStatusProcessing},
+		},
+		{
+			name:         "pushes all transactions back to the queue if there are no definitive errors",
+			wantStatuses: []store.TransactionStatus{store.TransactionStatusProcessing, store.TransactionStatusProcessing, store.TransactionStatusProcessing},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer store.DeleteAllFromChannelAccounts(t, ctx, dbConnectionPool)
+			defer store.DeleteAllTransactionFixtures(t, ctx, dbConnectionPool)
+
+			bw := BatchTransactionWorker{tw: getTransactionWorkerInstance(t, dbConnectionPool)}
+			job := createTxBatchJobFixture(t, ctx, dbConnectionPool, 3, currentLedger, lockedToLedger, uuid.NewString())
+
+			wantErrorCount := 0
+			for _, status := range tc.wantStatuses {
+				if status == store.TransactionStatusError {
+					wantErrorCount++
+				}
+			}
+			mEventProducer := &events.MockProducer{}
+			if wantErrorCount > 0 {
+				mEventProducer.
+					On("WriteMessages", ctx, mock.AnythingOfType("[]events.Message")).
+					Run(func(args mock.Arguments) {
+						messages, ok := args.Get(1).([]events.Message)
+						require.True(t, ok)
+						require.Len(t, messages, wantErrorCount)
+						for _, msg := range messages {
+							assert.Equal(t, events.PaymentCompletedErrorType, msg.Type)
+							msgData, ok := msg.Data.(schemas.EventPaymentCompletedData)
+							require.True(t, ok)
+							assert.Equal(t, string(data.FailedPaymentStatus), msgData.PaymentStatus)
+						}
+					}).
+					Return(nil).
+					Once()
+			}
+			bw.tw.eventProducer = mEventProducer
+
+			err := bw.splitFailedBatch(ctx, &job, tc.isDefinitiveTxErr, tc.opCodes, "transaction failed")
+			require.NoError(t, err)
+
+			for i, tx := range job.Transactions {
+				updatedTx, err := bw.tw.txModel.Get(ctx, tx.ID)
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantStatuses[i], updatedTx.Status)
+				assert.False(t, updatedTx.IsLocked(currentLedger))
+			}
+
+			chAcc, err := bw.tw.chAccModel.Get(ctx, dbConnectionPool, job.ChannelAccount.PublicKey, 0)
+			require.NoError(t, err)
+			assert.False(t, chAcc.IsLocked(currentLedger))
+
+			mEventProducer.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_BatchTransactionWorker_reconcileSubmittedBatch(t *testing.T) {
+	dbt := dbtest.OpenWithTSSMigrationsOnly(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	const currentLedger = 1
+	const lockedToLedger = 2
+	const txHash = "3389e9f0f1a65f19736cacf544c2e825313e8447f569233bb8db39aa607c8889"
+	// envelope with a single payment operation
+	const envelopeXDR = "AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAACgAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAEAKZ7IPj/46PuWU6ZOtyMosctNAkXRNX9WCAI5RnfRk+AyxDLoDZP/9l3NvsxQtWj9juQOuoBlFLnWu8intgxQA"
+	const resultXDR = "AAAAAAAAAGQAAAAAAAAAAQAAAAAAAAAOAAAAAAAAAABw2JZZYIt4n/WXKcnDow3mbTBMPrOnldetgvGUlpTSEQAAAAA="
+
+	testCases := []struct {
+		name              string
+		horizonTxResponse horizon.Transaction
+		horizonTxError    error
+		wantStatus        store.TransactionStatus
+		wantHashCleared   bool
+		wantErrContains   string
+	}{
+		{
+			name:              "🎉 successfully verifies the batch went through and marks it as successful",
+			horizonTxResponse: horizon.Transaction{Successful: true, ResultXdr: resultXDR},
+			wantStatus:        store.TransactionStatusSuccess,
+		},
+		{
+			name:            "🎉 the batch transaction returns a 404, so we mark it for resubmission",
+			horizonTxError:  horizonclient.Error{Problem: problem.P{Status: http.StatusNotFound}},
+			wantStatus:      store.TransactionStatusProcessing,
+			wantHashCleared: true,
+		},
+		{
+			name:            "an unexpected error is returned, so we wrap and send to the caller",
+			horizonTxError:  horizonclient.Error{Problem: problem.P{Status: http.StatusTooManyRequests}},
+			wantStatus:      store.TransactionStatusProcessing,
+			wantErrContains: "unexpected error: horizon response error: StatusCode=429",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer store.DeleteAllFromChannelAccounts(t, ctx, dbConnectionPool)
+			defer store.DeleteAllTransactionFixtures(t, ctx, dbConnectionPool)
+
+			bw := BatchTransactionWorker{tw: getTransactionWorkerInstance(t, dbConnectionPool)}
+			job := createTxBatchJobFixture(t, ctx, dbConnectionPool, 1, currentLedger, lockedToLedger, uuid.NewString())
+			updatedTxs, err := bw.tw.txModel.UpdateStellarTransactionHashAndXDRSentForBatch(ctx, dbConnectionPool, []string{job.Transactions[0].ID}, txHash, envelopeXDR)
+			require.NoError(t, err)
+			bw.replaceTransactions(&job, updatedTxs)
+
+			hMock := &horizonclient.MockClient{}
+			hMock.On("TransactionDetail", txHash).Return(tc.horizonTxResponse, tc.horizonTxError).Once()
+			bw.tw.engine.HorizonClient = hMock
+
+			mEventProducer := &events.MockProducer{}
+			if tc.horizonTxResponse.Successful {
+				mEventProducer.
+					On("WriteMessages", ctx, mock.AnythingOfType("[]events.Message")).
+					Return(nil).
+					Once()
+			}
+			bw.tw.eventProducer = mEventProducer
+
+			err = bw.reconcileSubmittedBatch(ctx, &job)
+			if tc.wantErrContains != "" {
+				assert.ErrorContains(t, err, tc.wantErrContains)
+			} else {
+				require.NoError(t, err)
+			}
+
+			tx, err := bw.tw.txModel.Get(ctx, job.Transactions[0].ID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, tx.Status)
+			assert.Equal(t, tc.wantHashCleared, !tx.StellarTransactionHash.Valid)
+
+			hMock.AssertExpectations(t)
+			mEventProducer.AssertExpectations(t)
+		})
+	}
+}
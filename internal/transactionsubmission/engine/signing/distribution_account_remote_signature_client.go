@@ -0,0 +1,333 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httpclient"
+	sdpUtils "github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+)
+
+// RemoteSigner is an external service holding one of the signing keys of the distribution account. The SDP sends it the
+// transactions to be signed following the protocol described in RemoteSignRequest and RemoteSignResponse.
+type RemoteSigner struct {
+	// URL is the endpoint that receives the sign requests.
+	URL string `json:"url"`
+	// PublicKey is the public key of the signer registered in the distribution account.
+	PublicKey string `json:"public_key"`
+	// AuthToken is sent as a Bearer token in the Authorization header, when provided.
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+func (s RemoteSigner) Validate() error {
+	if _, err := url.ParseRequestURI(s.URL); err != nil {
+		return fmt.Errorf("invalid url %q: %w", s.URL, err)
+	}
+
+	if !strkey.IsValidEd25519PublicKey(s.PublicKey) {
+		return fmt.Errorf("public key %q is not a valid Ed25519 public key", s.PublicKey)
+	}
+
+	return nil
+}
+
+// RemoteSignRequest is the JSON body POSTed to a remote signer's URL.
+type RemoteSignRequest struct {
+	// NetworkPassphrase is the passphrase of the network where the transaction will be submitted.
+	NetworkPassphrase string `json:"network_passphrase"`
+	// Account is the distribution account that is authorizing the transaction.
+	Account string `json:"account"`
+	// Signer is the public key that is expected to produce the signature.
+	Signer string `json:"signer"`
+	// TransactionXDR is the base64 encoded transaction envelope, so the signer can inspect it before signing.
+	TransactionXDR string `json:"transaction_xdr"`
+	// TransactionHash is the hex encoded hash of the transaction, which is the payload to be signed.
+	TransactionHash string `json:"transaction_hash"`
+}
+
+// RemoteSignResponse is the JSON body returned by a remote signer with a 200 status code.
+type RemoteSignResponse struct {
+	// PublicKey is the public key used to sign the transaction hash.
+	PublicKey string `json:"public_key"`
+	// Signature is the base64 encoded Ed25519 signature of the transaction hash.
+	Signature string `json:"signature"`
+}
+
+type DistributionAccountRemoteSignatureClientOptions struct {
+	NetworkPassphrase   string
+	DistributionAccount string
+	Signers             []RemoteSigner
+	Threshold           int
+	HTTPClient          httpclient.HttpClientInterface // (optional)
+}
+
+func (opts *DistributionAccountRemoteSignatureClientOptions) Validate() error {
+	if opts.NetworkPassphrase == "" {
+		return fmt.Errorf("network passphrase cannot be empty")
+	}
+
+	if !strkey.IsValidEd25519PublicKey(opts.DistributionAccount) {
+		return fmt.Errorf("distribution account is not a valid Ed25519 public key")
+	}
+
+	if len(opts.Signers) == 0 {
+		return fmt.Errorf("signers cannot be empty")
+	}
+
+	signerKeys := map[string]struct{}{}
+	for i, signer := range opts.Signers {
+		if err := signer.Validate(); err != nil {
+			return fmt.Errorf("validating signer %d: %w", i, err)
+		}
+		if _, ok := signerKeys[signer.PublicKey]; ok {
+			return fmt.Errorf("signer %s is configured more than once", signer.PublicKey)
+		}
+		signerKeys[signer.PublicKey] = struct{}{}
+	}
+
+	if opts.Threshold < 1 || opts.Threshold > len(opts.Signers) {
+		return fmt.Errorf("threshold must be between 1 and the number of signers (%d)", len(opts.Signers))
+	}
+
+	return nil
+}
+
+// DistributionAccountRemoteSignatureClient signs the distribution account transactions through external signers, so
+// the distribution account private keys are never held by the SDP. The signers are called in the configured order
+// until `Threshold` valid signatures are collected, which allows the distribution account to be an M-of-N multisig
+// account.
+type DistributionAccountRemoteSignatureClient struct {
+	networkPassphrase   string
+	distributionAccount string
+	signers             []RemoteSigner
+	threshold           int
+	httpClient          httpclient.HttpClientInterface
+}
+
+// NewDistributionAccountRemoteSignatureClient returns a new instance of the DistributionAccountRemote SignatureClient.
+func NewDistributionAccountRemoteSignatureClient(opts DistributionAccountRemoteSignatureClientOptions) (*DistributionAccountRemoteSignatureClient, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = httpclient.DefaultClient()
+	}
+
+	return &DistributionAccountRemoteSignatureClient{
+		networkPassphrase:   opts.NetworkPassphrase,
+		distributionAccount: opts.DistributionAccount,
+		signers:             opts.Signers,
+		threshold:           opts.Threshold,
+		httpClient:          httpClient,
+	}, nil
+}
+
+var _ SignatureClient = (*DistributionAccountRemoteSignatureClient)(nil)
+
+// validateStellarAccounts ensures that the distribution account is the only account signing the transaction.
+func (c *DistributionAccountRemoteSignatureClient) validateStellarAccounts(stellarAccounts ...string) error {
+	if len(stellarAccounts) == 0 {
+		return fmt.Errorf("stellar accounts cannot be empty in %s", c.name())
+	}
+
+	for _, stellarAccount := range stellarAccounts {
+		if stellarAccount != c.distributionAccount {
+			return fmt.Errorf("stellar account %s is not allowed to sign in %s", stellarAccount, c.name())
+		}
+	}
+
+	return nil
+}
+
+func (c *DistributionAccountRemoteSignatureClient) SignStellarTransaction(ctx context.Context, stellarTx *txnbuild.Transaction, stellarAccounts ...string) (signedStellarTx *txnbuild.Transaction, err error) {
+	if stellarTx == nil {
+		return nil, fmt.Errorf("stellarTx cannot be nil in %s", c.name())
+	}
+
+	err = c.validateStellarAccounts(stellarAccounts...)
+	if err != nil {
+		return nil, fmt.Errorf("validating stellar accounts: %w", err)
+	}
+
+	txHash, err := stellarTx.Hash(c.networkPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("hashing transaction in %s: %w", c.name(), err)
+	}
+	txXDR, err := stellarTx.Base64()
+	if err != nil {
+		return nil, fmt.Errorf("encoding transaction in %s: %w", c.name(), err)
+	}
+
+	signatures, err := c.collectSignatures(ctx, txHash, txXDR)
+	if err != nil {
+		return nil, fmt.Errorf("collecting signatures in %s: %w", c.name(), err)
+	}
+
+	signedStellarTx, err = stellarTx.AddSignatureDecorated(signatures...)
+	if err != nil {
+		return nil, fmt.Errorf("adding signatures to transaction in %s: %w", c.name(), err)
+	}
+
+	return signedStellarTx, nil
+}
+
+func (c *DistributionAccountRemoteSignatureClient) SignFeeBumpStellarTransaction(ctx context.Context, feeBumpStellarTx *txnbuild.FeeBumpTransaction, stellarAccounts ...string) (signedFeeBumpStellarTx *txnbuild.FeeBumpTransaction, err error) {
+	if feeBumpStellarTx == nil {
+		return nil, fmt.Errorf("stellarTx cannot be nil in %s", c.name())
+	}
+
+	err = c.validateStellarAccounts(stellarAccounts...)
+	if err != nil {
+		return nil, fmt.Errorf("validating stellar accounts: %w", err)
+	}
+
+	txHash, err := feeBumpStellarTx.Hash(c.networkPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("hashing transaction in %s: %w", c.name(), err)
+	}
+	txXDR, err := feeBumpStellarTx.Base64()
+	if err != nil {
+		return nil, fmt.Errorf("encoding transaction in %s: %w", c.name(), err)
+	}
+
+	signatures, err := c.collectSignatures(ctx, txHash, txXDR)
+	if err != nil {
+		return nil, fmt.Errorf("collecting signatures in %s: %w", c.name(), err)
+	}
+
+	signedFeeBumpStellarTx, err = feeBumpStellarTx.AddSignatureDecorated(signatures...)
+	if err != nil {
+		return nil, fmt.Errorf("adding signatures to transaction in %s: %w", c.name(), err)
+	}
+
+	return signedFeeBumpStellarTx, nil
+}
+
+// collectSignatures requests signatures from the signers, in the configured order, until the threshold is reached.
+// Signers that fail or return an invalid signature are skipped, and an error is only returned if the threshold cannot
+// be reached.
+func (c *DistributionAccountRemoteSignatureClient) collectSignatures(ctx context.Context, txHash [32]byte, txXDR string) ([]xdr.DecoratedSignature, error) {
+	signatures := make([]xdr.DecoratedSignature, 0, c.threshold)
+	var signerErrs []error
+	for _, signer := range c.signers {
+		signature, err := c.requestSignature(ctx, signer, txHash, txXDR)
+		if err != nil {
+			log.Ctx(ctx).Warnf("Remote signer %s failed to sign transaction %x: %v", signer.PublicKey, txHash, err)
+			signerErrs = append(signerErrs, fmt.Errorf("signer %s: %w", signer.PublicKey, err))
+			continue
+		}
+
+		signatures = append(signatures, signature)
+		if len(signatures) == c.threshold {
+			return signatures, nil
+		}
+	}
+
+	return nil, fmt.Errorf("collected %d of the %d required signatures: %w", len(signatures), c.threshold, errors.Join(signerErrs...))
+}
+
+// requestSignature sends the transaction to a remote signer and validates the returned signature against the signer's
+// public key.
+func (c *DistributionAccountRemoteSignatureClient) requestSignature(ctx context.Context, signer RemoteSigner, txHash [32]byte, txXDR string) (xdr.DecoratedSignature, error) {
+	reqBody, err := json.Marshal(RemoteSignRequest{
+		NetworkPassphrase: c.networkPassphrase,
+		Account:           c.distributionAccount,
+		Signer:            signer.PublicKey,
+		TransactionXDR:    txXDR,
+		TransactionHash:   hex.EncodeToString(txHash[:]),
+	})
+	if err != nil {
+		return xdr.DecoratedSignature{}, fmt.Errorf("marshalling sign request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, signer.URL, bytes.NewReader(reqBody))
+	if err != nil {
+		return xdr.DecoratedSignature{}, fmt.Errorf("creating sign request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if signer.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+signer.AuthToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return xdr.DecoratedSignature{}, fmt.Errorf("sending sign request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return xdr.DecoratedSignature{}, fmt.Errorf("reading sign response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return xdr.DecoratedSignature{}, fmt.Errorf("sign request failed with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var signResp RemoteSignResponse
+	if err = json.Unmarshal(respBody, &signResp); err != nil {
+		return xdr.DecoratedSignature{}, fmt.Errorf("unmarshalling sign response: %w", err)
+	}
+	if signResp.PublicKey != signer.PublicKey {
+		return xdr.DecoratedSignature{}, fmt.Errorf("signature was produced by %q instead of the configured signer", signResp.PublicKey)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(signResp.Signature)
+	if err != nil {
+		return xdr.DecoratedSignature{}, fmt.Errorf("decoding signature: %w", err)
+	}
+
+	kp, err := keypair.ParseAddress(signer.PublicKey)
+	if err != nil {
+		return xdr.DecoratedSignature{}, fmt.Errorf("parsing signer public key: %w", err)
+	}
+	if err = kp.Verify(txHash[:], signature); err != nil {
+		return xdr.DecoratedSignature{}, fmt.Errorf("verifying signature: %w", err)
+	}
+
+	return xdr.DecoratedSignature{Hint: kp.Hint(), Signature: signature}, nil
+}
+
+func (c *DistributionAccountRemoteSignatureClient) BatchInsert(ctx context.Context, number int) (publicKeys []string, err error) {
+	if number <= 0 {
+		return nil, fmt.Errorf("number must be greater than 0")
+	}
+
+	publicKeys = make([]string, number)
+	for i := 0; i < number; i++ {
+		publicKeys[i] = c.distributionAccount
+	}
+	err = fmt.Errorf("BatchInsert called for signature client type %s: %w", c.name(), ErrUnsupportedCommand)
+	return publicKeys, err
+}
+
+func (c *DistributionAccountRemoteSignatureClient) Delete(ctx context.Context, publicKey string) error {
+	err := c.validateStellarAccounts(publicKey)
+	if err != nil {
+		return fmt.Errorf("validating stellar account to delete: %w", err)
+	}
+	return fmt.Errorf("Delete called for signature client type %s: %w", c.name(), ErrUnsupportedCommand)
+}
+
+func (c *DistributionAccountRemoteSignatureClient) name() string {
+	return sdpUtils.GetTypeName(c)
+}
+
+func (c *DistributionAccountRemoteSignatureClient) NetworkPassphrase() string {
+	return c.networkPassphrase
+}
@@ -0,0 +1,366 @@
+package signing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DistributionAccountRemoteSignatureClientOptions_Validate(t *testing.T) {
+	distributionAccount := keypair.MustRandom().Address()
+	signer := RemoteSigner{URL: "https://signer.example.com/sign", PublicKey: keypair.MustRandom().Address()}
+
+	testCases := []struct {
+		name              string
+		opts              DistributionAccountRemoteSignatureClientOptions
+		wantErrorContains string
+	}{
+		{
+			name:              "returns an error if the network passphrase is empty",
+			opts:              DistributionAccountRemoteSignatureClientOptions{},
+			wantErrorContains: "network passphrase cannot be empty",
+		},
+		{
+			name: "returns an error if the distribution account is invalid",
+			opts: DistributionAccountRemoteSignatureClientOptions{
+				NetworkPassphrase:   network.TestNetworkPassphrase,
+				DistributionAccount: "invalid",
+			},
+			wantErrorContains: "distribution account is not a valid Ed25519 public key",
+		},
+		{
+			name: "returns an error if there are no signers",
+			opts: DistributionAccountRemoteSignatureClientOptions{
+				NetworkPassphrase:   network.TestNetworkPassphrase,
+				DistributionAccount: distributionAccount,
+			},
+			wantErrorContains: "signers cannot be empty",
+		},
+		{
+			name: "returns an error if a signer URL is invalid",
+			opts: DistributionAccountRemoteSignatureClientOptions{
+				NetworkPassphrase:   network.TestNetworkPassphrase,
+				DistributionAccount: distributionAccount,
+				Signers:             []RemoteSigner{{URL: "invalid", PublicKey: signer.PublicKey}},
+			},
+			wantErrorContains: `validating signer 0: invalid url "invalid"`,
+		},
+		{
+			name: "returns an error if a signer public key is invalid",
+			opts: DistributionAccountRemoteSignatureClientOptions{
+				NetworkPassphrase:   network.TestNetworkPassphrase,
+				DistributionAccount: distributionAccount,
+				Signers:             []RemoteSigner{{URL: signer.URL, PublicKey: "invalid"}},
+			},
+			wantErrorContains: `validating signer 0: public key "invalid" is not a valid Ed25519 public key`,
+		},
+		{
+			name: "returns an error if a signer is repeated",
+			opts: DistributionAccountRemoteSignatureClientOptions{
+				NetworkPassphrase:   network.TestNetworkPassphrase,
+				DistributionAccount: distributionAccount,
+				Signers:             []RemoteSigner{signer, signer},
+			},
+			wantErrorContains: "is configured more than once",
+		},
+		{
+			name: "returns an error if the threshold is greater than the number of signers",
+			opts: DistributionAccountRemoteSignatureClientOptions{
+				NetworkPassphrase:   network.TestNetworkPassphrase,
+				DistributionAccount: distributionAccount,
+				Signers:             []RemoteSigner{signer},
+				Threshold:           2,
+			},
+			wantErrorContains: "threshold must be between 1 and the number of signers (1)",
+		},
+		{
+			name: "🎉 successfully validate options",
+			opts: DistributionAccountRemoteSignatureClientOptions{
+				NetworkPassphrase:   network.TestNetworkPassphrase,
+				DistributionAccount: distributionAccount,
+				Signers:             []RemoteSigner{signer},
+				Threshold:           1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			if tc.wantErrorContains != "" {
+				assert.ErrorContains(t, err, tc.wantErrorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// newRemoteSignersForTest starts one LocalRemoteSigner server per keypair, and closes them when the test ends.
+func newRemoteSignersForTest(t *testing.T, kps ...*keypair.Full) ([]*LocalRemoteSigner, []RemoteSigner) {
+	t.Helper()
+
+	localSigners := []*LocalRemoteSigner{}
+	remoteSigners := []RemoteSigner{}
+	for _, kp := range kps {
+		localSigner := &LocalRemoteSigner{KP: kp, AuthToken: "token-" + kp.Address()}
+		server, remoteSigner := NewLocalRemoteSignerServer(localSigner)
+		t.Cleanup(server.Close)
+
+		localSigners = append(localSigners, localSigner)
+		remoteSigners = append(remoteSigners, remoteSigner)
+	}
+
+	return localSigners, remoteSigners
+}
+
+func Test_DistributionAccountRemoteSignatureClient_SignStellarTransaction(t *testing.T) {
+	ctx := context.Background()
+	distributionAccount := keypair.MustRandom().Address()
+	signerKP1, signerKP2, signerKP3 := keypair.MustRandom(), keypair.MustRandom(), keypair.MustRandom()
+
+	stellarTx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: distributionAccount},
+		Operations: []txnbuild.Operation{&txnbuild.Payment{
+			Destination: keypair.MustRandom().Address(),
+			Amount:      "1",
+			Asset:       txnbuild.NativeAsset{},
+		}},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewTimeout(60)},
+	})
+	require.NoError(t, err)
+	txHash, err := stellarTx.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+
+	t.Run("returns an error if the transaction is nil", func(t *testing.T) {
+		_, remoteSigners := newRemoteSignersForTest(t, signerKP1)
+		sigClient, err := NewDistributionAccountRemoteSignatureClient(DistributionAccountRemoteSignatureClientOptions{
+			NetworkPassphrase:   network.TestNetworkPassphrase,
+			DistributionAccount: distributionAccount,
+			Signers:             remoteSigners,
+			Threshold:           1,
+		})
+		require.NoError(t, err)
+
+		signedTx, err := sigClient.SignStellarTransaction(ctx, nil, distributionAccount)
+		assert.EqualError(t, err, "stellarTx cannot be nil in DistributionAccountRemoteSignatureClient")
+		assert.Nil(t, signedTx)
+	})
+
+	t.Run("returns an error if the account is not the distribution account", func(t *testing.T) {
+		_, remoteSigners := newRemoteSignersForTest(t, signerKP1)
+		sigClient, err := NewDistributionAccountRemoteSignatureClient(DistributionAccountRemoteSignatureClientOptions{
+			NetworkPassphrase:   network.TestNetworkPassphrase,
+			DistributionAccount: distributionAccount,
+			Signers:             remoteSigners,
+			Threshold:           1,
+		})
+		require.NoError(t, err)
+
+		otherAccount := keypair.MustRandom().Address()
+		signedTx, err := sigClient.SignStellarTransaction(ctx, stellarTx, otherAccount)
+		assert.ErrorContains(t, err, "stellar account "+otherAccount+" is not allowed to sign")
+		assert.Nil(t, signedTx)
+	})
+
+	t.Run("returns an error if the threshold cannot be reached", func(t *testing.T) {
+		_, remoteSigners := newRemoteSignersForTest(t, signerKP1, signerKP2)
+		remoteSigners[1].AuthToken = "wrong-token"
+		sigClient, err := NewDistributionAccountRemoteSignatureClient(DistributionAccountRemoteSignatureClientOptions{
+			NetworkPassphrase:   network.TestNetworkPassphrase,
+			DistributionAccount: distributionAccount,
+			Signers:             remoteSigners,
+			Threshold:           2,
+		})
+		require.NoError(t, err)
+
+		signedTx, err := sigClient.SignStellarTransaction(ctx, stellarTx, distributionAccount)
+		assert.ErrorContains(t, err, "collected 1 of the 2 required signatures")
+		assert.ErrorContains(t, err, "sign request failed with status 401")
+		assert.Nil(t, signedTx)
+	})
+
+	t.Run("returns an error if a signer returns an invalid signature", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"public_key":"` + signerKP1.Address() + `","signature":"aW52YWxpZA=="}`))
+		}))
+		defer server.Close()
+
+		sigClient, err := NewDistributionAccountRemoteSignatureClient(DistributionAccountRemoteSignatureClientOptions{
+			NetworkPassphrase:   network.TestNetworkPassphrase,
+			DistributionAccount: distributionAccount,
+			Signers:             []RemoteSigner{{URL: server.URL, PublicKey: signerKP1.Address()}},
+			Threshold:           1,
+		})
+		require.NoError(t, err)
+
+		signedTx, err := sigClient.SignStellarTransaction(ctx, stellarTx, distributionAccount)
+		assert.ErrorContains(t, err, "verifying signature")
+		assert.Nil(t, signedTx)
+	})
+
+	t.Run("🎉 collects signatures until the threshold is reached, skipping the signers that fail", func(t *testing.T) {
+		localSigners, remoteSigners := newRemoteSignersForTest(t, signerKP1, signerKP2, signerKP3)
+		remoteSigners[0].AuthToken = "wrong-token"
+		sigClient, err := NewDistributionAccountRemoteSignatureClient(DistributionAccountRemoteSignatureClientOptions{
+			NetworkPassphrase:   network.TestNetworkPassphrase,
+			DistributionAccount: distributionAccount,
+			Signers:             remoteSigners,
+			Threshold:           2,
+		})
+		require.NoError(t, err)
+
+		signedTx, err := sigClient.SignStellarTransaction(ctx, stellarTx, distributionAccount)
+		require.NoError(t, err)
+
+		wantSignedTx, err := stellarTx.Sign(network.TestNetworkPassphrase, signerKP2, signerKP3)
+		require.NoError(t, err)
+		assert.Equal(t, wantSignedTx.Signatures(), signedTx.Signatures())
+
+		// The signer with the wrong token is not able to authenticate the request:
+		assert.Empty(t, localSigners[0].Requests())
+		for i, localSigner := range localSigners[1:] {
+			require.Len(t, localSigner.Requests(), 1)
+			assert.Equal(t, RemoteSignRequest{
+				NetworkPassphrase: network.TestNetworkPassphrase,
+				Account:           distributionAccount,
+				Signer:            remoteSigners[i+1].PublicKey,
+				TransactionXDR:    localSigner.Requests()[0].TransactionXDR,
+				TransactionHash:   txHash,
+			}, localSigner.Requests()[0])
+		}
+	})
+
+	t.Run("🎉 stops calling signers once the threshold is reached", func(t *testing.T) {
+		localSigners, remoteSigners := newRemoteSignersForTest(t, signerKP1, signerKP2)
+		sigClient, err := NewDistributionAccountRemoteSignatureClient(DistributionAccountRemoteSignatureClientOptions{
+			NetworkPassphrase:   network.TestNetworkPassphrase,
+			DistributionAccount: distributionAccount,
+			Signers:             remoteSigners,
+			Threshold:           1,
+		})
+		require.NoError(t, err)
+
+		signedTx, err := sigClient.SignStellarTransaction(ctx, stellarTx, distributionAccount)
+		require.NoError(t, err)
+		assert.Len(t, signedTx.Signatures(), 1)
+		assert.Len(t, localSigners[0].Requests(), 1)
+		assert.Empty(t, localSigners[1].Requests())
+	})
+}
+
+func Test_DistributionAccountRemoteSignatureClient_SignFeeBumpStellarTransaction(t *testing.T) {
+	ctx := context.Background()
+	distributionAccount := keypair.MustRandom().Address()
+	signerKP1, signerKP2 := keypair.MustRandom(), keypair.MustRandom()
+	chAccKP := keypair.MustRandom()
+
+	stellarTx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: chAccKP.Address()},
+		Operations: []txnbuild.Operation{&txnbuild.Payment{
+			SourceAccount: distributionAccount,
+			Destination:   keypair.MustRandom().Address(),
+			Amount:        "1",
+			Asset:         txnbuild.NativeAsset{},
+		}},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewTimeout(60)},
+	})
+	require.NoError(t, err)
+	stellarTx, err = stellarTx.Sign(network.TestNetworkPassphrase, chAccKP)
+	require.NoError(t, err)
+	feeBumpTx, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      stellarTx,
+		FeeAccount: distributionAccount,
+		BaseFee:    2 * txnbuild.MinBaseFee,
+	})
+	require.NoError(t, err)
+
+	t.Run("returns an error if the transaction is nil", func(t *testing.T) {
+		_, remoteSigners := newRemoteSignersForTest(t, signerKP1)
+		sigClient, err := NewDistributionAccountRemoteSignatureClient(DistributionAccountRemoteSignatureClientOptions{
+			NetworkPassphrase:   network.TestNetworkPassphrase,
+			DistributionAccount: distributionAccount,
+			Signers:             remoteSigners,
+			Threshold:           1,
+		})
+		require.NoError(t, err)
+
+		signedTx, err := sigClient.SignFeeBumpStellarTransaction(ctx, nil, distributionAccount)
+		assert.EqualError(t, err, "stellarTx cannot be nil in DistributionAccountRemoteSignatureClient")
+		assert.Nil(t, signedTx)
+	})
+
+	t.Run("🎉 signs the fee bump transaction with M-of-N remote signers", func(t *testing.T) {
+		_, remoteSigners := newRemoteSignersForTest(t, signerKP1, signerKP2)
+		sigClient, err := NewDistributionAccountRemoteSignatureClient(DistributionAccountRemoteSignatureClientOptions{
+			NetworkPassphrase:   network.TestNetworkPassphrase,
+			DistributionAccount: distributionAccount,
+			Signers:             remoteSigners,
+			Threshold:           2,
+		})
+		require.NoError(t, err)
+
+		signedTx, err := sigClient.SignFeeBumpStellarTransaction(ctx, feeBumpTx, distributionAccount)
+		require.NoError(t, err)
+
+		wantSignedTx, err := feeBumpTx.Sign(network.TestNetworkPassphrase, signerKP1, signerKP2)
+		require.NoError(t, err)
+		assert.Equal(t, wantSignedTx.Signatures(), signedTx.Signatures())
+	})
+}
+
+func Test_DistributionAccountRemoteSignatureClient_BatchInsert(t *testing.T) {
+	ctx := context.Background()
+	distributionAccount := keypair.MustRandom().Address()
+	sigClient, err := NewDistributionAccountRemoteSignatureClient(DistributionAccountRemoteSignatureClientOptions{
+		NetworkPassphrase:   network.TestNetworkPassphrase,
+		DistributionAccount: distributionAccount,
+		Signers:             []RemoteSigner{{URL: "https://signer.example.com/sign", PublicKey: keypair.MustRandom().Address()}},
+		Threshold:           1,
+	})
+	require.NoError(t, err)
+
+	t.Run("number needs to be greater than zero", func(t *testing.T) {
+		publicKeys, err := sigClient.BatchInsert(ctx, 0)
+		assert.EqualError(t, err, "number must be greater than 0")
+		assert.Nil(t, publicKeys)
+	})
+
+	t.Run("returns the distribution account with the error ErrUnsupportedCommand", func(t *testing.T) {
+		publicKeys, err := sigClient.BatchInsert(ctx, 2)
+		assert.ErrorIs(t, err, ErrUnsupportedCommand)
+		assert.Equal(t, []string{distributionAccount, distributionAccount}, publicKeys)
+	})
+}
+
+func Test_DistributionAccountRemoteSignatureClient_Delete(t *testing.T) {
+	ctx := context.Background()
+	distributionAccount := keypair.MustRandom().Address()
+	sigClient, err := NewDistributionAccountRemoteSignatureClient(DistributionAccountRemoteSignatureClientOptions{
+		NetworkPassphrase:   network.TestNetworkPassphrase,
+		DistributionAccount: distributionAccount,
+		Signers:             []RemoteSigner{{URL: "https://signer.example.com/sign", PublicKey: keypair.MustRandom().Address()}},
+		Threshold:           1,
+	})
+	require.NoError(t, err)
+
+	t.Run("return an error if attempted to delete an unsupported account", func(t *testing.T) {
+		err := sigClient.Delete(ctx, keypair.MustRandom().Address())
+		assert.ErrorContains(t, err, "validating stellar account to delete")
+	})
+
+	t.Run("return the error ErrUnsupportedCommand if attempted to delete the distribution account", func(t *testing.T) {
+		err := sigClient.Delete(ctx, distributionAccount)
+		assert.ErrorIs(t, err, ErrUnsupportedCommand)
+	})
+}
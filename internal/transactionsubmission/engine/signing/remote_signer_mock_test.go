@@ -0,0 +1,87 @@
+package signing
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/stellar/go/keypair"
+)
+
+// LocalRemoteSigner is a remote signer that runs in-process and signs every request with a local keypair, implementing
+// the remote signer protocol for the tests.
+type LocalRemoteSigner struct {
+	KP        *keypair.Full
+	AuthToken string
+
+	mu sync.Mutex
+	// requests holds the requests received by the signer, in order.
+	requests []RemoteSignRequest
+}
+
+// Requests returns the requests received by the signer, in order.
+func (s *LocalRemoteSigner) Requests() []RemoteSignRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]RemoteSignRequest(nil), s.requests...)
+}
+
+var _ http.Handler = (*LocalRemoteSigner)(nil)
+
+func (s *LocalRemoteSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.AuthToken != "" && r.Header.Get("Authorization") != "Bearer "+s.AuthToken {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var signReq RemoteSignRequest
+	if err := json.NewDecoder(r.Body).Decode(&signReq); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	s.requests = append(s.requests, signReq)
+	s.mu.Unlock()
+
+	if signReq.Signer != s.KP.Address() {
+		http.Error(w, "unknown signer", http.StatusBadRequest)
+		return
+	}
+
+	txHash, err := hex.DecodeString(signReq.TransactionHash)
+	if err != nil {
+		http.Error(w, "invalid transaction hash", http.StatusBadRequest)
+		return
+	}
+
+	signature, err := s.KP.Sign(txHash)
+	if err != nil {
+		http.Error(w, "signing transaction hash", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(RemoteSignResponse{
+		PublicKey: s.KP.Address(),
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	})
+}
+
+// NewLocalRemoteSignerServer starts an HTTP server for a LocalRemoteSigner, returning the server and the RemoteSigner
+// configuration pointing to it. The caller is responsible for closing the server.
+func NewLocalRemoteSignerServer(signer *LocalRemoteSigner) (*httptest.Server, RemoteSigner) {
+	server := httptest.NewServer(signer)
+	return server, RemoteSigner{
+		URL:       server.URL,
+		PublicKey: signer.KP.Address(),
+		AuthToken: signer.AuthToken,
+	}
+}
@@ -34,6 +34,9 @@ type SignatureClientOptions struct {
 	// DistributionAccountDB:
 	DistAccEncryptionPassphrase string
 
+	// DistributionAccountRemote:
+	RemoteSignerOptions RemoteSignerOptions
+
 	// ChannelAccountDB:
 	ChAccEncryptionPassphrase string
 
@@ -43,6 +46,18 @@ type SignatureClientOptions struct {
 	Encrypter           sdpUtils.PrivateKeyEncrypter // (optional)
 }
 
+// RemoteSignerOptions configures the distribution account whose keys are held by remote signers.
+type RemoteSignerOptions struct {
+	DistributionAccount string
+	Signers             []RemoteSigner
+	Threshold           int
+}
+
+// IsConfigured returns true if any remote signer was configured.
+func (opts RemoteSignerOptions) IsConfigured() bool {
+	return len(opts.Signers) > 0
+}
+
 func NewSignatureClient(accType schema.AccountType, opts SignatureClientOptions) (SignatureClient, error) {
 	switch accType {
 	case schema.HostStellarEnv, schema.DistributionAccountStellarEnv:
@@ -69,6 +84,14 @@ func NewSignatureClient(accType schema.AccountType, opts SignatureClientOptions)
 			Encrypter:            opts.Encrypter,
 		})
 
+	case schema.DistributionAccountStellarRemote:
+		return NewDistributionAccountRemoteSignatureClient(DistributionAccountRemoteSignatureClientOptions{
+			NetworkPassphrase:   opts.NetworkPassphrase,
+			DistributionAccount: opts.RemoteSignerOptions.DistributionAccount,
+			Signers:             opts.RemoteSignerOptions.Signers,
+			Threshold:           opts.RemoteSignerOptions.Threshold,
+		})
+
 	default:
 		return nil, fmt.Errorf("cannot find a Stellar signature client for accountType=%v", accType)
 	}
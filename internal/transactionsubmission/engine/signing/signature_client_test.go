@@ -12,6 +12,7 @@ import (
 
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
 	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httpclient"
 	preconditionsMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/preconditions/mocks"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store"
 	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
@@ -89,6 +90,25 @@ func Test_NewSignatureClient(t *testing.T) {
 				accountType:         schema.DistributionAccountStellarEnv,
 			},
 		},
+		{
+			name:    "🎉 successfully instantiate a Distribution Account REMOTE instance",
+			accType: schema.DistributionAccountStellarRemote,
+			opts: SignatureClientOptions{
+				NetworkPassphrase: network.TestNetworkPassphrase,
+				RemoteSignerOptions: RemoteSignerOptions{
+					DistributionAccount: distributionKP.Address(),
+					Signers:             []RemoteSigner{{URL: "https://signer.example.com/sign", PublicKey: distributionKP.Address()}},
+					Threshold:           1,
+				},
+			},
+			wantResult: &DistributionAccountRemoteSignatureClient{
+				networkPassphrase:   network.TestNetworkPassphrase,
+				distributionAccount: distributionKP.Address(),
+				signers:             []RemoteSigner{{URL: "https://signer.example.com/sign", PublicKey: distributionKP.Address()}},
+				threshold:           1,
+				httpClient:          httpclient.DefaultClient(),
+			},
+		},
 		{
 			name:    "🎉 successfully instantiate a Distribution Account ENV instance (HOST)",
 			accType: schema.HostStellarEnv,
@@ -45,6 +45,9 @@ type SignatureServiceOptions struct {
 	// DistributionAccountDB:
 	DistAccEncryptionPassphrase string
 
+	// DistributionAccountRemote:
+	RemoteSignerOptions RemoteSignerOptions
+
 	// ChannelAccountDB:
 	ChAccEncryptionPassphrase string
 
@@ -70,6 +73,7 @@ func NewSignatureService(opts SignatureServiceOptions) (SignatureService, error)
 		DBConnectionPool:            opts.DBConnectionPool,
 		ChAccEncryptionPassphrase:   opts.ChAccEncryptionPassphrase,
 		DistAccEncryptionPassphrase: opts.DistAccEncryptionPassphrase,
+		RemoteSignerOptions:         opts.RemoteSignerOptions,
 		Encrypter:                   opts.Encrypter,
 		LedgerNumberTracker:         opts.LedgerNumberTracker,
 	}
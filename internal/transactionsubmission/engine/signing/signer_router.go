@@ -52,6 +52,9 @@ type SignatureRouterOptions struct {
 	// DISTRIBUTION_ACCOUNT.STELLAR.DB_VAULT:
 	DistAccEncryptionPassphrase string
 
+	// DISTRIBUTION_ACCOUNT.STELLAR.REMOTE:
+	RemoteSignerOptions RemoteSignerOptions
+
 	// CHANNEL_ACCOUNT.STELLAR.DB:
 	ChAccEncryptionPassphrase string
 	LedgerNumberTracker       preconditions.LedgerNumberTracker
@@ -69,6 +72,9 @@ func NewSignerRouter(opts SignatureRouterOptions, accountTypes ...schema.Account
 			schema.DistributionAccountStellarEnv,
 			schema.DistributionAccountStellarDBVault,
 		}
+		if opts.RemoteSignerOptions.IsConfigured() {
+			accountTypes = append(accountTypes, schema.DistributionAccountStellarRemote)
+		}
 	}
 
 	router := map[schema.AccountType]SignatureClient{}
@@ -108,6 +114,14 @@ func NewSignerRouter(opts SignatureRouterOptions, accountTypes ...schema.Account
 				Encrypter:            opts.Encrypter,
 			})
 
+		case schema.DistributionAccountStellarRemote:
+			newSigClient, err = NewDistributionAccountRemoteSignatureClient(DistributionAccountRemoteSignatureClientOptions{
+				NetworkPassphrase:   opts.NetworkPassphrase,
+				DistributionAccount: opts.RemoteSignerOptions.DistributionAccount,
+				Signers:             opts.RemoteSignerOptions.Signers,
+				Threshold:           opts.RemoteSignerOptions.Threshold,
+			})
+
 		default:
 			return nil, fmt.Errorf("cannot find a Stellar signature client for accountType=%v", accType)
 		}
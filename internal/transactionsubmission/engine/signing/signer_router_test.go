@@ -16,6 +16,7 @@ import (
 
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
 	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/serve/httpclient"
 	preconditionsMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/preconditions/mocks"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine/signing/mocks"
 	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store"
@@ -75,6 +76,21 @@ func Test_NewSignerRouter(t *testing.T) {
 		encrypter:            &sdpUtils.DefaultPrivateKeyEncrypter{},
 	}
 
+	remoteSignerOptions := RemoteSignerOptions{
+		DistributionAccount: distributionKP.Address(),
+		Signers:             []RemoteSigner{{URL: "https://signer.example.com/sign", PublicKey: keypair.MustRandom().Address()}},
+		Threshold:           1,
+	}
+	validOptionsWithRemoteSigner := validOptions
+	validOptionsWithRemoteSigner.RemoteSignerOptions = remoteSignerOptions
+	wantDistAccStellarRemoteSigner := &DistributionAccountRemoteSignatureClient{
+		networkPassphrase:   networkPassphrase,
+		distributionAccount: remoteSignerOptions.DistributionAccount,
+		signers:             remoteSignerOptions.Signers,
+		threshold:           remoteSignerOptions.Threshold,
+		httpClient:          httpclient.DefaultClient(),
+	}
+
 	testCases := []struct {
 		name             string
 		opts             SignatureRouterOptions
@@ -114,6 +130,14 @@ func Test_NewSignerRouter(t *testing.T) {
 			},
 			wantErrContains: `creating a new "DISTRIBUTION_ACCOUNT.STELLAR.DB_VAULT" signature client`,
 		},
+		{
+			name:         "error when DISTRIBUTION_ACCOUNT.STELLAR.REMOTE fails to be instantiated",
+			accountTypes: []schema.AccountType{schema.DistributionAccountStellarRemote},
+			opts: SignatureRouterOptions{
+				NetworkPassphrase: networkPassphrase,
+			},
+			wantErrContains: `creating a new "DISTRIBUTION_ACCOUNT.STELLAR.REMOTE" signature client`,
+		},
 		{
 			name:            "error when an invalid account type is passed",
 			accountTypes:    []schema.AccountType{"INVALID"},
@@ -163,6 +187,17 @@ func Test_NewSignerRouter(t *testing.T) {
 				networkPassphrase: networkPassphrase,
 			},
 		},
+		{
+			name:         "🎉 successfully instantiate new signature router with accountTypes=[DISTRIBUTION_ACCOUNT.STELLAR.REMOTE]",
+			accountTypes: []schema.AccountType{schema.DistributionAccountStellarRemote},
+			opts:         validOptionsWithRemoteSigner,
+			wantSignerRouter: &SignerRouterImpl{
+				strategies: map[schema.AccountType]SignatureClient{
+					schema.DistributionAccountStellarRemote: wantDistAccStellarRemoteSigner,
+				},
+				networkPassphrase: networkPassphrase,
+			},
+		},
 		{
 			name: "🎉 successfully instantiate new signature router with ALL types (non-empty accountTypes parameter)",
 			accountTypes: []schema.AccountType{
@@ -195,6 +230,20 @@ func Test_NewSignerRouter(t *testing.T) {
 				networkPassphrase: networkPassphrase,
 			},
 		},
+		{
+			name: "🎉 successfully instantiate new signature router with ALL types and the remote signer (empty accountTypes parameter)",
+			opts: validOptionsWithRemoteSigner,
+			wantSignerRouter: &SignerRouterImpl{
+				strategies: map[schema.AccountType]SignatureClient{
+					schema.HostStellarEnv:                    wantHostAccStellarEnvSigner,
+					schema.ChannelAccountStellarDB:           wantChAccStellarDBSigner,
+					schema.DistributionAccountStellarEnv:     wantDistAccStelarEnvSigner,
+					schema.DistributionAccountStellarDBVault: wantDistAccStellarDBVaultSigner,
+					schema.DistributionAccountStellarRemote:  wantDistAccStellarRemoteSigner,
+				},
+				networkPassphrase: networkPassphrase,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
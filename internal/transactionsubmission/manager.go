@@ -27,9 +27,12 @@ const serviceName = "Transaction Submission Service"
 type SubmitterOptions struct {
 	NumChannelAccounts   int
 	QueuePollingInterval int
-	MonitorService       tssMonitor.TSSMonitorService
-	CrashTrackerClient   crashtracker.CrashTrackerClient
-	EventProducer        events.Producer
+	// MaxPaymentsPerTransaction is the maximum number of payments bundled in a single Stellar transaction. Values
+	// lower or equal to 1 disable batching.
+	MaxPaymentsPerTransaction int
+	MonitorService            tssMonitor.TSSMonitorService
+	CrashTrackerClient        crashtracker.CrashTrackerClient
+	EventProducer             events.Producer
 
 	SubmitterEngine  engine.SubmitterEngine
 	DBConnectionPool db.DBConnectionPool
@@ -52,6 +55,10 @@ func (so *SubmitterOptions) validate() error {
 		return fmt.Errorf("queue polling interval must be greater than 6 seconds")
 	}
 
+	if so.MaxPaymentsPerTransaction < 0 || so.MaxPaymentsPerTransaction > store.MaxTransactionsPerBatch {
+		return fmt.Errorf("max payments per transaction must stay in the range from 0 to %d", store.MaxTransactionsPerBatch)
+	}
+
 	if sdpUtils.IsEmpty(so.MonitorService) {
 		return fmt.Errorf("monitor service cannot be nil")
 	}
@@ -66,8 +73,9 @@ type Manager struct {
 	chAccModel       *store.ChannelAccountModel
 	chTxBundleModel  *store.ChannelTransactionBundleModel
 	// job-related:
-	pollingInterval     time.Duration
-	txProcessingLimiter engine.TransactionProcessingLimiter
+	pollingInterval           time.Duration
+	txProcessingLimiter       engine.TransactionProcessingLimiter
+	maxPaymentsPerTransaction int
 	// transaction submission:
 	engine *engine.SubmitterEngine
 	// crash & metrics monitoring:
@@ -125,8 +133,9 @@ func NewManager(ctx context.Context, opts SubmitterOptions) (m *Manager, err err
 		txModel:          txModel,
 		chTxBundleModel:  chTxBundleModel,
 
-		pollingInterval:     time.Second * time.Duration(opts.QueuePollingInterval),
-		txProcessingLimiter: txProcessingLimiter,
+		pollingInterval:           time.Second * time.Duration(opts.QueuePollingInterval),
+		txProcessingLimiter:       txProcessingLimiter,
+		maxPaymentsPerTransaction: opts.MaxPaymentsPerTransaction,
 
 		engine: &opts.SubmitterEngine,
 
@@ -160,6 +169,11 @@ func (m *Manager) ProcessTransactions(ctx context.Context) {
 			return
 
 		case <-ticker.C:
+			if m.maxPaymentsPerTransaction > 1 {
+				m.processBatchJobs(ctx)
+				continue
+			}
+
 			log.Ctx(ctx).Debug("Loading transactions from database...")
 			jobs, err := m.loadReadyForProcessingBundles(ctx)
 			if err != nil {
@@ -215,3 +229,59 @@ func (m *Manager) loadReadyForProcessingBundles(ctx context.Context) ([]*store.C
 
 	return chTxBundles, nil
 }
+
+// processBatchJobs loads the batches of transactions that are ready to be processed and spawns a batch worker for each
+// one of them.
+func (m *Manager) processBatchJobs(ctx context.Context) {
+	log.Ctx(ctx).Debug("Loading transaction batches from database...")
+	batches, err := m.loadReadyForProcessingBatches(ctx)
+	if err != nil {
+		err = fmt.Errorf("attempting to load transaction batches from database: %w", err)
+		if errors.Is(err, store.ErrInsuficientChannelAccounts) {
+			log.Ctx(ctx).Warn(err)
+		} else {
+			m.crashTrackerClient.LogAndReportErrors(ctx, err, "")
+		}
+		return
+	}
+
+	log.Ctx(ctx).Debugf("Loaded '%d' transaction batches from database", len(batches))
+
+	for _, batch := range batches {
+		worker, err := NewBatchTransactionWorker(
+			m.dbConnectionPool,
+			m.txModel,
+			m.chAccModel,
+			m.engine,
+			m.crashTrackerClient,
+			m.txProcessingLimiter,
+			m.monitorService,
+			m.eventProducer,
+		)
+		if err != nil {
+			m.crashTrackerClient.LogAndReportErrors(ctx, err, "")
+			continue
+		}
+
+		batchJob := TxBatchJob(*batch)
+		go worker.Run(ctx, &batchJob)
+	}
+}
+
+// loadReadyForProcessingBatches loads a list of {channelAccount, []Transaction, LedgerBoundsMax} batches from the
+// database which are ready to be processed. The batches are locked for processing in the database, so that other
+// instances of the process don't pick them up.
+func (m *Manager) loadReadyForProcessingBatches(ctx context.Context) ([]*store.ChannelTransactionBatchBundle, error) {
+	currentLedgerNumber, err := m.engine.LedgerNumberTracker.GetLedgerNumber()
+	if err != nil {
+		return nil, fmt.Errorf("getting current ledger number: %w", err)
+	}
+	lockToLedgerNumber := currentLedgerNumber + preconditions.IncrementForMaxLedgerBounds
+
+	batches, err := m.chTxBundleModel.LoadAndLockBatches(ctx, currentLedgerNumber, lockToLedgerNumber, m.txProcessingLimiter.LimitValue(), m.maxPaymentsPerTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("loading channel transaction batches: %w", err)
+	}
+
+	return batches, nil
+}
@@ -149,6 +149,28 @@ func Test_SubmitterOptions_validate(t *testing.T) {
 			},
 			wantErrContains: "queue polling interval must be greater than 6 seconds",
 		},
+		{
+			name: "validate MaxPaymentsPerTransaction (min)",
+			submitterOptions: SubmitterOptions{
+				DBConnectionPool:          dbConnectionPool,
+				SubmitterEngine:           mSubmitterEngine,
+				NumChannelAccounts:        1,
+				QueuePollingInterval:      10,
+				MaxPaymentsPerTransaction: -1,
+			},
+			wantErrContains: "max payments per transaction must stay in the range from 0 to 100",
+		},
+		{
+			name: "validate MaxPaymentsPerTransaction (max)",
+			submitterOptions: SubmitterOptions{
+				DBConnectionPool:          dbConnectionPool,
+				SubmitterEngine:           mSubmitterEngine,
+				NumChannelAccounts:        1,
+				QueuePollingInterval:      10,
+				MaxPaymentsPerTransaction: 101,
+			},
+			wantErrContains: "max payments per transaction must stay in the range from 0 to 100",
+		},
 		{
 			name: "validate monitorService",
 			submitterOptions: SubmitterOptions{
@@ -441,7 +463,7 @@ func Test_Manager_ProcessTransactions(t *testing.T) {
 				AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 				DestinationAddress: keypair.MustRandom().Address(),
 				Status:             store.TransactionStatusPending,
-				Amount:             1,
+				Amount:             "1.0000000",
 				TenantID:           tnt.ID,
 			})
 
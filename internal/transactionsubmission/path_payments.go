@@ -0,0 +1,121 @@
+package transactionsubmission
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/txnbuild"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/utils"
+)
+
+// buildTransactionOperation builds the operation that delivers the transaction funds to its destination. Path payments
+// need to discover a conversion path in Horizon, while the other payout modes are built from the transaction alone.
+func (tw *TransactionWorker) buildTransactionOperation(tx *store.Transaction, distributionAccountAddress string) (txnbuild.Operation, error) {
+	if tx.IsPathPayment() {
+		return tw.buildPathPaymentOperation(tx, distributionAccountAddress)
+	}
+	return buildOperation(tx, distributionAccountAddress)
+}
+
+// buildPathPaymentOperation builds a `PathPaymentStrictReceive` operation that delivers the exact transaction amount in
+// the destination asset. The path is the cheapest one found in Horizon, and the maximum amount that can be spent is
+// the quoted source amount increased by the transaction's max slippage.
+func (tw *TransactionWorker) buildPathPaymentOperation(tx *store.Transaction, distributionAccountAddress string) (txnbuild.Operation, error) {
+	sendAsset, err := stellarAsset(tx)
+	if err != nil {
+		return nil, err
+	}
+	destAsset, err := destinationStellarAsset(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	sendAssetXDR, err := sendAsset.ToXDR()
+	if err != nil {
+		return nil, fmt.Errorf("converting send asset to XDR: %w", err)
+	}
+
+	pathsPage, err := tw.engine.HorizonClient.Paths(horizonclient.PathsRequest{
+		DestinationAssetType:   utils.HorizonAssetType(tx.DestinationAssetCode),
+		DestinationAssetCode:   destAsset.GetCode(),
+		DestinationAssetIssuer: destAsset.GetIssuer(),
+		DestinationAmount:      tx.Amount,
+		SourceAssets:           sendAssetXDR.StringCanonical(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting paths from Horizon: %w", err)
+	}
+
+	bestPath, err := utils.CheapestPath(pathsPage.Embedded.Records)
+	if err != nil {
+		return nil, fmt.Errorf("finding a path from %s to %s: %w", sendAssetXDR.StringCanonical(), destAsset.GetCode(), err)
+	}
+
+	sendMax, err := utils.ApplySlippage(bestPath.SourceAmount, tx.MaxSlippageBps)
+	if err != nil {
+		return nil, fmt.Errorf("applying slippage to the source amount: %w", err)
+	}
+
+	path := make([]txnbuild.Asset, 0, len(bestPath.Path))
+	for _, hAsset := range bestPath.Path {
+		path = append(path, txnbuildAsset(hAsset))
+	}
+
+	return &txnbuild.PathPaymentStrictReceive{
+		SourceAccount: distributionAccountAddress,
+		SendAsset:     sendAsset,
+		SendMax:       sendMax,
+		Destination:   tx.Destination,
+		DestAsset:     destAsset,
+		DestAmount:    tx.Amount,
+		Path:          path,
+	}, nil
+}
+
+// savePathPaymentResultIfNeeded saves the conversion path and the source amount spent by a successful path payment. The
+// path is read from the submitted envelope and the amount from the result of the operation at the given index.
+// Transactions that are not path payments are returned unchanged.
+func (tw *TransactionWorker) savePathPaymentResultIfNeeded(ctx context.Context, tx store.Transaction, resultXDR string, opIndex int) (*store.Transaction, error) {
+	if !tx.IsPathPayment() || tx.SourceAmount.Valid {
+		return &tx, nil
+	}
+
+	if tx.OperationIndex.Valid {
+		opIndex = int(tx.OperationIndex.Int32)
+	}
+	path, err := utils.PathPaymentPath(tx.XDRSent.String, opIndex)
+	if err != nil {
+		return nil, fmt.Errorf("getting path payment path for transaction %s: %w", tx.ID, err)
+	}
+	sourceAmount, err := utils.PathPaymentSourceAmount(resultXDR, opIndex)
+	if err != nil {
+		return nil, fmt.Errorf("getting path payment source amount for transaction %s: %w", tx.ID, err)
+	}
+
+	updatedTx, err := tw.txModel.UpdatePathPaymentResult(ctx, tx.ID, path, sourceAmount)
+	if err != nil {
+		return nil, fmt.Errorf("updating path payment result for transaction %s: %w", tx.ID, err)
+	}
+
+	return updatedTx, nil
+}
+
+// destinationStellarAsset validates the destination asset of the transaction and converts it into a txnbuild.Asset.
+func destinationStellarAsset(tx *store.Transaction) (txnbuild.Asset, error) {
+	asset, err := stellarAsset(&store.Transaction{AssetCode: tx.DestinationAssetCode, AssetIssuer: tx.DestinationAssetIssuer})
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination asset: %w", err)
+	}
+	return asset, nil
+}
+
+func txnbuildAsset(hAsset horizon.Asset) txnbuild.Asset {
+	if hAsset.Type == string(horizonclient.AssetTypeNative) {
+		return txnbuild.NativeAsset{}
+	}
+	return txnbuild.CreditAsset{Code: hAsset.Code, Issuer: hAsset.Issuer}
+}
@@ -0,0 +1,182 @@
+package transactionsubmission
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/engine"
+	"github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store"
+	storeMocks "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store/mocks"
+)
+
+func Test_TransactionWorker_buildPathPaymentOperation(t *testing.T) {
+	distributionAddress := keypair.MustRandom().Address()
+	destination := keypair.MustRandom().Address()
+	issuer := keypair.MustRandom().Address()
+	tx := &store.Transaction{
+		AssetCode:              "USDC",
+		AssetIssuer:            issuer,
+		Amount:                 "500",
+		Destination:            destination,
+		DestinationAssetCode:   "BRL",
+		DestinationAssetIssuer: issuer,
+		MaxSlippageBps:         100,
+	}
+	pathsRequest := horizonclient.PathsRequest{
+		DestinationAssetType:   horizonclient.AssetType4,
+		DestinationAssetCode:   "BRL",
+		DestinationAssetIssuer: issuer,
+		DestinationAmount:      "500",
+		SourceAssets:           "USDC:" + issuer,
+	}
+
+	t.Run("returns an error if Horizon fails", func(t *testing.T) {
+		hMock := &horizonclient.MockClient{}
+		defer hMock.AssertExpectations(t)
+		hMock.On("Paths", pathsRequest).Return(horizon.PathsPage{}, assert.AnError).Once()
+
+		tw := TransactionWorker{engine: &engine.SubmitterEngine{HorizonClient: hMock}}
+		op, err := tw.buildPathPaymentOperation(tx, distributionAddress)
+		assert.ErrorContains(t, err, "getting paths from Horizon")
+		assert.Nil(t, op)
+	})
+
+	t.Run("returns an error if no path is found", func(t *testing.T) {
+		hMock := &horizonclient.MockClient{}
+		defer hMock.AssertExpectations(t)
+		hMock.On("Paths", pathsRequest).Return(horizon.PathsPage{}, nil).Once()
+
+		tw := TransactionWorker{engine: &engine.SubmitterEngine{HorizonClient: hMock}}
+		op, err := tw.buildPathPaymentOperation(tx, distributionAddress)
+		assert.EqualError(t, err, "finding a path from USDC:"+issuer+" to BRL: no path found")
+		assert.Nil(t, op)
+	})
+
+	t.Run("🎉 builds the operation with the cheapest path", func(t *testing.T) {
+		pathsPage := horizon.PathsPage{}
+		pathsPage.Embedded.Records = []horizon.Path{
+			{SourceAmount: "100.5", Path: []horizon.Asset{{Type: "native"}}},
+			{SourceAmount: "100", Path: []horizon.Asset{{Type: "credit_alphanum4", Code: "EURC", Issuer: issuer}}},
+		}
+		hMock := &horizonclient.MockClient{}
+		defer hMock.AssertExpectations(t)
+		hMock.On("Paths", pathsRequest).Return(pathsPage, nil).Once()
+
+		tw := TransactionWorker{engine: &engine.SubmitterEngine{HorizonClient: hMock}}
+		op, err := tw.buildTransactionOperation(tx, distributionAddress)
+		require.NoError(t, err)
+
+		wantOp := &txnbuild.PathPaymentStrictReceive{
+			SourceAccount: distributionAddress,
+			SendAsset:     txnbuild.CreditAsset{Code: "USDC", Issuer: issuer},
+			SendMax:       "101.0000000",
+			Destination:   destination,
+			DestAsset:     txnbuild.CreditAsset{Code: "BRL", Issuer: issuer},
+			DestAmount:    "500",
+			Path:          []txnbuild.Asset{txnbuild.CreditAsset{Code: "EURC", Issuer: issuer}},
+		}
+		assert.Equal(t, wantOp, op)
+	})
+}
+
+func Test_TransactionWorker_savePathPaymentResultIfNeeded(t *testing.T) {
+	ctx := context.Background()
+	distributionKP := keypair.MustRandom()
+	issuer := keypair.MustRandom().Address()
+
+	innerTx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: distributionKP.Address(), Sequence: 1},
+		Operations: []txnbuild.Operation{
+			&txnbuild.PathPaymentStrictReceive{
+				SendAsset:   txnbuild.CreditAsset{Code: "USDC", Issuer: issuer},
+				SendMax:     "101",
+				Destination: keypair.MustRandom().Address(),
+				DestAsset:   txnbuild.CreditAsset{Code: "BRL", Issuer: issuer},
+				DestAmount:  "500",
+				Path:        []txnbuild.Asset{txnbuild.NativeAsset{}},
+			},
+		},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	innerTx, err = innerTx.Sign(network.TestNetworkPassphrase, distributionKP)
+	require.NoError(t, err)
+	feeBumpTx, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      innerTx,
+		FeeAccount: distributionKP.Address(),
+		BaseFee:    txnbuild.MinBaseFee * 2,
+	})
+	require.NoError(t, err)
+	envelopeXDR, err := feeBumpTx.Base64()
+	require.NoError(t, err)
+
+	opResults := []xdr.OperationResult{{
+		Code: xdr.OperationResultCodeOpInner,
+		Tr: &xdr.OperationResultTr{
+			Type: xdr.OperationTypePathPaymentStrictReceive,
+			PathPaymentStrictReceiveResult: &xdr.PathPaymentStrictReceiveResult{
+				Code: xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveSuccess,
+				Success: &xdr.PathPaymentStrictReceiveResultSuccess{
+					Offers: []xdr.ClaimAtom{{
+						Type: xdr.ClaimAtomTypeClaimAtomTypeOrderBook,
+						OrderBook: &xdr.ClaimOfferAtom{
+							SellerId:     xdr.MustAddress(keypair.MustRandom().Address()),
+							AssetSold:    xdr.MustNewNativeAsset(),
+							AmountSold:   10000000000,
+							AssetBought:  xdr.MustNewCreditAsset("USDC", issuer),
+							AmountBought: 995000000,
+						},
+					}},
+					Last: xdr.SimplePaymentResult{
+						Destination: xdr.MustAddress(keypair.MustRandom().Address()),
+						Asset:       xdr.MustNewCreditAsset("BRL", issuer),
+						Amount:      5000000000,
+					},
+				},
+			},
+		},
+	}}
+	resultXDR, err := xdr.MarshalBase64(xdr.TransactionResult{
+		Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &opResults},
+	})
+	require.NoError(t, err)
+
+	t.Run("returns transactions that are not path payments unchanged", func(t *testing.T) {
+		tx := store.Transaction{ID: "tx-id", AssetCode: "USDC"}
+		tw := TransactionWorker{txModel: storeMocks.NewMockTransactionStore(t)}
+
+		updatedTx, err := tw.savePathPaymentResultIfNeeded(ctx, tx, resultXDR, 0)
+		require.NoError(t, err)
+		assert.Equal(t, tx, *updatedTx)
+	})
+
+	t.Run("🎉 saves the path and the source amount", func(t *testing.T) {
+		tx := store.Transaction{
+			ID:                   "tx-id",
+			DestinationAssetCode: "BRL",
+			XDRSent:              sql.NullString{String: envelopeXDR, Valid: true},
+		}
+		mTxStore := storeMocks.NewMockTransactionStore(t)
+		mTxStore.
+			On("UpdatePathPaymentResult", mock.Anything, "tx-id", []string{"native"}, "99.5000000").
+			Return(&store.Transaction{ID: "tx-id", SourceAmount: sql.NullString{String: "99.5000000", Valid: true}}, nil).
+			Once()
+		tw := TransactionWorker{txModel: mTxStore}
+
+		updatedTx, err := tw.savePathPaymentResultIfNeeded(ctx, tx, resultXDR, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "99.5000000", updatedTx.SourceAmount.String)
+	})
+}
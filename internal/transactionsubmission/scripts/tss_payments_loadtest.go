@@ -132,7 +132,7 @@ func createPaymentTransactions(ctx context.Context, txModel *store.TransactionMo
 			ExternalID:  externalID,
 			AssetCode:   assetCode,
 			AssetIssuer: assetIssuer,
-			Amount:      0.1,
+			Amount:      "0.1",
 			Destination: destination,
 		})
 	}
@@ -131,3 +131,152 @@ func (m *ChannelTransactionBundleModel) LoadAndLockTuples(ctx context.Context, c
 		return bundles, nil
 	})
 }
+
+// MaxTransactionsPerBatch is the maximum number of transactions that can be grouped in a batch, which is limited by the
+// maximum number of operations allowed in a Stellar transaction.
+const MaxTransactionsPerBatch = 100
+
+// ChannelTransactionBatchBundle is an abstraction that aggregates a ChannelAccount and a batch of Transactions that
+// will be submitted together in a single Stellar transaction. All the transactions in a batch belong to the same tenant,
+// and therefore to the same distribution account, and share the same memo.
+type ChannelTransactionBatchBundle struct {
+	// ChannelAccount is the resource needed to process the Transactions.
+	ChannelAccount ChannelAccount
+	// Transactions are the jobs that would be handled together by the worker.
+	Transactions []Transaction
+	// LockedUntilLedgerNumber is the ledger number until which both the transactions and channel account are locked.
+	LockedUntilLedgerNumber int
+}
+
+// batchKey returns the key used to group transactions in batches. Transactions that were already submitted are grouped
+// by their Stellar transaction hash, so they can be reconciled together. Transactions pending submission are grouped by
+// tenant and memo, since the memo is set at the Stellar transaction level.
+func batchKey(tx Transaction) string {
+	if tx.StellarTransactionHash.Valid {
+		return "hash:" + tx.StellarTransactionHash.String
+	}
+	return fmt.Sprintf("tenant:%s|memo:%s:%s", tx.TenantID, tx.MemoType, tx.Memo)
+}
+
+// groupTransactionsInBatches groups the transactions in batches of at most batchSize transactions, keeping the order
+// in which the batches first appear in the input.
+func groupTransactionsInBatches(transactions []Transaction, batchSize int) [][]Transaction {
+	var batches [][]Transaction
+	openBatchIndexByKey := map[string]int{}
+	for _, tx := range transactions {
+		key := batchKey(tx)
+		idx, ok := openBatchIndexByKey[key]
+		if !ok || (len(batches[idx]) >= batchSize && !tx.StellarTransactionHash.Valid) {
+			batches = append(batches, []Transaction{})
+			idx = len(batches) - 1
+			openBatchIndexByKey[key] = idx
+		}
+		batches[idx] = append(batches[idx], tx)
+	}
+	return batches
+}
+
+// LoadAndLockBatches loads a slice of ChannelTransactionBatchBundle from the database, and locks them until the given
+// ledger number, up to the amount of batches specified by the {limit} parameter. Each batch contains at most
+// {batchSize} transactions. It returns the ErrInsuficientChannelAccounts error if there are transactions to process
+// but no channel accounts available.
+func (m *ChannelTransactionBundleModel) LoadAndLockBatches(ctx context.Context, currentLedgerNumber, lockToLedgerNumber, limit, batchSize int) ([]*ChannelTransactionBatchBundle, error) {
+	if limit < 1 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	if batchSize < 1 || batchSize > MaxTransactionsPerBatch {
+		return nil, fmt.Errorf("batch size must be between 1 and %d", MaxTransactionsPerBatch)
+	}
+
+	if lockToLedgerNumber <= currentLedgerNumber {
+		return nil, fmt.Errorf("lockToLedgerNumber must be greater than currentLedgerNumber")
+	}
+
+	return db.RunInTransactionWithResult(ctx, m.dbConnectionPool, nil, func(dbTx db.DBTransaction) ([]*ChannelTransactionBatchBundle, error) {
+		// STEP 1: get transactions available to be processed:
+		q := fmt.Sprintf(`
+			SELECT
+				*
+			FROM
+				submitter_transactions
+			WHERE
+				%s
+				AND synced_at IS NULL
+				AND status = ANY($1)
+			ORDER BY
+				updated_at ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		`, m.transactionModel.queryFilterForLockedState(false, int32(currentLedgerNumber)),
+		)
+		var unlockedTransactions []Transaction
+		allowedTxStatuses := []TransactionStatus{TransactionStatusPending, TransactionStatusProcessing}
+		err := dbTx.SelectContext(ctx, &unlockedTransactions, q, pq.Array(allowedTxStatuses), limit*batchSize)
+		if err != nil {
+			return nil, fmt.Errorf("fetching unlocked transactions: %w", err)
+		}
+		if len(unlockedTransactions) == 0 {
+			return nil, nil
+		}
+
+		// STEP 2: group the transactions in batches:
+		batches := groupTransactionsInBatches(unlockedTransactions, batchSize)
+		if len(batches) > limit {
+			batches = batches[:limit]
+		}
+
+		// STEP 3: get channel accounts available to process the batches:
+		q = fmt.Sprintf(`
+			SELECT
+				*
+			FROM
+				channel_accounts
+			WHERE
+				%s
+			ORDER BY
+				updated_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+			`, m.channelAccountModel.queryFilterForLockedState(false, int32(currentLedgerNumber)),
+		)
+		var unlockedChannelAccounts []ChannelAccount
+		err = dbTx.SelectContext(ctx, &unlockedChannelAccounts, q, len(batches))
+		if err != nil {
+			return nil, fmt.Errorf("calculating amount ov available channel accounts: %w", err)
+		}
+		if len(unlockedChannelAccounts) == 0 {
+			return nil, ErrInsuficientChannelAccounts
+		}
+
+		// STEP 4: lock channel accounts and transactions, and build the bundle slice:
+		bundleLen := len(unlockedChannelAccounts)
+		bundles := make([]*ChannelTransactionBatchBundle, bundleLen)
+		for i := 0; i < bundleLen; i++ {
+			chAcc := &unlockedChannelAccounts[i]
+			var lockedChAcc *ChannelAccount
+			lockedChAcc, err = m.channelAccountModel.Lock(ctx, dbTx, chAcc.PublicKey, int32(currentLedgerNumber), int32(lockToLedgerNumber))
+			if err != nil {
+				return nil, fmt.Errorf("locking channel account %q: %w", chAcc.PublicKey, err)
+			}
+
+			lockedTxs := make([]Transaction, 0, len(batches[i]))
+			for _, tx := range batches[i] {
+				var lockedTx *Transaction
+				lockedTx, err = m.transactionModel.Lock(ctx, dbTx, tx.ID, int32(currentLedgerNumber), int32(lockToLedgerNumber))
+				if err != nil {
+					return nil, fmt.Errorf("locking transaction %q: %w", tx.ID, err)
+				}
+				lockedTxs = append(lockedTxs, *lockedTx)
+			}
+
+			bundles[i] = &ChannelTransactionBatchBundle{
+				ChannelAccount:          *lockedChAcc,
+				Transactions:            lockedTxs,
+				LockedUntilLedgerNumber: lockToLedgerNumber,
+			}
+		}
+
+		return bundles, nil
+	})
+}
@@ -2,15 +2,18 @@ package store
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
 	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
 	sdpUtils "github.com/stellar/stellar-disbursement-platform-backend/internal/utils"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
 )
 
 func Test_NewChannelTransactionBundleModel(t *testing.T) {
@@ -169,7 +172,7 @@ func Test_ChannelTransactionBundleModel_LoadAndLockTuples(t *testing.T) {
 				AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 				DestinationAddress: "",
 				Status:             TransactionStatusPending,
-				Amount:             1,
+				Amount:             "1.0000000",
 				TenantID:           uuid.NewString(),
 			})
 			for _, tx := range lockedTransactions {
@@ -183,7 +186,7 @@ func Test_ChannelTransactionBundleModel_LoadAndLockTuples(t *testing.T) {
 				AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 				DestinationAddress: "",
 				Status:             TransactionStatusPending,
-				Amount:             1,
+				Amount:             "1.0000000",
 				TenantID:           uuid.NewString(),
 			})
 
@@ -234,3 +237,169 @@ func Test_ChannelTransactionBundleModel_LoadAndLockTuples(t *testing.T) {
 		})
 	}
 }
+
+func Test_groupTransactionsInBatches(t *testing.T) {
+	tenantA, tenantB := "tenant-a", "tenant-b"
+	newTx := func(id, tenantID, memo, hash string) Transaction {
+		tx := Transaction{ID: id, TenantID: tenantID}
+		if memo != "" {
+			tx.Memo = memo
+			tx.MemoType = schema.MemoTypeText
+		}
+		if hash != "" {
+			tx.StellarTransactionHash = sql.NullString{String: hash, Valid: true}
+		}
+		return tx
+	}
+
+	transactions := []Transaction{
+		newTx("1", tenantA, "", ""),
+		newTx("2", tenantB, "", ""),
+		newTx("3", tenantA, "", ""),
+		newTx("4", tenantA, "memo", ""),
+		newTx("5", tenantA, "", ""),
+		newTx("6", tenantA, "", "hash-1"),
+		newTx("7", tenantA, "memo", "hash-1"),
+	}
+
+	testCases := []struct {
+		name       string
+		batchSize  int
+		wantGroups [][]string
+	}{
+		{
+			name:       "batch size 1 keeps one transaction per batch, except for already submitted ones",
+			batchSize:  1,
+			wantGroups: [][]string{{"1"}, {"2"}, {"3"}, {"4"}, {"5"}, {"6", "7"}},
+		},
+		{
+			name:       "batch size 2 splits the transactions from the same tenant and memo",
+			batchSize:  2,
+			wantGroups: [][]string{{"1", "3"}, {"2"}, {"4"}, {"5"}, {"6", "7"}},
+		},
+		{
+			name:       "batch size 100 groups the transactions by tenant, memo and hash",
+			batchSize:  100,
+			wantGroups: [][]string{{"1", "3", "5"}, {"2"}, {"4"}, {"6", "7"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			batches := groupTransactionsInBatches(transactions, tc.batchSize)
+			gotGroups := sdpUtils.MapSlice(batches, func(batch []Transaction) []string {
+				return sdpUtils.MapSlice(batch, func(tx Transaction) string { return tx.ID })
+			})
+			assert.Equal(t, tc.wantGroups, gotGroups)
+		})
+	}
+}
+
+func Test_ChannelTransactionBundleModel_LoadAndLockBatches(t *testing.T) {
+	dbt := dbtest.OpenWithTSSMigrationsOnly(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	const currentLedgerNumber = 100
+
+	chTxBundleModel, err := NewChannelTransactionBundleModel(dbConnectionPool)
+	require.NoError(t, err)
+	txModel := NewTransactionModel(dbConnectionPool)
+	chAccModel := ChannelAccountModel{dbConnectionPool}
+
+	t.Run("validates the input parameters", func(t *testing.T) {
+		_, err = chTxBundleModel.LoadAndLockBatches(ctx, currentLedgerNumber, currentLedgerNumber+1, 0, 10)
+		assert.EqualError(t, err, "limit must be greater than 0")
+
+		_, err = chTxBundleModel.LoadAndLockBatches(ctx, currentLedgerNumber, currentLedgerNumber+1, 1, 0)
+		assert.EqualError(t, err, "batch size must be between 1 and 100")
+
+		_, err = chTxBundleModel.LoadAndLockBatches(ctx, currentLedgerNumber, currentLedgerNumber+1, 1, 101)
+		assert.EqualError(t, err, "batch size must be between 1 and 100")
+
+		_, err = chTxBundleModel.LoadAndLockBatches(ctx, currentLedgerNumber, currentLedgerNumber, 1, 10)
+		assert.EqualError(t, err, "lockToLedgerNumber must be greater than currentLedgerNumber")
+	})
+
+	t.Run("returns nil if there are no transactions to process", func(t *testing.T) {
+		batches, err := chTxBundleModel.LoadAndLockBatches(ctx, currentLedgerNumber, currentLedgerNumber+1, 1, 10)
+		require.NoError(t, err)
+		assert.Nil(t, batches)
+	})
+
+	t.Run("returns an error if there are no channel accounts available", func(t *testing.T) {
+		defer DeleteAllTransactionFixtures(t, ctx, dbConnectionPool)
+		CreateTransactionFixturesNew(t, ctx, dbConnectionPool, 2, TransactionFixture{
+			AssetCode:   "USDC",
+			AssetIssuer: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+			Status:      TransactionStatusPending,
+			Amount:      "1.0000000",
+			TenantID:    uuid.NewString(),
+		})
+
+		batches, err := chTxBundleModel.LoadAndLockBatches(ctx, currentLedgerNumber, currentLedgerNumber+1, 1, 10)
+		assert.ErrorIs(t, err, ErrInsuficientChannelAccounts)
+		assert.Nil(t, batches)
+	})
+
+	t.Run("🎉 successfully loads and locks batches grouped by tenant", func(t *testing.T) {
+		defer DeleteAllTransactionFixtures(t, ctx, dbConnectionPool)
+		defer DeleteAllFromChannelAccounts(t, ctx, dbConnectionPool)
+
+		chAccounts := CreateChannelAccountFixtures(t, ctx, dbConnectionPool, 3)
+		tenantATxs := CreateTransactionFixturesNew(t, ctx, dbConnectionPool, 5, TransactionFixture{
+			AssetCode:   "USDC",
+			AssetIssuer: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+			Status:      TransactionStatusPending,
+			Amount:      "1.0000000",
+			TenantID:    "tenant-a",
+		})
+		tenantBTxs := CreateTransactionFixturesNew(t, ctx, dbConnectionPool, 2, TransactionFixture{
+			AssetCode:   "USDC",
+			AssetIssuer: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+			Status:      TransactionStatusPending,
+			Amount:      "1.0000000",
+			TenantID:    "tenant-b",
+		})
+
+		const lockToLedgerNumber = currentLedgerNumber + 10
+		batches, err := chTxBundleModel.LoadAndLockBatches(ctx, currentLedgerNumber, lockToLedgerNumber, 10, 10)
+		require.NoError(t, err)
+		require.Len(t, batches, 2)
+
+		allTxIDs := append(
+			sdpUtils.MapSlice(tenantATxs, func(tx *Transaction) string { return tx.ID }),
+			sdpUtils.MapSlice(tenantBTxs, func(tx *Transaction) string { return tx.ID })...,
+		)
+		chAccIDs := sdpUtils.MapSlice(chAccounts, func(chAcc *ChannelAccount) string { return chAcc.PublicKey })
+		gotBatchSizes := map[string]int{}
+		for _, batch := range batches {
+			assert.Contains(t, chAccIDs, batch.ChannelAccount.PublicKey)
+			assert.True(t, batch.ChannelAccount.IsLocked(currentLedgerNumber))
+			assert.Equal(t, lockToLedgerNumber, batch.LockedUntilLedgerNumber)
+			for _, tx := range batch.Transactions {
+				assert.Contains(t, allTxIDs, tx.ID)
+				assert.True(t, tx.IsLocked(currentLedgerNumber))
+				assert.Equal(t, batch.Transactions[0].TenantID, tx.TenantID)
+			}
+			gotBatchSizes[batch.Transactions[0].TenantID] = len(batch.Transactions)
+		}
+		assert.Equal(t, map[string]int{"tenant-a": 5, "tenant-b": 2}, gotBatchSizes)
+
+		// verify if the channel accounts are properly locked in the DB
+		var count int
+		q := fmt.Sprintf(`SELECT COUNT(*) FROM channel_accounts WHERE %s`, chAccModel.queryFilterForLockedState(true, currentLedgerNumber))
+		err = dbConnectionPool.GetContext(ctx, &count, q)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		// verify if the transactions are properly locked in the DB
+		q = fmt.Sprintf(`SELECT COUNT(*) FROM submitter_transactions WHERE %s`, txModel.queryFilterForLockedState(true, currentLedgerNumber))
+		err = dbConnectionPool.GetContext(ctx, &count, q)
+		require.NoError(t, err)
+		assert.Equal(t, 7, count)
+	})
+}
@@ -40,9 +40,10 @@ type TransactionFixture struct {
 	AssetIssuer         string
 	DestinationAddress  string
 	Status              TransactionStatus
-	Amount              float64
+	Amount              string
 	TenantID            string
 	DistributionAccount string
+	ClaimableBalance    bool
 }
 
 // CreateTransactionFixture creates a submitter transaction in the database
@@ -69,9 +70,9 @@ func CreateTransactionFixtureNew(
 
 	const query = `
 		INSERT INTO submitter_transactions
-			(external_id, status, asset_code, asset_issuer, amount, destination, tenant_id, completed_at, started_at)
+			(external_id, status, asset_code, asset_issuer, amount, destination, tenant_id, completed_at, claimable_balance, started_at)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
 		RETURNING
 			*
 	`
@@ -86,6 +87,7 @@ func CreateTransactionFixtureNew(
 		txFixture.DestinationAddress,
 		txFixture.TenantID,
 		completedAt,
+		txFixture.ClaimableBalance,
 	)
 	require.NoError(t, err)
 
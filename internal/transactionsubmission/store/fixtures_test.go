@@ -24,7 +24,7 @@ func Test_Fixtures_CreateTransactionFixture(t *testing.T) {
 	tx := Transaction{
 		AssetCode:   "USDC",
 		AssetIssuer: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
-		Amount:      1,
+		Amount:      "1.0000000",
 	}
 
 	t.Run("create transaction with pending status", func(t *testing.T) {
@@ -76,7 +76,7 @@ func Test_Fixtures_CreateAndDeleteAllTransactionFixtures(t *testing.T) {
 		ExternalID:  "external-id-1",
 		AssetCode:   "USDC",
 		AssetIssuer: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
-		Amount:      1,
+		Amount:      "1.0000000",
 	}
 
 	t.Run("create and delete transactions", func(t *testing.T) {
@@ -6,9 +6,8 @@ import (
 	context "context"
 
 	db "github.com/stellar/stellar-disbursement-platform-backend/db"
-	mock "github.com/stretchr/testify/mock"
-
 	store "github.com/stellar/stellar-disbursement-platform-backend/internal/transactionsubmission/store"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // MockTransactionStore is an autogenerated mock type for the TransactionStore type
@@ -286,6 +285,66 @@ func (_m *MockTransactionStore) Unlock(ctx context.Context, sqlExec db.SQLExecut
 	return r0, r1
 }
 
+// UpdateClaimableBalanceID provides a mock function with given fields: ctx, txID, claimableBalanceID
+func (_m *MockTransactionStore) UpdateClaimableBalanceID(ctx context.Context, txID string, claimableBalanceID string) (*store.Transaction, error) {
+	ret := _m.Called(ctx, txID, claimableBalanceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateClaimableBalanceID")
+	}
+
+	var r0 *store.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*store.Transaction, error)); ok {
+		return rf(ctx, txID, claimableBalanceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *store.Transaction); ok {
+		r0 = rf(ctx, txID, claimableBalanceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, txID, claimableBalanceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePathPaymentResult provides a mock function with given fields: ctx, txID, path, sourceAmount
+func (_m *MockTransactionStore) UpdatePathPaymentResult(ctx context.Context, txID string, path []string, sourceAmount string) (*store.Transaction, error) {
+	ret := _m.Called(ctx, txID, path, sourceAmount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePathPaymentResult")
+	}
+
+	var r0 *store.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string) (*store.Transaction, error)); ok {
+		return rf(ctx, txID, path, sourceAmount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string) *store.Transaction); ok {
+		r0 = rf(ctx, txID, path, sourceAmount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, string) error); ok {
+		r1 = rf(ctx, txID, path, sourceAmount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateStatusToError provides a mock function with given fields: ctx, tx, message
 func (_m *MockTransactionStore) UpdateStatusToError(ctx context.Context, tx store.Transaction, message string) (*store.Transaction, error) {
 	ret := _m.Called(ctx, tx, message)
@@ -376,6 +435,36 @@ func (_m *MockTransactionStore) UpdateStellarTransactionHashAndXDRSent(ctx conte
 	return r0, r1
 }
 
+// UpdateStellarTransactionHashAndXDRSentForBatch provides a mock function with given fields: ctx, sqlExec, txIDs, txHash, txXDRSent
+func (_m *MockTransactionStore) UpdateStellarTransactionHashAndXDRSentForBatch(ctx context.Context, sqlExec db.SQLExecuter, txIDs []string, txHash string, txXDRSent string) ([]*store.Transaction, error) {
+	ret := _m.Called(ctx, sqlExec, txIDs, txHash, txXDRSent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStellarTransactionHashAndXDRSentForBatch")
+	}
+
+	var r0 []*store.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SQLExecuter, []string, string, string) ([]*store.Transaction, error)); ok {
+		return rf(ctx, sqlExec, txIDs, txHash, txXDRSent)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SQLExecuter, []string, string, string) []*store.Transaction); ok {
+		r0 = rf(ctx, sqlExec, txIDs, txHash, txXDRSent)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*store.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SQLExecuter, []string, string, string) error); ok {
+		r1 = rf(ctx, sqlExec, txIDs, txHash, txXDRSent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateStellarTransactionXDRReceived provides a mock function with given fields: ctx, txID, xdrReceived
 func (_m *MockTransactionStore) UpdateStellarTransactionXDRReceived(ctx context.Context, txID string, xdrReceived string) (*store.Transaction, error) {
 	ret := _m.Called(ctx, txID, xdrReceived)
@@ -36,6 +36,9 @@ type TransactionStore interface {
 	UpdateStatusToError(ctx context.Context, tx Transaction, message string) (updatedTx *Transaction, err error)
 	UpdateStellarTransactionXDRReceived(ctx context.Context, txID string, xdrReceived string) (*Transaction, error)
 	UpdateStellarTransactionHashAndXDRSent(ctx context.Context, txID string, txHash, txXDRSent string) (*Transaction, error)
+	UpdateStellarTransactionHashAndXDRSentForBatch(ctx context.Context, sqlExec db.SQLExecuter, txIDs []string, txHash, txXDRSent string) ([]*Transaction, error)
+	UpdateClaimableBalanceID(ctx context.Context, txID, claimableBalanceID string) (*Transaction, error)
+	UpdatePathPaymentResult(ctx context.Context, txID string, path []string, sourceAmount string) (*Transaction, error)
 	Lock(ctx context.Context, sqlExec db.SQLExecuter, transactionID string, currentLedger, nextLedgerLock int32) (*Transaction, error)
 	Unlock(ctx context.Context, sqlExec db.SQLExecuter, publicKey string) (*Transaction, error)
 	// Queue management:
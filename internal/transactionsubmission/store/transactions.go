@@ -11,10 +11,12 @@ import (
 	"time"
 
 	"github.com/lib/pq"
+	"github.com/stellar/go/amount"
 	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/xdr"
 
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
 )
 
 var ErrRecordNotFound = errors.New("record not found")
@@ -29,8 +31,29 @@ type Transaction struct {
 	StatusHistory TransactionStatusHistory `db:"status_history"`
 	AssetCode     string                   `db:"asset_code"`
 	AssetIssuer   string                   `db:"asset_issuer"`
-	Amount        float64                  `db:"amount"`
-	Destination   string                   `db:"destination"`
+	// Amount is a decimal string with up to 7 decimal places, to avoid losing precision in float conversions.
+	Amount      string `db:"amount"`
+	Destination string `db:"destination"`
+	// Memo and MemoType are optional and, when present, are attached to the Stellar transaction.
+	Memo     string          `db:"memo"`
+	MemoType schema.MemoType `db:"memo_type"`
+	// ClaimableBalance indicates that the funds should be sent as a claimable balance instead of a payment, so they can
+	// be delivered to receivers that don't have a trustline for the asset yet.
+	ClaimableBalance bool `db:"claimable_balance"`
+	// ClaimableBalanceID is the ID of the claimable balance created on the Stellar network, once the transaction succeeds.
+	ClaimableBalanceID sql.NullString `db:"claimable_balance_id"`
+	// DestinationAssetCode and DestinationAssetIssuer are set when the receiver should get a different asset than the
+	// one sent by the distribution account. In that case, the transaction is submitted as a `PathPaymentStrictReceive`,
+	// where Amount is denominated in the destination asset.
+	DestinationAssetCode   string `db:"destination_asset_code"`
+	DestinationAssetIssuer string `db:"destination_asset_issuer"`
+	// MaxSlippageBps is the maximum slippage, in basis points, accepted over the source amount quoted by Horizon when
+	// sending a path payment.
+	MaxSlippageBps int `db:"max_slippage_bps"`
+	// PathPaymentPath contains the intermediary assets used in the conversion of a path payment, in their canonical form.
+	PathPaymentPath pq.StringArray `db:"path_payment_path"`
+	// SourceAmount is the amount actually spent by the distribution account in a successful path payment.
+	SourceAmount sql.NullString `db:"source_amount"`
 
 	TenantID            string         `db:"tenant_id"`
 	DistributionAccount sql.NullString `db:"distribution_account"`
@@ -52,13 +75,21 @@ type Transaction struct {
 	XDRSent sql.NullString `db:"xdr_sent"`
 	// XDRReceived is the ResultXDR received from the Stellar network when attempting to create a transaction.
 	XDRReceived sql.NullString `db:"xdr_received"`
-	LockedAt    *time.Time     `db:"locked_at"`
+	// OperationIndex is the index of this transaction's operation in the Stellar transaction. It's only set when the
+	// transaction was submitted in a batch, together with other transactions.
+	OperationIndex sql.NullInt32 `db:"operation_index"`
+	LockedAt       *time.Time    `db:"locked_at"`
 	// LockedUntilLedgerNumber is the ledger number after which the lock expires. It should be synched with the
 	// expiration ledger bound set in the Stellar transaction submitted to the blockchain, and the same value in the
 	// namesake column of the channel account model.
 	LockedUntilLedgerNumber sql.NullInt32 `db:"locked_until_ledger_number"`
 }
 
+// StellarMemo returns the memo that should be attached to the Stellar transaction.
+func (tx *Transaction) StellarMemo() schema.Memo {
+	return schema.Memo{Value: tx.Memo, Type: tx.MemoType}
+}
+
 func (tx *Transaction) IsLocked(currentLedgerNumber int32) bool {
 	return tx.LockedUntilLedgerNumber.Valid && currentLedgerNumber <= tx.LockedUntilLedgerNumber.Int32
 }
@@ -80,18 +111,45 @@ func (tx *Transaction) validate() error {
 			return fmt.Errorf("asset issuer %q is not a valid ed25519 public key", tx.AssetIssuer)
 		}
 	}
-	if tx.Amount <= 0 {
+	stroops, err := amount.ParseInt64(tx.Amount)
+	if err != nil {
+		return fmt.Errorf("amount %q is not a valid Stellar amount: %w", tx.Amount, err)
+	}
+	if stroops <= 0 {
 		return fmt.Errorf("amount must be positive")
 	}
 	if !strkey.IsValidEd25519PublicKey(tx.Destination) {
 		return fmt.Errorf("destination %q is not a valid ed25519 public key", tx.Destination)
 	}
+	if tx.IsPathPayment() {
+		if len(tx.DestinationAssetCode) > 12 {
+			return fmt.Errorf("destination asset code must have between 1 and 12 characters")
+		}
+		if strings.ToLower(tx.DestinationAssetCode) != "xlm" && !strkey.IsValidEd25519PublicKey(tx.DestinationAssetIssuer) {
+			return fmt.Errorf("destination asset issuer %q is not a valid ed25519 public key", tx.DestinationAssetIssuer)
+		}
+		if tx.ClaimableBalance {
+			return fmt.Errorf("path payments cannot be sent as claimable balances")
+		}
+	}
+	if tx.MaxSlippageBps < 0 || tx.MaxSlippageBps > 10000 {
+		return fmt.Errorf("max slippage must be between 0 and 10000 basis points")
+	}
+	if err := tx.StellarMemo().Validate(); err != nil {
+		return fmt.Errorf("validating memo: %w", err)
+	}
 	if tx.TenantID == "" {
 		return fmt.Errorf("tenant ID is required")
 	}
 	return nil
 }
 
+// IsPathPayment returns true if the receiver should get a different asset than the one sent by the distribution
+// account.
+func (tx *Transaction) IsPathPayment() bool {
+	return tx.DestinationAssetCode != ""
+}
+
 type TransactionModel struct {
 	DBConnectionPool db.DBConnectionPool
 }
@@ -117,15 +175,15 @@ func (t *TransactionModel) BulkInsert(ctx context.Context, sqlExec db.SQLExecute
 	}
 
 	var queryBuilder strings.Builder
-	queryBuilder.WriteString("INSERT INTO submitter_transactions (external_id, asset_code, asset_issuer, amount, destination, tenant_id) VALUES ")
+	queryBuilder.WriteString("INSERT INTO submitter_transactions (external_id, asset_code, asset_issuer, amount, destination, tenant_id, memo, memo_type, claimable_balance, destination_asset_code, destination_asset_issuer, max_slippage_bps) VALUES ")
 	valueStrings := make([]string, 0, len(transactions))
-	valueArgs := make([]interface{}, 0, len(transactions)*6)
+	valueArgs := make([]interface{}, 0, len(transactions)*12)
 
 	for _, transaction := range transactions {
 		if err := transaction.validate(); err != nil {
 			return nil, fmt.Errorf("validating transaction for insertion: %w", err)
 		}
-		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?)")
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 		valueArgs = append(valueArgs,
 			transaction.ExternalID,
 			transaction.AssetCode,
@@ -133,6 +191,12 @@ func (t *TransactionModel) BulkInsert(ctx context.Context, sqlExec db.SQLExecute
 			transaction.Amount,
 			transaction.Destination,
 			transaction.TenantID,
+			transaction.Memo,
+			transaction.MemoType,
+			transaction.ClaimableBalance,
+			transaction.DestinationAssetCode,
+			transaction.DestinationAssetIssuer,
+			transaction.MaxSlippageBps,
 		)
 	}
 
@@ -215,6 +279,65 @@ func (t *TransactionModel) UpdateStatusToSuccess(ctx context.Context, tx Transac
 	return &updatedTx, nil
 }
 
+// UpdateClaimableBalanceID saves the ID of the claimable balance created by the transaction.
+func (t *TransactionModel) UpdateClaimableBalanceID(ctx context.Context, txID, claimableBalanceID string) (*Transaction, error) {
+	if claimableBalanceID == "" {
+		return nil, fmt.Errorf("claimable balance ID cannot be empty")
+	}
+
+	var updatedTx Transaction
+	query := `
+		UPDATE
+			submitter_transactions
+		SET
+			claimable_balance_id = $1
+		WHERE
+			id = $2
+			AND claimable_balance
+		RETURNING
+			*
+		`
+	err := t.DBConnectionPool.GetContext(ctx, &updatedTx, query, claimableBalanceID, txID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("updating claimable balance ID for transaction %s: %w", txID, err)
+	}
+
+	return &updatedTx, nil
+}
+
+// UpdatePathPaymentResult saves the conversion path and the source amount actually spent by a successful path payment.
+func (t *TransactionModel) UpdatePathPaymentResult(ctx context.Context, txID string, path []string, sourceAmount string) (*Transaction, error) {
+	if sourceAmount == "" {
+		return nil, fmt.Errorf("source amount cannot be empty")
+	}
+
+	var updatedTx Transaction
+	query := `
+		UPDATE
+			submitter_transactions
+		SET
+			path_payment_path = $1,
+			source_amount = $2
+		WHERE
+			id = $3
+			AND destination_asset_code != ''
+		RETURNING
+			*
+		`
+	err := t.DBConnectionPool.GetContext(ctx, &updatedTx, query, pq.Array(path), sourceAmount, txID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("updating path payment result for transaction %s: %w", txID, err)
+	}
+
+	return &updatedTx, nil
+}
+
 // UpdateStatusToError updates a Transaction's status to ERROR. Only succeeds if the current status is PROCESSING.
 func (t *TransactionModel) UpdateStatusToError(ctx context.Context, tx Transaction, message string) (*Transaction, error) {
 	// verify if this state transition is valid:
@@ -282,6 +405,53 @@ func (t *TransactionModel) UpdateStellarTransactionHashAndXDRSent(ctx context.Co
 	return &tx, nil
 }
 
+// UpdateStellarTransactionHashAndXDRSentForBatch updates the Stellar transaction hash and XDR sent of a batch of
+// Transactions that are submitted together in the same Stellar transaction. The operation index of each transaction
+// is set according to its position in the txIDs slice.
+func (t *TransactionModel) UpdateStellarTransactionHashAndXDRSentForBatch(ctx context.Context, sqlExec db.SQLExecuter, txIDs []string, txHash, txXDRSent string) ([]*Transaction, error) {
+	if len(txIDs) == 0 {
+		return nil, fmt.Errorf("no transaction IDs provided")
+	}
+	if len(txHash) != 64 {
+		return nil, fmt.Errorf("invalid transaction hash %q", txHash)
+	}
+
+	var txEnvelope xdr.TransactionEnvelope
+	err := xdr.SafeUnmarshalBase64(txXDRSent, &txEnvelope)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XDR envelope: %w", err)
+	}
+	if numOps := len(txEnvelope.Operations()); numOps != len(txIDs) {
+		return nil, fmt.Errorf("the XDR envelope has %d operations but %d transactions were provided", numOps, len(txIDs))
+	}
+
+	query := `
+		UPDATE
+			submitter_transactions
+		SET
+			stellar_transaction_hash = $1::text,
+			xdr_sent = $2,
+			operation_index = array_position($3::text[], id::text) - 1,
+			sent_at = NOW(),
+			status_history = array_append(status_history, create_submitter_transactions_status_history(NOW(), status, 'Updating Stellar Transaction Hash', $1::text, $2, xdr_received)),
+			attempts_count = attempts_count + 1
+		WHERE
+			id = ANY($3)
+		RETURNING
+			*
+	`
+	var transactions []*Transaction
+	err = sqlExec.SelectContext(ctx, &transactions, query, txHash, txXDRSent, pq.Array(txIDs))
+	if err != nil {
+		return nil, fmt.Errorf("updating transaction hash for batch: %w", err)
+	}
+	if len(transactions) != len(txIDs) {
+		return nil, fmt.Errorf("expected %d transactions to be updated, got %d: %w", len(txIDs), len(transactions), ErrRecordNotFound)
+	}
+
+	return transactions, nil
+}
+
 // UpdateStellarTransactionXDRReceived updates a Transaction's XDR received.
 func (t *TransactionModel) UpdateStellarTransactionXDRReceived(ctx context.Context, txID string, xdrReceived string) (*Transaction, error) {
 	var txResult xdr.TransactionResult
@@ -481,7 +651,8 @@ func (ca *TransactionModel) PrepareTransactionForReprocessing(ctx context.Contex
 			locked_until_ledger_number = NULL,
 			stellar_transaction_hash = NULL,
 			xdr_sent = NULL,
-			xdr_received = NULL
+			xdr_received = NULL,
+			operation_index = NULL
 		WHERE
 			id = $1
 			AND synced_at IS NULL
@@ -3,16 +3,19 @@ package store
 import (
 	"context"
 	"database/sql"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stellar/go/keypair"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/stellar/stellar-disbursement-platform-backend/db"
 	"github.com/stellar/stellar-disbursement-platform-backend/db/dbtest"
+	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
 )
 
 func Test_Transaction_IsLocked(t *testing.T) {
@@ -70,7 +73,7 @@ func Test_TransactionModel_Insert(t *testing.T) {
 			ExternalID:  "external-id-1",
 			AssetCode:   "USDC",
 			AssetIssuer: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
-			Amount:      1,
+			Amount:      "1.0000000",
 			Destination: "GBHNIYGWZUAVZX7KTLVSMILBXJMUACVO6XBEKIN6RW7AABDFH6S7GK2Y",
 			TenantID:    "tenant-id-1",
 		})
@@ -84,7 +87,7 @@ func Test_TransactionModel_Insert(t *testing.T) {
 		assert.Equal(t, "external-id-1", refreshedTx.ExternalID)
 		assert.Equal(t, "USDC", refreshedTx.AssetCode)
 		assert.Equal(t, "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX", refreshedTx.AssetIssuer)
-		assert.Equal(t, float64(1), refreshedTx.Amount)
+		assert.Equal(t, "1.0000000", refreshedTx.Amount)
 		assert.Equal(t, "GBHNIYGWZUAVZX7KTLVSMILBXJMUACVO6XBEKIN6RW7AABDFH6S7GK2Y", refreshedTx.Destination)
 		assert.Equal(t, TransactionStatusPending, refreshedTx.Status)
 		assert.Equal(t, "tenant-id-1", refreshedTx.TenantID)
@@ -128,7 +131,7 @@ func Test_TransactionModel_BulkInsert(t *testing.T) {
 			AssetCode:   "USDC",
 			AssetIssuer: keypair.MustRandom().Address(),
 			// Lowest number in the Stellar network (ref: https://developers.stellar.org/docs/fundamentals-and-concepts/stellar-data-structures/assets#amount-precision):
-			Amount:      0.0000001,
+			Amount:      "0.0000001",
 			Destination: keypair.MustRandom().Address(),
 			TenantID:    uuid.NewString(),
 		}
@@ -137,8 +140,10 @@ func Test_TransactionModel_BulkInsert(t *testing.T) {
 			AssetCode:   "USDC",
 			AssetIssuer: keypair.MustRandom().Address(),
 			// Largest number in the Stellar network (ref: https://developers.stellar.org/docs/fundamentals-and-concepts/stellar-data-structures/assets#amount-precision):
-			Amount:      922337203685.4775807,
+			Amount:      "922337203685.4775807",
 			Destination: keypair.MustRandom().Address(),
+			Memo:        "memo text",
+			MemoType:    schema.MemoTypeText,
 			TenantID:    uuid.NewString(),
 		}
 		insertedTransactions, err := txModel.BulkInsert(ctx, dbConnectionPool, []Transaction{incomingTx1, incomingTx2})
@@ -162,6 +167,8 @@ func Test_TransactionModel_BulkInsert(t *testing.T) {
 		assert.Equal(t, incomingTx1.AssetIssuer, insertedTx1.AssetIssuer)
 		assert.Equal(t, incomingTx1.Amount, insertedTx1.Amount)
 		assert.Equal(t, incomingTx1.Destination, insertedTx1.Destination)
+		assert.Empty(t, insertedTx1.Memo)
+		assert.Empty(t, insertedTx1.MemoType)
 		assert.Equal(t, TransactionStatusPending, insertedTx1.Status)
 
 		assert.Equal(t, incomingTx2.ExternalID, insertedTx2.ExternalID)
@@ -169,6 +176,8 @@ func Test_TransactionModel_BulkInsert(t *testing.T) {
 		assert.Equal(t, incomingTx2.AssetIssuer, insertedTx2.AssetIssuer)
 		assert.Equal(t, incomingTx2.Amount, insertedTx2.Amount)
 		assert.Equal(t, incomingTx2.Destination, insertedTx2.Destination)
+		assert.Equal(t, incomingTx2.Memo, insertedTx2.Memo)
+		assert.Equal(t, incomingTx2.MemoType, insertedTx2.MemoType)
 		assert.Equal(t, TransactionStatusPending, insertedTx2.Status)
 	})
 }
@@ -215,7 +224,7 @@ func Test_TransactionModel_UpdateStatusToSuccess(t *testing.T) {
 		AssetIssuer:        "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
 		DestinationAddress: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 		Status:             TransactionStatusPending,
-		Amount:             1.23,
+		Amount:             "1.2300000",
 		TenantID:           uuid.NewString(),
 	})
 
@@ -227,7 +236,7 @@ func Test_TransactionModel_UpdateStatusToSuccess(t *testing.T) {
 				AssetIssuer:        "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
 				DestinationAddress: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 				Status:             tc.transactionStatus,
-				Amount:             1.23,
+				Amount:             "1.2300000",
 				TenantID:           uuid.NewString(),
 			})
 			if (tc.transactionStatus != TransactionStatusSuccess) && (tc.transactionStatus != TransactionStatusError) {
@@ -303,7 +312,7 @@ func Test_TransactionModel_UpdateStatusToError(t *testing.T) {
 		AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 		DestinationAddress: "GBHNIYGWZUAVZX7KTLVSMILBXJMUACVO6XBEKIN6RW7AABDFH6S7GK2Y",
 		Status:             TransactionStatusPending,
-		Amount:             1.23,
+		Amount:             "1.2300000",
 		TenantID:           uuid.NewString(),
 	})
 
@@ -315,7 +324,7 @@ func Test_TransactionModel_UpdateStatusToError(t *testing.T) {
 				AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 				DestinationAddress: "GBHNIYGWZUAVZX7KTLVSMILBXJMUACVO6XBEKIN6RW7AABDFH6S7GK2Y",
 				Status:             tc.transactionStatus,
-				Amount:             1.23,
+				Amount:             "1.2300000",
 				TenantID:           uuid.NewString(),
 			})
 			assert.Empty(t, tx.StatusMessage)
@@ -409,7 +418,7 @@ func Test_TransactionModel_UpdateStellarTransactionHashAndXDRSent(t *testing.T)
 				ExternalID:  uuid.NewString(),
 				AssetCode:   "USDC",
 				AssetIssuer: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
-				Amount:      1,
+				Amount:      "1.0000000",
 				Destination: "GBHNIYGWZUAVZX7KTLVSMILBXJMUACVO6XBEKIN6RW7AABDFH6S7GK2Y",
 				TenantID:    uuid.NewString(),
 			})
@@ -517,7 +526,7 @@ func Test_TransactionModel_UpdateStellarTransactionXDRReceived(t *testing.T) {
 				ExternalID:  uuid.NewString(),
 				AssetCode:   "USDC",
 				AssetIssuer: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
-				Amount:      1,
+				Amount:      "1.0000000",
 				Destination: "GBHNIYGWZUAVZX7KTLVSMILBXJMUACVO6XBEKIN6RW7AABDFH6S7GK2Y",
 				TenantID:    uuid.NewString(),
 			})
@@ -603,6 +612,26 @@ func Test_Transaction_validate(t *testing.T) {
 				AssetCode:   "USDC",
 				AssetIssuer: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 			},
+			wantErrContains: `amount "" is not a valid Stellar amount`,
+		},
+		{
+			name: "validate Amount (more than 7 decimal places)",
+			transaction: Transaction{
+				ExternalID:  "123",
+				AssetCode:   "USDC",
+				AssetIssuer: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+				Amount:      "0.00000001",
+			},
+			wantErrContains: `amount "0.00000001" is not a valid Stellar amount: more than 7 significant digits`,
+		},
+		{
+			name: "validate Amount (not positive)",
+			transaction: Transaction{
+				ExternalID:  "123",
+				AssetCode:   "USDC",
+				AssetIssuer: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+				Amount:      "0",
+			},
 			wantErrContains: "amount must be positive",
 		},
 		{
@@ -611,18 +640,69 @@ func Test_Transaction_validate(t *testing.T) {
 				ExternalID:  "123",
 				AssetCode:   "USDC",
 				AssetIssuer: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
-				Amount:      100.0,
+				Amount:      "100",
 				Destination: "invalid-destination",
 			},
 			wantErrContains: `destination "invalid-destination" is not a valid ed25519 public key`,
 		},
+		{
+			name: "validate Memo",
+			transaction: Transaction{
+				ExternalID:  "123",
+				AssetCode:   "USDC",
+				AssetIssuer: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+				Amount:      "100",
+				Destination: "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
+				Memo:        "not-a-number",
+				MemoType:    schema.MemoTypeID,
+			},
+			wantErrContains: `validating memo: id memo "not-a-number" is not a valid uint64`,
+		},
+		{
+			name: "validate DestinationAssetIssuer",
+			transaction: Transaction{
+				ExternalID:             "123",
+				AssetCode:              "USDC",
+				AssetIssuer:            "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+				Amount:                 "100",
+				Destination:            "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
+				DestinationAssetCode:   "EURC",
+				DestinationAssetIssuer: "invalid-issuer",
+			},
+			wantErrContains: `destination asset issuer "invalid-issuer" is not a valid ed25519 public key`,
+		},
+		{
+			name: "validate path payments cannot be claimable balances",
+			transaction: Transaction{
+				ExternalID:           "123",
+				AssetCode:            "USDC",
+				AssetIssuer:          "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+				Amount:               "100",
+				Destination:          "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
+				DestinationAssetCode: "XLM",
+				ClaimableBalance:     true,
+			},
+			wantErrContains: "path payments cannot be sent as claimable balances",
+		},
+		{
+			name: "validate MaxSlippageBps",
+			transaction: Transaction{
+				ExternalID:     "123",
+				AssetCode:      "USDC",
+				AssetIssuer:    "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+				Amount:         "100",
+				Destination:    "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
+				MaxSlippageBps: 10001,
+			},
+			wantErrContains: "max slippage must be between 0 and 10000 basis points",
+		},
 		{
 			name: "validate tenant ID",
 			transaction: Transaction{
 				ExternalID:  "123",
 				AssetCode:   "USDC",
 				AssetIssuer: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
-				Amount:      100.0,
+				Amount:      "100",
 				Destination: "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
 				TenantID:    "",
 			},
@@ -634,17 +714,44 @@ func Test_Transaction_validate(t *testing.T) {
 				ExternalID:  "123",
 				AssetCode:   "USDC",
 				AssetIssuer: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
-				Amount:      100.0,
+				Amount:      "100",
 				Destination: "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
 				TenantID:    "tenant-id",
 			},
 		},
+		{
+			name: "🎉 successfully validate USDC transaction with memo",
+			transaction: Transaction{
+				ExternalID:  "123",
+				AssetCode:   "USDC",
+				AssetIssuer: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+				Amount:      "100",
+				Destination: "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
+				Memo:        "123456",
+				MemoType:    schema.MemoTypeID,
+				TenantID:    "tenant-id",
+			},
+		},
+		{
+			name: "🎉 successfully validate USDC to EURC path payment transaction",
+			transaction: Transaction{
+				ExternalID:             "123",
+				AssetCode:              "USDC",
+				AssetIssuer:            "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+				Amount:                 "100",
+				Destination:            "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
+				DestinationAssetCode:   "EURC",
+				DestinationAssetIssuer: "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
+				MaxSlippageBps:         100,
+				TenantID:               "tenant-id",
+			},
+		},
 		{
 			name: "🎉 successfully validate XLM transaction",
 			transaction: Transaction{
 				ExternalID:  "123",
 				AssetCode:   "xLm",
-				Amount:      100.0,
+				Amount:      "100",
 				Destination: "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
 				TenantID:    "tenant-id",
 			},
@@ -738,7 +845,7 @@ func Test_TransactionModel_GetTransactionBatchForUpdate(t *testing.T) {
 					AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 					DestinationAddress: "GBHNIYGWZUAVZX7KTLVSMILBXJMUACVO6XBEKIN6RW7AABDFH6S7GK2Y",
 					Status:             tc.transactionStatus,
-					Amount:             1.2,
+					Amount:             "1.2000000",
 					TenantID:           tenantID,
 				})
 			}
@@ -834,7 +941,7 @@ func Test_TransactionModel_GetTransactionPendingUpdateByID(t *testing.T) {
 					AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 					DestinationAddress: "GBHNIYGWZUAVZX7KTLVSMILBXJMUACVO6XBEKIN6RW7AABDFH6S7GK2Y",
 					Status:             tc.transactionStatus,
-					Amount:             1.2,
+					Amount:             "1.2000000",
 					TenantID:           uuid.NewString(),
 				})
 				defer DeleteAllTransactionFixtures(t, ctx, dbConnectionPool)
@@ -921,7 +1028,7 @@ func Test_TransactionModel_UpdateSyncedTransactions(t *testing.T) {
 					AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 					DestinationAddress: "GBHNIYGWZUAVZX7KTLVSMILBXJMUACVO6XBEKIN6RW7AABDFH6S7GK2Y",
 					Status:             tc.transactionStatus,
-					Amount:             1.2,
+					Amount:             "1.2000000",
 					TenantID:           uuid.NewString(),
 				})
 				for _, tx := range transactions {
@@ -1049,7 +1156,7 @@ func Test_TransactionModel_Lock(t *testing.T) {
 				AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 				DestinationAddress: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
 				Status:             tc.initialStatus,
-				Amount:             1,
+				Amount:             "1.0000000",
 				TenantID:           uuid.NewString(),
 			})
 			q := `UPDATE submitter_transactions SET locked_at = $1, locked_until_ledger_number = $2, synced_at = $3, status = $4 WHERE id = $5`
@@ -1139,7 +1246,7 @@ func Test_TransactionModel_Unlock(t *testing.T) {
 				AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 				DestinationAddress: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
 				Status:             tc.initialStatus,
-				Amount:             1,
+				Amount:             "1.0000000",
 				TenantID:           uuid.NewString(),
 			})
 			q := `UPDATE submitter_transactions SET locked_at = $1, locked_until_ledger_number = $2, synced_at = $3, status = $4 WHERE id = $5`
@@ -1218,7 +1325,7 @@ func Test_TransactionModel_PrepareTransactionForReprocessing(t *testing.T) {
 				AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 				DestinationAddress: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
 				Status:             tc.status,
-				Amount:             1,
+				Amount:             "1.0000000",
 				TenantID:           uuid.NewString(),
 			})
 			q := `UPDATE submitter_transactions SET status = $1, synced_at = $2, locked_at = NOW(), locked_until_ledger_number=$3 WHERE id = $4`
@@ -1253,3 +1360,154 @@ func Test_TransactionModel_PrepareTransactionForReprocessing(t *testing.T) {
 		})
 	}
 }
+
+func Test_TransactionModel_UpdateStellarTransactionHashAndXDRSentForBatch(t *testing.T) {
+	dbt := dbtest.OpenWithTSSMigrationsOnly(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	txModel := NewTransactionModel(dbConnectionPool)
+
+	const txHash = "3389e9f0f1a65f19736cacf544c2e825313e8447f569233bb8db39aa607c8889"
+	// envelope with a single payment operation
+	const envelopeXDR = "AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAACgAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAEAKZ7IPj/46PuWU6ZOtyMosctNAkXRNX9WCAI5RnfRk+AyxDLoDZP/9l3NvsxQtWj9juQOuoBlFLnWu8intgxQA"
+
+	testCases := []struct {
+		name            string
+		numTxs          int
+		txHash          string
+		xdrSent         string
+		wantErrContains string
+	}{
+		{
+			name:            "returns an error if no transaction IDs are provided",
+			txHash:          txHash,
+			xdrSent:         envelopeXDR,
+			wantErrContains: "no transaction IDs provided",
+		},
+		{
+			name:            "returns an error if the size of the txHash if invalid",
+			numTxs:          1,
+			txHash:          "invalid-tx-hash",
+			xdrSent:         envelopeXDR,
+			wantErrContains: `invalid transaction hash "invalid-tx-hash"`,
+		},
+		{
+			name:            "returns an error if XDR is not a valid base64 encoded",
+			numTxs:          1,
+			txHash:          txHash,
+			xdrSent:         "not-base-64-encoded",
+			wantErrContains: "invalid XDR envelope",
+		},
+		{
+			name:            "returns an error if the number of operations doesn't match the number of transactions",
+			numTxs:          2,
+			txHash:          txHash,
+			xdrSent:         envelopeXDR,
+			wantErrContains: "the XDR envelope has 1 operations but 2 transactions were provided",
+		},
+		{
+			name:    "🎉 successfully saves the tx hash, XDR envelope and operation index to the DB",
+			numTxs:  1,
+			txHash:  txHash,
+			xdrSent: envelopeXDR,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer DeleteAllTransactionFixtures(t, ctx, dbConnectionPool)
+
+			transactions := CreateTransactionFixturesNew(t, ctx, dbConnectionPool, tc.numTxs, TransactionFixture{
+				AssetCode:          "USDC",
+				AssetIssuer:        "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
+				DestinationAddress: "GBHNIYGWZUAVZX7KTLVSMILBXJMUACVO6XBEKIN6RW7AABDFH6S7GK2Y",
+				Status:             TransactionStatusPending,
+				Amount:             "1.0000000",
+				TenantID:           uuid.NewString(),
+			})
+			txIDs := make([]string, 0, len(transactions))
+			for _, tx := range transactions {
+				txIDs = append(txIDs, tx.ID)
+			}
+
+			updatedTxs, err := txModel.UpdateStellarTransactionHashAndXDRSentForBatch(ctx, dbConnectionPool, txIDs, tc.txHash, tc.xdrSent)
+			if tc.wantErrContains != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tc.wantErrContains)
+				assert.Nil(t, updatedTxs)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, updatedTxs, len(txIDs))
+			for _, updatedTx := range updatedTxs {
+				assert.Equal(t, txHash, updatedTx.StellarTransactionHash.String)
+				assert.Equal(t, envelopeXDR, updatedTx.XDRSent.String)
+				assert.NotNil(t, updatedTx.SentAt)
+				assert.Equal(t, 1, updatedTx.AttemptsCount)
+				assert.Equal(t, sql.NullInt32{Int32: int32(slices.Index(txIDs, updatedTx.ID)), Valid: true}, updatedTx.OperationIndex)
+			}
+		})
+	}
+}
+
+func Test_TransactionModel_UpdatePathPaymentResult(t *testing.T) {
+	dbt := dbtest.OpenWithTSSMigrationsOnly(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	ctx := context.Background()
+	txModel := NewTransactionModel(dbConnectionPool)
+
+	insertedTxs, err := txModel.BulkInsert(ctx, dbConnectionPool, []Transaction{
+		{
+			ExternalID:  "payment-1",
+			AssetCode:   "USDC",
+			AssetIssuer: "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+			Amount:      "100",
+			Destination: "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
+			TenantID:    "tenant-id",
+		},
+		{
+			ExternalID:           "payment-2",
+			AssetCode:            "USDC",
+			AssetIssuer:          "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+			Amount:               "100",
+			Destination:          "GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG",
+			DestinationAssetCode: "XLM",
+			MaxSlippageBps:       50,
+			TenantID:             "tenant-id",
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, insertedTxs, 2)
+	paymentTx, pathPaymentTx := insertedTxs[0], insertedTxs[1]
+	assert.Equal(t, "XLM", pathPaymentTx.DestinationAssetCode)
+	assert.Equal(t, 50, pathPaymentTx.MaxSlippageBps)
+
+	t.Run("returns an error if the source amount is empty", func(t *testing.T) {
+		updatedTx, err := txModel.UpdatePathPaymentResult(ctx, pathPaymentTx.ID, nil, "")
+		assert.EqualError(t, err, "source amount cannot be empty")
+		assert.Nil(t, updatedTx)
+	})
+
+	t.Run("returns an error if the transaction is not a path payment", func(t *testing.T) {
+		updatedTx, err := txModel.UpdatePathPaymentResult(ctx, paymentTx.ID, nil, "98.5")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+		assert.Nil(t, updatedTx)
+	})
+
+	t.Run("🎉 saves the path and the source amount", func(t *testing.T) {
+		path := []string{"EURC:GDUCE34WW5Z34GMCEPURYANUCUP47J6NORJLKC6GJNMDLN4ZI4PMI2MG"}
+		updatedTx, err := txModel.UpdatePathPaymentResult(ctx, pathPaymentTx.ID, path, "98.5")
+		require.NoError(t, err)
+		assert.Equal(t, pq.StringArray(path), updatedTx.PathPaymentPath)
+		assert.Equal(t, "98.5000000", updatedTx.SourceAmount.String)
+	})
+}
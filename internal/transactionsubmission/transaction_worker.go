@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"slices"
-	"strconv"
 	"strings"
 	"time"
 
@@ -30,9 +29,6 @@ import (
 	"github.com/stellar/stellar-disbursement-platform-backend/pkg/schema"
 )
 
-// Review these TODOs originally created by Stephen:
-// TODO - memo/memoType not supported yet - [SDP-463]
-
 type TxJob store.ChannelTransactionBundle
 
 func (job TxJob) String() string {
@@ -279,6 +275,18 @@ func (tw *TransactionWorker) handleSuccessfulTransaction(ctx context.Context, tx
 		return fmt.Errorf("transaction was not successful for some reason")
 	}
 
+	updatedTx, err := tw.saveClaimableBalanceIDIfNeeded(ctx, txJob.Transaction, hTxResp.ResultXdr, 0)
+	if err != nil {
+		return fmt.Errorf("saving claimable balance ID: %w", err)
+	}
+	txJob.Transaction = *updatedTx
+
+	updatedTx, err = tw.savePathPaymentResultIfNeeded(ctx, txJob.Transaction, hTxResp.ResultXdr, 0)
+	if err != nil {
+		return fmt.Errorf("saving path payment result: %w", err)
+	}
+	txJob.Transaction = *updatedTx
+
 	// Building the payment completed event before updating the transaction status. This way, if the message fails to be
 	// built, the transaction will be marked for reprocessing -> reconciliation and the event will be re-tried.
 	msg, err := tw.buildPaymentCompletedEvent(events.PaymentCompletedSuccessType, &txJob.Transaction, data.SuccessPaymentStatus, "")
@@ -286,7 +294,7 @@ func (tw *TransactionWorker) handleSuccessfulTransaction(ctx context.Context, tx
 		return fmt.Errorf("building payment completed event Status %s - Job %v: %w", txJob.Transaction.Status, txJob, err)
 	}
 
-	updatedTx, err := tw.txModel.UpdateStatusToSuccess(ctx, txJob.Transaction)
+	updatedTx, err = tw.txModel.UpdateStatusToSuccess(ctx, txJob.Transaction)
 	if err != nil {
 		return utils.NewTransactionStatusUpdateError("SUCCESS", txJob.Transaction.ID, false, err)
 	}
@@ -308,6 +316,30 @@ func (tw *TransactionWorker) handleSuccessfulTransaction(ctx context.Context, tx
 	return nil
 }
 
+// saveClaimableBalanceIDIfNeeded saves the ID of the claimable balance created by the transaction, which is found in the
+// result of the operation at the given index. Transactions that don't use the claimable balance payout mode are
+// returned unchanged.
+func (tw *TransactionWorker) saveClaimableBalanceIDIfNeeded(ctx context.Context, tx store.Transaction, resultXDR string, opIndex int) (*store.Transaction, error) {
+	if !tx.ClaimableBalance || tx.ClaimableBalanceID.Valid {
+		return &tx, nil
+	}
+
+	if tx.OperationIndex.Valid {
+		opIndex = int(tx.OperationIndex.Int32)
+	}
+	balanceID, err := utils.ClaimableBalanceID(resultXDR, opIndex)
+	if err != nil {
+		return nil, fmt.Errorf("getting claimable balance ID for transaction %s: %w", tx.ID, err)
+	}
+
+	updatedTx, err := tw.txModel.UpdateClaimableBalanceID(ctx, tx.ID, balanceID)
+	if err != nil {
+		return nil, fmt.Errorf("updating claimable balance ID for transaction %s: %w", tx.ID, err)
+	}
+
+	return updatedTx, nil
+}
+
 // reconcileSubmittedTransaction will check the status of a previously submitted transaction and handle it accordingly.
 // If the transaction was successful, it will be marked as such and the job will be unlocked.
 // If the transaction failed, it will be marked for resubmission.
@@ -493,28 +525,107 @@ func (tw *TransactionWorker) prepareForSubmission(ctx context.Context, txJob *Tx
 // buildAndSignTransaction builds & signs a Stellar payment transaction that is wrapped in a feebump transaction.
 func (tw *TransactionWorker) buildAndSignTransaction(ctx context.Context, txJob *TxJob) (feeBumpTx *txnbuild.FeeBumpTransaction, err error) {
 	// validate the transaction asset
-	if txJob.Transaction.AssetCode == "" {
+	_, err = stellarAsset(&txJob.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	distributionAccount, err := tw.resolveStellarDistributionAccount(ctx, txJob.Transaction.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	memo, err := txJob.Transaction.StellarMemo().ToTxnbuildMemo()
+	if err != nil {
+		return nil, fmt.Errorf("building memo for job %v: %w", txJob, err)
+	}
+
+	operation, err := tw.buildTransactionOperation(&txJob.Transaction, distributionAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("building operation for job %v: %w", txJob, err)
+	}
+	operations := []txnbuild.Operation{operation}
+
+	feeBumpTx, err = tw.buildAndSignFeeBumpTransaction(ctx, txJob.ChannelAccount.PublicKey, txJob.LockedUntilLedgerNumber, distributionAccount, memo, operations)
+	if err != nil {
+		return nil, fmt.Errorf("building transaction for job %v: %w", txJob, err)
+	}
+
+	return feeBumpTx, nil
+}
+
+// claimableBalanceReclaimWindowSeconds is the time window during which only the receiver can claim a claimable balance.
+// After that, the distribution account is also allowed to claim it back.
+const claimableBalanceReclaimWindowSeconds = 30 * 24 * 60 * 60
+
+// buildOperation builds the operation that delivers the transaction funds to its destination, which is either a
+// payment or, for transactions in the claimable balance payout mode, a claimable balance creation.
+func buildOperation(tx *store.Transaction, distributionAccountAddress string) (txnbuild.Operation, error) {
+	asset, err := stellarAsset(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.ClaimableBalance {
+		return &txnbuild.CreateClaimableBalance{
+			SourceAccount: distributionAccountAddress,
+			Amount:        tx.Amount,
+			Asset:         asset,
+			Destinations: []txnbuild.Claimant{
+				txnbuild.NewClaimant(tx.Destination, &txnbuild.UnconditionalPredicate),
+				txnbuild.NewClaimant(distributionAccountAddress, tssUtils.Ptr(txnbuild.NotPredicate(txnbuild.BeforeRelativeTimePredicate(claimableBalanceReclaimWindowSeconds)))),
+			},
+		}, nil
+	}
+
+	return &txnbuild.Payment{
+		SourceAccount: distributionAccountAddress,
+		Amount:        tx.Amount,
+		Destination:   tx.Destination,
+		Asset:         asset,
+	}, nil
+}
+
+// stellarAsset validates the asset of the transaction and converts it into a txnbuild.Asset.
+func stellarAsset(tx *store.Transaction) (txnbuild.Asset, error) {
+	if tx.AssetCode == "" {
 		return nil, fmt.Errorf("asset code cannot be empty")
 	}
-	var asset txnbuild.Asset = txnbuild.NativeAsset{}
-	if strings.ToUpper(txJob.Transaction.AssetCode) != "XLM" {
-		if !strkey.IsValidEd25519PublicKey(txJob.Transaction.AssetIssuer) {
-			return nil, fmt.Errorf("invalid asset issuer: %v", txJob.Transaction.AssetIssuer)
-		}
-		asset = txnbuild.CreditAsset{
-			Code:   txJob.Transaction.AssetCode,
-			Issuer: txJob.Transaction.AssetIssuer,
-		}
+	if strings.ToUpper(tx.AssetCode) == "XLM" {
+		return txnbuild.NativeAsset{}, nil
 	}
+	if !strkey.IsValidEd25519PublicKey(tx.AssetIssuer) {
+		return nil, fmt.Errorf("invalid asset issuer: %v", tx.AssetIssuer)
+	}
+	return txnbuild.CreditAsset{
+		Code:   tx.AssetCode,
+		Issuer: tx.AssetIssuer,
+	}, nil
+}
 
-	distributionAccount, err := tw.engine.DistributionAccountResolver.DistributionAccount(ctx, txJob.Transaction.TenantID)
+// resolveStellarDistributionAccount resolves the distribution account of the tenant, making sure it's a Stellar account.
+func (tw *TransactionWorker) resolveStellarDistributionAccount(ctx context.Context, tenantID string) (schema.TransactionAccount, error) {
+	distributionAccount, err := tw.engine.DistributionAccountResolver.DistributionAccount(ctx, tenantID)
 	if err != nil {
-		return nil, fmt.Errorf("resolving distribution account for tenantID=%s: %w", txJob.Transaction.TenantID, err)
+		return schema.TransactionAccount{}, fmt.Errorf("resolving distribution account for tenantID=%s: %w", tenantID, err)
 	} else if !distributionAccount.IsStellar() {
-		return nil, fmt.Errorf("expected distribution account to be a STELLAR account but got %q", distributionAccount.Type)
+		return schema.TransactionAccount{}, fmt.Errorf("expected distribution account to be a STELLAR account but got %q", distributionAccount.Type)
 	}
 
-	horizonAccount, err := tw.engine.HorizonClient.AccountDetail(horizonclient.AccountRequest{AccountID: txJob.ChannelAccount.PublicKey})
+	return distributionAccount, nil
+}
+
+// buildAndSignFeeBumpTransaction builds an inner transaction with the provided operations, using the channel account as
+// the source account, signs it, and wraps it in a fee-bump transaction paid and signed by the distribution account.
+func (tw *TransactionWorker) buildAndSignFeeBumpTransaction(
+	ctx context.Context,
+	chAccPublicKey string,
+	lockedUntilLedgerNumber int,
+	distributionAccount schema.TransactionAccount,
+	memo txnbuild.Memo,
+	operations []txnbuild.Operation,
+) (*txnbuild.FeeBumpTransaction, error) {
+	horizonAccount, err := tw.engine.HorizonClient.AccountDetail(horizonclient.AccountRequest{AccountID: chAccPublicKey})
 	if err != nil {
 		err = fmt.Errorf("getting account detail: %w", err)
 		return nil, utils.NewHorizonErrorWrapper(err)
@@ -524,41 +635,35 @@ func (tw *TransactionWorker) buildAndSignTransaction(ctx context.Context, txJob
 	paymentTx, err := txnbuild.NewTransaction(
 		txnbuild.TransactionParams{
 			SourceAccount: &txnbuild.SimpleAccount{
-				AccountID: txJob.ChannelAccount.PublicKey,
+				AccountID: chAccPublicKey,
 				Sequence:  horizonAccount.Sequence,
 			},
-			Operations: []txnbuild.Operation{
-				&txnbuild.Payment{
-					SourceAccount: distributionAccount.Address,
-					Amount:        strconv.FormatFloat(txJob.Transaction.Amount, 'f', 6, 32), // TODO find a better way to do this
-					Destination:   txJob.Transaction.Destination,
-					Asset:         asset,
-				},
-			},
-			BaseFee: int64(tw.engine.MaxBaseFee),
+			Operations: operations,
+			Memo:       memo,
+			BaseFee:    int64(tw.engine.MaxBaseFee),
 			Preconditions: txnbuild.Preconditions{
-				TimeBounds:   txnbuild.NewTimeout(300),                                                 // maximum 5 minutes
-				LedgerBounds: &txnbuild.LedgerBounds{MaxLedger: uint32(txJob.LockedUntilLedgerNumber)}, // currently, 8-10 ledgers in the future
+				TimeBounds:   txnbuild.NewTimeout(300),                                           // maximum 5 minutes
+				LedgerBounds: &txnbuild.LedgerBounds{MaxLedger: uint32(lockedUntilLedgerNumber)}, // currently, 8-10 ledgers in the future
 			},
 			IncrementSequenceNum: true,
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("building transaction for job %v: %w", txJob, err)
+		return nil, fmt.Errorf("building transaction: %w", err)
 	}
 
 	// Sign tx for the channel account:
 	chAccount := schema.TransactionAccount{
-		Address: txJob.ChannelAccount.PublicKey,
+		Address: chAccPublicKey,
 		Type:    schema.ChannelAccountStellarDB,
 	}
 	paymentTx, err = tw.engine.SignerRouter.SignStellarTransaction(ctx, paymentTx, chAccount, distributionAccount)
 	if err != nil {
-		return nil, fmt.Errorf("signing transaction in job=%v: %w", txJob, err)
+		return nil, fmt.Errorf("signing transaction: %w", err)
 	}
 
 	// build the outer fee-bump transaction
-	feeBumpTx, err = txnbuild.NewFeeBumpTransaction(
+	feeBumpTx, err := txnbuild.NewFeeBumpTransaction(
 		txnbuild.FeeBumpTransactionParams{
 			Inner:      paymentTx,
 			FeeAccount: distributionAccount.Address,
@@ -566,13 +671,13 @@ func (tw *TransactionWorker) buildAndSignTransaction(ctx context.Context, txJob
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("building fee-bump transaction for job %v: %w", txJob, err)
+		return nil, fmt.Errorf("building fee-bump transaction: %w", err)
 	}
 
 	// Sign fee-bump tx for the distribution account:
 	feeBumpTx, err = tw.engine.SignerRouter.SignFeeBumpStellarTransaction(ctx, feeBumpTx, distributionAccount)
 	if err != nil {
-		return nil, fmt.Errorf("signing fee-bump transaction for job %v: %w", txJob, err)
+		return nil, fmt.Errorf("signing fee-bump transaction: %w", err)
 	}
 
 	return feeBumpTx, nil
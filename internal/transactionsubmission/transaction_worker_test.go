@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -20,6 +19,7 @@ import (
 	"github.com/stellar/go/support/log"
 	"github.com/stellar/go/support/render/problem"
 	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -122,7 +122,7 @@ func createTxJobFixture(t *testing.T, ctx context.Context, dbConnectionPool db.D
 		AssetIssuer:        "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
 		DestinationAddress: "GCBIRB7Q5T53H4L6P5QSI3O6LPD5MBWGM5GHE7A5NY4XT5OT4VCOEZFX",
 		Status:             store.TransactionStatusProcessing,
-		Amount:             1,
+		Amount:             "1.0000000",
 		TenantID:           tenantID,
 	})
 	chAcc := store.CreateChannelAccountFixturesEncrypted(t, ctx, dbConnectionPool, encrypter, chAccEncryptionPassphrase, 1)[0]
@@ -1667,6 +1667,8 @@ func Test_TransactionWorker_buildAndSignTransaction(t *testing.T) {
 		name                    string
 		assetCode               string
 		assetIssuer             string
+		memo                    string
+		memoType                schema.MemoType
 		getAccountResponseObj   horizon.Account
 		getAccountResponseError *horizonclient.Error
 		wantErrorContains       string
@@ -1681,6 +1683,13 @@ func Test_TransactionWorker_buildAndSignTransaction(t *testing.T) {
 			assetIssuer:       "FOOBAR",
 			wantErrorContains: "invalid asset issuer: FOOBAR",
 		},
+		{
+			name:              "returns an error if the memo is not valid",
+			assetCode:         "XLM",
+			memo:              "not-a-number",
+			memoType:          schema.MemoTypeID,
+			wantErrorContains: `building memo for job`,
+		},
 		{
 			name:                    "return an error if the AccountDetail call fails",
 			assetCode:               "USDC",
@@ -1701,6 +1710,21 @@ func Test_TransactionWorker_buildAndSignTransaction(t *testing.T) {
 			assetIssuer:           "",
 			getAccountResponseObj: horizon.Account{Sequence: accountSequence},
 		},
+		{
+			name:                  "🎉 successfully build and sign a transaction with a text memo",
+			assetCode:             "XLM",
+			memo:                  "sub-account 42",
+			memoType:              schema.MemoTypeText,
+			getAccountResponseObj: horizon.Account{Sequence: accountSequence},
+		},
+		{
+			name:                  "🎉 successfully build and sign a transaction with an id memo",
+			assetCode:             "USDC",
+			assetIssuer:           "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5",
+			memo:                  "1234567890",
+			memoType:              schema.MemoTypeID,
+			getAccountResponseObj: horizon.Account{Sequence: accountSequence},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1713,6 +1737,8 @@ func Test_TransactionWorker_buildAndSignTransaction(t *testing.T) {
 			txJob := createTxJobFixture(t, ctx, dbConnectionPool, true, currentLedger, lockedToLedger, tnt.ID)
 			txJob.Transaction.AssetCode = tc.assetCode
 			txJob.Transaction.AssetIssuer = tc.assetIssuer
+			txJob.Transaction.Memo = tc.memo
+			txJob.Transaction.MemoType = tc.memoType
 
 			// mock horizon
 			mockHorizon := &horizonclient.MockClient{}
@@ -1758,6 +1784,8 @@ func Test_TransactionWorker_buildAndSignTransaction(t *testing.T) {
 						Issuer: txJob.Transaction.AssetIssuer,
 					}
 				}
+				wantMemo, err := txJob.Transaction.StellarMemo().ToTxnbuildMemo()
+				require.NoError(t, err)
 				wantInnerTx, err := txnbuild.NewTransaction(
 					txnbuild.TransactionParams{
 						SourceAccount: &txnbuild.SimpleAccount{
@@ -1767,11 +1795,12 @@ func Test_TransactionWorker_buildAndSignTransaction(t *testing.T) {
 						Operations: []txnbuild.Operation{
 							&txnbuild.Payment{
 								SourceAccount: distributionKP.Address(),
-								Amount:        strconv.FormatFloat(txJob.Transaction.Amount, 'f', 6, 32), // TODO find a better way to do this
+								Amount:        txJob.Transaction.Amount,
 								Destination:   txJob.Transaction.Destination,
 								Asset:         wantAsset,
 							},
 						},
+						Memo:    wantMemo,
 						BaseFee: int64(transactionWorker.engine.MaxBaseFee),
 						Preconditions: txnbuild.Preconditions{
 							TimeBounds:   txnbuild.NewTimeout(300),
@@ -2032,3 +2061,46 @@ func Test_TransactionWorker_buildPaymentCompletedEvent(t *testing.T) {
 		assert.Equal(t, wantMsg, msg)
 	})
 }
+
+func Test_buildOperation(t *testing.T) {
+	distributionAddress := keypair.MustRandom().Address()
+	destination := keypair.MustRandom().Address()
+	issuer := keypair.MustRandom().Address()
+
+	t.Run("returns an error if the asset is invalid", func(t *testing.T) {
+		op, err := buildOperation(&store.Transaction{AssetCode: "USDC", AssetIssuer: "invalid"}, distributionAddress)
+		assert.EqualError(t, err, "invalid asset issuer: invalid")
+		assert.Nil(t, op)
+	})
+
+	t.Run("🎉 builds a payment operation", func(t *testing.T) {
+		tx := &store.Transaction{AssetCode: "USDC", AssetIssuer: issuer, Amount: "100", Destination: destination}
+		op, err := buildOperation(tx, distributionAddress)
+		require.NoError(t, err)
+
+		wantOp := &txnbuild.Payment{
+			SourceAccount: distributionAddress,
+			Amount:        "100",
+			Destination:   destination,
+			Asset:         txnbuild.CreditAsset{Code: "USDC", Issuer: issuer},
+		}
+		assert.Equal(t, wantOp, op)
+	})
+
+	t.Run("🎉 builds a create claimable balance operation", func(t *testing.T) {
+		tx := &store.Transaction{AssetCode: "XLM", Amount: "100", Destination: destination, ClaimableBalance: true}
+		op, err := buildOperation(tx, distributionAddress)
+		require.NoError(t, err)
+
+		cbOp, ok := op.(*txnbuild.CreateClaimableBalance)
+		require.True(t, ok)
+		assert.Equal(t, distributionAddress, cbOp.SourceAccount)
+		assert.Equal(t, "100", cbOp.Amount)
+		assert.Equal(t, txnbuild.NativeAsset{}, cbOp.Asset)
+		require.Len(t, cbOp.Destinations, 2)
+		assert.Equal(t, destination, cbOp.Destinations[0].Destination)
+		assert.Equal(t, xdr.ClaimPredicateTypeClaimPredicateUnconditional, cbOp.Destinations[0].Predicate.Type)
+		assert.Equal(t, distributionAddress, cbOp.Destinations[1].Destination)
+		assert.Equal(t, xdr.ClaimPredicateTypeClaimPredicateNot, cbOp.Destinations[1].Predicate.Type)
+	})
+}
@@ -295,6 +295,31 @@ func (e *HorizonErrorWrapper) IsDestinationAccountNotReady() bool {
 		e.IsLineFull())
 }
 
+// definitiveTxErrCodes are the transaction error codes that won't be resolved with retries.
+var definitiveTxErrCodes = []string{
+	"tx_bad_auth",
+	"tx_bad_auth_extra",
+	"tx_insufficient_balance",
+}
+
+// definitiveOpErrCodes are the operation error codes that won't be resolved with retries.
+var definitiveOpErrCodes = []string{
+	"op_bad_auth",
+	"op_underfunded",
+	"op_src_not_authorized",
+	"op_no_destination",
+	"op_no_trust",
+	"op_line_full",
+	"op_not_authorized",
+	"op_no_issuer",
+}
+
+// IsDefinitiveOperationErrorCode returns true if the operation error code is a failure that won't be resolved with
+// retries.
+func IsDefinitiveOperationErrorCode(opCode string) bool {
+	return slices.Contains(definitiveOpErrCodes, opCode)
+}
+
 // ShouldMarkAsError determines whether a transaction neeeds to be marked as an error based on the
 // transaction error code or failed op code so that TSS can determine whether it needs
 // to be retried.
@@ -303,27 +328,12 @@ func (e *HorizonErrorWrapper) ShouldMarkAsError() bool {
 		return false
 	}
 
-	failedTxErrCodes := []string{
-		"tx_bad_auth",
-		"tx_bad_auth_extra",
-		"tx_insufficient_balance",
-	}
-	if slices.Contains(failedTxErrCodes, e.ResultCodes.TransactionCode) || slices.Contains(failedTxErrCodes, e.ResultCodes.InnerTransactionCode) {
+	if e.HasDefinitiveTransactionError() {
 		return true
 	}
 
-	failedOpCodes := []string{
-		"op_bad_auth",
-		"op_underfunded",
-		"op_src_not_authorized",
-		"op_no_destination",
-		"op_no_trust",
-		"op_line_full",
-		"op_not_authorized",
-		"op_no_issuer",
-	}
 	for _, opResult := range e.ResultCodes.OperationCodes {
-		if slices.Contains(failedOpCodes, opResult) {
+		if IsDefinitiveOperationErrorCode(opResult) {
 			return true
 		}
 	}
@@ -331,6 +341,16 @@ func (e *HorizonErrorWrapper) ShouldMarkAsError() bool {
 	return false
 }
 
+// HasDefinitiveTransactionError determines whether the transaction error code, regardless of the operation codes,
+// represents a failure that won't be resolved with retries.
+func (e *HorizonErrorWrapper) HasDefinitiveTransactionError() bool {
+	if e.ResultCodes == nil {
+		return false
+	}
+
+	return slices.Contains(definitiveTxErrCodes, e.ResultCodes.TransactionCode) || slices.Contains(definitiveTxErrCodes, e.ResultCodes.InnerTransactionCode)
+}
+
 func (e *HorizonErrorWrapper) handleExtrasResultCodes(msgBuilder *strings.Builder) {
 	if !e.HasResultCodes() {
 		return
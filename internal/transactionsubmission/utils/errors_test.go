@@ -1661,3 +1661,65 @@ func Test_HorizonErrorWrapper_ShouldMarkAsError(t *testing.T) {
 		})
 	}
 }
+
+func Test_HorizonErrorWrapper_HasDefinitiveTransactionError(t *testing.T) {
+	testCases := []struct {
+		name       string
+		hErr       error
+		wantResult bool
+	}{
+		{
+			name: "returns true if tx code in failed tx codes",
+			hErr: horizonclient.Error{
+				Problem: problem.P{
+					Status: http.StatusBadRequest,
+					Extras: map[string]interface{}{
+						"result_codes": map[string]interface{}{
+							"transaction": "tx_bad_auth",
+						},
+					},
+				},
+			},
+			wantResult: true,
+		},
+		{
+			name: "returns false if only the op code is in failed op codes",
+			hErr: horizonclient.Error{
+				Problem: problem.P{
+					Status: http.StatusBadRequest,
+					Extras: map[string]interface{}{
+						"result_codes": map[string]interface{}{
+							"transaction": "tx_fee_bump_inner_failed",
+							"operations":  []string{"op_no_destination"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "returns false if there are no result codes",
+			hErr: horizonclient.Error{
+				Problem: problem.P{
+					Status: http.StatusGatewayTimeout,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			wrapper := NewHorizonErrorWrapper(tc.hErr)
+			assert.Equal(t, tc.wantResult, wrapper.HasDefinitiveTransactionError())
+		})
+	}
+}
+
+func Test_IsDefinitiveOperationErrorCode(t *testing.T) {
+	for _, opCode := range []string{"op_no_destination", "op_no_trust", "op_not_authorized", "op_line_full", "op_no_issuer"} {
+		assert.True(t, IsDefinitiveOperationErrorCode(opCode), opCode)
+	}
+
+	for _, opCode := range []string{"", "op_success", "op_src_no_trust", "op_unknown"} {
+		assert.False(t, IsDefinitiveOperationErrorCode(opCode), opCode)
+	}
+}
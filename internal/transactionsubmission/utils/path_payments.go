@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/xdr"
+)
+
+// PathPaymentSourceAmount decodes a base64-encoded TransactionResult XDR and returns the amount spent, denominated in
+// the source asset, by the path payment at the given operation index.
+func PathPaymentSourceAmount(resultXDR string, opIndex int) (string, error) {
+	var txResult xdr.TransactionResult
+	err := xdr.SafeUnmarshalBase64(resultXDR, &txResult)
+	if err != nil {
+		return "", fmt.Errorf("unmarshalling transaction result XDR: %w", err)
+	}
+
+	opResults, ok := txResult.OperationResults()
+	if !ok || opIndex < 0 || opIndex >= len(opResults) {
+		return "", fmt.Errorf("operation result %d not found in the transaction result", opIndex)
+	}
+
+	tr, ok := opResults[opIndex].GetTr()
+	if !ok {
+		return "", fmt.Errorf("operation %d has no inner result", opIndex)
+	}
+	ppResult, ok := tr.GetPathPaymentStrictReceiveResult()
+	if !ok || ppResult.Code != xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveSuccess {
+		return "", fmt.Errorf("operation %d is not a successful path payment", opIndex)
+	}
+
+	return amount.String(ppResult.SendAmount()), nil
+}
+
+// PathPaymentPath decodes a base64-encoded TransactionEnvelope XDR and returns the intermediary assets of the path
+// payment at the given operation index, in their canonical form. Fee-bump envelopes are unwrapped to their inner
+// transaction.
+func PathPaymentPath(envelopeXDR string, opIndex int) ([]string, error) {
+	var envelope xdr.TransactionEnvelope
+	err := xdr.SafeUnmarshalBase64(envelopeXDR, &envelope)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling transaction envelope XDR: %w", err)
+	}
+
+	ops := envelope.Operations()
+	if opIndex < 0 || opIndex >= len(ops) {
+		return nil, fmt.Errorf("operation %d not found in the transaction envelope", opIndex)
+	}
+	ppOp, ok := ops[opIndex].Body.GetPathPaymentStrictReceiveOp()
+	if !ok {
+		return nil, fmt.Errorf("operation %d is not a path payment", opIndex)
+	}
+
+	path := make([]string, 0, len(ppOp.Path))
+	for _, asset := range ppOp.Path {
+		path = append(path, asset.StringCanonical())
+	}
+
+	return path, nil
+}
+
+// CheapestPath returns the path that spends the smallest source amount.
+func CheapestPath(paths []horizon.Path) (*horizon.Path, error) {
+	var bestPath *horizon.Path
+	var bestAmount int64
+	for i := range paths {
+		sourceAmount, err := amount.ParseInt64(paths[i].SourceAmount)
+		if err != nil {
+			return nil, fmt.Errorf("parsing source amount %q: %w", paths[i].SourceAmount, err)
+		}
+		if bestPath == nil || sourceAmount < bestAmount {
+			bestPath = &paths[i]
+			bestAmount = sourceAmount
+		}
+	}
+
+	if bestPath == nil {
+		return nil, fmt.Errorf("no path found")
+	}
+	return bestPath, nil
+}
+
+// ApplySlippage increases the amount by the given basis points, rounding up to the next stroop.
+func ApplySlippage(sourceAmount string, slippageBps int) (string, error) {
+	stroops, err := amount.ParseInt64(sourceAmount)
+	if err != nil {
+		return "", fmt.Errorf("parsing amount %q: %w", sourceAmount, err)
+	}
+
+	total := new(big.Int).Mul(big.NewInt(stroops), big.NewInt(int64(10000+slippageBps)))
+	total.Add(total, big.NewInt(9999))
+	total.Quo(total, big.NewInt(10000))
+	if !total.IsInt64() {
+		return "", fmt.Errorf("amount %s with %d bps of slippage overflows", sourceAmount, slippageBps)
+	}
+
+	return amount.StringFromInt64(total.Int64()), nil
+}
+
+// HorizonAssetType returns the Horizon asset type of the asset with the given code.
+func HorizonAssetType(code string) horizonclient.AssetType {
+	switch {
+	case strings.ToUpper(code) == "XLM":
+		return horizonclient.AssetTypeNative
+	case len(code) <= 4:
+		return horizonclient.AssetType4
+	default:
+		return horizonclient.AssetType12
+	}
+}
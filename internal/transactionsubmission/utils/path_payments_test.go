@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PathPaymentSourceAmount(t *testing.T) {
+	opResults := []xdr.OperationResult{
+		paymentOpResult(xdr.PaymentResultCodePaymentSuccess),
+		{
+			Code: xdr.OperationResultCodeOpInner,
+			Tr: &xdr.OperationResultTr{
+				Type: xdr.OperationTypePathPaymentStrictReceive,
+				PathPaymentStrictReceiveResult: &xdr.PathPaymentStrictReceiveResult{
+					Code: xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveSuccess,
+					Success: &xdr.PathPaymentStrictReceiveResultSuccess{
+						Offers: []xdr.ClaimAtom{
+							{
+								Type: xdr.ClaimAtomTypeClaimAtomTypeOrderBook,
+								OrderBook: &xdr.ClaimOfferAtom{
+									SellerId:     xdr.MustAddress(keypair.MustRandom().Address()),
+									AssetBought:  xdr.MustNewNativeAsset(),
+									AmountBought: 985000000,
+									AssetSold:    xdr.MustNewCreditAsset("EURC", keypair.MustRandom().Address()),
+									AmountSold:   1000000000,
+								},
+							},
+						},
+						Last: xdr.SimplePaymentResult{
+							Destination: xdr.MustAddress(keypair.MustRandom().Address()),
+							Asset:       xdr.MustNewCreditAsset("EURC", keypair.MustRandom().Address()),
+							Amount:      1000000000,
+						},
+					},
+				},
+			},
+		},
+	}
+	txResult := xdr.TransactionResult{
+		Result: xdr.TransactionResultResult{
+			Code:    xdr.TransactionResultCodeTxSuccess,
+			Results: &opResults,
+		},
+	}
+	resultXDR, err := xdr.MarshalBase64(txResult)
+	require.NoError(t, err)
+
+	t.Run("returns an error if the XDR is invalid", func(t *testing.T) {
+		_, err := PathPaymentSourceAmount("invalid", 0)
+		assert.ErrorContains(t, err, "unmarshalling transaction result XDR")
+	})
+
+	t.Run("returns an error if the operation index is out of range", func(t *testing.T) {
+		_, err := PathPaymentSourceAmount(resultXDR, 2)
+		assert.EqualError(t, err, "operation result 2 not found in the transaction result")
+	})
+
+	t.Run("returns an error if the operation is not a path payment", func(t *testing.T) {
+		_, err := PathPaymentSourceAmount(resultXDR, 0)
+		assert.EqualError(t, err, "operation 0 is not a successful path payment")
+	})
+
+	t.Run("🎉 returns the amount spent in the source asset", func(t *testing.T) {
+		sourceAmount, err := PathPaymentSourceAmount(resultXDR, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "98.5000000", sourceAmount)
+	})
+}
+
+func Test_PathPaymentPath(t *testing.T) {
+	distributionKP := keypair.MustRandom()
+	issuer := keypair.MustRandom().Address()
+	eurc := txnbuild.CreditAsset{Code: "EURC", Issuer: issuer}
+
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: distributionKP.Address(), Sequence: 1},
+		Operations: []txnbuild.Operation{
+			&txnbuild.Payment{Destination: keypair.MustRandom().Address(), Amount: "1", Asset: txnbuild.NativeAsset{}},
+			&txnbuild.PathPaymentStrictReceive{
+				SendAsset:   txnbuild.CreditAsset{Code: "USDC", Issuer: issuer},
+				SendMax:     "100",
+				Destination: keypair.MustRandom().Address(),
+				DestAsset:   txnbuild.CreditAsset{Code: "BRL", Issuer: issuer},
+				DestAmount:  "500",
+				Path:        []txnbuild.Asset{txnbuild.NativeAsset{}, eurc},
+			},
+		},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, distributionKP)
+	require.NoError(t, err)
+	feeBumpTx, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      tx,
+		FeeAccount: distributionKP.Address(),
+		BaseFee:    txnbuild.MinBaseFee * 2,
+	})
+	require.NoError(t, err)
+	envelopeXDR, err := feeBumpTx.Base64()
+	require.NoError(t, err)
+
+	t.Run("returns an error if the XDR is invalid", func(t *testing.T) {
+		_, err := PathPaymentPath("invalid", 0)
+		assert.ErrorContains(t, err, "unmarshalling transaction envelope XDR")
+	})
+
+	t.Run("returns an error if the operation index is out of range", func(t *testing.T) {
+		_, err := PathPaymentPath(envelopeXDR, 2)
+		assert.EqualError(t, err, "operation 2 not found in the transaction envelope")
+	})
+
+	t.Run("returns an error if the operation is not a path payment", func(t *testing.T) {
+		_, err := PathPaymentPath(envelopeXDR, 0)
+		assert.EqualError(t, err, "operation 0 is not a path payment")
+	})
+
+	t.Run("🎉 returns the path of a fee-bump transaction", func(t *testing.T) {
+		path, err := PathPaymentPath(envelopeXDR, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"native", "EURC:" + issuer}, path)
+	})
+}
+
+func Test_ApplySlippage(t *testing.T) {
+	testCases := []struct {
+		name            string
+		sourceAmount    string
+		slippageBps     int
+		wantAmount      string
+		wantErrContains string
+	}{
+		{
+			name:            "invalid amount",
+			sourceAmount:    "invalid",
+			wantErrContains: `parsing amount "invalid"`,
+		},
+		{
+			name:         "no slippage",
+			sourceAmount: "100",
+			slippageBps:  0,
+			wantAmount:   "100.0000000",
+		},
+		{
+			name:         "1% slippage",
+			sourceAmount: "100",
+			slippageBps:  100,
+			wantAmount:   "101.0000000",
+		},
+		{
+			name:         "rounds up to the next stroop",
+			sourceAmount: "0.0000001",
+			slippageBps:  1,
+			wantAmount:   "0.0000002",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotAmount, err := ApplySlippage(tc.sourceAmount, tc.slippageBps)
+			if tc.wantErrContains != "" {
+				assert.ErrorContains(t, err, tc.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantAmount, gotAmount)
+			}
+		})
+	}
+}
+
+func Test_CheapestPath(t *testing.T) {
+	t.Run("returns an error if there are no paths", func(t *testing.T) {
+		path, err := CheapestPath(nil)
+		assert.EqualError(t, err, "no path found")
+		assert.Nil(t, path)
+	})
+
+	t.Run("🎉 returns the path with the smallest source amount", func(t *testing.T) {
+		paths := []horizon.Path{
+			{SourceAmount: "101"},
+			{SourceAmount: "99.5"},
+			{SourceAmount: "100"},
+		}
+		path, err := CheapestPath(paths)
+		require.NoError(t, err)
+		assert.Equal(t, "99.5", path.SourceAmount)
+	})
+}
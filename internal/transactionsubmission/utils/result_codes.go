@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	OpSuccessCode = "op_success"
+	opUnknownCode = "op_unknown"
+)
+
+var operationResultCodes = map[xdr.OperationResultCode]string{
+	xdr.OperationResultCodeOpBadAuth:           "op_bad_auth",
+	xdr.OperationResultCodeOpNoAccount:         "op_no_source_account",
+	xdr.OperationResultCodeOpNotSupported:      "op_not_supported",
+	xdr.OperationResultCodeOpTooManySubentries: "op_too_many_subentries",
+	xdr.OperationResultCodeOpExceededWorkLimit: "op_exceeded_work_limit",
+	xdr.OperationResultCodeOpTooManySponsoring: "op_too_many_sponsoring",
+}
+
+var paymentResultCodes = map[xdr.PaymentResultCode]string{
+	xdr.PaymentResultCodePaymentSuccess:          OpSuccessCode,
+	xdr.PaymentResultCodePaymentMalformed:        "op_malformed",
+	xdr.PaymentResultCodePaymentUnderfunded:      "op_underfunded",
+	xdr.PaymentResultCodePaymentSrcNoTrust:       "op_src_no_trust",
+	xdr.PaymentResultCodePaymentSrcNotAuthorized: "op_src_not_authorized",
+	xdr.PaymentResultCodePaymentNoDestination:    "op_no_destination",
+	xdr.PaymentResultCodePaymentNoTrust:          "op_no_trust",
+	xdr.PaymentResultCodePaymentNotAuthorized:    "op_not_authorized",
+	xdr.PaymentResultCodePaymentLineFull:         "op_line_full",
+	xdr.PaymentResultCodePaymentNoIssuer:         "op_no_issuer",
+}
+
+var createClaimableBalanceResultCodes = map[xdr.CreateClaimableBalanceResultCode]string{
+	xdr.CreateClaimableBalanceResultCodeCreateClaimableBalanceSuccess:       OpSuccessCode,
+	xdr.CreateClaimableBalanceResultCodeCreateClaimableBalanceMalformed:     "op_malformed",
+	xdr.CreateClaimableBalanceResultCodeCreateClaimableBalanceLowReserve:    "op_low_reserve",
+	xdr.CreateClaimableBalanceResultCodeCreateClaimableBalanceNoTrust:       "op_no_trust",
+	xdr.CreateClaimableBalanceResultCodeCreateClaimableBalanceNotAuthorized: "op_not_authorized",
+	xdr.CreateClaimableBalanceResultCodeCreateClaimableBalanceUnderfunded:   "op_underfunded",
+}
+
+var pathPaymentStrictReceiveResultCodes = map[xdr.PathPaymentStrictReceiveResultCode]string{
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveSuccess:          OpSuccessCode,
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveMalformed:        "op_malformed",
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveUnderfunded:      "op_underfunded",
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveSrcNoTrust:       "op_src_no_trust",
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveSrcNotAuthorized: "op_src_not_authorized",
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveNoDestination:    "op_no_destination",
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveNoTrust:          "op_no_trust",
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveNotAuthorized:    "op_not_authorized",
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveLineFull:         "op_line_full",
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveNoIssuer:         "op_no_issuer",
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveTooFewOffers:     "op_too_few_offers",
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveOfferCrossSelf:   "op_cross_self",
+	xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveOverSendmax:      "op_over_source_max",
+}
+
+// OperationResultCode returns the result code of an operation in the same format used by Horizon, e.g. "op_success",
+// "op_no_trust" or "op_underfunded".
+func OperationResultCode(opResult xdr.OperationResult) string {
+	if opResult.Code != xdr.OperationResultCodeOpInner {
+		if code, ok := operationResultCodes[opResult.Code]; ok {
+			return code
+		}
+		return opUnknownCode
+	}
+
+	tr, ok := opResult.GetTr()
+	if !ok {
+		return opUnknownCode
+	}
+
+	switch tr.Type {
+	case xdr.OperationTypePayment:
+		if code, ok := paymentResultCodes[tr.MustPaymentResult().Code]; ok {
+			return code
+		}
+	case xdr.OperationTypePathPaymentStrictReceive:
+		if code, ok := pathPaymentStrictReceiveResultCodes[tr.MustPathPaymentStrictReceiveResult().Code]; ok {
+			return code
+		}
+	case xdr.OperationTypeCreateClaimableBalance:
+		if code, ok := createClaimableBalanceResultCodes[tr.MustCreateClaimableBalanceResult().Code]; ok {
+			return code
+		}
+	}
+
+	return opUnknownCode
+}
+
+// OperationResultCodes decodes a base64-encoded TransactionResult XDR and returns the result code of each one of its
+// operations, in the same order they appear in the transaction. Fee-bump results are unwrapped to their inner result.
+func OperationResultCodes(resultXDR string) ([]string, error) {
+	var txResult xdr.TransactionResult
+	err := xdr.SafeUnmarshalBase64(resultXDR, &txResult)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling transaction result XDR: %w", err)
+	}
+
+	opResults, ok := txResult.OperationResults()
+	if !ok {
+		return nil, nil
+	}
+
+	codes := make([]string, 0, len(opResults))
+	for _, opResult := range opResults {
+		codes = append(codes, OperationResultCode(opResult))
+	}
+
+	return codes, nil
+}
+
+// ClaimableBalanceID decodes a base64-encoded TransactionResult XDR and returns the hex-encoded ID of the claimable
+// balance created by the operation at the given index, in the same format used by Horizon.
+func ClaimableBalanceID(resultXDR string, opIndex int) (string, error) {
+	var txResult xdr.TransactionResult
+	err := xdr.SafeUnmarshalBase64(resultXDR, &txResult)
+	if err != nil {
+		return "", fmt.Errorf("unmarshalling transaction result XDR: %w", err)
+	}
+
+	opResults, ok := txResult.OperationResults()
+	if !ok || opIndex < 0 || opIndex >= len(opResults) {
+		return "", fmt.Errorf("operation result %d not found in the transaction result", opIndex)
+	}
+
+	tr, ok := opResults[opIndex].GetTr()
+	if !ok {
+		return "", fmt.Errorf("operation %d has no inner result", opIndex)
+	}
+	cbResult, ok := tr.GetCreateClaimableBalanceResult()
+	if !ok || cbResult.BalanceId == nil {
+		return "", fmt.Errorf("operation %d did not create a claimable balance", opIndex)
+	}
+
+	balanceID, err := xdr.MarshalHex(*cbResult.BalanceId)
+	if err != nil {
+		return "", fmt.Errorf("encoding claimable balance ID: %w", err)
+	}
+
+	return balanceID, nil
+}
+
+var transactionResultCodes = map[xdr.TransactionResultCode]string{
+	xdr.TransactionResultCodeTxFeeBumpInnerSuccess: "tx_fee_bump_inner_success",
+	xdr.TransactionResultCodeTxSuccess:             "tx_success",
+	xdr.TransactionResultCodeTxFailed:              "tx_failed",
+	xdr.TransactionResultCodeTxTooEarly:            "tx_too_early",
+	xdr.TransactionResultCodeTxTooLate:             "tx_too_late",
+	xdr.TransactionResultCodeTxMissingOperation:    "tx_missing_operation",
+	xdr.TransactionResultCodeTxBadSeq:              "tx_bad_seq",
+	xdr.TransactionResultCodeTxBadAuth:             "tx_bad_auth",
+	xdr.TransactionResultCodeTxInsufficientBalance: "tx_insufficient_balance",
+	xdr.TransactionResultCodeTxNoAccount:           "tx_no_source_account",
+	xdr.TransactionResultCodeTxInsufficientFee:     "tx_insufficient_fee",
+	xdr.TransactionResultCodeTxBadAuthExtra:        "tx_bad_auth_extra",
+	xdr.TransactionResultCodeTxInternalError:       "tx_internal_error",
+	xdr.TransactionResultCodeTxNotSupported:        "tx_not_supported",
+	xdr.TransactionResultCodeTxFeeBumpInnerFailed:  "tx_fee_bump_inner_failed",
+	xdr.TransactionResultCodeTxBadSponsorship:      "tx_bad_sponsorship",
+	xdr.TransactionResultCodeTxBadMinSeqAgeOrGap:   "tx_bad_minseq_age_or_gap",
+	xdr.TransactionResultCodeTxMalformed:           "tx_malformed",
+	xdr.TransactionResultCodeTxSorobanInvalid:      "tx_soroban_invalid",
+}
+
+// TransactionResultCode returns the result code of a transaction in the same format used by Horizon, e.g. "tx_success",
+// "tx_failed" or "tx_bad_seq".
+func TransactionResultCode(code xdr.TransactionResultCode) string {
+	if txCode, ok := transactionResultCodes[code]; ok {
+		return txCode
+	}
+	return "tx_unknown"
+}
+
+// ResultCodes are the decoded result codes of a transaction.
+type ResultCodes struct {
+	TransactionCode string
+	// InnerTransactionCode is only set for fee-bump transactions, with the result code of the inner transaction.
+	InnerTransactionCode string
+	OperationCodes       []string
+}
+
+// DecodeResultCodes decodes a base64-encoded TransactionResult XDR and returns the result codes of the transaction and
+// its operations, in the same format used by Horizon.
+func DecodeResultCodes(resultXDR string) (*ResultCodes, error) {
+	var txResult xdr.TransactionResult
+	err := xdr.SafeUnmarshalBase64(resultXDR, &txResult)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling transaction result XDR: %w", err)
+	}
+
+	resultCodes := &ResultCodes{TransactionCode: TransactionResultCode(txResult.Result.Code)}
+	if innerResultPair, ok := txResult.Result.GetInnerResultPair(); ok {
+		resultCodes.InnerTransactionCode = TransactionResultCode(innerResultPair.Result.Result.Code)
+	}
+
+	opResults, _ := txResult.OperationResults()
+	for _, opResult := range opResults {
+		resultCodes.OperationCodes = append(resultCodes.OperationCodes, OperationResultCode(opResult))
+	}
+
+	return resultCodes, nil
+}
+
+// TransactionSourceAccount decodes a base64-encoded TransactionEnvelope XDR and returns the source account of the
+// transaction, which is the channel account used to submit it. Fee-bump envelopes are unwrapped to their inner
+// transaction.
+func TransactionSourceAccount(envelopeXDR string) (string, error) {
+	var envelope xdr.TransactionEnvelope
+	err := xdr.SafeUnmarshalBase64(envelopeXDR, &envelope)
+	if err != nil {
+		return "", fmt.Errorf("unmarshalling transaction envelope XDR: %w", err)
+	}
+
+	switch envelope.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTx, xdr.EnvelopeTypeEnvelopeTypeTxV0, xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+	default:
+		return "", fmt.Errorf("unsupported envelope type %s", envelope.Type)
+	}
+
+	sourceAccount := envelope.SourceAccount()
+	address, err := sourceAccount.GetAddress()
+	if err != nil {
+		return "", fmt.Errorf("getting source account address: %w", err)
+	}
+
+	return address, nil
+}
@@ -0,0 +1,282 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func paymentOpResult(code xdr.PaymentResultCode) xdr.OperationResult {
+	return xdr.OperationResult{
+		Code: xdr.OperationResultCodeOpInner,
+		Tr: &xdr.OperationResultTr{
+			Type:          xdr.OperationTypePayment,
+			PaymentResult: &xdr.PaymentResult{Code: code},
+		},
+	}
+}
+
+func Test_OperationResultCode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opResult xdr.OperationResult
+		wantCode string
+	}{
+		{
+			name:     "outer operation error",
+			opResult: xdr.OperationResult{Code: xdr.OperationResultCodeOpNoAccount},
+			wantCode: "op_no_source_account",
+		},
+		{
+			name:     "payment success",
+			opResult: paymentOpResult(xdr.PaymentResultCodePaymentSuccess),
+			wantCode: OpSuccessCode,
+		},
+		{
+			name:     "payment no trust",
+			opResult: paymentOpResult(xdr.PaymentResultCodePaymentNoTrust),
+			wantCode: "op_no_trust",
+		},
+		{
+			name:     "payment underfunded",
+			opResult: paymentOpResult(xdr.PaymentResultCodePaymentUnderfunded),
+			wantCode: "op_underfunded",
+		},
+		{
+			name: "path payment over send max",
+			opResult: xdr.OperationResult{
+				Code: xdr.OperationResultCodeOpInner,
+				Tr: &xdr.OperationResultTr{
+					Type: xdr.OperationTypePathPaymentStrictReceive,
+					PathPaymentStrictReceiveResult: &xdr.PathPaymentStrictReceiveResult{
+						Code: xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveOverSendmax,
+					},
+				},
+			},
+			wantCode: "op_over_source_max",
+		},
+		{
+			name: "unsupported operation type",
+			opResult: xdr.OperationResult{
+				Code: xdr.OperationResultCodeOpInner,
+				Tr: &xdr.OperationResultTr{
+					Type:          xdr.OperationTypeBumpSequence,
+					BumpSeqResult: &xdr.BumpSequenceResult{Code: xdr.BumpSequenceResultCodeBumpSequenceSuccess},
+				},
+			},
+			wantCode: opUnknownCode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantCode, OperationResultCode(tc.opResult))
+		})
+	}
+}
+
+func Test_OperationResultCodes(t *testing.T) {
+	t.Run("returns an error if the XDR is invalid", func(t *testing.T) {
+		codes, err := OperationResultCodes("invalid")
+		assert.ErrorContains(t, err, "unmarshalling transaction result XDR")
+		assert.Nil(t, codes)
+	})
+
+	t.Run("returns nil if the result has no operation results", func(t *testing.T) {
+		txResult := xdr.TransactionResult{
+			Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxBadSeq},
+		}
+		resultXDR, err := xdr.MarshalBase64(txResult)
+		require.NoError(t, err)
+
+		codes, err := OperationResultCodes(resultXDR)
+		require.NoError(t, err)
+		assert.Nil(t, codes)
+	})
+
+	t.Run("🎉 returns the operation codes of a fee-bump transaction result", func(t *testing.T) {
+		opResults := []xdr.OperationResult{
+			paymentOpResult(xdr.PaymentResultCodePaymentSuccess),
+			paymentOpResult(xdr.PaymentResultCodePaymentNoDestination),
+			paymentOpResult(xdr.PaymentResultCodePaymentLineFull),
+		}
+		txResult := xdr.TransactionResult{
+			Result: xdr.TransactionResultResult{
+				Code: xdr.TransactionResultCodeTxFeeBumpInnerFailed,
+				InnerResultPair: &xdr.InnerTransactionResultPair{
+					Result: xdr.InnerTransactionResult{
+						Result: xdr.InnerTransactionResultResult{
+							Code:    xdr.TransactionResultCodeTxFailed,
+							Results: &opResults,
+						},
+					},
+				},
+			},
+		}
+		resultXDR, err := xdr.MarshalBase64(txResult)
+		require.NoError(t, err)
+
+		codes, err := OperationResultCodes(resultXDR)
+		require.NoError(t, err)
+		assert.Equal(t, []string{OpSuccessCode, "op_no_destination", "op_line_full"}, codes)
+	})
+}
+
+func Test_ClaimableBalanceID(t *testing.T) {
+	balanceID := xdr.ClaimableBalanceId{
+		Type: xdr.ClaimableBalanceIdTypeClaimableBalanceIdTypeV0,
+		V0:   &xdr.Hash{1, 2, 3},
+	}
+	opResults := []xdr.OperationResult{
+		paymentOpResult(xdr.PaymentResultCodePaymentSuccess),
+		{
+			Code: xdr.OperationResultCodeOpInner,
+			Tr: &xdr.OperationResultTr{
+				Type: xdr.OperationTypeCreateClaimableBalance,
+				CreateClaimableBalanceResult: &xdr.CreateClaimableBalanceResult{
+					Code:      xdr.CreateClaimableBalanceResultCodeCreateClaimableBalanceSuccess,
+					BalanceId: &balanceID,
+				},
+			},
+		},
+	}
+	txResult := xdr.TransactionResult{
+		Result: xdr.TransactionResultResult{
+			Code:    xdr.TransactionResultCodeTxSuccess,
+			Results: &opResults,
+		},
+	}
+	resultXDR, err := xdr.MarshalBase64(txResult)
+	require.NoError(t, err)
+
+	t.Run("returns an error if the XDR is invalid", func(t *testing.T) {
+		_, err := ClaimableBalanceID("invalid", 0)
+		assert.ErrorContains(t, err, "unmarshalling transaction result XDR")
+	})
+
+	t.Run("returns an error if the operation index is out of range", func(t *testing.T) {
+		_, err := ClaimableBalanceID(resultXDR, 2)
+		assert.EqualError(t, err, "operation result 2 not found in the transaction result")
+	})
+
+	t.Run("returns an error if the operation did not create a claimable balance", func(t *testing.T) {
+		_, err := ClaimableBalanceID(resultXDR, 0)
+		assert.EqualError(t, err, "operation 0 did not create a claimable balance")
+	})
+
+	t.Run("🎉 returns the hex-encoded claimable balance ID", func(t *testing.T) {
+		wantID, err := xdr.MarshalHex(balanceID)
+		require.NoError(t, err)
+
+		gotID, err := ClaimableBalanceID(resultXDR, 1)
+		require.NoError(t, err)
+		assert.Equal(t, wantID, gotID)
+		assert.Len(t, gotID, 72)
+	})
+}
+
+func Test_DecodeResultCodes(t *testing.T) {
+	t.Run("returns an error if the XDR is invalid", func(t *testing.T) {
+		resultCodes, err := DecodeResultCodes("invalid")
+		assert.ErrorContains(t, err, "unmarshalling transaction result XDR")
+		assert.Nil(t, resultCodes)
+	})
+
+	t.Run("🎉 returns the transaction code of a result without operation results", func(t *testing.T) {
+		txResult := xdr.TransactionResult{
+			Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxBadSeq},
+		}
+		resultXDR, err := xdr.MarshalBase64(txResult)
+		require.NoError(t, err)
+
+		resultCodes, err := DecodeResultCodes(resultXDR)
+		require.NoError(t, err)
+		assert.Equal(t, &ResultCodes{TransactionCode: "tx_bad_seq"}, resultCodes)
+	})
+
+	t.Run("🎉 returns the inner transaction and operation codes of a fee-bump transaction result", func(t *testing.T) {
+		opResults := []xdr.OperationResult{
+			paymentOpResult(xdr.PaymentResultCodePaymentSuccess),
+			paymentOpResult(xdr.PaymentResultCodePaymentNoTrust),
+		}
+		txResult := xdr.TransactionResult{
+			Result: xdr.TransactionResultResult{
+				Code: xdr.TransactionResultCodeTxFeeBumpInnerFailed,
+				InnerResultPair: &xdr.InnerTransactionResultPair{
+					Result: xdr.InnerTransactionResult{
+						Result: xdr.InnerTransactionResultResult{
+							Code:    xdr.TransactionResultCodeTxFailed,
+							Results: &opResults,
+						},
+					},
+				},
+			},
+		}
+		resultXDR, err := xdr.MarshalBase64(txResult)
+		require.NoError(t, err)
+
+		resultCodes, err := DecodeResultCodes(resultXDR)
+		require.NoError(t, err)
+		assert.Equal(t, &ResultCodes{
+			TransactionCode:      "tx_fee_bump_inner_failed",
+			InnerTransactionCode: "tx_failed",
+			OperationCodes:       []string{OpSuccessCode, "op_no_trust"},
+		}, resultCodes)
+	})
+}
+
+func Test_TransactionSourceAccount(t *testing.T) {
+	channelAccountKP := keypair.MustRandom()
+	distributionKP := keypair.MustRandom()
+
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: channelAccountKP.Address(), Sequence: 1},
+		Operations: []txnbuild.Operation{
+			&txnbuild.Payment{
+				SourceAccount: distributionKP.Address(),
+				Destination:   keypair.MustRandom().Address(),
+				Amount:        "1",
+				Asset:         txnbuild.NativeAsset{},
+			},
+		},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, channelAccountKP, distributionKP)
+	require.NoError(t, err)
+	feeBumpTx, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      tx,
+		FeeAccount: distributionKP.Address(),
+		BaseFee:    txnbuild.MinBaseFee * 2,
+	})
+	require.NoError(t, err)
+
+	t.Run("returns an error if the XDR is invalid", func(t *testing.T) {
+		_, err := TransactionSourceAccount("invalid")
+		assert.ErrorContains(t, err, "unmarshalling transaction envelope XDR")
+	})
+
+	t.Run("🎉 returns the source account of a transaction", func(t *testing.T) {
+		envelopeXDR, err := tx.Base64()
+		require.NoError(t, err)
+
+		sourceAccount, err := TransactionSourceAccount(envelopeXDR)
+		require.NoError(t, err)
+		assert.Equal(t, channelAccountKP.Address(), sourceAccount)
+	})
+
+	t.Run("🎉 returns the source account of the inner transaction of a fee-bump transaction", func(t *testing.T) {
+		envelopeXDR, err := feeBumpTx.Base64()
+		require.NoError(t, err)
+
+		sourceAccount, err := TransactionSourceAccount(envelopeXDR)
+		require.NoError(t, err)
+		assert.Equal(t, channelAccountKP.Address(), sourceAccount)
+	})
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed cron expression in the standard 5-field format: minute, hour, day of month, month and day
+// of week. Each field accepts `*`, single values, ranges (`1-5`), lists (`1,15`) and steps (`*/15`, `0-30/10`).
+type CronSchedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+	// domRestricted and dowRestricted follow the cron convention where, if both the day of month and the day of week
+	// are restricted, a day matches when either of them matches.
+	domRestricted bool
+	dowRestricted bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var (
+	cronMinuteField     = cronField{name: "minute", min: 0, max: 59}
+	cronHourField       = cronField{name: "hour", min: 0, max: 23}
+	cronDayOfMonthField = cronField{name: "day of month", min: 1, max: 31}
+	cronMonthField      = cronField{name: "month", min: 1, max: 12}
+	cronDayOfWeekField  = cronField{name: "day of week", min: 0, max: 6}
+
+	cronMacros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// cronMaxSearchYears bounds the search for the next occurrence, so expressions that can never match, such as
+// `0 0 31 2 *`, don't loop forever.
+const cronMaxSearchYears = 5
+
+// ParseCronSchedule parses a 5-field cron expression, or one of the @yearly, @monthly, @weekly, @daily and @hourly
+// macros.
+func ParseCronSchedule(expression string) (*CronSchedule, error) {
+	expression = strings.TrimSpace(expression)
+	if macro, ok := cronMacros[strings.ToLower(expression)]; ok {
+		expression = macro
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, found %d", expression, len(fields))
+	}
+
+	schedule := &CronSchedule{
+		domRestricted: !strings.HasPrefix(fields[2], "*"),
+		dowRestricted: !strings.HasPrefix(fields[4], "*"),
+	}
+
+	var err error
+	for i, target := range []struct {
+		bits  *uint64
+		field cronField
+	}{
+		{&schedule.minutes, cronMinuteField},
+		{&schedule.hours, cronHourField},
+		{&schedule.daysOfMonth, cronDayOfMonthField},
+		{&schedule.months, cronMonthField},
+		{&schedule.daysOfWeek, cronDayOfWeekField},
+	} {
+		if *target.bits, err = parseCronField(fields[i], target.field); err != nil {
+			return nil, fmt.Errorf("parsing cron expression %q: %w", expression, err)
+		}
+	}
+
+	return schedule, nil
+}
+
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in the %s field", stepPart, field.name)
+			}
+		}
+
+		start, end := field.min, field.max
+		if rangePart != "*" {
+			startStr, endStr, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			if start, err = strconv.Atoi(startStr); err != nil {
+				return 0, fmt.Errorf("invalid value %q in the %s field", part, field.name)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(endStr); err != nil {
+					return 0, fmt.Errorf("invalid value %q in the %s field", part, field.name)
+				}
+			} else if hasStep {
+				end = field.max
+			}
+
+			// 7 is accepted as an alias for Sunday.
+			if field == cronDayOfWeekField && end == 7 {
+				if start == 7 {
+					start, end = 0, 0
+				} else {
+					end = 6
+					bits |= 1
+				}
+			}
+		}
+
+		if start < field.min || end > field.max || start > end {
+			return 0, fmt.Errorf("value %q is out of the %d-%d range of the %s field", part, field.min, field.max, field.name)
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+// Next returns the first time matching the schedule strictly after the given time, evaluated in the location of the
+// given time. It returns the zero time if no match is found within the next few years.
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronMaxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	domMatch := s.daysOfMonth&(1<<uint(t.Day())) != 0
+	dowMatch := s.daysOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+// ParseUTCOffset parses a UTC offset in the "+02:00" format, returning a fixed time zone. An empty offset is
+// interpreted as UTC.
+func ParseUTCOffset(offset string) (*time.Location, error) {
+	if offset == "" {
+		return time.UTC, nil
+	}
+
+	t, err := time.Parse("-07:00", offset)
+	if err != nil {
+		return nil, fmt.Errorf("invalid UTC offset %q: %w", offset, err)
+	}
+	_, seconds := t.Zone()
+
+	return time.FixedZone(offset, seconds), nil
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseCronSchedule_errors(t *testing.T) {
+	testCases := []struct {
+		expression      string
+		wantErrContains string
+	}{
+		{"", "must have 5 fields, found 0"},
+		{"* * * *", "must have 5 fields, found 4"},
+		{"60 * * * *", `value "60" is out of the 0-59 range of the minute field`},
+		{"* 24 * * *", `value "24" is out of the 0-23 range of the hour field`},
+		{"* * 0 * *", `value "0" is out of the 1-31 range of the day of month field`},
+		{"* * * 13 *", `value "13" is out of the 1-12 range of the month field`},
+		{"* * * * 8", `value "8" is out of the 0-6 range of the day of week field`},
+		{"* * 5-1 * *", `value "5-1" is out of the 1-31 range of the day of month field`},
+		{"*/0 * * * *", `invalid step "0" in the minute field`},
+		{"a * * * *", `invalid value "a" in the minute field`},
+		{"@every-day", "must have 5 fields, found 1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tc.expression)
+			assert.Nil(t, schedule)
+			assert.ErrorContains(t, err, tc.wantErrContains)
+		})
+	}
+}
+
+func Test_CronSchedule_Next(t *testing.T) {
+	after := time.Date(2025, time.January, 15, 10, 30, 45, 0, time.UTC)
+
+	testCases := []struct {
+		expression string
+		want       time.Time
+	}{
+		{"* * * * *", time.Date(2025, time.January, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, time.January, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2025, time.January, 16, 9, 0, 0, 0, time.UTC)},
+		{"0 9 1 * *", time.Date(2025, time.February, 1, 9, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1-5", time.Date(2025, time.January, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 6-7", time.Date(2025, time.January, 18, 0, 0, 0, 0, time.UTC)},
+		// when both day fields are restricted, either of them matching is enough
+		{"0 0 1 * 5", time.Date(2025, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		{"30 10 29 2 *", time.Date(2028, time.February, 29, 10, 30, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tc.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, schedule.Next(after))
+		})
+	}
+
+	t.Run("evaluated in the location of the given time", func(t *testing.T) {
+		schedule, err := ParseCronSchedule("0 9 * * *")
+		require.NoError(t, err)
+
+		loc := time.FixedZone("+02:00", 2*60*60)
+		next := schedule.Next(after.In(loc))
+		assert.Equal(t, time.Date(2025, time.January, 16, 7, 0, 0, 0, time.UTC), next.UTC())
+	})
+}
+
+func Test_ParseUTCOffset(t *testing.T) {
+	loc, err := ParseUTCOffset("")
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+
+	loc, err = ParseUTCOffset("-03:30")
+	require.NoError(t, err)
+	_, offset := time.Date(2025, time.January, 1, 0, 0, 0, 0, loc).Zone()
+	assert.Equal(t, -(3*60*60 + 30*60), offset)
+
+	_, err = ParseUTCOffset("3 hours")
+	assert.ErrorContains(t, err, `invalid UTC offset "3 hours"`)
+}
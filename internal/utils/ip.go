@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// ErrNonPublicIP is returned when a connection to, or a URL pointing at, a non-public IP address is rejected.
+var ErrNonPublicIP = errors.New("address is not a public IP")
+
+// nonPublicIPNets are the ranges not covered by the net.IP helpers that must not be reachable from user-provided URLs.
+// 100.64.0.0/10 is the shared address space, which is used by some cloud providers for their metadata services.
+var nonPublicIPNets = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return ipNet
+}
+
+// IsPublicIP returns false for loopback, private, link-local (which includes the 169.254.169.254 cloud metadata
+// address), multicast, unspecified and shared addresses.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, ipNet := range nonPublicIPNets {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// PublicIPDialerControl is a net.Dialer Control function that rejects connections to non-public IPs. It runs after the
+// host is resolved, so it can't be bypassed by a DNS record that changes between the validation and the connection.
+func PublicIPDialerControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("splitting host and port of %q: %w", address, err)
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("dialing %s: %w", host, ErrNonPublicIP)
+	}
+	return nil
+}
+
+// ValidatePublicURLHost returns an error if the URL host is localhost or a non-public IP. Hostnames are only checked
+// when they're resolved, with PublicIPDialerControl.
+func ValidatePublicURLHost(link string) error {
+	parsedURL, err := url.Parse(link)
+	if err != nil {
+		return errors.New("invalid URL format")
+	}
+
+	host := strings.ToLower(parsedURL.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("host %s: %w", host, ErrNonPublicIP)
+	}
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return fmt.Errorf("host %s: %w", host, ErrNonPublicIP)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsPublicIP(t *testing.T) {
+	testCases := []struct {
+		ip       string
+		isPublic bool
+	}{
+		{ip: "8.8.8.8", isPublic: true},
+		{ip: "2001:4860:4860::8888", isPublic: true},
+		{ip: "127.0.0.1", isPublic: false},
+		{ip: "::1", isPublic: false},
+		{ip: "10.0.0.1", isPublic: false},
+		{ip: "172.16.0.1", isPublic: false},
+		{ip: "192.168.1.1", isPublic: false},
+		{ip: "169.254.169.254", isPublic: false},
+		{ip: "fd00:ec2::254", isPublic: false},
+		{ip: "100.100.100.200", isPublic: false},
+		{ip: "0.0.0.0", isPublic: false},
+		{ip: "0.1.2.3", isPublic: false},
+		{ip: "224.0.0.1", isPublic: false},
+		{ip: "::ffff:127.0.0.1", isPublic: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.ip, func(t *testing.T) {
+			assert.Equal(t, tc.isPublic, IsPublicIP(net.ParseIP(tc.ip)))
+		})
+	}
+}
+
+func Test_PublicIPDialerControl(t *testing.T) {
+	assert.NoError(t, PublicIPDialerControl("tcp", "8.8.8.8:443", nil))
+	assert.NoError(t, PublicIPDialerControl("tcp6", "[2001:4860:4860::8888]:443", nil))
+	assert.ErrorIs(t, PublicIPDialerControl("tcp", "127.0.0.1:8080", nil), ErrNonPublicIP)
+	assert.ErrorIs(t, PublicIPDialerControl("tcp", "169.254.169.254:80", nil), ErrNonPublicIP)
+	assert.ErrorIs(t, PublicIPDialerControl("tcp6", "[::1]:80", nil), ErrNonPublicIP)
+	assert.ErrorContains(t, PublicIPDialerControl("tcp", "invalid", nil), `splitting host and port of "invalid"`)
+}
+
+func Test_ValidatePublicURLHost(t *testing.T) {
+	assert.NoError(t, ValidatePublicURLHost("https://example.com/webhooks"))
+	assert.NoError(t, ValidatePublicURLHost("https://8.8.8.8/webhooks"))
+	assert.ErrorIs(t, ValidatePublicURLHost("http://localhost:8000/webhooks"), ErrNonPublicIP)
+	assert.ErrorIs(t, ValidatePublicURLHost("http://api.localhost/webhooks"), ErrNonPublicIP)
+	assert.ErrorIs(t, ValidatePublicURLHost("http://127.0.0.1/webhooks"), ErrNonPublicIP)
+	assert.ErrorIs(t, ValidatePublicURLHost("http://169.254.169.254/latest/meta-data"), ErrNonPublicIP)
+	assert.ErrorIs(t, ValidatePublicURLHost("http://[::1]/webhooks"), ErrNonPublicIP)
+}
@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// taxIDValidators maps the ISO 3166-1 alpha-2 code of a country to the validation of its individual tax IDs, which
+// receives the tax ID with its separators removed.
+var taxIDValidators = map[string]func(taxID string) error{
+	"AR": validateArgentinaCUIT,
+	"BR": validateBrazilCPF,
+	"CL": validateChileRUT,
+	"ES": validateSpainNIF,
+}
+
+// TaxIDCountries returns the codes of the countries whose tax IDs are supported, sorted alphabetically.
+func TaxIDCountries() []string {
+	countries := make([]string, 0, len(taxIDValidators))
+	for country := range taxIDValidators {
+		countries = append(countries, country)
+	}
+	slices.Sort(countries)
+	return countries
+}
+
+// ParseTaxID splits a tax ID in the `<country code>:<tax ID>` format, e.g. `BR:529.982.247-25`, returning the country
+// code uppercased and the tax ID uppercased and without the spaces, dots, dashes and slashes used as separators.
+func ParseTaxID(taxID string) (country, number string, err error) {
+	country, number, found := strings.Cut(strings.TrimSpace(taxID), ":")
+	if !found {
+		return "", "", errors.New("invalid tax id format. Correct format: <country code>:<tax id>, e.g. BR:529.982.247-25")
+	}
+
+	country = strings.ToUpper(strings.TrimSpace(country))
+	number = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(" .-/", r) {
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, number)
+
+	return country, number, nil
+}
+
+// NormalizeTaxID returns a tax ID in the `<country code>:<tax ID>` format without separators, so the same tax ID
+// written in different ways is always the same value.
+func NormalizeTaxID(taxID string) string {
+	country, number, err := ParseTaxID(taxID)
+	if err != nil {
+		return strings.TrimSpace(taxID)
+	}
+	return country + ":" + number
+}
+
+// ValidateTaxID validates a tax ID in the `<country code>:<tax ID>` format, including its check digits.
+func ValidateTaxID(taxID string) error {
+	country, number, err := ParseTaxID(taxID)
+	if err != nil {
+		return err
+	}
+
+	validate, ok := taxIDValidators[country]
+	if !ok {
+		return fmt.Errorf("tax id country %q is not supported. Supported countries: %v", country, TaxIDCountries())
+	}
+
+	if err = validate(number); err != nil {
+		return fmt.Errorf("invalid %s tax id: %w", country, err)
+	}
+
+	return nil
+}
+
+// digitsOf returns the digits of a string, or false if it has any character that isn't a digit.
+func digitsOf(s string) ([]int, bool) {
+	digits := make([]int, 0, len(s))
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return nil, false
+		}
+		digits = append(digits, int(r-'0'))
+	}
+	return digits, true
+}
+
+// validateBrazilCPF validates a Brazilian CPF, with 9 digits followed by 2 check digits.
+func validateBrazilCPF(cpf string) error {
+	digits, ok := digitsOf(cpf)
+	if !ok || len(digits) != 11 {
+		return errors.New("CPF must have 11 digits")
+	}
+	if slices.Max(digits) == slices.Min(digits) {
+		return errors.New("CPF cannot have all digits equal")
+	}
+
+	for _, n := range []int{9, 10} {
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += digits[i] * (n + 1 - i)
+		}
+		if (sum*10)%11%10 != digits[n] {
+			return errors.New("CPF check digits don't match")
+		}
+	}
+
+	return nil
+}
+
+// validateArgentinaCUIT validates an Argentinian CUIT or CUIL, with 10 digits followed by a check digit.
+func validateArgentinaCUIT(cuit string) error {
+	digits, ok := digitsOf(cuit)
+	if !ok || len(digits) != 11 {
+		return errors.New("CUIT must have 11 digits")
+	}
+
+	sum := 0
+	for i, weight := range []int{5, 4, 3, 2, 7, 6, 5, 4, 3, 2} {
+		sum += digits[i] * weight
+	}
+	checkDigit := 11 - sum%11
+	if checkDigit == 11 {
+		checkDigit = 0
+	}
+	if checkDigit != digits[10] {
+		return errors.New("CUIT check digit doesn't match")
+	}
+
+	return nil
+}
+
+// validateChileRUT validates a Chilean RUT, with up to 8 digits followed by a check digit, which can be a K.
+func validateChileRUT(rut string) error {
+	if len(rut) < 2 || len(rut) > 9 {
+		return errors.New("RUT must have between 2 and 9 characters")
+	}
+	digits, ok := digitsOf(rut[:len(rut)-1])
+	if !ok {
+		return errors.New("RUT must have digits followed by a check digit")
+	}
+
+	sum := 0
+	for i := range digits {
+		sum += digits[len(digits)-1-i] * (2 + i%6)
+	}
+	checkDigit := strconv.Itoa(11 - sum%11)
+	switch checkDigit {
+	case "11":
+		checkDigit = "0"
+	case "10":
+		checkDigit = "K"
+	}
+	if checkDigit != rut[len(rut)-1:] {
+		return errors.New("RUT check digit doesn't match")
+	}
+
+	return nil
+}
+
+// validateSpainNIF validates a Spanish DNI, with 8 digits followed by a check letter, or NIE, which starts with X, Y
+// or Z instead of the first digit.
+func validateSpainNIF(nif string) error {
+	if len(nif) != 9 {
+		return errors.New("NIF must have 9 characters")
+	}
+
+	number := strings.NewReplacer("X", "0", "Y", "1", "Z", "2").Replace(nif[:1]) + nif[1:8]
+	digits, ok := digitsOf(number)
+	if !ok {
+		return errors.New("NIF must have 8 digits followed by a check letter")
+	}
+
+	n := 0
+	for _, d := range digits {
+		n = n*10 + d
+	}
+	if string("TRWAGMYFPDXBNJZSQVHLCKE"[n%23]) != nif[8:] {
+		return errors.New("NIF check letter doesn't match")
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseTaxID(t *testing.T) {
+	country, number, err := ParseTaxID(" br : 529.982.247-25 ")
+	require.NoError(t, err)
+	assert.Equal(t, "BR", country)
+	assert.Equal(t, "52998224725", number)
+
+	_, _, err = ParseTaxID("52998224725")
+	assert.EqualError(t, err, "invalid tax id format. Correct format: <country code>:<tax id>, e.g. BR:529.982.247-25")
+}
+
+func Test_NormalizeTaxID(t *testing.T) {
+	assert.Equal(t, "BR:52998224725", NormalizeTaxID("br:529.982.247-25"))
+	assert.Equal(t, "BR:52998224725", NormalizeTaxID("BR:52998224725"))
+	assert.Equal(t, "CL:12345678K", NormalizeTaxID("cl:12.345.678-k"))
+	assert.Equal(t, "52998224725", NormalizeTaxID(" 52998224725 "))
+}
+
+func Test_ValidateTaxID(t *testing.T) {
+	testCases := []struct {
+		taxID   string
+		wantErr string
+	}{
+		{taxID: "BR:529.982.247-25"},
+		{taxID: "BR:52998224725"},
+		{taxID: "BR:529.982.247-52", wantErr: "invalid BR tax id: CPF check digits don't match"},
+		{taxID: "BR:111.111.111-11", wantErr: "invalid BR tax id: CPF cannot have all digits equal"},
+		{taxID: "BR:529.982.247", wantErr: "invalid BR tax id: CPF must have 11 digits"},
+		{taxID: "AR:20-12345678-6"},
+		{taxID: "AR:20-12345678-5", wantErr: "invalid AR tax id: CUIT check digit doesn't match"},
+		{taxID: "AR:20-1234567A-6", wantErr: "invalid AR tax id: CUIT must have 11 digits"},
+		{taxID: "CL:12.345.678-5"},
+		{taxID: "CL:12.345.678-K", wantErr: "invalid CL tax id: RUT check digit doesn't match"},
+		{taxID: "CL:10.000.013-K"},
+		{taxID: "CL:5", wantErr: "invalid CL tax id: RUT must have between 2 and 9 characters"},
+		{taxID: "ES:12345678Z"},
+		{taxID: "ES:X1234567L"},
+		{taxID: "ES:12345678A", wantErr: "invalid ES tax id: NIF check letter doesn't match"},
+		{taxID: "ES:1234567Z", wantErr: "invalid ES tax id: NIF must have 9 characters"},
+		{taxID: "US:123-45-6789", wantErr: `tax id country "US" is not supported. Supported countries: [AR BR CL ES]`},
+		{taxID: "52998224725", wantErr: "invalid tax id format. Correct format: <country code>:<tax id>, e.g. BR:529.982.247-25"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.taxID, func(t *testing.T) {
+			err := ValidateTaxID(tc.taxID)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
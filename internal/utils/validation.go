@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/asaskevich/govalidator"
@@ -18,6 +19,7 @@ var (
 	// RxPhone is a regex used to validate phone number, according with the E.164 standard https://en.wikipedia.org/wiki/E.164
 	rxPhone                   = regexp.MustCompile(`^\+[1-9]{1}[0-9]{9,14}$`)
 	rxOTP                     = regexp.MustCompile(`^\d{6}$`)
+	rxPhoneLast4              = regexp.MustCompile(`^\d{4}$`)
 	ErrInvalidE164PhoneNumber = fmt.Errorf("the provided phone number is not a valid E.164 number")
 	ErrEmptyPhoneNumber       = fmt.Errorf("phone number cannot be empty")
 	ErrEmptyEmail             = fmt.Errorf("email cannot be empty")
@@ -28,6 +30,8 @@ const (
 	VerificationFieldPinMaxLength = 8
 
 	VerificationFieldMaxIdLength = 50
+
+	VerificationFieldMaxSecretAnswerLength = 100
 )
 
 // https://github.com/firebase/firebase-admin-go/blob/cef91acd46f2fc5d0b3408d8154a0005db5bdb0b/auth/user_mgt.go#L449-L457
@@ -167,6 +171,37 @@ func ValidateNationalIDVerification(nationalID string) error {
 	return nil
 }
 
+// ValidateTaxIDVerification will validate the tax id field for receiver verification.
+func ValidateTaxIDVerification(taxID string) error {
+	if taxID == "" {
+		return fmt.Errorf("tax id cannot be empty")
+	}
+
+	return ValidateTaxID(taxID)
+}
+
+// ValidatePhoneLast4Verification will validate the last 4 digits of the phone number field for receiver verification.
+func ValidatePhoneLast4Verification(phoneLast4 string) error {
+	if !rxPhoneLast4.MatchString(phoneLast4) {
+		return fmt.Errorf("invalid phone last 4 digits. Needs to be a 4 digit value")
+	}
+
+	return nil
+}
+
+// ValidateSecretAnswerVerification will validate the secret question answer field for receiver verification.
+func ValidateSecretAnswerVerification(answer string) error {
+	if strings.TrimSpace(answer) == "" {
+		return fmt.Errorf("secret question answer cannot be empty")
+	}
+
+	if len(answer) > VerificationFieldMaxSecretAnswerLength {
+		return fmt.Errorf("invalid secret question answer. Cannot have more than %d characters in secret question answer", VerificationFieldMaxSecretAnswerLength)
+	}
+
+	return nil
+}
+
 // ValidatePathIsNotTraversal will validate the given path to ensure it does not contain path traversal.
 func ValidatePathIsNotTraversal(p string) error {
 	if pathTraversalPattern.MatchString(p) {
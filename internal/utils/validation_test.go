@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -256,6 +257,69 @@ func Test_ValidateNationalIDVerification(t *testing.T) {
 	}
 }
 
+func Test_ValidateTaxIDVerification(t *testing.T) {
+	tests := []struct {
+		name            string
+		taxID           string
+		wantErrContains string
+	}{
+		{"valid Tax ID", "BR:529.982.247-25", ""},
+		{"invalid Tax ID - empty", "", "tax id cannot be empty"},
+		{"invalid Tax ID - without country", "52998224725", "invalid tax id format"},
+		{"invalid Tax ID - check digits", "BR:529.982.247-26", "invalid BR tax id: CPF check digits don't match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTaxIDVerification(tt.taxID)
+			if tt.wantErrContains == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErrContains)
+			}
+		})
+	}
+}
+
+func Test_ValidatePhoneLast4Verification(t *testing.T) {
+	tests := []struct {
+		name          string
+		phoneLast4    string
+		expectedError error
+	}{
+		{"valid phone last 4 digits", "0123", nil},
+		{"invalid phone last 4 digits - empty", "", fmt.Errorf("invalid phone last 4 digits. Needs to be a 4 digit value")},
+		{"invalid phone last 4 digits - too short", "123", fmt.Errorf("invalid phone last 4 digits. Needs to be a 4 digit value")},
+		{"invalid phone last 4 digits - not digits", "12a4", fmt.Errorf("invalid phone last 4 digits. Needs to be a 4 digit value")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePhoneLast4Verification(tt.phoneLast4)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+func Test_ValidateSecretAnswerVerification(t *testing.T) {
+	tests := []struct {
+		name          string
+		answer        string
+		expectedError error
+	}{
+		{"valid secret question answer", "The name of my first pet", nil},
+		{"invalid secret question answer - empty", " ", fmt.Errorf("secret question answer cannot be empty")},
+		{"invalid secret question answer - too long", strings.Repeat("a", VerificationFieldMaxSecretAnswerLength+1), fmt.Errorf("invalid secret question answer. Cannot have more than %d characters in secret question answer", VerificationFieldMaxSecretAnswerLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSecretAnswerVerification(tt.answer)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
 func Test_ValidateURLScheme(t *testing.T) {
 	tests := []struct {
 		url             string
@@ -10,7 +10,8 @@ const (
 	ChannelAccountStellarDB           AccountType = "CHANNEL_ACCOUNT.STELLAR.DB"
 	DistributionAccountStellarEnv     AccountType = "DISTRIBUTION_ACCOUNT.STELLAR.ENV"      // was "ENV_STELLAR"
 	DistributionAccountStellarDBVault AccountType = "DISTRIBUTION_ACCOUNT.STELLAR.DB_VAULT" // was "DB_VAULT_STELLAR"
-	DistributionAccountCircleDBVault  AccountType = "DISTRIBUTION_ACCOUNT.CIRCLE.DB_VAULT"  // was "DB_VAULT_CIRCLE"
+	DistributionAccountStellarRemote  AccountType = "DISTRIBUTION_ACCOUNT.STELLAR.REMOTE"
+	DistributionAccountCircleDBVault  AccountType = "DISTRIBUTION_ACCOUNT.CIRCLE.DB_VAULT" // was "DB_VAULT_CIRCLE"
 )
 
 func AllAccountTypes() []AccountType {
@@ -19,6 +20,7 @@ func AllAccountTypes() []AccountType {
 		ChannelAccountStellarDB,
 		DistributionAccountStellarEnv,
 		DistributionAccountStellarDBVault,
+		DistributionAccountStellarRemote,
 		DistributionAccountCircleDBVault,
 	}
 }
@@ -55,6 +57,7 @@ var accRoleMap = map[AccountType]Role{
 	ChannelAccountStellarDB:           ChannelAccountRole,
 	DistributionAccountStellarEnv:     DistributionAccountRole,
 	DistributionAccountStellarDBVault: DistributionAccountRole,
+	DistributionAccountStellarRemote:  DistributionAccountRole,
 	DistributionAccountCircleDBVault:  DistributionAccountRole,
 }
 
@@ -75,6 +78,7 @@ var accPlatformMap = map[AccountType]Platform{
 	ChannelAccountStellarDB:           StellarPlatform,
 	DistributionAccountStellarEnv:     StellarPlatform,
 	DistributionAccountStellarDBVault: StellarPlatform,
+	DistributionAccountStellarRemote:  StellarPlatform,
 	DistributionAccountCircleDBVault:  CirclePlatform,
 }
 
@@ -82,13 +86,15 @@ func (t AccountType) Platform() Platform {
 	return accPlatformMap[t]
 }
 
-// StorageMethod represents the method used to store the account secret, e.g. ENV, DB_VAULT, or DB.
+// StorageMethod represents the method used to store the account secret, e.g. ENV, DB_VAULT, or DB. The REMOTE storage
+// method means the secrets are held by external signers and never reach the SDP.
 type StorageMethod string
 
 const (
 	EnvStorageMethod     StorageMethod = "ENV"
 	DBStorageMethod      StorageMethod = "DB"
 	DBVaultStorageMethod StorageMethod = "DB_VAULT"
+	RemoteStorageMethod  StorageMethod = "REMOTE"
 )
 
 var accStorageMethodMap = map[AccountType]StorageMethod{
@@ -96,6 +102,7 @@ var accStorageMethodMap = map[AccountType]StorageMethod{
 	ChannelAccountStellarDB:           DBStorageMethod,
 	DistributionAccountStellarEnv:     EnvStorageMethod,
 	DistributionAccountStellarDBVault: DBVaultStorageMethod,
+	DistributionAccountStellarRemote:  RemoteStorageMethod,
 	DistributionAccountCircleDBVault:  DBVaultStorageMethod,
 }
 
@@ -16,6 +16,7 @@ func Test_AccountType_IsStellar(t *testing.T) {
 		{accountType: ChannelAccountStellarDB, isStellar: true},
 		{accountType: DistributionAccountStellarEnv, isStellar: true},
 		{accountType: DistributionAccountStellarDBVault, isStellar: true},
+		{accountType: DistributionAccountStellarRemote, isStellar: true},
 		{accountType: DistributionAccountCircleDBVault, isStellar: false},
 	}
 	for _, tc := range testCases {
@@ -38,6 +39,7 @@ func Test_AccountType_IsCircle(t *testing.T) {
 		{accountType: ChannelAccountStellarDB, isCircle: false},
 		{accountType: DistributionAccountStellarEnv, isCircle: false},
 		{accountType: DistributionAccountStellarDBVault, isCircle: false},
+		{accountType: DistributionAccountStellarRemote, isCircle: false},
 		{accountType: DistributionAccountCircleDBVault, isCircle: true},
 	}
 	for _, tc := range testCases {
@@ -60,6 +62,7 @@ func Test_AccountType_Role(t *testing.T) {
 		{accountType: ChannelAccountStellarDB, wantRole: ChannelAccountRole},
 		{accountType: DistributionAccountStellarEnv, wantRole: DistributionAccountRole},
 		{accountType: DistributionAccountStellarDBVault, wantRole: DistributionAccountRole},
+		{accountType: DistributionAccountStellarRemote, wantRole: DistributionAccountRole},
 		{accountType: DistributionAccountCircleDBVault, wantRole: DistributionAccountRole},
 	}
 	for _, tc := range testCases {
@@ -84,6 +87,7 @@ func Test_AccountType_Platform(t *testing.T) {
 		{accountType: ChannelAccountStellarDB, wantPlatform: StellarPlatform},
 		{accountType: DistributionAccountStellarEnv, wantPlatform: StellarPlatform},
 		{accountType: DistributionAccountStellarDBVault, wantPlatform: StellarPlatform},
+		{accountType: DistributionAccountStellarRemote, wantPlatform: StellarPlatform},
 		{accountType: DistributionAccountCircleDBVault, wantPlatform: CirclePlatform},
 	}
 	for _, tc := range testCases {
@@ -108,6 +112,7 @@ func Test_AccountType_StorageMethod(t *testing.T) {
 		{accountType: ChannelAccountStellarDB, wantStorageMethod: DBStorageMethod},
 		{accountType: DistributionAccountStellarEnv, wantStorageMethod: EnvStorageMethod},
 		{accountType: DistributionAccountStellarDBVault, wantStorageMethod: DBVaultStorageMethod},
+		{accountType: DistributionAccountStellarRemote, wantStorageMethod: RemoteStorageMethod},
 		{accountType: DistributionAccountCircleDBVault, wantStorageMethod: DBVaultStorageMethod},
 	}
 	for _, tc := range testCases {
@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/stellar/go/txnbuild"
+)
+
+// MemoType represents the type of a Stellar memo, as stored in the receiver wallet after the SEP-24 registration.
+type MemoType string
+
+const (
+	MemoTypeText MemoType = "text"
+	MemoTypeID   MemoType = "id"
+	MemoTypeHash MemoType = "hash"
+)
+
+func AllMemoTypes() []MemoType {
+	return []MemoType{MemoTypeText, MemoTypeID, MemoTypeHash}
+}
+
+var ErrEmptyMemoValue = errors.New("memo value cannot be empty when memo type is set")
+
+// Memo represents a Stellar memo that can be attached to a transaction.
+type Memo struct {
+	Value string   `json:"memo"`
+	Type  MemoType `json:"memo_type"`
+}
+
+// IsEmpty returns true if neither the value nor the type of the memo are set.
+func (m Memo) IsEmpty() bool {
+	return m.Value == "" && m.Type == ""
+}
+
+// Validate checks if the memo value is valid for the memo type. An empty memo is considered valid.
+func (m Memo) Validate() error {
+	_, err := m.ToTxnbuildMemo()
+	return err
+}
+
+// ToTxnbuildMemo converts the memo into a txnbuild.Memo. It returns nil if the memo is empty. Hash memos are accepted
+// both in hex and base64 encodings, as long as they decode to 32 bytes.
+func (m Memo) ToTxnbuildMemo() (txnbuild.Memo, error) {
+	if m.IsEmpty() {
+		return nil, nil
+	}
+	if m.Value == "" {
+		return nil, ErrEmptyMemoValue
+	}
+
+	switch m.Type {
+	case MemoTypeText:
+		if len(m.Value) > txnbuild.MemoTextMaxLength {
+			return nil, fmt.Errorf("text memo must have at most %d bytes, got %d", txnbuild.MemoTextMaxLength, len(m.Value))
+		}
+		return txnbuild.MemoText(m.Value), nil
+
+	case MemoTypeID:
+		id, err := strconv.ParseUint(m.Value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("id memo %q is not a valid uint64: %w", m.Value, err)
+		}
+		return txnbuild.MemoID(id), nil
+
+	case MemoTypeHash:
+		hashBytes, err := decodeMemoHash(m.Value)
+		if err != nil {
+			return nil, fmt.Errorf("hash memo %q is not valid: %w", m.Value, err)
+		}
+		var memoHash txnbuild.MemoHash
+		copy(memoHash[:], hashBytes)
+		return memoHash, nil
+
+	default:
+		return nil, fmt.Errorf("invalid memo type %q, must be one of %v", m.Type, AllMemoTypes())
+	}
+}
+
+// decodeMemoHash decodes a 32-byte hash that can be encoded either in hex or base64.
+func decodeMemoHash(value string) ([]byte, error) {
+	hashBytes, err := hex.DecodeString(value)
+	if err != nil {
+		hashBytes, err = base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("value must be hex or base64 encoded")
+		}
+	}
+
+	if len(hashBytes) != 32 {
+		return nil, fmt.Errorf("decoded value must have 32 bytes, got %d", len(hashBytes))
+	}
+
+	return hashBytes, nil
+}
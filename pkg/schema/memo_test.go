@@ -0,0 +1,108 @@
+package schema
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Memo_ToTxnbuildMemo(t *testing.T) {
+	hashHex := strings.Repeat("ab", 32)
+	var expectedHash txnbuild.MemoHash
+	for i := range expectedHash {
+		expectedHash[i] = 0xab
+	}
+	hashBase64 := base64.StdEncoding.EncodeToString(expectedHash[:])
+
+	testCases := []struct {
+		name            string
+		memo            Memo
+		wantMemo        txnbuild.Memo
+		wantErrContains string
+	}{
+		{
+			name:     "empty memo",
+			memo:     Memo{},
+			wantMemo: nil,
+		},
+		{
+			name:            "🔴empty value with type",
+			memo:            Memo{Type: MemoTypeText},
+			wantErrContains: ErrEmptyMemoValue.Error(),
+		},
+		{
+			name:            "🔴invalid type",
+			memo:            Memo{Value: "123", Type: "return"},
+			wantErrContains: `invalid memo type "return"`,
+		},
+		{
+			name:            "🔴value without type",
+			memo:            Memo{Value: "123"},
+			wantErrContains: `invalid memo type ""`,
+		},
+		{
+			name:     "🟢text memo",
+			memo:     Memo{Value: "hello world", Type: MemoTypeText},
+			wantMemo: txnbuild.MemoText("hello world"),
+		},
+		{
+			name:            "🔴text memo too long",
+			memo:            Memo{Value: strings.Repeat("a", 29), Type: MemoTypeText},
+			wantErrContains: "text memo must have at most 28 bytes, got 29",
+		},
+		{
+			name:     "🟢id memo",
+			memo:     Memo{Value: "1234567890", Type: MemoTypeID},
+			wantMemo: txnbuild.MemoID(1234567890),
+		},
+		{
+			name:            "🔴id memo not a number",
+			memo:            Memo{Value: "abc", Type: MemoTypeID},
+			wantErrContains: `id memo "abc" is not a valid uint64`,
+		},
+		{
+			name:            "🔴id memo negative",
+			memo:            Memo{Value: "-1", Type: MemoTypeID},
+			wantErrContains: `id memo "-1" is not a valid uint64`,
+		},
+		{
+			name:     "🟢hash memo (hex)",
+			memo:     Memo{Value: hashHex, Type: MemoTypeHash},
+			wantMemo: expectedHash,
+		},
+		{
+			name:     "🟢hash memo (base64)",
+			memo:     Memo{Value: hashBase64, Type: MemoTypeHash},
+			wantMemo: expectedHash,
+		},
+		{
+			name:            "🔴hash memo with wrong length",
+			memo:            Memo{Value: "abcd", Type: MemoTypeHash},
+			wantErrContains: "decoded value must have 32 bytes, got 2",
+		},
+		{
+			name:            "🔴hash memo with invalid encoding",
+			memo:            Memo{Value: "not-a-hash!", Type: MemoTypeHash},
+			wantErrContains: "value must be hex or base64 encoded",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			memo, err := tc.memo.ToTxnbuildMemo()
+			if tc.wantErrContains != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tc.wantErrContains)
+				assert.ErrorContains(t, tc.memo.Validate(), tc.wantErrContains)
+			} else {
+				require.NoError(t, err)
+				require.NoError(t, tc.memo.Validate())
+				assert.Equal(t, tc.wantMemo, memo)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ type AuthManager interface {
 	UpdatePassword(ctx context.Context, token, currentPassword, newPassword string) error
 	GetUser(ctx context.Context, tokenString string) (*User, error)
 	GetUsersByID(ctx context.Context, userIDs []string) ([]*User, error)
+	GetActiveUsersWithRole(ctx context.Context, role string) ([]*User, error)
 	GetUserID(ctx context.Context, tokenString string) (string, error)
 	GetTenantID(ctx context.Context, tokenString string) (string, error)
 	GetAllUsers(ctx context.Context, tokenString string) ([]User, error)
@@ -325,6 +326,15 @@ func (am *defaultAuthManager) GetUsersByID(ctx context.Context, userIDs []string
 	return users, nil
 }
 
+func (am *defaultAuthManager) GetActiveUsersWithRole(ctx context.Context, role string) ([]*User, error) {
+	users, err := am.authenticator.GetActiveUsersWithRole(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("getting active users with role %s: %w", role, err)
+	}
+
+	return users, nil
+}
+
 func (am *defaultAuthManager) GetTenantID(ctx context.Context, tokenString string) (string, error) {
 	isValid, err := am.ValidateToken(ctx, tokenString)
 	if err != nil {
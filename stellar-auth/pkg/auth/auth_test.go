@@ -1415,6 +1415,45 @@ func Test_AuthManager_GetUsersByID(t *testing.T) {
 	authenticatorMock.AssertExpectations(t)
 }
 
+func Test_AuthManager_GetActiveUsersWithRole(t *testing.T) {
+	authenticatorMock := &AuthenticatorMock{}
+	authManager := NewAuthManager(WithCustomAuthenticatorOption(authenticatorMock))
+
+	ctx := context.Background()
+
+	t.Run("returns error when authenticator fails", func(t *testing.T) {
+		authenticatorMock.
+			On("GetActiveUsersWithRole", ctx, "role1").
+			Return(nil, errUnexpectedError).
+			Once()
+
+		_, err := authManager.GetActiveUsersWithRole(ctx, "role1")
+		require.EqualError(t, err, "getting active users with role role1: unexpected error")
+	})
+
+	t.Run("gets the active users with the role successfully", func(t *testing.T) {
+		expectedUsers := []*User{
+			{
+				ID:       "user1-ID",
+				Email:    "user1@email.com",
+				Roles:    []string{"role1"},
+				IsActive: true,
+			},
+		}
+
+		authenticatorMock.
+			On("GetActiveUsersWithRole", ctx, "role1").
+			Return(expectedUsers, nil).
+			Once()
+
+		users, err := authManager.GetActiveUsersWithRole(ctx, "role1")
+		require.NoError(t, err)
+		assert.Equal(t, expectedUsers, users)
+	})
+
+	authenticatorMock.AssertExpectations(t)
+}
+
 func Test_AuthManager_GetUserID(t *testing.T) {
 	jwtManagerMock := &JWTManagerMock{}
 	authenticatorMock := &AuthenticatorMock{}
@@ -42,6 +42,7 @@ type Authenticator interface {
 	GetAllUsers(ctx context.Context) ([]User, error)
 	GetUser(ctx context.Context, userID string) (*User, error)
 	GetUsers(ctx context.Context, userIDs []string) ([]*User, error)
+	GetActiveUsersWithRole(ctx context.Context, role string) ([]*User, error)
 }
 
 type defaultAuthenticator struct {
@@ -530,6 +531,53 @@ func (a *defaultAuthenticator) GetUsers(ctx context.Context, userIDs []string) (
 	return users, nil
 }
 
+// GetActiveUsersWithRole retrieves the active users that have the role.
+func (a *defaultAuthenticator) GetActiveUsersWithRole(ctx context.Context, role string) ([]*User, error) {
+	const query = `
+		SELECT
+			id,
+			first_name,
+			last_name,
+			email,
+			roles,
+			is_owner,
+			is_active
+		FROM
+			auth_users
+		WHERE
+			is_active = true AND $1 = ANY(roles)
+	`
+
+	dbUsers := []struct {
+		ID        string         `db:"id"`
+		FirstName string         `db:"first_name"`
+		LastName  string         `db:"last_name"`
+		Email     string         `db:"email"`
+		Roles     pq.StringArray `db:"roles"`
+		IsOwner   bool           `db:"is_owner"`
+		IsActive  bool           `db:"is_active"`
+	}{}
+	err := a.dbConnectionPool.SelectContext(ctx, &dbUsers, query, role)
+	if err != nil {
+		return nil, fmt.Errorf("error querying active users with role %s: %w", role, err)
+	}
+
+	users := make([]*User, len(dbUsers))
+	for i, u := range dbUsers {
+		users[i] = &User{
+			ID:        u.ID,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Email:     u.Email,
+			IsOwner:   u.IsOwner,
+			IsActive:  u.IsActive,
+			Roles:     u.Roles,
+		}
+	}
+
+	return users, nil
+}
+
 type defaultAuthenticatorOption func(a *defaultAuthenticator)
 
 func newDefaultAuthenticator(options ...defaultAuthenticatorOption) *defaultAuthenticator {
@@ -782,6 +782,45 @@ func Test_DefaultAuthenticator_GetUsers(t *testing.T) {
 	passwordEncrypterMock.AssertExpectations(t)
 }
 
+func Test_DefaultAuthenticator_GetActiveUsersWithRole(t *testing.T) {
+	dbt := dbtest.Open(t)
+	defer dbt.Close()
+	dbConnectionPool, err := db.OpenDBConnectionPool(dbt.DSN)
+	require.NoError(t, err)
+	defer dbConnectionPool.Close()
+
+	passwordEncrypterMock := &PasswordEncrypterMock{}
+	authenticator := newDefaultAuthenticator(withAuthenticatorDatabaseConnectionPool(dbConnectionPool))
+
+	ctx := context.Background()
+
+	t.Run("returns an empty slice if no users have the role", func(t *testing.T) {
+		users, err := authenticator.GetActiveUsersWithRole(ctx, "role1")
+		require.NoError(t, err)
+		assert.Empty(t, users)
+	})
+
+	t.Run("gets the active users with the role successfully", func(t *testing.T) {
+		passwordEncrypterMock.
+			On("Encrypt", ctx, mock.AnythingOfType("string")).
+			Return("encryptedPassword", nil)
+
+		randUser1 := CreateRandomAuthUserFixture(t, ctx, dbConnectionPool, passwordEncrypterMock, false, "role1", "role2")
+		randUser2 := CreateRandomAuthUserFixture(t, ctx, dbConnectionPool, passwordEncrypterMock, true, "role1")
+		inactiveUser := CreateRandomAuthUserFixture(t, ctx, dbConnectionPool, passwordEncrypterMock, false, "role1")
+		CreateRandomAuthUserFixture(t, ctx, dbConnectionPool, passwordEncrypterMock, false, "role3")
+
+		err := authenticator.DeactivateUser(ctx, inactiveUser.ID)
+		require.NoError(t, err)
+
+		users, err := authenticator.GetActiveUsersWithRole(ctx, "role1")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []*User{randUser1.ToUser(), randUser2.ToUser()}, users)
+	})
+
+	passwordEncrypterMock.AssertExpectations(t)
+}
+
 func Test_DefaultAuthenticator_UpdateUser(t *testing.T) {
 	dbt := dbtest.Open(t)
 	defer dbt.Close()
@@ -136,6 +136,14 @@ func (am *AuthenticatorMock) GetUsers(ctx context.Context, userIDs []string) ([]
 	return args.Get(0).([]*User), args.Error(1)
 }
 
+func (am *AuthenticatorMock) GetActiveUsersWithRole(ctx context.Context, role string) ([]*User, error) {
+	args := am.Called(ctx, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*User), args.Error(1)
+}
+
 var _ Authenticator = (*AuthenticatorMock)(nil)
 
 type RoleManagerMock struct {
@@ -243,6 +251,14 @@ func (am *AuthManagerMock) GetUsersByID(ctx context.Context, tokenString []strin
 	return args.Get(0).([]*User), args.Error(1)
 }
 
+func (am *AuthManagerMock) GetActiveUsersWithRole(ctx context.Context, role string) ([]*User, error) {
+	args := am.Called(ctx, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*User), args.Error(1)
+}
+
 func (am *AuthManagerMock) GetUserID(ctx context.Context, userID string) (string, error) {
 	args := am.Called(ctx, userID)
 	return args.Get(0).(string), args.Error(1)
@@ -179,7 +179,7 @@ func (m *Manager) fundTenantDistributionStellarAccountIfNeeded(ctx context.Conte
 		}
 		return nil
 
-	case schema.DistributionAccountStellarEnv:
+	case schema.DistributionAccountStellarEnv, schema.DistributionAccountStellarRemote:
 		log.Ctx(ctx).Warnf("Tenant distribution account is configured to use accountType=%s, no need to initiate funding.", tenant.DistributionAccountType)
 		return nil
 
@@ -201,7 +201,7 @@ func (m *Manager) provisionDistributionAccount(ctx context.Context, t *tenant.Te
 		t.DistributionAccountStatus = schema.AccountStatusPendingUserActivation
 		return nil
 
-	case schema.DistributionAccountStellarEnv, schema.DistributionAccountStellarDBVault:
+	case schema.DistributionAccountStellarEnv, schema.DistributionAccountStellarDBVault, schema.DistributionAccountStellarRemote:
 		distributionAccounts, err := m.SubmitterEngine.SignerRouter.BatchInsert(ctx, accountType, 1)
 		if err != nil {
 			if errors.Is(err, signing.ErrUnsupportedCommand) {